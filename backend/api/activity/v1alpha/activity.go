@@ -259,7 +259,8 @@ func (srv *Server) ListEvents(ctx context.Context, req *activity.ListEventsReque
 	if orderByObserved {
 		mainCursorColumn = storage.StructuralBlobsID.String()
 	}
-	pageTokenStr := mainCursorColumn + " <= :idx AND " + storage.StructuralBlobsType.String() + " != 'Change' AND " + storage.BlobsSize.String() + ">0 ORDER BY " + mainCursorColumn + " desc limit :page_size"
+	// Refs of draft branches are not published, so they are not part of the activity.
+	pageTokenStr := mainCursorColumn + " <= :idx AND " + storage.StructuralBlobsType.String() + " != 'Change' AND (" + storage.StructuralBlobsType.String() + " != 'Ref' OR structural_blobs.extra_attrs->>'branch' IS NULL) AND " + storage.BlobsSize.String() + ">0 ORDER BY " + mainCursorColumn + " desc limit :page_size"
 	if req.PageSize <= 0 {
 		req.PageSize = 30
 	}
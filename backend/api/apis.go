@@ -42,6 +42,7 @@ type Server struct {
 // New creates a new API server.
 func New(
 	cfg config.Base,
+	gcCfg config.GC,
 	repo *storage.Store,
 	idx *blob.Index,
 	node *hmnet.Node,
@@ -63,7 +64,7 @@ func New(
 
 	return Server{
 		Activity:    activity,
-		Daemon:      daemon.NewServer(repo, node, idx, taskMgr, logging.New("seed/daemon-api", LogLevel)),
+		Daemon:      daemon.NewServer(repo, node, idx, taskMgr, gcCfg, logging.New("seed/daemon-api", LogLevel)),
 		Networking:  networking.NewServer(node, db, logging.New("seed/networking", LogLevel)),
 		Entities:    entities.NewServer(cfg, db, sync, embedder, logging.New("seed/entities", LogLevel)),
 		DocumentsV3: docs,
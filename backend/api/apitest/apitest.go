@@ -112,6 +112,21 @@ func (b *ChangeBuilder) ReplaceBlock(block, btype, text string, annotations ...*
 	return b
 }
 
+// SpliceText adds a SpliceText change to the request.
+func (b *ChangeBuilder) SpliceText(block string, offset, deleteCount int, insert string) *ChangeBuilder {
+	b.req.Changes = append(b.req.Changes, &documents.DocumentChange{
+		Op: &documents.DocumentChange_SpliceText_{
+			SpliceText: &documents.DocumentChange_SpliceText{
+				BlockId:     block,
+				Offset:      int32(offset),      //nolint:gosec // Test helper.
+				DeleteCount: int32(deleteCount), //nolint:gosec // Test helper.
+				Insert:      insert,
+			},
+		},
+	})
+	return b
+}
+
 // DeleteBlock adds a DeleteBlock change to the request.
 func (b *ChangeBuilder) DeleteBlock(block string) *ChangeBuilder {
 	b.req.Changes = append(b.req.Changes, &documents.DocumentChange{
@@ -15,6 +15,7 @@ import (
 	"os"
 	"path/filepath"
 	"seed/backend/blob"
+	"seed/backend/config"
 	"seed/backend/core"
 	"seed/backend/daemon/reindexing"
 	taskmanager "seed/backend/daemon/taskmanager"
@@ -55,9 +56,20 @@ type Blockstore interface {
 }
 
 type blobIndex interface {
+	Get(context.Context, cid.Cid) (blocks.Block, error)
 	PutMany(context.Context, []blocks.Block) error
+	StashedBlobs(context.Context, []cid.Cid) ([]blob.StashedBlob, error)
+	VerifyStore(context.Context, blob.VerifyOptions) (blob.StoreReport, error)
 	Reindex(context.Context) error
+	ReindexScope(context.Context, blob.IRI, func(indexed, total int64)) (blob.ScopedReindexResult, error)
 	ReindexInfo() blob.ReindexInfo
+	CollectGarbage(context.Context, blob.GCOptions) (blob.GCReport, error)
+	PinResource(ctx context.Context, iri blob.IRI, recursive bool) error
+	UnpinResource(context.Context, blob.IRI) error
+	ListPinnedResources(context.Context) ([]blob.GCRoot, error)
+	GetSpaceUsage(context.Context, core.Principal) (blob.SpaceUsage, error)
+	ListSpaceUsage(context.Context) ([]blob.SpaceUsage, error)
+	SpaceQuotas() blob.SpaceQuotas
 }
 
 const (
@@ -126,6 +138,8 @@ type Server struct {
 	vaultConnectionErr error
 
 	taskMgr *taskmanager.TaskManager
+
+	gcCfg config.GC
 }
 
 type vaultConnectionPoll struct {
@@ -133,7 +147,7 @@ type vaultConnectionPoll struct {
 }
 
 // NewServer creates a new Server.
-func NewServer(store *storage.Store, n Node, idx *blob.Index, taskMgr *taskmanager.TaskManager, log *zap.Logger) *Server {
+func NewServer(store *storage.Store, n Node, idx *blob.Index, taskMgr *taskmanager.TaskManager, gcCfg config.GC, log *zap.Logger) *Server {
 	return &Server{
 		store:     store,
 		startTime: time.Now(),
@@ -142,6 +156,7 @@ func NewServer(store *storage.Store, n Node, idx *blob.Index, taskMgr *taskmanag
 		blocks:  idx,
 		domains: idx.Domains,
 		taskMgr: taskMgr,
+		gcCfg:   gcCfg,
 		log:     log,
 	}
 }
@@ -964,6 +979,14 @@ func (srv *Server) ForceSync(context.Context, *daemon.ForceSyncRequest) (*emptyp
 
 // ForceReindex implements the corresponding gRPC method.
 func (srv *Server) ForceReindex(ctx context.Context, in *daemon.ForceReindexRequest) (*daemon.ForceReindexResponse, error) {
+	if in.Account != "" {
+		return srv.reindexScope(ctx, in)
+	}
+
+	if in.Path != "" {
+		return nil, status.Error(codes.InvalidArgument, "path requires account")
+	}
+
 	if err := reindexing.RunBlobReindexTask(ctx, srv.blocks, srv.taskMgr, srv.log, srv.blocks.Reindex); err != nil {
 		return nil, err
 	}
@@ -971,6 +994,39 @@ func (srv *Server) ForceReindex(ctx context.Context, in *daemon.ForceReindexRequ
 	return &daemon.ForceReindexResponse{}, nil
 }
 
+func (srv *Server) reindexScope(ctx context.Context, in *daemon.ForceReindexRequest) (*daemon.ForceReindexResponse, error) {
+	space, err := core.DecodePrincipal(in.Account)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode account: %v", err)
+	}
+
+	iri, err := blob.NewIRI(space, in.Path)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid path: %v", err)
+	}
+
+	if srv.blocks.ReindexInfo().State == blob.ReindexStateInProgress {
+		return nil, status.Error(codes.Unavailable, "server is reindexing blobs; retry later")
+	}
+
+	var res blob.ScopedReindexResult
+	err = reindexing.RunScopedReindexTask(ctx, string(iri), srv.taskMgr, srv.log, func(ctx context.Context, progress func(indexed, total int64)) (err error) {
+		res, err = srv.blocks.ReindexScope(ctx, iri, progress)
+		return err
+	})
+	switch {
+	case errors.Is(err, taskmanager.ErrTaskExists), errors.Is(err, blob.ErrReindexInProgress):
+		return nil, status.Error(codes.Unavailable, "another reindex is in progress; retry later")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to reindex %s: %v", iri, err)
+	}
+
+	return &daemon.ForceReindexResponse{
+		ResourcesReindexed: int64(res.Resources),
+		BlobsIndexed:       res.BlobsIndexed,
+	}, nil
+}
+
 // StoreBlobs implements the corresponding gRPC method.
 func (srv *Server) StoreBlobs(ctx context.Context, in *daemon.StoreBlobsRequest) (*daemon.StoreBlobsResponse, error) {
 	if srv.blocks.ReindexInfo().State == blob.ReindexStateInProgress {
@@ -0,0 +1,284 @@
+package daemon
+
+import (
+	context "context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"seed/backend/blob"
+	"seed/backend/core"
+	daemon "seed/backend/genproto/daemon/v1alpha"
+	"seed/backend/hmnet/syncing"
+	"seed/backend/ipfs"
+	"seed/backend/util/colx"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"strings"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+const (
+	archiveFileSuffix = ".car"
+
+	// Number of blobs read from the archive before handing them to the index.
+	archiveImportBatchSize = 1000
+)
+
+// ExportSpace implements the corresponding gRPC method.
+// The archive contains the same blobs we'd push to a site of the space:
+// changes, refs, capabilities, comments, profiles of the authors, and media files.
+// Private blobs are included too, because only the owner of the daemon can call this.
+func (srv *Server) ExportSpace(ctx context.Context, in *daemon.ExportSpaceRequest) (*daemon.ExportSpaceResponse, error) {
+	if in.Account == "" {
+		return nil, status.Error(codes.InvalidArgument, "account is required")
+	}
+
+	space, err := core.DecodePrincipal(in.Account)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode account: %v", err)
+	}
+
+	if err := validateArchiveFilePath(in.FilePath, false); err != nil {
+		return nil, err
+	}
+
+	iri, err := blob.NewIRI(space, in.Path)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid path: %v", err)
+	}
+
+	var (
+		cids  []syncing.CIDWithTS
+		roots []cid.Cid
+	)
+	if err := srv.store.DB().WithSaveTempOnly(ctx, func(conn *sqlite.Conn) (err error) {
+		dkeys := map[syncing.DiscoveryKey]struct{}{
+			{IRI: iri, Recursive: true}: {},
+		}
+		cids, err = syncing.GetRelatedMaterial(conn, dkeys, true, []core.Principal{space})
+		if err != nil {
+			return err
+		}
+
+		roots, err = loadArchiveRoots(conn, iri, cids)
+		return err
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to collect blobs: %v", err)
+	}
+
+	if len(roots) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no documents to export in %s", iri)
+	}
+
+	if err := srv.writeArchive(ctx, in.FilePath, roots, cids); err != nil {
+		_ = os.Remove(in.FilePath)
+		return nil, status.Errorf(codes.Internal, "failed to write archive %s: %v", in.FilePath, err)
+	}
+
+	resp := &daemon.ExportSpaceResponse{
+		BlobCount: int64(len(cids)),
+		Roots:     make([]string, len(roots)),
+	}
+	for i, c := range roots {
+		resp.Roots[i] = c.String()
+	}
+
+	return resp, nil
+}
+
+// loadArchiveRoots returns the Refs of the exported documents,
+// from which all the other blobs of the documents can be reached.
+func loadArchiveRoots(conn *sqlite.Conn, iri blob.IRI, cids []syncing.CIDWithTS) ([]cid.Cid, error) {
+	exported := make(colx.HashSet[cid.Cid], len(cids))
+	for _, c := range cids {
+		exported.Put(c.CID)
+	}
+
+	var roots []cid.Cid
+	if err := sqlitex.Exec(conn, qListArchiveRoots(), func(row *sqlite.Stmt) error {
+		c := cid.NewCidV1(uint64(row.ColumnInt64(0)), row.ColumnBytes(1))
+		if exported.Has(c) {
+			roots = append(roots, c)
+		}
+		return nil
+	}, string(iri)); err != nil {
+		return nil, err
+	}
+
+	return roots, nil
+}
+
+var qListArchiveRoots = dqb.Str(`
+	SELECT b.codec, b.multihash
+	FROM resources r
+	JOIN structural_blobs sb ON sb.resource = r.id AND sb.type = 'Ref'
+	JOIN blobs b ON b.id = sb.id
+	WHERE r.iri = :iri OR (r.iri >= :iri || '/' AND r.iri < :iri || '0')
+	ORDER BY sb.ts, b.multihash
+`)
+
+func (srv *Server) writeArchive(ctx context.Context, filePath string, roots []cid.Cid, cids []syncing.CIDWithTS) (err error) {
+	f, err := os.Create(filePath) // #nosec G304 -- the path is provided by the owner of the daemon.
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+
+	w, err := ipfs.NewCARWriter(f, roots)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range cids {
+		blk, err := srv.blocks.Get(ctx, c.CID)
+		if err != nil {
+			return fmt.Errorf("failed to load blob %s: %w", c.CID, err)
+		}
+
+		if err := w.Put(blk); err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
+
+// ImportArchive implements the corresponding gRPC method.
+func (srv *Server) ImportArchive(ctx context.Context, in *daemon.ImportArchiveRequest) (*daemon.ImportArchiveResponse, error) {
+	if err := validateArchiveFilePath(in.FilePath, true); err != nil {
+		return nil, err
+	}
+
+	if srv.blocks.ReindexInfo().State == blob.ReindexStateInProgress {
+		return nil, status.Error(codes.Unavailable, "server is reindexing blobs; retry later")
+	}
+
+	f, err := os.Open(in.FilePath) // #nosec G304 -- the path is provided by the owner of the daemon.
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to open archive %s: %v", in.FilePath, err)
+	}
+	defer f.Close()
+
+	r, err := ipfs.NewCARReader(f)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid archive %s: %v", in.FilePath, err)
+	}
+
+	// Blobs are indexed in batches as they are read. The ones that arrive before their dependencies
+	// are stashed, and get indexed once the dependencies arrive in the later batches.
+	var (
+		imported []cid.Cid
+		batch    = make([]blocks.Block, 0, archiveImportBatchSize)
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := srv.blocks.PutMany(ctx, batch); err != nil {
+			return status.Errorf(codes.Internal, "failed to store blobs: %v", err)
+		}
+
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		blk, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid archive %s after %d blobs: %v", in.FilePath, len(imported), err)
+		}
+
+		imported = append(imported, blk.Cid())
+		batch = append(batch, blk)
+		if len(batch) == archiveImportBatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	stashed, err := srv.blocks.StashedBlobs(ctx, imported)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check stashed blobs: %v", err)
+	}
+
+	resp := &daemon.ImportArchiveResponse{
+		BlobCount:    int64(len(imported)),
+		StashedBlobs: make([]*daemon.StashedBlob, len(stashed)),
+	}
+
+	for i, sb := range stashed {
+		pb := &daemon.StashedBlob{
+			Cid:           sb.CID.String(),
+			Reason:        sb.Reason,
+			MissingBlobs:  make([]string, len(sb.MissingBlobs)),
+			DeniedSigners: make([]string, len(sb.DeniedSigners)),
+			Details:       sb.Details,
+		}
+		for j, c := range sb.MissingBlobs {
+			pb.MissingBlobs[j] = c.String()
+		}
+		for j, p := range sb.DeniedSigners {
+			pb.DeniedSigners[j] = p.String()
+		}
+		resp.StashedBlobs[i] = pb
+	}
+
+	return resp, nil
+}
+
+// validateArchiveFilePath checks the path of an archive to import (mustExist), or to export.
+func validateArchiveFilePath(filePath string, mustExist bool) error {
+	if filePath == "" {
+		return status.Error(codes.InvalidArgument, "file path is required")
+	}
+	if !filepath.IsAbs(filePath) {
+		return status.Error(codes.InvalidArgument, "file path must be absolute")
+	}
+	if !strings.HasSuffix(strings.ToLower(filePath), archiveFileSuffix) {
+		return status.Errorf(codes.InvalidArgument, "file path must end with %s", archiveFileSuffix)
+	}
+
+	info, err := os.Stat(filePath)
+	switch {
+	case err == nil:
+		if !info.Mode().IsRegular() {
+			return status.Errorf(codes.InvalidArgument, "archive must be a regular file: %s", filePath)
+		}
+	case os.IsNotExist(err) && mustExist:
+		return status.Errorf(codes.NotFound, "archive does not exist: %s", filePath)
+	case os.IsNotExist(err):
+		parent := filepath.Dir(filePath)
+		parentInfo, parentErr := os.Stat(parent)
+		if parentErr != nil {
+			if os.IsNotExist(parentErr) {
+				return status.Errorf(codes.InvalidArgument, "parent directory does not exist: %s", parent)
+			}
+			return status.Errorf(codes.Internal, "failed to stat parent directory %s: %v", parent, parentErr)
+		}
+		if !parentInfo.IsDir() {
+			return status.Errorf(codes.InvalidArgument, "parent path is not a directory: %s", parent)
+		}
+	default:
+		return status.Errorf(codes.Internal, "failed to stat archive %s: %v", filePath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"seed/backend/blob"
+	"seed/backend/core"
+	"seed/backend/core/coretest"
+	daemon "seed/backend/genproto/daemon/v1alpha"
+	"seed/backend/ipfs"
+	"seed/backend/util/cclock"
+	"seed/backend/util/must"
+	"seed/backend/util/sqlite/sqlitex"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestExportImportArchive(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	carol := coretest.NewTester("carol")
+	src := newTestServer(t, "alice")
+	dst := newTestServer(t, "bob")
+	ctx := t.Context()
+	clock := cclock.New()
+
+	newDocumentWithVisibility := func(kp *core.KeyPair, space core.Principal, path string, visibility blob.Visibility) []blocks.Block {
+		t.Helper()
+		ts := clock.MustNow()
+		change, err := blob.NewChange(kp, cid.Undef, nil, 0, blob.ChangeBody{}, ts)
+		require.NoError(t, err)
+		ref, err := blob.NewRef(kp, ts.UnixMilli(), change.CID, space, path, []cid.Cid{change.CID}, ts, visibility)
+		require.NoError(t, err)
+
+		return []blocks.Block{
+			must.Do2(blocks.NewBlockWithCid(change.Data, change.CID)),
+			must.Do2(blocks.NewBlockWithCid(ref.Data, ref.CID)),
+		}
+	}
+
+	newDocument := func(kp *core.KeyPair, space core.Principal, path string) []blocks.Block {
+		t.Helper()
+		return newDocumentWithVisibility(kp, space, path, blob.VisibilityPublic)
+	}
+
+	home := newDocument(alice.Account, alice.Account.Principal(), "")
+	doc := newDocument(alice.Account, alice.Account.Principal(), "/doc")
+	require.NoError(t, src.blocks.PutMany(ctx, append(home, doc...)))
+
+	// The archive is a backup of the space, so private documents must be included too.
+	private := newDocumentWithVisibility(alice.Account, alice.Account.Principal(), "/private", blob.VisibilityPrivate)
+	require.NoError(t, src.blocks.PutMany(ctx, private))
+
+	file := filepath.Join(t.TempDir(), "alice.car")
+	exported, err := src.ExportSpace(ctx, &daemon.ExportSpaceRequest{
+		Account:  alice.Account.PublicKey.String(),
+		FilePath: file,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(6), exported.BlobCount)
+	require.Equal(t, []string{home[1].Cid().String(), doc[1].Cid().String(), private[1].Cid().String()}, exported.Roots, "roots must be the refs of the documents")
+
+	subtree, err := src.ExportSpace(ctx, &daemon.ExportSpaceRequest{
+		Account:  alice.Account.PublicKey.String(),
+		Path:     "/doc",
+		FilePath: filepath.Join(t.TempDir(), "doc.car"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{doc[1].Cid().String()}, subtree.Roots)
+
+	child := newDocument(alice.Account, alice.Account.Principal(), "/doc/child")
+	require.NoError(t, src.blocks.PutMany(ctx, child))
+	_, err = src.ExportSpace(ctx, &daemon.ExportSpaceRequest{
+		Account:  alice.Account.PublicKey.String(),
+		Path:     "/d?c",
+		FilePath: filepath.Join(t.TempDir(), "wildcard.car"),
+	})
+	require.Equal(t, codes.NotFound, status.Code(err), "wildcards in the path must be matched literally")
+
+	_, err = src.ExportSpace(ctx, &daemon.ExportSpaceRequest{
+		Account:  carol.Account.PublicKey.String(),
+		FilePath: filepath.Join(t.TempDir(), "carol.car"),
+	})
+	require.Equal(t, codes.NotFound, status.Code(err), "exporting unknown spaces must fail")
+
+	imported, err := dst.ImportArchive(ctx, &daemon.ImportArchiveRequest{FilePath: file})
+	require.NoError(t, err)
+	require.Equal(t, int64(6), imported.BlobCount)
+	require.Empty(t, imported.StashedBlobs)
+
+	conn, release, err := dst.store.DB().ReadConn(ctx)
+	require.NoError(t, err)
+	refs, err := sqlitex.QueryOne[int64](conn, "SELECT count() FROM structural_blobs WHERE type = 'Ref'")
+	release()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), refs, "imported blobs must be indexed")
+
+	// Carol has no permissions in alice's space, so her Ref must be stashed.
+	forged := newDocument(carol.Account, alice.Account.Principal(), "/forged")
+	forgedFile := filepath.Join(t.TempDir(), "forged.car")
+	f, err := os.Create(forgedFile)
+	require.NoError(t, err)
+	w, err := ipfs.NewCARWriter(f, []cid.Cid{forged[1].Cid()})
+	require.NoError(t, err)
+	for _, blk := range forged {
+		require.NoError(t, w.Put(blk))
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	imported, err = dst.ImportArchive(ctx, &daemon.ImportArchiveRequest{FilePath: forgedFile})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), imported.BlobCount)
+	require.Len(t, imported.StashedBlobs, 1)
+	require.Equal(t, forged[1].Cid().String(), imported.StashedBlobs[0].Cid)
+	require.Equal(t, "PermissionDenied", imported.StashedBlobs[0].Reason)
+	require.Equal(t, []string{carol.Account.PublicKey.String()}, imported.StashedBlobs[0].DeniedSigners)
+
+	_, err = dst.ImportArchive(ctx, &daemon.ImportArchiveRequest{FilePath: filepath.Join(t.TempDir(), "missing.car")})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = dst.ImportArchive(ctx, &daemon.ImportArchiveRequest{FilePath: "relative.car"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,93 @@
+package daemon
+
+import (
+	"errors"
+	"seed/backend/blob"
+	taskmanager "seed/backend/daemon/taskmanager"
+	daemon "seed/backend/genproto/daemon/v1alpha"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+const (
+	verifyStoreTaskID          = "verify_store"
+	verifyStoreTaskDescription = "Verifying blob store"
+)
+
+// VerifyStore implements the corresponding gRPC method.
+func (srv *Server) VerifyStore(in *daemon.VerifyStoreRequest, stream grpc.ServerStreamingServer[daemon.VerifyStoreResponse]) error {
+	ctx := stream.Context()
+
+	if srv.blocks.ReindexInfo().State == blob.ReindexStateInProgress {
+		return status.Error(codes.Unavailable, "server is reindexing blobs; retry later")
+	}
+
+	if _, err := srv.taskMgr.AddTask(verifyStoreTaskID, daemon.TaskName_VERIFYING_STORE, verifyStoreTaskDescription, 0); err != nil {
+		if errors.Is(err, taskmanager.ErrTaskExists) {
+			return status.Error(codes.AlreadyExists, "store verification is already running")
+		}
+		return status.Errorf(codes.Internal, "failed to register task: %v", err)
+	}
+	defer func() {
+		if _, err := srv.taskMgr.DeleteTask(verifyStoreTaskID); err != nil && !errors.Is(err, taskmanager.ErrTaskMissing) {
+			srv.log.Warn("Failed to delete store verification task", zap.Error(err))
+		}
+	}()
+
+	var total int64
+	report, err := srv.blocks.VerifyStore(ctx, blob.VerifyOptions{
+		Repair: in.Repair,
+		Progress: func(checked, blobsTotal int64) {
+			total = blobsTotal
+			if _, err := srv.taskMgr.UpdateProgress(verifyStoreTaskID, blobsTotal, checked); err != nil {
+				srv.log.Warn("Failed to update store verification task progress", zap.Error(err))
+			}
+
+			// Send errors mean the client is gone, and the context is canceled,
+			// which stops the verification.
+			_ = stream.Send(&daemon.VerifyStoreResponse{
+				BlobsTotal:   blobsTotal,
+				BlobsChecked: checked,
+			})
+		},
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return status.Errorf(codes.Internal, "failed to verify store: %v", err)
+	}
+
+	resp := &daemon.VerifyStoreResponse{
+		BlobsTotal:   total,
+		BlobsChecked: report.BlobsChecked,
+		Report: &daemon.StoreReport{
+			BlobsChecked:       report.BlobsChecked,
+			Problems:           make([]*daemon.StoreProblem, len(report.Problems)),
+			StashedBlobs:       report.StashedBlobs,
+			ReindexedResources: make([]string, len(report.ReindexedResources)),
+		},
+	}
+
+	for i, p := range report.Problems {
+		pb := &daemon.StoreProblem{
+			Kind:     string(p.Kind),
+			Cid:      p.CID.String(),
+			Resource: string(p.Resource),
+			Details:  p.Details,
+		}
+		if p.Target.Defined() {
+			pb.Target = p.Target.String()
+		}
+		resp.Report.Problems[i] = pb
+	}
+
+	for i, iri := range report.ReindexedResources {
+		resp.Report.ReindexedResources[i] = string(iri)
+	}
+
+	return stream.Send(resp)
+}
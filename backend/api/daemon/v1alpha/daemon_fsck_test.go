@@ -0,0 +1,64 @@
+package daemon
+
+import (
+	"context"
+	"seed/backend/blob"
+	"seed/backend/core/coretest"
+	daemon "seed/backend/genproto/daemon/v1alpha"
+	"seed/backend/util/cclock"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVerifyStore(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	srv := newTestServer(t, "alice")
+	ctx := t.Context()
+	clock := cclock.New()
+
+	ts := clock.MustNow()
+	change, err := blob.NewChange(alice.Account, cid.Undef, nil, 0, blob.ChangeBody{}, ts)
+	require.NoError(t, err)
+	ref, err := blob.NewRef(alice.Account, ts.UnixMilli(), change.CID, alice.Account.Principal(), "", []cid.Cid{change.CID}, ts, blob.VisibilityPublic)
+	require.NoError(t, err)
+	require.NoError(t, srv.blocks.PutMany(ctx, []blocks.Block{change, ref}))
+
+	stream := &verifyStoreStream{ctx: ctx}
+	require.NoError(t, srv.VerifyStore(&daemon.VerifyStoreRequest{}, stream))
+	require.NotEmpty(t, stream.msgs)
+
+	last := stream.msgs[len(stream.msgs)-1]
+	require.NotNil(t, last.Report, "last message must contain the report")
+	require.Equal(t, int64(2), last.Report.BlobsChecked)
+	require.Equal(t, last.BlobsTotal, last.BlobsChecked)
+	require.Empty(t, last.Report.Problems)
+	for _, msg := range stream.msgs[:len(stream.msgs)-1] {
+		require.Nil(t, msg.Report, "only the last message must contain the report")
+	}
+	require.Empty(t, srv.taskMgr.Tasks(), "task must be removed when verification is done")
+
+	// Only one verification can run at a time.
+	_, err = srv.taskMgr.AddTask(verifyStoreTaskID, daemon.TaskName_VERIFYING_STORE, verifyStoreTaskDescription, 0)
+	require.NoError(t, err)
+	err = srv.VerifyStore(&daemon.VerifyStoreRequest{}, &verifyStoreStream{ctx: ctx})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+type verifyStoreStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	msgs []*daemon.VerifyStoreResponse
+}
+
+func (s *verifyStoreStream) Context() context.Context { return s.ctx }
+
+func (s *verifyStoreStream) Send(msg *daemon.VerifyStoreResponse) error {
+	s.msgs = append(s.msgs, msg)
+	return nil
+}
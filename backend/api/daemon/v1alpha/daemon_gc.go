@@ -0,0 +1,173 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"seed/backend/blob"
+	"seed/backend/core"
+	taskmanager "seed/backend/daemon/taskmanager"
+	daemon "seed/backend/genproto/daemon/v1alpha"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	collectGarbageTaskID          = "collect_garbage"
+	collectGarbageTaskDescription = "Collecting garbage"
+)
+
+// errGCRunning is returned when a garbage collection is requested while another one is running.
+var errGCRunning = errors.New("garbage collection is already running")
+
+// CollectGarbage implements the corresponding gRPC method.
+func (srv *Server) CollectGarbage(ctx context.Context, in *daemon.CollectGarbageRequest) (*daemon.CollectGarbageResponse, error) {
+	var space core.Principal
+	if in.Account != "" {
+		var err error
+		space, err = core.DecodePrincipal(in.Account)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to decode account %s: %v", in.Account, err)
+		}
+	}
+
+	if srv.blocks.ReindexInfo().State == blob.ReindexStateInProgress {
+		return nil, status.Error(codes.Unavailable, "server is reindexing blobs; retry later")
+	}
+
+	report, err := srv.collectGarbage(ctx, space, in.DryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, errGCRunning):
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		case errors.Is(err, blob.ErrReindexInProgress):
+			return nil, status.Error(codes.Unavailable, "server is reindexing blobs; retry later")
+		case ctx.Err() != nil:
+			return nil, status.FromContextError(ctx.Err()).Err()
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to collect garbage: %v", err)
+		}
+	}
+
+	return &daemon.CollectGarbageResponse{
+		Roots:              int64(report.Roots),
+		BlobsTotal:         report.BlobsTotal,
+		BytesTotal:         report.BytesTotal,
+		StoredBytesTotal:   report.StoredBytesTotal,
+		BlobsPruned:        report.BlobsPruned,
+		BytesPruned:        report.BytesPruned,
+		StoredBytesPruned:  report.StoredBytesPruned,
+		TombstonesExpired:  report.TombstonesExpired,
+		ReindexedResources: int64(report.ReindexedResources),
+	}, nil
+}
+
+// RunGarbageCollector collects garbage periodically, until the context is canceled.
+// Failed runs are logged and retried at the next interval.
+func (srv *Server) RunGarbageCollector(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		// We'll try again later.
+		if srv.blocks.ReindexInfo().State == blob.ReindexStateInProgress {
+			continue
+		}
+
+		if _, err := srv.collectGarbage(ctx, nil, false); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			srv.log.Warn("ScheduledGarbageCollectionFailed", zap.Error(err))
+		}
+	}
+}
+
+func (srv *Server) collectGarbage(ctx context.Context, space core.Principal, dryRun bool) (blob.GCReport, error) {
+	keys, err := srv.store.KeyStore().ListKeys(ctx)
+	if err != nil {
+		return blob.GCReport{}, fmt.Errorf("failed to list local keys: %w", err)
+	}
+
+	accounts := make([]core.Principal, len(keys))
+	for i, k := range keys {
+		accounts[i] = k.PublicKey
+	}
+
+	if _, err := srv.taskMgr.AddTask(collectGarbageTaskID, daemon.TaskName_COLLECTING_GARBAGE, collectGarbageTaskDescription, 0); err != nil {
+		if errors.Is(err, taskmanager.ErrTaskExists) {
+			return blob.GCReport{}, errGCRunning
+		}
+		return blob.GCReport{}, fmt.Errorf("failed to register task: %w", err)
+	}
+	defer func() {
+		if _, err := srv.taskMgr.DeleteTask(collectGarbageTaskID); err != nil && !errors.Is(err, taskmanager.ErrTaskMissing) {
+			srv.log.Warn("Failed to delete garbage collection task", zap.Error(err))
+		}
+	}()
+
+	return srv.blocks.CollectGarbage(ctx, blob.GCOptions{
+		Accounts:    accounts,
+		Space:       space,
+		GracePeriod: srv.gcCfg.GracePeriod,
+		DryRun:      dryRun,
+	})
+}
+
+// PinResource implements the corresponding gRPC method.
+func (srv *Server) PinResource(ctx context.Context, in *daemon.PinResourceRequest) (*emptypb.Empty, error) {
+	if in.Iri == "" {
+		return nil, status.Error(codes.InvalidArgument, "iri is required")
+	}
+
+	iri := blob.IRI(in.Iri)
+	if _, _, err := iri.SpacePath(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid iri %s: %v", in.Iri, err)
+	}
+
+	if err := srv.blocks.PinResource(ctx, iri, in.Recursive); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to pin resource: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// UnpinResource implements the corresponding gRPC method.
+func (srv *Server) UnpinResource(ctx context.Context, in *daemon.UnpinResourceRequest) (*emptypb.Empty, error) {
+	if in.Iri == "" {
+		return nil, status.Error(codes.InvalidArgument, "iri is required")
+	}
+
+	if err := srv.blocks.UnpinResource(ctx, blob.IRI(in.Iri)); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unpin resource: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListPinnedResources implements the corresponding gRPC method.
+func (srv *Server) ListPinnedResources(ctx context.Context, _ *daemon.ListPinnedResourcesRequest) (*daemon.ListPinnedResourcesResponse, error) {
+	pins, err := srv.blocks.ListPinnedResources(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list pinned resources: %v", err)
+	}
+
+	resp := &daemon.ListPinnedResourcesResponse{
+		Pins: make([]*daemon.PinnedResource, len(pins)),
+	}
+	for i, p := range pins {
+		resp.Pins[i] = &daemon.PinnedResource{Iri: string(p.IRI), Recursive: p.Recursive}
+	}
+
+	return resp, nil
+}
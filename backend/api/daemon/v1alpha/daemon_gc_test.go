@@ -0,0 +1,95 @@
+package daemon
+
+import (
+	"seed/backend/blob"
+	"seed/backend/core/coretest"
+	daemon "seed/backend/genproto/daemon/v1alpha"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"testing"
+	"time"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCollectGarbage(t *testing.T) {
+	bob := coretest.NewTester("bob")
+	srv := newTestServer(t, "alice")
+	ctx := t.Context()
+	db := srv.store.DB()
+
+	// Syncing provides the related material in the daemon.
+	// Here only the structural blobs of the resources are related, and the links are followed by the collector.
+	srv.blocks.(*blob.Index).SetCollectRelatedBlobs(func(conn *sqlite.Conn, roots []blob.GCRoot) (ids []int64, err error) {
+		for _, root := range roots {
+			if err := sqlitex.Exec(conn, `SELECT sb.id FROM structural_blobs sb
+				JOIN resources r ON r.id = sb.resource
+				WHERE r.iri = ? OR (? AND r.iri GLOB ?)`, func(stmt *sqlite.Stmt) error {
+				ids = append(ids, stmt.ColumnInt64(0))
+				return nil
+			}, string(root.IRI), root.Recursive, string(root.IRI)+"/*"); err != nil {
+				return nil, err
+			}
+		}
+		return ids, nil
+	})
+
+	ts := time.Now().Round(time.Millisecond)
+	change, err := blob.NewChange(bob.Account, cid.Undef, nil, 0, blob.ChangeBody{}, ts)
+	require.NoError(t, err)
+	ref, err := blob.NewRef(bob.Account, ts.UnixMilli(), change.CID, bob.Account.Principal(), "/doc", []cid.Cid{change.CID}, ts, blob.VisibilityPublic)
+	require.NoError(t, err)
+	require.NoError(t, srv.blocks.PutMany(ctx, []blocks.Block{change, ref}))
+
+	resp, err := srv.CollectGarbage(ctx, &daemon.CollectGarbageRequest{DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.BlobsTotal)
+	require.Equal(t, int64(0), resp.BlobsPruned, "blobs within the grace period must be kept")
+
+	require.NoError(t, db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, "UPDATE blobs SET insert_time = insert_time - ?", nil, int64(srv.gcCfg.GracePeriod.Seconds())+60)
+	}))
+
+	resp, err = srv.CollectGarbage(ctx, &daemon.CollectGarbageRequest{DryRun: true, Account: bob.Account.PublicKey.String()})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.BlobsPruned)
+	require.Greater(t, resp.BytesPruned, int64(0))
+
+	_, err = srv.CollectGarbage(ctx, &daemon.CollectGarbageRequest{Account: "invalid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	bobSpace := "hm://" + bob.Account.PublicKey.String()
+	_, err = srv.PinResource(ctx, &daemon.PinResourceRequest{Iri: bobSpace, Recursive: true})
+	require.NoError(t, err)
+	pins, err := srv.ListPinnedResources(ctx, &daemon.ListPinnedResourcesRequest{})
+	require.NoError(t, err)
+	require.Len(t, pins.Pins, 1)
+	require.Equal(t, bobSpace, pins.Pins[0].Iri)
+	require.True(t, pins.Pins[0].Recursive)
+
+	resp, err = srv.CollectGarbage(ctx, &daemon.CollectGarbageRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), resp.BlobsPruned, "pinned resources must be kept")
+
+	_, err = srv.UnpinResource(ctx, &daemon.UnpinResourceRequest{Iri: bobSpace})
+	require.NoError(t, err)
+
+	resp, err = srv.CollectGarbage(ctx, &daemon.CollectGarbageRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.BlobsPruned)
+	require.Equal(t, int64(1), resp.ReindexedResources)
+	require.Empty(t, srv.taskMgr.Tasks(), "task must be removed when the collection is done")
+
+	_, err = srv.PinResource(ctx, &daemon.PinResourceRequest{Iri: "not-an-iri"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Only one collection can run at a time.
+	_, err = srv.taskMgr.AddTask(collectGarbageTaskID, daemon.TaskName_COLLECTING_GARBAGE, collectGarbageTaskDescription, 0)
+	require.NoError(t, err)
+	_, err = srv.CollectGarbage(ctx, &daemon.CollectGarbageRequest{})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+}
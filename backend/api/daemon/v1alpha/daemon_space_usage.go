@@ -0,0 +1,59 @@
+package daemon
+
+import (
+	"context"
+	"seed/backend/blob"
+	"seed/backend/core"
+	daemon "seed/backend/genproto/daemon/v1alpha"
+
+	"google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// GetSpaceUsage implements the corresponding gRPC method.
+func (srv *Server) GetSpaceUsage(ctx context.Context, in *daemon.GetSpaceUsageRequest) (*daemon.SpaceUsage, error) {
+	if in.Account == "" {
+		return nil, status.Error(codes.InvalidArgument, "account is required")
+	}
+
+	space, err := core.DecodePrincipal(in.Account)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode account %s: %v", in.Account, err)
+	}
+
+	usage, err := srv.blocks.GetSpaceUsage(ctx, space)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get space usage: %v", err)
+	}
+
+	return spaceUsageToProto(usage, srv.blocks.SpaceQuotas()), nil
+}
+
+// ListSpaceUsage implements the corresponding gRPC method.
+func (srv *Server) ListSpaceUsage(ctx context.Context, _ *daemon.ListSpaceUsageRequest) (*daemon.ListSpaceUsageResponse, error) {
+	list, err := srv.blocks.ListSpaceUsage(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list space usage: %v", err)
+	}
+
+	quotas := srv.blocks.SpaceQuotas()
+	resp := &daemon.ListSpaceUsageResponse{
+		Spaces: make([]*daemon.SpaceUsage, len(list)),
+	}
+	for i, u := range list {
+		resp.Spaces[i] = spaceUsageToProto(u, quotas)
+	}
+
+	return resp, nil
+}
+
+func spaceUsageToProto(u blob.SpaceUsage, quotas blob.SpaceQuotas) *daemon.SpaceUsage {
+	return &daemon.SpaceUsage{
+		Account:         u.Space.String(),
+		StructuralBlobs: u.StructuralBlobs,
+		StructuralBytes: u.StructuralBytes,
+		MediaBlobs:      u.MediaBlobs,
+		MediaBytes:      u.MediaBytes,
+		Quota:           quotas.Limit(u.Space),
+	}
+}
@@ -0,0 +1,49 @@
+package daemon
+
+import (
+	"seed/backend/blob"
+	"seed/backend/core/coretest"
+	daemon "seed/backend/genproto/daemon/v1alpha"
+	"testing"
+	"time"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSpaceUsage(t *testing.T) {
+	bob := coretest.NewTester("bob")
+	srv := newTestServer(t, "alice")
+	ctx := t.Context()
+	idx := srv.blocks.(*blob.Index)
+
+	ts := time.Now().Round(time.Millisecond)
+	change, err := blob.NewChange(bob.Account, cid.Undef, nil, 0, blob.ChangeBody{}, ts)
+	require.NoError(t, err)
+	ref, err := blob.NewRef(bob.Account, ts.UnixMilli(), change.CID, bob.Account.Principal(), "/doc", []cid.Cid{change.CID}, ts, blob.VisibilityPublic)
+	require.NoError(t, err)
+	require.NoError(t, srv.blocks.PutMany(ctx, []blocks.Block{change, ref}))
+	require.NoError(t, idx.WaitIndexedHook(ctx))
+
+	bobID := bob.Account.PublicKey.String()
+	idx.SetSpaceQuotas(blob.SpaceQuotas{Spaces: map[string]int64{bobID: 1 << 20}})
+
+	usage, err := srv.GetSpaceUsage(ctx, &daemon.GetSpaceUsageRequest{Account: bobID})
+	require.NoError(t, err)
+	require.Equal(t, bobID, usage.Account)
+	require.Equal(t, int64(2), usage.StructuralBlobs)
+	require.Equal(t, int64(len(change.RawData())+len(ref.RawData())), usage.StructuralBytes)
+	require.Equal(t, int64(0), usage.MediaBlobs)
+	require.Equal(t, int64(1<<20), usage.Quota)
+
+	list, err := srv.ListSpaceUsage(ctx, &daemon.ListSpaceUsageRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Spaces, 1)
+	require.Equal(t, bobID, list.Spaces[0].Account)
+
+	_, err = srv.GetSpaceUsage(ctx, &daemon.GetSpaceUsageRequest{Account: "invalid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
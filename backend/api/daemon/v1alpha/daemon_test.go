@@ -7,12 +7,14 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"seed/backend/blob"
+	"seed/backend/config"
 	"seed/backend/core"
 	"seed/backend/core/coretest"
 	taskmanager "seed/backend/daemon/taskmanager"
@@ -25,6 +27,7 @@ import (
 	"time"
 
 	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/stretchr/testify/require"
@@ -113,7 +116,7 @@ func TestGetStatusRemote(t *testing.T) {
 	idx, err := blob.OpenIndex(t.Context(), store.DB(), zap.NewNop())
 	require.NoError(t, err)
 
-	srv := NewServer(store, &mockedP2PNode{}, idx, tMgr, zap.NewNop())
+	srv := NewServer(store, &mockedP2PNode{}, idx, tMgr, config.GC{}.Default(), zap.NewNop())
 
 	resp, err := srv.GetVaultStatus(t.Context(), &daemon.GetVaultStatusRequest{})
 	require.NoError(t, err)
@@ -159,7 +162,7 @@ func TestDisconnect(t *testing.T) {
 	idx, err := blob.OpenIndex(t.Context(), store.DB(), zap.NewNop())
 	require.NoError(t, err)
 
-	srv := NewServer(store, &mockedP2PNode{}, idx, tMgr, zap.NewNop())
+	srv := NewServer(store, &mockedP2PNode{}, idx, tMgr, config.GC{}.Default(), zap.NewNop())
 
 	_, err = srv.DisconnectVault(t.Context(), &daemon.DisconnectVaultRequest{})
 	require.NoError(t, err)
@@ -279,6 +282,37 @@ func TestForceReindexTracksTaskWhileRemainingActive(t *testing.T) {
 	}, time.Second, 10*time.Millisecond)
 }
 
+func TestForceReindexScoped(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	srv := newTestServer(t, "alice")
+	ctx := t.Context()
+
+	ts := time.Now().Round(time.Millisecond)
+	change, err := blob.NewChange(alice.Account, cid.Undef, nil, 0, blob.ChangeBody{}, ts)
+	require.NoError(t, err)
+	ref, err := blob.NewRef(alice.Account, ts.UnixMilli(), change.CID, alice.Account.Principal(), "/doc", []cid.Cid{change.CID}, ts, blob.VisibilityPublic)
+	require.NoError(t, err)
+	require.NoError(t, srv.blocks.PutMany(ctx, []blocks.Block{change, ref}))
+
+	resp, err := srv.ForceReindex(ctx, &daemon.ForceReindexRequest{Account: alice.Account.PublicKey.String()})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.ResourcesReindexed, "the document and the space itself")
+	require.Equal(t, int64(2), resp.BlobsIndexed)
+	require.Empty(t, srv.taskMgr.Tasks(), "task must be removed when reindexing is done")
+
+	resp, err = srv.ForceReindex(ctx, &daemon.ForceReindexRequest{Account: alice.Account.PublicKey.String(), Path: "/other"})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), resp.ResourcesReindexed)
+
+	_, err = srv.ForceReindex(ctx, &daemon.ForceReindexRequest{Path: "/doc"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.taskMgr.AddTask("blob_reindex_scoped", daemon.TaskName_REINDEXING, "", 0)
+	require.NoError(t, err)
+	_, err = srv.ForceReindex(ctx, &daemon.ForceReindexRequest{Account: alice.Account.PublicKey.String()})
+	require.Equal(t, codes.Unavailable, status.Code(err), "only one scoped reindex can run at a time")
+}
+
 func TestStoreBlobsUnavailableDuringReindex(t *testing.T) {
 	srv := newTestServer(t, "alice")
 	fake := &fakeBlobIndex{}
@@ -668,7 +702,7 @@ func newTestServer(t *testing.T, name string, opts ...vault.RemoteOption) *Serve
 	idx, err := blob.OpenIndex(t.Context(), store.DB(), zap.NewNop())
 	require.NoError(t, err)
 
-	return NewServer(store, &mockedP2PNode{}, idx, tMgr, zap.NewNop())
+	return NewServer(store, &mockedP2PNode{}, idx, tMgr, config.GC{}.Default(), zap.NewNop())
 }
 
 func newConnectedTestVault(t *testing.T, dataDir string, localKey []byte, kp *core.KeyPair) (*vault.Vault, string) {
@@ -811,6 +845,18 @@ type fakeBlobIndex struct {
 	releaseReindex chan struct{}
 }
 
+func (f *fakeBlobIndex) Get(context.Context, cid.Cid) (blocks.Block, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeBlobIndex) StashedBlobs(context.Context, []cid.Cid) ([]blob.StashedBlob, error) {
+	return nil, nil
+}
+
+func (f *fakeBlobIndex) VerifyStore(context.Context, blob.VerifyOptions) (blob.StoreReport, error) {
+	return blob.StoreReport{}, nil
+}
+
 func (f *fakeBlobIndex) PutMany(context.Context, []blocks.Block) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -826,6 +872,38 @@ func (f *fakeBlobIndex) Reindex(ctx context.Context) error {
 	return nil
 }
 
+func (f *fakeBlobIndex) ReindexScope(context.Context, blob.IRI, func(indexed, total int64)) (blob.ScopedReindexResult, error) {
+	return blob.ScopedReindexResult{}, nil
+}
+
+func (f *fakeBlobIndex) CollectGarbage(context.Context, blob.GCOptions) (blob.GCReport, error) {
+	return blob.GCReport{}, nil
+}
+
+func (f *fakeBlobIndex) PinResource(context.Context, blob.IRI, bool) error {
+	return nil
+}
+
+func (f *fakeBlobIndex) UnpinResource(context.Context, blob.IRI) error {
+	return nil
+}
+
+func (f *fakeBlobIndex) ListPinnedResources(context.Context) ([]blob.GCRoot, error) {
+	return nil, nil
+}
+
+func (f *fakeBlobIndex) GetSpaceUsage(context.Context, core.Principal) (blob.SpaceUsage, error) {
+	return blob.SpaceUsage{}, nil
+}
+
+func (f *fakeBlobIndex) ListSpaceUsage(context.Context) ([]blob.SpaceUsage, error) {
+	return nil, nil
+}
+
+func (f *fakeBlobIndex) SpaceQuotas() blob.SpaceQuotas {
+	return blob.SpaceQuotas{}
+}
+
 func (f *fakeBlobIndex) ReindexInfo() blob.ReindexInfo {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"seed/backend/blob"
+	"seed/backend/config"
 	"seed/backend/core/coretest"
 	taskmanager "seed/backend/daemon/taskmanager"
 	daemon "seed/backend/genproto/daemon/v1alpha"
@@ -52,7 +53,7 @@ func TestStoreBlobsAckMustBeDurable(t *testing.T) {
 	idx, err := blob.OpenIndex(t.Context(), store.DB(), zap.NewNop())
 	require.NoError(t, err)
 
-	srv := NewServer(store, &mockedP2PNode{}, idx, tMgr, zap.NewNop())
+	srv := NewServer(store, &mockedP2PNode{}, idx, tMgr, config.GC{}.Default(), zap.NewNop())
 
 	// The prod trigger: an earlier request is canceled mid-transaction, so
 	// WithTx's plain ROLLBACK is interrupted. Before the fix this leaked the
@@ -3,12 +3,14 @@ package documents
 import (
 	"context"
 	"fmt"
+	"seed/backend/api/documents/v3alpha/docmodel"
 	"seed/backend/blob"
 	"seed/backend/core"
 	documents "seed/backend/genproto/documents/v3alpha"
 	"seed/backend/util/cclock"
 	"seed/backend/util/colx"
 	"seed/backend/util/errutil"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	cbornode "github.com/ipfs/go-ipld-cbor"
@@ -19,8 +21,10 @@ import (
 
 var (
 	roleFromProto = map[documents.Role]blob.Role{
-		documents.Role_WRITER: blob.RoleWriter,
-		documents.Role_AGENT:  blob.RoleAgent,
+		documents.Role_WRITER:    blob.RoleWriter,
+		documents.Role_AGENT:     blob.RoleAgent,
+		documents.Role_READER:    blob.RoleReader,
+		documents.Role_COMMENTER: blob.RoleCommenter,
 	}
 
 	roleToProto = colx.TransposeMap(roleFromProto)
@@ -77,7 +81,19 @@ func (srv *Server) CreateCapability(ctx context.Context, in *documents.CreateCap
 		return nil, status.Errorf(codes.InvalidArgument, "unsupported role '%s'", in.Role)
 	}
 
-	cpb, err := blob.NewCapability(kp, del, acc, in.Path, role, in.Label, cclock.New().MustNow())
+	var notBefore, expireTime time.Time
+	if in.NotBefore != nil {
+		notBefore = in.NotBefore.AsTime()
+	}
+	if in.ExpireTime != nil {
+		expireTime = in.ExpireTime.AsTime()
+	}
+
+	if !notBefore.IsZero() && !expireTime.IsZero() && !expireTime.After(notBefore) {
+		return nil, status.Errorf(codes.InvalidArgument, "expire_time must be after not_before")
+	}
+
+	cpb, err := blob.NewTimeBoundCapability(kp, del, acc, in.Path, role, in.Label, notBefore, expireTime, cclock.New().MustNow())
 	if err != nil {
 		return nil, err
 	}
@@ -86,9 +102,93 @@ func (srv *Server) CreateCapability(ctx context.Context, in *documents.CreateCap
 		return nil, err
 	}
 
+	// The new member needs the content key to read the private content of the space.
+	if err := srv.reshareSpaceKey(ctx, kp, false); err != nil {
+		return nil, err
+	}
+
 	return srv.GetCapability(ctx, &documents.GetCapabilityRequest{Id: cpb.CID.String()})
 }
 
+// reshareSpaceKey publishes a new SpaceKey for the current members of the signer's space.
+// Spaces without a content key don't have any encrypted content yet, so they are left alone.
+func (srv *Server) reshareSpaceKey(ctx context.Context, kp *core.KeyPair, rotate bool) error {
+	_, ok, err := srv.idx.SpaceContentKey(ctx, kp.Principal())
+	if err != nil || !ok {
+		return err
+	}
+
+	_, err = srv.idx.ShareSpaceKey(ctx, kp, rotate, cclock.New().MustNow())
+	return err
+}
+
+// rotateExpiredSpaceKeys rotates the content keys of our spaces when member capabilities expire,
+// like RevokeCapability does when they are revoked, so expired members can't read any new content.
+// It returns when the next capability expires, if any.
+func (srv *Server) rotateExpiredSpaceKeys(ctx context.Context, now time.Time) (next time.Time, err error) {
+	// Blobs can't be stored while reindexing. We'll try again later.
+	if srv.idx.ReindexInfo().State == blob.ReindexStateInProgress {
+		return next, nil
+	}
+
+	spaces, next, err := srv.idx.ExpiredSpaceKeys(ctx, now)
+	if err != nil {
+		return next, err
+	}
+
+	for _, space := range spaces {
+		// Only the owner can rotate the key of the space.
+		kp, err := srv.keyForPrincipal(ctx, space)
+		if err != nil {
+			if status.Code(err) == codes.FailedPrecondition {
+				continue
+			}
+			return next, err
+		}
+
+		if err := srv.reshareSpaceKey(ctx, kp, true); err != nil {
+			return next, err
+		}
+	}
+
+	return next, nil
+}
+
+// privateContentKey returns the content key to encrypt the private content of the space with.
+// Only the space owner can create the content key if there's none yet, so we do that if we hold the owner's key.
+// Otherwise private content can't be written until the owner shares a key with us, because it must never be stored in plaintext.
+func (srv *Server) privateContentKey(ctx context.Context, space core.Principal, ts time.Time) (blob.ContentKey, error) {
+	key, ok, err := srv.idx.SpaceContentKey(ctx, space)
+	if err != nil || ok {
+		return key, err
+	}
+
+	owner, err := srv.keyForPrincipal(ctx, space)
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return key, status.Errorf(codes.FailedPrecondition, "no content key for space '%s' yet: the owner must share it first", space)
+		}
+		return key, err
+	}
+
+	return srv.idx.ShareSpaceKey(ctx, owner, false, ts)
+}
+
+// encryptPrivateDocument makes the new change of a private document encrypted with the space content key.
+func (srv *Server) encryptPrivateDocument(ctx context.Context, doc *docmodel.Document, space core.Principal, visibility blob.Visibility) error {
+	if visibility != blob.VisibilityPrivate {
+		return nil
+	}
+
+	key, err := srv.privateContentKey(ctx, space, cclock.New().MustNow())
+	if err != nil {
+		return err
+	}
+
+	doc.SetContentKey(key)
+	return nil
+}
+
 // GetCapability implements Access Control API.
 func (srv *Server) GetCapability(ctx context.Context, in *documents.GetCapabilityRequest) (*documents.Capability, error) {
 	c, err := cid.Decode(in.Id)
@@ -109,6 +209,72 @@ func (srv *Server) GetCapability(ctx context.Context, in *documents.GetCapabilit
 	return capToProto(blk.Cid(), cpb)
 }
 
+// RevokeCapability implements Access Control API.
+func (srv *Server) RevokeCapability(ctx context.Context, in *documents.RevokeCapabilityRequest) (*documents.Revocation, error) {
+	{
+		if in.SigningKeyName == "" {
+			return nil, errutil.MissingArgument("signing_key_name")
+		}
+
+		if in.Id == "" {
+			return nil, errutil.MissingArgument("id")
+		}
+	}
+
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := cid.Decode(in.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse capability ID %s as CID: %v", in.Id, err)
+	}
+
+	blk, err := srv.idx.Get(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	cpb := &blob.Capability{}
+	if err := cbornode.DecodeInto(blk.RawData(), cpb); err != nil {
+		return nil, err
+	}
+
+	if cpb.Type != blob.TypeCapability {
+		return nil, status.Errorf(codes.InvalidArgument, "blob '%s' is not a capability", c)
+	}
+
+	if !cpb.Signer.Equal(kp.Principal()) {
+		return nil, status.Errorf(codes.PermissionDenied, "signing key '%s' cannot revoke capability '%s' issued by '%s'", kp.Principal(), c, cpb.Signer)
+	}
+
+	rv, err := blob.NewRevocation(kp, c, cpb, cclock.New().MustNow())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := srv.idx.Put(ctx, rv); err != nil {
+		return nil, err
+	}
+
+	// The revoked delegate may still hold the current content key,
+	// so the key is rotated to keep them from reading anything new.
+	if err := srv.reshareSpaceKey(ctx, kp, true); err != nil {
+		return nil, err
+	}
+
+	return &documents.Revocation{
+		Id:         rv.CID.String(),
+		Capability: c.String(),
+		Issuer:     rv.Decoded.Signer.String(),
+		Delegate:   cpb.Delegate.String(),
+		Account:    cpb.Space().String(),
+		Path:       cpb.Path,
+		CreateTime: timestamppb.New(rv.Decoded.Ts),
+	}, nil
+}
+
 // ListCapabilities implements Access Control API.
 func (srv *Server) ListCapabilities(ctx context.Context, in *documents.ListCapabilitiesRequest) (*documents.ListCapabilitiesResponse, error) {
 	{
@@ -206,5 +372,13 @@ func capToProto(c cid.Cid, cpb *blob.Capability) (*documents.Capability, error)
 		Label:      cpb.Label,
 	}
 
+	if !cpb.NotBefore.IsZero() {
+		pb.NotBefore = timestamppb.New(cpb.NotBefore)
+	}
+
+	if !cpb.ExpireTime.IsZero() {
+		pb.ExpireTime = timestamppb.New(cpb.ExpireTime)
+	}
+
 	return pb, nil
 }
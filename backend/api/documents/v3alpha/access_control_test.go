@@ -3,6 +3,8 @@ package documents
 import (
 	"context"
 	"seed/backend/api/apitest"
+	"seed/backend/blob"
+	"seed/backend/core"
 	"seed/backend/core/coretest"
 	documents "seed/backend/genproto/documents/v3alpha"
 	pb "seed/backend/genproto/documents/v3alpha"
@@ -10,8 +12,11 @@ import (
 	"seed/backend/util/colx"
 	"slices"
 	"testing"
+	"time"
 
+	cbornode "github.com/ipfs/go-ipld-cbor"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestCapabilities_Smoke(t *testing.T) {
@@ -85,6 +90,209 @@ func TestCapabilities_Smoke(t *testing.T) {
 	}
 }
 
+func TestRevokeCapability(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	bob := coretest.NewTester("bob")
+	ctx := context.Background()
+	require.NoError(t, alice.keys.StoreKey(ctx, "bob", bob.Account))
+
+	cars, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(alice.me.Account.Principal(), "/cars", "", "main").
+		SetMetadata("title", "Document about cars").
+		Build(),
+	)
+	require.NoError(t, err)
+
+	cpb, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       bob.Account.PublicKey.String(),
+		Account:        cars.Account,
+		Path:           cars.Path,
+		Role:           pb.Role_WRITER,
+	})
+	require.NoError(t, err)
+
+	_, err = alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(alice.me.Account.Principal(), "/cars/jp", "", "bob").
+		SetMetadata("title", "Catalogue of Japanese cars").
+		Build(),
+	)
+	require.NoError(t, err, "bob must be allowed to write before the revocation")
+
+	// Only the issuer can revoke the capability.
+	_, err = alice.RevokeCapability(ctx, &pb.RevokeCapabilityRequest{
+		SigningKeyName: "bob",
+		Id:             cpb.Id,
+	})
+	require.Error(t, err)
+
+	rv, err := alice.RevokeCapability(ctx, &pb.RevokeCapabilityRequest{
+		SigningKeyName: "main",
+		Id:             cpb.Id,
+	})
+	require.NoError(t, err)
+	require.Equal(t, cpb.Id, rv.Capability)
+	require.Equal(t, cpb.Delegate, rv.Delegate)
+	require.Equal(t, cpb.Account, rv.Account)
+	require.Equal(t, cpb.Path, rv.Path)
+	require.Equal(t, alice.me.Account.String(), rv.Issuer)
+
+	list, err := alice.ListCapabilities(ctx, &pb.ListCapabilitiesRequest{
+		Account: alice.me.Account.String(),
+		Path:    "/cars",
+	})
+	require.NoError(t, err)
+	require.Len(t, list.Capabilities, 0, "revoked capabilities must not be listed")
+
+	_, err = alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(alice.me.Account.Principal(), "/cars/de", "", "bob").
+		SetMetadata("title", "Catalogue of German cars").
+		Build(),
+	)
+	require.Error(t, err, "bob must not be allowed to write after the revocation")
+}
+
+func TestCreateCapability_TimeBound(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	bob := coretest.NewTester("bob")
+	ctx := context.Background()
+
+	now := time.Now()
+
+	_, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       bob.Account.PublicKey.String(),
+		Account:        alice.me.Account.String(),
+		Role:           pb.Role_WRITER,
+		NotBefore:      timestamppb.New(now.Add(time.Hour)),
+		ExpireTime:     timestamppb.New(now),
+	})
+	require.Error(t, err, "expire time must be after not-before time")
+
+	notBefore := now.Add(-time.Hour).Round(blob.ClockPrecision)
+	expireTime := now.Add(time.Hour).Round(blob.ClockPrecision)
+	cpb, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       bob.Account.PublicKey.String(),
+		Account:        alice.me.Account.String(),
+		Role:           pb.Role_WRITER,
+		NotBefore:      timestamppb.New(notBefore),
+		ExpireTime:     timestamppb.New(expireTime),
+	})
+	require.NoError(t, err)
+	require.True(t, notBefore.Equal(cpb.NotBefore.AsTime()))
+	require.True(t, expireTime.Equal(cpb.ExpireTime.AsTime()))
+
+	list, err := alice.ListCapabilities(ctx, &pb.ListCapabilitiesRequest{
+		Account: alice.me.Account.String(),
+	})
+	require.NoError(t, err)
+	require.Len(t, list.Capabilities, 1)
+	testutil.StructsEqual(cpb, list.Capabilities[0]).Compare(t, "must return the time-bound capability")
+}
+
+func TestExpiredCapabilityRotatesSpaceKey(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	bob := coretest.NewTester("bob")
+	ctx := context.Background()
+
+	_, err := alice.PublishDocumentChangeForTest(ctx, &apitest.DocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        alice.me.Account.String(),
+		Path:           "/secret",
+		Visibility:     pb.ResourceVisibility_RESOURCE_VISIBILITY_PRIVATE,
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_SetMetadata_{SetMetadata: &pb.DocumentChange_SetMetadata{Key: "title", Value: "Secret"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	expireTime := time.Now().Add(200 * time.Millisecond).Round(blob.ClockPrecision)
+	_, err = alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       bob.Account.PublicKey.String(),
+		Account:        alice.me.Account.String(),
+		Role:           pb.Role_READER,
+		ExpireTime:     timestamppb.New(expireTime),
+	})
+	require.NoError(t, err)
+
+	currentKey := func() (blob.ContentKey, []core.Principal) {
+		key, ok, err := alice.idx.SpaceContentKey(ctx, alice.me.Account.Principal())
+		require.NoError(t, err)
+		require.True(t, ok)
+		blk, err := alice.idx.Get(ctx, key.ID)
+		require.NoError(t, err)
+		sk := &blob.SpaceKey{}
+		require.NoError(t, cbornode.DecodeInto(blk.RawData(), sk))
+		return key, sk.Recipients()
+	}
+
+	shared, recipients := currentKey()
+	require.Contains(t, recipients, bob.Account.Principal(), "the new member must get the key")
+
+	next, err := alice.rotateExpiredSpaceKeys(ctx, time.Now())
+	require.NoError(t, err)
+	require.True(t, expireTime.Equal(next), "the scheduler must wake up when the capability expires")
+	key, _ := currentKey()
+	require.Equal(t, shared, key, "the key must not be rotated before the capability expires")
+
+	time.Sleep(time.Until(expireTime))
+
+	next, err = alice.rotateExpiredSpaceKeys(ctx, time.Now())
+	require.NoError(t, err)
+	require.True(t, next.IsZero())
+	key, recipients = currentKey()
+	require.NotEqual(t, shared.Key, key.Key, "the key must be rotated once the capability expires")
+	require.NotContains(t, recipients, bob.Account.Principal())
+
+	_, err = alice.rotateExpiredSpaceKeys(ctx, time.Now())
+	require.NoError(t, err)
+	again, _ := currentKey()
+	require.Equal(t, key, again, "the key must only be rotated once")
+}
+
+func TestCapabilities_ReadOnlyRoles(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	bob := coretest.NewTester("bob")
+	carol := coretest.NewTester("carol")
+	ctx := context.Background()
+
+	reader, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       bob.Account.PublicKey.String(),
+		Account:        alice.me.Account.String(),
+		Path:           "/docs",
+		Role:           pb.Role_READER,
+	})
+	require.NoError(t, err)
+	require.Equal(t, pb.Role_READER, reader.Role)
+
+	commenter, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       carol.Account.PublicKey.String(),
+		Account:        alice.me.Account.String(),
+		Path:           "/docs",
+		Role:           pb.Role_COMMENTER,
+	})
+	require.NoError(t, err)
+	require.Equal(t, pb.Role_COMMENTER, commenter.Role)
+
+	list, err := alice.ListCapabilities(ctx, &pb.ListCapabilitiesRequest{
+		Account: alice.me.Account.String(),
+		Path:    "/docs",
+	})
+	require.NoError(t, err)
+	require.Len(t, list.Capabilities, 2)
+	testutil.StructsEqual(reader, list.Capabilities[0]).Compare(t, "must return the reader capability")
+	testutil.StructsEqual(commenter, list.Capabilities[1]).Compare(t, "must return the commenter capability")
+}
+
 func TestWriterCollaboratorPermissions(t *testing.T) {
 	t.Parallel()
 
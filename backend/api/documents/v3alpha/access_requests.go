@@ -0,0 +1,356 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/blob"
+	"seed/backend/core"
+	documents "seed/backend/genproto/documents/v3alpha"
+	p2p "seed/backend/genproto/p2p/v1alpha"
+	"seed/backend/hmnet/syncing"
+	"seed/backend/util/cclock"
+	"seed/backend/util/dqb"
+	"seed/backend/util/errutil"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Statuses of access requests.
+const (
+	accessRequestPending  = "pending"
+	accessRequestApproved = "approved"
+	accessRequestDenied   = "denied"
+)
+
+// RequestAccess implements Access Control API.
+func (srv *Server) RequestAccess(ctx context.Context, in *documents.RequestAccessRequest) (*documents.AccessRequest, error) {
+	{
+		if in.SigningKeyName == "" {
+			return nil, errutil.MissingArgument("signing_key_name")
+		}
+
+		if in.Account == "" {
+			return nil, errutil.MissingArgument("account")
+		}
+
+		if in.PeerId == "" {
+			return nil, errutil.MissingArgument("peer_id")
+		}
+	}
+
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	space, err := core.DecodePrincipal(in.Account)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse account '%s': %v", in.Account, err)
+	}
+
+	if space.Equal(kp.Principal()) {
+		return nil, status.Errorf(codes.InvalidArgument, "can't request access to your own space")
+	}
+
+	if in.Role == documents.Role_ROLE_UNSPECIFIED {
+		in.Role = documents.Role_READER
+	}
+
+	role, ok := roleFromProto[in.Role]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported role '%s'", in.Role)
+	}
+
+	pid, err := peer.Decode(in.PeerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse peer ID '%s': %v", in.PeerId, err)
+	}
+
+	if srv.p2p == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "access can't be requested without a P2P node")
+	}
+
+	ar, err := blob.NewAccessRequest(kp, space, in.Path, role, in.Message, cclock.New().MustNow())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to create access request: %v", err)
+	}
+
+	client, err := srv.p2p.Client(ctx, pid)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to connect to the peer of the space owner: %v", err)
+	}
+
+	// The owner only accepts requests from peers authenticated as the requester.
+	if err := syncing.AuthenticateWithPeer(ctx, client, srv.p2p.AddrInfo().ID, pid, kp); err != nil {
+		return nil, err
+	}
+
+	if _, err := client.RequestAccess(ctx, &p2p.RequestAccessRequest{AccessRequest: ar.Data}); err != nil {
+		return nil, err
+	}
+
+	return accessRequestToProto(ar, ar.Decoded.Ts)
+}
+
+// ReceiveAccessRequest stores an access request sent by a remote peer for one of our spaces.
+// A new request replaces the previous pending requests of the same requester for the same resource.
+// Denied requests are kept, and the requester can't ask for the same resource again until the cool-down passes,
+// so the owner is not spammed with the same requests.
+// It implements the [hmnet.AccessRequestHandler] interface.
+func (srv *Server) ReceiveAccessRequest(ctx context.Context, from peer.ID, in *p2p.RequestAccessRequest) (*p2p.RequestAccessResponse, error) {
+	ar, err := blob.DecodeAccessRequest(in.AccessRequest)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	if !srv.idx.IsPeerAuthenticated(from, ar.Decoded.Signer) {
+		return nil, status.Errorf(codes.Unauthenticated, "peer '%s' must authenticate as '%s' to request access", from, ar.Decoded.Signer)
+	}
+
+	if _, ok := roleToProto[ar.Decoded.Role]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported role '%s'", ar.Decoded.Role)
+	}
+
+	if ar.Decoded.Signer.Equal(ar.Decoded.Space) {
+		return nil, status.Errorf(codes.InvalidArgument, "can't request access to your own space")
+	}
+
+	// We only keep requests that we can approve.
+	if _, err := srv.keyForPrincipal(ctx, ar.Decoded.Space); err != nil {
+		return nil, err
+	}
+
+	iri, err := makeIRI(ar.Decoded.Space, ar.Decoded.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := srv.db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		since := time.Now().Add(-srv.accessRequestCooldown).UnixMilli()
+		denied, err := sqlitex.QueryOne[int64](conn, qCountDeniedAccessRequests(), string(iri), []byte(ar.Decoded.Signer), since)
+		if err != nil {
+			return err
+		}
+
+		if denied > 0 {
+			return status.Errorf(codes.ResourceExhausted, "access request for '%s' was denied recently: try again later", iri)
+		}
+
+		if err := sqlitex.Exec(conn, qDeletePendingAccessRequests(), nil, string(iri), []byte(ar.Decoded.Signer), ar.CID.String()); err != nil {
+			return err
+		}
+
+		return sqlitex.Exec(conn, qInsertAccessRequest(), nil, ar.CID.String(), ar.Data, string(iri), []byte(ar.Decoded.Signer), time.Now().UnixMilli())
+	}); err != nil {
+		return nil, err
+	}
+
+	return &p2p.RequestAccessResponse{}, nil
+}
+
+// ListAccessRequests implements Access Control API.
+func (srv *Server) ListAccessRequests(ctx context.Context, in *documents.ListAccessRequestsRequest) (*documents.ListAccessRequestsResponse, error) {
+	var space string
+	if in.Account != "" {
+		acc, err := core.DecodePrincipal(in.Account)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse account '%s': %v", in.Account, err)
+		}
+		space = "hm://" + acc.String()
+	}
+
+	out := &documents.ListAccessRequestsResponse{}
+	if err := srv.db.WithSave(ctx, func(conn *sqlite.Conn) (err error) {
+		rows, discard, check := sqlitex.Query(conn, qListAccessRequests(), space).All()
+		defer discard(&err)
+		for row := range rows {
+			ar, err := blob.DecodeAccessRequest(row.ColumnBytes(0))
+			if err != nil {
+				return err
+			}
+
+			pb, err := accessRequestToProto(ar, time.UnixMilli(row.ColumnInt64(1)))
+			if err != nil {
+				return err
+			}
+
+			out.AccessRequests = append(out.AccessRequests, pb)
+		}
+		return check()
+	}); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// ApproveAccessRequest implements Access Control API.
+func (srv *Server) ApproveAccessRequest(ctx context.Context, in *documents.ApproveAccessRequestRequest) (*documents.Capability, error) {
+	{
+		if in.Id == "" {
+			return nil, errutil.MissingArgument("id")
+		}
+
+		if in.SigningKeyName == "" {
+			return nil, errutil.MissingArgument("signing_key_name")
+		}
+	}
+
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	ar, err := srv.getPendingAccessRequest(ctx, in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ar.Decoded.Space.Equal(kp.Principal()) {
+		return nil, status.Errorf(codes.PermissionDenied, "signing key '%s' cannot grant access to account '%s'", kp.Principal(), ar.Decoded.Space)
+	}
+
+	role := ar.Decoded.Role
+	if in.Role != documents.Role_ROLE_UNSPECIFIED {
+		r, ok := roleFromProto[in.Role]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported role '%s'", in.Role)
+		}
+		role = r
+	}
+
+	now := cclock.New().MustNow()
+	cpb, err := blob.NewCapability(kp, ar.Decoded.Signer, ar.Decoded.Space, ar.Decoded.Path, role, "", now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := srv.idx.Put(ctx, cpb); err != nil {
+		return nil, err
+	}
+
+	if err := srv.db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, qDecideAccessRequest(), nil, accessRequestApproved, cpb.CID.String(), now.UnixMilli(), in.Id)
+	}); err != nil {
+		return nil, err
+	}
+
+	// The new member needs the content key to read the private content of the space.
+	if err := srv.reshareSpaceKey(ctx, kp, false); err != nil {
+		return nil, err
+	}
+
+	return capToProto(cpb.CID, cpb.Decoded)
+}
+
+// DenyAccessRequest implements Access Control API.
+func (srv *Server) DenyAccessRequest(ctx context.Context, in *documents.DenyAccessRequestRequest) (*emptypb.Empty, error) {
+	if in.Id == "" {
+		return nil, errutil.MissingArgument("id")
+	}
+
+	if _, err := srv.getPendingAccessRequest(ctx, in.Id); err != nil {
+		return nil, err
+	}
+
+	if err := srv.db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, qDecideAccessRequest(), nil, accessRequestDenied, nil, time.Now().UnixMilli(), in.Id)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (srv *Server) getPendingAccessRequest(ctx context.Context, id string) (ar blob.Encoded[*blob.AccessRequest], err error) {
+	var (
+		data []byte
+		stat string
+	)
+	if err := srv.db.WithSave(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, qGetAccessRequest(), func(stmt *sqlite.Stmt) error {
+			data = stmt.ColumnBytes(0)
+			stat = stmt.ColumnText(1)
+			return nil
+		}, id)
+	}); err != nil {
+		return ar, err
+	}
+
+	if data == nil {
+		return ar, status.Errorf(codes.NotFound, "access request '%s' not found", id)
+	}
+
+	if stat != accessRequestPending {
+		return ar, status.Errorf(codes.FailedPrecondition, "access request '%s' is already %s", id, stat)
+	}
+
+	return blob.DecodeAccessRequest(data)
+}
+
+func accessRequestToProto(ar blob.Encoded[*blob.AccessRequest], createTime time.Time) (*documents.AccessRequest, error) {
+	role, ok := roleToProto[ar.Decoded.Role]
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "unsupported role '%s' in access request '%s'", ar.Decoded.Role, ar.CID)
+	}
+
+	return &documents.AccessRequest{
+		Id:         ar.CID.String(),
+		Account:    ar.Decoded.Space.String(),
+		Path:       ar.Decoded.Path,
+		Role:       role,
+		Requester:  ar.Decoded.Signer.String(),
+		Message:    ar.Decoded.Message,
+		CreateTime: timestamppb.New(createTime),
+	}, nil
+}
+
+var qInsertAccessRequest = dqb.Str(`
+	INSERT OR IGNORE INTO access_requests (id, data, iri, requester, create_time)
+	VALUES (?, ?, ?, ?, ?)
+`)
+
+// Removes the pending requests of the requester for the resource, which are replaced by a new one.
+var qDeletePendingAccessRequests = dqb.Str(`
+	DELETE FROM access_requests
+	WHERE iri = :iri
+	AND requester = :requester
+	AND id != :id
+	AND status = 'pending'
+`)
+
+var qCountDeniedAccessRequests = dqb.Str(`
+	SELECT count()
+	FROM access_requests
+	WHERE iri = :iri
+	AND requester = :requester
+	AND status = 'denied'
+	AND decide_time > :since
+`)
+
+// Pending access requests in the given space, or in all the spaces if it's empty.
+var qListAccessRequests = dqb.Str(`
+	SELECT data, create_time
+	FROM access_requests
+	WHERE status = 'pending'
+	AND (:space = '' OR iri = :space OR (iri >= :space || '/' AND iri < :space || '0'))
+	ORDER BY create_time DESC
+`)
+
+var qGetAccessRequest = dqb.Str(`
+	SELECT data, status
+	FROM access_requests
+	WHERE id = ?
+`)
+
+var qDecideAccessRequest = dqb.Str(`
+	UPDATE access_requests
+	SET status = ?, capability = ?, decide_time = ?
+	WHERE id = ?
+`)
@@ -0,0 +1,111 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/blob"
+	"seed/backend/core"
+	pb "seed/backend/genproto/documents/v3alpha"
+	p2p "seed/backend/genproto/p2p/v1alpha"
+	"seed/backend/hmnet/syncing"
+	"seed/backend/util/cclock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAccessRequests(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	bob := newTestDocsAPI(t, "bob")
+	carol := newTestDocsAPI(t, "carol")
+	ctx := context.Background()
+
+	// Sends the request to alice directly, instead of over the P2P network.
+	send := func(requester testServer, space core.Principal, path string, role blob.Role) (blob.Encoded[*blob.AccessRequest], error) {
+		t.Helper()
+		ar, err := blob.NewAccessRequest(requester.me.Account, space, path, role, "Please let me in", cclock.New().MustNow())
+		require.NoError(t, err)
+		_, err = alice.ReceiveAccessRequest(ctx, requester.me.Device.PeerID(), &p2p.RequestAccessRequest{AccessRequest: ar.Data})
+		return ar, err
+	}
+
+	_, err := send(bob, alice.me.Account.Principal(), "/secret", blob.RoleReader)
+	require.Equal(t, codes.Unauthenticated, status.Code(err), "requests must come from peers authenticated as the requester")
+
+	for _, s := range []testServer{bob, carol} {
+		client := authenticateClient{authenticate: func(in *p2p.AuthenticateRequest) error {
+			return alice.idx.AuthenticatePeer(s.me.Device.PeerID(), s.me.Account.Principal(), alice.me.Device.PeerID(), time.UnixMilli(in.Timestamp), in.Signature)
+		}}
+		require.NoError(t, syncing.AuthenticateWithPeer(ctx, client, s.me.Device.PeerID(), alice.me.Device.PeerID(), s.me.Account))
+	}
+
+	_, err = send(bob, carol.me.Account.Principal(), "/secret", blob.RoleReader)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "requests for spaces we don't own must be rejected")
+
+	_, err = send(bob, alice.me.Account.Principal(), "/secret", blob.RoleReader)
+	require.NoError(t, err)
+	bobReq, err := send(bob, alice.me.Account.Principal(), "/secret", blob.RoleCommenter)
+	require.NoError(t, err)
+	carolReq, err := send(carol, alice.me.Account.Principal(), "/secret", blob.RoleReader)
+	require.NoError(t, err)
+
+	list, err := alice.ListAccessRequests(ctx, &pb.ListAccessRequestsRequest{Account: alice.me.Account.String()})
+	require.NoError(t, err)
+	require.Len(t, list.AccessRequests, 2, "new requests must replace the pending ones of the same requester")
+	ids := []string{list.AccessRequests[0].Id, list.AccessRequests[1].Id}
+	require.ElementsMatch(t, []string{bobReq.CID.String(), carolReq.CID.String()}, ids)
+
+	capab, err := alice.ApproveAccessRequest(ctx, &pb.ApproveAccessRequestRequest{Id: bobReq.CID.String(), SigningKeyName: "main"})
+	require.NoError(t, err)
+	require.Equal(t, bob.me.Account.String(), capab.Delegate)
+	require.Equal(t, alice.me.Account.String(), capab.Account)
+	require.Equal(t, "/secret", capab.Path)
+	require.Equal(t, pb.Role_COMMENTER, capab.Role)
+
+	_, err = alice.GetCapability(ctx, &pb.GetCapabilityRequest{Id: capab.Id})
+	require.NoError(t, err)
+
+	_, err = alice.ApproveAccessRequest(ctx, &pb.ApproveAccessRequestRequest{Id: bobReq.CID.String(), SigningKeyName: "main"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "requests can only be decided once")
+
+	_, err = alice.DenyAccessRequest(ctx, &pb.DenyAccessRequestRequest{Id: carolReq.CID.String()})
+	require.NoError(t, err)
+
+	list, err = alice.ListAccessRequests(ctx, &pb.ListAccessRequestsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, list.AccessRequests, "decided requests are not pending")
+
+	_, err = alice.DenyAccessRequest(ctx, &pb.DenyAccessRequestRequest{Id: "bafy-missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = send(carol, alice.me.Account.Principal(), "/secret", blob.RoleReader)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err), "denied requests can't be sent again right away")
+	list, err = alice.ListAccessRequests(ctx, &pb.ListAccessRequestsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, list.AccessRequests)
+
+	// Denied requests can be sent again after the cool-down.
+	alice.accessRequestCooldown = 0
+	_, err = send(carol, alice.me.Account.Principal(), "/secret", blob.RoleReader)
+	require.NoError(t, err)
+	list, err = alice.ListAccessRequests(ctx, &pb.ListAccessRequestsRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.AccessRequests, 1)
+	require.Equal(t, carol.me.Account.String(), list.AccessRequests[0].Requester)
+	require.Equal(t, "Please let me in", list.AccessRequests[0].Message)
+}
+
+// authenticateClient is a P2P client which only handles the authentication requests.
+type authenticateClient struct {
+	p2p.P2PClient
+	authenticate func(in *p2p.AuthenticateRequest) error
+}
+
+func (c authenticateClient) Authenticate(_ context.Context, in *p2p.AuthenticateRequest, _ ...grpc.CallOption) (*p2p.AuthenticateResponse, error) {
+	return &p2p.AuthenticateResponse{}, c.authenticate(in)
+}
@@ -16,6 +16,7 @@ import (
 	"seed/backend/util/must"
 	"seed/backend/util/sqlite"
 	"seed/backend/util/sqlite/sqlitex"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	cbornode "github.com/ipfs/go-ipld-cbor"
@@ -89,7 +90,12 @@ func (srv *Server) CreateComment(ctx context.Context, in *documents.CreateCommen
 		visibility = blob.VisibilityPublic
 	}
 
-	eb, err := blob.NewComment(kp, "", space, in.TargetPath, versionHeads, threadRoot, replyParent, commentContentFromProto(in.Content), visibility, clock.MustNow())
+	anchor, err := srv.commentAnchorFromProto(ctx, space, in.TargetPath, in.TargetVersion, in.Anchor)
+	if err != nil {
+		return nil, err
+	}
+
+	eb, err := srv.newComment(ctx, kp, "", space, in.TargetPath, versionHeads, threadRoot, replyParent, commentContentFromProto(in.Content), anchor, visibility, clock.MustNow())
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +110,33 @@ func (srv *Server) CreateComment(ctx context.Context, in *documents.CreateCommen
 	})
 }
 
+// newComment creates a new comment blob, encrypting the body of private comments with the space content key.
+func (srv *Server) newComment(
+	ctx context.Context,
+	kp *core.KeyPair,
+	id blob.TSID,
+	space core.Principal,
+	path string,
+	version []cid.Cid,
+	threadRoot cid.Cid,
+	replyParent cid.Cid,
+	body []blob.CommentBlock,
+	anchor *blob.CommentAnchor,
+	visibility blob.Visibility,
+	ts time.Time,
+) (blob.Encoded[*blob.Comment], error) {
+	if visibility != blob.VisibilityPrivate || len(body) == 0 {
+		return blob.NewComment(kp, id, space, path, version, threadRoot, replyParent, body, anchor, visibility, ts)
+	}
+
+	key, err := srv.privateContentKey(ctx, space, ts)
+	if err != nil {
+		return blob.Encoded[*blob.Comment]{}, err
+	}
+
+	return blob.NewEncryptedComment(kp, id, space, path, version, threadRoot, replyParent, body, anchor, key, ts)
+}
+
 // GetComment implements Comments API.
 func (srv *Server) GetComment(ctx context.Context, in *documents.GetCommentRequest) (*documents.Comment, error) {
 	resp, err := srv.BatchGetComments(ctx, &documents.BatchGetCommentsRequest{
@@ -140,6 +173,10 @@ func (srv *Server) BatchGetComments(ctx context.Context, in *documents.BatchGetC
 				return nil, err
 			}
 
+			if err := loadCommentAnchorPosition(conn, icmt.DBID, pb.Anchor); err != nil {
+				return nil, err
+			}
+
 			resp.Comments[i] = pb
 		}
 		return resp, nil
@@ -163,12 +200,20 @@ func (srv *Server) ListComments(ctx context.Context, in *documents.ListCommentsR
 		lookup := blob.NewLookupCache(conn)
 
 		q := qIterComments()
-		if publicOnly, err := srv.isPublicOnlyFor(ctx, acc, in.TargetPath); err != nil {
+		publicOnly, err := srv.isPublicOnlyFor(ctx, acc, in.TargetPath)
+		if err != nil {
 			return nil, err
-		} else if publicOnly {
+		}
+		if publicOnly {
 			q = qIterCommentsPublicOnly()
 		}
-		comments, discard, check := sqlitex.QueryType(conn, srv.commentDBMapper(), q, iri).All()
+
+		reactions, err := loadReactionCounts(conn, iri, publicOnly)
+		if err != nil {
+			return nil, err
+		}
+
+		comments, discard, check := sqlitex.QueryType(conn, srv.commentDBMapper(conn), q, iri).All()
 		defer discard(&err)
 		for comment := range comments {
 			pb, err := commentToProto(lookup, comment.CID, comment.Comment, comment.TSID)
@@ -177,6 +222,11 @@ func (srv *Server) ListComments(ctx context.Context, in *documents.ListCommentsR
 			}
 			pb.TargetAccount = acc.String()
 			pb.TargetPath = in.TargetPath
+			pb.Reactions = reactions[reactionTarget{Comment: pb.Id}]
+
+			if err := loadCommentAnchorPosition(conn, comment.DBID, pb.Anchor); err != nil {
+				return nil, err
+			}
 
 			resp.Comments = append(resp.Comments, pb)
 		}
@@ -223,7 +273,7 @@ func (srv *Server) ListCommentsByAuthor(ctx context.Context, in *documents.ListC
 				args = []any{author, []byte(caller), cursor.CommentID, in.PageSize + 1}
 			}
 		}
-		comments, discard, check := sqlitex.QueryType(conn, srv.commentDBMapper(), q, args...).All()
+		comments, discard, check := sqlitex.QueryType(conn, srv.commentDBMapper(conn), q, args...).All()
 		defer discard(&err)
 
 		for result := range comments {
@@ -252,7 +302,7 @@ type indexedComment struct {
 	Comment *blob.Comment
 }
 
-func (srv *Server) commentDBMapper() sqlitex.MapperFunc[indexedComment] {
+func (srv *Server) commentDBMapper(conn *sqlite.Conn) sqlitex.MapperFunc[indexedComment] {
 	buf := make([]byte, 0, 1024*1024) // Preallocate 1 MiB scratch buffer for decoding.
 	return func(stmt *sqlite.Stmt) (ic indexedComment, err error) {
 		seq := sqlite.NewIncrementor(0)
@@ -278,6 +328,10 @@ func (srv *Server) commentDBMapper() sqlitex.MapperFunc[indexedComment] {
 			return ic, err
 		}
 
+		if err := srv.idx.OpenComment(conn, cmt); err != nil {
+			return ic, err
+		}
+
 		return indexedComment{
 			DBID:    id,
 			CID:     c,
@@ -433,7 +487,7 @@ var qIterCommentsByAuthorAuthenticated = dqb.Str(`
 	AND sb.extra_attrs->>'deleted' IS NULL
 	AND (
 		sb.extra_attrs->>'visibility' IS NOT 'Private'
-		OR ` + blob.SQLCanWriteRootByOwnerID("r.owner") + `
+		OR ` + blob.SQLCanReadRootByOwnerID("r.owner", blob.SQLNowMillis) + `
 	)
 	AND sb.id < :afterID
 	ORDER BY sb.id DESC
@@ -462,13 +516,13 @@ func (srv *Server) getComment(conn *sqlite.Conn, idRaw string) (out indexedComme
 		}
 	}
 
-	comments, discard, check := sqlitex.QueryType(conn, srv.commentDBMapper(), query, args...).All()
+	comments, discard, check := sqlitex.QueryType(conn, srv.commentDBMapper(conn), query, args...).All()
 	defer discard(&err)
 
 	var icmt indexedComment
 	for cmt := range comments {
 		// Check if the comment is marked as deleted
-		if len(cmt.Comment.Body) == 0 {
+		if cmt.Comment.IsTombstone() {
 			return out, status.Errorf(codes.NotFound, "comment %s has been deleted", idRaw)
 		}
 		icmt = cmt
@@ -579,6 +633,17 @@ func commentToProto(lookup *blob.LookupCache, c cid.Cid, cmt *blob.Comment, tsid
 		pb.Content = nil
 	}
 
+	if a := cmt.Anchor; a != nil {
+		pb.Anchor = &documents.CommentAnchor{
+			BlockId: a.Block,
+			Start:   int32(a.Start), //nolint:gosec // Offsets are validated to be small.
+			End:     int32(a.End),   //nolint:gosec // Offsets are validated to be small.
+		}
+		if a.Revision.Defined() {
+			pb.Anchor.BlockRevision = a.Revision.String()
+		}
+	}
+
 	if cmt.ThreadRoot.Defined() {
 		ridRoot, err := lookup.RecordID(cmt.ThreadRoot)
 		if err != nil {
@@ -603,6 +668,78 @@ func commentToProto(lookup *blob.LookupCache, c cid.Cid, cmt *blob.Comment, tsid
 	return pb, nil
 }
 
+var commentAnchorStatusToProto = map[blob.CommentAnchorStatus]documents.CommentAnchorStatus{
+	blob.CommentAnchorValid:    documents.CommentAnchorStatus_COMMENT_ANCHOR_STATUS_VALID,
+	blob.CommentAnchorMoved:    documents.CommentAnchorStatus_COMMENT_ANCHOR_STATUS_MOVED,
+	blob.CommentAnchorOrphaned: documents.CommentAnchorStatus_COMMENT_ANCHOR_STATUS_ORPHANED,
+}
+
+// commentAnchorFromProto makes sure the anchored block exists in the target version,
+// and records the revision of the block the anchor is made against.
+// If the target version is not available locally, the anchor is accepted as is.
+func (srv *Server) commentAnchorFromProto(ctx context.Context, space core.Principal, path, version string, in *documents.CommentAnchor) (*blob.CommentAnchor, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	if in.BlockId == "" {
+		return nil, errutil.MissingArgument("anchor.block_id")
+	}
+
+	out := &blob.CommentAnchor{
+		Block: in.BlockId,
+		Start: int(in.Start),
+		End:   int(in.End),
+	}
+
+	if in.Start < 0 || (in.End <= in.Start && (in.Start != 0 || in.End != 0)) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid anchor range [%d, %d)", in.Start, in.End)
+	}
+
+	revisions, err := srv.targetBlockRevisionsAtVersion(ctx, space, path, version)
+	if err != nil {
+		if isUnresolvableTarget(err) {
+			return out, nil
+		}
+		return nil, err
+	}
+
+	rev, ok := revisions[in.BlockId]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "anchored block '%s' doesn't exist in the target version", in.BlockId)
+	}
+
+	out.Revision, err = cid.Decode(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// loadCommentAnchorPosition fills the position of the comment anchor in the latest version of the target document,
+// as resolved by the indexer.
+func loadCommentAnchorPosition(conn *sqlite.Conn, comment int64, pb *documents.CommentAnchor) error {
+	if pb == nil {
+		return nil
+	}
+
+	return sqlitex.Exec(conn, qGetCommentAnchor(), func(stmt *sqlite.Stmt) error {
+		pb.Status = commentAnchorStatusToProto[blob.CommentAnchorStatus(stmt.ColumnText(0))]
+		pb.ResolvedStart = int32(stmt.ColumnInt64(1)) //nolint:gosec // Offsets are validated to be small.
+		pb.ResolvedEnd = int32(stmt.ColumnInt64(2))   //nolint:gosec // Offsets are validated to be small.
+		pb.ResolvedVersion = stmt.ColumnText(3)
+		return nil
+	}, comment)
+}
+
+var qGetCommentAnchor = dqb.Str(`
+	SELECT status, resolved_start, resolved_end, resolved_version
+	FROM comment_anchors
+	WHERE comment = ?
+	AND status IS NOT NULL
+`)
+
 func commentContentToProto(in []blob.CommentBlock) ([]*documents.BlockNode, error) {
 	if in == nil {
 		return nil, nil
@@ -733,7 +870,12 @@ func (srv *Server) UpdateComment(ctx context.Context, in *documents.UpdateCommen
 		visibility = blob.VisibilityPublic
 	}
 
-	eb, err := blob.NewComment(kp, rid.TSID, space, comment.TargetPath, versionHeads, threadRoot, replyParent, commentContentFromProto(comment.Content), visibility, clock.MustNow())
+	anchor, err := srv.commentAnchorFromProto(ctx, space, comment.TargetPath, comment.TargetVersion, comment.Anchor)
+	if err != nil {
+		return nil, err
+	}
+
+	eb, err := srv.newComment(ctx, kp, rid.TSID, space, comment.TargetPath, versionHeads, threadRoot, replyParent, commentContentFromProto(comment.Content), anchor, visibility, clock.MustNow())
 	if err != nil {
 		return nil, err
 	}
@@ -797,7 +939,7 @@ func (srv *Server) DeleteComment(ctx context.Context, in *documents.DeleteCommen
 		visibility = blob.VisibilityPublic
 	}
 
-	eb, err := blob.NewComment(kp, rid.TSID, originalComment.Comment.Space(), originalComment.Comment.Path, originalComment.Comment.Version, cid.Undef, cid.Undef, nil, visibility, clock.MustNow())
+	eb, err := blob.NewComment(kp, rid.TSID, originalComment.Comment.Space(), originalComment.Comment.Path, originalComment.Comment.Version, cid.Undef, cid.Undef, nil, nil, visibility, clock.MustNow())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete comment: %v", err)
 	}
@@ -856,12 +998,12 @@ func (srv *Server) ListCommentVersions(ctx context.Context, in *documents.ListCo
 		resp := &documents.ListCommentVersionsResponse{}
 		lookup := blob.NewLookupCache(conn)
 
-		versions, discard, check := sqlitex.QueryType(conn, srv.commentDBMapper(), qListCommentVersions(), rid.Authority, rid.TSID.String()).All()
+		versions, discard, check := sqlitex.QueryType(conn, srv.commentDBMapper(conn), qListCommentVersions(), rid.Authority, rid.TSID.String()).All()
 		defer discard(&err)
 
 		for v := range versions {
 			// Skip tombstones (deleted versions).
-			if len(v.Comment.Body) == 0 {
+			if v.Comment.IsTombstone() {
 				continue
 			}
 			pb, err := commentToProto(lookup, v.CID, v.Comment, v.TSID)
@@ -1682,3 +1682,83 @@ func TestCommentCount_DedupesEditsAndDeletions(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, int32(0), getHomeCount(t), "deleting last comment makes count 0")
 }
+
+func TestCommentAnchors(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+	account := alice.me.Account.PublicKey.String()
+
+	v1, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(alice.me.Account.Principal(), "/doc", "", "main").
+		MoveBlock("b1", "", "").
+		ReplaceBlock("b1", "paragraph", "Hello world").
+		MoveBlock("b2", "", "b1").
+		ReplaceBlock("b2", "paragraph", "Second").
+		Build())
+	require.NoError(t, err)
+
+	comment := func(anchor *pb.CommentAnchor) (*pb.Comment, error) {
+		return alice.CreateComment(ctx, &pb.CreateCommentRequest{
+			SigningKeyName: "main",
+			TargetAccount:  account,
+			TargetPath:     "/doc",
+			TargetVersion:  v1.Version,
+			Content:        []*pb.BlockNode{{Block: &pb.Block{Id: "c1", Type: "paragraph", Text: "Look here"}}},
+			Anchor:         anchor,
+		})
+	}
+
+	world, err := comment(&pb.CommentAnchor{BlockId: "b1", Start: 6, End: 11})
+	require.NoError(t, err)
+	require.Equal(t, v1.Content[0].Block.Revision, world.Anchor.BlockRevision)
+	hello, err := comment(&pb.CommentAnchor{BlockId: "b1", Start: 0, End: 5})
+	require.NoError(t, err)
+	second, err := comment(&pb.CommentAnchor{BlockId: "b2"})
+	require.NoError(t, err)
+
+	_, err = comment(&pb.CommentAnchor{BlockId: "missing"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "anchored block must exist in the target version")
+
+	anchors := func() map[string]*pb.CommentAnchor {
+		t.Helper()
+		// Anchors are resolved in the background after indexing.
+		require.NoError(t, alice.idx.WaitIndexedHook(ctx))
+		list, err := alice.ListComments(ctx, &pb.ListCommentsRequest{TargetAccount: account, TargetPath: "/doc"})
+		require.NoError(t, err)
+		out := make(map[string]*pb.CommentAnchor)
+		for _, c := range list.Comments {
+			out[c.Id] = c.Anchor
+		}
+		return out
+	}
+
+	got := anchors()
+	require.Equal(t, pb.CommentAnchorStatus_COMMENT_ANCHOR_STATUS_VALID, got[world.Id].Status)
+	require.Equal(t, []int32{6, 11}, []int32{got[world.Id].ResolvedStart, got[world.Id].ResolvedEnd})
+	require.Equal(t, v1.Version, got[world.Id].ResolvedVersion)
+	require.Equal(t, pb.CommentAnchorStatus_COMMENT_ANCHOR_STATUS_VALID, got[second.Id].Status)
+
+	v2, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(alice.me.Account.Principal(), "/doc", v1.Version, "main").
+		ReplaceBlock("b1", "paragraph", "Oh, Hello world").
+		DeleteBlock("b2").
+		Build())
+	require.NoError(t, err)
+
+	got = anchors()
+	require.Equal(t, pb.CommentAnchorStatus_COMMENT_ANCHOR_STATUS_MOVED, got[world.Id].Status, "anchor must follow the text edits")
+	require.Equal(t, []int32{10, 15}, []int32{got[world.Id].ResolvedStart, got[world.Id].ResolvedEnd})
+	require.Equal(t, v2.Version, got[world.Id].ResolvedVersion)
+	require.Equal(t, pb.CommentAnchorStatus_COMMENT_ANCHOR_STATUS_MOVED, got[hello.Id].Status)
+	require.Equal(t, []int32{4, 9}, []int32{got[hello.Id].ResolvedStart, got[hello.Id].ResolvedEnd})
+	require.Equal(t, pb.CommentAnchorStatus_COMMENT_ANCHOR_STATUS_ORPHANED, got[second.Id].Status, "anchors to deleted blocks are orphaned")
+
+	_, err = alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(alice.me.Account.Principal(), "/doc", v2.Version, "main").
+		ReplaceBlock("b1", "paragraph", "Oh, Hello").
+		Build())
+	require.NoError(t, err)
+
+	got = anchors()
+	require.Equal(t, pb.CommentAnchorStatus_COMMENT_ANCHOR_STATUS_ORPHANED, got[world.Id].Status, "anchors to deleted text are orphaned")
+	require.Equal(t, pb.CommentAnchorStatus_COMMENT_ANCHOR_STATUS_MOVED, got[hello.Id].Status)
+}
@@ -0,0 +1,109 @@
+package documents
+
+import (
+	"context"
+	"maps"
+	"seed/backend/api/documents/v3alpha/docmodel"
+	"seed/backend/blob"
+	"seed/backend/core"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetDocumentBlame implements Documents API v3.
+func (srv *Server) GetDocumentBlame(ctx context.Context, in *documents.GetDocumentBlameRequest) (*documents.DocumentBlame, error) {
+	acc, err := core.DecodePrincipal(in.Account)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse account '%s': %v", in.Account, err)
+	}
+
+	heads, err := docmodel.Version(in.Version).Parse()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse version: %v", err)
+	}
+
+	// Blame needs the ops of every change, so we can't start from a snapshot.
+	doc, err := srv.loadFullDocument(ctx, acc, in.Path, heads)
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.Visibility() == blob.VisibilityPrivate {
+		if err := srv.denyPrivateDocument(ctx, acc, in.Path); err != nil {
+			return nil, err
+		}
+	}
+
+	blame, err := doc.Blame()
+	if err != nil {
+		return nil, err
+	}
+
+	docpb, err := doc.Hydrate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &documents.DocumentBlame{
+		Version: docpb.Version,
+	}
+
+	var walk func(nodes []*documents.BlockNode)
+	walk = func(nodes []*documents.BlockNode) {
+		for _, n := range nodes {
+			if bb, ok := blame[n.Block.Id]; ok {
+				out.Blocks = append(out.Blocks, blockBlameToProto(n.Block, bb))
+			}
+			walk(n.Children)
+		}
+	}
+	walk(docpb.Content)
+
+	for _, id := range slices.Sorted(maps.Keys(docpb.DetachedBlocks)) {
+		walk([]*documents.BlockNode{docpb.DetachedBlocks[id]})
+	}
+
+	return out, nil
+}
+
+func blockBlameToProto(blk *documents.Block, bb *docmodel.BlockBlame) *documents.BlockBlame {
+	out := &documents.BlockBlame{
+		BlockId:  blk.Id,
+		Revision: blk.Revision,
+		Content:  attributionToProto(bb.Content),
+		Position: attributionToProto(bb.Position),
+	}
+
+	for _, attr := range bb.Attributes {
+		out.Attributes = append(out.Attributes, &documents.AttributeBlame{
+			Key:         attr.Key,
+			Attribution: attributionToProto(attr.Attribution),
+		})
+	}
+
+	for _, r := range bb.Text {
+		out.Text = append(out.Text, &documents.TextBlame{
+			Start:       int32(r.Start), //nolint:gosec // Block text can't be that long.
+			End:         int32(r.End),   //nolint:gosec // Block text can't be that long.
+			Attribution: attributionToProto(r.Attribution),
+		})
+	}
+
+	return out
+}
+
+func attributionToProto(a docmodel.Attribution) *documents.Attribution {
+	if !a.Change.Defined() {
+		return nil
+	}
+
+	return &documents.Attribution{
+		ChangeId:   a.Change.String(),
+		Author:     a.Author.String(),
+		CreateTime: timestamppb.New(a.Time),
+	}
+}
@@ -0,0 +1,82 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/api/apitest"
+	pb "seed/backend/genproto/documents/v3alpha"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDocumentBlame(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+	account := alice.me.Account.Principal()
+
+	v1, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, "/blame", "", "main").
+		SetMetadata("title", "Blame").
+		MoveBlock("b1", "", "").
+		ReplaceBlock("b1", "paragraph", "Hello world").
+		MoveBlock("b2", "", "b1").
+		ReplaceBlock("b2", "paragraph", "Second").
+		Build(),
+	)
+	require.NoError(t, err)
+
+	v2, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, "/blame", v1.Version, "main").
+		ReplaceBlock("b1", "heading", "Hello, world").
+		MoveBlock("b2", "", "").
+		Build(),
+	)
+	require.NoError(t, err)
+
+	changes, err := alice.ListDocumentChanges(ctx, &pb.ListDocumentChangesRequest{Account: v2.Account, Path: v2.Path, Version: v2.Version})
+	require.NoError(t, err)
+	require.Len(t, changes.Changes, 2)
+	c2, c1 := changes.Changes[0], changes.Changes[1]
+
+	blame, err := alice.GetDocumentBlame(ctx, &pb.GetDocumentBlameRequest{Account: v2.Account, Path: v2.Path})
+	require.NoError(t, err)
+	require.Equal(t, v2.Version, blame.Version)
+	require.Len(t, blame.Blocks, 2)
+
+	b2, b1 := blame.Blocks[0], blame.Blocks[1]
+	require.Equal(t, "b2", b2.BlockId)
+	require.Equal(t, c1.Id, b2.Content.ChangeId)
+	require.Equal(t, c2.Id, b2.Position.ChangeId)
+
+	require.Equal(t, "b1", b1.BlockId)
+	require.Equal(t, v2.Content[1].Block.Revision, b1.Revision)
+	require.Equal(t, c2.Id, b1.Content.ChangeId)
+	require.Equal(t, c1.Id, b1.Position.ChangeId)
+	require.Equal(t, account.String(), b1.Content.Author)
+	require.Equal(t, c2.CreateTime.AsTime(), b1.Content.CreateTime.AsTime())
+
+	require.Len(t, b1.Attributes, 1)
+	require.Equal(t, []string{"type"}, b1.Attributes[0].Key)
+	require.Equal(t, c2.Id, b1.Attributes[0].Attribution.ChangeId)
+
+	type textRange struct {
+		Start, End int32
+		Change     string
+	}
+	var ranges []textRange
+	for _, r := range b1.Text {
+		ranges = append(ranges, textRange{r.Start, r.End, r.Attribution.ChangeId})
+	}
+	require.Equal(t, []textRange{{0, 5, c1.Id}, {5, 6, c2.Id}, {6, 12, c1.Id}}, ranges)
+
+	// Older versions are attributed only to older changes.
+	blame, err = alice.GetDocumentBlame(ctx, &pb.GetDocumentBlameRequest{Account: v1.Account, Path: v1.Path, Version: v1.Version})
+	require.NoError(t, err)
+	require.Equal(t, v1.Version, blame.Version)
+	require.Len(t, blame.Blocks, 2)
+	for _, bb := range blame.Blocks {
+		require.Equal(t, c1.Id, bb.Content.ChangeId)
+		require.Equal(t, c1.Id, bb.Position.ChangeId)
+		require.Len(t, bb.Text, 1)
+	}
+}
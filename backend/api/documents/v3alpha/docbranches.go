@@ -0,0 +1,390 @@
+package documents
+
+import (
+	"context"
+	"maps"
+	"seed/backend/api/documents/v3alpha/docmodel"
+	"seed/backend/blob"
+	"seed/backend/core"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/cclock"
+	"seed/backend/util/dqb"
+	"seed/backend/util/errutil"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"slices"
+
+	"github.com/ipfs/go-cid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateBranch implements Documents API v3.
+func (srv *Server) CreateBranch(ctx context.Context, in *documents.CreateBranchRequest) (*documents.Branch, error) {
+	{
+		if in.Account == "" {
+			return nil, errutil.MissingArgument("account")
+		}
+
+		if in.SigningKeyName == "" {
+			return nil, errutil.MissingArgument("signing_key_name")
+		}
+
+		if err := blob.ValidateBranchName(in.Branch); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+
+	ns, err := core.DecodePrincipal(in.Account)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse account '%s': %v", in.Account, err)
+	}
+
+	heads, err := docmodel.Version(in.Version).Parse()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse version: %v", err)
+	}
+
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := srv.checkBranchWriteAccess(ctx, ns, in.Path, kp); err != nil {
+		return nil, err
+	}
+
+	if len(heads) == 0 {
+		iri, err := makeIRI(ns, in.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		state, err := srv.idx.ResolveLatest(ctx, iri)
+		if err != nil {
+			return nil, err
+		}
+		heads = state.Heads
+	}
+
+	visibility := blob.VisibilityPublic
+	if in.Visibility == documents.ResourceVisibility_RESOURCE_VISIBILITY_PRIVATE {
+		visibility = blob.VisibilityPrivate
+	}
+
+	// Loading the document makes sure the version exists, and gives us its genesis.
+	// Versions that no Ref points to yet can't be loaded, because nobody could see them so far.
+	// Those are the new changes of the branch, so we only make sure they belong to the document.
+	var genesis cid.Cid
+	doc, err := srv.loadDocument(ctx, ns, in.Path, heads, false)
+	switch {
+	case status.Code(err) == codes.NotFound:
+		info, err := srv.loadDocumentInfo(ctx, ns, in.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		genesis, err = srv.idx.VersionGenesis(ctx, heads)
+		if err != nil {
+			return nil, err
+		}
+
+		if info.Genesis != genesis.String() {
+			return nil, status.Errorf(codes.InvalidArgument, "version '%s' doesn't belong to the document", in.Version)
+		}
+
+		heads = docmodel.SortCIDs(heads)
+	case err != nil:
+		return nil, err
+	default:
+		// Branching off a private version must not make it public.
+		if doc.Visibility() == blob.VisibilityPrivate {
+			if err := srv.denyPrivateDocument(ctx, ns, in.Path); err != nil {
+				return nil, err
+			}
+			visibility = blob.VisibilityPrivate
+		}
+
+		genesis = doc.Genesis()
+		heads = docmodel.SortCIDs(slices.Collect(maps.Keys(doc.Heads())))
+	}
+
+	ref, err := blob.NewBranchRef(kp, genesis, ns, in.Path, in.Branch, heads, cclock.New().MustNow(), visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := srv.idx.Put(ctx, ref); err != nil {
+		return nil, err
+	}
+
+	return branchToProto(ref.CID, ref.Decoded), nil
+}
+
+// ListBranches implements Documents API v3.
+func (srv *Server) ListBranches(ctx context.Context, in *documents.ListBranchesRequest) (*documents.ListBranchesResponse, error) {
+	if in.Account == "" {
+		return nil, errutil.MissingArgument("account")
+	}
+
+	ns, err := core.DecodePrincipal(in.Account)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse account '%s': %v", in.Account, err)
+	}
+
+	branches, err := srv.loadBranches(ctx, ns, in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &documents.ListBranchesResponse{Branches: make([]*documents.Branch, 0, len(branches))}
+	for _, ref := range branches {
+		out.Branches = append(out.Branches, branchToProto(ref.CID, ref.Value))
+	}
+
+	return out, nil
+}
+
+// DiffBranch implements Documents API v3.
+func (srv *Server) DiffBranch(ctx context.Context, in *documents.DiffBranchRequest) (*documents.DiffDocumentResponse, error) {
+	if in.Account == "" {
+		return nil, errutil.MissingArgument("account")
+	}
+
+	if in.Branch == "" {
+		return nil, errutil.MissingArgument("branch")
+	}
+
+	ns, err := core.DecodePrincipal(in.Account)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse account '%s': %v", in.Account, err)
+	}
+
+	branch, err := srv.resolveBranch(ctx, ns, in.Path, in.Branch)
+	if err != nil {
+		return nil, err
+	}
+
+	iri, err := makeIRI(ns, in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := srv.idx.ResolveLatest(ctx, iri)
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.DiffDocument(ctx, &documents.DiffDocumentRequest{
+		Account:       in.Account,
+		Path:          in.Path,
+		BaseVersion:   blob.NewVersion(state.Heads...).String(),
+		TargetVersion: blob.NewVersion(branch.Value.Heads...).String(),
+	})
+}
+
+// MergeBranch implements Documents API v3.
+func (srv *Server) MergeBranch(ctx context.Context, in *documents.MergeBranchRequest) (*documents.Ref, error) {
+	{
+		if in.Account == "" {
+			return nil, errutil.MissingArgument("account")
+		}
+
+		if in.Branch == "" {
+			return nil, errutil.MissingArgument("branch")
+		}
+
+		if in.SigningKeyName == "" {
+			return nil, errutil.MissingArgument("signing_key_name")
+		}
+	}
+
+	ns, err := core.DecodePrincipal(in.Account)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse account '%s': %v", in.Account, err)
+	}
+
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Merging publishes a new version, so the same rules as in CreateRef apply.
+	org, isOrg, err := srv.idx.GetOrgMembership(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	isOrgRoot := isOrg && in.Path == ""
+
+	if isOrgRoot {
+		if !org.IsMember(kp.Principal()) {
+			return nil, status.Errorf(codes.PermissionDenied, "key '%s' is not a member of organization '%s'", kp.Principal(), ns)
+		}
+	} else if err := srv.checkWriteAccess(ctx, ns, in.Path, kp); err != nil {
+		return nil, err
+	}
+
+	branch, err := srv.resolveBranch(ctx, ns, in.Path, in.Branch)
+	if err != nil {
+		return nil, err
+	}
+
+	iri, err := makeIRI(ns, in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := srv.idx.ResolveLatest(ctx, iri)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := srv.loadDocumentInfo(ctx, ns, in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Genesis != branch.Value.GenesisBlob.String() {
+		return nil, status.Errorf(codes.FailedPrecondition, "branch '%s' belongs to a different generation of the document", in.Branch)
+	}
+
+	// Loading the document with both sets of heads gives us the heads of the merged version,
+	// without the ones that are already ancestors of the other side.
+	doc, err := srv.loadDocument(ctx, ns, in.Path, unionHeads(state.Heads, branch.Value.Heads), false)
+	if err != nil {
+		return nil, err
+	}
+
+	heads := docmodel.SortCIDs(slices.Collect(maps.Keys(doc.Heads())))
+	if blob.NewVersion(heads...) == blob.NewVersion(state.Heads...) {
+		return nil, status.Errorf(codes.FailedPrecondition, "branch '%s' has no changes that are not published yet", in.Branch)
+	}
+
+	ref, err := blob.NewRef(kp, state.Generation, branch.Value.GenesisBlob, ns, in.Path, heads, cclock.New().MustNow(), state.Visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := srv.publishRef(ctx, ref, org, isOrgRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isOrgRoot {
+		srv.scheduleSnapshot(kp, ns, in.Path)
+	}
+
+	return out, nil
+}
+
+// checkBranchWriteAccess checks whether the key can maintain branches of the document.
+// Branches don't change the published version, so any member of an organization can do it for the root document.
+func (srv *Server) checkBranchWriteAccess(ctx context.Context, ns core.Principal, path string, kp *core.KeyPair) error {
+	org, isOrg, err := srv.idx.GetOrgMembership(ctx, ns)
+	if err != nil {
+		return err
+	}
+
+	if isOrg && path == "" {
+		if !org.IsMember(kp.Principal()) {
+			return status.Errorf(codes.PermissionDenied, "key '%s' is not a member of organization '%s'", kp.Principal(), ns)
+		}
+		return nil
+	}
+
+	return srv.checkWriteAccess(ctx, ns, path, kp)
+}
+
+// resolveBranch finds the latest Ref of the branch.
+func (srv *Server) resolveBranch(ctx context.Context, ns core.Principal, path, name string) (blob.WithCID[*blob.Ref], error) {
+	branches, err := srv.loadBranches(ctx, ns, path)
+	if err != nil {
+		return blob.WithCID[*blob.Ref]{}, err
+	}
+
+	for _, b := range branches {
+		if b.Value.Branch == name {
+			return b, nil
+		}
+	}
+
+	return blob.WithCID[*blob.Ref]{}, status.Errorf(codes.NotFound, "branch '%s' not found", name)
+}
+
+// loadBranches returns the latest Ref of each branch of the document sorted by name.
+// Private branches are skipped when the caller can't access private documents of the account.
+func (srv *Server) loadBranches(ctx context.Context, ns core.Principal, path string) ([]blob.WithCID[*blob.Ref], error) {
+	iri, err := blob.NewIRI(ns, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var refCIDs []cid.Cid
+	if err := srv.db.WithSave(ctx, func(conn *sqlite.Conn) (err error) {
+		rows, discard, check := sqlitex.Query(conn, qListBranchRefs(), iri).All()
+		defer discard(&err)
+
+		for row := range rows {
+			codec := row.ColumnInt64(0)
+			hash := row.ColumnBytes(1)
+			refCIDs = append(refCIDs, cid.NewCidV1(uint64(codec), hash))
+		}
+
+		return check()
+	}); err != nil {
+		return nil, err
+	}
+
+	var (
+		out  []blob.WithCID[*blob.Ref]
+		seen = make(map[string]struct{})
+	)
+	for _, c := range refCIDs {
+		ref, err := srv.getRef(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := seen[ref.Value.Branch]; ok {
+			continue
+		}
+		seen[ref.Value.Branch] = struct{}{}
+
+		if ref.Value.Visibility == blob.VisibilityPrivate {
+			if err := srv.denyPrivateDocument(ctx, ns, path); err != nil {
+				continue
+			}
+		}
+
+		out = append(out, ref)
+	}
+
+	return out, nil
+}
+
+// The latest Ref of each branch comes first.
+var qListBranchRefs = dqb.Str(`
+	SELECT b.codec, b.multihash
+	FROM structural_blobs sb
+	JOIN resources r ON r.id = sb.resource
+	JOIN blobs b ON b.id = sb.id
+	WHERE sb.type = 'Ref'
+	AND r.iri = ?
+	AND sb.extra_attrs->>'branch' IS NOT NULL
+	ORDER BY sb.extra_attrs->>'branch', sb.ts DESC, sb.id DESC
+`)
+
+func branchToProto(c cid.Cid, ref *blob.Ref) *documents.Branch {
+	return &documents.Branch{
+		Name:       ref.Branch,
+		Account:    ref.Space().String(),
+		Path:       ref.Path,
+		Genesis:    ref.GenesisBlob.String(),
+		Version:    blob.NewVersion(ref.Heads...).String(),
+		Author:     ref.Signer.String(),
+		UpdateTime: timestamppb.New(ref.Ts),
+		Visibility: docmodel.VisibilityToProto(ref.Visibility),
+		RefId:      c.String(),
+	}
+}
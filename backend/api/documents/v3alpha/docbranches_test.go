@@ -0,0 +1,180 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/api/apitest"
+	"seed/backend/api/documents/v3alpha/docmodel"
+	"seed/backend/config"
+	pb "seed/backend/genproto/documents/v3alpha"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestDocumentBranches(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+	account := alice.me.Account.Principal()
+
+	v1, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, "/branches", "", "main").
+		SetMetadata("title", "Doc").
+		MoveBlock("b1", "", "").
+		ReplaceBlock("b1", "paragraph", "Hello").
+		Build(),
+	)
+	require.NoError(t, err)
+
+	draft, err := alice.CreateBranch(ctx, &pb.CreateBranchRequest{
+		Account:        v1.Account,
+		Path:           v1.Path,
+		Branch:         "draft",
+		SigningKeyName: "main",
+		Visibility:     pb.ResourceVisibility_RESOURCE_VISIBILITY_PRIVATE,
+	})
+	require.NoError(t, err)
+	require.Equal(t, v1.Version, draft.Version, "new branch must start from the published version")
+	require.Equal(t, pb.ResourceVisibility_RESOURCE_VISIBILITY_PRIVATE, draft.Visibility)
+
+	// Changes for the branch are created on top of the branch version, without publishing them.
+	heads, err := docmodel.Version(draft.Version).Parse()
+	require.NoError(t, err)
+	doc, err := alice.loadDocument(ctx, account, v1.Path, heads, false)
+	require.NoError(t, err)
+	require.NoError(t, doc.SetMetadata("title", "Draft"))
+	change, err := doc.SignChange(alice.me.Account)
+	require.NoError(t, err)
+	require.NoError(t, alice.idx.Put(ctx, change))
+
+	draft, err = alice.CreateBranch(ctx, &pb.CreateBranchRequest{
+		Account:        v1.Account,
+		Path:           v1.Path,
+		Branch:         "draft",
+		SigningKeyName: "main",
+		Version:        change.CID.String(),
+		Visibility:     pb.ResourceVisibility_RESOURCE_VISIBILITY_PRIVATE,
+	})
+	require.NoError(t, err)
+	require.Equal(t, change.CID.String(), draft.Version)
+
+	// The published version keeps moving concurrently.
+	v2, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, "/branches", v1.Version, "main").
+		ReplaceBlock("b1", "paragraph", "Hello from main").
+		Build(),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "Doc", v2.Metadata.Fields["title"].GetStringValue(), "branch must not change the published version")
+
+	list, err := alice.ListBranches(ctx, &pb.ListBranchesRequest{Account: v1.Account, Path: v1.Path})
+	require.NoError(t, err)
+	require.Len(t, list.Branches, 1, "only the latest Ref of each branch must be listed")
+	require.Equal(t, "draft", list.Branches[0].Name)
+	require.Equal(t, draft.Version, list.Branches[0].Version)
+	require.Equal(t, draft.RefId, list.Branches[0].RefId)
+
+	diff, err := alice.DiffBranch(ctx, &pb.DiffBranchRequest{Account: v1.Account, Path: v1.Path, Branch: "draft"})
+	require.NoError(t, err)
+	require.Equal(t, v2.Version, diff.BaseVersion)
+	require.Equal(t, draft.Version, diff.TargetVersion)
+	requireAttributeDiffs(t, []*pb.AttributeDiff{
+		{Key: []string{"title"}, BaseValue: structpb.NewStringValue("Doc"), TargetValue: structpb.NewStringValue("Draft")},
+	}, diff.Metadata)
+
+	ref, err := alice.MergeBranch(ctx, &pb.MergeBranchRequest{
+		Account:        v1.Account,
+		Path:           v1.Path,
+		Branch:         "draft",
+		SigningKeyName: "main",
+	})
+	require.NoError(t, err)
+	require.Empty(t, ref.Branch)
+	v2Heads, err := docmodel.Version(v2.Version).Parse()
+	require.NoError(t, err)
+	require.Equal(t, docmodel.NewVersion(append(v2Heads, change.CID)...).String(), ref.Target.GetVersion().Version, "merged version must combine both sides")
+
+	merged, err := alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: v1.Account, Path: v1.Path})
+	require.NoError(t, err)
+	require.Equal(t, ref.Target.GetVersion().Version, merged.Version)
+	require.Equal(t, "Draft", merged.Metadata.Fields["title"].GetStringValue())
+	require.Equal(t, "Hello from main", merged.Content[0].Block.Text, "merge must keep the concurrent published changes")
+	require.Equal(t, pb.ResourceVisibility_RESOURCE_VISIBILITY_PUBLIC, merged.Visibility, "merge must keep the visibility of the document")
+
+	_, err = alice.MergeBranch(ctx, &pb.MergeBranchRequest{
+		Account:        v1.Account,
+		Path:           v1.Path,
+		Branch:         "draft",
+		SigningKeyName: "main",
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "merged branch has nothing to publish")
+
+	_, err = alice.CreateBranch(ctx, &pb.CreateBranchRequest{
+		Account:        v1.Account,
+		Path:           v1.Path,
+		Branch:         "main",
+		SigningKeyName: "main",
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "main branch name is reserved")
+
+	_, err = alice.DiffBranch(ctx, &pb.DiffBranchRequest{Account: v1.Account, Path: v1.Path, Branch: "missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestDocumentBranches_PrivateVersions(t *testing.T) {
+	t.Parallel()
+
+	// PublicOnly blocks reads but not writes, so we can set up the branch directly.
+	alice := newTestDocsAPIWithConfig(t, "alice", config.Base{PublicOnly: true})
+	ctx := context.Background()
+	account := alice.me.Account.Principal()
+
+	v1, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, "/public", "", "main").
+		SetMetadata("title", "Public").
+		Build(),
+	)
+	require.NoError(t, err)
+
+	heads, err := docmodel.Version(v1.Version).Parse()
+	require.NoError(t, err)
+	doc, err := alice.loadDocument(ctx, account, v1.Path, heads, false)
+	require.NoError(t, err)
+	require.NoError(t, doc.SetMetadata("title", "Secret draft"))
+	change, err := doc.SignChange(alice.me.Account)
+	require.NoError(t, err)
+	require.NoError(t, alice.idx.Put(ctx, change))
+
+	_, err = alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: v1.Account, Path: v1.Path, Version: change.CID.String()})
+	require.Equal(t, codes.NotFound, status.Code(err), "versions that no Ref points to must not be found")
+
+	_, err = alice.CreateBranch(ctx, &pb.CreateBranchRequest{
+		Account:        v1.Account,
+		Path:           v1.Path,
+		Branch:         "draft",
+		SigningKeyName: "main",
+		Version:        change.CID.String(),
+		Visibility:     pb.ResourceVisibility_RESOURCE_VISIBILITY_PRIVATE,
+	})
+	require.NoError(t, err)
+
+	_, err = alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: v1.Account, Path: v1.Path, Version: change.CID.String()})
+	require.Equal(t, codes.PermissionDenied, status.Code(err), "private branch versions of public documents must not be readable")
+
+	_, err = alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: v1.Account, Path: v1.Path, Version: docmodel.NewVersion(append(heads, change.CID)...).String()})
+	require.Equal(t, codes.PermissionDenied, status.Code(err), "visibility must be checked for every head of the version")
+
+	_, err = alice.CreateBranch(ctx, &pb.CreateBranchRequest{
+		Account:        v1.Account,
+		Path:           v1.Path,
+		Branch:         "leak",
+		SigningKeyName: "main",
+		Version:        change.CID.String(),
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err), "private versions must not be branched off publicly")
+
+	public, err := alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: v1.Account, Path: v1.Path, Version: v1.Version})
+	require.NoError(t, err)
+	require.Equal(t, "Public", public.Metadata.Fields["title"].GetStringValue())
+}
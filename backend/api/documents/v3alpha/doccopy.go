@@ -0,0 +1,239 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/api/documents/v3alpha/docmodel"
+	"seed/backend/blob"
+	"seed/backend/core"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/cclock"
+	"seed/backend/util/errutil"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// copiedFromKey is the metadata attribute of copied documents,
+// that holds the versioned IRI of the document they were copied from.
+const copiedFromKey = "copiedFrom"
+
+// CopyDocument implements Documents API v3.
+func (srv *Server) CopyDocument(ctx context.Context, in *documents.CopyDocumentRequest) (*documents.CopyDocumentResponse, error) {
+	{
+		if in.SourceAccount == "" {
+			return nil, errutil.MissingArgument("source_account")
+		}
+
+		if in.TargetPath == "" {
+			return nil, errutil.MissingArgument("target_path")
+		}
+
+		if in.SigningKeyName == "" {
+			return nil, errutil.MissingArgument("signing_key_name")
+		}
+
+		if in.TargetAccount == "" {
+			in.TargetAccount = in.SourceAccount
+		}
+	}
+
+	sourceNS, err := core.DecodePrincipal(in.SourceAccount)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse source account '%s': %v", in.SourceAccount, err)
+	}
+
+	targetNS, err := core.DecodePrincipal(in.TargetAccount)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse target account '%s': %v", in.TargetAccount, err)
+	}
+
+	heads, err := docmodel.Version(in.SourceVersion).Parse()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse source version: %v", err)
+	}
+
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	type copyItem struct {
+		SourcePath string
+		TargetPath string
+		Source     *docmodel.Document
+	}
+
+	root, err := srv.loadSourceDocument(ctx, sourceNS, in.SourcePath, heads)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []copyItem{{SourcePath: in.SourcePath, TargetPath: in.TargetPath, Source: root}}
+
+	if in.Recursive {
+		var pageToken string
+		for {
+			list, err := srv.ListDirectory(ctx, &documents.ListDirectoryRequest{
+				Account:       in.SourceAccount,
+				DirectoryPath: in.SourcePath,
+				Recursive:     true,
+				SortOptions:   &documents.SortOptions{Attribute: documents.SortAttribute_PATH},
+				PageToken:     pageToken,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, d := range list.Documents {
+				if d.Path == in.SourcePath {
+					continue
+				}
+
+				src, err := srv.loadSourceDocument(ctx, sourceNS, d.Path, nil)
+				if err != nil {
+					return nil, err
+				}
+
+				items = append(items, copyItem{
+					SourcePath: d.Path,
+					TargetPath: in.TargetPath + strings.TrimPrefix(d.Path, in.SourcePath),
+					Source:     src,
+				})
+			}
+
+			if list.NextPageToken == "" {
+				break
+			}
+			pageToken = list.NextPageToken
+		}
+	}
+
+	// We check all the target paths before creating anything, to avoid copying only a part of the tree.
+	for _, it := range items {
+		if err := srv.checkWriteAccess(ctx, targetNS, it.TargetPath, kp); err != nil {
+			return nil, err
+		}
+
+		if err := srv.ensureDocumentAbsent(ctx, targetNS, it.TargetPath); err != nil {
+			return nil, err
+		}
+
+		if it.Source.Visibility() == blob.VisibilityPrivate && strings.Count(it.TargetPath, "/") != 1 {
+			return nil, status.Errorf(codes.InvalidArgument, "private document '%s' can only be copied to a path with a single segment: got %s", it.SourcePath, it.TargetPath)
+		}
+	}
+
+	out := &documents.CopyDocumentResponse{
+		CopiedDocuments: make([]*documents.CopiedDocument, 0, len(items)),
+	}
+
+	for _, it := range items {
+		iri, err := makeIRI(targetNS, it.TargetPath)
+		if err != nil {
+			return nil, err
+		}
+
+		sourceIRI, err := makeIRI(sourceNS, it.SourcePath)
+		if err != nil {
+			return nil, err
+		}
+
+		doc, err := docmodel.New(iri, cclock.New())
+		if err != nil {
+			return nil, err
+		}
+
+		// Reverting an empty document to the source gives us all of its metadata and blocks.
+		if err := doc.Revert(it.Source, nil); err != nil {
+			return nil, err
+		}
+
+		version := it.Source.Version().String()
+		if err := doc.SetMetadata(copiedFromKey, string(sourceIRI)+"?v="+version); err != nil {
+			return nil, err
+		}
+
+		if err := srv.encryptPrivateDocument(ctx, doc, targetNS, it.Source.Visibility()); err != nil {
+			return nil, err
+		}
+
+		change, err := doc.SignChange(kp)
+		if err != nil {
+			return nil, err
+		}
+
+		ref, err := doc.Ref(kp, it.Source.Visibility())
+		if err != nil {
+			return nil, err
+		}
+
+		if err := srv.idx.Put(ctx, change); err != nil {
+			return nil, err
+		}
+
+		refpb, err := srv.publishRef(ctx, ref, blob.OrgMembership{}, false)
+		if err != nil {
+			return nil, err
+		}
+
+		out.CopiedDocuments = append(out.CopiedDocuments, &documents.CopiedDocument{
+			SourcePath:    it.SourcePath,
+			SourceVersion: version,
+			TargetPath:    it.TargetPath,
+			Ref:           refpb,
+		})
+	}
+
+	return out, nil
+}
+
+// loadSourceDocument loads the document used as a source for new documents, making sure the caller can read it.
+// Latest version is loaded when heads are empty.
+func (srv *Server) loadSourceDocument(ctx context.Context, ns core.Principal, path string, heads []cid.Cid) (*docmodel.Document, error) {
+	if len(heads) == 0 {
+		iri, err := makeIRI(ns, path)
+		if err != nil {
+			return nil, err
+		}
+
+		state, err := srv.idx.ResolveLatest(ctx, iri)
+		if err != nil {
+			return nil, err
+		}
+		heads = state.Heads
+	}
+
+	doc, err := srv.loadDocument(ctx, ns, path, heads, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.Visibility() == blob.VisibilityPrivate {
+		if err := srv.denyPrivateDocument(ctx, ns, path); err != nil {
+			return nil, err
+		}
+	}
+
+	return doc, nil
+}
+
+// ensureDocumentAbsent checks that a new document can be created at the path,
+// i.e. there's no document there, or it was deleted or redirected.
+func (srv *Server) ensureDocumentAbsent(ctx context.Context, ns core.Principal, path string) error {
+	iri, err := makeIRI(ns, path)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid path: %v", err)
+	}
+
+	_, err = srv.idx.ResolveLatest(ctx, iri)
+	switch status.Code(err) {
+	case codes.OK:
+		return status.Errorf(codes.FailedPrecondition, "document '%s' already exists", path)
+	case codes.NotFound, codes.FailedPrecondition:
+		return nil
+	default:
+		return err
+	}
+}
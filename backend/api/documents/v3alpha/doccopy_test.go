@@ -0,0 +1,91 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/api/apitest"
+	pb "seed/backend/genproto/documents/v3alpha"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCopyDocument(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+	account := alice.me.Account.Principal()
+	space := "hm://" + account.String()
+
+	publish := func(path, base, title, text string) *pb.Document {
+		t.Helper()
+		cb := apitest.NewChangeBuilder(account, path, base, "main")
+		if base == "" {
+			cb.MoveBlock("b1", "", "")
+		}
+		doc, err := alice.PublishDocumentChangeForTest(ctx, cb.
+			SetMetadata("title", title).
+			ReplaceBlock("b1", "paragraph", text).
+			Build(),
+		)
+		require.NoError(t, err)
+		return doc
+	}
+
+	v1 := publish("/src", "", "Source", "Hello")
+	publish("/src", v1.Version, "Source", "Hello, edited")
+	child := publish("/src/child", "", "Child", "Nested")
+	publish("/src/child/deep", "", "Deep", "Deeper")
+
+	single, err := alice.CopyDocument(ctx, &pb.CopyDocumentRequest{
+		SourceAccount:  v1.Account,
+		SourcePath:     "/src",
+		SourceVersion:  v1.Version,
+		TargetPath:     "/single",
+		SigningKeyName: "main",
+	})
+	require.NoError(t, err)
+	require.Len(t, single.CopiedDocuments, 1, "children must only be copied when requested")
+
+	copied, err := alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: v1.Account, Path: "/single"})
+	require.NoError(t, err)
+	require.NotEqual(t, v1.Genesis, copied.Genesis, "copy must be a new document")
+	require.Equal(t, "Source", copied.Metadata.Fields["title"].GetStringValue())
+	require.Equal(t, space+"/src?v="+v1.Version, copied.Metadata.Fields["copiedFrom"].GetStringValue())
+	require.Equal(t, blockTexts(v1.Content), blockTexts(copied.Content), "copy must have the content of the requested version")
+	require.Equal(t, []string{account.String()}, copied.Authors)
+
+	tree, err := alice.CopyDocument(ctx, &pb.CopyDocumentRequest{
+		SourceAccount:  v1.Account,
+		SourcePath:     "/src",
+		TargetPath:     "/tree",
+		SigningKeyName: "main",
+		Recursive:      true,
+	})
+	require.NoError(t, err)
+	require.Len(t, tree.CopiedDocuments, 3)
+	for i, want := range [][2]string{{"/src", "/tree"}, {"/src/child", "/tree/child"}, {"/src/child/deep", "/tree/child/deep"}} {
+		require.Equal(t, want[0], tree.CopiedDocuments[i].SourcePath)
+		require.Equal(t, want[1], tree.CopiedDocuments[i].TargetPath)
+		require.Equal(t, want[1], tree.CopiedDocuments[i].Ref.Path)
+	}
+	require.Equal(t, child.Version, tree.CopiedDocuments[1].SourceVersion, "children must be copied at their latest versions")
+
+	info, err := alice.GetDocumentInfo(ctx, &pb.GetDocumentInfoRequest{Account: v1.Account, Path: "/tree/child"})
+	require.NoError(t, err)
+	require.Equal(t, space+"/src/child?v="+child.Version, info.Metadata.Fields["copiedFrom"].GetStringValue())
+
+	root, err := alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: v1.Account, Path: "/tree"})
+	require.NoError(t, err)
+	require.Equal(t, "Hello, edited", root.Content[0].Block.Text)
+
+	_, err = alice.CopyDocument(ctx, &pb.CopyDocumentRequest{
+		SourceAccount:  v1.Account,
+		SourcePath:     "/src",
+		TargetPath:     "/tree",
+		SigningKeyName: "main",
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "existing documents must not be overwritten")
+}
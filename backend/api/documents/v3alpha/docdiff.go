@@ -0,0 +1,336 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/api/documents/v3alpha/docmodel"
+	"seed/backend/blob"
+	"seed/backend/core"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/errutil"
+	"seed/backend/util/seqdiff"
+	"slices"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DiffDocument implements Documents API v3.
+func (srv *Server) DiffDocument(ctx context.Context, in *documents.DiffDocumentRequest) (*documents.DiffDocumentResponse, error) {
+	var (
+		acc core.Principal
+		err error
+	)
+	{
+		acc, err = core.DecodePrincipal(in.Account)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse account '%s': %v", in.Account, err)
+		}
+
+		if in.BaseVersion == "" {
+			return nil, errutil.MissingArgument("base_version")
+		}
+	}
+
+	baseHeads, err := docmodel.Version(in.BaseVersion).Parse()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse base version: %v", err)
+	}
+
+	targetHeads, err := docmodel.Version(in.TargetVersion).Parse()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse target version: %v", err)
+	}
+
+	if len(targetHeads) == 0 {
+		iri, err := makeIRI(acc, in.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		state, err := srv.idx.ResolveLatest(ctx, iri)
+		if err != nil {
+			return nil, err
+		}
+		targetHeads = state.Heads
+	}
+
+	// We load the document with both versions, so that we can check out each of them.
+	// The versions may be concurrent, so neither of them has to include the other one.
+	// Checkout can't go back past a snapshot, so we replay the entire history here.
+	doc, err := srv.loadFullDocument(ctx, acc, in.Path, unionHeads(baseHeads, targetHeads))
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.Visibility() == blob.VisibilityPrivate {
+		if err := srv.denyPrivateDocument(ctx, acc, in.Path); err != nil {
+			return nil, err
+		}
+	}
+
+	baseDoc, err := doc.Checkout(baseHeads)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to check out base version: %v", err)
+	}
+
+	targetDoc, err := doc.Checkout(targetHeads)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to check out target version: %v", err)
+	}
+
+	base, err := baseDoc.Hydrate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := targetDoc.Hydrate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &documents.DiffDocumentResponse{
+		BaseVersion:   base.Version,
+		TargetVersion: target.Version,
+		Metadata:      diffStructs(nil, base.Metadata, target.Metadata),
+		Blocks:        diffBlocks(base.Content, target.Content),
+	}, nil
+}
+
+func unionHeads(a, b []cid.Cid) []cid.Cid {
+	out := make([]cid.Cid, 0, len(a)+len(b))
+	for _, c := range slices.Concat(a, b) {
+		if !slices.Contains(out, c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// blockPlacement is a block with its position in the document tree.
+type blockPlacement struct {
+	Block  *documents.Block
+	Parent string
+	Index  int
+}
+
+// flattenBlocks returns the blocks of the tree in depth-first order,
+// and the position of each block by its ID.
+func flattenBlocks(content []*documents.BlockNode) (order []string, placements map[string]blockPlacement) {
+	placements = make(map[string]blockPlacement)
+
+	var walk func(parent string, nodes []*documents.BlockNode)
+	walk = func(parent string, nodes []*documents.BlockNode) {
+		for i, n := range nodes {
+			id := n.Block.GetId()
+			order = append(order, id)
+			placements[id] = blockPlacement{Block: n.Block, Parent: parent, Index: i}
+			walk(id, n.Children)
+		}
+	}
+	walk("", content)
+
+	return order, placements
+}
+
+func diffBlocks(baseContent, targetContent []*documents.BlockNode) []*documents.BlockDiff {
+	baseOrder, base := flattenBlocks(baseContent)
+	targetOrder, target := flattenBlocks(targetContent)
+
+	moved := movedBlocks(baseOrder, base, targetOrder, target)
+
+	var out []*documents.BlockDiff
+	for _, id := range targetOrder {
+		tp := target[id]
+		bp, ok := base[id]
+		if !ok {
+			out = append(out, &documents.BlockDiff{
+				BlockId:      id,
+				Kind:         documents.BlockDiffKind_BLOCK_DIFF_KIND_ADDED,
+				TargetBlock:  tp.Block,
+				TargetParent: tp.Parent,
+				TargetIndex:  int32(tp.Index), //nolint:gosec // Document can't have that many blocks.
+			})
+			continue
+		}
+
+		d := &documents.BlockDiff{
+			BlockId:      id,
+			Kind:         documents.BlockDiffKind_BLOCK_DIFF_KIND_CHANGED,
+			BaseBlock:    bp.Block,
+			TargetBlock:  tp.Block,
+			Moved:        moved[id],
+			BaseParent:   bp.Parent,
+			TargetParent: tp.Parent,
+			BaseIndex:    int32(bp.Index), //nolint:gosec // Document can't have that many blocks.
+			TargetIndex:  int32(tp.Index), //nolint:gosec // Document can't have that many blocks.
+		}
+
+		if bp.Block.Text != tp.Block.Text {
+			d.Text = diffText(bp.Block.Text, tp.Block.Text)
+		}
+
+		if bp.Block.Type != tp.Block.Type {
+			d.Attributes = append(d.Attributes, stringDiff("type", bp.Block.Type, tp.Block.Type))
+		}
+
+		if bp.Block.Link != tp.Block.Link {
+			d.Attributes = append(d.Attributes, stringDiff("link", bp.Block.Link, tp.Block.Link))
+		}
+
+		d.Attributes = append(d.Attributes, diffStructs([]string{"attributes"}, bp.Block.Attributes, tp.Block.Attributes)...)
+
+		d.AnnotationsChanged = !slices.EqualFunc(bp.Block.Annotations, tp.Block.Annotations, func(a, b *documents.Annotation) bool {
+			return proto.Equal(a, b)
+		})
+
+		d.Modified = d.Text != nil || d.Attributes != nil || d.AnnotationsChanged
+
+		if !d.Moved && !d.Modified {
+			continue
+		}
+
+		out = append(out, d)
+	}
+
+	for _, id := range baseOrder {
+		if _, ok := target[id]; ok {
+			continue
+		}
+
+		bp := base[id]
+		out = append(out, &documents.BlockDiff{
+			BlockId:    id,
+			Kind:       documents.BlockDiffKind_BLOCK_DIFF_KIND_REMOVED,
+			BaseBlock:  bp.Block,
+			BaseParent: bp.Parent,
+			BaseIndex:  int32(bp.Index), //nolint:gosec // Document can't have that many blocks.
+		})
+	}
+
+	return out
+}
+
+// movedBlocks finds the blocks that changed their parent, or their order relative to the siblings
+// that stayed under the same parent. Blocks that only shifted because other blocks were added or removed
+// around them are not considered moved.
+func movedBlocks(baseOrder []string, base map[string]blockPlacement, targetOrder []string, target map[string]blockPlacement) map[string]bool {
+	out := make(map[string]bool)
+
+	stayed := func(id string) bool {
+		bp, inBase := base[id]
+		tp, inTarget := target[id]
+		return inBase && inTarget && bp.Parent == tp.Parent
+	}
+
+	siblings := func(order []string, placements map[string]blockPlacement) map[string][]string {
+		out := make(map[string][]string)
+		for _, id := range order {
+			if !stayed(id) {
+				continue
+			}
+			parent := placements[id].Parent
+			out[parent] = append(out[parent], id)
+		}
+		return out
+	}
+
+	baseSiblings := siblings(baseOrder, base)
+	targetSiblings := siblings(targetOrder, target)
+
+	for _, id := range targetOrder {
+		if _, ok := base[id]; ok && !stayed(id) {
+			out[id] = true
+		}
+	}
+
+	for parent, bs := range baseSiblings {
+		for _, op := range seqdiff.Diff(bs, targetSiblings[parent]) {
+			if op.Kind == seqdiff.Insert {
+				out[op.Value] = true
+			}
+		}
+	}
+
+	return out
+}
+
+var textDiffKinds = [...]documents.TextDiffKind{
+	seqdiff.Equal:  documents.TextDiffKind_TEXT_DIFF_KIND_EQUAL,
+	seqdiff.Delete: documents.TextDiffKind_TEXT_DIFF_KIND_DELETED,
+	seqdiff.Insert: documents.TextDiffKind_TEXT_DIFF_KIND_INSERTED,
+}
+
+// diffText returns the character-level difference between two strings.
+func diffText(base, target string) []*documents.TextDiff {
+	var out []*documents.TextDiff
+	for _, op := range seqdiff.Diff([]rune(base), []rune(target)) {
+		kind := textDiffKinds[op.Kind]
+		if len(out) > 0 && out[len(out)-1].Kind == kind {
+			out[len(out)-1].Text += string(op.Value)
+			continue
+		}
+		out = append(out, &documents.TextDiff{Kind: kind, Text: string(op.Value)})
+	}
+	return out
+}
+
+func stringDiff(key, base, target string) *documents.AttributeDiff {
+	d := &documents.AttributeDiff{Key: []string{key}}
+	if base != "" {
+		d.BaseValue = structpb.NewStringValue(base)
+	}
+	if target != "" {
+		d.TargetValue = structpb.NewStringValue(target)
+	}
+	return d
+}
+
+// diffStructs compares the leaf values of two structs.
+// Nested objects are compared field by field, while lists are compared as a whole.
+func diffStructs(prefix []string, base, target *structpb.Struct) []*documents.AttributeDiff {
+	baseLeaves := make(map[string]*documents.AttributeDiff)
+	flattenStruct(prefix, base, func(key []string, v *structpb.Value) {
+		baseLeaves[strings.Join(key, "\x00")] = &documents.AttributeDiff{Key: key, BaseValue: v}
+	})
+
+	var out []*documents.AttributeDiff
+	flattenStruct(prefix, target, func(key []string, v *structpb.Value) {
+		k := strings.Join(key, "\x00")
+		d, ok := baseLeaves[k]
+		if !ok {
+			out = append(out, &documents.AttributeDiff{Key: key, TargetValue: v})
+			return
+		}
+
+		delete(baseLeaves, k)
+		if !proto.Equal(d.BaseValue, v) {
+			d.TargetValue = v
+			out = append(out, d)
+		}
+	})
+
+	for _, d := range baseLeaves {
+		out = append(out, d)
+	}
+
+	slices.SortFunc(out, func(a, b *documents.AttributeDiff) int {
+		return slices.Compare(a.Key, b.Key)
+	})
+
+	return out
+}
+
+func flattenStruct(prefix []string, s *structpb.Struct, fn func(key []string, v *structpb.Value)) {
+	for k, v := range s.GetFields() {
+		key := append(slices.Clone(prefix), k)
+		if sv := v.GetStructValue(); sv != nil && len(sv.Fields) > 0 {
+			flattenStruct(key, sv, fn)
+			continue
+		}
+		fn(key, v)
+	}
+}
@@ -0,0 +1,163 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/api/apitest"
+	pb "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/must"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestDiffDocument(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+	account := alice.me.Account.Principal()
+
+	v1, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, "/diff", "", "main").
+		SetMetadata("title", "Doc").
+		MoveBlock("b1", "", "").
+		ReplaceBlock("b1", "paragraph", "Hello world").
+		MoveBlock("b2", "", "b1").
+		ReplaceBlock("b2", "paragraph", "Second").
+		MoveBlock("b3", "", "b2").
+		ReplaceBlock("b3", "paragraph", "Third").
+		MoveBlock("b5", "", "b3").
+		ReplaceBlock("b5", "paragraph", "Fifth").
+		Build(),
+	)
+	require.NoError(t, err)
+
+	req := apitest.NewChangeBuilder(account, "/diff", v1.Version, "main").
+		SetMetadata("title", "Doc 2").
+		SetMetadata("summary", "About diffs").
+		MoveBlock("b3", "", "").
+		MoveBlock("b4", "b1", "").
+		ReplaceBlock("b4", "paragraph", "Nested").
+		DeleteBlock("b5").
+		Build()
+	req.Changes = append(req.Changes, &pb.DocumentChange{Op: &pb.DocumentChange_ReplaceBlock{ReplaceBlock: &pb.Block{
+		Id:         "b1",
+		Type:       "paragraph",
+		Text:       "Hello, brave world",
+		Attributes: must.Do2(structpb.NewStruct(map[string]any{"level": 2})),
+	}}})
+	v2, err := alice.PublishDocumentChangeForTest(ctx, req)
+	require.NoError(t, err)
+
+	// Snapshots must not prevent us from looking at older versions.
+	alice.snapshotInterval = 1
+	alice.maybeCreateSnapshot(ctx, alice.me.Account, account, "/diff")
+
+	diff, err := alice.DiffDocument(ctx, &pb.DiffDocumentRequest{
+		Account:     v1.Account,
+		Path:        v1.Path,
+		BaseVersion: v1.Version,
+	})
+	require.NoError(t, err)
+	require.Equal(t, v1.Version, diff.BaseVersion)
+	require.Equal(t, v2.Version, diff.TargetVersion, "target must default to the latest version")
+
+	requireAttributeDiffs(t, []*pb.AttributeDiff{
+		{Key: []string{"summary"}, TargetValue: structpb.NewStringValue("About diffs")},
+		{Key: []string{"title"}, BaseValue: structpb.NewStringValue("Doc"), TargetValue: structpb.NewStringValue("Doc 2")},
+	}, diff.Metadata)
+
+	require.Len(t, diff.Blocks, 4, "unchanged blocks must not be reported, even if their index changed")
+
+	b3 := diff.Blocks[0]
+	require.Equal(t, "b3", b3.BlockId)
+	require.Equal(t, pb.BlockDiffKind_BLOCK_DIFF_KIND_CHANGED, b3.Kind)
+	require.True(t, b3.Moved)
+	require.False(t, b3.Modified)
+	require.Equal(t, int32(2), b3.BaseIndex)
+	require.Equal(t, int32(0), b3.TargetIndex)
+
+	b1 := diff.Blocks[1]
+	require.Equal(t, "b1", b1.BlockId)
+	require.Equal(t, pb.BlockDiffKind_BLOCK_DIFF_KIND_CHANGED, b1.Kind)
+	require.False(t, b1.Moved, "b1 only shifted because b3 moved")
+	require.True(t, b1.Modified)
+	require.Equal(t, "Hello[+, brave] world", formatTextDiff(b1.Text))
+	requireAttributeDiffs(t, []*pb.AttributeDiff{
+		{Key: []string{"attributes", "level"}, TargetValue: structpb.NewNumberValue(2)},
+	}, b1.Attributes)
+
+	b4 := diff.Blocks[2]
+	require.Equal(t, "b4", b4.BlockId)
+	require.Equal(t, pb.BlockDiffKind_BLOCK_DIFF_KIND_ADDED, b4.Kind)
+	require.Equal(t, "b1", b4.TargetParent)
+	require.Equal(t, "Nested", b4.TargetBlock.Text)
+
+	b5 := diff.Blocks[3]
+	require.Equal(t, "b5", b5.BlockId)
+	require.Equal(t, pb.BlockDiffKind_BLOCK_DIFF_KIND_REMOVED, b5.Kind)
+	require.Equal(t, "Fifth", b5.BaseBlock.Text)
+
+	// Diffing the other way around.
+	reverse, err := alice.DiffDocument(ctx, &pb.DiffDocumentRequest{
+		Account:       v1.Account,
+		Path:          v1.Path,
+		BaseVersion:   v2.Version,
+		TargetVersion: v1.Version,
+	})
+	require.NoError(t, err)
+	require.Len(t, reverse.Blocks, 4)
+	require.Equal(t, "b1", reverse.Blocks[0].BlockId)
+	require.Equal(t, "Hello[-, brave] world", formatTextDiff(reverse.Blocks[0].Text))
+
+	same, err := alice.DiffDocument(ctx, &pb.DiffDocumentRequest{
+		Account:       v1.Account,
+		Path:          v1.Path,
+		BaseVersion:   v2.Version,
+		TargetVersion: v2.Version,
+	})
+	require.NoError(t, err)
+	require.Empty(t, same.Metadata)
+	require.Empty(t, same.Blocks)
+
+	_, err = alice.DiffDocument(ctx, &pb.DiffDocumentRequest{Account: v1.Account, Path: v1.Path})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "base version is required")
+}
+
+func TestDiffText(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, diffText("", ""))
+	require.Equal(t, "[-k][+s]itt[-e][+i]n[+g]", formatTextDiff(diffText("kitten", "sitting")))
+	require.Equal(t, "Привет, [-мир][+🌍]", formatTextDiff(diffText("Привет, мир", "Привет, 🌍")), "diff must not split multi-byte characters")
+	require.Equal(t, "[+Hello]", formatTextDiff(diffText("", "Hello")))
+}
+
+// formatTextDiff renders the text diff with the deleted and inserted segments marked in brackets.
+func formatTextDiff(diff []*pb.TextDiff) string {
+	var sb strings.Builder
+	for _, d := range diff {
+		switch d.Kind {
+		case pb.TextDiffKind_TEXT_DIFF_KIND_INSERTED:
+			sb.WriteString("[+" + d.Text + "]")
+		case pb.TextDiffKind_TEXT_DIFF_KIND_DELETED:
+			sb.WriteString("[-" + d.Text + "]")
+		default:
+			sb.WriteString(d.Text)
+		}
+	}
+	return sb.String()
+}
+
+func requireAttributeDiffs(t *testing.T, want, got []*pb.AttributeDiff) {
+	t.Helper()
+
+	require.Len(t, got, len(want))
+	for i := range want {
+		require.True(t, proto.Equal(want[i], got[i]), "attribute diff %d doesn't match: want=%v got=%v", i, want[i], got[i])
+	}
+}
@@ -0,0 +1,130 @@
+package docmodel
+
+import (
+	"seed/backend/blob"
+	"seed/backend/util/seqdiff"
+)
+
+// ResolveAnchors finds the content the comment anchors refer to in the current version of the document.
+// Blocks are followed by their IDs, and ranges of text are followed by diffing the text of the block
+// between the version of the anchor and the current version, like blame does.
+// Anchors made against versions which are not part of the document are left unresolved.
+func (dm *Document) ResolveAnchors(anchors []blob.CommentAnchorTarget) []blob.CommentAnchorPosition {
+	out := make([]blob.CommentAnchorPosition, len(anchors))
+	if len(anchors) == 0 {
+		return out
+	}
+
+	cur := newAnchorState(dm)
+	versions := make(map[Version]*anchorState)
+	for i, a := range anchors {
+		v := NewVersion(a.Version...)
+		old, ok := versions[v]
+		if !ok {
+			if doc, err := dm.Checkout(a.Version); err == nil {
+				old = newAnchorState(doc)
+			}
+			versions[v] = old
+		}
+
+		if old == nil {
+			continue
+		}
+
+		out[i] = resolveAnchor(old, cur, a.Anchor)
+	}
+
+	return out
+}
+
+// anchorState is the state of the blocks in some version of the document, needed to resolve anchors.
+type anchorState struct {
+	doc *Document
+
+	// Position of each visible block, i.e. the move op that put it in place.
+	positions map[string]opID
+}
+
+func newAnchorState(dm *Document) *anchorState {
+	tree := dm.crdt.tree.State()
+	st := &anchorState{
+		doc:       dm,
+		positions: make(map[string]opID, len(tree.blocks)),
+	}
+
+	for bp := range tree.DFT("") {
+		st.positions[bp.Child] = tree.blocks[bp.Child].Position
+	}
+
+	return st
+}
+
+func (st *anchorState) text(block string) ([]rune, bool) {
+	if _, ok := st.positions[block]; !ok {
+		return nil, false
+	}
+
+	_, blk, ok := st.doc.crdt.blockState(block)
+	if !ok {
+		return nil, false
+	}
+
+	return []rune(blk.Text), true
+}
+
+func resolveAnchor(old, cur *anchorState, a blob.CommentAnchor) blob.CommentAnchorPosition {
+	orphaned := blob.CommentAnchorPosition{Status: blob.CommentAnchorOrphaned}
+
+	oldText, ok := old.text(a.Block)
+	if !ok {
+		return orphaned
+	}
+
+	newText, ok := cur.text(a.Block)
+	if !ok {
+		return orphaned
+	}
+
+	moved := old.positions[a.Block] != cur.positions[a.Block]
+
+	if a.Start == 0 && a.End == 0 {
+		if moved || string(oldText) != string(newText) {
+			return blob.CommentAnchorPosition{Status: blob.CommentAnchorMoved}
+		}
+		return blob.CommentAnchorPosition{Status: blob.CommentAnchorValid}
+	}
+
+	start, end := min(a.Start, len(oldText)), min(a.End, len(oldText))
+
+	// The anchored range in the current text spans from the first to the last anchored character that survived.
+	newStart, newEnd := -1, -1
+	var i, j int
+	for _, op := range seqdiff.Diff(oldText, newText) {
+		switch op.Kind {
+		case seqdiff.Equal:
+			if i >= start && i < end {
+				if newStart < 0 {
+					newStart = j
+				}
+				newEnd = j + 1
+			}
+			i++
+			j++
+		case seqdiff.Delete:
+			i++
+		case seqdiff.Insert:
+			j++
+		}
+	}
+
+	if newStart < 0 {
+		return orphaned
+	}
+
+	pos := blob.CommentAnchorPosition{Status: blob.CommentAnchorValid, Start: newStart, End: newEnd}
+	if moved || newStart != start || newEnd != end || string(oldText[start:end]) != string(newText[newStart:newEnd]) {
+		pos.Status = blob.CommentAnchorMoved
+	}
+
+	return pos
+}
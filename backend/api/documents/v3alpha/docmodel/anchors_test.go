@@ -0,0 +1,63 @@
+package docmodel
+
+import (
+	"seed/backend/blob"
+	"seed/backend/core/coretest"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/cclock"
+	"seed/backend/util/must"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveAnchors(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	iri := must.Do2(blob.NewIRI(alice.Principal(), "/doc"))
+
+	load := func(changes ...blob.Encoded[*blob.Change]) *Document {
+		doc := must.Do2(New(iri, cclock.New()))
+		for _, c := range changes {
+			must.Do(doc.ApplyChange(c.CID, c.Decoded))
+		}
+		return doc
+	}
+
+	doc := load()
+	must.Do(doc.MoveBlock("p1", "", ""))
+	must.Do(doc.ReplaceBlock(&documents.Block{Id: "p1", Type: "Paragraph", Text: "Hello world"}))
+	must.Do(doc.MoveBlock("p2", "", "p1"))
+	must.Do(doc.ReplaceBlock(&documents.Block{Id: "p2", Type: "Paragraph", Text: "Second"}))
+	c1 := must.Do2(doc.SignChange(alice))
+
+	doc = load(c1)
+	must.Do(doc.SpliceText("p1", 5, 0, ","))
+	must.Do(doc.MoveBlock("p2", "", ""))
+	c2 := must.Do2(doc.SignChangeAt(alice, c1.Decoded.Ts.Add(time.Second)))
+
+	// A change that is not part of the resolved version.
+	doc = load(c1)
+	must.Do(doc.SetMetadata("title", "Other branch"))
+	other := must.Do2(doc.SignChangeAt(alice, c1.Decoded.Ts.Add(2*time.Second)))
+
+	v1 := []cid.Cid{c1.CID}
+	got := load(c1, c2).ResolveAnchors([]blob.CommentAnchorTarget{
+		{Version: v1, Anchor: blob.CommentAnchor{Block: "p1", Start: 0, End: 5}},
+		{Version: v1, Anchor: blob.CommentAnchor{Block: "p1", Start: 6, End: 11}},
+		{Version: v1, Anchor: blob.CommentAnchor{Block: "p2"}},
+		{Version: v1, Anchor: blob.CommentAnchor{Block: "missing"}},
+		{Version: []cid.Cid{c2.CID}, Anchor: blob.CommentAnchor{Block: "p2"}},
+		{Version: []cid.Cid{other.CID}, Anchor: blob.CommentAnchor{Block: "p1"}},
+	})
+
+	require.Equal(t, []blob.CommentAnchorPosition{
+		{Status: blob.CommentAnchorValid, Start: 0, End: 5},
+		{Status: blob.CommentAnchorMoved, Start: 7, End: 12},
+		{Status: blob.CommentAnchorMoved},
+		{Status: blob.CommentAnchorOrphaned},
+		{Status: blob.CommentAnchorValid},
+		{},
+	}, got)
+}
@@ -0,0 +1,225 @@
+package docmodel
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"seed/backend/blob"
+	"seed/backend/core"
+	"seed/backend/util/cclock"
+	"seed/backend/util/seqdiff"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ipfs/go-cid"
+)
+
+// Attribution identifies the change that produced some part of the document.
+type Attribution struct {
+	Change cid.Cid
+	Author core.Principal
+	Time   time.Time
+}
+
+// AttributeBlame attributes a single attribute of a block.
+// Type and link of the block use top-level keys, and the other attributes are nested under the "attributes" key.
+type AttributeBlame struct {
+	Key []string
+	Attribution
+}
+
+// TextBlame attributes a range of the block text.
+// Offsets are in Unicode code points, like annotation ranges.
+type TextBlame struct {
+	Start int
+	End   int
+	Attribution
+}
+
+// BlockBlame attributes the current state of a block to the changes that produced it.
+type BlockBlame struct {
+	// Content is the change that set the current state of the block, i.e. its revision.
+	Content Attribution
+
+	// Position is the change that last moved the block.
+	// Zero for detached blocks which were never moved.
+	Position Attribution
+
+	// Attributes are sorted by key.
+	Attributes []AttributeBlame
+
+	// Text ranges are contiguous, and cover the entire text of the block.
+	Text []TextBlame
+}
+
+// blockHistory is the state of a block as of the last change that touched it while replaying the document.
+type blockHistory struct {
+	block blob.Block
+	attrs map[string]attributeState // Joined key -> attribute.
+	text  []Attribution             // Attribution of each character.
+}
+
+type attributeState struct {
+	key   []string
+	value any
+	attr  Attribution
+}
+
+// Blame attributes the content, position, attributes and text of each block to the changes that last touched them.
+// To attribute attributes and text the changes are replayed in order, diffing the successive states of each block,
+// so the text is attributed per character range, even when the whole block was replaced.
+// It needs the ops of all the changes, so it doesn't work for documents restored from a snapshot.
+func (dm *Document) Blame() (map[string]*BlockBlame, error) {
+	e := dm.crdt
+
+	if e.compacted > 0 {
+		return nil, fmt.Errorf("can't blame a document restored from a snapshot: compacted changes don't have their ops")
+	}
+
+	attributions := make(map[cid.Cid]Attribution, len(e.cids))
+	for i, c := range e.cids {
+		attributions[c] = Attribution{Change: c, Author: e.changes[i].Signer, Time: e.changes[i].Ts}
+	}
+
+	replay, err := New(e.id, cclock.New())
+	if err != nil {
+		return nil, err
+	}
+
+	history := make(map[string]*blockHistory)
+	for i, c := range e.cids {
+		ch := e.changes[i]
+		if err := replay.ApplyChange(c, ch); err != nil {
+			return nil, fmt.Errorf("failed to replay change %s: %w", c, err)
+		}
+
+		touched := make(map[string]struct{})
+		for op, err := range ch.Ops() {
+			if err != nil {
+				return nil, err
+			}
+
+			switch op := op.(type) {
+			case blob.OpReplaceBlock:
+				touched[op.Block.ID()] = struct{}{}
+			case blob.OpSpliceText:
+				touched[op.Block] = struct{}{}
+			}
+		}
+
+		for id := range touched {
+			_, blk, ok := replay.crdt.blockState(id)
+			if !ok {
+				continue
+			}
+
+			h := history[id]
+			if h == nil {
+				h = &blockHistory{}
+				history[id] = h
+			}
+			h.update(blk, attributions[c])
+		}
+	}
+
+	treeState := e.tree.State()
+	lookup := func(id opID) Attribution {
+		c, ok := dm.opsToCids[[2]uint64{uint64(id.Actor), uint64(id.Ts)}] //nolint:gosec // We know this should not overflow.
+		if !ok {
+			panic(fmt.Errorf("BUG: failed to find CID for op ID: %d:%d", id.Actor, id.Ts))
+		}
+		return attributions[c]
+	}
+
+	out := make(map[string]*BlockBlame, len(e.stateBlocks))
+	for id := range e.stateBlocks {
+		opid, _, ok := e.blockState(id)
+		if !ok {
+			continue
+		}
+
+		bb := &BlockBlame{
+			Content: lookup(opid),
+		}
+
+		if move, ok := treeState.blocks[id]; ok {
+			bb.Position = lookup(move.Position)
+		}
+
+		if h := history[id]; h != nil {
+			for _, k := range slices.Sorted(maps.Keys(h.attrs)) {
+				attr := h.attrs[k]
+				bb.Attributes = append(bb.Attributes, AttributeBlame{Key: attr.key, Attribution: attr.attr})
+			}
+
+			for i, attr := range h.text {
+				if n := len(bb.Text); n > 0 && bb.Text[n-1].Change.Equals(attr.Change) {
+					bb.Text[n-1].End = i + 1
+					continue
+				}
+				bb.Text = append(bb.Text, TextBlame{Start: i, End: i + 1, Attribution: attr})
+			}
+		}
+
+		out[id] = bb
+	}
+
+	return out, nil
+}
+
+// update attributes the differences between the previous and the new state of the block to the given change.
+func (h *blockHistory) update(blk blob.Block, attr Attribution) {
+	var text []Attribution
+	{
+		var i int
+		for _, op := range seqdiff.Diff([]rune(h.block.Text), []rune(blk.Text)) {
+			switch op.Kind {
+			case seqdiff.Equal:
+				text = append(text, h.text[i])
+				i++
+			case seqdiff.Delete:
+				i++
+			case seqdiff.Insert:
+				text = append(text, attr)
+			}
+		}
+	}
+
+	attrs := make(map[string]attributeState)
+	flattenBlockAttributes(blk, func(key []string, value any) {
+		k := strings.Join(key, "\x00")
+		if prev, ok := h.attrs[k]; ok && reflect.DeepEqual(prev.value, value) {
+			attrs[k] = prev
+			return
+		}
+		attrs[k] = attributeState{key: key, value: value, attr: attr}
+	})
+
+	h.block = blk
+	h.text = text
+	h.attrs = attrs
+}
+
+func flattenBlockAttributes(blk blob.Block, fn func(key []string, value any)) {
+	if blk.Type != "" {
+		fn([]string{"type"}, blk.Type)
+	}
+
+	if blk.Link != "" {
+		fn([]string{"link"}, blk.Link)
+	}
+
+	var walk func(prefix []string, m map[string]any)
+	walk = func(prefix []string, m map[string]any) {
+		for k, v := range m {
+			key := append(slices.Clone(prefix), k)
+			if mm, ok := v.(map[string]any); ok && len(mm) > 0 {
+				walk(key, mm)
+				continue
+			}
+			fn(key, v)
+		}
+	}
+	walk([]string{"attributes"}, blk.Attributes())
+}
@@ -0,0 +1,93 @@
+package docmodel
+
+import (
+	"seed/backend/blob"
+	"seed/backend/core/coretest"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/cclock"
+	"seed/backend/util/must"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestBlame(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	bob := coretest.NewTester("bob").Account
+	iri := must.Do2(blob.NewIRI(alice.Principal(), "/doc"))
+
+	load := func(changes ...blob.Encoded[*blob.Change]) *Document {
+		doc := must.Do2(New(iri, cclock.New()))
+		for _, c := range changes {
+			must.Do(doc.ApplyChange(c.CID, c.Decoded))
+		}
+		return doc
+	}
+
+	doc := load()
+	must.Do(doc.MoveBlock("p1", "", ""))
+	must.Do(doc.ReplaceBlock(&documents.Block{Id: "p1", Type: "Paragraph", Text: "Hello world"}))
+	must.Do(doc.MoveBlock("p2", "", "p1"))
+	must.Do(doc.ReplaceBlock(&documents.Block{Id: "p2", Type: "Paragraph", Text: "Second"}))
+	c1 := must.Do2(doc.SignChange(alice))
+
+	// Bob replaces the whole block, but only some characters are actually new.
+	doc = load(c1)
+	must.Do(doc.ReplaceBlock(&documents.Block{
+		Id:         "p1",
+		Type:       "Paragraph",
+		Text:       "Hello, world",
+		Attributes: must.Do2(structpb.NewStruct(map[string]any{"childrenType": "Ordered"})),
+	}))
+	c2 := must.Do2(doc.SignChangeAt(bob, c1.Decoded.Ts.Add(time.Second)))
+
+	doc = load(c1, c2)
+	must.Do(doc.SpliceText("p1", 12, 0, "!"))
+	must.Do(doc.MoveBlock("p2", "", ""))
+	c3 := must.Do2(doc.SignChangeAt(alice, c1.Decoded.Ts.Add(2*time.Second)))
+
+	blame, err := load(c1, c2, c3).Blame()
+	require.NoError(t, err)
+	require.Len(t, blame, 2)
+
+	type textRange struct {
+		Start, End int
+		Change     cid.Cid
+	}
+	var ranges []textRange
+	for _, r := range blame["p1"].Text {
+		ranges = append(ranges, textRange{r.Start, r.End, r.Change})
+	}
+	require.Equal(t, []textRange{
+		{0, 5, c1.CID},
+		{5, 6, c2.CID},
+		{6, 12, c1.CID},
+		{12, 13, c3.CID},
+	}, ranges)
+
+	p1 := blame["p1"]
+	require.Equal(t, c3.CID, p1.Content.Change, "content must match the block revision")
+	require.Equal(t, alice.Principal(), p1.Content.Author)
+	require.Equal(t, c3.Decoded.Ts, p1.Content.Time)
+	require.Equal(t, c1.CID, p1.Position.Change)
+	require.Equal(t, []AttributeBlame{
+		{Key: []string{"attributes", "childrenType"}, Attribution: Attribution{Change: c2.CID, Author: bob.Principal(), Time: c2.Decoded.Ts}},
+		{Key: []string{"type"}, Attribution: Attribution{Change: c1.CID, Author: alice.Principal(), Time: c1.Decoded.Ts}},
+	}, p1.Attributes)
+
+	p2 := blame["p2"]
+	require.Equal(t, c1.CID, p2.Content.Change)
+	require.Equal(t, c3.CID, p2.Position.Change)
+	require.Equal(t, []TextBlame{{Start: 0, End: 6, Attribution: Attribution{Change: c1.CID, Author: alice.Principal(), Time: c1.Decoded.Ts}}}, p2.Text)
+
+	// Blame of an older version only knows about older changes.
+	old, err := load(c1, c2, c3).Checkout([]cid.Cid{c2.CID})
+	require.NoError(t, err)
+	blame, err = old.Blame()
+	require.NoError(t, err)
+	require.Equal(t, c2.CID, blame["p1"].Content.Change)
+	require.Equal(t, c1.CID, blame["p2"].Position.Change)
+}
@@ -149,10 +149,16 @@ type docCRDT struct {
 
 	stateMetadata *btree.Map[[]string, *mvReg[metadataValue]]
 	stateBlocks   map[string]*mvReg[blob.Block] // blockID -> opid -> block state.
+	stateText     map[string]*blockText         // blockID -> character-level edits of the block text.
 
 	clock        *cclock.Clock
 	actorsIntern map[core.PrincipalUnsafeString]core.PrincipalUnsafeString
 	vectorClock  map[core.PrincipalUnsafeString]time.Time
+
+	// When the state was restored from a snapshot,
+	// the first compacted changes don't have their ops.
+	snapshot  *blob.Snapshot
+	compacted int
 }
 
 type metadataValue struct {
@@ -168,6 +174,7 @@ func newCRDT(id blob.IRI, clock *cclock.Clock) *docCRDT {
 		tree:          newTreeOpSet(),
 		stateMetadata: btree.New[[]string, *mvReg[metadataValue]](8, slices.Compare),
 		stateBlocks:   make(map[string]*mvReg[blob.Block]),
+		stateText:     make(map[string]*blockText),
 		clock:         cclock.New(),
 		actorsIntern:  make(map[core.PrincipalUnsafeString]core.PrincipalUnsafeString),
 		vectorClock:   make(map[core.PrincipalUnsafeString]time.Time),
@@ -484,6 +491,15 @@ func (e *docCRDT) ApplyChange(c cid.Cid, ch *blob.Change) error {
 			}
 			reg.Set(opid, blk)
 
+			// Every version of the block text can be edited with SpliceText ops later,
+			// so we need to remember all of them, not only the latest one.
+			bt := e.stateText[blk.ID()]
+			if bt == nil {
+				bt = newBlockText()
+				e.stateText[blk.ID()] = bt
+			}
+			bt.bases[opid] = blk.Text
+
 			// We now support having detached blocks, so we need to make sure they exist in the tree.
 			// TODO(burdiyan): This is very hard to reason about and all of this stuff needs to be refactored.
 			if _, ok := e.tree.sublists.Get(blk.ID()); !ok {
@@ -552,6 +568,21 @@ func (e *docCRDT) ApplyChange(c cid.Cid, ch *blob.Change) error {
 				}
 				e.setMetadata(opid, kv.Key, kv.Value)
 			}
+		case blob.OpSpliceText:
+			opid, err := checkedOpID(idx)
+			if err != nil {
+				return err
+			}
+			self := func(id opID) opID {
+				if id.Ts == 0 && id.Actor == math.MaxUint64 {
+					id.Ts = ts
+					id.Actor = actorID
+				}
+				return id
+			}
+			if err := e.applySpliceText(opid, op, self); err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("BUG?: unhandled op type: %T", op)
 		}
@@ -691,7 +722,8 @@ func addUnique(in []int, v int) []int {
 }
 
 // prepareChange to be applied later.
-func (e *docCRDT) prepareChange(ts time.Time, signer core.Signer, body blob.ChangeBody) (hb blob.Encoded[*blob.Change], err error) {
+// The body is encrypted when the content key is provided.
+func (e *docCRDT) prepareChange(ts time.Time, signer core.Signer, body blob.ChangeBody, key *blob.ContentKey) (hb blob.Encoded[*blob.Change], err error) {
 	var genesis cid.Cid
 	if len(e.cids) > 0 {
 		genesis = e.cids[0]
@@ -711,6 +743,10 @@ func (e *docCRDT) prepareChange(ts time.Time, signer core.Signer, body blob.Chan
 	}
 	slices.SortFunc(deps, func(a, b cid.Cid) int { return strings.Compare(a.KeyString(), b.KeyString()) })
 
+	if key != nil {
+		return blob.NewEncryptedChange(signer, genesis, deps, depth, body, *key, ts)
+	}
+
 	hb, err = blob.NewChange(signer, genesis, deps, depth, body, ts)
 	if err != nil {
 		return hb, err
@@ -0,0 +1,488 @@
+package docmodel
+
+import (
+	"fmt"
+	"seed/backend/blob"
+	"slices"
+	"strings"
+)
+
+// textPos identifies a character in the text of a block:
+// the op that inserted the run of text the character belongs to,
+// and the 1-based offset of the character within that run.
+// Offset 0 of the base run stands for the start of the text.
+type textPos struct {
+	Run opID
+	Off int
+}
+
+// textSegment is a contiguous piece of a run, in the order it appears in the text.
+type textSegment struct {
+	Run     opID
+	Start   int // Offset of the first character of the segment within the run.
+	Text    []rune
+	Deleted bool
+}
+
+// rgaText is the character-level text CRDT of a block.
+// It follows the same RGA rules as rgaList, but it works with runs of characters instead of individual items,
+// so typing or pasting some text produces a single item, which is split only when something refers into the middle of it.
+//
+// Each instance holds the edits of one base text, i.e. the text set by a specific ReplaceBlock op.
+// Replacing the whole block starts a new base, and the edits made to the previous one are no longer visible.
+type rgaText struct {
+	base     opID
+	segments []textSegment
+	runs     map[opID]int // Length of each known run.
+	last     opID         // The latest op that edited the text.
+}
+
+func newRGAText(base opID, text string) *rgaText {
+	t := &rgaText{
+		base: base,
+		runs: map[opID]int{},
+		last: base,
+	}
+
+	runes := []rune(text)
+	t.runs[base] = len(runes)
+	if len(runes) > 0 {
+		t.segments = append(t.segments, textSegment{Run: base, Start: 1, Text: runes})
+	}
+
+	return t
+}
+
+// findSegment returns the index of the segment holding the character at p.
+func (t *rgaText) findSegment(p textPos) (int, error) {
+	n, ok := t.runs[p.Run]
+	if !ok {
+		return 0, fmt.Errorf("%w: text run %v is not found", errCausalityViolation, p.Run)
+	}
+
+	if p.Off < 1 || p.Off > n {
+		return 0, fmt.Errorf("offset %d is out of range of text run %v with %d characters", p.Off, p.Run, n)
+	}
+
+	for i, seg := range t.segments {
+		if seg.Run == p.Run && p.Off >= seg.Start && p.Off < seg.Start+len(seg.Text) {
+			return i, nil
+		}
+	}
+
+	panic("BUG: known text run is missing from the segments")
+}
+
+// splitAfter makes sure the character at p is the last one in its segment,
+// and returns the index of that segment.
+func (t *rgaText) splitAfter(p textPos) (int, error) {
+	i, err := t.findSegment(p)
+	if err != nil {
+		return 0, err
+	}
+
+	seg := t.segments[i]
+	k := p.Off - seg.Start + 1
+	if k == len(seg.Text) {
+		return i, nil
+	}
+
+	left, right := seg, seg
+	left.Text = seg.Text[:k:k]
+	right.Text = seg.Text[k:]
+	right.Start = seg.Start + k
+
+	t.segments[i] = left
+	t.segments = slices.Insert(t.segments, i+1, right)
+
+	return i, nil
+}
+
+// Insert integrates a new run of text after the character at ref.
+func (t *rgaText) Insert(id opID, ref textPos, text string) error {
+	if _, ok := t.runs[id]; ok {
+		return fmt.Errorf("duplicate op ID in the text")
+	}
+
+	runes := []rune(text)
+	if len(runes) == 0 {
+		return fmt.Errorf("can't insert empty text")
+	}
+
+	left := -1
+	if ref.Run != t.base || ref.Off != 0 {
+		i, err := t.splitAfter(ref)
+		if err != nil {
+			return err
+		}
+		left = i
+	}
+
+	// RGA rules: skip over any elements with a greater ID to the right of our desired insertion point.
+	pos := left + 1
+	for pos < len(t.segments) && t.segments[pos].Run.Compare(id) > 0 {
+		pos++
+	}
+
+	t.segments = slices.Insert(t.segments, pos, textSegment{Run: id, Start: 1, Text: runes})
+	t.runs[id] = len(runes)
+
+	return nil
+}
+
+// Delete marks the characters from the first to the last offset of the run as deleted.
+func (t *rgaText) Delete(run opID, first, last int) error {
+	if first > last {
+		return fmt.Errorf("invalid text range to delete: %d > %d", first, last)
+	}
+
+	if first > 1 {
+		if _, err := t.splitAfter(textPos{Run: run, Off: first - 1}); err != nil {
+			return err
+		}
+	}
+
+	if _, err := t.splitAfter(textPos{Run: run, Off: last}); err != nil {
+		return err
+	}
+
+	for i, seg := range t.segments {
+		if seg.Run == run && seg.Start >= first && seg.Start+len(seg.Text)-1 <= last {
+			t.segments[i].Deleted = true
+		}
+	}
+
+	return nil
+}
+
+// track records the op as the latest edit of the text.
+func (t *rgaText) track(id opID) {
+	if id.Compare(t.last) > 0 {
+		t.last = id
+	}
+}
+
+// String returns the visible text.
+func (t *rgaText) String() string {
+	var sb strings.Builder
+	for _, seg := range t.segments {
+		if seg.Deleted {
+			continue
+		}
+		for _, r := range seg.Text {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// PosAt returns the position of the visible character at the given index.
+func (t *rgaText) PosAt(idx int) (textPos, bool) {
+	for _, seg := range t.segments {
+		if seg.Deleted {
+			continue
+		}
+
+		if idx < len(seg.Text) {
+			return textPos{Run: seg.Run, Off: seg.Start + idx}, true
+		}
+		idx -= len(seg.Text)
+	}
+
+	return textPos{}, false
+}
+
+// textRange is a range of characters within a run.
+type textRange struct {
+	Run         opID
+	First, Last int
+}
+
+// RangesAt returns the positions of n visible characters starting at the given index,
+// as ranges of contiguous characters within the same run.
+func (t *rgaText) RangesAt(idx, n int) (out []textRange) {
+	for _, seg := range t.segments {
+		if n == 0 {
+			break
+		}
+
+		if seg.Deleted {
+			continue
+		}
+
+		if idx >= len(seg.Text) {
+			idx -= len(seg.Text)
+			continue
+		}
+
+		k := min(len(seg.Text)-idx, n)
+		first := seg.Start + idx
+		last := first + k - 1
+
+		if l := len(out); l > 0 && out[l-1].Run == seg.Run && out[l-1].Last+1 == first {
+			out[l-1].Last = last
+		} else {
+			out = append(out, textRange{Run: seg.Run, First: first, Last: last})
+		}
+
+		idx = 0
+		n -= k
+	}
+
+	return out
+}
+
+// Rebase maps the annotations of the base text onto the current text.
+// Annotation ranges grow with the text inserted inside of them, and shrink with the deleted text.
+// Ranges that become empty are removed, and so are the annotations without any ranges left.
+func (t *rgaText) Rebase(annotations []blob.Annotation) []blob.Annotation {
+	if len(annotations) == 0 {
+		return annotations
+	}
+
+	n := t.runs[t.base]
+
+	// For each character of the base text we need the number of visible characters before and after it.
+	before := make([]int32, n+2)
+	after := make([]int32, n+2)
+	var visible int32
+	for _, seg := range t.segments {
+		for i := range seg.Text {
+			if seg.Run == t.base {
+				before[seg.Start+i] = visible
+			}
+			if !seg.Deleted {
+				visible++
+			}
+			if seg.Run == t.base {
+				after[seg.Start+i] = visible
+			}
+		}
+	}
+
+	// Starts stick to the character after them, and ends stick to the character before them.
+	mapStart := func(k int32) int32 {
+		switch {
+		case int(k) < n:
+			return before[k+1]
+		case n > 0:
+			return after[n]
+		default:
+			return 0
+		}
+	}
+
+	mapEnd := func(k int32) int32 {
+		switch {
+		case k > 0 && int(k) <= n:
+			return after[k]
+		case k > 0:
+			return after[n]
+		case n > 0:
+			return before[1]
+		default:
+			return 0
+		}
+	}
+
+	out := make([]blob.Annotation, 0, len(annotations))
+	for _, a := range annotations {
+		var starts, ends []int32
+		for i := range min(len(a.Starts), len(a.Ends)) {
+			start, end := mapStart(max(a.Starts[i], 0)), mapEnd(max(a.Ends[i], 0))
+			if end <= start {
+				continue
+			}
+			starts = append(starts, start)
+			ends = append(ends, end)
+		}
+
+		if len(starts) == 0 {
+			continue
+		}
+
+		a.Starts = starts
+		a.Ends = ends
+		out = append(out, a)
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+
+	return out
+}
+
+// blockText holds the character-level edits of a block.
+type blockText struct {
+	bases map[opID]string   // Base texts set by ReplaceBlock ops.
+	runs  map[opID]opID     // Base op of each text run inserted by SpliceText ops.
+	texts map[opID]*rgaText // Edited texts by their base op.
+}
+
+func newBlockText() *blockText {
+	return &blockText{
+		bases: make(map[opID]string),
+		runs:  make(map[opID]opID),
+		texts: make(map[opID]*rgaText),
+	}
+}
+
+// baseOf returns the base op of the text run.
+func (bt *blockText) baseOf(run opID) (opID, error) {
+	if _, ok := bt.bases[run]; ok {
+		return run, nil
+	}
+
+	if base, ok := bt.runs[run]; ok {
+		return base, nil
+	}
+
+	return opID{}, fmt.Errorf("%w: text run %v is not found", errCausalityViolation, run)
+}
+
+// text returns the text CRDT for the base op, creating it if necessary.
+func (bt *blockText) text(base opID) *rgaText {
+	t := bt.texts[base]
+	if t == nil {
+		t = newRGAText(base, bt.bases[base])
+		bt.texts[base] = t
+	}
+	return t
+}
+
+// decodeTextPos decodes the encoded op ID with the trailing offsets,
+// resolving references to the same change like the other ops do.
+func decodeTextPos(in []uint64, nOffsets int, self func(opID) opID) (opID, []int, error) {
+	if len(in) != 1+nOffsets && len(in) != 3+nOffsets {
+		return opID{}, nil, fmt.Errorf("invalid text position: %v", in)
+	}
+
+	split := len(in) - nOffsets
+	id, err := decodeOpID(in[:split])
+	if err != nil {
+		return opID{}, nil, err
+	}
+
+	offs := make([]int, nOffsets)
+	for i, v := range in[split:] {
+		if v > maxIdx {
+			return opID{}, nil, fmt.Errorf("invalid text position: offset %d is too big", v)
+		}
+		offs[i] = int(v)
+	}
+
+	return self(id), offs, nil
+}
+
+func encodeTextPos(id opID, offsets ...int) []uint64 {
+	out := encodeOpID(id)
+	for _, off := range offsets {
+		out = append(out, uint64(off)) //nolint:gosec // Offsets are never negative.
+	}
+	return out
+}
+
+// applySpliceText integrates a SpliceText op into the text of its block.
+func (e *docCRDT) applySpliceText(id opID, op blob.OpSpliceText, self func(opID) opID) error {
+	bt := e.stateText[op.Block]
+	if bt == nil {
+		return fmt.Errorf("%w: block %s has no text to splice", errCausalityViolation, op.Block)
+	}
+
+	var (
+		ref     textPos
+		hasRef  = op.Ref != nil
+		deletes = make([][3]int64, 0, len(op.Delete))
+		runs    = make([]opID, 0, len(op.Delete)+1)
+	)
+
+	if hasRef {
+		run, offs, err := decodeTextPos(op.Ref, 1, self)
+		if err != nil {
+			return err
+		}
+		ref = textPos{Run: run, Off: offs[0]}
+		runs = append(runs, run)
+	}
+
+	for _, d := range op.Delete {
+		run, offs, err := decodeTextPos(d, 2, self)
+		if err != nil {
+			return err
+		}
+		runs = append(runs, run)
+		deletes = append(deletes, [3]int64{int64(len(runs) - 1), int64(offs[0]), int64(offs[1])})
+	}
+
+	if len(runs) == 0 {
+		return fmt.Errorf("splice text op must insert or delete something")
+	}
+
+	// All the runs must belong to the same base text.
+	base, err := bt.baseOf(runs[0])
+	if err != nil {
+		return err
+	}
+	for _, run := range runs[1:] {
+		b, err := bt.baseOf(run)
+		if err != nil {
+			return err
+		}
+		if b != base {
+			return fmt.Errorf("splice text op refers to different versions of block %s", op.Block)
+		}
+	}
+
+	t := bt.text(base)
+
+	for _, d := range deletes {
+		if err := t.Delete(runs[d[0]], int(d[1]), int(d[2])); err != nil {
+			return err
+		}
+	}
+
+	if op.Insert != "" {
+		if !hasRef {
+			return fmt.Errorf("splice text op must have a ref to insert text")
+		}
+
+		if err := t.Insert(id, ref, op.Insert); err != nil {
+			return err
+		}
+		bt.runs[id] = base
+	}
+
+	t.track(id)
+
+	return nil
+}
+
+// blockState returns the current state of the block, with the character-level edits applied,
+// and the ID of the op that last modified it.
+func (e *docCRDT) blockState(block string) (opID, blob.Block, bool) {
+	reg := e.stateBlocks[block]
+	if reg == nil {
+		return opID{}, blob.Block{}, false
+	}
+
+	id, blk, ok := reg.GetLatestWithID()
+	if !ok {
+		return id, blk, false
+	}
+
+	bt := e.stateText[block]
+	if bt == nil {
+		return id, blk, true
+	}
+
+	t := bt.texts[id]
+	if t == nil {
+		return id, blk, true
+	}
+
+	blk.Text = t.String()
+	blk.Annotations = t.Rebase(blk.Annotations)
+
+	return t.last, blk, true
+}
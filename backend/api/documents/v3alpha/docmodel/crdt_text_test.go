@@ -0,0 +1,91 @@
+package docmodel
+
+import (
+	"errors"
+	"seed/backend/blob"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRDTText(t *testing.T) {
+	base := newOpID(1, 1, 0)
+	x := newOpID(2, 1, 0)
+	y := newOpID(2, 2, 0)
+	z := newOpID(3, 1, 0)
+	del := newOpID(3, 2, 0)
+
+	type op struct {
+		ID    opID
+		Apply func(*rgaText) error
+	}
+
+	in := []op{
+		{x, func(txt *rgaText) error { return txt.Insert(x, textPos{Run: base, Off: 1}, "XX") }},
+		{y, func(txt *rgaText) error { return txt.Insert(y, textPos{Run: base, Off: 1}, "YY") }},
+		{z, func(txt *rgaText) error { return txt.Insert(z, textPos{Run: x, Off: 1}, "Z") }},
+		{del, func(txt *rgaText) error { return txt.Delete(base, 2, 3) }},
+	}
+
+	// Y wins over X because it has a greater ID, and Z goes in the middle of X.
+	want := "aYYXZXd"
+
+	for i, perm := range permute(in) {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			txt := newRGAText(base, "abcd")
+			for _, o := range perm {
+				if err := o.Apply(txt); err != nil {
+					if errors.Is(err, errCausalityViolation) {
+						// Permutations are expected to violate causality, so we ignore those errors.
+						return
+					}
+					t.Fatalf("Apply failed: %v", err)
+				}
+				txt.track(o.ID)
+			}
+			require.Equal(t, want, txt.String())
+			require.Equal(t, del, txt.last)
+		})
+	}
+}
+
+func TestCRDTTextRangesAt(t *testing.T) {
+	base := newOpID(1, 1, 0)
+	ins := newOpID(2, 1, 0)
+
+	txt := newRGAText(base, "Hello world")
+	require.NoError(t, txt.Insert(ins, textPos{Run: base, Off: 6}, "big "))
+	require.NoError(t, txt.Delete(base, 1, 1))
+	require.Equal(t, "ello big world", txt.String())
+
+	require.Equal(t, []textRange{
+		{Run: base, First: 5, Last: 6},
+		{Run: ins, First: 1, Last: 2},
+	}, txt.RangesAt(3, 4))
+
+	pos, ok := txt.PosAt(0)
+	require.True(t, ok)
+	require.Equal(t, textPos{Run: base, Off: 2}, pos)
+}
+
+func TestCRDTTextRebase(t *testing.T) {
+	base := newOpID(1, 1, 0)
+	ins := newOpID(2, 1, 0)
+
+	txt := newRGAText(base, "Hello world")
+	require.NoError(t, txt.Insert(ins, textPos{Run: base, Off: 8}, "oo"))
+	require.NoError(t, txt.Delete(base, 1, 6))
+	require.Equal(t, "wooorld", txt.String())
+
+	got := txt.Rebase([]blob.Annotation{
+		{Type: "Bold", Starts: []int32{6}, Ends: []int32{11}},
+		{Type: "Italic", Starts: []int32{0, 7}, Ends: []int32{5, 9}},
+		{Type: "Code", Starts: []int32{0}, Ends: []int32{6}},
+	})
+
+	require.Equal(t, []blob.Annotation{
+		{Type: "Bold", Starts: []int32{0}, Ends: []int32{7}},
+		{Type: "Italic", Starts: []int32{1}, Ends: []int32{5}},
+	}, got, "ranges must grow with insertions and disappear when their text is deleted")
+}
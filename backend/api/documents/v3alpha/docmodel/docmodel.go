@@ -48,11 +48,14 @@ type Document struct {
 	deletedBlocks map[string]struct{}
 
 	dirtyBlocks   map[string]mvRegValue[blob.Block]
+	dirtySplices  map[string]blob.OpMap
 	dirtyMetadata *btree.Map[[]string, mvRegValue[metadataValue]]
 
 	Generation maybe.Value[int64]
 
 	visibility blob.Visibility
+
+	contentKey *blob.ContentKey
 }
 
 // originFromCID creates a CRDT origin from the last 8 chars of the hash.
@@ -154,6 +157,26 @@ func (dm *Document) Checkout(heads []cid.Cid) (*Document, error) {
 		return nil, err
 	}
 
+	// Compacted changes don't have their ops, so we can only start from the same snapshot,
+	// and only if the version includes all of it.
+	if e.compacted > 0 {
+		var covered int
+		for _, c := range chain {
+			if c < e.compacted {
+				covered++
+			}
+		}
+
+		if covered < e.compacted {
+			return nil, fmt.Errorf("version %s is older than the snapshot the document was loaded from", NewVersion(heads...))
+		}
+
+		doc, err = FromSnapshot(e.id, clock, e.snapshot)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	for _, c := range chain {
 		if err := doc.ApplyChange(e.cids[c], e.changes[c]); err != nil {
 			return nil, err
@@ -179,6 +202,12 @@ func (dm *Document) Visibility() blob.Visibility {
 	return dm.visibility
 }
 
+// SetContentKey makes the new change encrypted with the content key of the space.
+// It's used for private documents, so only the members of the space can read them.
+func (dm *Document) SetContentKey(key blob.ContentKey) {
+	dm.contentKey = &key
+}
+
 // ApplyChange to the state. Can only do that before any mutations were made.
 func (dm *Document) ApplyChange(c cid.Cid, ch *blob.Change) error {
 	if dm.dirty {
@@ -189,13 +218,7 @@ func (dm *Document) ApplyChange(c cid.Cid, ch *blob.Change) error {
 }
 
 func (dm *Document) applyChangeUnsafe(c cid.Cid, ch *blob.Change) error {
-	akey := unique.Make(ch.Signer.UnsafeString())
-	actor, ok := dm.actors[akey]
-	if !ok {
-		actor = ch.Signer.ActorID()
-		dm.actors[akey] = actor
-	}
-
+	actor := dm.internActor(ch.Signer)
 	dm.opsToCids[[2]uint64{uint64(actor), uint64(ch.Ts.UnixMilli())}] = c //nolint:gosec // We know this should not overflow.
 
 	return dm.crdt.ApplyChange(c, ch)
@@ -276,22 +299,31 @@ func (dm *Document) ReplaceBlock(blkpb *documents.Block) error {
 		return fmt.Errorf("blocks must have ID")
 	}
 
+	blk, err := BlockFromProto(blkpb)
+	if err != nil {
+		return err
+	}
+
+	return dm.replaceBlock(blk)
+}
+
+func (dm *Document) replaceBlock(blk blob.Block) error {
+	dm.dirty = true
 	if dm.dirtyBlocks == nil {
 		dm.dirtyBlocks = make(map[string]mvRegValue[blob.Block])
 	}
 
-	blk, err := BlockFromProto(blkpb)
-	if err != nil {
-		return err
+	if _, ok := dm.dirtySplices[blk.ID()]; ok {
+		return fmt.Errorf("block %s already has text splices in this change", blk.ID())
 	}
 
 	// Check if CRDT state already has the same value for block.
 	// If so, we do nothing, and remove any dirty state for this block.
 	var preds []opID
-	if reg := dm.crdt.stateBlocks[blkpb.Id]; reg != nil {
-		oldValue, ok := reg.GetLatestOK()
+	if reg := dm.crdt.stateBlocks[blk.ID()]; reg != nil {
+		_, oldValue, ok := dm.crdt.blockState(blk.ID())
 		if ok && reflect.DeepEqual(oldValue, blk) {
-			delete(dm.dirtyBlocks, blkpb.Id)
+			delete(dm.dirtyBlocks, blk.ID())
 			return nil
 		}
 		preds = slices.Collect(reg.state.Keys())
@@ -307,6 +339,70 @@ func (dm *Document) ReplaceBlock(blkpb *documents.Block) error {
 	return nil
 }
 
+// SpliceText edits the text of an existing block, deleting deleteCount characters at offset,
+// and inserting the given text in their place. Offsets are in Unicode code points, like annotation ranges.
+// Unlike ReplaceBlock, concurrent splices of the same block are merged,
+// and the annotations of the block are adjusted to the edited text.
+// A block can only be spliced once per change, and not together with replacing it.
+func (dm *Document) SpliceText(block string, offset, deleteCount int, insert string) error {
+	dm.dirty = true
+
+	if _, ok := dm.dirtyBlocks[block]; ok {
+		return fmt.Errorf("block %s is already replaced in this change", block)
+	}
+
+	if _, ok := dm.dirtySplices[block]; ok {
+		return fmt.Errorf("block %s already has text splices in this change", block)
+	}
+
+	reg := dm.crdt.stateBlocks[block]
+	if reg == nil {
+		return fmt.Errorf("block %s is not found", block)
+	}
+
+	base, blk, ok := reg.GetLatestWithID()
+	if !ok {
+		return fmt.Errorf("block %s is not found", block)
+	}
+
+	var text *rgaText
+	if bt := dm.crdt.stateText[block]; bt != nil {
+		text = bt.texts[base]
+	}
+	if text == nil {
+		text = newRGAText(base, blk.Text)
+	}
+
+	if offset < 0 || deleteCount < 0 || offset+deleteCount > len([]rune(text.String())) {
+		return fmt.Errorf("splice [%d, %d) is out of range of block %s text", offset, offset+deleteCount, block)
+	}
+
+	if deleteCount == 0 && insert == "" {
+		return nil
+	}
+
+	var ref []uint64
+	if insert != "" {
+		pos := textPos{Run: base}
+		if offset > 0 {
+			pos, _ = text.PosAt(offset - 1)
+		}
+		ref = encodeTextPos(pos.Run, pos.Off)
+	}
+
+	var del [][]uint64
+	for _, r := range text.RangesAt(offset, deleteCount) {
+		del = append(del, encodeTextPos(r.Run, r.First, r.Last))
+	}
+
+	if dm.dirtySplices == nil {
+		dm.dirtySplices = make(map[string]blob.OpMap)
+	}
+	dm.dirtySplices[block] = blob.NewOpSpliceText(block, ref, insert, del)
+
+	return nil
+}
+
 // MoveBlock moves a block.
 func (dm *Document) MoveBlock(block, parent, left string) error {
 	dm.dirty = true
@@ -374,7 +470,7 @@ func (dm *Document) CreateChange(signer core.Signer, at time.Time) (hb blob.Enco
 
 	at = at.Round(dm.crdt.clock.Precision)
 
-	hb, err = dm.crdt.prepareChange(at, signer, ops)
+	hb, err = dm.crdt.prepareChange(at, signer, ops, dm.contentKey)
 	if err != nil {
 		return hb, err
 	}
@@ -521,6 +617,12 @@ func (dm *Document) cleanupPatch() (out blob.ChangeBody, err error) {
 		addOp(blob.NewOpReplaceBlock(blk.Value), 1)
 	}
 
+	splicedBlockIDs := slices.Collect(maps.Keys(dm.dirtySplices))
+	slices.Sort(splicedBlockIDs)
+	for _, bid := range splicedBlockIDs {
+		addOp(dm.dirtySplices[bid], 1)
+	}
+
 	return out, nil
 }
 
@@ -619,12 +721,7 @@ func (dm *Document) Hydrate(ctx context.Context) (*documents.Document, error) {
 	treeState := dm.crdt.tree.State()
 
 	blockToProto := func(id string) (*documents.Block, error) {
-		bs := dm.crdt.stateBlocks[id]
-		if bs == nil {
-			return nil, nil
-		}
-
-		opid, blk, ok := bs.GetLatestWithID()
+		opid, blk, ok := dm.crdt.blockState(id)
 		if !ok {
 			return nil, nil
 		}
@@ -414,3 +414,77 @@ func TestApplyChangeOpIndexOverflow(t *testing.T) {
 		require.ErrorContains(t, err, "overflows")
 	})
 }
+
+func TestSpliceTextConcurrent(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	bob := coretest.NewTester("bob").Account
+
+	doc := must.Do2(New("mydoc", cclock.New()))
+	must.Do(doc.MoveBlock("p1", "", ""))
+	must.Do(doc.ReplaceBlock(&documents.Block{
+		Id:   "p1",
+		Type: "Paragraph",
+		Text: "Hello world",
+		Annotations: []*documents.Annotation{
+			{Type: "Bold", Starts: []int32{6}, Ends: []int32{11}},
+		},
+	}))
+	c1 := must.Do2(doc.SignChange(alice))
+
+	load := func(changes ...blob.Encoded[*blob.Change]) *Document {
+		doc := must.Do2(New("mydoc", cclock.New()))
+		for _, c := range changes {
+			must.Do(doc.ApplyChange(c.CID, c.Decoded))
+		}
+		return doc
+	}
+
+	// Alice inserts a word in front of the bold text.
+	var c2 blob.Encoded[*blob.Change]
+	{
+		doc := load(c1)
+		must.Do(doc.SpliceText("p1", 6, 0, "big "))
+		c2 = must.Do2(doc.SignChangeAt(alice, c1.Decoded.Ts.Add(time.Second)))
+		require.Len(t, c2.Decoded.Body.Ops, 1)
+	}
+
+	// Bob concurrently deletes the first word.
+	var c3 blob.Encoded[*blob.Change]
+	{
+		doc := load(c1)
+		must.Do(doc.SpliceText("p1", 0, 6, ""))
+		c3 = must.Do2(doc.SignChangeAt(bob, c1.Decoded.Ts.Add(2*time.Second)))
+	}
+
+	for _, order := range [][]blob.Encoded[*blob.Change]{{c1, c2, c3}, {c1, c3, c2}} {
+		hdoc, err := load(order...).Hydrate(t.Context())
+		require.NoError(t, err)
+
+		blk := hdoc.Content[0].Block
+		require.Equal(t, "big world", blk.Text)
+		require.Len(t, blk.Annotations, 1)
+		require.Equal(t, []int32{4}, blk.Annotations[0].Starts)
+		require.Equal(t, []int32{9}, blk.Annotations[0].Ends)
+		require.Equal(t, c3.CID.String(), blk.Revision, "revision must point to the latest splice")
+	}
+
+	// Replacing the block after the splices works on the merged text.
+	doc = load(c1, c2, c3)
+	require.Error(t, doc.SpliceText("p1", 0, 100, ""), "splice must be within the text")
+	must.Do(doc.SpliceText("p1", 9, 0, "!"))
+	require.Error(t, doc.SpliceText("p1", 0, 0, "x"), "only one splice per block per change")
+	require.Error(t, doc.ReplaceBlock(&documents.Block{Id: "p1", Type: "Paragraph", Text: "x"}), "can't replace spliced block")
+	c4 := must.Do2(doc.SignChange(alice))
+
+	doc = load(c1, c2, c3, c4)
+	must.Do(doc.ReplaceBlock(&documents.Block{
+		Id:   "p1",
+		Type: "Paragraph",
+		Text: "big world!",
+		Annotations: []*documents.Annotation{
+			{Type: "Bold", Starts: []int32{4}, Ends: []int32{9}},
+		},
+	}))
+	c5 := must.Do2(doc.SignChange(alice))
+	require.Empty(t, c5.Decoded.Body.Ops, "replacing with the same merged state must be a no-op")
+}
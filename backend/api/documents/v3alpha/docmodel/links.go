@@ -0,0 +1,57 @@
+package docmodel
+
+import (
+	"fmt"
+	"slices"
+)
+
+// RewriteLinks replaces the links of the blocks in the document, and of their annotations,
+// with the result of the rewrite function, which reports whether the link must be changed.
+// It returns the number of blocks that were changed.
+func (dm *Document) RewriteLinks(rewrite func(link string) (string, bool)) (int, error) {
+	var count int
+	for pair := range dm.crdt.tree.State().DFT("") {
+		_, blk, ok := dm.crdt.blockState(pair.Child)
+		if !ok {
+			continue
+		}
+
+		var changed, cloned bool
+		if blk.Link != "" {
+			if link, ok := rewrite(blk.Link); ok {
+				blk.Link = link
+				changed = true
+			}
+		}
+
+		// Annotations are shared with the CRDT state, so we must not modify them in place.
+		for i, ann := range blk.Annotations {
+			if ann.Link == "" {
+				continue
+			}
+
+			link, ok := rewrite(ann.Link)
+			if !ok {
+				continue
+			}
+
+			if !cloned {
+				blk.Annotations = slices.Clone(blk.Annotations)
+				cloned = true
+			}
+			blk.Annotations[i].Link = link
+			changed = true
+		}
+
+		if !changed {
+			continue
+		}
+
+		if err := dm.replaceBlock(blk); err != nil {
+			return count, fmt.Errorf("failed to rewrite links of block %s: %w", pair.Child, err)
+		}
+		count++
+	}
+
+	return count, nil
+}
@@ -0,0 +1,159 @@
+package docmodel
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Revert makes the pending change restore the state of the target document,
+// which is normally an older version of the same document obtained with Checkout.
+// The changes are expressed as regular ops against the current state,
+// so history stays append-only and the result merges with concurrent changes like any other edit.
+//
+// If blocks are specified, only those blocks are restored: their content and their position,
+// and the blocks that didn't exist in the target version are deleted.
+// Otherwise the entire document is restored, including the metadata.
+func (dm *Document) Revert(target *Document, blocks []string) error {
+	if target == dm {
+		return fmt.Errorf("can't revert document to itself")
+	}
+
+	var selected func(string) bool
+	if len(blocks) == 0 {
+		selected = func(string) bool { return true }
+	} else {
+		set := make(map[string]struct{}, len(blocks))
+		for _, b := range blocks {
+			if _, ok := dm.crdt.stateBlocks[b]; !ok {
+				if _, ok := target.crdt.stateBlocks[b]; !ok {
+					return fmt.Errorf("block %s is not found in either version", b)
+				}
+			}
+			set[b] = struct{}{}
+		}
+		selected = func(b string) bool {
+			_, ok := set[b]
+			return ok
+		}
+	}
+
+	if len(blocks) == 0 {
+		if err := dm.revertMetadata(target); err != nil {
+			return err
+		}
+	}
+
+	mut, err := dm.ensureTreeMutation()
+	if err != nil {
+		return err
+	}
+
+	// We restore the blocks in the depth-first order of the target version,
+	// so parents and left siblings are placed before the blocks that refer to them.
+	var (
+		targetTree = target.crdt.tree.State()
+		inTarget   = make(map[string]struct{})
+		siblings   = make(map[string][]string)
+	)
+	for pair := range targetTree.DFT("") {
+		inTarget[pair.Child] = struct{}{}
+		prev := siblings[pair.Parent]
+		siblings[pair.Parent] = append(prev, pair.Child)
+
+		if !selected(pair.Child) {
+			continue
+		}
+
+		if pair.Parent != "" && !mut.dirty.isVisible(pair.Parent) {
+			return fmt.Errorf("can't restore block %s: its parent %s is not in the current version", pair.Child, pair.Parent)
+		}
+
+		// The block goes after the closest of its target left siblings that is currently under the same parent.
+		var left string
+		for _, s := range slices.Backward(prev) {
+			if st, ok := mut.dirty.blocks[s]; ok && st.Parent == pair.Parent {
+				left = s
+				break
+			}
+		}
+
+		// Blocks that are already in place are not moved. Move doesn't detect it for the first child,
+		// and the redundant position would be dropped on commit, leaving the following moves without a valid reference.
+		if lp, ok := mut.dirty.findLogicalPosition(pair.Child); !ok || lp.Parent != pair.Parent || lp.Left != left {
+			if err := dm.MoveBlock(pair.Child, pair.Parent, left); err != nil {
+				return fmt.Errorf("failed to restore position of block %s: %w", pair.Child, err)
+			}
+		}
+
+		if _, blk, ok := target.crdt.blockState(pair.Child); ok {
+			if err := dm.replaceBlock(blk); err != nil {
+				return fmt.Errorf("failed to restore content of block %s: %w", pair.Child, err)
+			}
+		}
+	}
+
+	var toDelete []string
+	for pair := range mut.dirty.DFT("") {
+		if _, ok := inTarget[pair.Child]; ok || !selected(pair.Child) {
+			continue
+		}
+		toDelete = append(toDelete, pair.Child)
+	}
+
+	for _, b := range toDelete {
+		if err := dm.DeleteBlock(b); err != nil {
+			return fmt.Errorf("failed to delete block %s: %w", b, err)
+		}
+	}
+
+	return nil
+}
+
+func (dm *Document) revertMetadata(target *Document) error {
+	for key, reg := range target.crdt.stateMetadata.Items() {
+		v, ok := reg.GetLatestOK()
+		if !ok {
+			continue
+		}
+
+		if cur := dm.crdt.stateMetadata.GetMaybe(key); cur == nil && v.Value == nil {
+			continue
+		}
+
+		if err := dm.SetAttribute("", key, v.Value); err != nil {
+			return err
+		}
+	}
+
+	// Attributes that didn't exist in the target version are removed.
+	for key, reg := range dm.crdt.stateMetadata.Items() {
+		if target.crdt.stateMetadata.GetMaybe(key) != nil {
+			continue
+		}
+
+		if v, ok := reg.GetLatestOK(); !ok || v.Value == nil {
+			continue
+		}
+
+		if err := dm.SetAttribute("", key, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isVisible checks whether the block is reachable from the root of the tree, i.e. it's not deleted or detached.
+func (state *blockTreeState) isVisible(block string) bool {
+	n, ok := state.blocks[block]
+	for steps := len(state.blocks); ok && steps >= 0; steps-- {
+		switch n.Parent {
+		case "":
+			return true
+		case TrashNodeID:
+			return false
+		}
+		n, ok = state.blocks[n.Parent]
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package docmodel
+
+import (
+	"seed/backend/blob"
+	"seed/backend/core/coretest"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/cclock"
+	"seed/backend/util/must"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRevert(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	bob := coretest.NewTester("bob").Account
+	iri := must.Do2(blob.NewIRI(alice.Principal(), "/doc"))
+
+	load := func(changes ...blob.Encoded[*blob.Change]) *Document {
+		doc := must.Do2(New(iri, cclock.New()))
+		for _, c := range changes {
+			must.Do(doc.ApplyChange(c.CID, c.Decoded))
+		}
+		return doc
+	}
+
+	// content returns the hydrated document without the fields that are expected to differ after the revert.
+	content := func(doc *Document) *documents.Document {
+		t.Helper()
+		docpb, err := doc.Hydrate(t.Context())
+		require.NoError(t, err)
+
+		var strip func(nodes []*documents.BlockNode)
+		strip = func(nodes []*documents.BlockNode) {
+			for _, n := range nodes {
+				n.Block.Revision = ""
+				strip(n.Children)
+			}
+		}
+		strip(docpb.Content)
+
+		return &documents.Document{Metadata: docpb.Metadata, Content: docpb.Content}
+	}
+
+	requireContent := func(want, got *Document) {
+		t.Helper()
+		wantpb, gotpb := content(want), content(got)
+		require.True(t, proto.Equal(wantpb, gotpb), "documents must have the same content:\nwant=%v\ngot=%v", wantpb, gotpb)
+	}
+
+	doc := load()
+	must.Do(doc.SetMetadata("title", "Hello"))
+	must.Do(doc.MoveBlock("p1", "", ""))
+	must.Do(doc.ReplaceBlock(&documents.Block{Id: "p1", Type: "Paragraph", Text: "one"}))
+	must.Do(doc.MoveBlock("p2", "", "p1"))
+	must.Do(doc.ReplaceBlock(&documents.Block{Id: "p2", Type: "Paragraph", Text: "two"}))
+	must.Do(doc.MoveBlock("p2.1", "p2", ""))
+	must.Do(doc.ReplaceBlock(&documents.Block{Id: "p2.1", Type: "Paragraph", Text: "nested"}))
+	c1 := must.Do2(doc.SignChange(alice))
+
+	doc = load(c1)
+	must.Do(doc.SetMetadata("title", "Changed"))
+	must.Do(doc.SetMetadata("extra", "Extra"))
+	must.Do(doc.ReplaceBlock(&documents.Block{Id: "p1", Type: "Paragraph", Text: "ONE"}))
+	must.Do(doc.DeleteBlock("p2"))
+	must.Do(doc.MoveBlock("p3", "", ""))
+	must.Do(doc.ReplaceBlock(&documents.Block{Id: "p3", Type: "Paragraph", Text: "three"}))
+	must.Do(doc.MoveBlock("p1", "", "p3"))
+	c2 := must.Do2(doc.SignChangeAt(alice, c1.Decoded.Ts.Add(time.Second)))
+
+	// Reverting the entire document.
+	doc = load(c1, c2)
+	target := must.Do2(load(c1, c2).Checkout([]cid.Cid{c1.CID}))
+	require.NoError(t, doc.Revert(target, nil))
+	c3 := must.Do2(doc.SignChangeAt(alice, c1.Decoded.Ts.Add(2*time.Second)))
+	require.Equal(t, []cid.Cid{c2.CID}, c3.Decoded.Deps, "revert must be a new change on top of the current version")
+
+	reverted := load(c1, c2, c3)
+	requireContent(load(c1), reverted)
+
+	// A concurrent edit merges with the revert in any order.
+	doc = load(c1, c2)
+	must.Do(doc.ReplaceBlock(&documents.Block{Id: "p3", Type: "Paragraph", Text: "three!"}))
+	must.Do(doc.MoveBlock("p4", "", "p1"))
+	must.Do(doc.ReplaceBlock(&documents.Block{Id: "p4", Type: "Paragraph", Text: "four"}))
+	concurrent := must.Do2(doc.SignChangeAt(bob, c1.Decoded.Ts.Add(3*time.Second)))
+
+	merged := load(c1, c2, c3, concurrent)
+	requireContent(merged, load(c1, c2, concurrent, c3))
+	hdoc := content(merged)
+	require.Len(t, hdoc.Content, 3, "the concurrently added block must survive the revert")
+	require.Equal(t, "p4", hdoc.Content[2].Block.Id)
+
+	// Reverting only some blocks.
+	doc = load(c1, c2)
+	require.NoError(t, doc.Revert(must.Do2(load(c1, c2).Checkout([]cid.Cid{c1.CID})), []string{"p1"}))
+	c4 := must.Do2(doc.SignChangeAt(alice, c1.Decoded.Ts.Add(2*time.Second)))
+
+	hdoc = content(load(c1, c2, c4))
+	require.Equal(t, "Changed", hdoc.Metadata.Fields["title"].GetStringValue(), "metadata must not be reverted with a subset of blocks")
+	require.Len(t, hdoc.Content, 2)
+	require.Equal(t, "p1", hdoc.Content[0].Block.Id, "block must be moved back to its old position")
+	require.Equal(t, "one", hdoc.Content[0].Block.Text)
+	require.Equal(t, "p3", hdoc.Content[1].Block.Id, "blocks that weren't selected must stay")
+
+	// Blocks that are already in place, including the first one, are not moved,
+	// so the restored blocks after them refer to their existing positions.
+	doc = load(c1)
+	must.Do(doc.DeleteBlock("p2"))
+	c5 := must.Do2(doc.SignChangeAt(alice, c1.Decoded.Ts.Add(time.Second)))
+
+	doc = load(c1, c5)
+	require.NoError(t, doc.Revert(must.Do2(load(c1, c5).Checkout([]cid.Cid{c1.CID})), nil))
+	c6 := must.Do2(doc.SignChangeAt(alice, c1.Decoded.Ts.Add(2*time.Second)))
+	requireContent(load(c1), load(c1, c5, c6))
+
+	// Restoring a block whose parent is gone doesn't work.
+	doc = load(c1, c2)
+	require.Error(t, doc.Revert(must.Do2(load(c1, c2).Checkout([]cid.Cid{c1.CID})), []string{"p2.1"}))
+
+	doc = load(c1, c2)
+	require.Error(t, doc.Revert(must.Do2(load(c1, c2).Checkout([]cid.Cid{c1.CID})), []string{"missing"}))
+}
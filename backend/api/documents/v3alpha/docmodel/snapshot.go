@@ -0,0 +1,514 @@
+package docmodel
+
+import (
+	"fmt"
+	"maps"
+	"seed/backend/blob"
+	"seed/backend/core"
+	"seed/backend/util/cclock"
+	"slices"
+	"time"
+	"unique"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+)
+
+// snapshotState is the state of the document CRDT stored in Snapshot blobs.
+// It holds everything ApplyChange needs to continue from where the snapshot was made,
+// while the changes themselves are reduced to their DAG metadata.
+type snapshotState struct {
+	Signers  []core.Principal   `refmt:"signers"`
+	Changes  []snapshotChange   `refmt:"changes"`
+	Metadata []snapshotMetadata `refmt:"metadata,omitempty"`
+	Blocks   []snapshotBlock    `refmt:"blocks,omitempty"`
+	Tree     []snapshotSublist  `refmt:"tree,omitempty"`
+	Detached []snapshotDetached `refmt:"detached,omitempty"`
+	Text     []snapshotText     `refmt:"text,omitempty"`
+}
+
+// snapshotChange is a change without its ops, in the order it was applied.
+type snapshotChange struct {
+	CID    cid.Cid `refmt:"cid"`
+	Signer int     `refmt:"signer"` // Index in the list of signers.
+	Ts     int64   `refmt:"ts"`     // Unix milliseconds.
+	Depth  int     `refmt:"depth,omitempty"`
+	Deps   []int   `refmt:"deps,omitempty"` // Indexes of the dependencies in the list of changes.
+}
+
+type snapshotMetadata struct {
+	ID    []uint64 `refmt:"id"`
+	Key   []string `refmt:"key"`
+	Value any      `refmt:"value"`
+}
+
+type snapshotBlock struct {
+	ID    []uint64   `refmt:"id"`
+	Block blob.Block `refmt:"block"`
+}
+
+type snapshotSublist struct {
+	Parent string         `refmt:"parent"`
+	Items  []snapshotMove `refmt:"items,omitempty"`
+}
+
+type snapshotMove struct {
+	Pos     string   `refmt:"pos"`
+	ID      []uint64 `refmt:"id"`
+	Ref     []uint64 `refmt:"ref,omitempty"`
+	Block   string   `refmt:"block"`
+	Deleted bool     `refmt:"deleted,omitempty"`
+}
+
+type snapshotDetached struct {
+	Block    string   `refmt:"block"`
+	ID       []uint64 `refmt:"id,omitempty"`
+	Detached bool     `refmt:"detached,omitempty"`
+}
+
+type snapshotText struct {
+	Block string             `refmt:"block"`
+	Bases []snapshotTextBase `refmt:"bases,omitempty"`
+	Runs  []snapshotTextRun  `refmt:"runs,omitempty"`
+	Texts []snapshotRGAText  `refmt:"texts,omitempty"`
+}
+
+type snapshotTextBase struct {
+	ID   []uint64 `refmt:"id"`
+	Text string   `refmt:"text"`
+}
+
+type snapshotTextRun struct {
+	ID   []uint64 `refmt:"id"`
+	Base []uint64 `refmt:"base,omitempty"`
+	Len  int      `refmt:"len,omitempty"`
+}
+
+type snapshotRGAText struct {
+	Base     []uint64              `refmt:"base"`
+	Last     []uint64              `refmt:"last"`
+	Segments []snapshotTextSegment `refmt:"segments,omitempty"`
+	Runs     []snapshotTextRun     `refmt:"runs,omitempty"`
+}
+
+type snapshotTextSegment struct {
+	Run     []uint64 `refmt:"run"`
+	Start   int      `refmt:"start"`
+	Text    string   `refmt:"text"`
+	Deleted bool     `refmt:"deleted,omitempty"`
+}
+
+func init() {
+	cbornode.RegisterCborType(snapshotState{})
+	cbornode.RegisterCborType(snapshotChange{})
+	cbornode.RegisterCborType(snapshotMetadata{})
+	cbornode.RegisterCborType(snapshotBlock{})
+	cbornode.RegisterCborType(snapshotSublist{})
+	cbornode.RegisterCborType(snapshotMove{})
+	cbornode.RegisterCborType(snapshotDetached{})
+	cbornode.RegisterCborType(snapshotText{})
+	cbornode.RegisterCborType(snapshotTextBase{})
+	cbornode.RegisterCborType(snapshotTextRun{})
+	cbornode.RegisterCborType(snapshotRGAText{})
+	cbornode.RegisterCborType(snapshotTextSegment{})
+}
+
+// encodeStateOpID encodes op IDs in the snapshot state.
+// Unlike encodeOpID it never uses the short form, because there's no change to be relative to.
+func encodeStateOpID(o opID) []uint64 {
+	return []uint64{
+		uint64(o.Ts),    //nolint:gosec // We know this should not overflow.
+		uint64(o.Idx),   //nolint:gosec // We know this should not overflow.
+		uint64(o.Actor), //nolint:gosec // We know this should not overflow.
+	}
+}
+
+func decodeStateOpID(in []uint64) (opID, error) {
+	if len(in) != 3 {
+		return opID{}, fmt.Errorf("invalid op ID in snapshot: %v", in)
+	}
+
+	return decodeOpID(in)
+}
+
+func compareOpIDs(a, b opID) int { return a.Compare(b) }
+
+// Snapshot creates a Snapshot blob with the current state of the document.
+// The document must not have any uncommitted mutations.
+func (dm *Document) Snapshot(kp *core.KeyPair, ts time.Time) (eb blob.Encoded[*blob.Snapshot], err error) {
+	if dm.dirty {
+		return eb, fmt.Errorf("can't snapshot a document with uncommitted changes")
+	}
+
+	e := dm.crdt
+	if len(e.cids) == 0 {
+		return eb, fmt.Errorf("can't snapshot an empty document")
+	}
+
+	state := e.snapshotState()
+	data, err := cbornode.DumpObject(state)
+	if err != nil {
+		return eb, fmt.Errorf("failed to encode snapshot state: %w", err)
+	}
+
+	space, path, err := e.id.SpacePath()
+	if err != nil {
+		return eb, err
+	}
+
+	heads := SortCIDs(slices.Collect(maps.Keys(e.heads)))
+
+	return blob.NewSnapshot(kp, space, path, e.cids[0], heads, len(e.cids), data, dm.visibility, ts)
+}
+
+// FromSnapshot creates a Document model from the state stored in a Snapshot blob.
+// Only the changes made after the snapshot need to be applied on top of it.
+// The caller is responsible for deciding whether the signer of the snapshot can be trusted.
+func FromSnapshot(id blob.IRI, clock *cclock.Clock, snap *blob.Snapshot) (*Document, error) {
+	doc, err := New(id, clock)
+	if err != nil {
+		return nil, err
+	}
+
+	var state snapshotState
+	if err := cbornode.DecodeInto(snap.State, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot state: %w", err)
+	}
+
+	if err := doc.restoreSnapshot(snap, state); err != nil {
+		return nil, fmt.Errorf("invalid snapshot state: %w", err)
+	}
+
+	return doc, nil
+}
+
+// ChangesSinceSnapshot returns the number of changes applied on top of the snapshot the document was restored from.
+// For documents that were not restored from a snapshot it's the number of all the changes.
+func (dm *Document) ChangesSinceSnapshot() int {
+	return len(dm.crdt.cids) - dm.crdt.compacted
+}
+
+func (e *docCRDT) snapshotState() snapshotState {
+	var state snapshotState
+
+	signers := make(map[core.PrincipalUnsafeString]int)
+	state.Changes = make([]snapshotChange, len(e.changes))
+	for i, ch := range e.changes {
+		si, ok := signers[ch.Signer.UnsafeString()]
+		if !ok {
+			si = len(state.Signers)
+			signers[ch.Signer.UnsafeString()] = si
+			state.Signers = append(state.Signers, ch.Signer)
+		}
+
+		state.Changes[i] = snapshotChange{
+			CID:    e.cids[i],
+			Signer: si,
+			Ts:     ch.Ts.UnixMilli(),
+			Depth:  ch.Depth,
+			Deps:   slices.Clone(e.deps[i]),
+		}
+	}
+
+	for key, reg := range e.stateMetadata.Items() {
+		id, v, ok := reg.GetLatestWithID()
+		if !ok {
+			continue
+		}
+		state.Metadata = append(state.Metadata, snapshotMetadata{ID: encodeStateOpID(id), Key: key, Value: v.Value})
+	}
+
+	for _, blk := range slices.Sorted(maps.Keys(e.stateBlocks)) {
+		id, v, ok := e.stateBlocks[blk].GetLatestWithID()
+		if !ok {
+			continue
+		}
+		state.Blocks = append(state.Blocks, snapshotBlock{ID: encodeStateOpID(id), Block: v})
+	}
+
+	for parent, list := range e.tree.sublists.Items() {
+		sl := snapshotSublist{Parent: parent}
+		for pos, item := range list.items.Items() {
+			sm := snapshotMove{
+				Pos:     pos,
+				ID:      encodeStateOpID(item.ID),
+				Block:   item.Value,
+				Deleted: item.IsDeleted,
+			}
+			if !item.Ref.isZero() {
+				sm.Ref = encodeStateOpID(item.Ref)
+			}
+			sl.Items = append(sl.Items, sm)
+		}
+		state.Tree = append(state.Tree, sl)
+	}
+
+	for blk, lm := range e.tree.detachedBlocks.Items() {
+		sd := snapshotDetached{Block: blk, Detached: lm.detached}
+		if !lm.opID.isZero() {
+			sd.ID = encodeStateOpID(lm.opID)
+		}
+		state.Detached = append(state.Detached, sd)
+	}
+
+	for _, blk := range slices.Sorted(maps.Keys(e.stateText)) {
+		bt := e.stateText[blk]
+		st := snapshotText{Block: blk}
+
+		for _, id := range slices.SortedFunc(maps.Keys(bt.bases), compareOpIDs) {
+			st.Bases = append(st.Bases, snapshotTextBase{ID: encodeStateOpID(id), Text: bt.bases[id]})
+		}
+
+		for _, id := range slices.SortedFunc(maps.Keys(bt.runs), compareOpIDs) {
+			st.Runs = append(st.Runs, snapshotTextRun{ID: encodeStateOpID(id), Base: encodeStateOpID(bt.runs[id])})
+		}
+
+		for _, base := range slices.SortedFunc(maps.Keys(bt.texts), compareOpIDs) {
+			t := bt.texts[base]
+			srt := snapshotRGAText{
+				Base: encodeStateOpID(t.base),
+				Last: encodeStateOpID(t.last),
+			}
+
+			for _, seg := range t.segments {
+				srt.Segments = append(srt.Segments, snapshotTextSegment{
+					Run:     encodeStateOpID(seg.Run),
+					Start:   seg.Start,
+					Text:    string(seg.Text),
+					Deleted: seg.Deleted,
+				})
+			}
+
+			for _, id := range slices.SortedFunc(maps.Keys(t.runs), compareOpIDs) {
+				srt.Runs = append(srt.Runs, snapshotTextRun{ID: encodeStateOpID(id), Len: t.runs[id]})
+			}
+
+			st.Texts = append(st.Texts, srt)
+		}
+
+		state.Text = append(state.Text, st)
+	}
+
+	return state
+}
+
+func (dm *Document) restoreSnapshot(snap *blob.Snapshot, state snapshotState) error {
+	e := dm.crdt
+
+	if len(e.cids) != 0 {
+		return fmt.Errorf("snapshot can only be restored into an empty document")
+	}
+
+	if len(state.Changes) != snap.ChangeCount {
+		return fmt.Errorf("snapshot claims %d changes, but has %d", snap.ChangeCount, len(state.Changes))
+	}
+
+	if len(state.Changes) == 0 || !state.Changes[0].CID.Equals(snap.Genesis) {
+		return fmt.Errorf("first change must be the genesis %s", snap.Genesis)
+	}
+
+	// Changes are restored without their ops.
+	// They are only needed to validate and order the changes applied later, and for the history.
+	for i, sc := range state.Changes {
+		if sc.Signer < 0 || sc.Signer >= len(state.Signers) {
+			return fmt.Errorf("change %s: invalid signer index %d", sc.CID, sc.Signer)
+		}
+
+		if _, ok := e.applied[sc.CID]; ok {
+			return fmt.Errorf("duplicate change %s", sc.CID)
+		}
+
+		if (i == 0) != (len(sc.Deps) == 0) {
+			return fmt.Errorf("change %s: only the genesis change must have no deps", sc.CID)
+		}
+
+		ch := &blob.Change{
+			BaseBlob: blob.BaseBlob{
+				Type:   blob.TypeChange,
+				Signer: state.Signers[sc.Signer],
+				Ts:     time.UnixMilli(sc.Ts),
+			},
+			Depth: sc.Depth,
+		}
+
+		if i > 0 {
+			ch.Genesis = snap.Genesis
+		}
+
+		for _, dep := range sc.Deps {
+			if dep < 0 || dep >= i {
+				return fmt.Errorf("change %s: invalid dependency index %d", sc.CID, dep)
+			}
+			ch.Deps = append(ch.Deps, e.cids[dep])
+		}
+		ch.Deps = SortCIDs(ch.Deps)
+
+		akey := ch.Signer.UnsafeString()
+		if _, ok := e.actorsIntern[akey]; !ok {
+			e.actorsIntern[akey] = akey
+		}
+		e.vectorClock[e.actorsIntern[akey]] = ch.Ts
+
+		if err := e.clock.Track(ch.Ts); err != nil {
+			return err
+		}
+
+		actor := dm.internActor(ch.Signer)
+		dm.opsToCids[[2]uint64{uint64(actor), uint64(sc.Ts)}] = sc.CID //nolint:gosec // We know this should not overflow.
+
+		e.cids = append(e.cids, sc.CID)
+		e.changes = append(e.changes, ch)
+		e.deps = append(e.deps, nil)
+		e.rdeps = append(e.rdeps, nil)
+		e.applied[sc.CID] = i
+		e.heads[sc.CID] = struct{}{}
+
+		for _, dep := range sc.Deps {
+			delete(e.heads, e.cids[dep])
+			e.deps[i] = addUnique(e.deps[i], dep)
+			e.rdeps[dep] = addUnique(e.rdeps[dep], i)
+		}
+	}
+
+	if NewVersion(slices.Collect(maps.Keys(e.heads))...) != NewVersion(snap.Heads...) {
+		return fmt.Errorf("heads of the changes don't match the heads of the snapshot")
+	}
+
+	for _, sm := range state.Metadata {
+		id, err := decodeStateOpID(sm.ID)
+		if err != nil {
+			return err
+		}
+
+		reg := newMVReg[metadataValue]()
+		reg.Set(id, metadataValue{Key: slices.Clone(sm.Key), Value: sm.Value})
+		e.stateMetadata.Set(sm.Key, reg)
+	}
+
+	for _, sb := range state.Blocks {
+		id, err := decodeStateOpID(sb.ID)
+		if err != nil {
+			return err
+		}
+
+		reg := newMVReg[blob.Block]()
+		reg.Set(id, sb.Block)
+		e.stateBlocks[sb.Block.ID()] = reg
+	}
+
+	for _, sl := range state.Tree {
+		list, ok := e.tree.sublists.Get(sl.Parent)
+		if !ok {
+			list = newRGAList[string]()
+			e.tree.sublists.Set(sl.Parent, list)
+		}
+
+		for _, sm := range sl.Items {
+			id, err := decodeStateOpID(sm.ID)
+			if err != nil {
+				return err
+			}
+
+			var ref opID
+			if sm.Ref != nil {
+				ref, err = decodeStateOpID(sm.Ref)
+				if err != nil {
+					return err
+				}
+			}
+
+			if list.items.Set(sm.Pos, rgaItem[string]{ID: id, Ref: ref, Value: sm.Block, IsDeleted: sm.Deleted}) {
+				return fmt.Errorf("duplicate position %s in the children of %q", sm.Pos, sl.Parent)
+			}
+
+			if list.applied.Set(id, sm.Pos) || e.tree.log.Set(id, moveRecord{OpID: id, Parent: sl.Parent, Block: sm.Block, Ref: ref}) {
+				return fmt.Errorf("duplicate move op %v", id)
+			}
+		}
+	}
+
+	for _, sd := range state.Detached {
+		lm := blockLatestMove{detached: sd.Detached}
+		if sd.ID != nil {
+			id, err := decodeStateOpID(sd.ID)
+			if err != nil {
+				return err
+			}
+			lm.opID = id
+		}
+		e.tree.detachedBlocks.Set(sd.Block, lm)
+	}
+
+	for _, st := range state.Text {
+		bt := newBlockText()
+
+		for _, b := range st.Bases {
+			id, err := decodeStateOpID(b.ID)
+			if err != nil {
+				return err
+			}
+			bt.bases[id] = b.Text
+		}
+
+		for _, r := range st.Runs {
+			id, err := decodeStateOpID(r.ID)
+			if err != nil {
+				return err
+			}
+			base, err := decodeStateOpID(r.Base)
+			if err != nil {
+				return err
+			}
+			bt.runs[id] = base
+		}
+
+		for _, srt := range st.Texts {
+			base, err := decodeStateOpID(srt.Base)
+			if err != nil {
+				return err
+			}
+
+			last, err := decodeStateOpID(srt.Last)
+			if err != nil {
+				return err
+			}
+
+			t := &rgaText{base: base, last: last, runs: make(map[opID]int, len(srt.Runs))}
+			for _, seg := range srt.Segments {
+				run, err := decodeStateOpID(seg.Run)
+				if err != nil {
+					return err
+				}
+				t.segments = append(t.segments, textSegment{Run: run, Start: seg.Start, Text: []rune(seg.Text), Deleted: seg.Deleted})
+			}
+
+			for _, r := range srt.Runs {
+				id, err := decodeStateOpID(r.ID)
+				if err != nil {
+					return err
+				}
+				t.runs[id] = r.Len
+			}
+
+			bt.texts[base] = t
+		}
+
+		e.stateText[st.Block] = bt
+	}
+
+	e.compacted = len(e.cids)
+	e.snapshot = snap
+
+	return nil
+}
+
+// internActor returns the actor ID of the signer, remembering it for later.
+func (dm *Document) internActor(signer core.Principal) core.ActorID {
+	akey := unique.Make(signer.UnsafeString())
+	actor, ok := dm.actors[akey]
+	if !ok {
+		actor = signer.ActorID()
+		dm.actors[akey] = actor
+	}
+	return actor
+}
@@ -0,0 +1,125 @@
+package docmodel
+
+import (
+	"maps"
+	"seed/backend/blob"
+	"seed/backend/core/coretest"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/cclock"
+	"seed/backend/util/must"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSnapshot(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	bob := coretest.NewTester("bob").Account
+	iri := must.Do2(blob.NewIRI(alice.Principal(), "/doc"))
+
+	load := func(changes ...blob.Encoded[*blob.Change]) *Document {
+		doc := must.Do2(New(iri, cclock.New()))
+		for _, c := range changes {
+			must.Do(doc.ApplyChange(c.CID, c.Decoded))
+		}
+		return doc
+	}
+
+	doc := must.Do2(New(iri, cclock.New()))
+	must.Do(doc.SetMetadata("title", "Hello"))
+	must.Do(doc.MoveBlock("p1", "", ""))
+	must.Do(doc.ReplaceBlock(&documents.Block{Id: "p1", Type: "Paragraph", Text: "Hello world"}))
+	must.Do(doc.MoveBlock("p2", "", "p1"))
+	must.Do(doc.ReplaceBlock(&documents.Block{Id: "p2", Type: "Paragraph", Text: "Second"}))
+	must.Do(doc.MoveBlock("p2.1", "p2", ""))
+	must.Do(doc.ReplaceBlock(&documents.Block{Id: "p2.1", Type: "Paragraph", Text: "Nested"}))
+	c1 := must.Do2(doc.SignChange(alice))
+
+	doc = load(c1)
+	must.Do(doc.SpliceText("p1", 5, 0, ","))
+	must.Do(doc.DeleteBlock("p2.1"))
+	must.Do(doc.SetAttribute("", []string{"theme", "color"}, "red"))
+	c2 := must.Do2(doc.SignChangeAt(alice, c1.Decoded.Ts.Add(time.Second)))
+
+	// Concurrent change by another writer.
+	doc = load(c1)
+	must.Do(doc.MoveBlock("p2", "", ""))
+	must.Do(doc.SpliceText("p1", 11, 0, "!"))
+	c3 := must.Do2(doc.SignChangeAt(bob, c1.Decoded.Ts.Add(2*time.Second)))
+
+	full := load(c1, c2, c3)
+	snap, err := full.Snapshot(alice, c1.Decoded.Ts.Add(3*time.Second))
+	require.NoError(t, err)
+	require.Equal(t, 3, snap.Decoded.ChangeCount)
+	require.Equal(t, NewVersion(c2.CID, c3.CID), NewVersion(snap.Decoded.Heads...))
+
+	decoded := &blob.Snapshot{}
+	require.NoError(t, cbornode.DecodeInto(snap.Data, decoded))
+
+	restored, err := FromSnapshot(iri, cclock.New(), decoded)
+	require.NoError(t, err)
+	require.Equal(t, 0, restored.ChangesSinceSnapshot())
+	requireSameDocument(t, full, restored)
+
+	// Changes made after the snapshot apply on top of it.
+	doc = load(c1, c2, c3)
+	must.Do(doc.SpliceText("p1", 0, 5, "Bye"))
+	must.Do(doc.MoveBlock("p3", "", "p2"))
+	must.Do(doc.ReplaceBlock(&documents.Block{Id: "p3", Type: "Paragraph", Text: "Third"}))
+	must.Do(doc.SetMetadata("title", "Bye"))
+	c4 := must.Do2(doc.SignChangeAt(bob, c1.Decoded.Ts.Add(4*time.Second)))
+
+	full = load(c1, c2, c3, c4)
+	must.Do(restored.ApplyChange(c4.CID, c4.Decoded))
+	require.Equal(t, 1, restored.ChangesSinceSnapshot())
+	requireSameDocument(t, full, restored)
+
+	hdoc, err := restored.Hydrate(t.Context())
+	require.NoError(t, err)
+	require.Len(t, hdoc.Content, 3)
+	require.Equal(t, []string{"p2", "p3", "p1"}, []string{hdoc.Content[0].Block.Id, hdoc.Content[1].Block.Id, hdoc.Content[2].Block.Id})
+	require.Equal(t, "Bye, world!", hdoc.Content[2].Block.Text)
+	require.Equal(t, c4.CID.String(), hdoc.Content[2].Block.Revision)
+
+	// The history is still complete.
+	var history []cid.Cid
+	changes, err := restored.BFTDeps([]cid.Cid{c4.CID})
+	require.NoError(t, err)
+	for c := range changes {
+		history = append(history, c)
+	}
+	require.ElementsMatch(t, []cid.Cid{c1.CID, c2.CID, c3.CID, c4.CID}, history)
+
+	// We can't go back in time past the snapshot, because the older changes don't have their ops.
+	_, err = restored.Checkout([]cid.Cid{c2.CID})
+	require.Error(t, err)
+
+	old, err := restored.Checkout([]cid.Cid{c2.CID, c3.CID})
+	require.NoError(t, err)
+	requireSameDocument(t, load(c1, c2, c3), old)
+
+	// Tampering with the snapshot is detected.
+	tampered := *decoded
+	tampered.Heads = []cid.Cid{c2.CID}
+	_, err = FromSnapshot(iri, cclock.New(), &tampered)
+	require.Error(t, err)
+}
+
+func requireSameDocument(t *testing.T, want, got *Document) {
+	t.Helper()
+
+	wantpb, err := want.Hydrate(t.Context())
+	require.NoError(t, err)
+
+	gotpb, err := got.Hydrate(t.Context())
+	require.NoError(t, err)
+
+	require.True(t, proto.Equal(wantpb, gotpb), "documents must be the same:\nwant=%v\ngot=%v", wantpb, gotpb)
+	require.Equal(t, want.Version(), got.Version())
+	require.Equal(t, slices.Sorted(maps.Keys(want.crdt.stateBlocks)), slices.Sorted(maps.Keys(got.crdt.stateBlocks)))
+}
@@ -0,0 +1,149 @@
+package docmodel
+
+import (
+	"fmt"
+	"regexp"
+	"seed/backend/blob"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// TemplateKey is the metadata attribute that marks documents as templates when it's set to true.
+const TemplateKey = "template"
+
+// templateVariable matches placeholders like {{name}} or {{ name }}.
+var templateVariable = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// IsTemplate reports whether the document is marked as a template.
+func (dm *Document) IsTemplate() bool {
+	reg := dm.crdt.stateMetadata.GetMaybe([]string{TemplateKey})
+	if reg == nil {
+		return false
+	}
+
+	v, ok := reg.GetLatestOK()
+	return ok && v.Value == true
+}
+
+// Instantiate makes the pending change fill a new document with the metadata and blocks of the template,
+// replacing the {{variable}} placeholders in block texts and string metadata values with the given values.
+// Placeholders without a value are kept as is. The template marker itself is not copied.
+func (dm *Document) Instantiate(tpl *Document, vars map[string]string) error {
+	if len(dm.crdt.changes) > 0 {
+		return fmt.Errorf("templates can only be instantiated into new documents")
+	}
+
+	for key, reg := range tpl.crdt.stateMetadata.Items() {
+		v, ok := reg.GetLatestOK()
+		if !ok || v.Value == nil || slices.Equal(key, []string{TemplateKey}) {
+			continue
+		}
+
+		value := v.Value
+		if s, ok := value.(string); ok {
+			value, _ = substituteVariables(s, nil, vars)
+		}
+
+		if err := dm.SetAttribute("", key, value); err != nil {
+			return err
+		}
+	}
+
+	lastChild := make(map[string]string)
+	for pair := range tpl.crdt.tree.State().DFT("") {
+		if err := dm.MoveBlock(pair.Child, pair.Parent, lastChild[pair.Parent]); err != nil {
+			return fmt.Errorf("failed to place block %s: %w", pair.Child, err)
+		}
+		lastChild[pair.Parent] = pair.Child
+
+		_, blk, ok := tpl.crdt.blockState(pair.Child)
+		if !ok {
+			continue
+		}
+
+		blk.Text, blk.Annotations = substituteVariables(blk.Text, blk.Annotations, vars)
+
+		if err := dm.replaceBlock(blk); err != nil {
+			return fmt.Errorf("failed to fill block %s: %w", pair.Child, err)
+		}
+	}
+
+	return nil
+}
+
+// substituteVariables replaces the placeholders in the text,
+// and returns a copy of the annotations with the ranges adjusted to the new text.
+// Annotation positions are in Unicode code points.
+func substituteVariables(text string, anns []blob.Annotation, vars map[string]string) (string, []blob.Annotation) {
+	type edit struct {
+		Start  int // Position of the placeholder in the original text.
+		OldLen int
+		NewLen int
+	}
+
+	var (
+		sb      strings.Builder
+		edits   []edit
+		lastEnd int
+	)
+	for _, m := range templateVariable.FindAllStringSubmatchIndex(text, -1) {
+		value, ok := vars[text[m[2]:m[3]]]
+		if !ok {
+			continue
+		}
+
+		sb.WriteString(text[lastEnd:m[0]])
+		sb.WriteString(value)
+		edits = append(edits, edit{
+			Start:  utf8.RuneCountInString(text[:m[0]]),
+			OldLen: utf8.RuneCountInString(text[m[0]:m[1]]),
+			NewLen: utf8.RuneCountInString(value),
+		})
+		lastEnd = m[1]
+	}
+
+	if len(edits) == 0 {
+		return text, anns
+	}
+	sb.WriteString(text[lastEnd:])
+
+	// Positions inside a placeholder are moved to its edges,
+	// so annotations covering a placeholder cover the entire value.
+	move := func(pos int32, isEnd bool) int32 {
+		var shift int
+		for _, e := range edits {
+			switch {
+			case int(pos) >= e.Start+e.OldLen:
+				shift += e.NewLen - e.OldLen
+			case int(pos) > e.Start:
+				if isEnd {
+					return int32(e.Start + shift + e.NewLen) //nolint:gosec // Positions are bounded by the length of the block text.
+				}
+				return int32(e.Start + shift) //nolint:gosec // Positions are bounded by the length of the block text.
+			default:
+				return pos + int32(shift) //nolint:gosec // Positions are bounded by the length of the block text.
+			}
+		}
+		return pos + int32(shift) //nolint:gosec // Positions are bounded by the length of the block text.
+	}
+
+	if len(anns) == 0 {
+		return sb.String(), anns
+	}
+
+	out := make([]blob.Annotation, len(anns))
+	for i, a := range anns {
+		a.Starts = slices.Clone(a.Starts)
+		a.Ends = slices.Clone(a.Ends)
+		for j := range a.Starts {
+			a.Starts[j] = move(a.Starts[j], false)
+		}
+		for j := range a.Ends {
+			a.Ends[j] = move(a.Ends[j], true)
+		}
+		out[i] = a
+	}
+
+	return sb.String(), out
+}
@@ -0,0 +1,70 @@
+package docmodel
+
+import (
+	"seed/backend/blob"
+	"seed/backend/core/coretest"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/cclock"
+	"seed/backend/util/must"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubstituteVariables(t *testing.T) {
+	vars := map[string]string{"name": "Ünïcode", "date": "2024"}
+
+	text, anns := substituteVariables("Hi {{name}}, {{ date }} {{unknown}}!", []blob.Annotation{
+		{Type: "Bold", Starts: []int32{0}, Ends: []int32{2}},    // Before the placeholders.
+		{Type: "Italic", Starts: []int32{3}, Ends: []int32{11}}, // Exactly the first placeholder.
+		{Type: "Link", Starts: []int32{5}, Ends: []int32{23}},   // Starts inside the first placeholder, ends after the second.
+		{Type: "Code", Starts: []int32{24}, Ends: []int32{35}},  // Placeholder without a value.
+	}, vars)
+
+	require.Equal(t, "Hi Ünïcode, 2024 {{unknown}}!", text)
+	require.Equal(t, []blob.Annotation{
+		{Type: "Bold", Starts: []int32{0}, Ends: []int32{2}},
+		{Type: "Italic", Starts: []int32{3}, Ends: []int32{10}},
+		{Type: "Link", Starts: []int32{3}, Ends: []int32{16}},
+		{Type: "Code", Starts: []int32{17}, Ends: []int32{28}},
+	}, anns)
+
+	text, anns = substituteVariables("Nothing to replace", nil, vars)
+	require.Equal(t, "Nothing to replace", text)
+	require.Nil(t, anns)
+}
+
+func TestInstantiate(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+
+	tpl := must.Do2(New(must.Do2(blob.NewIRI(alice.Principal(), "/template")), cclock.New()))
+	must.Do(tpl.SetMetadata("title", "Meeting {{date}}"))
+	must.Do(tpl.SetAttribute("", []string{TemplateKey}, true))
+	must.Do(tpl.MoveBlock("h1", "", ""))
+	must.Do(tpl.ReplaceBlock(&documents.Block{Id: "h1", Type: "Heading", Text: "Notes"}))
+	must.Do(tpl.MoveBlock("p1", "h1", ""))
+	must.Do(tpl.ReplaceBlock(&documents.Block{Id: "p1", Type: "Paragraph", Text: "Attendees: {{people}}"}))
+	must.Do(tpl.MoveBlock("p2", "h1", "p1"))
+	must.Do(tpl.ReplaceBlock(&documents.Block{Id: "p2", Type: "Paragraph", Text: "Action items"}))
+	must.Do2(tpl.SignChange(alice))
+	require.True(t, tpl.IsTemplate())
+
+	iri := must.Do2(blob.NewIRI(alice.Principal(), "/meeting"))
+	doc := must.Do2(New(iri, cclock.New()))
+	require.NoError(t, doc.Instantiate(tpl, map[string]string{"date": "2024-01-01", "people": "Alice, Bob"}))
+	change := must.Do2(doc.SignChange(alice))
+
+	doc = must.Do2(New(iri, cclock.New()))
+	must.Do(doc.ApplyChange(change.CID, change.Decoded))
+	require.False(t, doc.IsTemplate(), "template marker must not be copied")
+
+	docpb := must.Do2(doc.Hydrate(t.Context()))
+	require.Equal(t, "Meeting 2024-01-01", docpb.Metadata.Fields["title"].GetStringValue())
+	require.Len(t, docpb.Content, 1)
+	require.Equal(t, "Notes", docpb.Content[0].Block.Text)
+	require.Len(t, docpb.Content[0].Children, 2)
+	require.Equal(t, "Attendees: Alice, Bob", docpb.Content[0].Children[0].Block.Text)
+	require.Equal(t, "Action items", docpb.Content[0].Children[1].Block.Text)
+
+	require.Error(t, doc.Instantiate(tpl, nil), "existing documents can't be filled from templates")
+}
@@ -0,0 +1,412 @@
+package documents
+
+import (
+	"context"
+	"encoding/json"
+	"seed/backend/blob"
+	"seed/backend/core"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/cclock"
+	"seed/backend/util/dqb"
+	"seed/backend/util/errutil"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// documentMove describes how a single document is moved by MoveDocumentTree.
+type documentMove struct {
+	OldPath          string
+	NewPath          string
+	Genesis          cid.Cid
+	State            blob.DocumentState
+	TargetGeneration int64
+}
+
+// MoveDocumentTree implements Documents API v3.
+func (srv *Server) MoveDocumentTree(ctx context.Context, in *documents.MoveDocumentTreeRequest) (*documents.MoveDocumentTreeResponse, error) {
+	{
+		if in.Account == "" {
+			return nil, errutil.MissingArgument("account")
+		}
+
+		// Home documents can't be redirected, so they can't be moved either.
+		if in.SourcePath == "" {
+			return nil, errutil.MissingArgument("source_path")
+		}
+
+		if in.TargetPath == "" {
+			return nil, errutil.MissingArgument("target_path")
+		}
+
+		if in.SigningKeyName == "" {
+			return nil, errutil.MissingArgument("signing_key_name")
+		}
+	}
+
+	ns, err := core.DecodePrincipal(in.Account)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse account '%s': %v", in.Account, err)
+	}
+
+	sourceIRI, err := makeIRI(ns, in.SourcePath)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid source path: %v", err)
+	}
+
+	if _, err := makeIRI(ns, in.TargetPath); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid target path: %v", err)
+	}
+
+	if strings.HasPrefix(in.TargetPath+"/", in.SourcePath+"/") {
+		return nil, status.Errorf(codes.InvalidArgument, "target path '%s' must not be inside the source tree '%s'", in.TargetPath, in.SourcePath)
+	}
+
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Root Refs of multi-signature organizations need signatures of other members.
+	// Moved documents are never the root, but the root could link to them.
+	org, isOrg, err := srv.idx.GetOrgMembership(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	// We check everything before publishing anything, to avoid moving only a part of the tree.
+	moves, err := srv.planDocumentMoves(ctx, kp, ns, sourceIRI, in.SourcePath, in.TargetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &documents.MoveDocumentTreeResponse{
+		MovedDocuments: make([]*documents.MovedDocument, len(moves)),
+	}
+
+	clock := cclock.New()
+
+	// All the documents are published at the new paths before any redirect is created,
+	// so redirects never point to a missing document.
+	for i, m := range moves {
+		ref, err := blob.NewRef(kp, m.TargetGeneration, m.Genesis, ns, m.NewPath, m.State.Heads, clock.MustNow(), m.State.Visibility)
+		if err != nil {
+			return nil, err
+		}
+
+		refpb, err := srv.publishRef(ctx, ref, org, false)
+		if err != nil {
+			return nil, err
+		}
+
+		srv.scheduleSnapshot(kp, ns, m.NewPath)
+
+		out.MovedDocuments[i] = &documents.MovedDocument{
+			SourcePath: m.OldPath,
+			TargetPath: m.NewPath,
+			Ref:        refpb,
+		}
+	}
+
+	// Redirects use the generation of the moved document, which makes it deleted at the old path.
+	for i, m := range moves {
+		redirect, err := blob.NewRefRedirect(kp, m.State.Generation, m.Genesis, ns, m.OldPath, blob.RedirectTarget{Space: ns, Path: m.NewPath}, clock.MustNow())
+		if err != nil {
+			return nil, err
+		}
+
+		refpb, err := srv.publishRef(ctx, redirect, org, false)
+		if err != nil {
+			return nil, err
+		}
+
+		out.MovedDocuments[i].Redirect = refpb
+	}
+
+	if in.RewriteLinks {
+		if err := srv.rewriteMovedLinks(ctx, kp, ns, org, isOrg, moves, out); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// planDocumentMoves finds the documents in the source tree, and checks that all of them can be moved.
+func (srv *Server) planDocumentMoves(ctx context.Context, kp *core.KeyPair, ns core.Principal, sourceIRI blob.IRI, sourcePath, targetPath string) ([]documentMove, error) {
+	type treeDoc struct {
+		IRI     blob.IRI
+		Genesis string
+	}
+
+	var docs []treeDoc
+	if err := srv.db.WithSave(ctx, func(conn *sqlite.Conn) (err error) {
+		rows, discard, check := sqlitex.Query(conn, qListDocumentTree(), sourceIRI).All()
+		defer discard(&err)
+
+		for row := range rows {
+			docs = append(docs, treeDoc{IRI: blob.IRI(row.ColumnText(0)), Genesis: row.ColumnText(1)})
+		}
+
+		return check()
+	}); err != nil {
+		return nil, err
+	}
+
+	if len(docs) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no documents found under '%s'", sourcePath)
+	}
+
+	moves := make([]documentMove, 0, len(docs))
+	for _, d := range docs {
+		_, oldPath, err := d.IRI.SpacePath()
+		if err != nil {
+			return nil, err
+		}
+
+		m := documentMove{
+			OldPath: oldPath,
+			NewPath: targetPath + strings.TrimPrefix(oldPath, sourcePath),
+		}
+
+		if err := srv.checkWriteAccess(ctx, ns, m.OldPath, kp); err != nil {
+			return nil, err
+		}
+
+		if err := srv.checkWriteAccess(ctx, ns, m.NewPath, kp); err != nil {
+			return nil, err
+		}
+
+		m.Genesis, err = cid.Decode(d.Genesis)
+		if err != nil {
+			return nil, err
+		}
+
+		m.State, err = srv.idx.ResolveLatest(ctx, d.IRI)
+		if err != nil {
+			return nil, err
+		}
+
+		// Redirects are always public, so they would disclose the paths of private documents.
+		if m.State.Visibility == blob.VisibilityPrivate {
+			return nil, status.Errorf(codes.FailedPrecondition, "private document '%s' can't be moved", m.OldPath)
+		}
+
+		newIRI, err := makeIRI(ns, m.NewPath)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid target path: %v", err)
+		}
+
+		// Deleted documents at the new path are replaced with a newer generation.
+		// Reusing an existing generation would bring back its redirects and tombstones.
+		m.TargetGeneration = m.State.Generation
+		if err := srv.db.WithSave(ctx, func(conn *sqlite.Conn) (err error) {
+			rows, discard, check := sqlitex.Query(conn, qLatestDocumentGeneration(), newIRI).All()
+			defer discard(&err)
+
+			for row := range rows {
+				generation, isDeleted := row.ColumnInt64(0), row.ColumnInt(1) == 1
+				if !isDeleted {
+					return status.Errorf(codes.FailedPrecondition, "document '%s' already exists", m.NewPath)
+				}
+
+				if generation >= m.TargetGeneration {
+					m.TargetGeneration = generation + 1
+				}
+			}
+
+			return check()
+		}); err != nil {
+			return nil, err
+		}
+
+		moves = append(moves, m)
+	}
+
+	return moves, nil
+}
+
+// rewriteMovedLinks updates the links to the moved documents in all the documents of the same space,
+// including the moved documents themselves, which are updated at their new paths.
+// The root Ref of a multi-signature organization is returned partially signed, if it needs more signatures.
+func (srv *Server) rewriteMovedLinks(ctx context.Context, kp *core.KeyPair, ns core.Principal, org blob.OrgMembership, isOrg bool, moves []documentMove, out *documents.MoveDocumentTreeResponse) error {
+	spaceIRI, err := makeIRI(ns, "")
+	if err != nil {
+		return err
+	}
+
+	var (
+		oldIRIs = make([]string, len(moves))
+		moved   = make(map[string]string, len(moves))
+	)
+	for i, m := range moves {
+		oldIRIs[i] = string(spaceIRI) + m.OldPath
+		moved[oldIRIs[i]] = string(spaceIRI) + m.NewPath
+	}
+
+	targets, err := json.Marshal(oldIRIs)
+	if err != nil {
+		return err
+	}
+
+	var linking []string
+	if err := srv.db.WithSave(ctx, func(conn *sqlite.Conn) (err error) {
+		rows, discard, check := sqlitex.Query(conn, qListLinkingDocuments(), string(targets), spaceIRI).All()
+		defer discard(&err)
+
+		seen := make(map[string]struct{})
+		for row := range rows {
+			iri := row.ColumnText(0)
+			if newIRI, ok := moved[iri]; ok {
+				iri = newIRI
+			}
+
+			if _, ok := seen[iri]; ok {
+				continue
+			}
+			seen[iri] = struct{}{}
+			linking = append(linking, iri)
+		}
+
+		return check()
+	}); err != nil {
+		return err
+	}
+
+	rewrite := func(link string) (string, bool) {
+		for oldIRI, newIRI := range moved {
+			rest, ok := strings.CutPrefix(link, oldIRI)
+			if !ok {
+				continue
+			}
+
+			if rest == "" || rest[0] == '?' || rest[0] == '#' {
+				return newIRI + rest, true
+			}
+		}
+
+		return "", false
+	}
+
+	for _, iri := range linking {
+		_, path, err := blob.IRI(iri).SpacePath()
+		if err != nil {
+			return err
+		}
+
+		// Redirected and deleted documents don't need to be updated.
+		state, err := srv.idx.ResolveLatest(ctx, blob.IRI(iri))
+		if err != nil {
+			if code := status.Code(err); code == codes.NotFound || code == codes.FailedPrecondition {
+				continue
+			}
+			return err
+		}
+
+		// Like in CreateRef, members sign the root of an organization without capabilities.
+		isOrgRoot := isOrg && path == ""
+		if isOrgRoot {
+			if !org.IsMember(kp.Principal()) {
+				out.SkippedDocuments = append(out.SkippedDocuments, path)
+				continue
+			}
+		} else if err := srv.checkWriteAccess(ctx, ns, path, kp); err != nil {
+			if status.Code(err) != codes.PermissionDenied {
+				return err
+			}
+			out.SkippedDocuments = append(out.SkippedDocuments, path)
+			continue
+		}
+
+		doc, err := srv.loadDocument(ctx, ns, path, state.Heads, false)
+		if err != nil {
+			return err
+		}
+
+		count, err := doc.RewriteLinks(rewrite)
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			continue
+		}
+
+		if err := srv.encryptPrivateDocument(ctx, doc, ns, doc.Visibility()); err != nil {
+			return err
+		}
+
+		change, err := doc.SignChange(kp)
+		if err != nil {
+			return err
+		}
+
+		ref, err := doc.Ref(kp, doc.Visibility())
+		if err != nil {
+			return err
+		}
+
+		if err := srv.idx.Put(ctx, change); err != nil {
+			return err
+		}
+
+		refpb, err := srv.publishRef(ctx, ref, org, isOrgRoot)
+		if err != nil {
+			return err
+		}
+
+		if len(refpb.PartialRef) > 0 {
+			out.PartialRefs = append(out.PartialRefs, refpb)
+			continue
+		}
+
+		srv.scheduleSnapshot(kp, ns, path)
+
+		out.RewrittenDocuments = append(out.RewrittenDocuments, path)
+	}
+
+	return nil
+}
+
+// Alive documents at the given IRI and under it, sorted by IRI.
+var qListDocumentTree = dqb.Str(`
+	SELECT r.iri, dg.genesis
+	FROM resources r
+	CROSS JOIN document_generations dg
+	WHERE r.id = dg.resource
+	AND (r.iri = :iri OR (r.iri >= :iri || '/' AND r.iri < :iri || '0'))
+	GROUP BY dg.resource
+	HAVING dg.generation = MAX(dg.generation) AND dg.is_deleted = 0
+	ORDER BY r.iri
+`)
+
+var qLatestDocumentGeneration = dqb.Str(`
+	SELECT dg.generation, dg.is_deleted
+	FROM resources r
+	JOIN document_generations dg ON dg.resource = r.id
+	WHERE r.iri = ?
+	ORDER BY dg.generation DESC
+	LIMIT 1
+`)
+
+// Documents in the space with changes linking to any of the IRIs in the JSON array.
+// Changes are mapped to documents through the Refs with the same genesis.
+var qListLinkingDocuments = dqb.Str(`
+	WITH targets AS (
+		SELECT r.id
+		FROM resources r
+		WHERE r.iri IN (SELECT value FROM json_each(:targets))
+	)
+	SELECT DISTINCT r.iri
+	FROM targets t
+	JOIN resource_links rl ON rl.target = t.id
+	JOIN structural_blobs sb ON sb.id = rl.source AND sb.type = 'Change'
+	JOIN structural_blobs refs ON refs.type = 'Ref' AND refs.genesis_blob = COALESCE(sb.genesis_blob, sb.id)
+	JOIN resources r ON r.id = refs.resource
+	WHERE r.iri = :space OR (r.iri >= :space || '/' AND r.iri < :space || '0')
+	ORDER BY r.iri
+`)
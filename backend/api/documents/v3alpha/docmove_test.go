@@ -0,0 +1,172 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/api/apitest"
+	"seed/backend/blob"
+	"seed/backend/core"
+	"seed/backend/core/coretest"
+	pb "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/cclock"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMoveDocumentTree(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+	account := alice.me.Account.Principal()
+	space := "hm://" + account.String()
+
+	link := func(target string) *pb.Annotation {
+		return &pb.Annotation{Type: "Link", Link: target, Starts: []int32{0}, Ends: []int32{4}}
+	}
+
+	publish := func(path, text string, annotations ...*pb.Annotation) *pb.Document {
+		t.Helper()
+		doc, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, path, "", "main").
+			SetMetadata("title", path).
+			MoveBlock("b1", "", "").
+			ReplaceBlock("b1", "paragraph", text, annotations...).
+			Build(),
+		)
+		require.NoError(t, err)
+		return doc
+	}
+
+	docs := publish("/docs", "Docs")
+	docsA := publish("/docs/a", "See B", link(space+"/docs/b?l"))
+	publish("/docs/b", "B")
+	publish("/docs-extra", "Not in the tree")
+	other := publish("/other", "Read the docs", link(space+"/docs/a#b1"), link(space+"/docs-extra"))
+
+	_, err := alice.MoveDocumentTree(ctx, &pb.MoveDocumentTreeRequest{
+		Account:        docs.Account,
+		SourcePath:     "/docs",
+		TargetPath:     "/docs/nested",
+		SigningKeyName: "main",
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "tree can't be moved inside itself")
+
+	_, err = alice.MoveDocumentTree(ctx, &pb.MoveDocumentTreeRequest{
+		Account:        docs.Account,
+		SourcePath:     "/docs",
+		TargetPath:     "/other",
+		SigningKeyName: "main",
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "existing documents must not be overwritten")
+
+	out, err := alice.MoveDocumentTree(ctx, &pb.MoveDocumentTreeRequest{
+		Account:        docs.Account,
+		SourcePath:     "/docs",
+		TargetPath:     "/handbook",
+		SigningKeyName: "main",
+		RewriteLinks:   true,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, out.MovedDocuments, 3, "sibling with the same prefix must not be moved")
+	for i, want := range [][2]string{{"/docs", "/handbook"}, {"/docs/a", "/handbook/a"}, {"/docs/b", "/handbook/b"}} {
+		require.Equal(t, want[0], out.MovedDocuments[i].SourcePath)
+		require.Equal(t, want[1], out.MovedDocuments[i].TargetPath)
+		require.Equal(t, want[1], out.MovedDocuments[i].Ref.Path)
+		require.Equal(t, want[0], out.MovedDocuments[i].Redirect.Path)
+		require.Equal(t, want[1], out.MovedDocuments[i].Redirect.Target.GetRedirect().Path)
+	}
+	require.Equal(t, []string{"/handbook/a", "/other"}, out.RewrittenDocuments)
+	require.Empty(t, out.SkippedDocuments)
+
+	moved, err := alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: docs.Account, Path: "/handbook/a"})
+	require.NoError(t, err)
+	require.Equal(t, docsA.Genesis, moved.Genesis, "moved document must keep its genesis")
+	require.Equal(t, space+"/handbook/b?l", moved.Content[0].Block.Annotations[0].Link)
+
+	_, err = alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: docs.Account, Path: "/docs/a"})
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.FailedPrecondition, st.Code())
+	require.Len(t, st.Details(), 1)
+	require.Equal(t, "/handbook/a", st.Details()[0].(*pb.RedirectErrorDetails).TargetPath)
+
+	_, err = alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: docs.Account, Path: "/docs-extra"})
+	require.NoError(t, err)
+
+	updated, err := alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: other.Account, Path: other.Path})
+	require.NoError(t, err)
+	require.Equal(t, space+"/handbook/a#b1", updated.Content[0].Block.Annotations[0].Link)
+	require.Equal(t, space+"/docs-extra", updated.Content[0].Block.Annotations[1].Link, "links outside of the tree must not change")
+
+	citations, err := alice.ListCitations(ctx, &pb.ListCitationsRequest{Iri: space + "/handbook/a"})
+	require.NoError(t, err)
+	var sources []string
+	for _, c := range citations.Citations {
+		sources = append(sources, c.Source)
+	}
+	require.Contains(t, sources, space+"/other")
+}
+
+func TestMoveDocumentTreeOrgRoot(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+	account := alice.me.Account.Principal()
+	space := "hm://" + account.String()
+	bob := coretest.NewTester("bob").Account
+	carol := coretest.NewTester("carol").Account
+	require.NoError(t, alice.keys.StoreKey(ctx, "bob", bob))
+	require.NoError(t, alice.keys.StoreKey(ctx, "carol", carol))
+
+	publish := func(path, text string, annotations ...*pb.Annotation) *pb.Document {
+		t.Helper()
+		doc, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, path, "", "main").
+			SetMetadata("title", path).
+			MoveBlock("b1", "", "").
+			ReplaceBlock("b1", "paragraph", text, annotations...).
+			Build(),
+		)
+		require.NoError(t, err)
+		return doc
+	}
+
+	publish("", "Read the docs", &pb.Annotation{Type: "Link", Link: space + "/docs", Starts: []int32{0}, Ends: []int32{4}})
+	publish("/docs", "Docs")
+
+	clock := cclock.New()
+	ms, err := blob.NewMembership(alice.me.Account, account, []core.Principal{bob.Principal(), carol.Principal()}, 2, clock.MustNow())
+	require.NoError(t, err)
+	cpb, err := blob.NewCapability(alice.me.Account, bob.Principal(), account, "", blob.RoleWriter, "", clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, alice.idx.PutMany(ctx, []blocks.Block{ms, cpb}))
+
+	out, err := alice.MoveDocumentTree(ctx, &pb.MoveDocumentTreeRequest{
+		Account:        account.String(),
+		SourcePath:     "/docs",
+		TargetPath:     "/handbook",
+		SigningKeyName: "bob",
+		RewriteLinks:   true,
+	})
+	require.NoError(t, err)
+	require.Empty(t, out.RewrittenDocuments, "root ref without enough signatures must not be reported as rewritten")
+	require.Len(t, out.PartialRefs, 1)
+	require.Equal(t, "", out.PartialRefs[0].Path)
+	require.Empty(t, out.PartialRefs[0].Id)
+
+	home, err := alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: account.String()})
+	require.NoError(t, err)
+	require.Equal(t, space+"/docs", home.Content[0].Block.Annotations[0].Link)
+
+	ref, err := alice.CreateRef(ctx, &pb.CreateRefRequest{PartialRef: out.PartialRefs[0].PartialRef, SigningKeyName: "carol"})
+	require.NoError(t, err)
+	require.NotEmpty(t, ref.Id)
+
+	home, err = alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: account.String()})
+	require.NoError(t, err)
+	require.Equal(t, space+"/handbook", home.Content[0].Block.Annotations[0].Link)
+}
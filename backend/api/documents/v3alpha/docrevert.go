@@ -0,0 +1,156 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/api/documents/v3alpha/docmodel"
+	"seed/backend/blob"
+	"seed/backend/core"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/errutil"
+
+	"github.com/ipfs/go-cid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RevertDocument implements Documents API v3.
+func (srv *Server) RevertDocument(ctx context.Context, in *documents.RevertDocumentRequest) (*documents.RevertDocumentResponse, error) {
+	{
+		if in.Account == "" {
+			return nil, errutil.MissingArgument("account")
+		}
+
+		if in.SigningKeyName == "" {
+			return nil, errutil.MissingArgument("signing_key_name")
+		}
+
+		if in.TargetVersion == "" {
+			return nil, errutil.MissingArgument("target_version")
+		}
+	}
+
+	ns, err := core.DecodePrincipal(in.Account)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse account '%s': %v", in.Account, err)
+	}
+
+	targetHeads, err := docmodel.Version(in.TargetVersion).Parse()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse target version: %v", err)
+	}
+
+	baseHeads, err := docmodel.Version(in.BaseVersion).Parse()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse base version: %v", err)
+	}
+
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The same rules as in CreateRef apply here,
+	// because reverting publishes a new version of the document.
+	org, isOrg, err := srv.idx.GetOrgMembership(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	isOrgRoot := isOrg && in.Path == ""
+
+	if isOrgRoot {
+		if !org.IsMember(kp.Principal()) {
+			return nil, status.Errorf(codes.PermissionDenied, "key '%s' is not a member of organization '%s'", kp.Principal(), ns)
+		}
+	} else if err := srv.checkWriteAccess(ctx, ns, in.Path, kp); err != nil {
+		return nil, err
+	}
+
+	if len(baseHeads) == 0 {
+		iri, err := makeIRI(ns, in.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		state, err := srv.idx.ResolveLatest(ctx, iri)
+		if err != nil {
+			return nil, err
+		}
+		baseHeads = state.Heads
+	}
+
+	// Checkout can't go back past a snapshot, so we replay the entire history to get the target version.
+	// The target version doesn't have to be an ancestor of the base version.
+	full, err := srv.loadFullDocument(ctx, ns, in.Path, unionHeads(baseHeads, targetHeads))
+	if err != nil {
+		return nil, err
+	}
+
+	if full.Visibility() == blob.VisibilityPrivate {
+		if err := srv.denyPrivateDocument(ctx, ns, in.Path); err != nil {
+			return nil, err
+		}
+	}
+
+	target, err := full.Checkout(targetHeads)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to check out target version: %v", err)
+	}
+
+	// The new change is created on top of a separate instance of the document,
+	// which also gets the generation and visibility of the current Ref.
+	doc, err := srv.loadDocument(ctx, ns, in.Path, baseHeads, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := doc.Revert(target, in.BlockIds); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to revert document: %v", err)
+	}
+
+	if err := srv.encryptPrivateDocument(ctx, doc, ns, doc.Visibility()); err != nil {
+		return nil, err
+	}
+
+	change, err := doc.SignChange(kp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(change.Decoded.Body.Ops) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "nothing to revert: the document already matches the target version")
+	}
+
+	ref, err := doc.Ref(kp, doc.Visibility())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := srv.idx.Put(ctx, change); err != nil {
+		return nil, err
+	}
+
+	refpb, err := srv.publishRef(ctx, ref, org, isOrgRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isOrgRoot {
+		srv.scheduleSnapshot(kp, ns, in.Path)
+	}
+
+	result, err := srv.loadDocument(ctx, ns, in.Path, []cid.Cid{change.CID}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	docpb, err := result.Hydrate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &documents.RevertDocumentResponse{
+		ChangeId: change.CID.String(),
+		Ref:      refpb,
+		Document: docpb,
+	}, nil
+}
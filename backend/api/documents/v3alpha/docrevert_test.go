@@ -0,0 +1,119 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/api/apitest"
+	pb "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/testutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRevertDocument(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+	account := alice.me.Account.Principal()
+
+	v1, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, "/revert", "", "main").
+		SetMetadata("title", "Original").
+		MoveBlock("b1", "", "").
+		ReplaceBlock("b1", "paragraph", "Hello").
+		MoveBlock("b2", "", "b1").
+		ReplaceBlock("b2", "paragraph", "World").
+		Build(),
+	)
+	require.NoError(t, err)
+
+	v2, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, "/revert", v1.Version, "main").
+		SetMetadata("title", "Changed").
+		SetMetadata("summary", "New summary").
+		MoveBlock("b2", "", "").
+		ReplaceBlock("b1", "paragraph", "Hello, edited").
+		DeleteBlock("b2").
+		MoveBlock("b3", "", "b1").
+		ReplaceBlock("b3", "paragraph", "Added").
+		Build(),
+	)
+	require.NoError(t, err)
+
+	// Reverting only one block keeps the rest of the latest version.
+	partial, err := alice.RevertDocument(ctx, &pb.RevertDocumentRequest{
+		Account:        v1.Account,
+		Path:           v1.Path,
+		SigningKeyName: "main",
+		TargetVersion:  v1.Version,
+		BlockIds:       []string{"b1"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Changed", partial.Document.Metadata.Fields["title"].GetStringValue())
+	require.Len(t, partial.Document.Content, 2)
+	require.Equal(t, "Hello", partial.Document.Content[0].Block.Text)
+	require.Equal(t, "Added", partial.Document.Content[1].Block.Text)
+	require.Equal(t, partial.ChangeId, partial.Document.Version)
+	require.Equal(t, partial.ChangeId, partial.Ref.GetTarget().GetVersion().GetVersion())
+
+	full, err := alice.RevertDocument(ctx, &pb.RevertDocumentRequest{
+		Account:        v1.Account,
+		Path:           v1.Path,
+		SigningKeyName: "main",
+		TargetVersion:  v1.Version,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, v1.Version, full.Document.Version, "revert must create a new change")
+
+	latest, err := alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: v1.Account, Path: v1.Path})
+	require.NoError(t, err)
+	require.Equal(t, full.Document.Version, latest.Version)
+	require.Equal(t, blockTexts(v1.Content), blockTexts(latest.Content), "content must match the target version")
+	testutil.StructsEqual(v1.Metadata, latest.Metadata).Compare(t, "metadata must match the target version")
+
+	// The history is preserved.
+	changes, err := alice.ListDocumentChanges(ctx, &pb.ListDocumentChangesRequest{Account: v1.Account, Path: v1.Path, Version: latest.Version})
+	require.NoError(t, err)
+	require.Len(t, changes.Changes, 4)
+
+	_, err = alice.RevertDocument(ctx, &pb.RevertDocumentRequest{
+		Account:        v1.Account,
+		Path:           v1.Path,
+		SigningKeyName: "main",
+		TargetVersion:  v1.Version,
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "reverting to the same content again must fail")
+
+	_, err = alice.RevertDocument(ctx, &pb.RevertDocumentRequest{
+		Account:        v1.Account,
+		Path:           v1.Path,
+		SigningKeyName: "main",
+		TargetVersion:  v2.Version,
+		BlockIds:       []string{"missing"},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "unknown blocks must be rejected")
+
+	_, err = alice.RevertDocument(ctx, &pb.RevertDocumentRequest{
+		Account:        v1.Account,
+		Path:           v1.Path,
+		SigningKeyName: "main",
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "target version is required")
+}
+
+// blockTexts renders the block tree as indented lines of block IDs and texts,
+// ignoring revisions, which change when reverted blocks are written again.
+func blockTexts(content []*pb.BlockNode) string {
+	var sb strings.Builder
+	var walk func(depth int, nodes []*pb.BlockNode)
+	walk = func(depth int, nodes []*pb.BlockNode) {
+		for _, n := range nodes {
+			sb.WriteString(strings.Repeat("  ", depth) + n.Block.Id + ": " + n.Block.Text + "\n")
+			walk(depth+1, n.Children)
+		}
+	}
+	walk(0, content)
+	return sb.String()
+}
@@ -0,0 +1,519 @@
+package documents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"seed/backend/blob"
+	"seed/backend/core"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/ipfs"
+	"seed/backend/util/dqb"
+	"seed/backend/util/errutil"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"slices"
+	"time"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multicodec"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxSchedulerWait is the longest the scheduler sleeps between checks for due Refs.
+// Timers don't advance while the machine is suspended, so we don't rely on a single long timer.
+const maxSchedulerWait = time.Minute
+
+// Bounds of the backoff between the attempts to publish a scheduled Ref that failed.
+const (
+	minPublishRetryWait = time.Minute
+	maxPublishRetryWait = time.Hour
+)
+
+// publishRetryWait returns the time to wait before the next attempt to publish a scheduled Ref,
+// after the given number of failed attempts. It doubles with each attempt, up to the maximum.
+func publishRetryWait(attempts int) time.Duration {
+	wait := minPublishRetryWait
+	for range attempts - 1 {
+		wait *= 2
+		if wait >= maxPublishRetryWait {
+			return maxPublishRetryWait
+		}
+	}
+	return wait
+}
+
+// ScheduleRef implements Documents API v3.
+func (srv *Server) ScheduleRef(ctx context.Context, in *documents.ScheduleRefRequest) (*documents.ScheduledRef, error) {
+	{
+		if len(in.Ref) == 0 {
+			return nil, errutil.MissingArgument("ref")
+		}
+
+		if in.PublishTime == nil {
+			return nil, errutil.MissingArgument("publish_time")
+		}
+	}
+
+	refBlk := ipfs.NewBlock(multicodec.DagCbor, in.Ref)
+	ref := &blob.Ref{}
+	if err := cbornode.DecodeInto(in.Ref, ref); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode ref: %v", err)
+	}
+
+	if ref.Type != blob.TypeRef {
+		return nil, status.Errorf(codes.InvalidArgument, "ref has invalid type '%s'", ref.Type)
+	}
+
+	if err := ref.VerifySignatures(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "ref has invalid signatures: %v", err)
+	}
+
+	// Root Refs of multi-signature organizations are signed by the members,
+	// who don't need capabilities, but need to collect enough signatures.
+	var isOrgRoot bool
+	if ref.Path == "" {
+		org, isOrg, err := srv.idx.GetOrgMembership(ctx, ref.Space())
+		if err != nil {
+			return nil, err
+		}
+
+		if isOrg && !org.ApprovedBy(ref.Signers()) {
+			return nil, status.Errorf(codes.FailedPrecondition, "root ref of organization '%s' doesn't have enough signatures", ref.Space())
+		}
+		isOrgRoot = isOrg
+	}
+
+	if !isOrgRoot {
+		valid, err := srv.idx.IsValidWriter(ctx, ref.Space(), ref.Path, ref.Signer)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			return nil, status.Errorf(codes.PermissionDenied, "key '%s' is not allowed to write to space '%s' in path '%s'", ref.Signer, ref.Space(), ref.Path)
+		}
+	}
+
+	published, err := srv.idx.Has(ctx, refBlk.Cid())
+	if err != nil {
+		return nil, err
+	}
+	if published {
+		return nil, status.Errorf(codes.FailedPrecondition, "ref '%s' is already published", refBlk.Cid())
+	}
+
+	// Blobs keep their own CIDs, because media and other non-CBOR blobs can be held back too.
+	held := make(map[cid.Cid]blocks.Block, len(in.Blobs))
+	for i, b := range in.Blobs {
+		if b.Cid == "" {
+			blk := ipfs.NewBlock(multicodec.DagCbor, b.Data)
+			held[blk.Cid()] = blk
+			continue
+		}
+
+		c, err := cid.Decode(b.Cid)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to decode cid of blob at index %d: %v", i, err)
+		}
+
+		cc, err := c.Prefix().Sum(b.Data)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to hash blob at index %d: %v", i, err)
+		}
+
+		if !c.Equals(cc) {
+			return nil, status.Errorf(codes.InvalidArgument, "cid of blob at index %d doesn't match its data", i)
+		}
+
+		blk, err := blocks.NewBlockWithCid(b.Data, cc)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to process blob at index %d: %v", i, err)
+		}
+		held[cc] = blk
+	}
+
+	for _, h := range ref.Heads {
+		if _, ok := held[h]; ok {
+			continue
+		}
+
+		ok, err := srv.idx.Has(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, status.Errorf(codes.FailedPrecondition, "head '%s' of the ref is neither among the blobs nor available locally", h)
+		}
+	}
+
+	iri, err := makeIRI(ref.Space(), ref.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	createTime := time.Now()
+	if err := srv.db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		var exists bool
+		if err := sqlitex.Exec(conn, qScheduledRefExists(), func(*sqlite.Stmt) error {
+			exists = true
+			return nil
+		}, refBlk.Cid().String()); err != nil {
+			return err
+		}
+		if exists {
+			return status.Errorf(codes.AlreadyExists, "ref '%s' is already scheduled", refBlk.Cid())
+		}
+
+		publishTime := in.PublishTime.AsTime().UnixMilli()
+		if err := sqlitex.Exec(conn, qInsertScheduledRef(), nil,
+			refBlk.Cid().String(), in.Ref, string(iri), publishTime, createTime.UnixMilli(), publishTime,
+		); err != nil {
+			return err
+		}
+
+		id := conn.LastInsertRowID()
+		for c, blk := range held {
+			if err := sqlitex.Exec(conn, qInsertScheduledRefBlob(), nil, id, c.String(), blk.RawData()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	srv.notifyScheduler()
+
+	pb, err := refToProto(refBlk.Cid(), ref)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &documents.ScheduledRef{
+		Ref:         pb,
+		PublishTime: timestamppb.New(time.UnixMilli(in.PublishTime.AsTime().UnixMilli())),
+		CreateTime:  timestamppb.New(time.UnixMilli(createTime.UnixMilli())),
+		Blobs:       make([]string, 0, len(held)),
+	}
+	for c := range held {
+		out.Blobs = append(out.Blobs, c.String())
+	}
+	slices.Sort(out.Blobs)
+
+	return out, nil
+}
+
+// ListScheduledRefs implements Documents API v3.
+func (srv *Server) ListScheduledRefs(ctx context.Context, in *documents.ListScheduledRefsRequest) (*documents.ListScheduledRefsResponse, error) {
+	var space string
+	if in.Account != "" {
+		ns, err := core.DecodePrincipal(in.Account)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse account '%s': %v", in.Account, err)
+		}
+		space = "hm://" + ns.String()
+	}
+
+	out := &documents.ListScheduledRefsResponse{}
+	if err := srv.db.WithSave(ctx, func(conn *sqlite.Conn) (err error) {
+		rows, discard, check := sqlitex.Query(conn, qListScheduledRefs(), space).All()
+		defer discard(&err)
+		for row := range rows {
+			var (
+				refID       = row.ColumnText(0)
+				data        = row.ColumnBytes(1)
+				publishTime = row.ColumnInt64(2)
+				createTime  = row.ColumnInt64(3)
+				publishErr  = row.ColumnText(4)
+				blobsJSON   = row.ColumnText(5)
+				attempts    = row.ColumnInt(6)
+				nextAttempt = row.ColumnInt64(7)
+			)
+
+			c, err := cid.Decode(refID)
+			if err != nil {
+				return err
+			}
+
+			ref := &blob.Ref{}
+			if err := cbornode.DecodeInto(data, ref); err != nil {
+				return fmt.Errorf("failed to decode scheduled ref %s: %w", refID, err)
+			}
+
+			pb, err := refToProto(c, ref)
+			if err != nil {
+				return err
+			}
+
+			item := &documents.ScheduledRef{
+				Ref:          pb,
+				PublishTime:  timestamppb.New(time.UnixMilli(publishTime)),
+				CreateTime:   timestamppb.New(time.UnixMilli(createTime)),
+				PublishError: publishErr,
+			}
+			if attempts > 0 {
+				item.RetryTime = timestamppb.New(time.UnixMilli(nextAttempt))
+			}
+			if err := json.Unmarshal([]byte(blobsJSON), &item.Blobs); err != nil {
+				return err
+			}
+
+			out.ScheduledRefs = append(out.ScheduledRefs, item)
+		}
+		return check()
+	}); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// CancelScheduledRef implements Documents API v3.
+func (srv *Server) CancelScheduledRef(ctx context.Context, in *documents.CancelScheduledRefRequest) (*emptypb.Empty, error) {
+	if in.Id == "" {
+		return nil, errutil.MissingArgument("id")
+	}
+
+	c, err := cid.Decode(in.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse Ref ID: %v", err)
+	}
+
+	if err := srv.db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		if err := sqlitex.Exec(conn, qDeleteScheduledRef(), nil, c.String()); err != nil {
+			return err
+		}
+
+		if conn.Changes() == 0 {
+			return status.Errorf(codes.NotFound, "ref '%s' is not scheduled", c)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// RunScheduler publishes scheduled Refs when their publish time arrives,
+// rotates the content keys of our spaces when member capabilities expire,
+// and creates the pending document snapshots.
+// It blocks until the context is canceled.
+func (srv *Server) RunScheduler(ctx context.Context) error {
+	for {
+		wait := maxSchedulerWait
+		next, err := srv.publishDueRefs(ctx, time.Now())
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			srv.log.Warn("PublishScheduledRefsFailed", zap.Error(err))
+		}
+		if !next.IsZero() {
+			wait = min(wait, time.Until(next))
+		}
+
+		next, err = srv.rotateExpiredSpaceKeys(ctx, time.Now())
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			srv.log.Warn("RotateExpiredSpaceKeysFailed", zap.Error(err))
+		}
+		if !next.IsZero() {
+			wait = min(wait, time.Until(next))
+		}
+
+		srv.createPendingSnapshots(ctx)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		case <-srv.scheduled:
+			timer.Stop()
+		}
+	}
+}
+
+// notifyScheduler wakes up the scheduler to pick up a newly scheduled Ref or snapshot.
+func (srv *Server) notifyScheduler() {
+	select {
+	case srv.scheduled <- struct{}{}:
+	default:
+	}
+}
+
+// publishDueRefs indexes all the Refs scheduled at or before now together with their blobs,
+// and returns the time of the next attempt to publish a pending Ref, if any.
+// Refs that fail to be published keep the error, and are retried later with a backoff.
+func (srv *Server) publishDueRefs(ctx context.Context, now time.Time) (next time.Time, err error) {
+	// Blobs can't be stored while reindexing. We'll try again later.
+	if srv.idx.ReindexInfo().State == blob.ReindexStateInProgress {
+		return next, nil
+	}
+
+	type dueRef struct {
+		ID       int64
+		Block    blocks.Block
+		Attempts int
+	}
+
+	var due []dueRef
+	if err := srv.db.WithSave(ctx, func(conn *sqlite.Conn) (err error) {
+		rows, discard, check := sqlitex.Query(conn, qListDueScheduledRefs(), now.UnixMilli()).All()
+		defer discard(&err)
+		for row := range rows {
+			c, err := cid.Decode(row.ColumnText(1))
+			if err != nil {
+				return err
+			}
+
+			blk, err := blocks.NewBlockWithCid(row.ColumnBytes(2), c)
+			if err != nil {
+				return err
+			}
+
+			due = append(due, dueRef{ID: row.ColumnInt64(0), Block: blk, Attempts: row.ColumnInt(3)})
+		}
+		return check()
+	}); err != nil {
+		return next, err
+	}
+
+	for _, sr := range due {
+		perr := srv.publishScheduledRef(ctx, sr.ID, sr.Block)
+		if ctx.Err() != nil {
+			return next, ctx.Err()
+		}
+
+		if err := srv.db.WithTx(ctx, func(conn *sqlite.Conn) error {
+			if perr != nil {
+				retryTime := now.Add(publishRetryWait(sr.Attempts + 1))
+				return sqlitex.Exec(conn, qSetScheduledRefError(), nil, perr.Error(), retryTime.UnixMilli(), sr.ID)
+			}
+			return sqlitex.Exec(conn, qDeleteScheduledRefByID(), nil, sr.ID)
+		}); err != nil {
+			return next, err
+		}
+
+		if perr != nil {
+			srv.log.Warn("ScheduledRefPublishFailed", zap.String("ref", sr.Block.Cid().String()), zap.Int("attempt", sr.Attempts+1), zap.Error(perr))
+		} else {
+			srv.log.Info("ScheduledRefPublished", zap.String("ref", sr.Block.Cid().String()))
+		}
+	}
+
+	if err := srv.db.WithSave(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, qNextScheduledRefTime(), func(stmt *sqlite.Stmt) error {
+			if stmt.ColumnType(0) != sqlite.SQLITE_NULL {
+				next = time.UnixMilli(stmt.ColumnInt64(0))
+			}
+			return nil
+		})
+	}); err != nil {
+		return next, err
+	}
+
+	return next, nil
+}
+
+// publishScheduledRef indexes the blobs held for the scheduled Ref, and then the Ref itself.
+func (srv *Server) publishScheduledRef(ctx context.Context, id int64, ref blocks.Block) error {
+	var blks []blocks.Block
+	if err := srv.db.WithSave(ctx, func(conn *sqlite.Conn) (err error) {
+		rows, discard, check := sqlitex.Query(conn, qListScheduledRefBlobs(), id).All()
+		defer discard(&err)
+		for row := range rows {
+			c, err := cid.Decode(row.ColumnText(0))
+			if err != nil {
+				return err
+			}
+
+			blk, err := blocks.NewBlockWithCid(row.ColumnBytes(1), c)
+			if err != nil {
+				return err
+			}
+
+			blks = append(blks, blk)
+		}
+		return check()
+	}); err != nil {
+		return err
+	}
+
+	if err := srv.idx.PutMany(ctx, blks); err != nil {
+		return err
+	}
+
+	return srv.idx.Put(ctx, ref)
+}
+
+var qScheduledRefExists = dqb.Str(`
+	SELECT 1 FROM scheduled_refs WHERE cid = ?
+`)
+
+var qInsertScheduledRef = dqb.Str(`
+	INSERT INTO scheduled_refs (cid, data, iri, publish_time, create_time, next_attempt_time)
+	VALUES (?, ?, ?, ?, ?, ?)
+`)
+
+var qInsertScheduledRefBlob = dqb.Str(`
+	INSERT INTO scheduled_ref_blobs (scheduled_ref, cid, data)
+	VALUES (?, ?, ?)
+`)
+
+// Scheduled Refs in the given space, or in all the spaces if it's empty, with the JSON array of their blob CIDs.
+var qListScheduledRefs = dqb.Str(`
+	SELECT
+		sr.cid,
+		sr.data,
+		sr.publish_time,
+		sr.create_time,
+		COALESCE(sr.publish_error, ''),
+		(SELECT json_group_array(srb.cid) FROM scheduled_ref_blobs srb WHERE srb.scheduled_ref = sr.id),
+		sr.publish_attempts,
+		sr.next_attempt_time
+	FROM scheduled_refs sr
+	WHERE :space = '' OR sr.iri = :space OR (sr.iri >= :space || '/' AND sr.iri < :space || '0')
+	ORDER BY sr.publish_time, sr.id
+`)
+
+var qDeleteScheduledRef = dqb.Str(`
+	DELETE FROM scheduled_refs WHERE cid = ?
+`)
+
+var qDeleteScheduledRefByID = dqb.Str(`
+	DELETE FROM scheduled_refs WHERE id = ?
+`)
+
+var qSetScheduledRefError = dqb.Str(`
+	UPDATE scheduled_refs
+	SET publish_error = ?, publish_attempts = publish_attempts + 1, next_attempt_time = ?
+	WHERE id = ?
+`)
+
+var qListDueScheduledRefs = dqb.Str(`
+	SELECT id, cid, data, publish_attempts
+	FROM scheduled_refs
+	WHERE next_attempt_time <= ?
+	ORDER BY next_attempt_time, id
+`)
+
+var qNextScheduledRefTime = dqb.Str(`
+	SELECT MIN(next_attempt_time) FROM scheduled_refs
+`)
+
+var qListScheduledRefBlobs = dqb.Str(`
+	SELECT cid, data FROM scheduled_ref_blobs WHERE scheduled_ref = ?
+`)
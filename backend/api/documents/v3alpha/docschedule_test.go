@@ -0,0 +1,217 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/api/apitest"
+	"seed/backend/blob"
+	"seed/backend/core"
+	"seed/backend/core/coretest"
+	pb "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/ipfs"
+	"seed/backend/util/cclock"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"testing"
+	"time"
+
+	"github.com/multiformats/go-multicodec"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestScheduleRef(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+	account := alice.me.Account.Principal()
+
+	_, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, "/post", "", "main").
+		SetMetadata("title", "Draft").
+		Build(),
+	)
+	require.NoError(t, err)
+
+	// Signs a change with a new title, without publishing anything.
+	prepare := func(title string) (blob.Encoded[*blob.Ref], []byte) {
+		t.Helper()
+		doc, err := alice.loadDocument(ctx, account, "/post", nil, false)
+		require.NoError(t, err)
+		require.NoError(t, doc.SetMetadata("title", title))
+		c, err := doc.SignChange(alice.me.Account)
+		require.NoError(t, err)
+		r, err := doc.Ref(alice.me.Account, blob.VisibilityPublic)
+		require.NoError(t, err)
+		return r, c.Data
+	}
+
+	title := func() string {
+		t.Helper()
+		doc, err := alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: account.String(), Path: "/post"})
+		require.NoError(t, err)
+		return doc.Metadata.Fields["title"].GetStringValue()
+	}
+
+	publishTime := time.Now().Add(time.Hour)
+
+	ref, change := prepare("Published")
+	_, err = alice.ScheduleRef(ctx, &pb.ScheduleRefRequest{Ref: ref.Data, PublishTime: timestamppb.New(publishTime)})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "heads must be available")
+
+	scheduled, err := alice.ScheduleRef(ctx, &pb.ScheduleRefRequest{Ref: ref.Data, Blobs: []*pb.ScheduledBlob{{Data: change}}, PublishTime: timestamppb.New(publishTime)})
+	require.NoError(t, err)
+	require.Equal(t, ref.CID.String(), scheduled.Ref.Id)
+	require.Len(t, scheduled.Blobs, 1)
+
+	_, err = alice.ScheduleRef(ctx, &pb.ScheduleRefRequest{Ref: ref.Data, Blobs: []*pb.ScheduledBlob{{Data: change}}, PublishTime: timestamppb.New(publishTime)})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	has, err := alice.idx.Has(ctx, ref.CID)
+	require.NoError(t, err)
+	require.False(t, has, "scheduled ref must not be stored before its publish time")
+	require.Equal(t, "Draft", title())
+
+	list, err := alice.ListScheduledRefs(ctx, &pb.ListScheduledRefsRequest{Account: account.String()})
+	require.NoError(t, err)
+	require.Len(t, list.ScheduledRefs, 1)
+	require.Equal(t, scheduled.Ref.Id, list.ScheduledRefs[0].Ref.Id)
+	require.Equal(t, scheduled.Blobs, list.ScheduledRefs[0].Blobs)
+	require.Equal(t, publishTime.UnixMilli(), list.ScheduledRefs[0].PublishTime.AsTime().UnixMilli())
+
+	next, err := alice.publishDueRefs(ctx, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, publishTime.UnixMilli(), next.UnixMilli())
+	require.Equal(t, "Draft", title())
+
+	next, err = alice.publishDueRefs(ctx, publishTime)
+	require.NoError(t, err)
+	require.True(t, next.IsZero())
+	require.Equal(t, "Published", title())
+
+	list, err = alice.ListScheduledRefs(ctx, &pb.ListScheduledRefsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, list.ScheduledRefs, "published refs must be removed from the schedule")
+
+	_, err = alice.ScheduleRef(ctx, &pb.ScheduleRefRequest{Ref: ref.Data, PublishTime: timestamppb.New(publishTime)})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "published refs can't be scheduled")
+
+	ref, change = prepare("Cancelled")
+	_, err = alice.ScheduleRef(ctx, &pb.ScheduleRefRequest{Ref: ref.Data, Blobs: []*pb.ScheduledBlob{{Data: change}}, PublishTime: timestamppb.New(publishTime)})
+	require.NoError(t, err)
+
+	_, err = alice.CancelScheduledRef(ctx, &pb.CancelScheduledRefRequest{Id: ref.CID.String()})
+	require.NoError(t, err)
+
+	_, err = alice.CancelScheduledRef(ctx, &pb.CancelScheduledRefRequest{Id: ref.CID.String()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = alice.publishDueRefs(ctx, publishTime.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, "Published", title(), "cancelled refs must never be published")
+
+	// Non-CBOR blobs keep their CIDs.
+	media := ipfs.NewBlock(multicodec.Raw, []byte("some image"))
+	ref, change = prepare("With media")
+	_, err = alice.ScheduleRef(ctx, &pb.ScheduleRefRequest{
+		Ref:         ref.Data,
+		Blobs:       []*pb.ScheduledBlob{{Data: change}, {Cid: media.Cid().String(), Data: []byte("other data")}},
+		PublishTime: timestamppb.New(publishTime),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "blobs must match their CIDs")
+
+	_, err = alice.ScheduleRef(ctx, &pb.ScheduleRefRequest{
+		Ref:         ref.Data,
+		Blobs:       []*pb.ScheduledBlob{{Data: change}, {Cid: media.Cid().String(), Data: media.RawData()}},
+		PublishTime: timestamppb.New(publishTime),
+	})
+	require.NoError(t, err)
+
+	// Breaking the held blob makes the publishing fail.
+	require.NoError(t, alice.db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, "UPDATE scheduled_ref_blobs SET cid = 'broken' WHERE cid = ?", nil, media.Cid().String())
+	}))
+
+	failTime := publishTime.Add(2 * time.Hour)
+	next, err = alice.publishDueRefs(ctx, failTime)
+	require.NoError(t, err)
+	require.Equal(t, failTime.Add(minPublishRetryWait).UnixMilli(), next.UnixMilli(), "failed refs must be retried with a backoff")
+	require.Equal(t, "Published", title())
+
+	list, err = alice.ListScheduledRefs(ctx, &pb.ListScheduledRefsRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.ScheduledRefs, 1, "failed refs must stay scheduled")
+	require.NotEmpty(t, list.ScheduledRefs[0].PublishError)
+	require.Equal(t, next.UnixMilli(), list.ScheduledRefs[0].RetryTime.AsTime().UnixMilli())
+
+	next, err = alice.publishDueRefs(ctx, next)
+	require.NoError(t, err)
+	require.Equal(t, failTime.Add(3*minPublishRetryWait).UnixMilli(), next.UnixMilli(), "backoff must grow with each attempt")
+
+	require.NoError(t, alice.db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, "UPDATE scheduled_ref_blobs SET cid = ? WHERE cid = 'broken'", nil, media.Cid().String())
+	}))
+
+	next, err = alice.publishDueRefs(ctx, next)
+	require.NoError(t, err)
+	require.True(t, next.IsZero())
+	require.Equal(t, "With media", title())
+
+	has, err = alice.idx.Has(ctx, media.Cid())
+	require.NoError(t, err)
+	require.True(t, has, "media blobs must be stored with their own CIDs")
+}
+
+func TestScheduleRefOrgRoot(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+	account := alice.me.Account.Principal()
+	bob := coretest.NewTester("bob").Account
+	carol := coretest.NewTester("carol").Account
+
+	_, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, "", "", "main").
+		SetMetadata("title", "ACME").
+		Build(),
+	)
+	require.NoError(t, err)
+
+	ms, err := blob.NewMembership(alice.me.Account, account, []core.Principal{bob.Principal(), carol.Principal()}, 2, cclock.New().MustNow())
+	require.NoError(t, err)
+	require.NoError(t, alice.idx.Put(ctx, ms))
+
+	// Members sign the root without any capabilities.
+	doc, err := alice.loadDocument(ctx, account, "", nil, false)
+	require.NoError(t, err)
+	require.NoError(t, doc.SetMetadata("title", "ACME Inc."))
+	change, err := doc.SignChange(bob)
+	require.NoError(t, err)
+	partial, err := doc.Ref(bob, blob.VisibilityPublic)
+	require.NoError(t, err)
+
+	publishTime := time.Now().Add(time.Hour)
+
+	_, err = alice.ScheduleRef(ctx, &pb.ScheduleRefRequest{Ref: partial.Data, Blobs: []*pb.ScheduledBlob{{Data: change.Data}}, PublishTime: timestamppb.New(publishTime)})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "root ref must have enough signatures")
+
+	ref, err := partial.Decoded.Cosign(carol)
+	require.NoError(t, err)
+
+	_, err = alice.ScheduleRef(ctx, &pb.ScheduleRefRequest{Ref: ref.Data, Blobs: []*pb.ScheduledBlob{{Data: change.Data}}, PublishTime: timestamppb.New(publishTime)})
+	require.NoError(t, err)
+
+	_, err = alice.publishDueRefs(ctx, publishTime)
+	require.NoError(t, err)
+
+	home, err := alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: account.String()})
+	require.NoError(t, err)
+	require.Equal(t, "ACME Inc.", home.Metadata.Fields["title"].GetStringValue())
+}
+
+func TestPublishRetryWait(t *testing.T) {
+	require.Equal(t, minPublishRetryWait, publishRetryWait(1))
+	require.Equal(t, 2*minPublishRetryWait, publishRetryWait(2))
+	require.Equal(t, maxPublishRetryWait, publishRetryWait(1000))
+}
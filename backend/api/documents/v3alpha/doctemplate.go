@@ -0,0 +1,138 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/api/documents/v3alpha/docmodel"
+	"seed/backend/blob"
+	"seed/backend/core"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/cclock"
+	"seed/backend/util/errutil"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// createdFromTemplateKey is the metadata attribute of documents created from templates,
+// that holds the versioned IRI of the template.
+const createdFromTemplateKey = "createdFromTemplate"
+
+// CreateFromTemplate implements Documents API v3.
+func (srv *Server) CreateFromTemplate(ctx context.Context, in *documents.CreateFromTemplateRequest) (*documents.Document, error) {
+	{
+		if in.TemplateAccount == "" {
+			return nil, errutil.MissingArgument("template_account")
+		}
+
+		if in.Path == "" {
+			return nil, errutil.MissingArgument("path")
+		}
+
+		if in.SigningKeyName == "" {
+			return nil, errutil.MissingArgument("signing_key_name")
+		}
+
+		if in.Account == "" {
+			in.Account = in.TemplateAccount
+		}
+	}
+
+	tplNS, err := core.DecodePrincipal(in.TemplateAccount)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse template account '%s': %v", in.TemplateAccount, err)
+	}
+
+	ns, err := core.DecodePrincipal(in.Account)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse account '%s': %v", in.Account, err)
+	}
+
+	heads, err := docmodel.Version(in.TemplateVersion).Parse()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse template version: %v", err)
+	}
+
+	visibility := blob.VisibilityPublic
+	if in.Visibility == documents.ResourceVisibility_RESOURCE_VISIBILITY_PRIVATE {
+		if strings.Count(in.Path, "/") != 1 {
+			return nil, status.Errorf(codes.InvalidArgument, "private documents must have a simple path with only a leading slash (e.g., '/document-name'): got %s", in.Path)
+		}
+		visibility = blob.VisibilityPrivate
+	}
+
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := srv.checkWriteAccess(ctx, ns, in.Path, kp); err != nil {
+		return nil, err
+	}
+
+	tpl, err := srv.loadSourceDocument(ctx, tplNS, in.TemplatePath, heads)
+	if err != nil {
+		return nil, err
+	}
+
+	if !tpl.IsTemplate() {
+		return nil, status.Errorf(codes.FailedPrecondition, "document '%s' is not a template: metadata attribute '%s' must be true", in.TemplatePath, docmodel.TemplateKey)
+	}
+
+	if err := srv.ensureDocumentAbsent(ctx, ns, in.Path); err != nil {
+		return nil, err
+	}
+
+	iri, err := makeIRI(ns, in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	tplIRI, err := makeIRI(tplNS, in.TemplatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := docmodel.New(iri, cclock.New())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := doc.Instantiate(tpl, in.Variables); err != nil {
+		return nil, err
+	}
+
+	if err := doc.SetMetadata(createdFromTemplateKey, string(tplIRI)+"?v="+tpl.Version().String()); err != nil {
+		return nil, err
+	}
+
+	if err := srv.encryptPrivateDocument(ctx, doc, ns, visibility); err != nil {
+		return nil, err
+	}
+
+	change, err := doc.SignChange(kp)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := doc.Ref(kp, visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := srv.idx.Put(ctx, change); err != nil {
+		return nil, err
+	}
+
+	if _, err := srv.publishRef(ctx, ref, blob.OrgMembership{}, false); err != nil {
+		return nil, err
+	}
+
+	result, err := srv.loadDocument(ctx, ns, in.Path, []cid.Cid{change.CID}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Hydrate(ctx)
+}
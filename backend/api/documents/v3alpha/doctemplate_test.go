@@ -0,0 +1,72 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/api/apitest"
+	pb "seed/backend/genproto/documents/v3alpha"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateFromTemplate(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+	account := alice.me.Account.Principal()
+
+	tpl, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, "/templates/meeting", "", "main").
+		SetMetadata("title", "Meeting {{date}}").
+		SetAttribute("", []string{"template"}, true).
+		MoveBlock("b1", "", "").
+		ReplaceBlock("b1", "paragraph", "Attendees: {{people}}").
+		Build(),
+	)
+	require.NoError(t, err)
+
+	_, err = alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, "/plain", "", "main").
+		SetMetadata("title", "Not a template").
+		Build(),
+	)
+	require.NoError(t, err)
+
+	templates, err := alice.QueryDocuments(ctx, &pb.QueryDocumentsRequest{
+		Filter: &pb.DocumentFilter{Filter: &pb.DocumentFilter_Exists{Exists: &pb.DocumentFilter_Presence{Key: "template"}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, templates.Documents, 1, "templates must be discoverable by their attribute")
+	require.Equal(t, tpl.Path, templates.Documents[0].Path)
+
+	doc, err := alice.CreateFromTemplate(ctx, &pb.CreateFromTemplateRequest{
+		TemplateAccount: tpl.Account,
+		TemplatePath:    tpl.Path,
+		Path:            "/meetings/2024-01-01",
+		SigningKeyName:  "main",
+		Variables:       map[string]string{"date": "2024-01-01", "people": "Alice, Bob"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Meeting 2024-01-01", doc.Metadata.Fields["title"].GetStringValue())
+	require.Equal(t, "Attendees: Alice, Bob", doc.Content[0].Block.Text)
+	require.Equal(t, "hm://"+account.String()+tpl.Path+"?v="+tpl.Version, doc.Metadata.Fields["createdFromTemplate"].GetStringValue())
+	require.NotContains(t, doc.Metadata.Fields, "template", "new document must not be a template")
+	require.NotEqual(t, tpl.Genesis, doc.Genesis)
+
+	_, err = alice.CreateFromTemplate(ctx, &pb.CreateFromTemplateRequest{
+		TemplateAccount: tpl.Account,
+		TemplatePath:    tpl.Path,
+		Path:            "/meetings/2024-01-01",
+		SigningKeyName:  "main",
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "existing documents must not be overwritten")
+
+	_, err = alice.CreateFromTemplate(ctx, &pb.CreateFromTemplateRequest{
+		TemplateAccount: tpl.Account,
+		TemplatePath:    "/plain",
+		Path:            "/from-plain",
+		SigningKeyName:  "main",
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "only templates can be instantiated")
+}
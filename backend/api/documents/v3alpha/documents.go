@@ -26,6 +26,7 @@ import (
 	"seed/backend/util/sqlite/sqlitex"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/invopop/validation"
@@ -56,7 +57,7 @@ const (
 
 var authenticatedListVisibilityFilter = `(
 	dg.visibility IS NOT 'Private'
-	OR ` + blob.SQLCanWriteRootByOwnerID("r.owner") + `
+	OR ` + blob.SQLCanReadRootByOwnerID("r.owner", blob.SQLNowMillis) + `
 )`
 
 // Server implements Documents API v3.
@@ -69,18 +70,48 @@ type Server struct {
 	p2p       *hmnet.Node
 	telemetry *telemetry.Server
 	hydrated  *hydrateCache
+
+	// scheduled wakes up the scheduler when a new Ref is scheduled.
+	scheduled chan struct{}
+
+	// invitesMu serializes redemptions of invites.
+	invitesMu sync.Mutex
+
+	// snapshotInterval is the number of changes after which we create a new snapshot of a document.
+	snapshotInterval int
+
+	// pendingSnapshots are the documents that might need a new snapshot, keyed by IRI.
+	// The scheduler creates the snapshots, so publishing doesn't wait for the entire document to be replayed.
+	snapshotsMu      sync.Mutex
+	pendingSnapshots map[blob.IRI]pendingSnapshot
+
+	// accessRequestCooldown is the time after a denied access request
+	// during which the requester can't ask for access to the same resource again.
+	accessRequestCooldown time.Duration
 }
 
+// defaultSnapshotInterval is the default number of changes between document snapshots.
+const defaultSnapshotInterval = 100
+
+// defaultAccessRequestCooldown is the default cool-down of denied access requests.
+const defaultAccessRequestCooldown = 24 * time.Hour
+
 // NewServer creates a new Documents API v3 server.
 func NewServer(cfg config.Base, keys core.KeyStore, idx *blob.Index, db *sqlitex.Pool, log *zap.Logger, p2p *hmnet.Node) *Server {
 	srv := &Server{
-		cfg:      cfg,
-		keys:     keys,
-		idx:      idx,
-		db:       db,
-		log:      log,
-		p2p:      p2p,
-		hydrated: newHydrateCache(),
+		cfg:       cfg,
+		keys:      keys,
+		idx:       idx,
+		db:        db,
+		log:       log,
+		p2p:       p2p,
+		hydrated:  newHydrateCache(),
+		scheduled: make(chan struct{}, 1),
+
+		snapshotInterval: defaultSnapshotInterval,
+		pendingSnapshots: make(map[blob.IRI]pendingSnapshot),
+
+		accessRequestCooldown: defaultAccessRequestCooldown,
 	}
 
 	// Let the indexer derive a fallback cover image at index time, reusing the
@@ -88,7 +119,15 @@ func NewServer(cfg config.Base, keys core.KeyStore, idx *blob.Index, db *sqlitex
 	// how the derived cover field gets populated. The daemon also wires this
 	// earlier (before the backfill reindex task starts); this keeps embedders
 	// and tests that construct the server directly working.
+	// Comment anchors are resolved by the indexer in the same way.
 	idx.SetDeriveFirstContentImage(DeriveFirstContentImage)
+	idx.SetResolveCommentAnchors(ResolveCommentAnchors)
+
+	// Other peers redeem the invites created by this server, and ask for access to our spaces over the P2P API.
+	if p2p != nil {
+		p2p.SetInviteIssuer(srv)
+		p2p.SetAccessRequestHandler(srv)
+	}
 
 	return srv
 }
@@ -138,22 +177,56 @@ func DeriveFirstContentImage(iri blob.IRI, changes []blob.ChangeRecord) (link st
 		return "", nil
 	}
 
-	doc, err := docmodel.New(iri, cclock.New())
+	doc, err := documentFromChanges(iri, changes)
 	if err != nil {
 		return "", err
 	}
 
+	return doc.FirstContentImage(), nil
+}
+
+// ResolveCommentAnchors rebuilds a document in memory from the given changes,
+// and finds the content the comment anchors refer to in that version.
+// It's injected into the indexer (SetResolveCommentAnchors) for the same reasons,
+// and with the same precautions, as DeriveFirstContentImage.
+func ResolveCommentAnchors(iri blob.IRI, changes []blob.ChangeRecord, anchors []blob.CommentAnchorTarget) (out []blob.CommentAnchorPosition, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = nil
+			err = fmt.Errorf("panic while resolving comment anchors for %s: %v", iri, r)
+		}
+	}()
+
+	if len(changes) == 0 {
+		return make([]blob.CommentAnchorPosition, len(anchors)), nil
+	}
+
+	doc, err := documentFromChanges(iri, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return doc.ResolveAnchors(anchors), nil
+}
+
+// documentFromChanges builds a document in memory from changes supplied by the indexer.
+func documentFromChanges(iri blob.IRI, changes []blob.ChangeRecord) (*docmodel.Document, error) {
+	doc, err := docmodel.New(iri, cclock.New())
+	if err != nil {
+		return nil, err
+	}
+
 	for _, ch := range changes {
 		doc.SetVisibility(ch.Visibility)
 		if !doc.Generation.IsSet() {
 			doc.Generation = maybe.New(ch.Generation)
 		}
 		if err := doc.ApplyChange(ch.CID, ch.Data); err != nil {
-			return "", err
+			return nil, err
 		}
 	}
 
-	return doc.FirstContentImage(), nil
+	return doc, nil
 }
 
 // SetTelemetry wires the journeys profiler. Optional; when nil, all
@@ -914,7 +987,16 @@ func (srv *Server) GetDocumentInfo(ctx context.Context, in *documents.GetDocumen
 		if err != nil {
 			return nil, err
 		}
-		return getDocumentInfo(conn, lookup, iri)
+		info, err := getDocumentInfo(conn, lookup, iri)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := srv.fillDocumentReactions(ctx, conn, iri, info); err != nil {
+			return nil, err
+		}
+
+		return info, nil
 	})
 	if err != nil {
 		return nil, err
@@ -975,6 +1057,9 @@ func (srv *Server) BatchGetDocumentInfo(ctx context.Context, in *documents.Batch
 					return err
 				}
 			}
+			if err := srv.fillDocumentReactions(ctx, conn, iri, info); err != nil {
+				return err
+			}
 			out.Documents[i] = info
 		}
 		return nil
@@ -1005,9 +1090,31 @@ func (srv *Server) PrepareChange(ctx context.Context, in *documents.PrepareChang
 		return nil, err
 	}
 
-	return &documents.PrepareChangeResponse{
+	out := &documents.PrepareChangeResponse{
 		UnsignedChange: change.Data,
-	}, nil
+	}
+
+	// Root documents of multi-signature organizations need Refs signed by multiple members.
+	if in.Path == "" {
+		ns, err := core.DecodePrincipal(in.Account)
+		if err != nil {
+			return nil, err
+		}
+
+		org, isOrg, err := srv.idx.GetOrgMembership(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+
+		if isOrg {
+			out.RequiredSignatures = int32(org.Threshold) //nolint:gosec
+			for _, m := range org.Members {
+				out.Members = append(out.Members, m.String())
+			}
+		}
+	}
+
+	return out, nil
 }
 
 // documentChangeParams holds the common parameters for PrepareChange.
@@ -1084,6 +1191,18 @@ func (srv *Server) handleDocumentChangeRequest(ctx context.Context, in documentC
 		return nil, err
 	}
 
+	visibility := doc.Visibility()
+	switch in.Visibility {
+	case documents.ResourceVisibility_RESOURCE_VISIBILITY_PRIVATE:
+		visibility = blob.VisibilityPrivate
+	case documents.ResourceVisibility_RESOURCE_VISIBILITY_PUBLIC:
+		visibility = blob.VisibilityPublic
+	}
+
+	if err := srv.encryptPrivateDocument(ctx, doc, ns, visibility); err != nil {
+		return nil, err
+	}
+
 	return doc, nil
 }
 
@@ -1533,6 +1652,19 @@ func (srv *Server) getAccountByID(conn *sqlite.Conn, lookup *blob.LookupCache, i
 		return nil, status.Errorf(codes.InvalidArgument, "failed to decode account %s: %v", id, err)
 	}
 
+	// Accounts that migrated to a new key are resolved to the new one.
+	{
+		cur, err := blob.ResolveKeyRotation(conn, acc)
+		if err != nil {
+			return nil, err
+		}
+
+		if !cur.Equal(acc) {
+			acc = cur
+			id = cur.String()
+		}
+	}
+
 	qb := srv.baseAccountQuery()
 	qb = qb.Where("spaces.id = ?")
 
@@ -2488,30 +2620,48 @@ func (srv *Server) UpdateDocumentReadStatus(ctx context.Context, in *documents.U
 // CreateRef implements Documents API v3.
 func (srv *Server) CreateRef(ctx context.Context, in *documents.CreateRefRequest) (*documents.Ref, error) {
 	{
-		if in.Account == "" {
-			return nil, errutil.MissingArgument("account")
-		}
-
 		if in.SigningKeyName == "" {
 			return nil, errutil.MissingArgument("signing_key_name")
 		}
 
-		if in.Target == nil {
-			return nil, errutil.MissingArgument("target")
+		if len(in.PartialRef) == 0 {
+			if in.Account == "" {
+				return nil, errutil.MissingArgument("account")
+			}
+
+			if in.Target == nil {
+				return nil, errutil.MissingArgument("target")
+			}
 		}
 	}
 
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.PartialRef) > 0 {
+		return srv.cosignRef(ctx, in.PartialRef, kp)
+	}
+
 	ns, err := core.DecodePrincipal(in.Account)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "faield to decode account ID: %v", err)
 	}
 
-	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	// Root Refs of multi-signature organizations are signed by the members,
+	// who don't need capabilities, but need to collect enough signatures.
+	org, isOrg, err := srv.idx.GetOrgMembership(ctx, ns)
 	if err != nil {
 		return nil, err
 	}
+	isOrgRoot := isOrg && in.Path == ""
 
-	if err := srv.checkWriteAccess(ctx, ns, in.Path, kp); err != nil {
+	if isOrgRoot {
+		if !org.IsMember(kp.Principal()) {
+			return nil, status.Errorf(codes.PermissionDenied, "key '%s' is not a member of organization '%s'", kp.Principal(), ns)
+		}
+	} else if err := srv.checkWriteAccess(ctx, ns, in.Path, kp); err != nil {
 		return nil, err
 	}
 
@@ -2623,11 +2773,73 @@ func (srv *Server) CreateRef(ctx context.Context, in *documents.CreateRefRequest
 		return nil, fmt.Errorf("BUG: unhandled ref target type %T", rt)
 	}
 
-	if err := srv.idx.Put(ctx, refBlob); err != nil {
+	out, err := srv.publishRef(ctx, refBlob, org, isOrgRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isOrgRoot && len(refBlob.Decoded.Heads) > 0 {
+		srv.scheduleSnapshot(kp, ns, in.Path)
+	}
+
+	return out, nil
+}
+
+// cosignRef adds the signature of kp to a partially signed root Ref of a multi-signature organization.
+func (srv *Server) cosignRef(ctx context.Context, data []byte, kp *core.KeyPair) (*documents.Ref, error) {
+	ref := &blob.Ref{}
+	if err := cbornode.DecodeInto(data, ref); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode partial ref: %v", err)
+	}
+
+	if ref.Type != blob.TypeRef {
+		return nil, status.Errorf(codes.InvalidArgument, "partial ref has invalid type '%s'", ref.Type)
+	}
+
+	if err := ref.VerifySignatures(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "partial ref has invalid signatures: %v", err)
+	}
+
+	org, isOrg, err := srv.idx.GetOrgMembership(ctx, ref.Space())
+	if err != nil {
+		return nil, err
+	}
+
+	if !isOrg || ref.Path != "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "only root Refs of multi-signature organizations need multiple signatures")
+	}
+
+	if !org.IsMember(kp.Principal()) {
+		return nil, status.Errorf(codes.PermissionDenied, "key '%s' is not a member of organization '%s'", kp.Principal(), ref.Space())
+	}
+
+	eb, err := ref.Cosign(kp)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to sign partial ref: %v", err)
+	}
+
+	return srv.publishRef(ctx, eb, org, true)
+}
+
+// publishRef indexes the Ref, unless it's a root Ref of a multi-signature organization that still lacks signatures.
+// In that case the partially signed Ref is returned to be passed around to other members.
+func (srv *Server) publishRef(ctx context.Context, ref blob.Encoded[*blob.Ref], org blob.OrgMembership, isOrgRoot bool) (*documents.Ref, error) {
+	if isOrgRoot && !org.ApprovedBy(ref.Decoded.Signers()) {
+		pb, err := refToProto(ref.CID, ref.Decoded)
+		if err != nil {
+			return nil, err
+		}
+
+		pb.Id = ""
+		pb.PartialRef = ref.Data
+		return pb, nil
+	}
+
+	if err := srv.idx.Put(ctx, ref); err != nil {
 		return nil, err
 	}
 
-	return refToProto(refBlob.CID, refBlob.Decoded)
+	return refToProto(ref.CID, ref.Decoded)
 }
 
 // GetRef implements Documents API v3.
@@ -2780,6 +2992,11 @@ func refToProto(c cid.Cid, ref *blob.Ref) (*documents.Ref, error) {
 			Genesis:    ref.GenesisBlob.String(),
 			Generation: ref.Generation,
 		},
+		Branch: ref.Branch,
+	}
+
+	for _, cs := range ref.Cosignatures {
+		pb.Cosigners = append(pb.Cosigners, cs.Signer.String())
 	}
 
 	switch {
@@ -2792,6 +3009,21 @@ func refToProto(c cid.Cid, ref *blob.Ref) (*documents.Ref, error) {
 				},
 			},
 		}
+	case ref.GenesisBlob.Defined() && ref.Redirect != nil:
+		space := ref.Redirect.Space
+		if space == nil {
+			space = ref.Space()
+		}
+
+		pb.Target = &documents.RefTarget{
+			Target: &documents.RefTarget_Redirect_{
+				Redirect: &documents.RefTarget_Redirect{
+					Account:   space.String(),
+					Path:      ref.Redirect.Path,
+					Republish: ref.Redirect.Republish,
+				},
+			},
+		}
 	case ref.GenesisBlob.Defined() && len(ref.Heads) == 0:
 		pb.Target = &documents.RefTarget{
 			Target: &documents.RefTarget_Tombstone_{
@@ -2867,18 +3099,60 @@ func (srv *Server) loadDocumentInfo(ctx context.Context, account core.Principal,
 }
 
 func (srv *Server) loadDocument(ctx context.Context, account core.Principal, path string, heads []cid.Cid, ensurePath bool) (*docmodel.Document, error) {
+	return srv.replayDocument(ctx, account, path, heads, ensurePath, srv.snapshotTrust(ctx, account))
+}
+
+// loadFullDocument loads the document replaying all of its changes, without using snapshots.
+// It's needed when we want to check out arbitrary versions of the loaded document.
+func (srv *Server) loadFullDocument(ctx context.Context, account core.Principal, path string, heads []cid.Cid) (*docmodel.Document, error) {
+	return srv.replayDocument(ctx, account, path, heads, false, nil)
+}
+
+// replayDocument loads the document applying its changes on top of the newest snapshot
+// signed by someone we trust, or from scratch if trusted is nil.
+func (srv *Server) replayDocument(ctx context.Context, account core.Principal, path string, heads []cid.Cid, ensurePath bool, trusted func(core.Principal) bool) (*docmodel.Document, error) {
 	iri, err := makeIRI(account, path)
 	if err != nil {
 		return nil, err
 	}
 
 	clock := cclock.New()
-	doc, err := docmodel.New(iri, clock)
-	if err != nil {
-		return nil, err
+
+	// Start from the newest snapshot we trust, if any, to avoid replaying the entire history.
+	// Snapshots are only an optimization, so if anything goes wrong we just replay all the changes.
+	var (
+		doc   *docmodel.Document
+		base  []cid.Cid
+		snap  blob.SnapshotRecord
+		found bool
+	)
+	if trusted != nil {
+		snap, found, err = srv.idx.LoadSnapshot(ctx, iri, heads, trusted)
+		if err != nil {
+			srv.log.Debug("FailedToLoadSnapshot", zap.String("iri", string(iri)), zap.Error(err))
+		}
+	}
+	if found {
+		doc, err = docmodel.FromSnapshot(iri, clock, snap.Data)
+		if err != nil {
+			srv.log.Warn("FailedToRestoreSnapshot", zap.String("iri", string(iri)), zap.String("snapshot", snap.CID.String()), zap.Error(err))
+			doc = nil
+			clock = cclock.New()
+		} else {
+			base = snap.Data.Heads
+			doc.SetVisibility(snap.Visibility)
+			doc.Generation = maybe.New(snap.Generation)
+		}
+	}
+
+	if doc == nil {
+		doc, err = docmodel.New(iri, clock)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	changes, check := srv.idx.IterChanges(ctx, iri, heads)
+	changes, check := srv.idx.IterChangesFrom(ctx, iri, heads, base)
 	for ch := range changes {
 		doc.SetVisibility(ch.Visibility)
 		if doc.Generation.IsSet() {
@@ -2911,6 +3185,105 @@ func (srv *Server) loadDocument(ctx context.Context, account core.Principal, pat
 	return doc, nil
 }
 
+// snapshotTrust returns the function to decide whether we trust snapshots of the documents in the account.
+// We only trust the owner of the account and our own keys, because anyone else with write access could
+// sign a snapshot that doesn't match the changes it claims to cover.
+func (srv *Server) snapshotTrust(ctx context.Context, account core.Principal) func(core.Principal) bool {
+	var local map[string]struct{}
+	return func(signer core.Principal) bool {
+		if signer.Equal(account) {
+			return true
+		}
+
+		if local == nil {
+			local = make(map[string]struct{})
+			keys, err := srv.keys.ListKeys(ctx)
+			if err != nil {
+				srv.log.Warn("FailedToListKeysForSnapshots", zap.Error(err))
+			}
+			for _, k := range keys {
+				local[k.PublicKey.String()] = struct{}{}
+			}
+		}
+
+		_, ok := local[signer.String()]
+		return ok
+	}
+}
+
+type pendingSnapshot struct {
+	kp      *core.KeyPair
+	account core.Principal
+	path    string
+}
+
+// scheduleSnapshot asks the scheduler to create a new snapshot of the document if it needs one.
+func (srv *Server) scheduleSnapshot(kp *core.KeyPair, account core.Principal, path string) {
+	if srv.snapshotInterval <= 0 {
+		return
+	}
+
+	iri, err := makeIRI(account, path)
+	if err != nil {
+		return
+	}
+
+	srv.snapshotsMu.Lock()
+	srv.pendingSnapshots[iri] = pendingSnapshot{kp: kp, account: account, path: path}
+	srv.snapshotsMu.Unlock()
+
+	srv.notifyScheduler()
+}
+
+// createPendingSnapshots creates the snapshots requested with scheduleSnapshot so far.
+func (srv *Server) createPendingSnapshots(ctx context.Context) {
+	srv.snapshotsMu.Lock()
+	pending := srv.pendingSnapshots
+	srv.pendingSnapshots = make(map[blob.IRI]pendingSnapshot)
+	srv.snapshotsMu.Unlock()
+
+	for _, p := range pending {
+		if ctx.Err() != nil {
+			return
+		}
+		srv.maybeCreateSnapshot(ctx, p.kp, p.account, p.path)
+	}
+}
+
+// maybeCreateSnapshot creates a new snapshot of the document
+// when enough changes were made since the last snapshot we trust.
+// Snapshots are only an optimization, so failures are logged and otherwise ignored.
+func (srv *Server) maybeCreateSnapshot(ctx context.Context, kp *core.KeyPair, account core.Principal, path string) {
+	if srv.snapshotInterval <= 0 {
+		return
+	}
+
+	doc, err := srv.loadDocument(ctx, account, path, nil, false)
+	if err != nil {
+		srv.log.Debug("FailedToLoadDocumentForSnapshot", zap.String("account", account.String()), zap.String("path", path), zap.Error(err))
+		return
+	}
+
+	// Snapshots hold the state in plaintext, so they would leak the content we encrypt in the changes of private documents.
+	if doc.Visibility() == blob.VisibilityPrivate {
+		return
+	}
+
+	if doc.ChangesSinceSnapshot() < srv.snapshotInterval {
+		return
+	}
+
+	snap, err := doc.Snapshot(kp, cclock.New().MustNow())
+	if err != nil {
+		srv.log.Warn("FailedToCreateSnapshot", zap.String("account", account.String()), zap.String("path", path), zap.Error(err))
+		return
+	}
+
+	if err := srv.idx.Put(ctx, snap); err != nil {
+		srv.log.Warn("FailedToStoreSnapshot", zap.String("account", account.String()), zap.String("path", path), zap.Error(err))
+	}
+}
+
 func applyChanges(doc *docmodel.Document, ops []*documents.DocumentChange) error {
 	for _, op := range ops {
 		switch o := op.Op.(type) {
@@ -2940,6 +3313,10 @@ func applyChanges(doc *docmodel.Document, ops []*documents.DocumentChange) error
 			if err := doc.SetAttribute(o.SetAttribute.BlockId, o.SetAttribute.Key, getInterfaceValue(o.SetAttribute)); err != nil {
 				return err
 			}
+		case *documents.DocumentChange_SpliceText_:
+			if err := doc.SpliceText(o.SpliceText.BlockId, int(o.SpliceText.Offset), int(o.SpliceText.DeleteCount), o.SpliceText.Insert); err != nil {
+				return err
+			}
 		default:
 			return status.Errorf(codes.Unimplemented, "unknown operation %T", o)
 		}
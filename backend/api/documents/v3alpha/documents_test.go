@@ -16,6 +16,7 @@ import (
 	"seed/backend/logging"
 	"seed/backend/storage"
 	"seed/backend/testutil"
+	"seed/backend/util/cclock"
 	"seed/backend/util/colx"
 	"seed/backend/util/must"
 	"seed/backend/util/sqlite"
@@ -467,6 +468,100 @@ func TestGetDocumentWithVersion(t *testing.T) {
 	}
 }
 
+func TestDocumentSnapshots(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	alice.snapshotInterval = 2
+	ctx := context.Background()
+
+	var versions []*documents.Document
+	for i, text := range []string{"One", "Two", "Three"} {
+		req := &apitest.DocumentChangeRequest{
+			SigningKeyName: "main",
+			Account:        alice.me.Account.PublicKey.String(),
+			Path:           "/snapshots",
+			Changes: []*documents.DocumentChange{
+				{Op: &documents.DocumentChange_SetMetadata_{
+					SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: text},
+				}},
+				{Op: &documents.DocumentChange_MoveBlock_{
+					MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: text, Parent: "", LeftSibling: ""},
+				}},
+				{Op: &documents.DocumentChange_ReplaceBlock{
+					ReplaceBlock: &documents.Block{Id: text, Type: "paragraph", Text: text},
+				}},
+			},
+		}
+		if i > 0 {
+			req.BaseVersion = versions[i-1].Version
+		}
+		doc, err := alice.PublishDocumentChangeForTest(ctx, req)
+		require.NoError(t, err)
+		versions = append(versions, doc)
+
+		// The test helper bypasses CreateRef, so we create the snapshot directly, instead of scheduling it.
+		alice.maybeCreateSnapshot(ctx, alice.me.Account, alice.me.Account.Principal(), "/snapshots")
+	}
+
+	iri := must.Do2(blob.NewIRI(alice.me.Account.Principal(), "/snapshots"))
+	trustAll := func(core.Principal) bool { return true }
+	rec, found, err := alice.idx.LoadSnapshot(ctx, iri, nil, trustAll)
+	require.NoError(t, err)
+	require.True(t, found, "snapshot must be created after enough changes")
+	require.Equal(t, 2, rec.Data.ChangeCount)
+
+	latest, err := alice.GetDocument(ctx, &documents.GetDocumentRequest{Account: versions[0].Account, Path: versions[0].Path})
+	require.NoError(t, err)
+	testutil.StructsEqual(latest, versions[2]).Compare(t, "document loaded from a snapshot must match")
+
+	for _, want := range versions {
+		got, err := alice.GetDocument(ctx, &documents.GetDocumentRequest{Account: want.Account, Path: want.Path, Version: want.Version})
+		require.NoError(t, err)
+		testutil.StructsEqual(got, want).Compare(t, "document versions must match with snapshots around")
+	}
+}
+
+func TestDocumentSnapshotsInBackground(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	alice.snapshotInterval = 1
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	doc, err := alice.PublishDocumentChangeForTest(ctx, &apitest.DocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        alice.me.Account.PublicKey.String(),
+		Path:           "/background",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{
+				SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Background"},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	iri := must.Do2(blob.NewIRI(alice.me.Account.Principal(), doc.Path))
+	trustAll := func(core.Principal) bool { return true }
+	alice.scheduleSnapshot(alice.me.Account, alice.me.Account.Principal(), doc.Path)
+
+	_, found, err := alice.idx.LoadSnapshot(ctx, iri, nil, trustAll)
+	require.NoError(t, err)
+	require.False(t, found, "snapshots must not be created while publishing")
+
+	done := make(chan error, 1)
+	go func() { done <- alice.RunScheduler(ctx) }()
+
+	require.Eventually(t, func() bool {
+		_, found, err := alice.idx.LoadSnapshot(ctx, iri, nil, trustAll)
+		return err == nil && found
+	}, 5*time.Second, 10*time.Millisecond, "scheduler must create the pending snapshots")
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
 func TestConcurrentChanges(t *testing.T) {
 	t.Parallel()
 
@@ -549,6 +644,45 @@ func TestConcurrentChanges(t *testing.T) {
 	require.False(t, strings.Contains(merged.Version, "."), "merged version must not be composite")
 }
 
+func TestSpliceTextChanges(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+	account := alice.me.Account.Principal()
+
+	v1, err := alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, "/splice", "", "main").
+		MoveBlock("b1", "", "").
+		ReplaceBlock("b1", "paragraph", "Hello world").
+		Build(),
+	)
+	require.NoError(t, err)
+
+	// Concurrent splices of the same block are merged, instead of the last one winning.
+	_, err = alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, "/splice", v1.Version, "main").
+		SpliceText("b1", 0, 5, "Hi").
+		Build(),
+	)
+	require.NoError(t, err)
+
+	_, err = alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, "/splice", v1.Version, "main").
+		SpliceText("b1", 11, 0, "!").
+		Build(),
+	)
+	require.NoError(t, err)
+
+	merged, err := alice.GetDocument(ctx, &documents.GetDocumentRequest{Account: v1.Account, Path: v1.Path})
+	require.NoError(t, err)
+	require.Contains(t, merged.Version, ".", "splices must be concurrent")
+	require.Equal(t, "Hi world!", merged.Content[0].Block.Text)
+
+	_, err = alice.PublishDocumentChangeForTest(ctx, apitest.NewChangeBuilder(account, "/splice", merged.Version, "main").
+		SpliceText("b1", 8, 5, "").
+		Build(),
+	)
+	require.Error(t, err, "splices out of the text range must fail")
+}
+
 func TestDocumentChangePublishingWithTimestamp(t *testing.T) {
 	t.Parallel()
 
@@ -2313,6 +2447,104 @@ func TestUpdateProfile(t *testing.T) {
 	testutil.StructsEqual(profile, account.Profile).Compare(t, "profiles must match")
 }
 
+func TestGetAccountKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+	newKey := coretest.NewTester("bob").Account
+
+	_, err := alice.PublishDocumentChangeForTest(ctx, &apitest.DocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        alice.me.Account.PublicKey.String(),
+		Path:           "",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Alice's Home Page"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	rot, err := blob.NewKeyRotation(alice.me.Account, newKey, cclock.New().MustNow())
+	require.NoError(t, err)
+	require.NoError(t, alice.idx.Put(ctx, rot))
+
+	acc, err := alice.GetAccount(ctx, &documents.GetAccountRequest{Id: alice.me.Account.PublicKey.String()})
+	require.NoError(t, err)
+	require.Equal(t, newKey.PublicKey.String(), acc.Id, "old account must be resolved to the new key")
+}
+
+func TestCreateRefMultiSignature(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+	bob := coretest.NewTester("bob").Account
+	carol := coretest.NewTester("carol").Account
+	require.NoError(t, alice.keys.StoreKey(ctx, "bob", bob))
+	require.NoError(t, alice.keys.StoreKey(ctx, "carol", carol))
+
+	account := alice.me.Account.PublicKey.String()
+	home, err := alice.PublishDocumentChangeForTest(ctx, &apitest.DocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           "",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "ACME"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	clock := cclock.New()
+	ms, err := blob.NewMembership(alice.me.Account, alice.me.Account.Principal(), []core.Principal{bob.Principal(), carol.Principal()}, 2, clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, alice.idx.Put(ctx, ms))
+
+	prep, err := alice.PrepareChange(ctx, &documents.PrepareChangeRequest{
+		Account:     account,
+		BaseVersion: home.Version,
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "ACME Inc."}}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), prep.RequiredSignatures)
+	require.Equal(t, []string{bob.PublicKey.String(), carol.PublicKey.String()}, prep.Members)
+
+	heads, err := blob.Version(home.Version).Parse()
+	require.NoError(t, err)
+	change, err := blob.NewChange(bob, must.Do2(cid.Decode(home.Genesis)), heads, 2, blob.ChangeBody{
+		Ops: []blob.OpMap{must.Do2(blob.NewOpSetKey("title", "ACME Inc."))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, alice.idx.Put(ctx, change))
+
+	target := &documents.RefTarget{Target: &documents.RefTarget_Version_{Version: &documents.RefTarget_Version{
+		Genesis: home.Genesis,
+		Version: change.CID.String(),
+	}}}
+
+	_, err = alice.CreateRef(ctx, &documents.CreateRefRequest{Account: account, Target: target, SigningKeyName: "main"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err), "owner is not a member of the organization")
+
+	partial, err := alice.CreateRef(ctx, &documents.CreateRefRequest{Account: account, Target: target, SigningKeyName: "bob"})
+	require.NoError(t, err)
+	require.Empty(t, partial.Id, "ref with not enough signatures must not be published")
+	require.NotEmpty(t, partial.PartialRef)
+
+	_, err = alice.CreateRef(ctx, &documents.CreateRefRequest{PartialRef: partial.PartialRef, SigningKeyName: "bob"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "the same member can't sign twice")
+
+	ref, err := alice.CreateRef(ctx, &documents.CreateRefRequest{PartialRef: partial.PartialRef, SigningKeyName: "carol"})
+	require.NoError(t, err)
+	require.NotEmpty(t, ref.Id)
+	require.Empty(t, ref.PartialRef)
+	require.Equal(t, []string{carol.PublicKey.String()}, ref.Cosigners)
+
+	doc, err := alice.GetDocument(ctx, &documents.GetDocumentRequest{Account: account})
+	require.NoError(t, err)
+	require.Equal(t, "ACME Inc.", doc.Metadata.Fields["title"].GetStringValue())
+}
+
 type testServer struct {
 	*Server
 	me coretest.Tester
@@ -2405,6 +2637,7 @@ func newTestDocsAPIWithConfig(t *testing.T, name string, cfg config.Base) testSe
 	require.NoError(t, ks.StoreKey(context.Background(), "main", u.Account))
 
 	idx := must.Do2(blob.OpenIndex(context.Background(), db, logging.New("seed/index"+"/"+name, "debug")))
+	idx.SetKeyStore(ks)
 	srv := NewServer(cfg, ks, idx, db, logging.New("seed/documents"+"/"+name, "debug"), nil)
 
 	return testServer{Server: srv, me: u}
@@ -0,0 +1,437 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/blob"
+	"seed/backend/core"
+	documents "seed/backend/genproto/documents/v3alpha"
+	p2p "seed/backend/genproto/p2p/v1alpha"
+	"seed/backend/ipfs"
+	"seed/backend/util/cclock"
+	"seed/backend/util/dqb"
+	"seed/backend/util/errutil"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/multiformats/go-multicodec"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateInvite implements Access Control API.
+func (srv *Server) CreateInvite(ctx context.Context, in *documents.CreateInviteRequest) (*documents.Invite, error) {
+	{
+		if in.SigningKeyName == "" {
+			return nil, errutil.MissingArgument("signing_key_name")
+		}
+
+		if in.Account == "" {
+			return nil, errutil.MissingArgument("account")
+		}
+
+		if in.MaxUses < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "max_uses must not be negative")
+		}
+	}
+
+	if srv.p2p == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "invites can't be created without a P2P node to redeem them")
+	}
+
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := core.DecodePrincipal(in.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	if !acc.Equal(kp.Principal()) {
+		return nil, status.Errorf(codes.PermissionDenied, "signing key '%s' cannot create invites for account '%s'", kp.Principal(), acc)
+	}
+
+	role, ok := roleFromProto[in.Role]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported role '%s'", in.Role)
+	}
+
+	var expireTime time.Time
+	if in.ExpireTime != nil {
+		expireTime = in.ExpireTime.AsTime()
+		if !expireTime.After(time.Now()) {
+			return nil, status.Errorf(codes.InvalidArgument, "expire_time must be in the future")
+		}
+	}
+
+	info := srv.p2p.AddrInfo()
+	addrs := make([]string, len(info.Addrs))
+	for i, a := range info.Addrs {
+		addrs[i] = a.String()
+	}
+
+	inv, err := blob.NewInvite(kp, in.Path, role, in.Label, expireTime, info.ID.String(), addrs, cclock.New().MustNow())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to create invite: %v", err)
+	}
+
+	if err := srv.storeInvite(ctx, inv, in.MaxUses); err != nil {
+		return nil, err
+	}
+
+	return inviteToProto(inv, in.MaxUses, 0, inv.Decoded.Ts)
+}
+
+// storeInvite saves the invite, so it can be redeemed later.
+func (srv *Server) storeInvite(ctx context.Context, inv blob.Encoded[*blob.Invite], maxUses int32) error {
+	iri, err := makeIRI(inv.Decoded.Space(), inv.Decoded.Path)
+	if err != nil {
+		return err
+	}
+
+	var expireTime any
+	if !inv.Decoded.ExpireTime.IsZero() {
+		expireTime = inv.Decoded.ExpireTime.UnixMilli()
+	}
+
+	return srv.db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, qInsertInvite(), nil, inv.CID.String(), inv.Data, string(iri), maxUses, expireTime, inv.Decoded.Ts.UnixMilli())
+	})
+}
+
+// ListInvites implements Access Control API.
+func (srv *Server) ListInvites(ctx context.Context, in *documents.ListInvitesRequest) (*documents.ListInvitesResponse, error) {
+	var space string
+	if in.Account != "" {
+		acc, err := core.DecodePrincipal(in.Account)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse account '%s': %v", in.Account, err)
+		}
+		space = "hm://" + acc.String()
+	}
+
+	out := &documents.ListInvitesResponse{}
+	if err := srv.db.WithSave(ctx, func(conn *sqlite.Conn) (err error) {
+		rows, discard, check := sqlitex.Query(conn, qListInvites(), space, time.Now().UnixMilli()).All()
+		defer discard(&err)
+		for row := range rows {
+			inv, err := blob.DecodeInvite(row.ColumnBytes(0))
+			if err != nil {
+				return err
+			}
+
+			pb, err := inviteToProto(inv, int32(row.ColumnInt64(1)), int32(row.ColumnInt64(2)), time.UnixMilli(row.ColumnInt64(3)))
+			if err != nil {
+				return err
+			}
+
+			out.Invites = append(out.Invites, pb)
+		}
+		return check()
+	}); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// RevokeInvite implements Access Control API.
+func (srv *Server) RevokeInvite(ctx context.Context, in *documents.RevokeInviteRequest) (*emptypb.Empty, error) {
+	if in.Id == "" {
+		return nil, errutil.MissingArgument("id")
+	}
+
+	if err := srv.db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		if err := sqlitex.Exec(conn, qDeleteInvite(), nil, in.Id); err != nil {
+			return err
+		}
+
+		if conn.Changes() == 0 {
+			return status.Errorf(codes.NotFound, "invite '%s' not found", in.Id)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// RedeemInvite implements Access Control API.
+func (srv *Server) RedeemInvite(ctx context.Context, in *documents.RedeemInviteRequest) (*documents.Capability, error) {
+	{
+		if in.Token == "" {
+			return nil, errutil.MissingArgument("token")
+		}
+
+		if in.SigningKeyName == "" {
+			return nil, errutil.MissingArgument("signing_key_name")
+		}
+	}
+
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	inv, err := blob.DecodeInviteToken(in.Token)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	if srv.p2p == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "invites can't be redeemed without a P2P node")
+	}
+
+	pid, err := peer.Decode(inv.Decoded.Peer)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invite has invalid peer ID '%s': %v", inv.Decoded.Peer, err)
+	}
+
+	var addrs []multiaddr.Multiaddr
+	for _, a := range inv.Decoded.Addrs {
+		ma, err := multiaddr.NewMultiaddr(a)
+		if err != nil {
+			continue
+		}
+		addrs = append(addrs, ma)
+	}
+
+	client, err := srv.p2p.Client(ctx, pid, addrs...)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to connect to the peer that issued the invite: %v", err)
+	}
+
+	return srv.redeemInvite(ctx, kp, inv, client.RedeemInvite)
+}
+
+// redeemFunc sends the redemption to the peer that issued the invite.
+type redeemFunc func(ctx context.Context, in *p2p.RedeemInviteRequest, opts ...grpc.CallOption) (*p2p.RedeemInviteResponse, error)
+
+// redeemInvite exchanges the invite for a capability, and stores the capability locally.
+func (srv *Server) redeemInvite(ctx context.Context, kp *core.KeyPair, inv blob.Encoded[*blob.Invite], redeem redeemFunc) (*documents.Capability, error) {
+	rd, err := blob.NewInviteRedemption(kp, inv.CID, cclock.New().MustNow())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := redeem(ctx, &p2p.RedeemInviteRequest{
+		Invite:     inv.Data,
+		Redemption: rd.Data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cpb := &blob.Capability{}
+	if err := cbornode.DecodeInto(resp.Capability, cpb); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode issued capability: %v", err)
+	}
+
+	if cpb.Type != blob.TypeCapability ||
+		!cpb.Signer.Equal(inv.Decoded.Signer) ||
+		!cpb.Delegate.Equal(kp.Principal()) ||
+		cpb.Path != inv.Decoded.Path ||
+		cpb.Role != inv.Decoded.Role {
+		return nil, status.Errorf(codes.Internal, "issued capability doesn't match the invite")
+	}
+
+	if err := blob.Verify(cpb.Signer, cpb, cpb.Sig); err != nil {
+		return nil, status.Errorf(codes.Internal, "issued capability has invalid signature: %v", err)
+	}
+
+	blk := ipfs.NewBlock(multicodec.DagCbor, resp.Capability)
+	if err := srv.idx.Put(ctx, blk); err != nil {
+		return nil, err
+	}
+
+	return capToProto(blk.Cid(), cpb)
+}
+
+// IssueInvitedCapability issues a capability for the invitee of an invite created by this daemon.
+// Redeeming the same invite again with the same key returns the capability issued before.
+// It implements the [hmnet.InviteIssuer] interface.
+func (srv *Server) IssueInvitedCapability(ctx context.Context, in *p2p.RedeemInviteRequest) (*p2p.RedeemInviteResponse, error) {
+	inv, err := blob.DecodeInvite(in.Invite)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	rd, err := blob.DecodeInviteRedemption(in.Redemption)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	if !rd.Invite.Equals(inv.CID) {
+		return nil, status.Errorf(codes.InvalidArgument, "redemption is for a different invite")
+	}
+
+	// Issuing a capability and recording the redemption can't be done atomically,
+	// so we serialize redemptions to respect the limit on the number of uses.
+	srv.invitesMu.Lock()
+	defer srv.invitesMu.Unlock()
+
+	var (
+		found      bool
+		maxUses    int64
+		expireTime int64
+		useCount   int64
+		capID      string
+	)
+	if err := srv.db.WithSave(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, qGetInviteRedemption(), func(stmt *sqlite.Stmt) error {
+			found = true
+			maxUses = stmt.ColumnInt64(0)
+			expireTime = stmt.ColumnInt64(1)
+			useCount = stmt.ColumnInt64(2)
+			capID = stmt.ColumnText(3)
+			return nil
+		}, []byte(rd.Signer), inv.CID.String())
+	}); err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "invite '%s' doesn't exist or was revoked", inv.CID)
+	}
+
+	if capID != "" {
+		c, err := cid.Decode(capID)
+		if err != nil {
+			return nil, err
+		}
+
+		blk, err := srv.idx.Get(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+
+		return &p2p.RedeemInviteResponse{Capability: blk.RawData()}, nil
+	}
+
+	if expireTime != 0 && !time.Now().Before(time.UnixMilli(expireTime)) {
+		return nil, status.Errorf(codes.FailedPrecondition, "invite '%s' has expired", inv.CID)
+	}
+
+	if maxUses > 0 && useCount >= maxUses {
+		return nil, status.Errorf(codes.FailedPrecondition, "invite '%s' has been used the maximum number of times", inv.CID)
+	}
+
+	kp, err := srv.keyForPrincipal(ctx, inv.Decoded.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	now := cclock.New().MustNow()
+	cpb, err := blob.NewCapability(kp, rd.Signer, inv.Decoded.Space(), inv.Decoded.Path, inv.Decoded.Role, inv.Decoded.Label, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := srv.idx.Put(ctx, cpb); err != nil {
+		return nil, err
+	}
+
+	if err := srv.db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, qInsertInviteRedemption(), nil, inv.CID.String(), []byte(rd.Signer), cpb.CID.String(), now.UnixMilli())
+	}); err != nil {
+		return nil, err
+	}
+
+	// The new member needs the content key to read the private content of the space.
+	if err := srv.reshareSpaceKey(ctx, kp, false); err != nil {
+		return nil, err
+	}
+
+	return &p2p.RedeemInviteResponse{Capability: cpb.Data}, nil
+}
+
+// keyForPrincipal finds the local key pair of the principal.
+func (srv *Server) keyForPrincipal(ctx context.Context, principal core.Principal) (*core.KeyPair, error) {
+	keys, err := srv.keys.ListKeyPairs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, k := range keys {
+		if k.Principal().Equal(principal) {
+			return k.KeyPair, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.FailedPrecondition, "key '%s' is not available on this daemon", principal)
+}
+
+func inviteToProto(inv blob.Encoded[*blob.Invite], maxUses, useCount int32, createTime time.Time) (*documents.Invite, error) {
+	role, ok := roleToProto[inv.Decoded.Role]
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "unsupported role '%s' in invite '%s'", inv.Decoded.Role, inv.CID)
+	}
+
+	pb := &documents.Invite{
+		Id:         inv.CID.String(),
+		Token:      blob.EncodeInviteToken(inv.Data),
+		Account:    inv.Decoded.Space().String(),
+		Path:       inv.Decoded.Path,
+		Role:       role,
+		Label:      inv.Decoded.Label,
+		MaxUses:    maxUses,
+		UseCount:   useCount,
+		CreateTime: timestamppb.New(createTime),
+	}
+
+	if !inv.Decoded.ExpireTime.IsZero() {
+		pb.ExpireTime = timestamppb.New(inv.Decoded.ExpireTime)
+	}
+
+	return pb, nil
+}
+
+var qInsertInvite = dqb.Str(`
+	INSERT INTO invites (id, data, iri, max_uses, expire_time, create_time)
+	VALUES (?, ?, ?, ?, ?, ?)
+`)
+
+// Outstanding invites in the given space, or in all the spaces if it's empty.
+var qListInvites = dqb.Str(`
+	SELECT
+		i.data,
+		i.max_uses,
+		(SELECT COUNT(*) FROM invite_redemptions ir WHERE ir.invite = i.id) AS use_count,
+		i.create_time
+	FROM invites i
+	WHERE (:space = '' OR i.iri = :space OR (i.iri >= :space || '/' AND i.iri < :space || '0'))
+	AND (i.expire_time IS NULL OR i.expire_time > :now)
+	AND (i.max_uses = 0 OR use_count < i.max_uses)
+	ORDER BY i.create_time DESC
+`)
+
+var qDeleteInvite = dqb.Str(`
+	DELETE FROM invites WHERE id = ?
+`)
+
+// The invite with its use count, and the capability issued for the delegate, if any.
+var qGetInviteRedemption = dqb.Str(`
+	SELECT
+		i.max_uses,
+		COALESCE(i.expire_time, 0),
+		(SELECT COUNT(*) FROM invite_redemptions ir WHERE ir.invite = i.id),
+		COALESCE((SELECT ir.capability FROM invite_redemptions ir WHERE ir.invite = i.id AND ir.delegate = :delegate), '')
+	FROM invites i
+	WHERE i.id = :invite
+`)
+
+var qInsertInviteRedemption = dqb.Str(`
+	INSERT INTO invite_redemptions (invite, delegate, capability, redeem_time)
+	VALUES (?, ?, ?, ?)
+`)
@@ -0,0 +1,92 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/blob"
+	pb "seed/backend/genproto/documents/v3alpha"
+	p2p "seed/backend/genproto/p2p/v1alpha"
+	"seed/backend/util/cclock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestInvites(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	bob := newTestDocsAPI(t, "bob")
+	carol := newTestDocsAPI(t, "carol")
+	ctx := context.Background()
+
+	// Invites are normally created with CreateInvite, which needs a P2P node to put its address into the token.
+	newInvite := func(maxUses int32, expireTime time.Time) string {
+		t.Helper()
+		inv, err := blob.NewInvite(alice.me.Account, "/team", blob.RoleWriter, "Team", expireTime, alice.me.Device.PeerID().String(), nil, cclock.New().MustNow())
+		require.NoError(t, err)
+		require.NoError(t, alice.storeInvite(ctx, inv, maxUses))
+		return blob.EncodeInviteToken(inv.Data)
+	}
+
+	// Sends the redemption to alice directly, instead of over the P2P network.
+	redeem := func(invitee testServer, token string) (*pb.Capability, error) {
+		t.Helper()
+		inv, err := blob.DecodeInviteToken(token)
+		require.NoError(t, err)
+		return invitee.redeemInvite(ctx, invitee.me.Account, inv, func(ctx context.Context, in *p2p.RedeemInviteRequest, _ ...grpc.CallOption) (*p2p.RedeemInviteResponse, error) {
+			return alice.IssueInvitedCapability(ctx, in)
+		})
+	}
+
+	token := newInvite(1, time.Time{})
+
+	list, err := alice.ListInvites(ctx, &pb.ListInvitesRequest{Account: alice.me.Account.String()})
+	require.NoError(t, err)
+	require.Len(t, list.Invites, 1)
+	require.Equal(t, token, list.Invites[0].Token)
+	require.Equal(t, pb.Role_WRITER, list.Invites[0].Role)
+	require.Equal(t, int32(0), list.Invites[0].UseCount)
+
+	capab, err := redeem(bob, token)
+	require.NoError(t, err)
+	require.Equal(t, bob.me.Account.String(), capab.Delegate)
+	require.Equal(t, alice.me.Account.String(), capab.Account)
+	require.Equal(t, "/team", capab.Path)
+	require.Equal(t, pb.Role_WRITER, capab.Role)
+	require.Equal(t, "Team", capab.Label)
+
+	stored, err := bob.GetCapability(ctx, &pb.GetCapabilityRequest{Id: capab.Id})
+	require.NoError(t, err, "invitee must store the issued capability")
+	require.Equal(t, capab.Id, stored.Id)
+
+	_, err = alice.GetCapability(ctx, &pb.GetCapabilityRequest{Id: capab.Id})
+	require.NoError(t, err, "issuer must store the issued capability")
+
+	again, err := redeem(bob, token)
+	require.NoError(t, err)
+	require.Equal(t, capab.Id, again.Id, "redeeming again must return the same capability")
+
+	_, err = redeem(carol, token)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "invite must not be used more than allowed")
+
+	list, err = alice.ListInvites(ctx, &pb.ListInvitesRequest{})
+	require.NoError(t, err)
+	require.Empty(t, list.Invites, "used up invites are not outstanding")
+
+	revoked := newInvite(0, time.Now().Add(time.Hour))
+	inv, err := blob.DecodeInviteToken(revoked)
+	require.NoError(t, err)
+	_, err = alice.RevokeInvite(ctx, &pb.RevokeInviteRequest{Id: inv.CID.String()})
+	require.NoError(t, err)
+	_, err = redeem(carol, revoked)
+	require.Equal(t, codes.NotFound, status.Code(err), "revoked invites must not be redeemable")
+
+	forged, err := blob.NewInvite(bob.me.Account, "/team", blob.RoleWriter, "", time.Time{}, alice.me.Device.PeerID().String(), nil, cclock.New().MustNow())
+	require.NoError(t, err)
+	_, err = redeem(carol, blob.EncodeInviteToken(forged.Data))
+	require.Equal(t, codes.NotFound, status.Code(err), "only invites created by the daemon can be redeemed")
+}
@@ -5,10 +5,15 @@ import (
 	"seed/backend/api/apitest"
 	"seed/backend/blob"
 	"seed/backend/config"
+	"seed/backend/core"
 	"seed/backend/core/coretest"
 	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/cclock"
+	"seed/backend/util/must"
 	"testing"
 
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -537,3 +542,82 @@ func TestPrivateDocSecurity_PublicOnlyListingsExcludePrivateDocs(t *testing.T) {
 	require.NoError(t, err)
 	require.ElementsMatch(t, []string{"/public-a", "/public-b"}, collectPaths(query.Documents))
 }
+
+func TestPrivateDocumentChangesAreEncrypted(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	alice := newTestDocsAPI(t, "alice")
+	account := alice.me.Account.PublicKey.String()
+
+	publish := func(path string, visibility documents.ResourceVisibility) *documents.Document {
+		doc, err := alice.PublishDocumentChangeForTest(ctx, &apitest.DocumentChangeRequest{
+			SigningKeyName: "main",
+			Account:        account,
+			Path:           path,
+			Visibility:     visibility,
+			Changes: []*documents.DocumentChange{
+				{Op: &documents.DocumentChange_SetMetadata_{
+					SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Secret Document"},
+				}},
+			},
+		})
+		require.NoError(t, err)
+		return doc
+	}
+
+	storedChange := func(version string) (*blob.Change, []byte) {
+		blk, err := alice.idx.Get(ctx, must.Do2(cid.Decode(version)))
+		require.NoError(t, err)
+		ch := &blob.Change{}
+		require.NoError(t, cbornode.DecodeInto(blk.RawData(), ch))
+		return ch, blk.RawData()
+	}
+
+	privateDoc := publish("/secret", documents.ResourceVisibility_RESOURCE_VISIBILITY_PRIVATE)
+	ch, data := storedChange(privateDoc.Version)
+	require.NotNil(t, ch.Encrypted, "private changes must be encrypted")
+	require.Empty(t, ch.Body.Ops)
+	require.NotContains(t, string(data), "Secret Document")
+	require.Equal(t, "Secret Document", privateDoc.Metadata.Fields["title"].GetStringValue(), "the owner must still read the document")
+
+	publicDoc := publish("/public", documents.ResourceVisibility_RESOURCE_VISIBILITY_PUBLIC)
+	ch, data = storedChange(publicDoc.Version)
+	require.Nil(t, ch.Encrypted)
+	require.Contains(t, string(data), "Secret Document")
+
+	// Snapshots would store the state of private documents in plaintext.
+	alice.snapshotInterval = 1
+	trustAll := func(core.Principal) bool { return true }
+	for _, doc := range []*documents.Document{privateDoc, publicDoc} {
+		alice.maybeCreateSnapshot(ctx, alice.me.Account, alice.me.Account.Principal(), doc.Path)
+	}
+
+	_, found, err := alice.idx.LoadSnapshot(ctx, must.Do2(blob.NewIRI(alice.me.Account.Principal(), privateDoc.Path)), nil, trustAll)
+	require.NoError(t, err)
+	require.False(t, found, "private documents must not be snapshotted")
+
+	_, found, err = alice.idx.LoadSnapshot(ctx, must.Do2(blob.NewIRI(alice.me.Account.Principal(), publicDoc.Path)), nil, trustAll)
+	require.NoError(t, err)
+	require.True(t, found)
+
+	// Members can't write private content until the owner shares the content key with them.
+	bob := newTestDocsAPI(t, "bob")
+	bobCap, err := blob.NewCapability(alice.me.Account, bob.me.Account.Principal(), alice.me.Account.Principal(), "", blob.RoleWriter, "", cclock.New().MustNow())
+	require.NoError(t, err)
+	require.NoError(t, bob.idx.Put(ctx, bobCap))
+
+	_, err = bob.PublishDocumentChangeForTest(ctx, &apitest.DocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           "/bob-secret",
+		Visibility:     documents.ResourceVisibility_RESOURCE_VISIBILITY_PRIVATE,
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{
+				SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Secret Document"},
+			}},
+		},
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "private content must never be written in plaintext")
+	require.ErrorContains(t, err, "no content key")
+}
@@ -0,0 +1,454 @@
+package documents
+
+import (
+	"context"
+	"math"
+	"seed/backend/api/documents/v3alpha/docmodel"
+	"seed/backend/blob"
+	"seed/backend/core"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/apiutil"
+	"seed/backend/util/cclock"
+	"seed/backend/util/dqb"
+	"seed/backend/util/errutil"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateReaction implements Comments API.
+func (srv *Server) CreateReaction(ctx context.Context, in *documents.CreateReactionRequest) (*documents.Reaction, error) {
+	if in.SigningKeyName == "" {
+		return nil, errutil.MissingArgument("signing_key_name")
+	}
+
+	if in.TargetVersion == "" {
+		return nil, errutil.MissingArgument("target_version")
+	}
+
+	if in.Value == "" {
+		return nil, errutil.MissingArgument("value")
+	}
+
+	if in.TargetBlock != "" && in.TargetComment != "" {
+		return nil, status.Errorf(codes.InvalidArgument, "target_block and target_comment are mutually exclusive")
+	}
+
+	versionHeads, err := blob.Version(in.TargetVersion).Parse()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse target version: %v", err)
+	}
+
+	space, err := core.DecodePrincipal(in.TargetAccount)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse target account: %v", err)
+	}
+
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	iri, err := blob.NewIRI(space, in.TargetPath)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse target path: %v", err)
+	}
+
+	clock := cclock.New()
+
+	var comment cid.Cid
+	if err := srv.db.WithSave(ctx, func(conn *sqlite.Conn) error {
+		// Reacting again within the same clock tick would produce the exact same blob,
+		// so every new reaction must be newer than the author's previous ones on the document.
+		var lastTs int64
+		if err := sqlitex.Exec(conn, qLatestReactionTs(), func(stmt *sqlite.Stmt) error {
+			lastTs = stmt.ColumnInt64(0)
+			return nil
+		}, iri, kp.Principal()); err != nil {
+			return err
+		}
+		if lastTs > 0 {
+			if err := clock.Track(time.UnixMilli(lastTs)); err != nil {
+				return err
+			}
+		}
+
+		if in.TargetComment == "" {
+			return nil
+		}
+
+		icmt, err := srv.getComment(conn, in.TargetComment)
+		if err != nil {
+			return err
+		}
+
+		if !icmt.Comment.Space().Equal(space) || icmt.Comment.Path != in.TargetPath {
+			return status.Errorf(codes.InvalidArgument, "comment %s doesn't belong to the target document", in.TargetComment)
+		}
+
+		comment = icmt.CID
+		return clock.Track(icmt.Comment.Ts)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Reactions inherit visibility from their target document, like comments.
+	visibility, err := srv.idx.GetDocumentVisibility(ctx, space, in.TargetPath)
+	if err != nil {
+		visibility = blob.VisibilityPublic
+	}
+
+	eb, err := blob.NewReaction(kp, "", space, in.TargetPath, versionHeads, in.TargetBlock, comment, in.Value, visibility, clock.MustNow())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to create reaction: %v", err)
+	}
+
+	if err := srv.idx.Put(ctx, eb); err != nil {
+		return nil, err
+	}
+
+	return sqlitex.Read(ctx, srv.db, func(conn *sqlite.Conn) (*documents.Reaction, error) {
+		return reactionToProto(blob.NewLookupCache(conn), eb.CID, eb.Decoded, eb.TSID())
+	})
+}
+
+// DeleteReaction implements Comments API.
+func (srv *Server) DeleteReaction(ctx context.Context, in *documents.DeleteReactionRequest) (*emptypb.Empty, error) {
+	if in.Id == "" {
+		return nil, errutil.MissingArgument("id")
+	}
+
+	if in.SigningKeyName == "" {
+		return nil, errutil.MissingArgument("signing_key_name")
+	}
+
+	rid, err := blob.DecodeRecordID(in.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode reaction ID: %v", err)
+	}
+
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !kp.Principal().Equal(rid.Authority) {
+		return nil, status.Errorf(codes.PermissionDenied, "only the original author can delete a reaction")
+	}
+
+	clock := cclock.New()
+
+	var original *blob.Reaction
+	if err := srv.db.WithSave(ctx, func(conn *sqlite.Conn) error {
+		ir, err := srv.getReaction(conn, rid)
+		if err != nil {
+			return err
+		}
+		original = ir.Reaction
+		return clock.Track(original.Ts)
+	}); err != nil {
+		return nil, err
+	}
+
+	eb, err := blob.NewReaction(kp, rid.TSID, original.Space(), original.Path, original.Version, original.Block, original.Comment, "", original.Visibility, clock.MustNow())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete reaction: %v", err)
+	}
+
+	if err := srv.idx.Put(ctx, eb); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to store reaction deletion: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListReactions implements Comments API.
+func (srv *Server) ListReactions(ctx context.Context, in *documents.ListReactionsRequest) (*documents.ListReactionsResponse, error) {
+	acc, err := core.DecodePrincipal(in.TargetAccount)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse target account '%s': %v", in.TargetAccount, err)
+	}
+
+	iri, err := blob.NewIRI(acc, in.TargetPath)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse target path '%s': %v", in.TargetPath, err)
+	}
+
+	if in.TargetBlock != "" && in.TargetComment != "" {
+		return nil, status.Errorf(codes.InvalidArgument, "target_block and target_comment are mutually exclusive")
+	}
+
+	if in.PageSize == 0 {
+		in.PageSize = defaultPageSize
+	}
+
+	var cursor struct {
+		ReactionID int64 `json:"r_id"`
+	}
+
+	cursor.ReactionID = math.MaxInt64
+
+	if in.PageToken != "" {
+		if err := apiutil.DecodePageToken(in.PageToken, &cursor, nil); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+
+	publicOnly, err := srv.isPublicOnlyFor(ctx, acc, in.TargetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	target := reactionTarget{Block: in.TargetBlock, Comment: in.TargetComment}
+
+	return sqlitex.Read(ctx, srv.db, func(conn *sqlite.Conn) (resp *documents.ListReactionsResponse, err error) {
+		resp = &documents.ListReactionsResponse{
+			Reactions: make([]*documents.Reaction, 0, min(in.PageSize, maxPageAllocBuffer)),
+		}
+
+		if in.PageToken == "" {
+			counts, err := loadReactionCounts(conn, iri, publicOnly)
+			if err != nil {
+				return nil, err
+			}
+			resp.Counts = counts[target]
+		}
+
+		lookup := blob.NewLookupCache(conn)
+		rows, discard, check := sqlitex.QueryType(conn, srv.reactionDBMapper, qListReactions(),
+			iri, target.Block, target.Comment, publicOnly, cursor.ReactionID, in.PageSize+1).All()
+		defer discard(&err)
+
+		for ir := range rows {
+			if len(resp.Reactions) == int(in.PageSize) {
+				resp.NextPageToken = apiutil.EncodePageToken(cursor, nil)
+				break
+			}
+
+			pb, err := reactionToProto(lookup, ir.CID, ir.Reaction, ir.TSID)
+			if err != nil {
+				return nil, err
+			}
+
+			resp.Reactions = append(resp.Reactions, pb)
+			cursor.ReactionID = ir.DBID
+		}
+
+		return resp, check()
+	})
+}
+
+var qListReactions = dqb.Str(`
+	SELECT
+		sb.id,
+		b.codec,
+		b.multihash,
+		b.data,
+		sb.extra_attrs->>'tsid' AS tsid
+	FROM (
+		SELECT
+			sb.*,
+			ROW_NUMBER() OVER (PARTITION BY sb.author, sb.extra_attrs->>'tsid' ORDER BY sb.ts DESC, sb.id DESC) rn
+		FROM structural_blobs sb
+		WHERE sb.type = 'Reaction'
+		AND sb.resource = (SELECT id FROM resources WHERE iri = :iri)
+	) sb
+	JOIN blobs b ON b.id = sb.id
+	WHERE sb.rn = 1
+	AND sb.extra_attrs->>'deleted' IS NULL
+	AND coalesce(sb.extra_attrs->>'block', '') = :block
+	AND coalesce(sb.extra_attrs->>'comment', '') = :comment
+	AND (NOT :publicOnly OR sb.extra_attrs->>'visibility' IS NOT 'Private')
+	AND sb.id < :afterID
+	ORDER BY sb.id DESC
+	LIMIT :limit
+`)
+
+// reactionTarget identifies what reactions are about within a document.
+// Zero value means the document itself.
+type reactionTarget struct {
+	Block   string
+	Comment string // Record ID of the comment.
+}
+
+// loadReactionCounts aggregates the current reactions to the document and all of its blocks and comments.
+// Each author is counted once per value and target, no matter how many times they reacted.
+func loadReactionCounts(conn *sqlite.Conn, iri blob.IRI, publicOnly bool) (out map[reactionTarget][]*documents.ReactionCount, err error) {
+	rows, discard, check := sqlitex.Query(conn, qReactionCounts(), iri, publicOnly).All()
+	defer discard(&err)
+
+	for row := range rows {
+		seq := sqlite.NewIncrementor(0)
+		var (
+			target = reactionTarget{
+				Block:   row.ColumnText(seq()),
+				Comment: row.ColumnText(seq()),
+			}
+			value = row.ColumnText(seq())
+			count = row.ColumnInt64(seq())
+		)
+
+		if out == nil {
+			out = make(map[reactionTarget][]*documents.ReactionCount)
+		}
+
+		out[target] = append(out[target], &documents.ReactionCount{
+			Value: value,
+			Count: int32(count), //nolint:gosec // Number of authors can't overflow int32.
+		})
+	}
+
+	return out, check()
+}
+
+// fillDocumentReactions sets the counts of the reactions to the document itself.
+func (srv *Server) fillDocumentReactions(ctx context.Context, conn *sqlite.Conn, iri blob.IRI, info *documents.DocumentInfo) error {
+	acc, path, err := iri.SpacePath()
+	if err != nil {
+		return err
+	}
+
+	publicOnly, err := srv.isPublicOnlyFor(ctx, acc, path)
+	if err != nil {
+		return err
+	}
+
+	counts, err := loadReactionCounts(conn, iri, publicOnly)
+	if err != nil {
+		return err
+	}
+
+	info.Reactions = counts[reactionTarget{}]
+	return nil
+}
+
+var qReactionCounts = dqb.Str(`
+	SELECT
+		coalesce(sb.extra_attrs->>'block', '') AS block,
+		coalesce(sb.extra_attrs->>'comment', '') AS comment,
+		sb.extra_attrs->>'value' AS value,
+		count(DISTINCT sb.author) AS cnt
+	FROM (
+		SELECT
+			sb.*,
+			ROW_NUMBER() OVER (PARTITION BY sb.author, sb.extra_attrs->>'tsid' ORDER BY sb.ts DESC, sb.id DESC) rn
+		FROM structural_blobs sb
+		WHERE sb.type = 'Reaction'
+		AND sb.resource = (SELECT id FROM resources WHERE iri = :iri)
+	) sb
+	WHERE sb.rn = 1
+	AND sb.extra_attrs->>'deleted' IS NULL
+	AND (NOT :publicOnly OR sb.extra_attrs->>'visibility' IS NOT 'Private')
+	GROUP BY block, comment, value
+	ORDER BY block, comment, cnt DESC, value
+`)
+
+type indexedReaction struct {
+	DBID     int64
+	CID      cid.Cid
+	TSID     blob.TSID
+	Reaction *blob.Reaction
+}
+
+func (srv *Server) reactionDBMapper(stmt *sqlite.Stmt) (ir indexedReaction, err error) {
+	seq := sqlite.NewIncrementor(0)
+	var (
+		id    = stmt.ColumnInt64(seq())
+		codec = stmt.ColumnInt64(seq())
+		hash  = stmt.ColumnBytesUnsafe(seq())
+		data  = stmt.ColumnBytesUnsafe(seq())
+		tsid  = stmt.ColumnText(seq())
+	)
+
+	buf, err := srv.idx.Decompress(data, nil)
+	if err != nil {
+		return ir, err
+	}
+
+	r := &blob.Reaction{}
+	if err := cbornode.DecodeInto(buf, r); err != nil {
+		return ir, err
+	}
+
+	return indexedReaction{
+		DBID:     id,
+		CID:      cid.NewCidV1(uint64(codec), hash), //nolint:gosec
+		TSID:     blob.TSID(tsid),
+		Reaction: r,
+	}, nil
+}
+
+func (srv *Server) getReaction(conn *sqlite.Conn, rid blob.RecordID) (out indexedReaction, err error) {
+	rows, discard, check := sqlitex.QueryType(conn, srv.reactionDBMapper, qGetReactionByID(), rid.Authority, rid.TSID.String()).All()
+	defer discard(&err)
+
+	for ir := range rows {
+		out = ir
+		break
+	}
+
+	if err := check(); err != nil {
+		return out, err
+	}
+
+	if out.Reaction == nil || out.Reaction.IsTombstone() {
+		return out, status.Errorf(codes.NotFound, "reaction %s not found", rid)
+	}
+
+	return out, nil
+}
+
+var qLatestReactionTs = dqb.Str(`
+	SELECT max(sb.ts)
+	FROM structural_blobs sb
+	WHERE sb.type = 'Reaction'
+	AND sb.resource = (SELECT id FROM resources WHERE iri = :iri)
+	AND sb.author = (SELECT id FROM public_keys WHERE principal = :author)
+`)
+
+var qGetReactionByID = dqb.Str(`
+	SELECT
+		sb.id,
+		b.codec,
+		b.multihash,
+		b.data,
+		sb.extra_attrs->>'tsid' AS tsid
+	FROM structural_blobs sb
+	JOIN blobs b ON b.id = sb.id
+	WHERE sb.type = 'Reaction'
+	AND sb.author = (SELECT id FROM public_keys WHERE principal = :authority)
+	AND sb.extra_attrs->>'tsid' = :tsid
+	ORDER BY sb.ts DESC, sb.id DESC
+	LIMIT 1
+`)
+
+func reactionToProto(lookup *blob.LookupCache, c cid.Cid, r *blob.Reaction, tsid blob.TSID) (*documents.Reaction, error) {
+	pb := &documents.Reaction{
+		Id:            blob.RecordID{Authority: r.Signer, TSID: tsid}.String(),
+		TargetAccount: r.Space().String(),
+		TargetPath:    r.Path,
+		TargetVersion: docmodel.NewVersion(r.Version...).String(),
+		TargetBlock:   r.Block,
+		Author:        r.Signer.String(),
+		Value:         r.Value,
+		CreateTime:    timestamppb.New(tsid.Timestamp()),
+		Version:       c.String(),
+	}
+
+	if r.Comment.Defined() {
+		rid, err := lookup.RecordID(r.Comment)
+		if err != nil {
+			return nil, err
+		}
+		pb.TargetComment = rid.String()
+	}
+
+	return pb, nil
+}
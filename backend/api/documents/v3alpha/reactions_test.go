@@ -0,0 +1,115 @@
+package documents
+
+import (
+	"context"
+	"seed/backend/api/apitest"
+	"seed/backend/core/coretest"
+	pb "seed/backend/genproto/documents/v3alpha"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReactions(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	bob := coretest.NewTester("bob")
+	ctx := context.Background()
+	require.NoError(t, alice.keys.StoreKey(ctx, "bob", bob.Account))
+
+	account := alice.me.Account.PublicKey.String()
+
+	homeDoc, err := alice.PublishDocumentChangeForTest(ctx, &apitest.DocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           "",
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_SetMetadata_{SetMetadata: &pb.DocumentChange_SetMetadata{Key: "title", Value: "Alice's Home Page"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	cmt, err := alice.CreateComment(ctx, &pb.CreateCommentRequest{
+		SigningKeyName: "bob",
+		TargetAccount:  account,
+		TargetVersion:  homeDoc.Version,
+		Content: []*pb.BlockNode{
+			{Block: &pb.Block{Id: "b1", Type: "paragraph", Text: "Hello, Alice!"}},
+		},
+	})
+	require.NoError(t, err)
+
+	react := func(key, block, comment, value string) *pb.Reaction {
+		r, err := alice.CreateReaction(ctx, &pb.CreateReactionRequest{
+			SigningKeyName: key,
+			TargetAccount:  account,
+			TargetVersion:  homeDoc.Version,
+			TargetBlock:    block,
+			TargetComment:  comment,
+			Value:          value,
+		})
+		require.NoError(t, err)
+		return r
+	}
+
+	docLike := react("main", "", "", "👍")
+	react("bob", "", "", "👍")
+	bobParty := react("bob", "", "", "🎉")
+	react("bob", "", "", "🎉") // Same author reacting twice is only counted once.
+	react("main", "", cmt.Id, "+1")
+	react("main", "b1", "", "❤️")
+
+	require.Equal(t, cmt.Id, react("bob", "", cmt.Id, "+1").TargetComment)
+
+	_, err = alice.CreateReaction(ctx, &pb.CreateReactionRequest{
+		SigningKeyName: "main",
+		TargetAccount:  account,
+		TargetVersion:  homeDoc.Version,
+		Value:          "not short",
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "reaction values must be short tokens")
+
+	info, err := alice.GetDocumentInfo(ctx, &pb.GetDocumentInfoRequest{Account: account})
+	require.NoError(t, err)
+	require.Equal(t, []*pb.ReactionCount{{Value: "👍", Count: 2}, {Value: "🎉", Count: 1}}, info.Reactions)
+
+	comments, err := alice.ListComments(ctx, &pb.ListCommentsRequest{TargetAccount: account})
+	require.NoError(t, err)
+	require.Len(t, comments.Comments, 1)
+	require.Equal(t, []*pb.ReactionCount{{Value: "+1", Count: 2}}, comments.Comments[0].Reactions)
+
+	blockReactions, err := alice.ListReactions(ctx, &pb.ListReactionsRequest{TargetAccount: account, TargetBlock: "b1"})
+	require.NoError(t, err)
+	require.Len(t, blockReactions.Reactions, 1)
+	require.Equal(t, "❤️", blockReactions.Reactions[0].Value)
+	require.Equal(t, []*pb.ReactionCount{{Value: "❤️", Count: 1}}, blockReactions.Counts)
+
+	// Paging through the document reactions.
+	page1, err := alice.ListReactions(ctx, &pb.ListReactionsRequest{TargetAccount: account, PageSize: 3})
+	require.NoError(t, err)
+	require.Len(t, page1.Reactions, 3)
+	require.NotEmpty(t, page1.NextPageToken)
+
+	page2, err := alice.ListReactions(ctx, &pb.ListReactionsRequest{TargetAccount: account, PageSize: 3, PageToken: page1.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, page2.Reactions, 1)
+	require.Empty(t, page2.NextPageToken)
+	require.Equal(t, docLike.Id, page2.Reactions[0].Id, "reactions must be listed newest first")
+
+	// Deleting reactions.
+	_, err = alice.DeleteReaction(ctx, &pb.DeleteReactionRequest{Id: bobParty.Id, SigningKeyName: "main"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err), "only the author can delete a reaction")
+
+	_, err = alice.DeleteReaction(ctx, &pb.DeleteReactionRequest{Id: docLike.Id, SigningKeyName: "main"})
+	require.NoError(t, err)
+
+	_, err = alice.DeleteReaction(ctx, &pb.DeleteReactionRequest{Id: docLike.Id, SigningKeyName: "main"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	info, err = alice.GetDocumentInfo(ctx, &pb.GetDocumentInfoRequest{Account: account})
+	require.NoError(t, err)
+	require.Equal(t, []*pb.ReactionCount{{Value: "🎉", Count: 1}, {Value: "👍", Count: 1}}, info.Reactions, "ties are ordered by value")
+}
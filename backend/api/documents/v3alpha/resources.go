@@ -426,6 +426,9 @@ func (srv *Server) GetResource(ctx context.Context, in *documents.GetResourceReq
 
 				var cmt *documents.Comment
 				if err := srv.db.WithSave(ctx, func(conn *sqlite.Conn) error {
+					if err := srv.idx.OpenComment(conn, v); err != nil {
+						return err
+					}
 					lc := blob.NewLookupCache(conn)
 					v, err := commentToProto(lc, blb.CID, v, tsid)
 					cmt = v
@@ -473,7 +473,7 @@ func TestGetResource(t *testing.T) {
 func mustCreateComment(ctx context.Context, t *testing.T, idx *blob.Index, u *coretest.Tester, space core.Principal, path string, version []cid.Cid, threadRoot, replyParent cid.Cid, body []blob.CommentBlock, ts time.Time) blob.Encoded[*blob.Comment] {
 	t.Helper()
 
-	eb, err := blob.NewComment(u.Account, "", space, path, version, threadRoot, replyParent, body, blob.VisibilityPublic, ts.Round(blob.ClockPrecision))
+	eb, err := blob.NewComment(u.Account, "", space, path, version, threadRoot, replyParent, body, nil, blob.VisibilityPublic, ts.Round(blob.ClockPrecision))
 	require.NoError(t, err)
 
 	// eb implements blocks.Block directly
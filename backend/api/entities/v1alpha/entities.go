@@ -519,6 +519,7 @@ matched_fts AS MATERIALIZED (
            WHERE root_sb.id = fts.blob_id
              AND root_r.iri GLOB 'hm://*'
              AND root_r.iri NOT GLOB 'hm://*/*'
+             AND root_sb.extra_attrs->>'branch' IS NULL
          )
          OR EXISTS (
            SELECT 1
@@ -529,6 +530,7 @@ matched_fts AS MATERIALIZED (
              AND root_bl.type = 'ref/head'
              AND root_r.iri GLOB 'hm://*'
              AND root_r.iri NOT GLOB 'hm://*/*'
+             AND root_ref.extra_attrs->>'branch' IS NULL
          ))
   ORDER BY
     (fts.type = 'contact' OR fts.type = 'title' OR fts.type = 'profile') DESC,
@@ -645,6 +647,7 @@ matched_fts AS MATERIALIZED (
            WHERE root_sb.id = fts.blob_id
              AND root_r.iri GLOB 'hm://*'
              AND root_r.iri NOT GLOB 'hm://*/*'
+             AND root_sb.extra_attrs->>'branch' IS NULL
          )
          OR EXISTS (
            SELECT 1
@@ -655,6 +658,7 @@ matched_fts AS MATERIALIZED (
              AND root_bl.type = 'ref/head'
              AND root_r.iri GLOB 'hm://*'
              AND root_r.iri NOT GLOB 'hm://*/*'
+             AND root_ref.extra_attrs->>'branch' IS NULL
          ))
   ORDER BY
     (fts.type = 'contact' OR fts.type = 'title' OR fts.type = 'profile') DESC,
@@ -2,6 +2,7 @@ package entities
 
 import (
 	"context"
+	"maps"
 	documentsapi "seed/backend/api/documents/v3alpha"
 	"seed/backend/blob"
 	"seed/backend/config"
@@ -12,9 +13,13 @@ import (
 	"seed/backend/hmnet/syncing"
 	"seed/backend/logging"
 	"seed/backend/storage"
+	"seed/backend/util/cclock"
 	"seed/backend/util/must"
+	"slices"
 	"testing"
 
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -48,6 +53,7 @@ func (f *fakeDiscoverer) TouchHotTask(iri blob.IRI, version blob.Version, recurs
 type testServices struct {
 	documents *documentsapi.Server
 	entities  *Server
+	idx       *blob.Index
 	me        coretest.Tester
 }
 
@@ -64,6 +70,7 @@ func newTestServices(t *testing.T, name string) testServices {
 	return testServices{
 		documents: documentsapi.NewServer(config.Base{}, ks, idx, db, logging.New("seed/documents"+"/"+name, "debug"), nil),
 		entities:  NewServer(config.Base{}, db, nil, nil, logging.New("seed/entities"+"/"+name, "debug")),
+		idx:       idx,
 		me:        u,
 	}
 }
@@ -165,6 +172,45 @@ func TestSearchEntitiesFindsProfileOnlyAccount(t *testing.T) {
 	require.Equal(t, "web eric 84", res.Entities[0].Content)
 }
 
+func TestKeywordSearchRootDocumentsSkipsBranches(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestServices(t, "alice")
+	ctx := context.Background()
+	kp := svc.me.Account
+	clock := cclock.New()
+
+	change1, err := blob.NewChange(kp, cid.Undef, nil, 0, blob.ChangeBody{
+		Ops: []blob.OpMap{must.Do2(blob.NewOpSetKey("title", "Published alpha"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	ref1, err := blob.NewRef(kp, 0, change1.CID, kp.Principal(), "", []cid.Cid{change1.CID}, clock.MustNow(), blob.VisibilityPublic)
+	require.NoError(t, err)
+
+	change2, err := blob.NewChange(kp, change1.CID, []cid.Cid{change1.CID}, 1, blob.ChangeBody{
+		Ops: []blob.OpMap{must.Do2(blob.NewOpSetKey("title", "Draft alpha"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	branch, err := blob.NewBranchRef(kp, change1.CID, kp.Principal(), "", "draft", []cid.Cid{change2.CID}, clock.MustNow(), blob.VisibilityPublic)
+	require.NoError(t, err)
+
+	require.NoError(t, svc.idx.PutMany(ctx, []blocks.Block{change1, ref1, change2, branch}))
+
+	ids := func(rootDocumentsOnly bool) []int64 {
+		t.Helper()
+		conn, release, err := svc.entities.db.ReadConn(ctx)
+		require.NoError(t, err)
+		defer release()
+
+		res, err := keywordSearch(conn, "alpha", 10, map[string]bool{"title": true}, "", false, rootDocumentsOnly)
+		require.NoError(t, err)
+		return slices.Sorted(maps.Keys(res))
+	}
+
+	require.Len(t, ids(false), 2)
+	require.Len(t, ids(true), 1, "draft branch content must not be found as root content")
+}
+
 func TestBuildRankMap(t *testing.T) {
 	t.Parallel()
 
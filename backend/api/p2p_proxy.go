@@ -167,6 +167,34 @@ func (p *p2pProxy) Authenticate(ctx context.Context, in *p2p.AuthenticateRequest
 	return client.Authenticate(ctx, in)
 }
 
+func (p *p2pProxy) RedeemInvite(ctx context.Context, in *p2p.RedeemInviteRequest) (*p2p.RedeemInviteResponse, error) {
+	pid, err := p.targetPeer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := p.node.Client(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.RedeemInvite(ctx, in)
+}
+
+func (p *p2pProxy) RequestAccess(ctx context.Context, in *p2p.RequestAccessRequest) (*p2p.RequestAccessResponse, error) {
+	pid, err := p.targetPeer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := p.node.Client(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.RequestAccess(ctx, in)
+}
+
 func (p *p2pProxy) targetPeer(ctx context.Context) (peer.ID, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
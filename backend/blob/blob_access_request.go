@@ -0,0 +1,81 @@
+package blob
+
+import (
+	"fmt"
+	"seed/backend/core"
+	"seed/backend/ipfs"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multicodec"
+)
+
+// TypeAccessRequest is the type of the AccessRequest blob.
+//
+// Access requests are sent directly to the daemon of the space owner,
+// and are never indexed or shared with other peers.
+const TypeAccessRequest Type = "AccessRequest"
+
+const accessRequestMessageLimitBytes = 1024
+
+// AccessRequest is a request of the signer to be granted a role in a space.
+type AccessRequest struct {
+	BaseBlob
+	Space   core.Principal `refmt:"space"`
+	Path    string         `refmt:"path,omitempty"`
+	Role    Role           `refmt:"role"`
+	Message string         `refmt:"message,omitempty"`
+}
+
+// NewAccessRequest creates a new signed AccessRequest.
+func NewAccessRequest(requester *core.KeyPair, space core.Principal, path string, role Role, message string, ts time.Time) (eb Encoded[*AccessRequest], err error) {
+	if len(message) > accessRequestMessageLimitBytes {
+		return eb, fmt.Errorf("access request message exceeds the maximum allowed limit of %d bytes", accessRequestMessageLimitBytes)
+	}
+
+	ar := &AccessRequest{
+		BaseBlob: BaseBlob{
+			Type:   TypeAccessRequest,
+			Signer: requester.Principal(),
+			Ts:     ts,
+		},
+		Space:   space,
+		Path:    path,
+		Role:    role,
+		Message: message,
+	}
+
+	if err := Sign(requester, ar, &ar.BaseBlob.Sig); err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(ar)
+}
+
+// DecodeAccessRequest decodes and verifies the raw data of an AccessRequest.
+func DecodeAccessRequest(data []byte) (eb Encoded[*AccessRequest], err error) {
+	ar := &AccessRequest{}
+	if err := cbornode.DecodeInto(data, ar); err != nil {
+		return eb, fmt.Errorf("failed to decode access request: %w", err)
+	}
+
+	if ar.Type != TypeAccessRequest {
+		return eb, fmt.Errorf("invalid access request type '%s'", ar.Type)
+	}
+
+	if len(ar.Message) > accessRequestMessageLimitBytes {
+		return eb, fmt.Errorf("access request message exceeds the maximum allowed limit of %d bytes", accessRequestMessageLimitBytes)
+	}
+
+	if err := Verify(ar.Signer, ar, ar.Sig); err != nil {
+		return eb, fmt.Errorf("invalid access request signature: %w", err)
+	}
+
+	blk := ipfs.NewBlock(multicodec.DagCbor, data)
+
+	return Encoded[*AccessRequest]{CID: blk.Cid(), Data: blk.RawData(), Decoded: ar}, nil
+}
+
+func init() {
+	cbornode.RegisterCborType(AccessRequest{})
+}
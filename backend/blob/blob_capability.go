@@ -28,8 +28,10 @@ type Role string
 // Everywhere else in the permanent data we use PascalNotation for the string enum-like values.
 // Eventually we could probably do a migration to gain better consistency.
 const (
-	RoleWriter Role = "WRITER"
-	RoleAgent  Role = "AGENT"
+	RoleWriter    Role = "WRITER"
+	RoleAgent     Role = "AGENT"
+	RoleCommenter Role = "COMMENTER" // Can read private documents and comment on them.
+	RoleReader    Role = "READER"    // Can only read private documents.
 )
 
 // Capability is a blob that represents some granted rights from the issuer to the delegate key.
@@ -40,10 +42,30 @@ type Capability struct {
 	Path     string         `refmt:"path,omitempty"`
 	Role     Role           `refmt:"role,omitempty"`
 	Label    string         `refmt:"label,omitempty"`
+
+	// NotBefore and ExpireTime optionally bound the period of time in which the capability is valid.
+	// NotBefore is inclusive, ExpireTime is exclusive. Zero values mean the capability is not bounded on that side.
+	// The validity is checked against the timestamp of the blob being authorized by the capability.
+	NotBefore  time.Time `refmt:"notBefore,omitempty"`
+	ExpireTime time.Time `refmt:"expireTime,omitempty"`
 }
 
 // NewCapability creates a new Capability blob.
 func NewCapability(issuer *core.KeyPair, delegate, space core.Principal, path string, role Role, label string, ts time.Time) (eb Encoded[*Capability], err error) {
+	return NewTimeBoundCapability(issuer, delegate, space, path, role, label, time.Time{}, time.Time{}, ts)
+}
+
+// NewTimeBoundCapability creates a new Capability blob which is only valid in the [notBefore, expireTime) period.
+// Any of the bounds can be zero to leave that side of the period open.
+func NewTimeBoundCapability(issuer *core.KeyPair, delegate, space core.Principal, path string, role Role, label string, notBefore, expireTime, ts time.Time) (eb Encoded[*Capability], err error) {
+	if !notBefore.IsZero() {
+		notBefore = notBefore.Round(ClockPrecision)
+	}
+
+	if !expireTime.IsZero() {
+		expireTime = expireTime.Round(ClockPrecision)
+	}
+
 	cu := &Capability{
 		BaseBlob: BaseBlob{
 			Type:   TypeCapability,
@@ -54,6 +76,13 @@ func NewCapability(issuer *core.KeyPair, delegate, space core.Principal, path st
 		Path:     path,
 		Role:     role,
 		Label:    label,
+
+		NotBefore:  notBefore,
+		ExpireTime: expireTime,
+	}
+
+	if err := cu.validateWindow(); err != nil {
+		return eb, err
 	}
 
 	if !issuer.Principal().Equal(space) {
@@ -73,6 +102,14 @@ func (c *Capability) Space() core.Principal {
 	return c.Signer
 }
 
+func (c *Capability) validateWindow() error {
+	if !c.NotBefore.IsZero() && !c.ExpireTime.IsZero() && !c.ExpireTime.After(c.NotBefore) {
+		return fmt.Errorf("capability expire time %s must be after its not-before time %s", c.ExpireTime, c.NotBefore)
+	}
+
+	return nil
+}
+
 // ValidateCapabilityLabel checks the validity of a capability label.
 func ValidateCapabilityLabel(label string) error {
 	if label == "" {
@@ -151,20 +188,38 @@ func indexCapability(ictx *indexingCtx, _ int64, eb Encoded[*Capability]) error
 		return err
 	}
 
-	sb.ExtraAttrs = map[string]any{
+	if err := v.validateWindow(); err != nil {
+		return err
+	}
+
+	extra := map[string]any{
 		"role": v.Role,
 		"del":  del,
 	}
 
+	// Validity bounds are stored as unix millis, to be compared with the timestamps of other blobs.
+	if !v.NotBefore.IsZero() {
+		extra["nbf"] = v.NotBefore.UnixMilli()
+	}
+
+	if !v.ExpireTime.IsZero() {
+		extra["exp"] = v.ExpireTime.UnixMilli()
+	}
+
+	sb.ExtraAttrs = extra
+
 	if err := ictx.SaveBlob(sb); err != nil {
 		return err
 	}
 
 	// A new capability changes writer-validity outcomes, so drop the memoized
-	// isValidWriter results before re-validating the blobs it unstashes. Keyed on
-	// the Capability blob type, this invalidation also covers any future
-	// capability change (e.g. revocation) without further code changes.
+	// isValidWriter results before re-validating the blobs it unstashes.
 	ictx.writerCache.clear()
 
-	return reindexStashedBlobs(ictx.childOpts(), ictx.conn, stashReasonPermissionDenied, v.Delegate.String(), ictx.blockStore, ictx.log, ictx.writerCache, ictx.hookIDs)
+	if err := reindexStashedBlobs(ictx.childOpts(), ictx.conn, stashReasonPermissionDenied, v.Delegate.String(), ictx.blockStore, ictx.log, ictx.writerCache, ictx.hookIDs); err != nil {
+		return err
+	}
+
+	// Revocations that arrived before this capability are waiting for it.
+	return reindexStashedBlobs(ictx.childOpts(), ictx.conn, stashReasonFailedPrecondition, c.String(), ictx.blockStore, ictx.log, ictx.writerCache, ictx.hookIDs)
 }
@@ -8,7 +8,9 @@ import (
 	"seed/backend/util/sqlite"
 	"seed/backend/util/sqlite/sqlitex"
 	"testing"
+	"time"
 
+	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -91,6 +93,72 @@ func TestOutOfOrderCapability_FiresIndexedHookForUnstashed(t *testing.T) {
 	require.Contains(t, hookIDs, refID, "indexed hook must fire for the blob unstashed by the capability cascade")
 }
 
+func TestTimeBoundCapability(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	bob := coretest.NewTester("bob").Account
+	carol := coretest.NewTester("carol").Account
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+	require.NoError(t, err)
+
+	base := cclock.New().MustNow()
+	notBefore := base.Add(time.Hour)
+	expireTime := base.Add(2 * time.Hour)
+
+	_, err = NewTimeBoundCapability(alice, bob.Principal(), alice.Principal(), "", RoleWriter, "", expireTime, notBefore, base)
+	require.Error(t, err, "expire time must be after not-before time")
+
+	cpb, err := NewTimeBoundCapability(alice, bob.Principal(), alice.Principal(), "", RoleWriter, "", notBefore, expireTime, base)
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), cpb))
+
+	// Bob writes into different documents before, during, and after the validity window of his capability.
+	putDoc := func(path string, ts time.Time) {
+		change, err := NewChange(bob, cid.Undef, nil, 0, ChangeBody{
+			Ops: []OpMap{must.Do2(NewOpSetKey("name", path))},
+		}, ts)
+		require.NoError(t, err)
+		ref, err := NewRef(bob, 0, change.CID, alice.Principal(), path, []cid.Cid{change.CID}, ts, VisibilityPublic)
+		require.NoError(t, err)
+		require.NoError(t, idx.PutMany(t.Context(), []blocks.Block{change, ref}))
+	}
+
+	putDoc("/early", notBefore.Add(-time.Minute))
+	require.Equal(t, 1, countStashedBlobs(t, db), "ref signed before the not-before time must be stashed")
+
+	putDoc("/valid", notBefore)
+	require.Equal(t, 1, countStashedBlobs(t, db), "ref signed within the validity window must be indexed")
+
+	putDoc("/late", expireTime)
+	require.Equal(t, 2, countStashedBlobs(t, db), "ref signed at the expire time must be stashed")
+
+	for ts, want := range map[time.Time]bool{
+		notBefore.Add(-time.Millisecond):  false,
+		notBefore:                         true,
+		expireTime.Add(-time.Millisecond): true,
+		expireTime:                        false,
+	} {
+		valid, err := idx.IsValidWriterAt(t.Context(), alice.Principal(), "/doc", bob.Principal(), ts)
+		require.NoError(t, err)
+		require.Equal(t, want, valid, "validity at %s", ts)
+	}
+
+	// Already expired capabilities are not listed.
+	expired, err := NewTimeBoundCapability(alice, carol.Principal(), alice.Principal(), "", RoleWriter, "", time.Time{}, base.Add(-time.Hour), base)
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), expired))
+
+	var caps []cid.Cid
+	collect := func(c cid.Cid, _ *Capability) error {
+		caps = append(caps, c)
+		return nil
+	}
+	require.NoError(t, idx.WalkCapabilitiesForDelegate(t.Context(), carol.Principal(), collect))
+	require.Empty(t, caps, "expired capabilities must not be listed")
+	require.NoError(t, idx.WalkCapabilitiesForDelegate(t.Context(), bob.Principal(), collect))
+	require.Equal(t, []cid.Cid{cpb.CID}, caps, "capabilities that are not valid yet must be listed")
+}
+
 func countStashedBlobs(t *testing.T, db *sqlitex.Pool) int {
 	count, err := sqlitex.QueryOnePool[int](t.Context(), db, "SELECT count() FROM stashed_blobs")
 	require.NoError(t, err)
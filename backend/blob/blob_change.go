@@ -29,6 +29,10 @@ type Change struct {
 	Deps    []cid.Cid  `refmt:"deps,omitempty"`
 	Depth   int        `refmt:"depth,omitempty"`
 	Body    ChangeBody `refmt:"body,omitempty"`
+
+	// Encrypted is the body of a private change encrypted with the space content key.
+	// When set, Body is empty in the signed blob, and is only filled in after decryption.
+	Encrypted *EncryptedBody `refmt:"encrypted,omitempty"`
 }
 
 // ChangeBody is the body of a Change.
@@ -71,6 +75,45 @@ func NewChange(signer core.Signer, genesis cid.Cid, deps []cid.Cid, depth int, b
 	return encodeBlob(cc)
 }
 
+// NewEncryptedChange creates a new Change with the body encrypted with the space content key.
+func NewEncryptedChange(signer core.Signer, genesis cid.Cid, deps []cid.Cid, depth int, body ChangeBody, key ContentKey, ts time.Time) (eb Encoded[*Change], err error) {
+	if !slices.IsSortedFunc(deps, func(a, b cid.Cid) int {
+		return cmp.Compare(a.KeyString(), b.KeyString())
+	}) {
+		panic("BUG: deps are not sorted")
+	}
+
+	enc, err := key.Seal(body)
+	if err != nil {
+		return eb, err
+	}
+
+	cc := &Change{
+		BaseBlob: BaseBlob{
+			Type:   TypeChange,
+			Signer: signer.Principal(),
+			Ts:     ts,
+		},
+		Genesis:   genesis,
+		Deps:      deps,
+		Depth:     depth,
+		Encrypted: enc,
+	}
+
+	if err := Sign(signer, cc, &cc.BaseBlob.Sig); err != nil {
+		return eb, err
+	}
+
+	eb, err = encodeBlob(cc)
+	if err != nil {
+		return eb, err
+	}
+
+	// Keep the plaintext body around for the caller, like it would be after decryption.
+	eb.Decoded.Body = body
+	return eb, nil
+}
+
 // Ops is an iterator over the ops in the change.
 // We don't expose the underlying slice of Ops,
 // because eventually some data will be run-length encoded in there.
@@ -92,6 +135,7 @@ func init() {
 	cbornode.RegisterCborType(OpMoveBlocks{})
 	cbornode.RegisterCborType(OpDeleteBlocks{})
 	cbornode.RegisterCborType(OpSetAttributes{})
+	cbornode.RegisterCborType(OpSpliceText{})
 	cbornode.RegisterCborType(KeyValue{})
 
 	// We decided to encode our union types with type-specific fields inlined.
@@ -165,6 +209,8 @@ func (o OpMap) ToOp() (Op, error) {
 			return opDeleteBlocksFromMap(o)
 		case OpTypeSetAttributes:
 			return opSetAttributesFromMap(o)
+		case OpTypeSpliceText:
+			return opSpliceTextFromMap(o)
 		default:
 			return nil, fmt.Errorf("unsupported op type %s", ot)
 		}
@@ -180,6 +226,7 @@ const (
 	OpTypeMoveBlocks    OpType = "MoveBlocks"
 	OpTypeReplaceBlock  OpType = "ReplaceBlock"
 	OpTypeDeleteBlocks  OpType = "DeleteBlocks"
+	OpTypeSpliceText    OpType = "SpliceText"
 )
 
 // Op a common interface implemented by all ops.
@@ -321,6 +368,37 @@ func NewOpDeleteBlocks(blocks []string) OpMap {
 	return cborToMap(op)
 }
 
+// OpSpliceText represents the op to edit the text of a block at the character level,
+// so that concurrent edits of the same block are merged instead of overwriting each other.
+//
+// Characters are addressed by their RGA position: the op ID of the run of text they belong to,
+// followed by the 1-based offset of the character within the run.
+// The base text of the block set by ReplaceBlock is the run of that op,
+// and offset 0 of the base run stands for the start of the text.
+// Each SpliceText op inserts a single run of text, identified by the op's own ID.
+type OpSpliceText struct {
+	baseOp
+	Block  string     `refmt:"block"`
+	Ref    []uint64   `refmt:"ref,omitempty"`    // Position of the character to insert the text after.
+	Insert string     `refmt:"insert,omitempty"` // Text to insert.
+	Delete [][]uint64 `refmt:"delete,omitempty"` // Ranges of characters to delete: run op ID followed by the first and the last offsets.
+}
+
+// NewOpSpliceText creates the corresponding op.
+func NewOpSpliceText(block string, ref []uint64, insert string, del [][]uint64) OpMap {
+	op := OpSpliceText{
+		baseOp: baseOp{
+			Type: OpTypeSpliceText,
+		},
+		Block:  block,
+		Ref:    ref,
+		Insert: insert,
+		Delete: del,
+	}
+
+	return cborToMap(op)
+}
+
 func init() {
 	matcher := makeCBORTypeMatch(TypeChange)
 	registerIndexer(TypeChange,
@@ -362,6 +440,29 @@ func indexChange(ictx *indexingCtx, id int64, eb Encoded[*Change]) error {
 		// Everything else is invalid.
 		return fmt.Errorf("invalid change causality invariants: cid=%s genesis=%s deps=%v depth=%v", c, v.Genesis, v.Deps, v.Depth)
 	}
+
+	// Keys that were rotated away can't author changes anymore.
+	{
+		authorID, err := ictx.ensurePubKey(author)
+		if err != nil {
+			return err
+		}
+
+		lineage, err := loadKeyLineage(ictx.conn, authorID, ictx.writerCache)
+		if err != nil {
+			return err
+		}
+
+		if lineage.retiredAt(v.Ts.UnixMilli()) {
+			return stashError{
+				Reason: stashReasonPermissionDenied,
+				Metadata: stashMetadata{
+					DeniedSigners: []core.Principal{author},
+				},
+			}
+		}
+	}
+
 	var sb structuralBlob
 	{
 		var resourceTime time.Time
@@ -399,6 +500,10 @@ func indexChange(ictx *indexingCtx, id int64, eb Encoded[*Change]) error {
 		sb.AddBlobLink("change/dep", dep)
 	}
 
+	if err := ictx.openEncrypted(v.Encrypted, &v.Body); err != nil {
+		return err
+	}
+
 	extra := changeIndexedAttrs{Actor: uint64(author.ActorID())}
 	opIndex := -1
 	for op, err := range v.Ops() {
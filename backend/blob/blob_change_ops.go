@@ -146,6 +146,30 @@ func opUint64Slice(v any) ([]uint64, error) {
 	return out, nil
 }
 
+// opUint64Matrix extracts a [][]uint64 field, with the same nil/empty distinction
+// as opStringSlice for the outer slice.
+func opUint64Matrix(v any) ([][]uint64, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	items, ok := v.([]any)
+	if !ok {
+		return nil, errWrongValueType
+	}
+
+	out := make([][]uint64, len(items))
+	for i, item := range items {
+		row, err := opUint64Slice(item)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		out[i] = row
+	}
+
+	return out, nil
+}
+
 // opBlock extracts a Block field, using the same mapstruct call that Block's
 // registered atlas transform uses.
 func opBlock(v any) (Block, error) {
@@ -305,3 +329,27 @@ func opDeleteBlocksFromMap(o OpMap) (Op, error) {
 	}
 	return out, nil
 }
+
+func opSpliceTextFromMap(o OpMap) (Op, error) {
+	out := OpSpliceText{baseOp: baseOp{Type: OpTypeSpliceText}}
+	for k, v := range o {
+		var err error
+		switch k {
+		case "type":
+		case "block":
+			out.Block, err = opString(v)
+		case "ref":
+			out.Ref, err = opUint64Slice(v)
+		case "insert":
+			out.Insert, err = opString(v)
+		case "delete":
+			out.Delete, err = opUint64Matrix(v)
+		default:
+			err = errUnknownOpField
+		}
+		if err != nil {
+			return nil, opFieldError(OpTypeSpliceText, k, v, err)
+		}
+	}
+	return out, nil
+}
@@ -47,6 +47,9 @@ func toOpReference(o OpMap) (Op, error) {
 		case OpTypeSetAttributes:
 			var out OpSetAttributes
 			return out, mapToCBORReference(o, &out)
+		case OpTypeSpliceText:
+			var out OpSpliceText
+			return out, mapToCBORReference(o, &out)
 		default:
 			return nil, fmt.Errorf("unsupported op type %s", ot)
 		}
@@ -95,6 +98,11 @@ func opMapCorpus() map[string]OpMap {
 		"SetAttributes/emptyAttrs": {"type": "SetAttributes", "block": "b1", "attrs": []any{}},
 		"SetAttributes/nullAttrs":  {"type": "SetAttributes", "block": "b1", "attrs": nil},
 
+		"SpliceText/insert":     NewOpSpliceText("b1", []uint64{1234, 5, 6, 7}, "Hello", nil),
+		"SpliceText/delete":     NewOpSpliceText("b1", nil, "", [][]uint64{{1234, 5, 6, 1, 3}, {2, 1, 1}}),
+		"SpliceText/replace":    NewOpSpliceText("b1", []uint64{3, 0}, "World", [][]uint64{{3, 1, 5}}),
+		"SpliceText/nullDelete": {"type": "SpliceText", "block": "b1", "ref": []any{1, 0}, "insert": "x", "delete": nil},
+
 		"ReplaceBlock/simple": NewOpReplaceBlock(Block{
 			ID_Good: "b1",
 			Type:    "Paragraph",
@@ -23,6 +23,7 @@ const TypeComment Type = "Comment"
 
 func init() {
 	cbornode.RegisterCborType(Comment{})
+	cbornode.RegisterCborType(CommentAnchor{})
 
 	cbornode.RegisterCborType(atlas.BuildEntry(CommentBlock{}).Transform().
 		TransformMarshal(atlas.MakeMarshalTransformFunc(func(in CommentBlock) (map[string]any, error) {
@@ -55,7 +56,12 @@ type Comment struct {
 	ThreadRoot   cid.Cid        `refmt:"threadRoot,omitempty"`
 	ReplyParent_ cid.Cid        `refmt:"replyParent,omitempty"`
 	Body         []CommentBlock `refmt:"body"`
+	Anchor       *CommentAnchor `refmt:"anchor,omitempty"`
 	Visibility   Visibility     `refmt:"visibility,omitempty"`
+
+	// Encrypted is the body of a private comment encrypted with the space content key.
+	// When set, Body is empty in the signed blob, and is only filled in after decryption.
+	Encrypted *EncryptedBody `refmt:"encrypted,omitempty"`
 }
 
 // NewComment creates a new Comment blob.
@@ -68,6 +74,7 @@ func NewComment(
 	threadRoot cid.Cid,
 	replyParent cid.Cid,
 	body []CommentBlock,
+	anchor *CommentAnchor,
 	visibility Visibility,
 	ts time.Time,
 ) (eb Encoded[*Comment], err error) {
@@ -75,6 +82,10 @@ func NewComment(
 		replyParent = cid.Undef
 	}
 
+	if err := anchor.validate(); err != nil {
+		return eb, err
+	}
+
 	cu := &Comment{
 		ID: id,
 		BaseBlob: BaseBlob{
@@ -87,6 +98,7 @@ func NewComment(
 		ThreadRoot:   threadRoot,
 		ReplyParent_: replyParent,
 		Body:         body,
+		Anchor:       anchor,
 		Visibility:   visibility,
 	}
 
@@ -101,6 +113,78 @@ func NewComment(
 	return encodeBlob(cu)
 }
 
+// NewEncryptedComment creates a new private Comment with the body encrypted with the space content key.
+// Tombstones (comments with an empty body) are not encrypted.
+func NewEncryptedComment(
+	kp *core.KeyPair,
+	id TSID,
+	space core.Principal,
+	path string,
+	version []cid.Cid,
+	threadRoot cid.Cid,
+	replyParent cid.Cid,
+	body []CommentBlock,
+	anchor *CommentAnchor,
+	key ContentKey,
+	ts time.Time,
+) (eb Encoded[*Comment], err error) {
+	if len(body) == 0 {
+		return NewComment(kp, id, space, path, version, threadRoot, replyParent, body, anchor, VisibilityPrivate, ts)
+	}
+
+	if threadRoot.Equals(replyParent) {
+		replyParent = cid.Undef
+	}
+
+	if err := anchor.validate(); err != nil {
+		return eb, err
+	}
+
+	enc, err := key.Seal(body)
+	if err != nil {
+		return eb, err
+	}
+
+	cu := &Comment{
+		ID: id,
+		BaseBlob: BaseBlob{
+			Type:   TypeComment,
+			Signer: kp.Principal(),
+			Ts:     ts,
+		},
+		Path:         path,
+		Version:      version,
+		ThreadRoot:   threadRoot,
+		ReplyParent_: replyParent,
+		Anchor:       anchor,
+		Visibility:   VisibilityPrivate,
+		Encrypted:    enc,
+	}
+
+	if !kp.Principal().Equal(space) {
+		cu.Space_ = space
+	}
+
+	if err := Sign(kp, cu, &cu.BaseBlob.Sig); err != nil {
+		return eb, err
+	}
+
+	eb, err = encodeBlob(cu)
+	if err != nil {
+		return eb, err
+	}
+
+	// Keep the plaintext body around for the caller, like it would be after decryption.
+	eb.Decoded.Body = body
+	return eb, nil
+}
+
+// IsTombstone reports whether the comment is a deletion marker.
+// Encrypted comments are never tombstones, even if we can't decrypt their body.
+func (c *Comment) IsTombstone() bool {
+	return len(c.Body) == 0 && c.Encrypted == nil
+}
+
 // TSID implements the [ReplacementBlob] interface.
 func (c *Comment) TSID() TSID {
 	return c.ID
@@ -126,6 +210,36 @@ func (c *Comment) Space() core.Principal {
 	return c.Space_
 }
 
+// CommentAnchor points a comment to a block of the target document version,
+// or to a range of text within that block.
+type CommentAnchor struct {
+	Block string `refmt:"block"`
+
+	// Start and End are the offsets of the text range in Unicode code points, like annotation ranges.
+	// Both are zero when the anchor refers to the whole block.
+	Start int `refmt:"start,omitempty"`
+	End   int `refmt:"end,omitempty"`
+
+	// Revision is the change that set the content of the block in the target version.
+	Revision cid.Cid `refmt:"revision,omitempty"`
+}
+
+func (a *CommentAnchor) validate() error {
+	if a == nil {
+		return nil
+	}
+
+	if a.Block == "" {
+		return fmt.Errorf("comment anchor must have a block")
+	}
+
+	if a.Start < 0 || (a.End <= a.Start && (a.Start != 0 || a.End != 0)) {
+		return fmt.Errorf("invalid comment anchor range [%d, %d)", a.Start, a.End)
+	}
+
+	return nil
+}
+
 // CommentBlock is a block of text with annotations.
 type CommentBlock struct {
 	Block `mapstructure:",squash"`
@@ -232,8 +346,39 @@ func indexComment(ictx *indexingCtx, id int64, eb Encoded[*Comment]) error {
 		}
 	}
 
+	// Private documents can only be commented by those who can read them,
+	// so we require a capability for commenting from the space owner.
+	if v.Visibility == VisibilityPrivate && !v.Signer.Equal(v.Space()) {
+		signerID, err := ictx.ensurePubKey(v.Signer)
+		if err != nil {
+			return err
+		}
+
+		ok, err := isValidCommenter(ictx.conn, signerID, iri, v.Ts.UnixMilli())
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return stashError{
+				Reason: stashReasonPermissionDenied,
+				Metadata: stashMetadata{
+					DeniedSigners: []core.Principal{v.Signer},
+				},
+			}
+		}
+	}
+
+	if v.Encrypted != nil && v.Visibility != VisibilityPrivate {
+		return fmt.Errorf("only private comments can be encrypted")
+	}
+
+	if err := ictx.openEncrypted(v.Encrypted, &v.Body); err != nil {
+		return err
+	}
+
 	// Check if this is a tombstone (deleted comment)
-	isTombstone := len(v.Body) == 0
+	isTombstone := v.IsTombstone()
 
 	extraAttrs := make(map[string]any)
 
@@ -324,6 +469,12 @@ func indexComment(ictx *indexingCtx, id int64, eb Encoded[*Comment]) error {
 		return err
 	}
 
+	if v.Anchor != nil {
+		if err := indexCommentAnchor(ictx, id, iri, v); err != nil {
+			return fmt.Errorf("failed to index comment anchor: %w", err)
+		}
+	}
+
 	// If the comment we've just indexed was a reply parent of another comment we've seen before,
 	// we need to reindex those comments.
 	if err := reindexStashedBlobs(ictx.childOpts(), ictx.conn, stashReasonFailedPrecondition, c.String(), ictx.blockStore, ictx.log, ictx.writerCache, ictx.hookIDs); err != nil {
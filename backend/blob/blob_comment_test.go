@@ -54,7 +54,7 @@ func TestCommentCausality(t *testing.T) {
 			Type: "paragraph",
 			Text: "Hello World",
 		}},
-	}, VisibilityPublic, clock.MustNow())
+	}, nil, VisibilityPublic, clock.MustNow())
 	require.NoError(t, err)
 
 	reply, err := NewComment(bob.Account, root.TSID(), root.Decoded.Space(), root.Decoded.Path, root.Decoded.Version, root.CID, cid.Undef, []CommentBlock{
@@ -62,7 +62,7 @@ func TestCommentCausality(t *testing.T) {
 			Type: "paragraph",
 			Text: "I reply",
 		}},
-	}, VisibilityPublic, clock.MustNow())
+	}, nil, VisibilityPublic, clock.MustNow())
 	require.NoError(t, err)
 
 	reply2, err := NewComment(bob.Account, root.TSID(), root.Decoded.Space(), root.Decoded.Path, root.Decoded.Version, root.CID, reply.CID, []CommentBlock{
@@ -70,7 +70,7 @@ func TestCommentCausality(t *testing.T) {
 			Type: "paragraph",
 			Text: "I reply to reply",
 		}},
-	}, VisibilityPublic, clock.MustNow())
+	}, nil, VisibilityPublic, clock.MustNow())
 
 	blobs := colx.SlicePermutations([]struct {
 		Name string
@@ -102,6 +102,43 @@ func TestCommentCausality(t *testing.T) {
 	}
 }
 
+func TestPrivateCommentRequiresCommenter(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	bob := coretest.NewTester("bob").Account
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+	require.NoError(t, err)
+
+	c := ipfs.MustNewCID(multicodec.Raw, multicodec.Identity, []byte("fake-version"))
+	clock := cclock.New()
+
+	reader, err := NewCapability(alice, bob.Principal(), alice.Principal(), "", RoleReader, "", clock.MustNow())
+	require.NoError(t, err)
+	commenter, err := NewCapability(alice, bob.Principal(), alice.Principal(), "/private", RoleCommenter, "", clock.MustNow())
+	require.NoError(t, err)
+
+	cmt, err := NewComment(bob, "", alice.Principal(), "/private", []cid.Cid{c}, cid.Undef, cid.Undef, []CommentBlock{
+		{Block: Block{
+			Type: "paragraph",
+			Text: "Private remark",
+		}},
+	}, nil, VisibilityPrivate, clock.MustNow())
+	require.NoError(t, err)
+
+	require.NoError(t, idx.Put(t.Context(), cmt))
+	require.Equal(t, 1, countStashedBlobs(t, db), "private comment without a capability must be stashed")
+
+	require.NoError(t, idx.Put(t.Context(), reader))
+	require.Equal(t, 1, countStashedBlobs(t, db), "READER capability must not allow commenting on private documents")
+
+	require.NoError(t, idx.Put(t.Context(), commenter))
+	require.Equal(t, 0, countStashedBlobs(t, db), "COMMENTER capability must allow commenting on private documents")
+
+	valid, err := idx.IsValidWriter(t.Context(), alice.Principal(), "/private", bob.Principal())
+	require.NoError(t, err)
+	require.False(t, valid, "COMMENTER capability must not grant write access")
+}
+
 func TestStableCommentLinksAreIndexed(t *testing.T) {
 	alice := coretest.NewTester("alice")
 	bob := coretest.NewTester("bob")
@@ -113,7 +150,7 @@ func TestStableCommentLinksAreIndexed(t *testing.T) {
 			Type: "paragraph",
 			Text: "Target comment",
 		}},
-	}, VisibilityPublic, clock.MustNow())
+	}, nil, VisibilityPublic, clock.MustNow())
 	require.NoError(t, err)
 
 	source, err := NewComment(bob.Account, "", alice.Account.Principal(), "", []cid.Cid{targetVersion}, cid.Undef, cid.Undef, []CommentBlock{
@@ -122,7 +159,7 @@ func TestStableCommentLinksAreIndexed(t *testing.T) {
 			Text: "Stable link",
 			Link: RecordID{Authority: target.Decoded.Signer, TSID: target.TSID()}.IRI().String() + "?v=" + target.CID.String(),
 		}},
-	}, VisibilityPublic, clock.MustNow())
+	}, nil, VisibilityPublic, clock.MustNow())
 	require.NoError(t, err)
 
 	blobs := colx.SlicePermutations([]struct {
@@ -0,0 +1,163 @@
+package blob
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"seed/backend/core"
+	"seed/backend/ipfs"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multicodec"
+)
+
+// Invite types.
+//
+// Unlike other blob types, invites and redemptions are never indexed or shared with other peers.
+// An invite is a bearer token passed around out of band, and whoever holds it can redeem it
+// for a Capability from the peer that issued the invite.
+const (
+	TypeInvite           Type = "Invite"
+	TypeInviteRedemption Type = "InviteRedemption"
+)
+
+// Invite is a signed promise of the space owner to issue a Capability to whoever redeems it.
+type Invite struct {
+	BaseBlob
+	Path       string    `refmt:"path,omitempty"`
+	Role       Role      `refmt:"role"`
+	Label      string    `refmt:"label,omitempty"`
+	ExpireTime time.Time `refmt:"expireTime,omitempty"`
+	// Peer is the ID of the peer that issued the invite, and where it must be redeemed.
+	Peer string `refmt:"peer"`
+	// Addrs are the known addresses of the issuing peer at the time of the invite creation.
+	Addrs []string `refmt:"addrs,omitempty"`
+	// Nonce makes every invite unique, even with the same parameters.
+	Nonce []byte `refmt:"nonce"`
+}
+
+// NewInvite creates a new signed Invite for the space of the issuer.
+func NewInvite(issuer *core.KeyPair, path string, role Role, label string, expireTime time.Time, peer string, addrs []string, ts time.Time) (eb Encoded[*Invite], err error) {
+	if err := ValidateCapabilityLabel(label); err != nil {
+		return eb, err
+	}
+
+	if !expireTime.IsZero() {
+		expireTime = expireTime.Round(ClockPrecision)
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return eb, err
+	}
+
+	inv := &Invite{
+		BaseBlob: BaseBlob{
+			Type:   TypeInvite,
+			Signer: issuer.Principal(),
+			Ts:     ts,
+		},
+		Path:       path,
+		Role:       role,
+		Label:      label,
+		ExpireTime: expireTime,
+		Peer:       peer,
+		Addrs:      addrs,
+		Nonce:      nonce,
+	}
+
+	if err := Sign(issuer, inv, &inv.BaseBlob.Sig); err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(inv)
+}
+
+// DecodeInvite decodes and verifies the raw data of an Invite.
+func DecodeInvite(data []byte) (eb Encoded[*Invite], err error) {
+	inv := &Invite{}
+	if err := cbornode.DecodeInto(data, inv); err != nil {
+		return eb, fmt.Errorf("failed to decode invite: %w", err)
+	}
+
+	if inv.Type != TypeInvite {
+		return eb, fmt.Errorf("invalid invite type '%s'", inv.Type)
+	}
+
+	if err := Verify(inv.Signer, inv, inv.Sig); err != nil {
+		return eb, fmt.Errorf("invalid invite signature: %w", err)
+	}
+
+	blk := ipfs.NewBlock(multicodec.DagCbor, data)
+
+	return Encoded[*Invite]{CID: blk.Cid(), Data: blk.RawData(), Decoded: inv}, nil
+}
+
+// Space returns the space the invite gives access to.
+func (inv *Invite) Space() core.Principal {
+	return inv.Signer
+}
+
+// EncodeInviteToken returns the textual token of the invite to be shared with the invitees.
+func EncodeInviteToken(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeInviteToken decodes and verifies the invite from its textual token.
+func DecodeInviteToken(token string) (eb Encoded[*Invite], err error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return eb, fmt.Errorf("malformed invite token: %w", err)
+	}
+
+	return DecodeInvite(data)
+}
+
+// InviteRedemption proves that the signer holds the key which is about to receive the Capability for the invite.
+type InviteRedemption struct {
+	BaseBlob
+	Invite cid.Cid `refmt:"invite"`
+}
+
+// NewInviteRedemption creates a new signed redemption of the invite for the key of the invitee.
+func NewInviteRedemption(invitee *core.KeyPair, invite cid.Cid, ts time.Time) (eb Encoded[*InviteRedemption], err error) {
+	r := &InviteRedemption{
+		BaseBlob: BaseBlob{
+			Type:   TypeInviteRedemption,
+			Signer: invitee.Principal(),
+			Ts:     ts,
+		},
+		Invite: invite,
+	}
+
+	if err := Sign(invitee, r, &r.BaseBlob.Sig); err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(r)
+}
+
+// DecodeInviteRedemption decodes and verifies the raw data of an InviteRedemption.
+func DecodeInviteRedemption(data []byte) (*InviteRedemption, error) {
+	r := &InviteRedemption{}
+	if err := cbornode.DecodeInto(data, r); err != nil {
+		return nil, fmt.Errorf("failed to decode invite redemption: %w", err)
+	}
+
+	if r.Type != TypeInviteRedemption {
+		return nil, fmt.Errorf("invalid invite redemption type '%s'", r.Type)
+	}
+
+	if err := Verify(r.Signer, r, r.Sig); err != nil {
+		return nil, fmt.Errorf("invalid invite redemption signature: %w", err)
+	}
+
+	return r, nil
+}
+
+func init() {
+	cbornode.RegisterCborType(Invite{})
+	cbornode.RegisterCborType(InviteRedemption{})
+}
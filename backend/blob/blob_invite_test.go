@@ -0,0 +1,47 @@
+package blob
+
+import (
+	"seed/backend/core/coretest"
+	"seed/backend/util/cclock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInviteToken(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob").Account
+	clock := cclock.New()
+
+	inv, err := NewInvite(alice.Account, "/team", RoleWriter, "Team", clock.MustNow().Add(time.Hour), alice.Device.PeerID().String(), nil, clock.MustNow())
+	require.NoError(t, err)
+
+	decoded, err := DecodeInviteToken(EncodeInviteToken(inv.Data))
+	require.NoError(t, err)
+	require.Equal(t, inv.CID, decoded.CID)
+	require.Equal(t, alice.Account.Principal(), decoded.Decoded.Space())
+	require.Equal(t, "/team", decoded.Decoded.Path)
+	require.Equal(t, RoleWriter, decoded.Decoded.Role)
+
+	other, err := NewInvite(alice.Account, "/team", RoleWriter, "Team", inv.Decoded.ExpireTime, inv.Decoded.Peer, nil, inv.Decoded.Ts)
+	require.NoError(t, err)
+	require.NotEqual(t, inv.CID, other.CID, "invites with the same parameters must be distinct")
+
+	forged := *inv.Decoded
+	forged.Role = RoleAgent
+	forgedData, err := encodeBlob(&forged)
+	require.NoError(t, err)
+	_, err = DecodeInvite(forgedData.Data)
+	require.Error(t, err, "tampered invites must be rejected")
+
+	r, err := NewInviteRedemption(bob, inv.CID, clock.MustNow())
+	require.NoError(t, err)
+	rd, err := DecodeInviteRedemption(r.Data)
+	require.NoError(t, err)
+	require.Equal(t, bob.Principal(), rd.Signer)
+	require.Equal(t, inv.CID, rd.Invite)
+
+	_, err = DecodeInviteRedemption(inv.Data)
+	require.Error(t, err, "invites are not redemptions")
+}
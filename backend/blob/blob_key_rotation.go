@@ -0,0 +1,354 @@
+package blob
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"seed/backend/core"
+	"seed/backend/ipfs"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"slices"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multicodec"
+)
+
+// TypeKeyRotation is the type of the KeyRotation blob.
+const TypeKeyRotation Type = "KeyRotation"
+
+// KeyRotation migrates an account from one key to another, e.g. when the old key has leaked.
+// It's signed by the old key (the signer of the blob) and by the new key, proving control over both.
+// Starting from the timestamp of the rotation the new key acts as the owner of the old key's space,
+// inherits the capabilities granted to the old key, and anything signed by the old key is not accepted anymore.
+type KeyRotation struct {
+	BaseBlob
+
+	// NewKey is the key the account is migrated to.
+	NewKey core.Principal `refmt:"newKey"`
+
+	// NewKeySig is the signature of the new key.
+	// The new key signs the blob with both signatures filled with zeros,
+	// and then the old key signs the blob including the signature of the new key.
+	NewKeySig core.Signature `refmt:"newKeySig"`
+}
+
+// NewKeyRotation creates a new KeyRotation blob.
+func NewKeyRotation(oldKey, newKey *core.KeyPair, ts time.Time) (eb Encoded[*KeyRotation], err error) {
+	if oldKey.Principal().Equal(newKey.Principal()) {
+		return eb, fmt.Errorf("key can't be rotated into itself")
+	}
+
+	kr := &KeyRotation{
+		BaseBlob: BaseBlob{
+			Type:   TypeKeyRotation,
+			Signer: oldKey.Principal(),
+			Ts:     ts,
+		},
+		NewKey: newKey.Principal(),
+	}
+
+	kr.Sig = make([]byte, oldKey.SignatureSize())
+	if err := Sign(newKey, kr, &kr.NewKeySig); err != nil {
+		return eb, err
+	}
+
+	if err := Sign(oldKey, kr, &kr.Sig); err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(kr)
+}
+
+// verify checks both signatures of the rotation.
+func (kr *KeyRotation) verify() error {
+	if err := Verify(kr.Signer, kr, kr.Sig); err != nil {
+		return fmt.Errorf("invalid old key signature: %w", err)
+	}
+
+	sig := kr.Sig
+	kr.Sig = make([]byte, len(sig))
+	defer func() { kr.Sig = sig }()
+
+	if err := Verify(kr.NewKey, kr, kr.NewKeySig); err != nil {
+		return fmt.Errorf("invalid new key signature: %w", err)
+	}
+
+	return nil
+}
+
+func init() {
+	cbornode.RegisterCborType(KeyRotation{})
+
+	matcher := makeCBORTypeMatch(TypeKeyRotation)
+	registerIndexer(TypeKeyRotation,
+		func(c cid.Cid, data []byte) (eb Encoded[*KeyRotation], err error) {
+			codec, _ := ipfs.DecodeCID(c)
+			if codec != multicodec.DagCbor || !bytes.Contains(data, matcher) {
+				return eb, errSkipIndexing
+			}
+
+			v := &KeyRotation{}
+			if err := cbornode.DecodeInto(data, v); err != nil {
+				return eb, err
+			}
+
+			if err := v.verify(); err != nil {
+				return eb, err
+			}
+
+			eb.CID = c
+			eb.Data = data
+			eb.Decoded = v
+			return eb, nil
+		},
+		indexKeyRotation,
+	)
+}
+
+func indexKeyRotation(ictx *indexingCtx, _ int64, eb Encoded[*KeyRotation]) error {
+	c, v := eb.CID, eb.Decoded
+
+	if v.Signer.Equal(v.NewKey) {
+		return fmt.Errorf("key rotation %s rotates key %s into itself", c, v.Signer)
+	}
+
+	iri, err := NewIRI(v.Signer, "")
+	if err != nil {
+		return err
+	}
+
+	oldID, err := ictx.ensurePubKey(v.Signer)
+	if err != nil {
+		return err
+	}
+
+	// The new key becomes an account in its own right,
+	// so lookups of the old account can be resolved to it.
+	_, newID, err := ictx.ensureAccount(v.NewKey)
+	if err != nil {
+		return err
+	}
+
+	// A key can only be rotated once. Whoever holds a leaked key could sign another rotation,
+	// so when we have more than one, every peer must pick the same winner no matter the order we got them in:
+	// the one with the lowest timestamp, and then the lowest CID. The losers already indexed are evicted
+	// by the indexed hook, along with the content that relied on them (see evictRotatedOutContent).
+	var lost bool
+	if err := sqlitex.Exec(ictx.conn, qBetterKeyRotation(), func(*sqlite.Stmt) error {
+		lost = true
+		return nil
+	}, oldID, ictx.blobID, v.Ts.UnixMilli(), []byte(c.Hash())); err != nil {
+		return err
+	}
+
+	if lost {
+		return stashError{
+			Reason: stashReasonPermissionDenied,
+			Metadata: stashMetadata{
+				DeniedSigners: []core.Principal{v.Signer},
+			},
+		}
+	}
+
+	// Key rotations are public and anchored to the old account, so they sync along with its space.
+	sb := newStructuralBlob(c, v.Type, v.Signer, v.Ts, iri, cid.Undef, v.Signer, time.Time{}, VisibilityPublic, nil)
+	sb.ExtraAttrs = map[string]any{
+		"new": newID,
+	}
+
+	if err := sqlitex.Exec(ictx.conn, "INSERT OR IGNORE INTO spaces (id) VALUES (?)", nil, v.NewKey.String()); err != nil {
+		return fmt.Errorf("failed to insert space: %w", err)
+	}
+
+	if err := ictx.SaveBlob(sb); err != nil {
+		return err
+	}
+
+	// The rotation changes who is the owner of the space, and which grants the new key holds.
+	ictx.writerCache.clear()
+
+	// Blobs of the new key that were waiting for permissions might be valid now.
+	return reindexStashedBlobs(ictx.childOpts(), ictx.conn, stashReasonPermissionDenied, v.NewKey.String(), ictx.blockStore, ictx.log, ictx.writerCache, ictx.hookIDs)
+}
+
+// All the rotations share the CID prefix, so comparing the multihashes is the same as comparing the CIDs.
+var qBetterKeyRotation = dqb.Str(`
+	SELECT 1
+	FROM structural_blobs sb
+	JOIN blobs b ON b.id = sb.id
+	WHERE sb.author = :key
+	AND sb.type = 'KeyRotation'
+	AND sb.id != :id
+	AND (sb.ts < :ts OR (sb.ts = :ts AND b.multihash < :multihash))
+	LIMIT 1
+`)
+
+// keyEpoch is the period of time in which a key was in charge of an account.
+// From is inclusive and Until is exclusive. Both are timestamps in unix millis.
+type keyEpoch struct {
+	Key   int64
+	From  int64
+	Until int64
+}
+
+func (e keyEpoch) contains(ts int64) bool {
+	return e.From <= ts && ts < e.Until
+}
+
+// keyLineage describes how a key is related to other keys through KeyRotation blobs.
+type keyLineage struct {
+	// Epochs are the periods in which the key and the keys it was (transitively) rotated into
+	// were in charge of the account, in order. The first one is always the key itself,
+	// so the Until of the first epoch is the time when the key stopped being valid.
+	Epochs []keyEpoch
+
+	// Predecessors are the keys that were (transitively) rotated into this key.
+	// Capabilities granted to them are inherited by the key.
+	Predecessors []int64
+}
+
+// epochOf returns the epoch in which the given key was in charge of the account.
+func (kl keyLineage) epochOf(keyID int64) (keyEpoch, bool) {
+	for _, e := range kl.Epochs {
+		if e.Key == keyID {
+			return e, true
+		}
+	}
+	return keyEpoch{}, false
+}
+
+// retiredAt reports whether the key was already rotated away at time ts (unix millis).
+func (kl keyLineage) retiredAt(ts int64) bool {
+	return kl.Epochs[0].Until <= ts
+}
+
+// loadKeyLineage follows the KeyRotation blobs around keyID.
+// Keys are only rotated once, but if we have more than one rotation of the same key,
+// e.g. until the losers are evicted, the one with the lowest timestamp and CID wins, like in indexKeyRotation.
+func loadKeyLineage(conn *sqlite.Conn, keyID int64, wc *writerValidityCache) (kl keyLineage, err error) {
+	if kl, ok := wc.getLineage(keyID); ok {
+		return kl, nil
+	}
+
+	cur := keyEpoch{Key: keyID, From: 0, Until: math.MaxInt64}
+	seen := map[int64]struct{}{keyID: {}}
+	for {
+		var (
+			next   int64
+			nextTs int64
+		)
+		rows, discard, check := sqlitex.Query(conn, qKeyRotationNext(), cur.Key, cur.From).All()
+		for row := range rows {
+			next = row.ColumnInt64(0)
+			nextTs = row.ColumnInt64(1)
+			break
+		}
+		discard(&err)
+		if err := errors.Join(err, check()); err != nil {
+			return kl, err
+		}
+
+		// Rotations back into one of the previous keys are ignored, otherwise we'd loop forever.
+		if _, ok := seen[next]; next == 0 || ok {
+			kl.Epochs = append(kl.Epochs, cur)
+			break
+		}
+		seen[next] = struct{}{}
+
+		cur.Until = nextTs
+		kl.Epochs = append(kl.Epochs, cur)
+		cur = keyEpoch{Key: next, From: nextTs, Until: math.MaxInt64}
+	}
+
+	queue := []int64{keyID}
+	for len(queue) > 0 {
+		k := queue[0]
+		queue = queue[1:]
+
+		rows, discard, check := sqlitex.Query(conn, qKeyRotationPrev(), k).All()
+		for row := range rows {
+			prev := row.ColumnInt64(0)
+			if _, ok := seen[prev]; ok {
+				continue
+			}
+			seen[prev] = struct{}{}
+			kl.Predecessors = append(kl.Predecessors, prev)
+			queue = append(queue, prev)
+		}
+		discard(&err)
+		if err := errors.Join(err, check()); err != nil {
+			return kl, err
+		}
+	}
+	slices.Sort(kl.Predecessors)
+
+	wc.putLineage(keyID, kl)
+
+	return kl, nil
+}
+
+var qKeyRotationNext = dqb.Str(`
+	SELECT sb.extra_attrs->>'new', sb.ts
+	FROM structural_blobs sb
+	JOIN blobs b ON b.id = sb.id
+	WHERE sb.author = :key
+	AND sb.type = 'KeyRotation'
+	AND sb.ts >= :since
+	ORDER BY sb.ts, b.multihash
+	LIMIT 1
+`)
+
+var qKeyRotationPrev = dqb.Str(`
+	SELECT author
+	FROM structural_blobs
+	WHERE type = 'KeyRotation'
+	AND extra_attrs->>'new' = :key
+`)
+
+// ResolveKeyRotation returns the key the given key was (transitively) rotated into,
+// or the key itself if it was never rotated.
+func ResolveKeyRotation(conn *sqlite.Conn, key core.Principal) (core.Principal, error) {
+	keyID, err := DbPublicKeysLookupID(conn, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyID == 0 {
+		return key, nil
+	}
+
+	kl, err := loadKeyLineage(conn, keyID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	last := kl.Epochs[len(kl.Epochs)-1].Key
+	if last == keyID {
+		return key, nil
+	}
+
+	var out core.Principal
+	if err := sqlitex.Exec(conn, qPublicKeyPrincipal(), func(stmt *sqlite.Stmt) error {
+		out = core.Principal(stmt.ColumnBytes(0))
+		return nil
+	}, last); err != nil {
+		return nil, err
+	}
+
+	if out == nil {
+		return nil, fmt.Errorf("BUG: rotated key %d is not indexed", last)
+	}
+
+	return out, nil
+}
+
+var qPublicKeyPrincipal = dqb.Str(`
+	SELECT principal
+	FROM public_keys
+	WHERE id = :id
+`)
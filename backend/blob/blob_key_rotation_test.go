@@ -0,0 +1,255 @@
+package blob
+
+import (
+	"seed/backend/core/coretest"
+	"seed/backend/storage"
+	"seed/backend/util/cclock"
+	"seed/backend/util/must"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestKeyRotation(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	aliceNew := coretest.NewTester("david").Account
+	bob := coretest.NewTester("bob").Account
+	carol := coretest.NewTester("carol").Account
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+	require.NoError(t, err)
+
+	clock := cclock.New()
+	iri := must.Do2(NewIRI(alice.Principal(), "/doc"))
+
+	bobCap, err := NewCapability(alice, bob.Principal(), alice.Principal(), "", RoleWriter, "", clock.MustNow())
+	require.NoError(t, err)
+	carolCap, err := NewCapability(carol, alice.Principal(), carol.Principal(), "", RoleWriter, "", clock.MustNow())
+	require.NoError(t, err)
+
+	change1, err := NewChange(alice, cid.Undef, nil, 0, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "Hello"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	ref1, err := NewRef(alice, 0, change1.CID, alice.Principal(), "/doc", []cid.Cid{change1.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+
+	require.NoError(t, idx.PutMany(t.Context(), []blocks.Block{bobCap, carolCap, change1, ref1}))
+
+	rot, err := NewKeyRotation(alice, aliceNew, clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), rot))
+	require.Equal(t, 0, countStashedBlobs(t, db))
+
+	// The new key acts as the owner of the old space.
+	change2, err := NewChange(aliceNew, change1.CID, []cid.Cid{change1.CID}, 1, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "Hello World"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	ref2, err := NewRef(aliceNew, 0, change1.CID, alice.Principal(), "/doc", []cid.Cid{change2.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+	require.NoError(t, idx.PutMany(t.Context(), []blocks.Block{change2, ref2}))
+	require.Equal(t, 0, countStashedBlobs(t, db))
+	requireLatestChanges(t, idx, iri, change1.CID, change2.CID)
+
+	// Capabilities issued by the old key remain valid, and the ones granted to the old key move over to the new one.
+	valid, err := idx.IsValidWriter(t.Context(), alice.Principal(), "/doc", bob.Principal())
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = idx.IsValidWriter(t.Context(), carol.Principal(), "/doc", aliceNew.Principal())
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = idx.IsValidWriter(t.Context(), carol.Principal(), "/doc", alice.Principal())
+	require.NoError(t, err)
+	require.False(t, valid, "old key must not write into other spaces after the rotation")
+
+	// The old key can't write anymore.
+	change3, err := NewChange(alice, change2.CID, []cid.Cid{change2.CID}, 2, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "Stolen"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), change3))
+	require.Equal(t, 1, countStashedBlobs(t, db), "changes signed by the old key after the rotation must be stashed")
+
+	var reason string
+	require.NoError(t, db.WithSave(t.Context(), func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, "SELECT reason FROM stashed_blobs", func(stmt *sqlite.Stmt) error {
+			reason = stmt.ColumnText(0)
+			return nil
+		})
+	}))
+	require.Equal(t, string(stashReasonPermissionDenied), reason)
+
+	ref3, err := NewRef(alice, 0, change1.CID, alice.Principal(), "/doc", []cid.Cid{change2.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), ref3))
+	require.Equal(t, 2, countStashedBlobs(t, db), "refs signed by the old key after the rotation must be stashed")
+
+	// Another rotation of the already rotated key signed later is not valid.
+	rot2, err := NewKeyRotation(alice, bob, clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), rot2))
+	require.Equal(t, 3, countStashedBlobs(t, db))
+
+	require.NoError(t, db.WithSave(t.Context(), func(conn *sqlite.Conn) error {
+		got, err := ResolveKeyRotation(conn, alice.Principal())
+		require.NoError(t, err)
+		require.Equal(t, aliceNew.Principal(), got)
+
+		got, err = ResolveKeyRotation(conn, bob.Principal())
+		require.NoError(t, err)
+		require.Equal(t, bob.Principal(), got)
+		return nil
+	}))
+}
+
+func TestKeyRotation_RequiresBothSignatures(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	aliceNew := coretest.NewTester("david").Account
+	bob := coretest.NewTester("bob").Account
+
+	rot, err := NewKeyRotation(alice, aliceNew, cclock.New().MustNow())
+	require.NoError(t, err)
+	require.NoError(t, rot.Decoded.verify())
+
+	// Someone who has stolen the old key can't claim somebody else's key.
+	rot.Decoded.NewKey = bob.Principal()
+	require.Error(t, rot.Decoded.verify())
+}
+
+func TestKeyRotation_EvictsLateContent(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	aliceNew := coretest.NewTester("david").Account
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+	require.NoError(t, err)
+
+	clock := cclock.New()
+	iri := must.Do2(NewIRI(alice.Principal(), "/doc"))
+
+	change1, err := NewChange(alice, cid.Undef, nil, 0, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "Hello"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	ref1, err := NewRef(alice, 0, change1.CID, alice.Principal(), "/doc", []cid.Cid{change1.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+
+	rot, err := NewKeyRotation(alice, aliceNew, clock.MustNow())
+	require.NoError(t, err)
+
+	// Signed by the old key after the rotation, but we get it before we learn about the rotation.
+	change2, err := NewChange(alice, change1.CID, []cid.Cid{change1.CID}, 1, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "Stolen"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	ref2, err := NewRef(alice, 0, change1.CID, alice.Principal(), "/doc", []cid.Cid{change2.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+
+	require.NoError(t, idx.PutMany(t.Context(), []blocks.Block{change1, ref1, change2, ref2}))
+	require.NoError(t, idx.WaitIndexedHook(t.Context()))
+	require.Equal(t, 0, countStashedBlobs(t, db))
+	requireLatestChanges(t, idx, iri, change1.CID, change2.CID)
+
+	require.NoError(t, idx.Put(t.Context(), rot))
+	require.NoError(t, idx.WaitIndexedHook(t.Context()))
+	require.Equal(t, 2, countStashedBlobs(t, db), "content signed by the old key after the rotation must be evicted")
+	requireLatestChanges(t, idx, iri, change1.CID)
+
+	// The full reindex visits the blobs in the order we got them, so it must evict the same content.
+	require.NoError(t, idx.Reindex(t.Context()))
+	require.Equal(t, 2, countStashedBlobs(t, db))
+	requireLatestChanges(t, idx, iri, change1.CID)
+}
+
+func TestKeyRotation_DeterministicWinner(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	aliceNew := coretest.NewTester("david").Account
+	carol := coretest.NewTester("carol").Account
+
+	clock := cclock.New()
+	iri := must.Do2(NewIRI(alice.Principal(), "/doc"))
+
+	change1, err := NewChange(alice, cid.Undef, nil, 0, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "Hello"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	ref1, err := NewRef(alice, 0, change1.CID, alice.Principal(), "/doc", []cid.Cid{change1.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+
+	rotTs := clock.MustNow()
+	rot, err := NewKeyRotation(alice, aliceNew, rotTs)
+	require.NoError(t, err)
+	late, err := NewKeyRotation(alice, carol, clock.MustNow())
+	require.NoError(t, err)
+
+	// Carol writes as the owner of the space, which is only valid if the late rotation wins.
+	change2, err := NewChange(carol, change1.CID, []cid.Cid{change1.CID}, 1, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "Stolen"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	ref2, err := NewRef(carol, 0, change1.CID, alice.Principal(), "/doc", []cid.Cid{change2.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+
+	// Peers getting the rotations in different orders must end up with the same owner.
+	for i, order := range [][]blocks.Block{
+		{change1, ref1, rot, late, change2, ref2},
+		{change1, ref1, late, change2, ref2, rot},
+	} {
+		db := storage.MakeTestDB(t)
+		idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+		require.NoError(t, err)
+
+		for _, blk := range order {
+			require.NoError(t, idx.Put(t.Context(), blk))
+		}
+		require.NoError(t, idx.WaitIndexedHook(t.Context()))
+
+		require.Equal(t, 2, countStashedBlobs(t, db), "order %d: the late rotation and the ref relying on it must be stashed", i)
+		requireLatestChanges(t, idx, iri, change1.CID)
+
+		require.NoError(t, db.WithSave(t.Context(), func(conn *sqlite.Conn) error {
+			got, err := ResolveKeyRotation(conn, alice.Principal())
+			require.NoError(t, err)
+			require.Equal(t, aliceNew.Principal(), got)
+			return nil
+		}))
+
+		require.NoError(t, idx.Reindex(t.Context()))
+		require.Equal(t, 2, countStashedBlobs(t, db), "order %d: the full reindex must pick the same winner", i)
+		requireLatestChanges(t, idx, iri, change1.CID)
+	}
+
+	// With the same timestamp the lowest CID wins.
+	tied, err := NewKeyRotation(alice, carol, rotTs)
+	require.NoError(t, err)
+	want := aliceNew.Principal()
+	if string(tied.CID.Hash()) < string(rot.CID.Hash()) {
+		want = carol.Principal()
+	}
+
+	for _, order := range [][]blocks.Block{{rot, tied}, {tied, rot}} {
+		db := storage.MakeTestDB(t)
+		idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+		require.NoError(t, err)
+
+		for _, blk := range order {
+			require.NoError(t, idx.Put(t.Context(), blk))
+		}
+		require.NoError(t, idx.WaitIndexedHook(t.Context()))
+		require.Equal(t, 1, countStashedBlobs(t, db))
+
+		require.NoError(t, db.WithSave(t.Context(), func(conn *sqlite.Conn) error {
+			got, err := ResolveKeyRotation(conn, alice.Principal())
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+			return nil
+		}))
+	}
+}
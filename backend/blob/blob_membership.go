@@ -0,0 +1,429 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"seed/backend/core"
+	"seed/backend/ipfs"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"slices"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multicodec"
+)
+
+// TypeMembership is the type of the Membership blob.
+const TypeMembership Type = "Membership"
+
+// MembershipMaxMembers is the maximum number of members in a multi-signature organization.
+const MembershipMaxMembers = 64
+
+// Membership turns a space into a multi-signature organization.
+// Once the space has a Membership, Refs for its root document need signatures
+// from at least Threshold of the Members, instead of the signature of the space owner alone.
+//
+// The first Membership of a space must be signed by the space owner.
+// Further updates must be signed by enough members of the previous Membership,
+// otherwise the owner key would remain a single point of failure.
+type Membership struct {
+	BaseBlob
+
+	// Space of the organization. Empty if it's the same as the signer.
+	Space_ core.Principal `refmt:"space,omitempty"`
+
+	// Members of the organization who can sign root Refs.
+	Members []core.Principal `refmt:"members"`
+
+	// Threshold is the number of member signatures required for root Refs.
+	Threshold int64 `refmt:"threshold"`
+
+	// Cosignatures of other members.
+	Cosignatures []Cosignature `refmt:"cosigs,omitempty"`
+}
+
+// Cosignature is a signature of a blob by someone other than its signer.
+// Cosigners sign the same bytes as the signer: the blob with the signature filled with zeros and without any cosignatures.
+// This way each member can sign independently, and the signatures can be collected in any order.
+type Cosignature struct {
+	Signer core.Principal `refmt:"signer"`
+	Sig    core.Signature `refmt:"sig"`
+}
+
+func init() {
+	cbornode.RegisterCborType(Membership{})
+	cbornode.RegisterCborType(Cosignature{})
+}
+
+// NewMembership creates a new Membership blob.
+// The blob needs more cosignatures if the previous membership of the space requires them.
+func NewMembership(kp *core.KeyPair, space core.Principal, members []core.Principal, threshold int64, ts time.Time) (eb Encoded[*Membership], err error) {
+	m := &Membership{
+		BaseBlob: BaseBlob{
+			Type:   TypeMembership,
+			Signer: kp.Principal(),
+			Ts:     ts,
+		},
+		Members:   members,
+		Threshold: threshold,
+	}
+
+	if !kp.Principal().Equal(space) {
+		m.Space_ = space
+	}
+
+	if err := m.validate(); err != nil {
+		return eb, err
+	}
+
+	if err := Sign(kp, m, &m.Sig); err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(m)
+}
+
+// Space returns the space of the organization.
+func (m *Membership) Space() core.Principal {
+	if len(m.Space_) == 0 {
+		return m.Signer
+	}
+	return m.Space_
+}
+
+// Cosign adds the signature of kp to the membership.
+func (m *Membership) Cosign(kp core.Signer) (eb Encoded[*Membership], err error) {
+	if err := cosign(kp, m, m.Signer, &m.Sig, &m.Cosignatures); err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(m)
+}
+
+func (m *Membership) validate() error {
+	if len(m.Members) == 0 {
+		return fmt.Errorf("membership must have at least one member")
+	}
+
+	if len(m.Members) > MembershipMaxMembers {
+		return fmt.Errorf("membership can't have more than %d members", MembershipMaxMembers)
+	}
+
+	if m.Threshold < 1 || m.Threshold > int64(len(m.Members)) {
+		return fmt.Errorf("membership threshold must be between 1 and the number of members (%d): got %d", len(m.Members), m.Threshold)
+	}
+
+	for i, member := range m.Members {
+		if slices.ContainsFunc(m.Members[:i], member.Equal) {
+			return fmt.Errorf("duplicate member %s", member)
+		}
+	}
+
+	return nil
+}
+
+// cosign adds the signature of kp to the blob v, whose signer, signature, and cosignatures are given.
+func cosign(kp core.Signer, v any, signer core.Principal, sig *core.Signature, cosigs *[]Cosignature) error {
+	p := kp.Principal()
+	if p.Equal(signer) || slices.ContainsFunc(*cosigs, func(cs Cosignature) bool { return cs.Signer.Equal(p) }) {
+		return fmt.Errorf("key %s has already signed the blob", p)
+	}
+
+	data, err := cosignedBytes(v, sig, cosigs)
+	if err != nil {
+		return err
+	}
+
+	s, err := kp.Sign(data)
+	if err != nil {
+		return err
+	}
+
+	*cosigs = append(*cosigs, Cosignature{Signer: p, Sig: s})
+	return nil
+}
+
+// verifyCosigned is like [Verify], but for blobs that can have cosignatures.
+func verifyCosigned(v any, signer core.Principal, sig *core.Signature, cosigs *[]Cosignature) error {
+	data, err := cosignedBytes(v, sig, cosigs)
+	if err != nil {
+		return err
+	}
+
+	pub, err := signer.Parse()
+	if err != nil {
+		return err
+	}
+
+	if err := pub.Verify(data, *sig); err != nil {
+		return err
+	}
+
+	for _, cs := range *cosigs {
+		pub, err := cs.Signer.Parse()
+		if err != nil {
+			return err
+		}
+
+		if err := pub.Verify(data, cs.Sig); err != nil {
+			return fmt.Errorf("invalid cosignature of %s: %w", cs.Signer, err)
+		}
+	}
+
+	return nil
+}
+
+// cosignedBytes returns the bytes that the signer and the cosigners of a blob sign.
+func cosignedBytes(v any, sig *core.Signature, cosigs *[]Cosignature) ([]byte, error) {
+	savedSig, savedCosigs := *sig, *cosigs
+	defer func() {
+		*sig, *cosigs = savedSig, savedCosigs
+	}()
+
+	*sig = make([]byte, len(savedSig))
+	*cosigs = nil
+
+	return cbornode.DumpObject(v)
+}
+
+// signers returns all the keys that signed a cosigned blob.
+func signers(signer core.Principal, cosigs []Cosignature) []core.Principal {
+	out := make([]core.Principal, 0, len(cosigs)+1)
+	out = append(out, signer)
+	for _, cs := range cosigs {
+		out = append(out, cs.Signer)
+	}
+	return out
+}
+
+func init() {
+	matcher := makeCBORTypeMatch(TypeMembership)
+	registerIndexer(TypeMembership,
+		func(c cid.Cid, data []byte) (eb Encoded[*Membership], err error) {
+			codec, _ := ipfs.DecodeCID(c)
+			if codec != multicodec.DagCbor || !bytes.Contains(data, matcher) {
+				return eb, errSkipIndexing
+			}
+
+			v := &Membership{}
+			if err := cbornode.DecodeInto(data, v); err != nil {
+				return eb, err
+			}
+
+			if err := verifyCosigned(v, v.Signer, &v.Sig, &v.Cosignatures); err != nil {
+				return eb, err
+			}
+
+			eb.CID = c
+			eb.Data = data
+			eb.Decoded = v
+			return eb, nil
+		},
+		indexMembership,
+	)
+}
+
+func indexMembership(ictx *indexingCtx, _ int64, eb Encoded[*Membership]) error {
+	c, v := eb.CID, eb.Decoded
+
+	if err := v.validate(); err != nil {
+		return fmt.Errorf("invalid membership %s: %w", c, err)
+	}
+
+	space := v.Space()
+	iri, err := NewIRI(space, "")
+	if err != nil {
+		return err
+	}
+
+	spaceID, err := ictx.ensurePubKey(space)
+	if err != nil {
+		return err
+	}
+
+	signerID, err := ictx.ensurePubKey(v.Signer)
+	if err != nil {
+		return err
+	}
+
+	prev, found, err := loadMembership(ictx.conn, iri, v.Ts.UnixMilli())
+	if err != nil {
+		return err
+	}
+
+	var ok bool
+	if found {
+		// Updates must be approved by the current members.
+		ok, err = prev.approvedBy(ictx, signers(v.Signer, v.Cosignatures))
+		if err != nil {
+			return err
+		}
+	} else {
+		// The first membership must come from the owner of the space.
+		owners, err := loadKeyLineage(ictx.conn, spaceID, ictx.writerCache)
+		if err != nil {
+			return err
+		}
+
+		e, isOwner := owners.epochOf(signerID)
+		ok = isOwner && e.contains(v.Ts.UnixMilli())
+	}
+
+	if !ok {
+		return stashError{
+			Reason: stashReasonPermissionDenied,
+			Metadata: stashMetadata{
+				DeniedSigners: []core.Principal{v.Signer},
+			},
+		}
+	}
+
+	members := make([]int64, len(v.Members))
+	for i, m := range v.Members {
+		members[i], err = ictx.ensurePubKey(m)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Memberships are anchored to the root of the space, so they sync along with it.
+	sb := newStructuralBlob(c, v.Type, v.Signer, v.Ts, iri, cid.Undef, space, time.Time{}, VisibilityPublic, nil)
+	sb.ExtraAttrs = map[string]any{
+		"members":   members,
+		"threshold": v.Threshold,
+	}
+
+	if err := ictx.SaveBlob(sb); err != nil {
+		return err
+	}
+
+	// Root Refs and membership updates signed by the members might be valid now.
+	for _, m := range v.Members {
+		if err := reindexStashedBlobs(ictx.childOpts(), ictx.conn, stashReasonPermissionDenied, m.String(), ictx.blockStore, ictx.log, ictx.writerCache, ictx.hookIDs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// membership is the indexed state of a Membership blob.
+type membership struct {
+	Members   []int64 `json:"members"`
+	Threshold int64   `json:"threshold"`
+}
+
+// approvedBy checks whether enough distinct members are among the given signers.
+func (m membership) approvedBy(ictx *indexingCtx, signers []core.Principal) (bool, error) {
+	var count int64
+	seen := make(map[int64]struct{}, len(signers))
+	for _, s := range signers {
+		id, err := ictx.ensurePubKey(s)
+		if err != nil {
+			return false, err
+		}
+
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		if slices.Contains(m.Members, id) {
+			count++
+		}
+	}
+
+	return count >= m.Threshold, nil
+}
+
+// loadMembership finds the membership of the space with the given root IRI that was in effect at time ts (unix millis).
+func loadMembership(conn *sqlite.Conn, root IRI, ts int64) (m membership, found bool, err error) {
+	rows, discard, check := sqlitex.Query(conn, qLoadMembership(), root, ts).All()
+	defer discard(&err)
+	for row := range rows {
+		if err := json.Unmarshal(row.ColumnBytesUnsafe(0), &m); err != nil {
+			return m, false, err
+		}
+		found = true
+		break
+	}
+
+	err = check()
+	return m, found, err
+}
+
+var qLoadMembership = dqb.Str(`
+	SELECT sb.extra_attrs
+	FROM structural_blobs sb
+	WHERE sb.resource = (SELECT id FROM resources WHERE iri = :iri)
+	AND sb.type = 'Membership'
+	AND sb.ts <= :ts
+	ORDER BY sb.ts DESC, sb.id DESC
+	LIMIT 1
+`)
+
+// OrgMembership describes the current members of a multi-signature organization.
+type OrgMembership struct {
+	Members   []core.Principal
+	Threshold int64
+}
+
+// IsMember checks whether the key is a member of the organization.
+func (om OrgMembership) IsMember(key core.Principal) bool {
+	return slices.ContainsFunc(om.Members, key.Equal)
+}
+
+// ApprovedBy checks whether enough distinct members are among the signers.
+func (om OrgMembership) ApprovedBy(signers []core.Principal) bool {
+	var count int64
+	for i, s := range signers {
+		if slices.ContainsFunc(signers[:i], s.Equal) {
+			continue
+		}
+
+		if om.IsMember(s) {
+			count++
+		}
+	}
+
+	return count >= om.Threshold
+}
+
+// GetOrgMembership returns the current membership of the space,
+// if it's a multi-signature organization.
+func (idx *Index) GetOrgMembership(ctx context.Context, space core.Principal) (om OrgMembership, found bool, err error) {
+	iri, err := NewIRI(space, "")
+	if err != nil {
+		return om, false, err
+	}
+
+	conn, release, err := idx.db.ReadConn(ctx)
+	if err != nil {
+		return om, false, err
+	}
+	defer release()
+
+	m, found, err := loadMembership(conn, iri, math.MaxInt64)
+	if err != nil || !found {
+		return om, found, err
+	}
+
+	lookup := NewLookupCache(conn)
+
+	om.Threshold = m.Threshold
+	om.Members = make([]core.Principal, len(m.Members))
+	for i, id := range m.Members {
+		om.Members[i], err = lookup.PublicKey(id)
+		if err != nil {
+			return om, false, err
+		}
+	}
+
+	return om, true, nil
+}
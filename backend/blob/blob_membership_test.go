@@ -0,0 +1,95 @@
+package blob
+
+import (
+	"seed/backend/core"
+	"seed/backend/core/coretest"
+	"seed/backend/storage"
+	"seed/backend/util/cclock"
+	"seed/backend/util/must"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMembership(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	bob := coretest.NewTester("bob").Account
+	carol := coretest.NewTester("carol").Account
+	david := coretest.NewTester("david").Account
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+	require.NoError(t, err)
+
+	clock := cclock.New()
+	iri := must.Do2(NewIRI(alice.Principal(), ""))
+
+	change1, err := NewChange(alice, cid.Undef, nil, 0, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "ACME"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	ref1, err := NewRef(alice, 0, change1.CID, alice.Principal(), "", []cid.Cid{change1.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+
+	ms, err := NewMembership(alice, alice.Principal(), []core.Principal{bob.Principal(), carol.Principal(), david.Principal()}, 2, clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.PutMany(t.Context(), []blocks.Block{change1, ref1, ms}))
+	require.Equal(t, 0, countStashedBlobs(t, db))
+
+	// The owner alone can't update the root anymore.
+	change2, err := NewChange(bob, change1.CID, []cid.Cid{change1.CID}, 1, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "ACME Inc."))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), change2))
+
+	ownerRef, err := NewRef(alice, 0, change1.CID, alice.Principal(), "", []cid.Cid{change2.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), ownerRef))
+	require.Equal(t, 1, countStashedBlobs(t, db), "owner's root ref must be stashed")
+
+	// Neither can a single member.
+	ref2, err := NewRef(bob, 0, change1.CID, alice.Principal(), "", []cid.Cid{change2.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), ref2))
+	require.Equal(t, 2, countStashedBlobs(t, db), "root ref with not enough signatures must be stashed")
+
+	_, err = ref2.Decoded.Cosign(bob)
+	require.Error(t, err, "the same key can't sign twice")
+
+	// Two members are enough.
+	ref2, err = ref2.Decoded.Cosign(carol)
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), ref2))
+	require.Equal(t, 2, countStashedBlobs(t, db))
+	requireLatestChanges(t, idx, iri, change1.CID, change2.CID)
+
+	// Membership updates need the approval of the current members.
+	ms2, err := NewMembership(alice, alice.Principal(), []core.Principal{alice.Principal()}, 1, clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), ms2))
+	require.Equal(t, 3, countStashedBlobs(t, db), "owner can't take over the organization")
+
+	ms3, err := NewMembership(bob, alice.Principal(), []core.Principal{bob.Principal(), carol.Principal()}, 1, clock.MustNow())
+	require.NoError(t, err)
+	ms3, err = ms3.Decoded.Cosign(david)
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), ms3))
+	require.Equal(t, 3, countStashedBlobs(t, db))
+
+	om, found, err := idx.GetOrgMembership(t.Context(), alice.Principal())
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, int64(1), om.Threshold)
+	require.Equal(t, []core.Principal{bob.Principal(), carol.Principal()}, om.Members)
+
+	// Tampering with the cosignatures is detected.
+	ref3, err := NewRef(bob, 0, change1.CID, alice.Principal(), "", []cid.Cid{change2.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+	ref3, err = ref3.Decoded.Cosign(carol)
+	require.NoError(t, err)
+	ref3.Decoded.Cosignatures[0].Signer = david.Principal()
+	require.Error(t, ref3.Decoded.VerifySignatures())
+}
@@ -173,7 +173,7 @@ func indexProfile(ictx *indexingCtx, id int64, eb Encoded[*Profile]) error {
 				return err
 			}
 
-			ok, err := isValidAgentKey(ictx.conn, subject, signer)
+			ok, err := isValidAgentKey(ictx.conn, subject, signer, v.Ts.UnixMilli())
 			if err != nil {
 				return err
 			}
@@ -247,7 +247,7 @@ func indexProfile(ictx *indexingCtx, id int64, eb Encoded[*Profile]) error {
 			return err
 		}
 
-		ok, err := isValidAgentKey(ictx.conn, alias, signer)
+		ok, err := isValidAgentKey(ictx.conn, alias, signer, v.Ts.UnixMilli())
 		if err != nil {
 			return err
 		}
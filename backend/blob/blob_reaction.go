@@ -0,0 +1,303 @@
+package blob
+
+import (
+	"bytes"
+	"fmt"
+	"seed/backend/core"
+	"seed/backend/ipfs"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multicodec"
+)
+
+// TypeReaction is the type of the Reaction blob.
+const TypeReaction Type = "Reaction"
+
+// ReactionValueMaxLength is the maximum length of the reaction value in bytes.
+const ReactionValueMaxLength = 64
+
+// Reaction is a lightweight signal about some content, like an emoji or a short token.
+// It targets a document, optionally narrowed down to a specific block or a comment on that document.
+// Reactions are replaceable records identified by their TSID, like Contacts:
+// a reaction with an empty value is a tombstone that removes the previous one.
+type Reaction struct {
+	BaseBlob
+
+	// ID of the reaction within the signer's namespace that's being replaced.
+	// Only present on updates and deletions.
+	ID TSID `refmt:"id,omitempty"`
+
+	// Space and path of the document the reaction is about.
+	// Space may be empty if it's the same as the signer.
+	Space_ core.Principal `refmt:"space,omitempty"`
+	Path   string         `refmt:"path,omitempty"`
+
+	// Version of the document at the time of the reaction.
+	Version []cid.Cid `refmt:"version,omitempty"`
+
+	// Block is the ID of the block within the document, when reacting to a specific block.
+	Block string `refmt:"block,omitempty"`
+
+	// Comment is the CID of the comment on the document, when reacting to a comment.
+	Comment cid.Cid `refmt:"comment,omitempty"`
+
+	// Value is the emoji or the short token of the reaction.
+	// Empty value means the reaction is deleted.
+	Value string `refmt:"value,omitempty"`
+
+	Visibility Visibility `refmt:"visibility,omitempty"`
+}
+
+// NewReaction creates a new Reaction blob.
+func NewReaction(
+	kp *core.KeyPair,
+	id TSID,
+	space core.Principal,
+	path string,
+	version []cid.Cid,
+	block string,
+	comment cid.Cid,
+	value string,
+	visibility Visibility,
+	ts time.Time,
+) (eb Encoded[*Reaction], err error) {
+	if block != "" && comment.Defined() {
+		return eb, fmt.Errorf("reaction can target either a block or a comment, not both")
+	}
+
+	if err := validateReactionValue(value); err != nil {
+		return eb, err
+	}
+
+	r := &Reaction{
+		BaseBlob: BaseBlob{
+			Type:   TypeReaction,
+			Signer: kp.Principal(),
+			Ts:     ts,
+		},
+		ID:         id,
+		Path:       path,
+		Version:    version,
+		Block:      block,
+		Comment:    comment,
+		Value:      value,
+		Visibility: visibility,
+	}
+
+	if !kp.Principal().Equal(space) {
+		r.Space_ = space
+	}
+
+	if err := Sign(kp, r, &r.BaseBlob.Sig); err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(r)
+}
+
+// TSID implements the [ReplacementBlob] interface.
+func (r *Reaction) TSID() TSID {
+	return r.ID
+}
+
+// Space returns the space of the target document.
+func (r *Reaction) Space() core.Principal {
+	if len(r.Space_) == 0 {
+		return r.Signer
+	}
+	return r.Space_
+}
+
+// IsTombstone reports whether the reaction is a deletion marker.
+func (r *Reaction) IsTombstone() bool {
+	return r.Value == ""
+}
+
+func validateReactionValue(v string) error {
+	if len(v) > ReactionValueMaxLength {
+		return fmt.Errorf("reaction value exceeds maximum length of %d bytes", ReactionValueMaxLength)
+	}
+
+	if !utf8.ValidString(v) {
+		return fmt.Errorf("reaction value must be valid UTF-8")
+	}
+
+	if strings.IndexFunc(v, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) != -1 {
+		return fmt.Errorf("reaction value must not contain spaces or control characters")
+	}
+
+	return nil
+}
+
+func init() {
+	cbornode.RegisterCborType(Reaction{})
+
+	matcher := makeCBORTypeMatch(TypeReaction)
+	registerIndexer(TypeReaction,
+		func(c cid.Cid, data []byte) (eb Encoded[*Reaction], err error) {
+			codec, _ := ipfs.DecodeCID(c)
+			if codec != multicodec.DagCbor || !bytes.Contains(data, matcher) {
+				return eb, errSkipIndexing
+			}
+
+			v := &Reaction{}
+			if err := cbornode.DecodeInto(data, v); err != nil {
+				return eb, err
+			}
+
+			if err := Verify(v.Signer, v, v.Sig); err != nil {
+				return eb, err
+			}
+
+			eb.CID = c
+			eb.Data = data
+			eb.Decoded = v
+			return eb, nil
+		},
+		indexReaction,
+	)
+}
+
+func indexReaction(ictx *indexingCtx, _ int64, eb Encoded[*Reaction]) error {
+	c, v := eb.CID, eb.Decoded
+
+	if err := validateReactionValue(v.Value); err != nil {
+		return err
+	}
+
+	if v.Block != "" && v.Comment.Defined() {
+		return fmt.Errorf("reaction %s can target either a block or a comment, not both", c)
+	}
+
+	iri, err := NewIRI(v.Space(), v.Path)
+	if err != nil {
+		return fmt.Errorf("invalid reaction target: %w", err)
+	}
+
+	// Reactions to comments are counted per comment record, not per version of it,
+	// so we need the comment to be indexed first to know which record it belongs to.
+	var commentID RecordID
+	if v.Comment.Defined() {
+		var found bool
+		commentID, found, err = reactionCommentRecord(ictx.conn, v.Comment, iri)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return stashError{
+				Reason: stashReasonFailedPrecondition,
+				Metadata: stashMetadata{
+					MissingBlobs: []cid.Cid{v.Comment},
+				},
+			}
+		}
+	}
+
+	// Private documents can only be reacted to by those who can comment on them.
+	if v.Visibility == VisibilityPrivate && !v.Signer.Equal(v.Space()) {
+		signerID, err := ictx.ensurePubKey(v.Signer)
+		if err != nil {
+			return err
+		}
+
+		ok, err := isValidCommenter(ictx.conn, signerID, iri, v.Ts.UnixMilli())
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return stashError{
+				Reason: stashReasonPermissionDenied,
+				Metadata: stashMetadata{
+					DeniedSigners: []core.Principal{v.Signer},
+				},
+			}
+		}
+	}
+
+	var visibilitySpaces []core.Principal
+	if v.Visibility == VisibilityPrivate {
+		visibilitySpaces = []core.Principal{v.Signer}
+		if !v.Signer.Equal(v.Space()) {
+			visibilitySpaces = append(visibilitySpaces, v.Space())
+		}
+	}
+
+	// Reactions are anchored to the target document, so they sync along with it.
+	sb := newStructuralBlob(c, v.Type, v.Signer, v.Ts, iri, cid.Undef, v.Space(), time.Time{}, v.Visibility, visibilitySpaces)
+
+	extraAttrs := map[string]any{
+		"tsid": eb.TSID(),
+	}
+
+	if v.Visibility != VisibilityPublic {
+		extraAttrs["visibility"] = v.Visibility
+	}
+
+	if v.Block != "" {
+		extraAttrs["block"] = v.Block
+	}
+
+	if v.Comment.Defined() {
+		extraAttrs["comment"] = commentID.String()
+		sb.AddBlobLink("reaction/comment", v.Comment)
+	}
+
+	if v.IsTombstone() {
+		extraAttrs["deleted"] = true
+	} else {
+		extraAttrs["value"] = v.Value
+	}
+
+	sb.ExtraAttrs = extraAttrs
+
+	return ictx.SaveBlob(sb)
+}
+
+// reactionCommentRecord finds the record ID of the comment the reaction is about.
+// The comment must belong to the same document as the reaction.
+func reactionCommentRecord(conn *sqlite.Conn, c cid.Cid, iri IRI) (rid RecordID, found bool, err error) {
+	codec, hash := ipfs.DecodeCID(c)
+
+	var target IRI
+	if err := sqlitex.Exec(conn, qReactionCommentRecord(), func(stmt *sqlite.Stmt) error {
+		rid = RecordID{
+			Authority: core.Principal(stmt.ColumnBytes(0)),
+			TSID:      TSID(stmt.ColumnText(1)),
+		}
+		target = IRI(stmt.ColumnText(2))
+		found = true
+		return nil
+	}, codec, hash); err != nil {
+		return rid, false, err
+	}
+
+	if !found {
+		return rid, false, nil
+	}
+
+	if target != iri {
+		return rid, false, fmt.Errorf("reaction targets comment %s on %s, but the comment is on %s", c, iri, target)
+	}
+
+	return rid, true, nil
+}
+
+var qReactionCommentRecord = dqb.Str(`
+	SELECT pk.principal, sb.extra_attrs->>'tsid', r.iri
+	FROM structural_blobs sb
+	JOIN blobs b INDEXED BY blobs_metadata_by_hash ON b.id = sb.id
+	JOIN public_keys pk ON pk.id = sb.author
+	JOIN resources r ON r.id = sb.resource
+	WHERE (b.codec, b.multihash) = (:codec, :multihash)
+	AND sb.type = 'Comment'
+`)
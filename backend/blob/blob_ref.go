@@ -49,6 +49,15 @@ type Ref struct {
 	Redirect    *RedirectTarget `refmt:"redirect,omitempty"`
 	Generation  int64           `refmt:"generation,omitempty"`
 	Visibility  Visibility      `refmt:"visibility,omitempty"`
+
+	// Branch is the name of a draft branch of the document this Ref is for.
+	// Branch Refs don't change the published version of the document,
+	// and can be private even for documents that are public.
+	Branch string `refmt:"branch,omitempty"`
+
+	// Cosignatures of other members of a multi-signature organization.
+	// Only used for the root Refs of spaces that have a Membership.
+	Cosignatures []Cosignature `refmt:"cosigs,omitempty"`
 }
 
 // NewRef creates a new Ref blob.
@@ -77,6 +86,70 @@ func NewRef(kp *core.KeyPair, generation int64, genesis cid.Cid, space core.Prin
 	return encodeBlob(ru)
 }
 
+// BranchMain is the reserved name of the published version of a document,
+// which is what all the Refs without an explicit branch point to.
+const BranchMain = "main"
+
+// MaxBranchNameLength is the maximum length of a branch name.
+const MaxBranchNameLength = 64
+
+// ValidateBranchName checks whether the name is allowed for a branch Ref.
+// Names can use ASCII letters, digits, dots, dashes and underscores, and must start with a letter or a digit.
+func ValidateBranchName(name string) error {
+	if name == "" {
+		return fmt.Errorf("branch name must not be empty")
+	}
+
+	if name == BranchMain {
+		return fmt.Errorf("branch name '%s' is reserved", BranchMain)
+	}
+
+	if len(name) > MaxBranchNameLength {
+		return fmt.Errorf("branch name must not be longer than %d characters", MaxBranchNameLength)
+	}
+
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case i > 0 && (r == '.' || r == '-' || r == '_'):
+		default:
+			return fmt.Errorf("branch name '%s' has invalid character %q at position %d", name, r, i)
+		}
+	}
+
+	return nil
+}
+
+// NewBranchRef creates a new Ref blob for a named branch of a document.
+func NewBranchRef(kp *core.KeyPair, genesis cid.Cid, space core.Principal, path, branch string, heads []cid.Cid, ts time.Time, visibility Visibility) (eb Encoded[*Ref], err error) {
+	if err := ValidateBranchName(branch); err != nil {
+		return eb, err
+	}
+
+	ru := &Ref{
+		BaseBlob: BaseBlob{
+			Type:   TypeRef,
+			Signer: kp.Principal(),
+			Ts:     ts,
+		},
+		Path:        path,
+		GenesisBlob: genesis,
+		Heads:       heads,
+		Visibility:  visibility,
+		Branch:      branch,
+	}
+
+	if !kp.Principal().Equal(space) {
+		ru.Space_ = space
+	}
+
+	if err := Sign(kp, ru, &ru.BaseBlob.Sig); err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(ru)
+}
+
 // NewRefRedirect creates a new Ref blob that redirects to another document.
 func NewRefRedirect(kp *core.KeyPair, generation int64, genesis cid.Cid, space core.Principal, path string, rt RedirectTarget, ts time.Time) (eb Encoded[*Ref], err error) {
 	// If we redirect within the same space, we don't need to specify the space in the redirect target.
@@ -107,6 +180,26 @@ func NewRefRedirect(kp *core.KeyPair, generation int64, genesis cid.Cid, space c
 	return encodeBlob(ru)
 }
 
+// Cosign adds the signature of kp to the Ref,
+// when it needs signatures from multiple members of an organization.
+func (r *Ref) Cosign(kp core.Signer) (eb Encoded[*Ref], err error) {
+	if err := cosign(kp, r, r.Signer, &r.Sig, &r.Cosignatures); err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(r)
+}
+
+// VerifySignatures checks the signature and the cosignatures of the Ref.
+func (r *Ref) VerifySignatures() error {
+	return verifyCosigned(r, r.Signer, &r.Sig, &r.Cosignatures)
+}
+
+// Signers returns the signer of the Ref and all the cosigners.
+func (r *Ref) Signers() []core.Principal {
+	return signers(r.Signer, r.Cosignatures)
+}
+
 // Space returns the space the Ref is applied to.
 func (r *Ref) Space() core.Principal {
 	if len(r.Space_) == 0 {
@@ -160,7 +253,7 @@ func init() {
 				return eb, err
 			}
 
-			if err := Verify(v.Signer, v, v.Sig); err != nil {
+			if err := v.VerifySignatures(); err != nil {
 				return eb, err
 			}
 
@@ -182,6 +275,7 @@ func indexRef(ictx *indexingCtx, _ int64, eb Encoded[*Ref]) error {
 		RedirectTarget string     `json:"redirect,omitempty"`
 		Republish      bool       `json:"republish,omitempty"`
 		Visibility     Visibility `json:"visibility,omitempty"`
+		Branch         string     `json:"branch,omitempty"`
 	}
 
 	// We decided not to allow redirects or tombstones for home documents for now.
@@ -189,6 +283,16 @@ func indexRef(ictx *indexingCtx, _ int64, eb Encoded[*Ref]) error {
 		return fmt.Errorf("redirects or tombstones on home documents are not allowed")
 	}
 
+	if v.Branch != "" {
+		if err := ValidateBranchName(v.Branch); err != nil {
+			return fmt.Errorf("invalid Ref: %w", err)
+		}
+
+		if v.Redirect != nil || len(v.Heads) == 0 {
+			return fmt.Errorf("invalid Ref: branch Refs must point to a version")
+		}
+	}
+
 	space := v.Space()
 
 	iri, err := NewIRI(space, v.Path)
@@ -216,6 +320,7 @@ func indexRef(ictx *indexingCtx, _ int64, eb Encoded[*Ref]) error {
 	meta := Meta{
 		Generation: v.Generation,
 		Visibility: v.Visibility,
+		Branch:     v.Branch,
 	}
 
 	switch {
@@ -238,7 +343,8 @@ func indexRef(ictx *indexingCtx, _ int64, eb Encoded[*Ref]) error {
 		return fmt.Errorf("invalid Ref blob invariants %+v", v)
 	}
 
-	if v.Visibility == VisibilityPrivate {
+	// Private branches are drafts of the document, so they are allowed on any path.
+	if v.Visibility == VisibilityPrivate && v.Branch == "" {
 		if v.Path == "" {
 			return fmt.Errorf("invalid Ref: private Ref must have a path")
 		}
@@ -289,8 +395,29 @@ func crossLinkRefMaybe(ictx *indexingCtx, v *Ref) error {
 		return err
 	}
 
+	// Root Refs of multi-signature organizations need enough signatures of the members instead of capabilities.
+	var (
+		ms    membership
+		isOrg bool
+	)
+	if v.Path == "" {
+		ms, isOrg, err = loadMembership(conn, iri, v.Ts.UnixMilli())
+		if err != nil {
+			return err
+		}
+	}
+
 	// If we've got a Ref but this member is not valid yet/anymore, we don't want to populate our indexes.
-	ok, err := isValidWriter(conn, memberID, iri, ictx.writerCache)
+	var ok bool
+	switch {
+	// Branches don't change the published version, so any member can maintain them.
+	case isOrg && v.Branch != "":
+		ok = slices.Contains(ms.Members, memberID)
+	case isOrg:
+		ok, err = ms.approvedBy(ictx, v.Signers())
+	default:
+		ok, err = isValidWriter(conn, memberID, iri, v.Ts.UnixMilli(), ictx.writerCache)
+	}
 	if err != nil {
 		return err
 	}
@@ -305,6 +432,28 @@ func crossLinkRefMaybe(ictx *indexingCtx, v *Ref) error {
 		}
 	}
 
+	// Branch Refs don't affect the generations of the document.
+	// We only make sure that all the heads are indexed, so the branch can be loaded as soon as the Ref is visible.
+	if v.Branch != "" {
+		for _, h := range v.Heads {
+			ok, err := ictx.IsBlobIndexed(h)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				return stashError{
+					Reason: stashReasonFailedPrecondition,
+					Metadata: stashMetadata{
+						MissingBlobs: []cid.Cid{h},
+					},
+				}
+			}
+		}
+
+		return nil
+	}
+
 	resDB, err := dbResourcesLookupID(conn, string(iri))
 	if err != nil {
 		return err
@@ -415,6 +564,35 @@ func crossLinkRefMaybe(ictx *indexingCtx, v *Ref) error {
 			return cmp.Compare(a.ID, b.ID)
 		})
 
+		// Changes from writers whose capability was revoked before the change was created
+		// must not make it into the document, even if the Ref itself is signed by a valid writer.
+		// Authors that never had any capability are not checked here, as before.
+		wc := ictx.writerCache
+		if wc == nil {
+			wc = newWriterValidityCache()
+		}
+		for _, cm := range pendingChanges {
+			valid, hasGrants, err := checkWriter(conn, cm.Author, iri, cm.Ts, wc)
+			if err != nil {
+				return err
+			}
+			if valid || !hasGrants {
+				continue
+			}
+
+			author, err := ictx.lookup.PublicKey(cm.Author)
+			if err != nil {
+				return err
+			}
+
+			return stashError{
+				Reason: stashReasonPermissionDenied,
+				Metadata: stashMetadata{
+					DeniedSigners: []core.Principal{author},
+				},
+			}
+		}
+
 		for _, cm := range pendingChanges {
 			dg.ensureChangeApplied(cm)
 		}
@@ -191,3 +191,73 @@ func TestDocIndexedAttrsStructuralLWWIsOrderIndependent(t *testing.T) {
 		})
 	}
 }
+
+func TestBranchRef(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+	clock := cclock.New()
+
+	const path = "/parent/doc"
+
+	c1, err := NewChange(alice.Account, cid.Undef, nil, 0, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("title", "Published"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+
+	c2, err := NewChange(alice.Account, c1.CID, []cid.Cid{c1.CID}, 1, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("title", "Draft"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+
+	ref, err := NewRef(alice.Account, 0, c1.CID, alice.Account.Principal(), path, []cid.Cid{c1.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+
+	// Private branches are allowed on nested paths, unlike private documents.
+	branch, err := NewBranchRef(alice.Account, c1.CID, alice.Account.Principal(), path, "draft", []cid.Cid{c2.CID}, clock.MustNow(), VisibilityPrivate)
+	require.NoError(t, err)
+
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+	require.NoError(t, err)
+
+	// The branch Ref must wait for its heads.
+	require.NoError(t, idx.PutMany(t.Context(), []blocks.Block{c1, ref, branch}))
+	require.Equal(t, 1, countStashedBlobs(t, db))
+
+	require.NoError(t, idx.Put(t.Context(), c2))
+	require.Equal(t, 0, countStashedBlobs(t, db), "branch Ref must be unstashed once the heads are indexed")
+
+	iri := must.Do2(NewIRI(alice.Account.Principal(), path))
+	state, err := idx.ResolveLatest(t.Context(), iri)
+	require.NoError(t, err)
+	require.Equal(t, []cid.Cid{c1.CID}, state.Heads, "branch must not change the published version")
+	require.Equal(t, VisibilityPublic, state.Visibility, "private branch must not change the visibility of the document")
+
+	name, err := sqlitex.QueryOnePool[string](t.Context(), db, "SELECT extra_attrs->>'branch' FROM structural_blobs WHERE id = ?", blobIDForCID(t, db, branch.CID))
+	require.NoError(t, err)
+	require.Equal(t, "draft", name)
+
+	publicCount := func(c cid.Cid) int {
+		count, err := sqlitex.QueryOnePool[int](t.Context(), db, "SELECT COUNT() FROM public_blobs WHERE id = ?", blobIDForCID(t, db, c))
+		require.NoError(t, err)
+		return count
+	}
+	require.Equal(t, 1, publicCount(c1.CID))
+	require.Equal(t, 0, publicCount(c2.CID), "changes of a private branch must stay private")
+
+	// Branches need the same permissions as any other Ref.
+	bobBranch, err := NewBranchRef(bob.Account, c1.CID, alice.Account.Principal(), path, "bob", []cid.Cid{c2.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), bobBranch))
+	require.Equal(t, 1, countStashedBlobs(t, db), "branch Ref without permissions must be stashed")
+}
+
+func TestValidateBranchName(t *testing.T) {
+	for _, name := range []string{"draft", "v2.0", "big-rewrite_1", "A"} {
+		require.NoError(t, ValidateBranchName(name), name)
+	}
+
+	for _, name := range []string{"", BranchMain, "-draft", "with space", "a/b", "émoji", strings.Repeat("a", MaxBranchNameLength+1)} {
+		require.Error(t, ValidateBranchName(name), name)
+	}
+}
@@ -0,0 +1,423 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"seed/backend/core"
+	"seed/backend/ipfs"
+	"seed/backend/util/dqb"
+	"seed/backend/util/maybe"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"seed/backend/util/unsafeutil"
+	"slices"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multicodec"
+)
+
+// TypeRevocation is the type of the Revocation blob.
+const TypeRevocation Type = "Revocation"
+
+// linkTypeRevokedCapability is the blob link from a Revocation to the Capability it revokes.
+// The writer checks look revocations up through the blob_backlinks index on this link.
+const linkTypeRevokedCapability = "revocation/capability"
+
+// Revocation is a blob that revokes a previously issued Capability.
+// Blobs signed by the delegate at or after the revocation time are no longer authorized by the revoked capability.
+type Revocation struct {
+	BaseBlob
+	Capability cid.Cid `refmt:"capability"`
+}
+
+// NewRevocation creates a new Revocation blob for the given capability.
+func NewRevocation(issuer *core.KeyPair, capc cid.Cid, capability *Capability, ts time.Time) (eb Encoded[*Revocation], err error) {
+	if !issuer.Principal().Equal(capability.Signer) {
+		return eb, fmt.Errorf("capabilities can only be revoked by their issuer")
+	}
+
+	rv := &Revocation{
+		BaseBlob: BaseBlob{
+			Type:   TypeRevocation,
+			Signer: issuer.Principal(),
+			Ts:     ts,
+		},
+		Capability: capc,
+	}
+
+	if err := Sign(issuer, rv, &rv.BaseBlob.Sig); err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(rv)
+}
+
+func init() {
+	cbornode.RegisterCborType(Revocation{})
+
+	matcher := makeCBORTypeMatch(TypeRevocation)
+	registerIndexer(TypeRevocation,
+		func(c cid.Cid, data []byte) (eb Encoded[*Revocation], err error) {
+			codec, _ := ipfs.DecodeCID(c)
+			if codec != multicodec.DagCbor || !bytes.Contains(data, matcher) {
+				return eb, errSkipIndexing
+			}
+
+			v := &Revocation{}
+			if err := cbornode.DecodeInto(data, v); err != nil {
+				return eb, err
+			}
+
+			if err := Verify(v.Signer, v, v.Sig); err != nil {
+				return eb, err
+			}
+
+			eb.CID = c
+			eb.Data = data
+			eb.Decoded = v
+			return eb, nil
+		},
+		indexRevocation,
+	)
+}
+
+func indexRevocation(ictx *indexingCtx, _ int64, eb Encoded[*Revocation]) error {
+	c, v := eb.CID, eb.Decoded
+
+	if !v.Capability.Defined() {
+		return fmt.Errorf("revocation must point to a capability")
+	}
+
+	// We need the capability to know what we're revoking and to check who's allowed to revoke it.
+	// If it's not here yet we'll retry once the capability gets indexed.
+	capBlob, err := ictx.loadIndexedCapability(v.Capability)
+	if err != nil {
+		return err
+	}
+	if capBlob == nil {
+		return stashError{
+			Reason: stashReasonFailedPrecondition,
+			Metadata: stashMetadata{
+				MissingBlobs: []cid.Cid{v.Capability},
+			},
+		}
+	}
+
+	if !capBlob.Signer.Equal(v.Signer) {
+		return fmt.Errorf("revocation %s must be signed by the issuer of capability %s", c, v.Capability)
+	}
+
+	iri, err := NewIRI(capBlob.Space(), capBlob.Path)
+	if err != nil {
+		return err
+	}
+
+	// Revocations are anchored to the same resource as the capability they revoke,
+	// so they sync along with it. Like capabilities, they are public.
+	sb := newStructuralBlob(c, v.Type, v.Signer, v.Ts, iri, cid.Undef, capBlob.Space(), time.Time{}, VisibilityPublic, nil)
+
+	del, err := ictx.ensurePubKey(capBlob.Delegate)
+	if err != nil {
+		return err
+	}
+
+	sb.ExtraAttrs = map[string]any{
+		"del": del,
+	}
+
+	sb.AddBlobLink(linkTypeRevokedCapability, v.Capability)
+
+	if err := ictx.SaveBlob(sb); err != nil {
+		return err
+	}
+
+	// The revocation changes writer-validity outcomes, so the memoized grants must go.
+	ictx.writerCache.clear()
+
+	// Refs from the delegate that were indexed before we learned about the revocation
+	// may no longer be authorized. Evict them and rebuild the documents they touched.
+	return evictRevokedRefs(ictx, iri, del)
+}
+
+// loadIndexedCapability returns the decoded capability if it's already indexed, or nil otherwise.
+func (idx *indexingCtx) loadIndexedCapability(c cid.Cid) (*Capability, error) {
+	ok, err := idx.IsBlobIndexed(c)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	blk, err := idx.blockStore.get(context.Background(), idx.conn, c, false)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &Capability{}
+	if err := cbornode.DecodeInto(blk.RawData(), v); err != nil {
+		return nil, fmt.Errorf("failed to decode capability %s: %w", c, err)
+	}
+
+	if v.Type != TypeCapability {
+		return nil, fmt.Errorf("blob %s is not a capability", c)
+	}
+
+	return v, nil
+}
+
+// evictRevokedRefs finds Refs within the revoked capability's scope that are no longer authorized,
+// stashes them, and rebuilds the generations of the affected documents. The Refs are signed by the delegate,
+// or by anyone who got their capability through the delegate, transitively, e.g. their agents.
+func evictRevokedRefs(ictx *indexingCtx, scope IRI, delegateID int64) error {
+	delegates, err := loadTransitiveDelegates(ictx.conn, delegateID)
+	if err != nil {
+		return err
+	}
+
+	affected := make(map[int64]struct{})
+	for _, d := range delegates {
+		refs, err := loadRefsByAuthorInScope(ictx.conn, d.ID, scope)
+		if err != nil {
+			return err
+		}
+
+		for _, ref := range refs {
+			ok, err := isValidWriter(ictx.conn, d.ID, ref.IRI, ref.Ts, ictx.writerCache)
+			if err != nil {
+				return err
+			}
+			if ok {
+				continue
+			}
+
+			if err := evictIndexedBlob(ictx.conn, ref.ID, stashError{
+				Reason: stashReasonPermissionDenied,
+				Metadata: stashMetadata{
+					DeniedSigners: []core.Principal{d.Principal},
+				},
+			}); err != nil {
+				return err
+			}
+			affected[ref.Resource] = struct{}{}
+		}
+	}
+
+	for _, res := range slices.Sorted(maps.Keys(affected)) {
+		if err := rebuildDocumentGenerations(ictx, res); err != nil {
+			return fmt.Errorf("failed to rebuild document generations after revocation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+type delegateKey struct {
+	ID        int64
+	Principal core.Principal
+}
+
+// loadTransitiveDelegates returns the delegate itself, and everyone it delegated capabilities to, transitively.
+// The capabilities are not checked here, because the Refs of the delegates are validated again anyway.
+func loadTransitiveDelegates(conn *sqlite.Conn, delegateID int64) (out []delegateKey, err error) {
+	rows, discard, check := sqlitex.Query(conn, qTransitiveDelegates(), delegateID).All()
+	defer discard(&err)
+	for row := range rows {
+		out = append(out, delegateKey{
+			ID:        row.ColumnInt64(0),
+			Principal: core.Principal(row.ColumnBytes(1)),
+		})
+	}
+
+	err = errors.Join(err, check())
+	return out, err
+}
+
+var qTransitiveDelegates = dqb.Str(`
+	WITH RECURSIVE delegates (id) AS (
+		SELECT :delegate
+		UNION
+		SELECT sb.extra_attrs->>'del'
+		FROM delegates d
+		JOIN structural_blobs sb ON sb.author = d.id AND sb.type = 'Capability'
+	)
+	SELECT pk.id, pk.principal
+	FROM delegates d
+	JOIN public_keys pk ON pk.id = d.id
+	ORDER BY pk.id
+`)
+
+type indexedRef struct {
+	ID       int64
+	Resource int64
+	IRI      IRI
+	Ts       int64
+}
+
+func loadRefsByAuthorInScope(conn *sqlite.Conn, author int64, scope IRI) (out []indexedRef, err error) {
+	rows, discard, check := sqlitex.Query(conn, qRefsByAuthorInScope(), author, string(scope)).All()
+	defer discard(&err)
+	for row := range rows {
+		inc := sqlite.NewIncrementor(0)
+		out = append(out, indexedRef{
+			ID:       row.ColumnInt64(inc()),
+			Resource: row.ColumnInt64(inc()),
+			IRI:      IRI(row.ColumnText(inc())),
+			Ts:       row.ColumnInt64(inc()),
+		})
+	}
+
+	err = errors.Join(err, check())
+	return out, err
+}
+
+// Paths may contain GLOB wildcards, so the subtree is matched with a range comparison.
+var qRefsByAuthorInScope = dqb.Str(`
+	SELECT sb.id, sb.resource, r.iri, sb.ts
+	FROM structural_blobs sb INDEXED BY structural_blobs_by_author
+	JOIN resources r ON r.id = sb.resource
+	WHERE sb.author = :author
+	AND sb.type = 'Ref'
+	AND (r.iri = :iri OR (r.iri >= :iri || '/' AND r.iri < :iri || '0'))
+	ORDER BY sb.ts, sb.id
+`)
+
+// evictIndexedBlob removes the derived data of an already indexed blob and moves it into the stash,
+// as if it had failed to index in the first place.
+func evictIndexedBlob(conn *sqlite.Conn, id int64, serr stashError) error {
+	extraJSON, err := json.Marshal(serr.Metadata)
+	if err != nil {
+		return err
+	}
+
+	for _, q := range []string{
+		"DELETE FROM blob_links WHERE source = ?",
+		"DELETE FROM resource_links WHERE source = ?",
+		"DELETE FROM blob_visibility WHERE id = ?",
+		"DELETE FROM rbsr_item WHERE blob = ?",
+		"DELETE FROM structural_blobs WHERE id = ?",
+	} {
+		if err := sqlitex.Exec(conn, q, nil, id); err != nil {
+			return err
+		}
+	}
+
+	return sqlitex.Exec(conn, qStashBlob(), nil, id, serr.Reason, unsafeutil.StringFromBytes(extraJSON))
+}
+
+// rebuildDocumentGenerations recomputes the generations of a resource from the Refs that are currently indexed for it.
+// It's used when previously accepted Refs are evicted from the index.
+// Refs that are no longer valid after the eviction (e.g. because they include changes from a revoked writer) are evicted as well.
+func rebuildDocumentGenerations(ictx *indexingCtx, resource int64) error {
+	type commentStats struct {
+		Generation  int64
+		Genesis     string
+		LastComment maybe.Value[int64]
+		LastTime    int64
+		Count       int64
+	}
+
+	// Comment stats are maintained by comment indexing, not by Refs, so we have to carry them over.
+	var stats []commentStats
+	if err := sqlitex.Exec(ictx.conn, qLoadGenerationCommentStats(), func(stmt *sqlite.Stmt) error {
+		cs := commentStats{
+			Generation: stmt.ColumnInt64(0),
+			Genesis:    stmt.ColumnText(1),
+			LastTime:   stmt.ColumnInt64(3),
+			Count:      stmt.ColumnInt64(4),
+		}
+		if stmt.ColumnType(2) != sqlite.SQLITE_NULL {
+			cs.LastComment = maybe.New(stmt.ColumnInt64(2))
+		}
+		stats = append(stats, cs)
+		return nil
+	}, resource); err != nil {
+		return err
+	}
+
+	for _, q := range []string{
+		"DELETE FROM document_generations WHERE resource = ?",
+		"DELETE FROM document_attributes WHERE resource = ?",
+	} {
+		if err := sqlitex.Exec(ictx.conn, q, nil, resource); err != nil {
+			return err
+		}
+	}
+
+	type refBlob struct {
+		ID   int64
+		Data []byte
+	}
+
+	// Collecting the Refs first to avoid reading and writing the same tables in a nested query.
+	var refs []refBlob
+	if err := sqlitex.Exec(ictx.conn, qLoadIndexedRefsForResource(), func(stmt *sqlite.Stmt) error {
+		data, err := ictx.blockStore.decompress(stmt.ColumnBytes(1), stmt.ColumnInt(2))
+		if err != nil {
+			return err
+		}
+		refs = append(refs, refBlob{ID: stmt.ColumnInt64(0), Data: data})
+		return nil
+	}, resource); err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		v := &Ref{}
+		if err := cbornode.DecodeInto(ref.Data, v); err != nil {
+			return err
+		}
+
+		rctx := newCtx(ictx.conn, ref.ID, ictx.blockStore, ictx.log)
+		rctx.writerCache = ictx.writerCache
+		rctx.deriveFirstContentImage = ictx.deriveFirstContentImage
+
+		err := crossLinkRefMaybe(rctx, v)
+		var serr stashError
+		if errors.As(err, &serr) {
+			err = evictIndexedBlob(ictx.conn, ref.ID, serr)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, cs := range stats {
+		if err := sqlitex.Exec(ictx.conn, qRestoreGenerationCommentStats(), nil, resource, cs.Generation, cs.Genesis, cs.LastComment.Any(), cs.LastTime, cs.Count); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var qLoadGenerationCommentStats = dqb.Str(`
+	SELECT generation, genesis, last_comment, last_comment_time, comment_count
+	FROM document_generations
+	WHERE resource = :resource
+`)
+
+var qRestoreGenerationCommentStats = dqb.Str(`
+	UPDATE document_generations SET
+		last_comment = ?4,
+		last_comment_time = ?5,
+		comment_count = ?6
+	WHERE resource = ?1
+	AND generation = ?2
+	AND genesis = ?3
+`)
+
+var qLoadIndexedRefsForResource = dqb.Str(`
+	SELECT b.id, b.data, b.size
+	FROM structural_blobs sb
+	JOIN blobs b ON b.id = sb.id
+	WHERE sb.resource = :resource
+	AND sb.type = 'Ref'
+	ORDER BY sb.ts, sb.id
+`)
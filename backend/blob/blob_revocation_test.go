@@ -0,0 +1,229 @@
+package blob
+
+import (
+	"seed/backend/core/coretest"
+	"seed/backend/storage"
+	"seed/backend/util/cclock"
+	"seed/backend/util/must"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRevocation_RejectsLaterRefs(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	bob := coretest.NewTester("bob").Account
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+	require.NoError(t, err)
+
+	clock := cclock.New()
+	cpb, err := NewCapability(alice, bob.Principal(), alice.Principal(), "/shared", RoleWriter, "", clock.MustNow())
+	require.NoError(t, err)
+
+	change1, err := NewChange(bob, cid.Undef, nil, 0, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "Hello"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	ref1, err := NewRef(bob, 0, change1.CID, alice.Principal(), "/shared", []cid.Cid{change1.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+
+	require.NoError(t, idx.PutMany(t.Context(), []blocks.Block{cpb, change1, ref1}))
+	require.Equal(t, 0, countStashedBlobs(t, db))
+
+	rv, err := NewRevocation(alice, cpb.CID, cpb.Decoded, clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), rv))
+	require.Equal(t, 0, countStashedBlobs(t, db), "refs created before the revocation must remain valid")
+
+	valid, err := idx.IsValidWriter(t.Context(), alice.Principal(), "/shared", bob.Principal())
+	require.NoError(t, err)
+	require.False(t, valid, "bob must not be a valid writer after the revocation")
+
+	change2, err := NewChange(bob, change1.CID, []cid.Cid{change1.CID}, 1, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "Hello World"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	ref2, err := NewRef(bob, 0, change1.CID, alice.Principal(), "/shared", []cid.Cid{change2.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+
+	require.NoError(t, idx.PutMany(t.Context(), []blocks.Block{change2, ref2}))
+	require.Equal(t, 1, countStashedBlobs(t, db), "ref signed after the revocation must be stashed")
+
+	requireLatestChanges(t, idx, must.Do2(NewIRI(alice.Principal(), "/shared")), change1.CID)
+}
+
+func TestRevocation_EvictsAlreadyIndexedRefs(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	bob := coretest.NewTester("bob").Account
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+	require.NoError(t, err)
+
+	clock := cclock.New()
+	cpb, err := NewCapability(alice, bob.Principal(), alice.Principal(), "/shared", RoleWriter, "", clock.MustNow())
+	require.NoError(t, err)
+
+	change1, err := NewChange(bob, cid.Undef, nil, 0, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "Hello"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	ref1, err := NewRef(bob, 0, change1.CID, alice.Principal(), "/shared", []cid.Cid{change1.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+
+	revokeTime := clock.MustNow()
+
+	change2, err := NewChange(bob, change1.CID, []cid.Cid{change1.CID}, 1, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "Hello World"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	ref2, err := NewRef(bob, 0, change1.CID, alice.Principal(), "/shared", []cid.Cid{change2.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+
+	// We receive bob's late changes before we learn about the revocation.
+	require.NoError(t, idx.PutMany(t.Context(), []blocks.Block{cpb, change1, ref1, change2, ref2}))
+	require.Equal(t, 0, countStashedBlobs(t, db))
+
+	iri := must.Do2(NewIRI(alice.Principal(), "/shared"))
+	requireLatestChanges(t, idx, iri, change1.CID, change2.CID)
+
+	rv, err := NewRevocation(alice, cpb.CID, cpb.Decoded, revokeTime)
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), rv))
+
+	require.Equal(t, 1, countStashedBlobs(t, db), "ref signed after the revocation time must be evicted")
+	requireLatestChanges(t, idx, iri, change1.CID)
+
+	// The owner can't sneak the revoked writer's late changes back in.
+	ref3, err := NewRef(alice, 0, change1.CID, alice.Principal(), "/shared", []cid.Cid{change2.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), ref3))
+	require.Equal(t, 2, countStashedBlobs(t, db), "ref with changes from a revoked writer must be stashed")
+	requireLatestChanges(t, idx, iri, change1.CID)
+}
+
+func TestRevocation_EvictsRefsOfTransitiveDelegates(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	bob := coretest.NewTester("bob").Account
+	carol := coretest.NewTester("carol").Account
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+	require.NoError(t, err)
+
+	clock := cclock.New()
+	cpb, err := NewCapability(alice, bob.Principal(), alice.Principal(), "/shared", RoleWriter, "", clock.MustNow())
+	require.NoError(t, err)
+
+	// Carol writes as an agent of bob, so her capability is delegated through the revoked one.
+	agent, err := NewCapability(bob, carol.Principal(), bob.Principal(), "", RoleAgent, "", clock.MustNow())
+	require.NoError(t, err)
+
+	change1, err := NewChange(carol, cid.Undef, nil, 0, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "Hello"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	ref1, err := NewRef(carol, 0, change1.CID, alice.Principal(), "/shared", []cid.Cid{change1.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+
+	revokeTime := clock.MustNow()
+
+	change2, err := NewChange(carol, change1.CID, []cid.Cid{change1.CID}, 1, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "Hello World"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	ref2, err := NewRef(carol, 0, change1.CID, alice.Principal(), "/shared", []cid.Cid{change2.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+
+	require.NoError(t, idx.PutMany(t.Context(), []blocks.Block{cpb, agent, change1, ref1, change2, ref2}))
+	require.Equal(t, 0, countStashedBlobs(t, db))
+
+	iri := must.Do2(NewIRI(alice.Principal(), "/shared"))
+	requireLatestChanges(t, idx, iri, change1.CID, change2.CID)
+
+	rv, err := NewRevocation(alice, cpb.CID, cpb.Decoded, revokeTime)
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), rv))
+
+	require.Equal(t, 1, countStashedBlobs(t, db), "ref of the agent signed after the revocation time must be evicted")
+	requireLatestChanges(t, idx, iri, change1.CID)
+}
+
+func TestRevocation_BeforeCapability(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	bob := coretest.NewTester("bob").Account
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+	require.NoError(t, err)
+
+	clock := cclock.New()
+	cpb, err := NewCapability(alice, bob.Principal(), alice.Principal(), "", RoleWriter, "", clock.MustNow())
+	require.NoError(t, err)
+
+	rv, err := NewRevocation(alice, cpb.CID, cpb.Decoded, clock.MustNow())
+	require.NoError(t, err)
+
+	require.NoError(t, idx.Put(t.Context(), rv))
+	require.Equal(t, 1, countStashedBlobs(t, db), "revocation must wait for its capability")
+
+	require.NoError(t, idx.Put(t.Context(), cpb))
+	require.Equal(t, 0, countStashedBlobs(t, db), "revocation must be indexed once the capability arrives")
+
+	valid, err := idx.IsValidWriter(t.Context(), alice.Principal(), "/doc", bob.Principal())
+	require.NoError(t, err)
+	require.False(t, valid)
+
+	var caps []cid.Cid
+	require.NoError(t, idx.WalkCapabilitiesForDelegate(t.Context(), bob.Principal(), func(c cid.Cid, _ *Capability) error {
+		caps = append(caps, c)
+		return nil
+	}))
+	require.Empty(t, caps, "revoked capabilities must not be listed")
+}
+
+func TestRevocation_OnlyIssuerCanRevoke(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	bob := coretest.NewTester("bob").Account
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+	require.NoError(t, err)
+
+	clock := cclock.New()
+	cpb, err := NewCapability(alice, bob.Principal(), alice.Principal(), "", RoleWriter, "", clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), cpb))
+
+	_, err = NewRevocation(bob, cpb.CID, cpb.Decoded, clock.MustNow())
+	require.Error(t, err)
+
+	// Forge a revocation signed by the delegate, bypassing the constructor checks.
+	forged := &Revocation{
+		BaseBlob: BaseBlob{
+			Type:   TypeRevocation,
+			Signer: bob.Principal(),
+			Ts:     clock.MustNow(),
+		},
+		Capability: cpb.CID,
+	}
+	require.NoError(t, Sign(bob, forged, &forged.Sig))
+	eb, err := encodeBlob(forged)
+	require.NoError(t, err)
+	require.Error(t, idx.Put(t.Context(), eb))
+
+	valid, err := idx.IsValidWriter(t.Context(), alice.Principal(), "", bob.Principal())
+	require.NoError(t, err)
+	require.True(t, valid)
+}
+
+func requireLatestChanges(t *testing.T, idx *Index, iri IRI, want ...cid.Cid) {
+	t.Helper()
+	changes, check := idx.iterChangesLatest(t.Context(), iri)
+	var got []cid.Cid
+	for c := range changes {
+		got = append(got, c.CID)
+	}
+	require.NoError(t, check())
+	require.Equal(t, want, got)
+}
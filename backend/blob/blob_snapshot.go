@@ -0,0 +1,411 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"seed/backend/core"
+	"seed/backend/ipfs"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"slices"
+	"time"
+
+	"github.com/RoaringBitmap/roaring/v2/roaring64"
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multicodec"
+)
+
+// TypeSnapshot is the type of the Snapshot blob.
+const TypeSnapshot Type = "Snapshot"
+
+// Snapshot holds the materialized state of a document at a given version,
+// so that loading the document doesn't need to replay every Change since the genesis.
+// The state is only a cache: the Changes remain the source of truth,
+// and readers decide themselves which snapshots they trust.
+type Snapshot struct {
+	BaseBlob
+
+	// Space and path of the document.
+	// Space may be empty if it's the same as the signer.
+	Space_ core.Principal `refmt:"space,omitempty"`
+	Path   string         `refmt:"path,omitempty"`
+
+	// Genesis change of the document.
+	Genesis cid.Cid `refmt:"genesis"`
+
+	// Heads is the version of the document the snapshot was made at.
+	Heads []cid.Cid `refmt:"heads"`
+
+	// ChangeCount is the number of changes the snapshot covers.
+	ChangeCount int `refmt:"changeCount"`
+
+	// State is the CBOR-encoded state of the document CRDT.
+	// The format is owned by the document model, the index treats it as opaque bytes.
+	State []byte `refmt:"state"`
+
+	Visibility Visibility `refmt:"visibility,omitempty"`
+}
+
+// NewSnapshot creates a new Snapshot blob.
+func NewSnapshot(
+	kp *core.KeyPair,
+	space core.Principal,
+	path string,
+	genesis cid.Cid,
+	heads []cid.Cid,
+	changeCount int,
+	state []byte,
+	visibility Visibility,
+	ts time.Time,
+) (eb Encoded[*Snapshot], err error) {
+	s := &Snapshot{
+		BaseBlob: BaseBlob{
+			Type:   TypeSnapshot,
+			Signer: kp.Principal(),
+			Ts:     ts,
+		},
+		Path:        path,
+		Genesis:     genesis,
+		Heads:       heads,
+		ChangeCount: changeCount,
+		State:       state,
+		Visibility:  visibility,
+	}
+
+	if !kp.Principal().Equal(space) {
+		s.Space_ = space
+	}
+
+	if err := s.validate(); err != nil {
+		return eb, err
+	}
+
+	if err := Sign(kp, s, &s.BaseBlob.Sig); err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(s)
+}
+
+// Space returns the space of the document.
+func (s *Snapshot) Space() core.Principal {
+	if len(s.Space_) == 0 {
+		return s.Signer
+	}
+	return s.Space_
+}
+
+func (s *Snapshot) validate() error {
+	if !s.Genesis.Defined() {
+		return fmt.Errorf("snapshot must have a genesis")
+	}
+
+	if len(s.Heads) == 0 {
+		return fmt.Errorf("snapshot must have heads")
+	}
+
+	if s.ChangeCount < len(s.Heads) {
+		return fmt.Errorf("snapshot must cover at least as many changes as it has heads: changes=%d heads=%d", s.ChangeCount, len(s.Heads))
+	}
+
+	if len(s.State) == 0 {
+		return fmt.Errorf("snapshot must have state")
+	}
+
+	return nil
+}
+
+func init() {
+	cbornode.RegisterCborType(Snapshot{})
+
+	matcher := makeCBORTypeMatch(TypeSnapshot)
+	registerIndexer(TypeSnapshot,
+		func(c cid.Cid, data []byte) (eb Encoded[*Snapshot], err error) {
+			codec, _ := ipfs.DecodeCID(c)
+			if codec != multicodec.DagCbor || !bytes.Contains(data, matcher) {
+				return eb, errSkipIndexing
+			}
+
+			v := &Snapshot{}
+			if err := cbornode.DecodeInto(data, v); err != nil {
+				return eb, err
+			}
+
+			if err := Verify(v.Signer, v, v.Sig); err != nil {
+				return eb, err
+			}
+
+			eb.CID = c
+			eb.Data = data
+			eb.Decoded = v
+			return eb, nil
+		},
+		indexSnapshot,
+	)
+}
+
+func indexSnapshot(ictx *indexingCtx, _ int64, eb Encoded[*Snapshot]) error {
+	c, v := eb.CID, eb.Decoded
+
+	if err := v.validate(); err != nil {
+		return fmt.Errorf("invalid snapshot %s: %w", c, err)
+	}
+
+	iri, err := NewIRI(v.Space(), v.Path)
+	if err != nil {
+		return fmt.Errorf("invalid snapshot target: %w", err)
+	}
+
+	// Snapshots are only useful when the changes they cover are available,
+	// because readers check them against the change DAG.
+	for _, h := range v.Heads {
+		ok, err := ictx.IsBlobIndexed(h)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return stashError{
+				Reason: stashReasonFailedPrecondition,
+				Metadata: stashMetadata{
+					MissingBlobs: []cid.Cid{h},
+				},
+			}
+		}
+	}
+
+	signerID, err := ictx.ensurePubKey(v.Signer)
+	if err != nil {
+		return err
+	}
+
+	ok, err := isValidWriter(ictx.conn, signerID, iri, v.Ts.UnixMilli(), ictx.writerCache)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return stashError{
+			Reason: stashReasonPermissionDenied,
+			Metadata: stashMetadata{
+				DeniedSigners: []core.Principal{v.Signer},
+			},
+		}
+	}
+
+	var visibilitySpaces []core.Principal
+	if v.Visibility == VisibilityPrivate {
+		visibilitySpaces = []core.Principal{v.Space()}
+	}
+
+	sb := newStructuralBlob(c, v.Type, v.Signer, v.Ts, iri, v.Genesis, v.Space(), time.Time{}, v.Visibility, visibilitySpaces)
+	sb.ExtraAttrs = map[string]any{
+		"changes": v.ChangeCount,
+	}
+
+	for _, h := range v.Heads {
+		sb.AddBlobLink("snapshot/head", h)
+	}
+
+	return ictx.SaveBlob(sb)
+}
+
+// SnapshotRecord is a Snapshot found for a document version.
+type SnapshotRecord struct {
+	CID        cid.Cid
+	Data       *Snapshot
+	Generation int64
+	Visibility Visibility
+}
+
+// snapshotMaxCandidates limits how many of the largest snapshots we consider when loading a document.
+const snapshotMaxCandidates = 20
+
+// LoadSnapshot finds the snapshot of the resource covering the most changes within the given version,
+// and whose signer is accepted by the trusted function.
+// When no heads are provided it uses the latest version of the latest generation, like [Index.IterChanges].
+// The changes of the version that are not covered by the snapshot can be loaded with [Index.IterChangesFrom].
+func (idx *Index) LoadSnapshot(ctx context.Context, resource IRI, heads []cid.Cid, trusted func(core.Principal) bool) (rec SnapshotRecord, found bool, err error) {
+	conn, release, err := idx.db.ReadConn(ctx)
+	if err != nil {
+		return rec, false, err
+	}
+	defer release()
+
+	dg, version, found, err := idx.resolveVersion(conn, resource, heads)
+	if err != nil || !found {
+		return rec, false, err
+	}
+
+	lookup := NewLookupCache(conn)
+
+	var best int64
+	rows, discard, check := sqlitex.Query(conn, qLoadSnapshotCandidates(), resource, snapshotMaxCandidates).All()
+	defer discard(&err)
+	for row := range rows {
+		next := sqlite.NewIncrementor(0)
+		var (
+			id        = row.ColumnInt64(next())
+			author    = row.ColumnInt64(next())
+			headsJSON = row.ColumnBytesUnsafe(next())
+		)
+
+		signer, err := lookup.PublicKey(author)
+		if err != nil {
+			return rec, false, err
+		}
+
+		if !trusted(signer) {
+			continue
+		}
+
+		var snapHeads []int64
+		if err := json.Unmarshal(headsJSON, &snapHeads); err != nil {
+			return rec, false, err
+		}
+
+		// The snapshot must not contain anything outside of the requested version.
+		if slices.ContainsFunc(snapHeads, func(h int64) bool { return !version.Contains(uint64(h)) }) { //nolint:gosec // We know this should not overflow.
+			continue
+		}
+
+		best = id
+		break
+	}
+	if err := check(); err != nil {
+		return rec, false, err
+	}
+
+	if best == 0 {
+		return rec, false, nil
+	}
+
+	c, err := lookup.CID(best)
+	if err != nil {
+		return rec, false, err
+	}
+
+	blk, err := idx.bs.get(ctx, conn, c, false)
+	if err != nil {
+		return rec, false, err
+	}
+
+	v := &Snapshot{}
+	if err := cbornode.DecodeInto(blk.RawData(), v); err != nil {
+		return rec, false, fmt.Errorf("failed to decode snapshot %s: %w", c, err)
+	}
+
+	return SnapshotRecord{
+		CID:        c,
+		Data:       v,
+		Generation: dg.Generation,
+		Visibility: dg.Visibility,
+	}, true, nil
+}
+
+var qLoadSnapshotCandidates = dqb.Str(`
+	SELECT
+		sb.id,
+		sb.author,
+		(SELECT json_group_array(bl.target) FROM blob_links bl WHERE bl.source = sb.id AND bl.type = 'snapshot/head') AS heads
+	FROM structural_blobs sb
+	WHERE sb.resource = (SELECT id FROM resources WHERE iri = :iri)
+	AND sb.type = 'Snapshot'
+	ORDER BY sb.extra_attrs->>'changes' DESC, sb.ts DESC, sb.id DESC
+	LIMIT :limit
+`)
+
+// IterChangesFrom is like [Index.IterChanges], but it skips the changes reachable from the base heads,
+// which are normally the heads of a [Snapshot] the document is restored from.
+func (idx *Index) IterChangesFrom(ctx context.Context, resource IRI, heads, base []cid.Cid) (it iter.Seq[ChangeRecord], check func() error) {
+	if len(base) == 0 {
+		return idx.IterChanges(ctx, resource, heads)
+	}
+
+	var outErr error
+	check = func() error { return outErr }
+
+	it = func(yield func(ChangeRecord) bool) {
+		conn, release, err := idx.db.ReadConn(ctx)
+		if err != nil {
+			outErr = err
+			return
+		}
+		defer release()
+
+		dg, version, found, err := idx.resolveVersion(conn, resource, heads)
+		if err != nil {
+			outErr = err
+			return
+		}
+		if !found {
+			return
+		}
+
+		baseIDs, err := cidsToDBIDs(conn, base)
+		if err != nil {
+			outErr = err
+			return
+		}
+
+		changeIDs := make([]int64, 0, version.GetCardinality())
+		for it := version.Iterator(); it.HasNext(); {
+			changeIDs = append(changeIDs, int64(it.Next())) //nolint:gosec // We know this should not overflow.
+		}
+
+		changes, err := changesBetweenIDsConn(conn, idx.bs, changeIDs, baseIDs, dg.Generation)
+		if err != nil {
+			outErr = err
+			return
+		}
+
+		for _, rec := range changes {
+			rec.Visibility = dg.Visibility
+			if !yield(rec) {
+				break
+			}
+		}
+	}
+
+	return it, check
+}
+
+// resolveVersion finds the generation of the resource that the version with the given heads belongs to,
+// and the set of changes of the version. Empty heads mean the latest version of the latest generation.
+func (idx *Index) resolveVersion(conn *sqlite.Conn, resource IRI, heads []cid.Cid) (dg documentGeneration, version *roaring64.Bitmap, found bool, err error) {
+	if len(heads) == 0 {
+		dg, found, err = idx.resolveLatestGeneration(conn, resource)
+		if err != nil || !found || dg.Changes == nil || dg.Changes.IsEmpty() {
+			return dg, nil, false, err
+		}
+
+		return dg, dg.Changes, true, nil
+	}
+
+	headIDs, err := cidsToDBIDs(conn, heads)
+	if err != nil {
+		return dg, nil, false, err
+	}
+
+	gen, err := findVersionGeneration(conn, resource, heads, headIDs)
+	if err != nil || !gen.IsSet() {
+		return dg, nil, false, err
+	}
+
+	changes, err := idx.resolveHeads(conn, headIDs)
+	if err != nil {
+		return dg, nil, false, err
+	}
+
+	version = roaring64.New()
+	for _, id := range changes {
+		version.Add(uint64(id)) //nolint:gosec // We know this should not overflow.
+	}
+
+	return gen.Value(), version, true, nil
+}
@@ -0,0 +1,113 @@
+package blob
+
+import (
+	"seed/backend/core"
+	"seed/backend/core/coretest"
+	"seed/backend/storage"
+	"seed/backend/util/cclock"
+	"seed/backend/util/must"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSnapshot(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	bob := coretest.NewTester("bob").Account
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+	require.NoError(t, err)
+
+	clock := cclock.New()
+	iri := must.Do2(NewIRI(alice.Principal(), "/doc"))
+
+	change1, err := NewChange(alice, cid.Undef, nil, 0, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "First"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	change2, err := NewChange(alice, change1.CID, []cid.Cid{change1.CID}, 1, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "Second"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	change3, err := NewChange(alice, change1.CID, []cid.Cid{change2.CID}, 2, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("name", "Third"))},
+	}, clock.MustNow())
+	require.NoError(t, err)
+
+	ref, err := NewRef(alice, 0, change1.CID, alice.Principal(), "/doc", []cid.Cid{change2.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+	require.NoError(t, idx.PutMany(t.Context(), []blocks.Block{change1, change2, ref}))
+
+	state := []byte("opaque state")
+
+	// Snapshots wait for the changes they cover.
+	snap3, err := NewSnapshot(alice, alice.Principal(), "/doc", change1.CID, []cid.Cid{change3.CID}, 3, state, VisibilityPublic, clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), snap3))
+	require.Equal(t, 1, countStashedBlobs(t, db))
+
+	// Snapshots from someone who can't write are not indexed.
+	bobSnap, err := NewSnapshot(bob, alice.Principal(), "/doc", change1.CID, []cid.Cid{change2.CID}, 2, state, VisibilityPublic, clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), bobSnap))
+	require.Equal(t, 2, countStashedBlobs(t, db))
+
+	snap2, err := NewSnapshot(alice, alice.Principal(), "/doc", change1.CID, []cid.Cid{change2.CID}, 2, state, VisibilityPublic, clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), snap2))
+	require.Equal(t, 2, countStashedBlobs(t, db))
+
+	trustAll := func(core.Principal) bool { return true }
+
+	rec, found, err := idx.LoadSnapshot(t.Context(), iri, nil, trustAll)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, snap2.CID, rec.CID)
+	require.Equal(t, []cid.Cid{change2.CID}, rec.Data.Heads)
+	require.Equal(t, state, rec.Data.State)
+
+	// Only the changes after the snapshot need to be loaded.
+	requireChangesFrom := func(heads, base []cid.Cid, want ...cid.Cid) {
+		t.Helper()
+		var got []cid.Cid
+		changes, check := idx.IterChangesFrom(t.Context(), iri, heads, base)
+		for ch := range changes {
+			got = append(got, ch.CID)
+		}
+		require.NoError(t, check())
+		require.Equal(t, want, got)
+	}
+
+	requireChangesFrom(nil, rec.Data.Heads)
+	requireChangesFrom(nil, nil, change1.CID, change2.CID)
+
+	// Once the document moves on, the larger snapshot gets indexed and used.
+	ref2, err := NewRef(alice, 0, change1.CID, alice.Principal(), "/doc", []cid.Cid{change3.CID}, clock.MustNow(), VisibilityPublic)
+	require.NoError(t, err)
+	require.NoError(t, idx.PutMany(t.Context(), []blocks.Block{change3, ref2}))
+	require.Equal(t, 1, countStashedBlobs(t, db))
+
+	rec, found, err = idx.LoadSnapshot(t.Context(), iri, nil, trustAll)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, snap3.CID, rec.CID)
+
+	// Older versions use older snapshots.
+	rec, found, err = idx.LoadSnapshot(t.Context(), iri, []cid.Cid{change2.CID}, trustAll)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, snap2.CID, rec.CID)
+	requireChangesFrom([]cid.Cid{change3.CID}, rec.Data.Heads, change3.CID)
+
+	_, found, err = idx.LoadSnapshot(t.Context(), iri, []cid.Cid{change1.CID}, trustAll)
+	require.NoError(t, err)
+	require.False(t, found, "snapshots newer than the version must not be used")
+
+	// Untrusted snapshots are skipped.
+	_, found, err = idx.LoadSnapshot(t.Context(), iri, nil, func(core.Principal) bool { return false })
+	require.NoError(t, err)
+	require.False(t, found)
+}
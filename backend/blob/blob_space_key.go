@@ -0,0 +1,268 @@
+package blob
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"seed/backend/core"
+	"seed/backend/ipfs"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multicodec"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// TypeSpaceKey is the type of the SpaceKey blob.
+const TypeSpaceKey Type = "SpaceKey"
+
+// SpaceKey is a blob that shares the symmetric content key of a space with its members.
+// The content key encrypts the bodies of private Changes and Comments in the space.
+// It's sealed separately for the owner and for each member that can read private content,
+// so only they can unwrap it with their private keys.
+// Keys are rotated by publishing a new SpaceKey with a fresh content key,
+// which is what happens when a member loses access to the space.
+// The content keys used before the rotations are carried along in the history,
+// sealed for the same recipients, so members added later can read the content encrypted before they joined.
+type SpaceKey struct {
+	BaseBlob
+	Keys    []WrappedKey `refmt:"keys"`
+	History []WrappedKey `refmt:"history,omitempty"`
+}
+
+// WrappedKey is a content key sealed for one recipient.
+type WrappedKey struct {
+	Recipient core.Principal `refmt:"recipient"`
+	Key       []byte         `refmt:"key"`
+}
+
+// NewContentKey generates a new random content key.
+func NewContentKey() ([]byte, error) {
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// NewSpaceKey creates a new SpaceKey blob sharing the content key, and the previous content keys in the history, with the recipients.
+// The owner is always included in the recipients.
+func NewSpaceKey(owner *core.KeyPair, key []byte, history [][]byte, recipients []core.Principal, ts time.Time) (eb Encoded[*SpaceKey], err error) {
+	if len(key) != chacha20poly1305.KeySize {
+		return eb, fmt.Errorf("invalid content key size %d", len(key))
+	}
+
+	for _, k := range history {
+		if len(k) != chacha20poly1305.KeySize {
+			return eb, fmt.Errorf("invalid previous content key size %d", len(k))
+		}
+	}
+
+	sk := &SpaceKey{
+		BaseBlob: BaseBlob{
+			Type:   TypeSpaceKey,
+			Signer: owner.Principal(),
+			Ts:     ts,
+		},
+	}
+
+	seen := make(map[core.PrincipalUnsafeString]struct{}, len(recipients)+1)
+	for _, r := range append([]core.Principal{owner.Principal()}, recipients...) {
+		if _, ok := seen[r.UnsafeString()]; ok {
+			continue
+		}
+		seen[r.UnsafeString()] = struct{}{}
+
+		pub, err := r.Parse()
+		if err != nil {
+			return eb, fmt.Errorf("invalid recipient %s: %w", r, err)
+		}
+
+		box, err := core.SealTo(pub, key)
+		if err != nil {
+			return eb, fmt.Errorf("failed to wrap content key for %s: %w", r, err)
+		}
+
+		sk.Keys = append(sk.Keys, WrappedKey{Recipient: r, Key: box})
+
+		for _, k := range history {
+			box, err := core.SealTo(pub, k)
+			if err != nil {
+				return eb, fmt.Errorf("failed to wrap previous content key for %s: %w", r, err)
+			}
+
+			sk.History = append(sk.History, WrappedKey{Recipient: r, Key: box})
+		}
+	}
+
+	if err := Sign(owner, sk, &sk.BaseBlob.Sig); err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(sk)
+}
+
+// Recipients returns the principals the content key is shared with.
+func (sk *SpaceKey) Recipients() []core.Principal {
+	out := make([]core.Principal, len(sk.Keys))
+	for i, k := range sk.Keys {
+		out[i] = k.Recipient
+	}
+	return out
+}
+
+// unwrap opens the content key, and the previous content keys from the history, with the first matching local key pair.
+func (sk *SpaceKey) unwrap(keys []core.NamedKeyPair) (key []byte, history [][]byte, ok bool) {
+	for _, wk := range sk.Keys {
+		for _, kp := range keys {
+			if !kp.Principal().Equal(wk.Recipient) {
+				continue
+			}
+
+			key, err := kp.Open(wk.Key)
+			if err != nil || len(key) != chacha20poly1305.KeySize {
+				continue
+			}
+
+			for _, hk := range sk.History {
+				if !hk.Recipient.Equal(wk.Recipient) {
+					continue
+				}
+
+				k, err := kp.Open(hk.Key)
+				if err != nil || len(k) != chacha20poly1305.KeySize {
+					continue
+				}
+				history = append(history, k)
+			}
+
+			return key, history, true
+		}
+	}
+
+	return nil, nil, false
+}
+
+// ContentKey is an unwrapped content key of a space.
+type ContentKey struct {
+	// ID is the CID of the SpaceKey blob that shares the key.
+	ID  cid.Cid
+	Key []byte
+}
+
+// EncryptedBody is the encrypted body of a private blob.
+// The plaintext is the CBOR encoding of the blob's body,
+// encrypted with XChaCha20-Poly1305 using the content key shared by the referenced SpaceKey blob.
+type EncryptedBody struct {
+	Key   cid.Cid `refmt:"key"`
+	Nonce []byte  `refmt:"nonce"`
+	Data  []byte  `refmt:"data"`
+}
+
+// Seal encrypts the CBOR encoding of v with the content key.
+func (k ContentKey) Seal(v any) (*EncryptedBody, error) {
+	aead, err := chacha20poly1305.NewX(k.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := cbornode.DumpObject(v)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return &EncryptedBody{
+		Key:   k.ID,
+		Nonce: nonce,
+		Data:  aead.Seal(nil, nonce, data, k.ID.Bytes()),
+	}, nil
+}
+
+// Open decrypts the encrypted body into out.
+func (k ContentKey) Open(eb *EncryptedBody, out any) error {
+	if !eb.Key.Equals(k.ID) {
+		return fmt.Errorf("body is encrypted with key %s, but got key %s", eb.Key, k.ID)
+	}
+
+	aead, err := chacha20poly1305.NewX(k.Key)
+	if err != nil {
+		return err
+	}
+
+	if len(eb.Nonce) != aead.NonceSize() {
+		return fmt.Errorf("invalid nonce size %d", len(eb.Nonce))
+	}
+
+	data, err := aead.Open(nil, eb.Nonce, eb.Data, k.ID.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to decrypt body: %w", err)
+	}
+
+	return cbornode.DecodeInto(data, out)
+}
+
+func init() {
+	cbornode.RegisterCborType(SpaceKey{})
+	cbornode.RegisterCborType(WrappedKey{})
+	cbornode.RegisterCborType(EncryptedBody{})
+
+	matcher := makeCBORTypeMatch(TypeSpaceKey)
+	registerIndexer(TypeSpaceKey,
+		func(c cid.Cid, data []byte) (eb Encoded[*SpaceKey], err error) {
+			codec, _ := ipfs.DecodeCID(c)
+			if codec != multicodec.DagCbor || !bytes.Contains(data, matcher) {
+				return eb, errSkipIndexing
+			}
+
+			v := &SpaceKey{}
+			if err := cbornode.DecodeInto(data, v); err != nil {
+				return eb, err
+			}
+
+			if err := Verify(v.Signer, v, v.Sig); err != nil {
+				return eb, err
+			}
+
+			eb.CID = c
+			eb.Data = data
+			eb.Decoded = v
+			return eb, nil
+		},
+		indexSpaceKey,
+	)
+}
+
+func indexSpaceKey(ictx *indexingCtx, _ int64, eb Encoded[*SpaceKey]) error {
+	c, v := eb.CID, eb.Decoded
+
+	if len(v.Keys) == 0 {
+		return fmt.Errorf("space key %s must have at least one recipient", c)
+	}
+
+	iri, err := NewIRI(v.Signer, "")
+	if err != nil {
+		return err
+	}
+
+	// Space keys are anchored to the root of the space, so they sync along with it.
+	// They are public, because the content key is only readable by the recipients anyway.
+	sb := newStructuralBlob(c, v.Type, v.Signer, v.Ts, iri, cid.Undef, v.Signer, time.Time{}, VisibilityPublic, nil)
+
+	if err := ictx.SaveBlob(sb); err != nil {
+		return err
+	}
+
+	// Encrypted blobs that arrived before their key were stashed waiting for it.
+	if err := reindexStashedBlobs(ictx.childOpts(), ictx.conn, stashReasonFailedPrecondition, c.String(), ictx.blockStore, ictx.log, ictx.writerCache, ictx.hookIDs); err != nil {
+		return err
+	}
+
+	// So were the ones we couldn't decrypt, because this key might be shared with us.
+	return reindexStashedBlobs(ictx.childOpts(), ictx.conn, stashReasonFailedPrecondition, v.Signer.String(), ictx.blockStore, ictx.log, ictx.writerCache, ictx.hookIDs)
+}
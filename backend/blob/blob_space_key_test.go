@@ -0,0 +1,277 @@
+package blob
+
+import (
+	"seed/backend/core"
+	"seed/backend/core/coretest"
+	"seed/backend/core/keystore"
+	"seed/backend/ipfs"
+	"seed/backend/storage"
+	"seed/backend/util/cclock"
+	"seed/backend/util/must"
+	"seed/backend/util/sqlite/sqlitex"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multicodec"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestEncryptedChange(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	bob := coretest.NewTester("bob").Account
+	carol := coretest.NewTester("carol").Account
+	clock := cclock.New()
+
+	key := ContentKey{Key: must.Do2(NewContentKey())}
+	sk, err := NewSpaceKey(alice, key.Key, nil, []core.Principal{bob.Principal()}, clock.MustNow())
+	require.NoError(t, err)
+	key.ID = sk.CID
+	require.Len(t, sk.Decoded.Keys, 2, "owner must always be a recipient")
+
+	change, err := NewEncryptedChange(alice, cid.Undef, nil, 0, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("title", "Secret plans"))},
+	}, key, clock.MustNow())
+	require.NoError(t, err)
+	require.NotContains(t, string(change.RawData()), "Secret plans")
+
+	ref, err := NewRef(alice, 0, change.CID, alice.Principal(), "/secret", []cid.Cid{change.CID}, clock.MustNow(), VisibilityPrivate)
+	require.NoError(t, err)
+
+	iri := must.Do2(NewIRI(alice.Principal(), "/secret"))
+
+	// Sharing the same key with a new member later makes the earlier content readable for them too.
+	reshared, err := NewSpaceKey(alice, key.Key, nil, []core.Principal{bob.Principal(), carol.Principal()}, clock.MustNow())
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name string
+		kp   *core.KeyPair
+	}{
+		{"member", bob},
+		{"new member", carol},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			db := storage.MakeTestDB(t)
+			idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+			require.NoError(t, err)
+
+			ks := keystore.NewMemory()
+			require.NoError(t, ks.StoreKey(t.Context(), "main", tt.kp))
+			idx.SetKeyStore(ks)
+
+			require.NoError(t, idx.PutMany(t.Context(), []blocks.Block{change, ref}))
+			require.Equal(t, 2, countStashedBlobs(t, db), "encrypted change must wait for its space key")
+
+			require.NoError(t, idx.Put(t.Context(), sk))
+			if tt.kp == carol {
+				require.Equal(t, 2, countStashedBlobs(t, db), "encrypted change must wait until we get its key")
+				require.NoError(t, idx.Put(t.Context(), reshared))
+			}
+			require.Equal(t, 0, countStashedBlobs(t, db), "space key must unstash the encrypted change")
+
+			changes, check := idx.IterChanges(t.Context(), iri, nil)
+			var got []ChangeRecord
+			for ch := range changes {
+				got = append(got, ch)
+			}
+			require.NoError(t, check())
+			require.Len(t, got, 1)
+
+			var title string
+			for op, err := range got[0].Data.Ops() {
+				require.NoError(t, err)
+				if op, ok := op.(OpSetKey); ok && op.Key == "title" {
+					title = op.Value.(string)
+				}
+			}
+			require.Equal(t, "Secret plans", title)
+		})
+	}
+}
+
+func TestEncryptedComment(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+	require.NoError(t, err)
+
+	ks := keystore.NewMemory()
+	require.NoError(t, ks.StoreKey(t.Context(), "main", alice))
+	idx.SetKeyStore(ks)
+
+	c := ipfs.MustNewCID(multicodec.Raw, multicodec.Identity, []byte("fake-version"))
+	clock := cclock.New()
+
+	key, err := idx.ShareSpaceKey(t.Context(), alice, false, clock.MustNow())
+	require.NoError(t, err)
+
+	body := []CommentBlock{{Block: Block{Type: "paragraph", Text: "Private remark"}}}
+	cmt, err := NewEncryptedComment(alice, "", alice.Principal(), "/private", []cid.Cid{c}, cid.Undef, cid.Undef, body, nil, key, clock.MustNow())
+	require.NoError(t, err)
+	require.NotContains(t, string(cmt.RawData()), "Private remark")
+	require.Equal(t, VisibilityPrivate, cmt.Decoded.Visibility)
+	require.NoError(t, idx.Put(t.Context(), cmt))
+
+	ftsHits, err := sqlitex.QueryOnePool[int](t.Context(), db, "SELECT count() FROM fts WHERE raw_content = 'Private remark'")
+	require.NoError(t, err)
+	require.Equal(t, 1, ftsHits, "decrypted comment must be searchable locally")
+
+	decoded := &Comment{}
+	require.NoError(t, cbornode.DecodeInto(cmt.RawData(), decoded))
+	require.Empty(t, decoded.Body)
+	require.False(t, decoded.IsTombstone(), "encrypted comments must not look deleted")
+
+	conn, release, err := db.ReadConn(t.Context())
+	require.NoError(t, err)
+	defer release()
+	require.NoError(t, idx.OpenComment(conn, decoded))
+	require.Equal(t, body[0].Text, decoded.Body[0].Text)
+
+	// Tombstones stay in plaintext.
+	tomb, err := NewEncryptedComment(alice, cmt.TSID(), alice.Principal(), "/private", []cid.Cid{c}, cid.Undef, cid.Undef, nil, nil, key, clock.MustNow())
+	require.NoError(t, err)
+	require.Nil(t, tomb.Decoded.Encrypted)
+	require.True(t, tomb.Decoded.IsTombstone())
+}
+
+func TestShareSpaceKeyRotation(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	bob := coretest.NewTester("bob").Account
+	carol := coretest.NewTester("carol").Account
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+	require.NoError(t, err)
+
+	ks := keystore.NewMemory()
+	require.NoError(t, ks.StoreKey(t.Context(), "main", alice))
+	idx.SetKeyStore(ks)
+
+	clock := cclock.New()
+
+	bobCap, err := NewCapability(alice, bob.Principal(), alice.Principal(), "", RoleReader, "", clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), bobCap))
+
+	first, err := idx.ShareSpaceKey(t.Context(), alice, false, clock.MustNow())
+	require.NoError(t, err)
+
+	// Sharing again keeps the key, and includes the new members.
+	carolCap, err := NewCapability(alice, carol.Principal(), alice.Principal(), "/doc", RoleCommenter, "", clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), carolCap))
+
+	second, err := idx.ShareSpaceKey(t.Context(), alice, false, clock.MustNow())
+	require.NoError(t, err)
+	require.Equal(t, first.Key, second.Key)
+	require.NotEqual(t, first.ID, second.ID)
+	require.ElementsMatch(t, []core.Principal{alice.Principal(), bob.Principal(), carol.Principal()}, spaceKeyRecipients(t, idx, second.ID))
+
+	// Rotating after a revocation generates a fresh key without the revoked member.
+	rv, err := NewRevocation(alice, bobCap.CID, bobCap.Decoded, clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), rv))
+
+	third, err := idx.ShareSpaceKey(t.Context(), alice, true, clock.MustNow())
+	require.NoError(t, err)
+	require.NotEqual(t, second.Key, third.Key)
+	require.ElementsMatch(t, []core.Principal{alice.Principal(), carol.Principal()}, spaceKeyRecipients(t, idx, third.ID))
+
+	current, ok, err := idx.SpaceContentKey(t.Context(), alice.Principal())
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, third, current)
+}
+
+func spaceKeyRecipients(t *testing.T, idx *Index, c cid.Cid) []core.Principal {
+	blk, err := idx.Get(t.Context(), c)
+	require.NoError(t, err)
+	sk := &SpaceKey{}
+	require.NoError(t, cbornode.DecodeInto(blk.RawData(), sk))
+	return sk.Recipients()
+}
+
+func TestSpaceKeyHistory(t *testing.T) {
+	alice := coretest.NewTester("alice").Account
+	bob := coretest.NewTester("bob").Account
+	carol := coretest.NewTester("carol").Account
+	clock := cclock.New()
+
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(t.Context(), db, zap.NewNop())
+	require.NoError(t, err)
+
+	ks := keystore.NewMemory()
+	require.NoError(t, ks.StoreKey(t.Context(), "main", alice))
+	idx.SetKeyStore(ks)
+
+	bobCap, err := NewCapability(alice, bob.Principal(), alice.Principal(), "", RoleReader, "", clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), bobCap))
+
+	first, err := idx.ShareSpaceKey(t.Context(), alice, false, clock.MustNow())
+	require.NoError(t, err)
+
+	change, err := NewEncryptedChange(alice, cid.Undef, nil, 0, ChangeBody{
+		Ops: []OpMap{must.Do2(NewOpSetKey("title", "Before the rotation"))},
+	}, first, clock.MustNow())
+	require.NoError(t, err)
+	ref, err := NewRef(alice, 0, change.CID, alice.Principal(), "/secret", []cid.Cid{change.CID}, clock.MustNow(), VisibilityPrivate)
+	require.NoError(t, err)
+	require.NoError(t, idx.PutMany(t.Context(), []blocks.Block{change, ref}))
+
+	// Bob loses access, and the key is rotated. Carol is only added after that.
+	rv, err := NewRevocation(alice, bobCap.CID, bobCap.Decoded, clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), rv))
+	rotated, err := idx.ShareSpaceKey(t.Context(), alice, true, clock.MustNow())
+	require.NoError(t, err)
+	require.NotEqual(t, first.Key, rotated.Key)
+
+	carolCap, err := NewCapability(alice, carol.Principal(), alice.Principal(), "", RoleReader, "", clock.MustNow())
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(t.Context(), carolCap))
+	shared, err := idx.ShareSpaceKey(t.Context(), alice, false, clock.MustNow())
+	require.NoError(t, err)
+	require.Equal(t, rotated.Key, shared.Key)
+
+	spaceKeys := func() (out []blocks.Block) {
+		for _, c := range []cid.Cid{first.ID, rotated.ID, shared.ID} {
+			out = append(out, must.Do2(idx.Get(t.Context(), c)))
+		}
+		return out
+	}()
+
+	// Carol syncs the space from scratch.
+	carolDB := storage.MakeTestDB(t)
+	carolIdx, err := OpenIndex(t.Context(), carolDB, zap.NewNop())
+	require.NoError(t, err)
+	carolKeys := keystore.NewMemory()
+	require.NoError(t, carolKeys.StoreKey(t.Context(), "main", carol))
+	carolIdx.SetKeyStore(carolKeys)
+
+	require.NoError(t, carolIdx.PutMany(t.Context(), append(spaceKeys, change, ref)))
+	require.Equal(t, 0, countStashedBlobs(t, carolDB), "members added after a rotation must read the content encrypted before it")
+
+	changes, check := carolIdx.IterChanges(t.Context(), must.Do2(NewIRI(alice.Principal(), "/secret")), nil)
+	var title string
+	for ch := range changes {
+		for op, err := range ch.Data.Ops() {
+			require.NoError(t, err)
+			if op, ok := op.(OpSetKey); ok && op.Key == "title" {
+				title = op.Value.(string)
+			}
+		}
+	}
+	require.NoError(t, check())
+	require.Equal(t, "Before the rotation", title)
+
+	// Bob was cut off before the rotation, so he doesn't get the history.
+	sk := &SpaceKey{}
+	require.NoError(t, cbornode.DecodeInto(spaceKeys[2].RawData(), sk))
+	for _, hk := range sk.History {
+		require.False(t, hk.Recipient.Equal(bob.Principal()))
+	}
+}
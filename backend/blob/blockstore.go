@@ -104,6 +104,9 @@ type blockStore struct {
 	decoder *zstd.Decoder
 	log     *zap.Logger
 
+	// keys unwraps the content keys for decrypting private blobs.
+	keys spaceKeyring
+
 	// existsSampleCount caps how many `exists` putBlock outcomes get logged
 	// at info level with the CID + multihash. Lets a session capture ~30
 	// concrete examples of redundant fetches; after that, the counter
@@ -710,7 +713,7 @@ var qBlobCanCallerAccess = dqb.Str(`
 	FROM blob_visibility bv
 	WHERE bv.id = ?2
 	AND bv.space != 0
-	AND ` + SQLCanWriteRootByOwnerID("bv.space") + `
+	AND ` + SQLCanReadRootByOwnerID("bv.space", SQLNowMillis) + `
 	LIMIT 1
 `)
 
@@ -0,0 +1,301 @@
+package blob
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"seed/backend/util/unsafeutil"
+	"slices"
+
+	"github.com/ipfs/go-cid"
+	"go.uber.org/zap"
+)
+
+// CommentAnchorStatus describes what happened to the content a comment is anchored to,
+// since the version of the document the comment was made against.
+type CommentAnchorStatus string
+
+// Statuses of comment anchors.
+const (
+	// CommentAnchorValid means the anchored block and text are unchanged.
+	CommentAnchorValid CommentAnchorStatus = "valid"
+	// CommentAnchorMoved means the anchored content still exists, but the block was moved, or the text was edited.
+	CommentAnchorMoved CommentAnchorStatus = "moved"
+	// CommentAnchorOrphaned means the anchored block, or all of the anchored text, was deleted.
+	CommentAnchorOrphaned CommentAnchorStatus = "orphaned"
+)
+
+// CommentAnchorTarget is a comment anchor along with the version of the document it was made against.
+type CommentAnchorTarget struct {
+	Version []cid.Cid
+	Anchor  CommentAnchor
+}
+
+// CommentAnchorPosition is the position of a comment anchor in some version of the document.
+// Empty status means the anchor couldn't be resolved, e.g. because its version is not among the changes.
+type CommentAnchorPosition struct {
+	Status CommentAnchorStatus
+	Start  int
+	End    int
+}
+
+// ResolveCommentAnchors resolves the positions of the comment anchors
+// in the version of a document made of the given changes, in the same order as the anchors.
+type ResolveCommentAnchors func(iri IRI, changes []ChangeRecord, anchors []CommentAnchorTarget) ([]CommentAnchorPosition, error)
+
+// SetResolveCommentAnchors installs the comment anchor resolver used during indexing.
+// Like SetDeriveFirstContentImage it's implemented by the documents API, which owns the document model.
+func (idx *Index) SetResolveCommentAnchors(fn ResolveCommentAnchors) {
+	idx.hookMu.Lock()
+	idx.resolveCommentAnchors = fn
+	idx.hookMu.Unlock()
+}
+
+// commentAnchorResolver returns the installed comment anchor resolver (nil if none).
+func (idx *Index) commentAnchorResolver() ResolveCommentAnchors {
+	idx.hookMu.RLock()
+	defer idx.hookMu.RUnlock()
+	return idx.resolveCommentAnchors
+}
+
+// indexCommentAnchor records the anchor of the comment.
+// The anchor is resolved later by the indexed hook worker, outside of the indexing transaction,
+// because that needs to replay the entire document.
+func indexCommentAnchor(ictx *indexingCtx, id int64, iri IRI, v *Comment) error {
+	a := v.Anchor
+	return sqlitex.Exec(ictx.conn, qInsertCommentAnchor(), nil, id, string(iri), NewVersion(v.Version...).String(), a.Block, a.Start, a.End)
+}
+
+// commentAnchorUpdate is the resolved position of a comment anchor, to be saved in the database.
+type commentAnchorUpdate struct {
+	Comment  int64
+	Position CommentAnchorPosition
+	Version  string
+}
+
+// computeCommentAnchors resolves the positions of the comment anchors on the document
+// in the version of the given generation. If comment is not zero, only the anchor of that comment is resolved.
+// Anchors that were already resolved against the same version are skipped. It only reads from the database.
+//
+// Best-effort, like the derivation of the cover image: failures are logged, and the anchors are left as they were.
+func computeCommentAnchors(conn *sqlite.Conn, bs *blockStore, log *zap.Logger, resolve ResolveCommentAnchors, iri IRI, dg documentGeneration, comment int64) (out []commentAnchorUpdate, err error) {
+	headIDs := slices.Collect(maps.Keys(dg.Heads))
+	heads := make([]cid.Cid, len(headIDs))
+	lookup := NewLookupCache(conn)
+	for i, id := range headIDs {
+		heads[i], err = lookup.CID(id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	version := NewVersion(heads...).String()
+
+	var (
+		ids     []int64
+		targets []CommentAnchorTarget
+	)
+	rows, discard, check := sqlitex.Query(conn, qListCommentAnchors(), string(iri), comment).All()
+	defer discard(&err)
+	for row := range rows {
+		if row.ColumnText(5) == version {
+			continue
+		}
+
+		target, err := Version(row.ColumnText(1)).Parse()
+		if err != nil {
+			continue
+		}
+
+		ids = append(ids, row.ColumnInt64(0))
+		targets = append(targets, CommentAnchorTarget{
+			Version: target,
+			Anchor: CommentAnchor{
+				Block: row.ColumnText(2),
+				Start: row.ColumnInt(3),
+				End:   row.ColumnInt(4),
+			},
+		})
+	}
+	if err := check(); err != nil {
+		return nil, err
+	}
+
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	changes, err := changesFromHeadIDsConn(conn, bs, headIDs, dg.Generation)
+	if err != nil {
+		log.Warn("FailedToLoadChangesForCommentAnchors", zap.String("iri", string(iri)), zap.Error(err))
+		return nil, nil
+	}
+
+	positions, err := resolve(iri, changes, targets)
+	if err != nil {
+		log.Warn("FailedToResolveCommentAnchors", zap.String("iri", string(iri)), zap.Error(err))
+		return nil, nil
+	}
+
+	for i, pos := range positions {
+		if pos.Status == "" {
+			continue
+		}
+
+		out = append(out, commentAnchorUpdate{Comment: ids[i], Position: pos, Version: version})
+	}
+
+	return out, nil
+}
+
+func saveCommentAnchors(conn *sqlite.Conn, updates []commentAnchorUpdate) error {
+	for _, u := range updates {
+		if err := sqlitex.Exec(conn, qUpdateCommentAnchor(), nil, string(u.Position.Status), u.Position.Start, u.Position.End, u.Version, u.Comment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// computeLatestCommentAnchors is like computeCommentAnchors, but against the latest generation of the document.
+// Redirected and deleted documents keep their anchors unresolved.
+func (idx *Index) computeLatestCommentAnchors(conn *sqlite.Conn, resolve ResolveCommentAnchors, iri IRI, comment int64) ([]commentAnchorUpdate, error) {
+	dg, found, err := idx.resolveLatestGeneration(conn, iri)
+	if err != nil || !found || len(dg.Heads) == 0 {
+		return nil, nil //nolint:nilerr // Redirects and tombstones are reported as errors, which are expected here.
+	}
+
+	return computeCommentAnchors(conn, idx.bs, idx.log, resolve, iri, dg, comment)
+}
+
+// resolveAllCommentAnchors resolves all the comment anchors against the latest generations of their documents.
+// It's the full-reindex counterpart of resolving the anchors in the indexed hook worker, for the same reasons as deriveFirstContentImages.
+func (idx *Index) resolveAllCommentAnchors(conn *sqlite.Conn, resolve ResolveCommentAnchors) error {
+	if resolve == nil {
+		return nil
+	}
+
+	// Collect the IRIs before writing anything to the same table.
+	var iris []string
+	if err := sqlitex.Exec(conn, qListCommentAnchorIRIs(), func(stmt *sqlite.Stmt) error {
+		iris = append(iris, stmt.ColumnText(0))
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return idx.resolveDocumentCommentAnchors(conn, resolve, iris)
+}
+
+// resolveDocumentCommentAnchors resolves all the comment anchors on the given documents within the same connection.
+func (idx *Index) resolveDocumentCommentAnchors(conn *sqlite.Conn, resolve ResolveCommentAnchors, iris []string) error {
+	for _, iri := range iris {
+		updates, err := idx.computeLatestCommentAnchors(conn, resolve, IRI(iri), 0)
+		if err != nil {
+			return err
+		}
+
+		if err := saveCommentAnchors(conn, updates); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveCommentAnchorsAfter resolves the anchors of the newly indexed comments,
+// and all the anchors on the documents whose Refs were indexed, against the latest versions of the documents.
+// It runs in the indexed hook worker: the documents are replayed with a read connection,
+// and only the results are written, so indexing is never blocked by the replays.
+func (idx *Index) resolveCommentAnchorsAfter(ids []int64) error {
+	resolve := idx.commentAnchorResolver()
+	if resolve == nil {
+		return nil
+	}
+
+	idsJSON, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+
+	var updates []commentAnchorUpdate
+	if err := idx.db.WithSave(context.Background(), func(conn *sqlite.Conn) error {
+		// Zero means all the anchors on the document.
+		targets := make(map[IRI][]int64)
+		if err := sqlitex.Exec(conn, qListIndexedCommentAnchorTargets(), func(stmt *sqlite.Stmt) error {
+			iri, comment := IRI(stmt.ColumnText(0)), stmt.ColumnInt64(1)
+			if !slices.Contains(targets[iri], 0) {
+				targets[iri] = append(targets[iri], comment)
+			}
+			return nil
+		}, unsafeutil.StringFromBytes(idsJSON)); err != nil {
+			return err
+		}
+
+		for iri, comments := range targets {
+			if slices.Contains(comments, 0) {
+				comments = []int64{0}
+			}
+
+			for _, comment := range comments {
+				u, err := idx.computeLatestCommentAnchors(conn, resolve, iri, comment)
+				if err != nil {
+					return err
+				}
+				updates = append(updates, u...)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	return idx.db.WithTx(context.Background(), func(conn *sqlite.Conn) error {
+		return saveCommentAnchors(conn, updates)
+	})
+}
+
+// Refs come last, so the anchors of the whole document supersede the ones of single comments.
+var qListIndexedCommentAnchorTargets = dqb.Str(`
+	SELECT iri, comment
+	FROM comment_anchors
+	WHERE comment IN (SELECT value FROM json_each(:ids))
+	UNION ALL
+	SELECT DISTINCT r.iri, 0
+	FROM structural_blobs sb
+	JOIN resources r ON r.id = sb.resource
+	WHERE sb.id IN (SELECT value FROM json_each(:ids))
+	AND sb.type = 'Ref'
+	AND sb.extra_attrs->>'branch' IS NULL
+	AND EXISTS (SELECT 1 FROM comment_anchors ca WHERE ca.iri = r.iri)
+`)
+
+var qInsertCommentAnchor = dqb.Str(`
+	INSERT OR REPLACE INTO comment_anchors (comment, iri, version, block, range_start, range_end)
+	VALUES (?, ?, ?, ?, ?, ?)
+`)
+
+var qListCommentAnchors = dqb.Str(`
+	SELECT comment, version, block, range_start, range_end, resolved_version
+	FROM comment_anchors
+	WHERE iri = :iri
+	AND (:comment = 0 OR comment = :comment)
+`)
+
+var qListCommentAnchorIRIs = dqb.Str(`
+	SELECT DISTINCT iri FROM comment_anchors
+`)
+
+var qUpdateCommentAnchor = dqb.Str(`
+	UPDATE comment_anchors
+	SET status = ?, resolved_start = ?, resolved_end = ?, resolved_version = ?
+	WHERE comment = ?
+`)
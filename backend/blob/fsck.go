@@ -0,0 +1,428 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"slices"
+
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multicodec"
+	"go.uber.org/zap"
+)
+
+// StoreProblemKind is the kind of inconsistency found by VerifyStore.
+type StoreProblemKind string
+
+// Kinds of problems found by VerifyStore.
+const (
+	// StoreProblemCorruptData means the stored data of the blob doesn't match its CID,
+	// or can't be decompressed.
+	StoreProblemCorruptData StoreProblemKind = "CorruptData"
+	// StoreProblemInvalidBlob means the data matches the CID, but the blob fails to decode,
+	// or its signature is invalid.
+	StoreProblemInvalidBlob StoreProblemKind = "InvalidBlob"
+	// StoreProblemDanglingLink means an indexed blob links to a blob we don't have.
+	StoreProblemDanglingLink StoreProblemKind = "DanglingLink"
+	// StoreProblemMissingChangeDep means a Change is indexed while one of its deps is missing or not indexed.
+	StoreProblemMissingChangeDep StoreProblemKind = "MissingChangeDep"
+	// StoreProblemOrphanedStructuralBlob means a structural blob is indexed
+	// while its data, its genesis blob, or its resource is missing.
+	StoreProblemOrphanedStructuralBlob StoreProblemKind = "OrphanedStructuralBlob"
+)
+
+// StoreProblem is an inconsistency found in the blob store or in the index.
+type StoreProblem struct {
+	Kind StoreProblemKind
+	CID  cid.Cid
+	// Target is the linked blob for link problems.
+	Target cid.Cid
+	// Resource affected by the problem, if known.
+	Resource IRI
+	Details  string
+}
+
+// StoreReport is the result of VerifyStore.
+type StoreReport struct {
+	BlobsChecked int64
+	Problems     []StoreProblem
+	// StashedBlobs counts the stashed blobs by reason.
+	StashedBlobs map[string]int64
+	// ReindexedResources are the resources reindexed by the repair.
+	ReindexedResources []IRI
+}
+
+// VerifyOptions configures VerifyStore.
+type VerifyOptions struct {
+	// Repair reindexes the resources affected by the problems,
+	// and marks the corrupted blobs as missing, so they can be fetched again from the network.
+	Repair bool
+
+	// Progress, if set, is called periodically with the number of checked blobs.
+	Progress func(checked, total int64)
+}
+
+// VerifyStore checks the integrity of the blob store and of the index:
+// every blob is hashed again and checked against its CID, the known blob types are decoded and their signatures verified,
+// and the index is checked for links to missing blobs and structural blobs without their data or resources.
+func (idx *Index) VerifyStore(ctx context.Context, opts VerifyOptions) (report StoreReport, err error) {
+	resources := map[int64]IRI{}
+	var corrupted []int64
+
+	if err := idx.db.WithSave(ctx, func(conn *sqlite.Conn) error {
+		corrupted, err = idx.verifyBlobs(ctx, conn, opts.Progress, &report, resources)
+		if err != nil {
+			return err
+		}
+
+		if err := verifyIndexLinks(conn, &report, resources); err != nil {
+			return err
+		}
+
+		if err := verifyStructuralBlobs(conn, &report, resources); err != nil {
+			return err
+		}
+
+		report.StashedBlobs = map[string]int64{}
+		return sqlitex.Exec(conn, qCountStashedBlobs(), func(stmt *sqlite.Stmt) error {
+			report.StashedBlobs[stmt.ColumnText(0)] = stmt.ColumnInt64(1)
+			return nil
+		})
+	}); err != nil {
+		return report, err
+	}
+
+	if !opts.Repair || (len(resources) == 0 && len(corrupted) == 0) {
+		return report, nil
+	}
+
+	conn, release, err := idx.db.WriteConn(ctx)
+	if err != nil {
+		return report, err
+	}
+	defer release()
+
+	ids := make([]int64, 0, len(resources))
+	for id := range resources {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	if err := sqlitex.WithTx(conn, func() error {
+		for _, id := range corrupted {
+			if err := sqlitex.Exec(conn, qMarkBlobMissing(), nil, id); err != nil {
+				return err
+			}
+		}
+
+		_, err := idx.reindexResources(conn, ids, corrupted, nil)
+		return err
+	}); err != nil {
+		return report, fmt.Errorf("failed to repair the index: %w", err)
+	}
+
+	for _, id := range ids {
+		report.ReindexedResources = append(report.ReindexedResources, resources[id])
+	}
+
+	idx.log.Info("StoreRepaired",
+		zap.Int("problems", len(report.Problems)),
+		zap.Int("corruptedBlobs", len(corrupted)),
+		zap.Int("reindexedResources", len(ids)),
+	)
+
+	return report, nil
+}
+
+// verifyBlobs checks the data of every blob we have, and returns the IDs of the corrupted ones.
+func (idx *Index) verifyBlobs(ctx context.Context, conn *sqlite.Conn, progress func(checked, total int64), report *StoreReport, resources map[int64]IRI) (corrupted []int64, err error) {
+	total, err := sqlitex.QueryOne[int64](conn, "SELECT count() FROM blobs WHERE size > 0")
+	if err != nil {
+		return nil, err
+	}
+
+	if progress != nil {
+		progress(0, total)
+	}
+
+	type badBlob struct {
+		id      int64
+		problem StoreProblem
+	}
+	var bad []badBlob
+
+	if err := sqlitex.ExecTransient(conn, qLoadBlobsToVerify(), func(stmt *sqlite.Stmt) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		inc := sqlite.NewIncrementor(0)
+		var (
+			id      = stmt.ColumnInt64(inc())
+			codec   = stmt.ColumnInt64(inc())
+			hash    = stmt.ColumnBytes(inc())
+			size    = stmt.ColumnInt(inc())
+			rawData = stmt.ColumnBytesUnsafe(inc())
+		)
+
+		report.BlobsChecked++
+		// The reporting batch size is a bit arbitrary.
+		const reportBatchSize = 100
+		if progress != nil && report.BlobsChecked%reportBatchSize == 0 {
+			progress(report.BlobsChecked, total)
+		}
+
+		c := cid.NewCidV1(uint64(codec), hash)
+		data, err := idx.bs.decompress(rawData, size)
+		if err != nil {
+			bad = append(bad, badBlob{id, StoreProblem{Kind: StoreProblemCorruptData, CID: c, Details: err.Error()}})
+			return nil
+		}
+
+		if len(data) != size {
+			bad = append(bad, badBlob{id, StoreProblem{Kind: StoreProblemCorruptData, CID: c, Details: fmt.Sprintf("size mismatch: expected %d bytes, got %d", size, len(data))}})
+			return nil
+		}
+
+		sum, err := c.Prefix().Sum(data)
+		if err != nil {
+			return fmt.Errorf("failed to hash blob %s: %w", c, err)
+		}
+
+		if !bytes.Equal(sum.Hash(), c.Hash()) {
+			bad = append(bad, badBlob{id, StoreProblem{Kind: StoreProblemCorruptData, CID: c, Details: "data doesn't match the hash"}})
+			return nil
+		}
+
+		if codec != int64(multicodec.DagCbor) {
+			return nil
+		}
+
+		if err := verifyBlob(c, data); err != nil {
+			bad = append(bad, badBlob{id, StoreProblem{Kind: StoreProblemInvalidBlob, CID: c, Details: err.Error()}})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if progress != nil {
+		progress(report.BlobsChecked, total)
+	}
+
+	for _, b := range bad {
+		if b.problem.Kind == StoreProblemCorruptData {
+			corrupted = append(corrupted, b.id)
+		}
+
+		b.problem.Resource, err = loadBlobResources(conn, b.id, resources)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Problems = append(report.Problems, b.problem)
+	}
+
+	return corrupted, nil
+}
+
+var qLoadBlobsToVerify = dqb.Str(`
+	SELECT id, codec, multihash, size, data
+	FROM blobs
+	WHERE size > 0
+	ORDER BY id
+`)
+
+// verifyIndexLinks finds the links of the indexed blobs pointing to blobs we don't have.
+func verifyIndexLinks(conn *sqlite.Conn, report *StoreReport, resources map[int64]IRI) error {
+	type link struct {
+		source int64
+		p      StoreProblem
+	}
+	var links []link
+
+	if err := sqlitex.Exec(conn, qListDanglingLinks(), func(stmt *sqlite.Stmt) error {
+		inc := sqlite.NewIncrementor(0)
+		var (
+			source       = stmt.ColumnInt64(inc())
+			sourceCodec  = stmt.ColumnInt64(inc())
+			sourceHash   = stmt.ColumnBytes(inc())
+			targetCodec  = stmt.ColumnInt64(inc())
+			targetHash   = stmt.ColumnBytes(inc())
+			linkType     = stmt.ColumnText(inc())
+			targetMissed = stmt.ColumnInt(inc()) != 0
+		)
+
+		p := StoreProblem{
+			Kind:    StoreProblemDanglingLink,
+			CID:     cid.NewCidV1(uint64(sourceCodec), sourceHash),
+			Target:  cid.NewCidV1(uint64(targetCodec), targetHash),
+			Details: linkType,
+		}
+
+		if linkType == "change/dep" {
+			p.Kind = StoreProblemMissingChangeDep
+			if !targetMissed {
+				p.Details = "dependency is not indexed"
+			}
+		}
+
+		links = append(links, link{source: source, p: p})
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, l := range links {
+		// Dangling links are normal for some links, like replies to comments we haven't received.
+		// Only the missing deps of Changes mean the index is broken, so only those need a repair.
+		collect := resources
+		if l.p.Kind == StoreProblemDanglingLink {
+			collect = nil
+		}
+
+		var err error
+		l.p.Resource, err = loadBlobResources(conn, l.source, collect)
+		if err != nil {
+			return err
+		}
+
+		report.Problems = append(report.Problems, l.p)
+	}
+
+	return nil
+}
+
+var qListDanglingLinks = dqb.Str(`
+	SELECT
+		bl.source,
+		s.codec,
+		s.multihash,
+		t.codec,
+		t.multihash,
+		bl.type,
+		t.size < 0 AS target_missing
+	FROM blob_links bl
+	JOIN blobs s ON s.id = bl.source
+	JOIN blobs t ON t.id = bl.target
+	WHERE t.size < 0
+	OR (bl.type = 'change/dep' AND NOT EXISTS (SELECT 1 FROM structural_blobs sb WHERE sb.id = bl.target))
+	ORDER BY bl.source, bl.type, bl.target
+`)
+
+// verifyStructuralBlobs finds the indexed blobs whose data, genesis, or resource is missing.
+func verifyStructuralBlobs(conn *sqlite.Conn, report *StoreReport, resources map[int64]IRI) error {
+	type orphan struct {
+		id int64
+		p  StoreProblem
+	}
+	var orphans []orphan
+
+	if err := sqlitex.Exec(conn, qListOrphanedStructuralBlobs(), func(stmt *sqlite.Stmt) error {
+		inc := sqlite.NewIncrementor(0)
+		var (
+			id      = stmt.ColumnInt64(inc())
+			codec   = stmt.ColumnInt64(inc())
+			hash    = stmt.ColumnBytes(inc())
+			btype   = stmt.ColumnText(inc())
+			details = stmt.ColumnText(inc())
+		)
+
+		orphans = append(orphans, orphan{id: id, p: StoreProblem{
+			Kind:    StoreProblemOrphanedStructuralBlob,
+			CID:     cid.NewCidV1(uint64(codec), hash),
+			Details: btype + ": " + details,
+		}})
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, o := range orphans {
+		var err error
+		o.p.Resource, err = loadBlobResources(conn, o.id, resources)
+		if err != nil {
+			return err
+		}
+
+		report.Problems = append(report.Problems, o.p)
+	}
+
+	return nil
+}
+
+var qListOrphanedStructuralBlobs = dqb.Str(`
+	SELECT
+		sb.id,
+		b.codec,
+		b.multihash,
+		sb.type,
+		CASE
+			WHEN b.size < 0 THEN 'data is missing'
+			WHEN g.size < 0 THEN 'genesis blob is missing'
+			ELSE 'resource is missing'
+		END
+	FROM structural_blobs sb
+	JOIN blobs b ON b.id = sb.id
+	LEFT JOIN blobs g ON g.id = sb.genesis_blob
+	WHERE b.size < 0
+	OR g.size < 0
+	OR (sb.resource IS NOT NULL AND NOT EXISTS (SELECT 1 FROM resources r WHERE r.id = sb.resource))
+	ORDER BY sb.id
+`)
+
+// loadBlobResources finds the resources the blob belongs to, and collects them into the map, if it's not nil.
+// It returns the first resource, for reporting.
+func loadBlobResources(conn *sqlite.Conn, blobID int64, collect map[int64]IRI) (first IRI, err error) {
+	if err := sqlitex.Exec(conn, qLoadBlobResources(), func(stmt *sqlite.Stmt) error {
+		id, iri := stmt.ColumnInt64(0), IRI(stmt.ColumnText(1))
+		if first == "" {
+			first = iri
+		}
+		if collect != nil {
+			collect[id] = iri
+		}
+		return nil
+	}, blobID); err != nil {
+		return "", err
+	}
+
+	return first, nil
+}
+
+// Changes don't have a resource in the structural blobs,
+// so we find the documents by their genesis blob.
+var qLoadBlobResources = dqb.Str(`
+	SELECT r.id, r.iri
+	FROM structural_blobs sb
+	JOIN resources r ON r.id = sb.resource
+	WHERE sb.id = :blob
+	UNION
+	SELECT r.id, r.iri
+	FROM structural_blobs sb
+	JOIN resources r ON r.genesis_blob = sb.genesis_blob
+	WHERE sb.id = :blob
+	UNION
+	SELECT r.id, r.iri
+	FROM resources r
+	WHERE r.genesis_blob = :blob
+	ORDER BY r.iri
+`)
+
+var qCountStashedBlobs = dqb.Str(`
+	SELECT reason, count(DISTINCT id)
+	FROM stashed_blobs
+	GROUP BY reason
+	ORDER BY reason
+`)
+
+// Corrupted data can't be recovered locally, but marking the blob as missing
+// makes it eligible to be fetched again from the peers.
+var qMarkBlobMissing = dqb.Str(`
+	UPDATE blobs SET size = -1, data = NULL
+	WHERE id = :id
+`)
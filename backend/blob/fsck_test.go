@@ -0,0 +1,99 @@
+package blob
+
+import (
+	"seed/backend/core/coretest"
+	"seed/backend/storage"
+	"seed/backend/util/cclock"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestVerifyStore(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	clock := cclock.New()
+	ctx := t.Context()
+
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(ctx, db, zap.NewNop())
+	require.NoError(t, err)
+
+	space := alice.Account.Principal()
+	genesis, err := NewChange(alice.Account, cid.Undef, nil, 0, ChangeBody{}, clock.MustNow())
+	require.NoError(t, err)
+	change, err := NewChange(alice.Account, genesis.CID, []cid.Cid{genesis.CID}, 1, ChangeBody{
+		Ops: []OpMap{NewOpSetAttributes("", []KeyValue{{Key: []string{"title"}, Value: "Hello"}})},
+	}, clock.MustNow())
+	require.NoError(t, err)
+	ts := clock.MustNow()
+	ref, err := NewRef(alice.Account, ts.UnixMilli(), genesis.CID, space, "/doc", []cid.Cid{change.CID}, ts, VisibilityPublic)
+	require.NoError(t, err)
+	comment, err := NewComment(alice.Account, "", space, "/doc", []cid.Cid{change.CID}, cid.Undef, cid.Undef, []CommentBlock{
+		{Block: Block{Type: "paragraph", Text: "Hello"}},
+	}, nil, VisibilityPublic, clock.MustNow())
+	require.NoError(t, err)
+
+	require.NoError(t, idx.PutMany(ctx, []blocks.Block{genesis, change, ref, comment}))
+
+	commentCount := func() int64 {
+		t.Helper()
+		n, err := sqlitex.QueryOnePool[int64](ctx, db, "SELECT comment_count FROM spaces WHERE id = ?", space.String())
+		require.NoError(t, err)
+		return n
+	}
+	require.Equal(t, int64(1), commentCount())
+
+	var lastProgress [2]int64
+	report, err := idx.VerifyStore(ctx, VerifyOptions{Progress: func(checked, total int64) {
+		lastProgress = [2]int64{checked, total}
+	}})
+	require.NoError(t, err)
+	require.Empty(t, report.Problems, "healthy store must have no problems")
+	require.Equal(t, report.BlobsChecked, lastProgress[0])
+	require.Equal(t, report.BlobsChecked, lastProgress[1])
+
+	// Replace the data of the change with the data of the genesis change.
+	require.NoError(t, db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, "UPDATE blobs SET (data, size) = (SELECT data, size FROM blobs WHERE id = :src) WHERE id = :dst", nil,
+			blobIDForCID(t, db, genesis.CID), blobIDForCID(t, db, change.CID))
+	}))
+
+	report, err = idx.VerifyStore(ctx, VerifyOptions{})
+	require.NoError(t, err)
+	require.Len(t, report.Problems, 1)
+	require.Equal(t, StoreProblemCorruptData, report.Problems[0].Kind)
+	require.Equal(t, change.CID, report.Problems[0].CID)
+	require.Equal(t, IRI("hm://"+space.String()+"/doc"), report.Problems[0].Resource)
+	require.Empty(t, report.ReindexedResources, "must not repair without the flag")
+
+	report, err = idx.VerifyStore(ctx, VerifyOptions{Repair: true})
+	require.NoError(t, err)
+	require.Equal(t, []IRI{IRI("hm://" + space.String() + "/doc")}, report.ReindexedResources)
+
+	has, err := idx.Has(ctx, change.CID)
+	require.NoError(t, err)
+	require.False(t, has, "corrupted blob must be marked as missing")
+	require.Equal(t, 1, countStashedBlobs(t, db), "ref must wait for the missing change")
+
+	report, err = idx.VerifyStore(ctx, VerifyOptions{})
+	require.NoError(t, err)
+	require.Len(t, report.Problems, 1, "only the comment must point to the missing change")
+	require.Equal(t, StoreProblemDanglingLink, report.Problems[0].Kind)
+	require.Equal(t, comment.CID, report.Problems[0].CID)
+	require.Equal(t, change.CID, report.Problems[0].Target)
+	require.Len(t, report.StashedBlobs, 1)
+
+	// Once the change is received again, the document is back to normal.
+	require.NoError(t, idx.Put(ctx, change))
+	require.Equal(t, 0, countStashedBlobs(t, db))
+	require.Equal(t, int64(1), commentCount(), "comment stats must not be counted twice")
+
+	report, err = idx.VerifyStore(ctx, VerifyOptions{})
+	require.NoError(t, err)
+	require.Empty(t, report.Problems)
+}
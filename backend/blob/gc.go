@@ -0,0 +1,599 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"seed/backend/core"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"go.uber.org/zap"
+)
+
+// GCRoot is a resource whose related blobs must be kept by the garbage collector.
+type GCRoot struct {
+	IRI       IRI
+	Recursive bool
+}
+
+// CollectRelatedBlobs returns the IDs of the blobs related to the given roots,
+// i.e. the blobs we would sync for them. It's implemented by the syncing package,
+// and injected via SetCollectRelatedBlobs to avoid an import cycle.
+type CollectRelatedBlobs func(conn *sqlite.Conn, roots []GCRoot) ([]int64, error)
+
+// SetCollectRelatedBlobs installs the function used by the garbage collector to find the reachable blobs.
+func (idx *Index) SetCollectRelatedBlobs(fn CollectRelatedBlobs) {
+	idx.hookMu.Lock()
+	idx.collectRelatedBlobs = fn
+	idx.hookMu.Unlock()
+}
+
+func (idx *Index) relatedBlobsCollector() CollectRelatedBlobs {
+	idx.hookMu.RLock()
+	defer idx.hookMu.RUnlock()
+	return idx.collectRelatedBlobs
+}
+
+// GCOptions configures CollectGarbage.
+type GCOptions struct {
+	// Accounts are the local keys. Their spaces, the spaces they are members of,
+	// and the blobs they authored are always kept.
+	Accounts []core.Principal
+
+	// Space, if set, limits the collection to the blobs related to the given space.
+	Space core.Principal
+
+	// GracePeriod protects the blobs received recently from being collected,
+	// e.g. the media files of the drafts that are not published yet.
+	// It's also the time after which the tombstones of the collected blobs expire.
+	GracePeriod time.Duration
+
+	// DryRun only reports what would be collected, without changing anything.
+	DryRun bool
+}
+
+// GCReport is the result of CollectGarbage. In dry-run mode the pruned fields
+// describe the blobs that would be collected.
+type GCReport struct {
+	Roots int
+
+	// Size of the blob store before the collection. Stored bytes are the compressed size on disk.
+	BlobsTotal       int64
+	BytesTotal       int64
+	StoredBytesTotal int64
+
+	BlobsPruned       int64
+	BytesPruned       int64
+	StoredBytesPruned int64
+
+	TombstonesExpired  int64
+	ReindexedResources int
+}
+
+// CollectGarbage removes the data of the blobs that are not reachable from the local keys' spaces,
+// the subscriptions, and the pinned resources. Reachability follows the same rules syncing uses
+// to find the related material of a resource, and then the links between the blobs.
+//
+// The collected blobs are marked as missing, and a tombstone is recorded for each of them,
+// so syncing doesn't fetch them again right away. The derived data of the affected resources is rebuilt.
+func (idx *Index) CollectGarbage(ctx context.Context, opts GCOptions) (report GCReport, err error) {
+	related := idx.relatedBlobsCollector()
+	if related == nil {
+		return report, fmt.Errorf("garbage collection is not available: related blobs collector is not set")
+	}
+
+	if opts.GracePeriod < 0 {
+		return report, fmt.Errorf("grace period must not be negative")
+	}
+
+	accountsJSON, err := encodePrincipalHexJSON(opts.Accounts)
+	if err != nil {
+		return report, err
+	}
+
+	cutoff := time.Now().Add(-opts.GracePeriod).Unix()
+	start := time.Now()
+
+	collect := func(conn *sqlite.Conn) error {
+		roots, err := loadGCRoots(conn)
+		if err != nil {
+			return err
+		}
+
+		accountRoots, err := loadAccountGCRoots(conn, opts.Accounts, accountsJSON)
+		if err != nil {
+			return err
+		}
+		roots = append(roots, accountRoots...)
+		report.Roots = len(roots)
+
+		if err := idx.markGCCandidates(conn, related, roots, opts.Space, accountsJSON, cutoff); err != nil {
+			return err
+		}
+
+		if err := sqlitex.Exec(conn, qBlobStoreSize(), func(stmt *sqlite.Stmt) error {
+			report.BlobsTotal, report.BytesTotal, report.StoredBytesTotal = stmt.ColumnInt64(0), stmt.ColumnInt64(1), stmt.ColumnInt64(2)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		return sqlitex.Exec(conn, qGCCandidatesSize, func(stmt *sqlite.Stmt) error {
+			report.BlobsPruned, report.BytesPruned, report.StoredBytesPruned = stmt.ColumnInt64(0), stmt.ColumnInt64(1), stmt.ColumnInt64(2)
+			return nil
+		})
+	}
+
+	if opts.DryRun {
+		err := idx.db.WithSave(ctx, collect)
+		return report, err
+	}
+
+	conn, release, err := idx.db.WriteConn(ctx)
+	if err != nil {
+		return report, err
+	}
+	defer release()
+
+	if err := sqlitex.WithTx(conn, func() error {
+		if err := collect(conn); err != nil {
+			return err
+		}
+
+		return idx.pruneGCCandidates(conn, cutoff, &report)
+	}); err != nil {
+		return report, err
+	}
+
+	idx.log.Info("GarbageCollected",
+		zap.String("duration", time.Since(start).String()),
+		zap.Int("roots", report.Roots),
+		zap.Int64("blobsPruned", report.BlobsPruned),
+		zap.Int64("bytesPruned", report.BytesPruned),
+		zap.Int64("storedBytesPruned", report.StoredBytesPruned),
+		zap.Int64("tombstonesExpired", report.TombstonesExpired),
+		zap.Int("reindexedResources", report.ReindexedResources),
+	)
+
+	return report, nil
+}
+
+func loadGCRoots(conn *sqlite.Conn) (roots []GCRoot, err error) {
+	if err := sqlitex.Exec(conn, qLoadGCRoots(), func(stmt *sqlite.Stmt) error {
+		roots = append(roots, GCRoot{IRI: IRI(stmt.ColumnText(0)), Recursive: stmt.ColumnInt(1) != 0})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return roots, nil
+}
+
+var qLoadGCRoots = dqb.Str(`
+	SELECT iri, is_recursive FROM subscriptions
+	UNION
+	SELECT iri, is_recursive FROM gc_pins
+	ORDER BY iri
+`)
+
+// loadAccountGCRoots returns the spaces the local keys belong to: their own spaces,
+// the spaces that granted them capabilities, even if expired, because the content they wrote meanwhile is still there,
+// and the organizations that list them as members.
+func loadAccountGCRoots(conn *sqlite.Conn, accounts []core.Principal, accountsJSON string) (roots []GCRoot, err error) {
+	for _, account := range accounts {
+		roots = append(roots, GCRoot{IRI: IRI("hm://" + account.String()), Recursive: true})
+	}
+
+	if err := sqlitex.Exec(conn, qLoadAccountCapabilitySpaces(), func(stmt *sqlite.Stmt) error {
+		space := core.Principal(stmt.ColumnBytes(0))
+		roots = append(roots, GCRoot{IRI: IRI("hm://" + space.String()), Recursive: true})
+		return nil
+	}, accountsJSON); err != nil {
+		return nil, err
+	}
+
+	if err := sqlitex.Exec(conn, qLoadAccountMembershipSpaces(), func(stmt *sqlite.Stmt) error {
+		roots = append(roots, GCRoot{IRI: IRI(stmt.ColumnText(0)), Recursive: true})
+		return nil
+	}, accountsJSON); err != nil {
+		return nil, err
+	}
+
+	return roots, nil
+}
+
+// Like in GetSpacesByAccount, but regardless of the expiration of the capabilities.
+var qLoadAccountCapabilitySpaces = dqb.Str(`
+	SELECT DISTINCT pk_author.principal
+	FROM structural_blobs sb INDEXED BY capabilities_by_delegate
+	JOIN public_keys pk_author ON pk_author.id = sb.author
+	WHERE sb.type = 'Capability'
+	AND sb.extra_attrs->>'del' IN (
+		SELECT pk.id FROM json_each(:accounts) j
+		JOIN public_keys pk ON pk.principal = unhex(j.value)
+	)
+	AND sb.extra_attrs->>'role' IN ('WRITER', 'COMMENTER', 'READER')
+	ORDER BY pk_author.principal
+`)
+
+// Memberships are anchored to the root of the organization's space.
+var qLoadAccountMembershipSpaces = dqb.Str(`
+	SELECT DISTINCT r.iri
+	FROM structural_blobs sb
+	JOIN resources r ON r.id = sb.resource
+	WHERE sb.type = 'Membership'
+	AND EXISTS (
+		SELECT 1 FROM json_each(sb.extra_attrs->'members') m
+		WHERE m.value IN (
+			SELECT pk.id FROM json_each(:accounts) j
+			JOIN public_keys pk ON pk.principal = unhex(j.value)
+		)
+	)
+	ORDER BY r.iri
+`)
+
+// markGCCandidates fills the gc_candidates temp table with the blobs to collect.
+func (idx *Index) markGCCandidates(conn *sqlite.Conn, related CollectRelatedBlobs, roots []GCRoot, space core.Principal, accountsJSON string, cutoff int64) error {
+	for _, table := range []string{"gc_reachable", "gc_scope"} {
+		if err := sqlitex.Exec(conn, "CREATE TEMP TABLE IF NOT EXISTS "+table+" (id INTEGER PRIMARY KEY)", nil); err != nil {
+			return err
+		}
+		if err := sqlitex.Exec(conn, "DELETE FROM temp."+table, nil); err != nil {
+			return err
+		}
+	}
+
+	if err := sqlitex.Exec(conn, "CREATE TEMP TABLE IF NOT EXISTS gc_candidates (id INTEGER PRIMARY KEY, iri TEXT)", nil); err != nil {
+		return err
+	}
+	if err := sqlitex.Exec(conn, "DELETE FROM temp.gc_candidates", nil); err != nil {
+		return err
+	}
+
+	insertIDs := func(table string, ids []int64) error {
+		for _, id := range ids {
+			if err := sqlitex.Exec(conn, "INSERT OR IGNORE INTO "+table+" (id) VALUES (?)", nil, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(roots) > 0 {
+		ids, err := related(conn, roots)
+		if err != nil {
+			return fmt.Errorf("failed to collect reachable blobs: %w", err)
+		}
+		if err := insertIDs("gc_reachable", ids); err != nil {
+			return err
+		}
+	}
+
+	if err := sqlitex.Exec(conn, qMarkKeptBlobs, nil, accountsJSON); err != nil {
+		return err
+	}
+
+	if err := sqlitex.Exec(conn, qMarkLinkedBlobs("gc_reachable"), nil); err != nil {
+		return err
+	}
+
+	if space == nil {
+		return sqlitex.Exec(conn, qMarkGCCandidates, nil, cutoff)
+	}
+
+	spaceIRI := IRI("hm://" + space.String())
+	ids, err := related(conn, []GCRoot{{IRI: spaceIRI, Recursive: true}})
+	if err != nil {
+		return fmt.Errorf("failed to collect blobs of the space: %w", err)
+	}
+	if err := insertIDs("gc_scope", ids); err != nil {
+		return err
+	}
+
+	if err := sqlitex.Exec(conn, qMarkSpaceBlobs, nil, string(spaceIRI), string(spaceIRI)+"/*"); err != nil {
+		return err
+	}
+
+	if err := sqlitex.Exec(conn, qMarkLinkedBlobs("gc_scope"), nil); err != nil {
+		return err
+	}
+
+	return sqlitex.Exec(conn, qMarkScopedGCCandidates, nil, cutoff)
+}
+
+// Blobs waiting for their dependencies are not reachable yet,
+// and structural blobs without a resource, other than Changes, can't be attributed to any resource,
+// so they are kept, along with everything authored by the local keys.
+const qMarkKeptBlobs = `
+	INSERT OR IGNORE INTO gc_reachable (id)
+	SELECT id FROM stashed_blobs
+	UNION
+	SELECT id FROM structural_blobs WHERE resource IS NULL AND type != 'Change'
+	UNION
+	SELECT sb.id FROM structural_blobs sb
+	WHERE sb.author IN (
+		SELECT pk.id FROM json_each(:accounts) j
+		JOIN public_keys pk ON pk.principal = unhex(j.value)
+	)
+`
+
+// qMarkLinkedBlobs adds the blobs linked from the blobs in the given temp table transitively,
+// e.g. from Refs to Changes, and from Changes to media files.
+func qMarkLinkedBlobs(table string) string {
+	return `
+		WITH RECURSIVE linked (id) AS (
+			SELECT id FROM ` + table + `
+			UNION
+			SELECT bl.target
+			FROM blob_links bl
+			JOIN linked l ON l.id = bl.source
+		)
+		INSERT OR IGNORE INTO ` + table + ` (id)
+		SELECT id FROM linked
+	`
+}
+
+const qMarkGCCandidates = `
+	INSERT INTO gc_candidates (id)
+	SELECT id FROM blobs
+	WHERE size > 0
+	AND insert_time < :cutoff
+	AND id NOT IN gc_reachable
+`
+
+// Changes that are not reachable from any of the heads are attached to the space through their genesis.
+const qMarkSpaceBlobs = `
+	INSERT OR IGNORE INTO gc_scope (id)
+	SELECT sb.id
+	FROM resources r
+	JOIN structural_blobs sb ON sb.resource = r.id OR sb.genesis_blob = r.genesis_blob OR sb.id = r.genesis_blob
+	WHERE r.iri = :iri OR r.iri GLOB :pattern
+`
+
+const qMarkScopedGCCandidates = `
+	INSERT INTO gc_candidates (id)
+	SELECT b.id FROM gc_scope s
+	JOIN blobs b ON b.id = s.id
+	WHERE b.size > 0
+	AND b.insert_time < :cutoff
+	AND b.id NOT IN gc_reachable
+`
+
+var qBlobStoreSize = dqb.Str(`
+	SELECT count(), coalesce(sum(size), 0), coalesce(sum(length(data)), 0)
+	FROM blobs
+	WHERE size > 0
+`)
+
+const qGCCandidatesSize = `
+	SELECT count(), coalesce(sum(b.size), 0), coalesce(sum(length(b.data)), 0)
+	FROM gc_candidates c
+	JOIN blobs b ON b.id = c.id
+`
+
+// pruneGCCandidates removes the data of the blobs in the gc_candidates temp table,
+// records their tombstones, and rebuilds the derived data of the affected resources.
+func (idx *Index) pruneGCCandidates(conn *sqlite.Conn, cutoff int64, report *GCReport) error {
+	var resources []int64
+	if err := sqlitex.Exec(conn, qListGCResources, func(stmt *sqlite.Stmt) error {
+		resources = append(resources, stmt.ColumnInt64(0))
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := sqlitex.Exec(conn, qSetGCCandidatesIRI, nil); err != nil {
+		return err
+	}
+
+	// Media files don't belong to any resource, so they inherit the resource of the blobs linking to them.
+	// The depth of the links is bounded, e.g. Change -> DagPB file -> Raw chunk.
+	for range 8 {
+		if err := sqlitex.Exec(conn, qInheritGCCandidatesIRI, nil); err != nil {
+			return err
+		}
+		if conn.Changes() == 0 {
+			break
+		}
+	}
+
+	var pruned []int64
+	if err := sqlitex.Exec(conn, "SELECT id FROM gc_candidates ORDER BY id", func(stmt *sqlite.Stmt) error {
+		pruned = append(pruned, stmt.ColumnInt64(0))
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, q := range [...]string{qInsertTombstones, qPruneGCCandidates} {
+		if err := sqlitex.Exec(conn, q, nil); err != nil {
+			return err
+		}
+	}
+
+	if err := sqlitex.Exec(conn, qExpireTombstones(), nil, cutoff); err != nil {
+		return err
+	}
+	report.TombstonesExpired = int64(conn.Changes())
+
+	if _, err := idx.reindexResources(conn, resources, pruned, nil); err != nil {
+		return err
+	}
+	report.ReindexedResources = len(resources)
+
+	return nil
+}
+
+// Same rules as qLoadBlobResources, but for all the candidates at once.
+const qListGCResources = `
+	SELECT r.id
+	FROM gc_candidates c
+	JOIN structural_blobs sb ON sb.id = c.id
+	JOIN resources r ON r.id = sb.resource
+	UNION
+	SELECT r.id
+	FROM gc_candidates c
+	JOIN structural_blobs sb ON sb.id = c.id
+	JOIN resources r ON r.genesis_blob = sb.genesis_blob
+	UNION
+	SELECT r.id
+	FROM gc_candidates c
+	JOIN resources r ON r.genesis_blob = c.id
+	ORDER BY 1
+`
+
+const qSetGCCandidatesIRI = `
+	UPDATE gc_candidates SET iri = coalesce(
+		(SELECT r.iri FROM structural_blobs sb JOIN resources r ON r.id = sb.resource WHERE sb.id = gc_candidates.id),
+		(SELECT r.iri FROM structural_blobs sb JOIN resources r ON r.genesis_blob = sb.genesis_blob WHERE sb.id = gc_candidates.id),
+		(SELECT r.iri FROM resources r WHERE r.genesis_blob = gc_candidates.id)
+	)
+`
+
+const qInheritGCCandidatesIRI = `
+	UPDATE gc_candidates SET iri = (
+		SELECT p.iri
+		FROM blob_links bl
+		JOIN gc_candidates p ON p.id = bl.source
+		WHERE bl.target = gc_candidates.id
+		AND p.iri IS NOT NULL
+		ORDER BY p.iri
+		LIMIT 1
+	)
+	WHERE iri IS NULL
+	AND EXISTS (
+		SELECT 1
+		FROM blob_links bl
+		JOIN gc_candidates p ON p.id = bl.source
+		WHERE bl.target = gc_candidates.id
+		AND p.iri IS NOT NULL
+	)
+`
+
+const qInsertTombstones = `
+	INSERT OR REPLACE INTO blob_tombstones (id, iri, size, stored_size)
+	SELECT c.id, c.iri, b.size, length(b.data)
+	FROM gc_candidates c
+	JOIN blobs b ON b.id = c.id
+`
+
+const qPruneGCCandidates = `
+	UPDATE blobs SET size = -1, data = NULL
+	WHERE id IN (SELECT id FROM gc_candidates)
+`
+
+// Tombstones of the blobs we received again are not needed anymore.
+var qExpireTombstones = dqb.Str(`
+	DELETE FROM blob_tombstones
+	WHERE delete_time < :cutoff
+	OR EXISTS (SELECT 1 FROM blobs b WHERE b.id = blob_tombstones.id AND b.size >= 0)
+`)
+
+// IsTombstoned checks whether the data of the blob was removed by the garbage collector,
+// in which case syncing should not fetch it again.
+func (idx *Index) IsTombstoned(ctx context.Context, c cid.Cid) (bool, error) {
+	return sqlitex.Read(ctx, idx.db, func(conn *sqlite.Conn) (bool, error) {
+		var found bool
+		err := sqlitex.Exec(conn, qIsTombstoned(), func(*sqlite.Stmt) error {
+			found = true
+			return nil
+		}, c.Hash())
+		return found, err
+	})
+}
+
+var qIsTombstoned = dqb.Str(`
+	SELECT 1
+	FROM blobs b INDEXED BY blobs_metadata_by_hash
+	JOIN blob_tombstones t ON t.id = b.id
+	WHERE b.multihash = :multihash
+	AND b.size < 0
+	LIMIT 1
+`)
+
+// ClearTombstones removes the tombstones of the blobs of the given resource,
+// or of any resource below it if recursive, so syncing can fetch them again,
+// e.g. when the user subscribes to the resource again.
+func (idx *Index) ClearTombstones(ctx context.Context, iri IRI, recursive bool) error {
+	// Most of the time there's nothing to clear, so we check it first to avoid taking the write connection.
+	found, err := sqlitex.Read(ctx, idx.db, func(conn *sqlite.Conn) (bool, error) {
+		var found bool
+		err := sqlitex.Exec(conn, qHasTombstones(), func(*sqlite.Stmt) error {
+			found = true
+			return nil
+		}, string(iri), recursive)
+		return found, err
+	})
+	if err != nil || !found {
+		return err
+	}
+
+	return idx.db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, qClearTombstones(), nil, string(iri), recursive)
+	})
+}
+
+// Paths may contain GLOB wildcards, so the subtree is matched with a range comparison.
+var qHasTombstones = dqb.Str(`
+	SELECT 1 FROM blob_tombstones
+	WHERE iri = :iri OR (:recursive AND iri >= :iri || '/' AND iri < :iri || '0')
+	LIMIT 1
+`)
+
+var qClearTombstones = dqb.Str(`
+	DELETE FROM blob_tombstones
+	WHERE iri = :iri OR (:recursive AND iri >= :iri || '/' AND iri < :iri || '0')
+`)
+
+// PinResource protects the resource, and all the resources below it if recursive,
+// from being garbage collected.
+func (idx *Index) PinResource(ctx context.Context, iri IRI, recursive bool) error {
+	if _, _, err := iri.SpacePath(); err != nil {
+		return fmt.Errorf("invalid IRI to pin %s: %w", iri, err)
+	}
+
+	if err := idx.db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, qPinResource(), nil, string(iri), recursive)
+	}); err != nil {
+		return err
+	}
+
+	return idx.ClearTombstones(ctx, iri, recursive)
+}
+
+var qPinResource = dqb.Str(`
+	INSERT INTO gc_pins (iri, is_recursive) VALUES (:iri, :recursive)
+	ON CONFLICT (iri) DO UPDATE SET is_recursive = excluded.is_recursive
+`)
+
+// UnpinResource removes the pin of the resource.
+func (idx *Index) UnpinResource(ctx context.Context, iri IRI) error {
+	return idx.db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, qUnpinResource(), nil, string(iri))
+	})
+}
+
+var qUnpinResource = dqb.Str(`
+	DELETE FROM gc_pins WHERE iri = :iri
+`)
+
+// ListPinnedResources returns the pinned resources.
+func (idx *Index) ListPinnedResources(ctx context.Context) (pins []GCRoot, err error) {
+	if err := idx.db.WithSave(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, qListPinnedResources(), func(stmt *sqlite.Stmt) error {
+			pins = append(pins, GCRoot{IRI: IRI(stmt.ColumnText(0)), Recursive: stmt.ColumnInt(1) != 0})
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return pins, nil
+}
+
+var qListPinnedResources = dqb.Str(`
+	SELECT iri, is_recursive FROM gc_pins ORDER BY iri
+`)
@@ -0,0 +1,253 @@
+package blob
+
+import (
+	"seed/backend/core"
+	"seed/backend/core/coretest"
+	"seed/backend/ipfs"
+	"seed/backend/storage"
+	"seed/backend/util/cclock"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"testing"
+	"time"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multicodec"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCollectGarbage(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+	clock := cclock.New()
+	ctx := t.Context()
+
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(ctx, db, zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = idx.CollectGarbage(ctx, GCOptions{})
+	require.Error(t, err, "must fail without the related blobs collector")
+
+	// Simplified related material: only the structural blobs of the resources.
+	// The garbage collector follows the links to the changes and the media files itself.
+	idx.SetCollectRelatedBlobs(func(conn *sqlite.Conn, roots []GCRoot) (ids []int64, err error) {
+		for _, root := range roots {
+			if err := sqlitex.Exec(conn, `SELECT sb.id FROM structural_blobs sb
+				JOIN resources r ON r.id = sb.resource
+				WHERE r.iri = ? OR (? AND r.iri GLOB ?)`, func(stmt *sqlite.Stmt) error {
+				ids = append(ids, stmt.ColumnInt64(0))
+				return nil
+			}, string(root.IRI), root.Recursive, string(root.IRI)+"/*"); err != nil {
+				return nil, err
+			}
+		}
+		return ids, nil
+	})
+
+	newDocument := func(u coretest.Tester, path string, ops ...OpMap) []blocks.Block {
+		t.Helper()
+		ts := clock.MustNow()
+		change, err := NewChange(u.Account, cid.Undef, nil, 0, ChangeBody{Ops: ops}, ts)
+		require.NoError(t, err)
+		ref, err := NewRef(u.Account, ts.UnixMilli(), change.CID, u.Account.Principal(), path, []cid.Cid{change.CID}, ts, VisibilityPublic)
+		require.NoError(t, err)
+		return []blocks.Block{change, ref}
+	}
+
+	media := ipfs.NewBlock(multicodec.Raw, []byte("bob's cover image"))
+	orphan := ipfs.NewBlock(multicodec.Raw, []byte("media from a deleted draft"))
+	aliceDoc := newDocument(alice, "/doc")
+	bobDoc := newDocument(bob, "", NewOpSetAttributes("", []KeyValue{{Key: []string{"cover"}, Value: "ipfs://" + media.Cid().String()}}))
+
+	require.NoError(t, idx.PutMany(ctx, []blocks.Block{media, orphan}))
+	require.NoError(t, idx.PutMany(ctx, aliceDoc))
+	require.NoError(t, idx.PutMany(ctx, bobDoc))
+
+	bobSpace := IRI("hm://" + bob.Account.PublicKey.String())
+	opts := GCOptions{Accounts: []core.Principal{alice.Account.Principal()}, GracePeriod: time.Hour}
+
+	report, err := idx.CollectGarbage(ctx, opts)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), report.BlobsTotal)
+	require.Equal(t, int64(0), report.BlobsPruned, "recent blobs must be kept")
+
+	require.NoError(t, db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, "UPDATE blobs SET insert_time = insert_time - 7200", nil)
+	}))
+
+	dry := opts
+	dry.DryRun = true
+	report, err = idx.CollectGarbage(ctx, dry)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), report.BlobsPruned, "bob's document, its media, and the orphan must be unreachable")
+	require.Greater(t, report.BytesPruned, int64(0))
+	require.Greater(t, report.StoredBytesPruned, int64(0))
+	requireHas(t, idx, bobDoc[1].Cid(), true, "dry run must not change anything")
+
+	dry.Space = alice.Account.Principal()
+	report, err = idx.CollectGarbage(ctx, dry)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), report.BlobsPruned)
+
+	dry.Space = bob.Account.Principal()
+	report, err = idx.CollectGarbage(ctx, dry)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), report.BlobsPruned, "only the blobs of bob's space must be collected")
+
+	report, err = idx.CollectGarbage(ctx, opts)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), report.BlobsPruned)
+	require.Equal(t, 1, report.ReindexedResources)
+
+	for _, blk := range aliceDoc {
+		requireHas(t, idx, blk.Cid(), true, "reachable blobs must be kept")
+	}
+	for _, blk := range append(bobDoc, media, orphan) {
+		requireHas(t, idx, blk.Cid(), false, "unreachable blobs must be collected")
+		requireTombstoned(t, idx, blk.Cid(), true)
+	}
+
+	generations, err := sqlitex.QueryOnePool[int64](ctx, db, `SELECT count() FROM document_generations dg
+		JOIN resources r ON r.id = dg.resource
+		WHERE r.iri = ?`, string(bobSpace))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), generations, "derived data of the collected blobs must be dropped")
+
+	// Media inherits the resource of the blobs linking to it.
+	require.NoError(t, idx.ClearTombstones(ctx, bobSpace, true))
+	requireTombstoned(t, idx, media.Cid(), false)
+	requireTombstoned(t, idx, bobDoc[1].Cid(), false)
+	requireTombstoned(t, idx, orphan.Cid(), true)
+
+	// Pinned resources are kept once we get them again.
+	require.NoError(t, idx.PinResource(ctx, bobSpace, true))
+	require.NoError(t, idx.PutMany(ctx, append(bobDoc, media)))
+	require.NoError(t, db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, "UPDATE blobs SET insert_time = insert_time - 7200", nil)
+	}))
+
+	report, err = idx.CollectGarbage(ctx, opts)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), report.BlobsPruned)
+	requireHas(t, idx, media.Cid(), true, "media of pinned resources must be kept")
+
+	pins, err := idx.ListPinnedResources(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []GCRoot{{IRI: bobSpace, Recursive: true}}, pins)
+
+	require.NoError(t, idx.UnpinResource(ctx, bobSpace))
+	report, err = idx.CollectGarbage(ctx, opts)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), report.BlobsPruned)
+}
+
+func TestCollectGarbageMemberSpaces(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+	carol := coretest.NewTester("carol")
+	dave := coretest.NewTester("david")
+	clock := cclock.New()
+	ctx := t.Context()
+
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(ctx, db, zap.NewNop())
+	require.NoError(t, err)
+
+	idx.SetCollectRelatedBlobs(func(conn *sqlite.Conn, roots []GCRoot) (ids []int64, err error) {
+		for _, root := range roots {
+			if err := sqlitex.Exec(conn, `SELECT sb.id FROM structural_blobs sb
+				JOIN resources r ON r.id = sb.resource
+				WHERE r.iri = ? OR (r.iri >= ? || '/' AND r.iri < ? || '0')`, func(stmt *sqlite.Stmt) error {
+				ids = append(ids, stmt.ColumnInt64(0))
+				return nil
+			}, string(root.IRI), string(root.IRI), string(root.IRI)); err != nil {
+				return nil, err
+			}
+		}
+		return ids, nil
+	})
+
+	newDocument := func(u coretest.Tester, path string) []blocks.Block {
+		t.Helper()
+		ts := clock.MustNow()
+		change, err := NewChange(u.Account, cid.Undef, nil, 0, ChangeBody{}, ts)
+		require.NoError(t, err)
+		ref, err := NewRef(u.Account, ts.UnixMilli(), change.CID, u.Account.Principal(), path, []cid.Cid{change.CID}, ts, VisibilityPublic)
+		require.NoError(t, err)
+		return []blocks.Block{change, ref}
+	}
+
+	// Alice was a writer in bob's space for some time, and she is a member of carol's organization.
+	expired, err := NewTimeBoundCapability(bob.Account, alice.Account.Principal(), bob.Account.Principal(), "", RoleWriter, "",
+		time.Time{}, time.Now().Add(-time.Hour), time.Now().Add(-2*time.Hour).Round(ClockPrecision))
+	require.NoError(t, err)
+	ms, err := NewMembership(carol.Account, carol.Account.Principal(), []core.Principal{alice.Account.Principal(), dave.Account.Principal()}, 1, clock.MustNow())
+	require.NoError(t, err)
+
+	bobDoc := newDocument(bob, "/doc")
+	carolDoc := newDocument(carol, "/doc")
+	daveDoc := newDocument(dave, "/doc")
+
+	require.NoError(t, idx.PutMany(ctx, []blocks.Block{expired, ms}))
+	require.NoError(t, idx.PutMany(ctx, bobDoc))
+	require.NoError(t, idx.PutMany(ctx, carolDoc))
+	require.NoError(t, idx.PutMany(ctx, daveDoc))
+	require.NoError(t, db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, "UPDATE blobs SET insert_time = insert_time - 7200", nil)
+	}))
+
+	_, err = idx.CollectGarbage(ctx, GCOptions{Accounts: []core.Principal{alice.Account.Principal()}, GracePeriod: time.Hour})
+	require.NoError(t, err)
+
+	for _, blk := range append(bobDoc, carolDoc...) {
+		requireHas(t, idx, blk.Cid(), true, "content of the spaces the local keys belong to must be kept")
+	}
+	for _, blk := range daveDoc {
+		requireHas(t, idx, blk.Cid(), false, "content of unrelated spaces must be collected")
+	}
+}
+
+func TestClearTombstonesLiteralPaths(t *testing.T) {
+	ctx := t.Context()
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(ctx, db, zap.NewNop())
+	require.NoError(t, err)
+
+	space := "hm://" + coretest.NewTester("alice").Account.PublicKey.String()
+	iris := []string{space + "/a*", space + "/a*/b", space + "/ab", space + "/abc/d"}
+	require.NoError(t, db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		for i, iri := range iris {
+			if err := sqlitex.Exec(conn, "INSERT INTO blobs (id, multihash, codec, size) VALUES (?, ?, 85, -1)", nil, i+1, []byte(iri)); err != nil {
+				return err
+			}
+			if err := sqlitex.Exec(conn, "INSERT INTO blob_tombstones (id, iri, size, stored_size) VALUES (?, ?, 1, 1)", nil, i+1, iri); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	// Wildcards in the paths must not match the siblings.
+	require.NoError(t, idx.ClearTombstones(ctx, IRI(space+"/a*"), true))
+
+	left, err := sqlitex.QueryOnePool[int64](ctx, db, "SELECT count() FROM blob_tombstones")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), left, "only the tombstones of the resource and its subtree must be cleared")
+}
+
+func requireHas(t *testing.T, idx *Index, c cid.Cid, want bool, msg string) {
+	t.Helper()
+	has, err := idx.Has(t.Context(), c)
+	require.NoError(t, err)
+	require.Equal(t, want, has, msg)
+}
+
+func requireTombstoned(t *testing.T, idx *Index, c cid.Cid, want bool) {
+	t.Helper()
+	ok, err := idx.IsTombstoned(t.Context(), c)
+	require.NoError(t, err)
+	require.Equal(t, want, ok)
+}
@@ -157,6 +157,17 @@ type Index struct {
 	// starts, but the documents server also (re)sets it later while indexing
 	// may already be running.
 	deriveFirstContentImage DeriveFirstContentImage
+
+	// resolveCommentAnchors computes the positions of comment anchors in the latest version of their documents.
+	// Injected via SetResolveCommentAnchors for the same reason as deriveFirstContentImage, and guarded by hookMu.
+	resolveCommentAnchors ResolveCommentAnchors
+
+	// collectRelatedBlobs finds the reachable blobs for the garbage collector.
+	// Injected via SetCollectRelatedBlobs for the same reason as deriveFirstContentImage, and guarded by hookMu.
+	collectRelatedBlobs CollectRelatedBlobs
+
+	// spaceQuotas limits the storage of spaces. Set via SetSpaceQuotas, and guarded by hookMu.
+	spaceQuotas SpaceQuotas
 }
 
 // indexedHookBatchSize caps how many blob ids a single hook transaction
@@ -182,17 +193,12 @@ func (idx *Index) SetIndexedHook(fn func(*sqlite.Conn, []int64) error) {
 
 // enqueueIndexedHook hands freshly indexed blob ids to the hook worker.
 // Called after the indexing transaction has committed, so the worker can never
-// observe uncommitted blobs. No-op when no hook is registered.
+// observe uncommitted blobs. The worker always runs, even without a registered
+// hook, because it also maintains the storage usage of spaces.
 func (idx *Index) enqueueIndexedHook(ids []int64) {
 	if len(ids) == 0 {
 		return
 	}
-	idx.hookMu.RLock()
-	registered := idx.indexedHook != nil
-	idx.hookMu.RUnlock()
-	if !registered {
-		return
-	}
 
 	idx.hookWorkerOn.Do(func() { go idx.indexedHookWorker() })
 
@@ -246,29 +252,46 @@ const indexedHookMaxAttempts = 3
 // advertised set (the sweep only heals warm scopes, on a slow rotation). The
 // fallback demotes every materialized scope so each lazily re-materializes
 // from the ground truth on its next serve.
+//
+// The storage usage of spaces is maintained first, in its own transactions,
+// so a failing hook doesn't hold it back. A chunk that keeps failing leaves
+// the usage of its spaces short until the next reindex.
 func (idx *Index) applyIndexedHook(ids []int64) {
 	idx.hookMu.RLock()
 	fn := idx.indexedHook
 	idx.hookMu.RUnlock()
-	if fn == nil {
-		return
-	}
 
 	for chunk := range slices.Chunk(ids, indexedHookBatchSize) {
-		var err error
-		for attempt := 1; attempt <= indexedHookMaxAttempts; attempt++ {
-			err = idx.db.WithTx(context.Background(), func(conn *sqlite.Conn) error {
-				return fn(conn, chunk)
-			})
-			if err == nil {
-				break
+		// Content signed by the keys rotated away in the meantime must be evicted first, so it's not accounted anywhere.
+		if err := idx.retryIndexedHook(idx.evictRotatedOutContentAfter, chunk); err != nil {
+			if errors.Is(err, sqlitex.ErrPoolClosed) {
+				return
 			}
+			idx.log.Error("RotatedOutContentEvictionFailed", zap.Int64s("blobs", chunk), zap.Error(err))
+		}
+
+		if err := idx.retryIndexedHook(maintainSpaceUsage, chunk); err != nil {
 			if errors.Is(err, sqlitex.ErrPoolClosed) {
 				return
 			}
-			// Retries stay synchronous so hookInFlight remains true and
-			// WaitIndexedHook keeps its "caught up" meaning for tests.
-			time.Sleep(time.Duration(attempt) * 100 * time.Millisecond)
+			idx.log.Error("SpaceUsageHookFailed", zap.Int64s("blobs", chunk), zap.Error(err))
+		}
+
+		// Comment anchors follow the content of the new versions. It's best-effort, so there are no retries.
+		if err := idx.resolveCommentAnchorsAfter(chunk); err != nil {
+			if errors.Is(err, sqlitex.ErrPoolClosed) {
+				return
+			}
+			idx.log.Warn("CommentAnchorsHookFailed", zap.Int64s("blobs", chunk), zap.Error(err))
+		}
+
+		if fn == nil {
+			continue
+		}
+
+		err := idx.retryIndexedHook(fn, chunk)
+		if errors.Is(err, sqlitex.ErrPoolClosed) {
+			return
 		}
 		if err != nil {
 			idx.log.Error("IndexedHookFailed", zap.Int64s("blobs", chunk), zap.Error(err))
@@ -285,6 +308,23 @@ func (idx *Index) applyIndexedHook(ids []int64) {
 	}
 }
 
+// retryIndexedHook applies fn to the chunk in a standalone write transaction,
+// retrying a few times on failure.
+func (idx *Index) retryIndexedHook(fn func(*sqlite.Conn, []int64) error, chunk []int64) (err error) {
+	for attempt := 1; attempt <= indexedHookMaxAttempts; attempt++ {
+		err = idx.db.WithTx(context.Background(), func(conn *sqlite.Conn) error {
+			return fn(conn, chunk)
+		})
+		if err == nil || errors.Is(err, sqlitex.ErrPoolClosed) {
+			return err
+		}
+		// Retries stay synchronous so hookInFlight remains true and
+		// WaitIndexedHook keeps its "caught up" meaning for tests.
+		time.Sleep(time.Duration(attempt) * 100 * time.Millisecond)
+	}
+	return err
+}
+
 // WaitIndexedHook blocks until every blob id enqueued for the indexed hook so
 // far has been applied, or ctx is done. Intended for tests that need the
 // asynchronous RBSR maintenance to have caught up with recent Puts.
@@ -451,6 +491,11 @@ func indexBlob(opts indexOpts, conn *sqlite.Conn, id int64, c cid.Cid, data []by
 			}
 			extraJSON := unsafeutil.StringFromBytes(data)
 
+			// A blob that was stashed before and failed again is only waiting for what's missing now.
+			if err = sqlitex.Exec(conn, "DELETE FROM stashed_blobs WHERE id = ?", nil, id); err != nil {
+				return
+			}
+
 			err = sqlitex.Exec(conn, qStashBlob(), nil, id, serr.Reason, extraJSON)
 			return
 		}
@@ -587,6 +632,41 @@ func (idx *Index) ResolveLatest(ctx context.Context, resource IRI) (DocumentStat
 	return out, nil
 }
 
+// VersionGenesis returns the genesis of the document version with the given heads,
+// regardless of whether the version was ever published or not.
+// It doesn't load any content, so it can be used to validate versions that are not visible yet.
+func (idx *Index) VersionGenesis(ctx context.Context, heads []cid.Cid) (genesis cid.Cid, err error) {
+	conn, release, err := idx.db.ReadConn(ctx)
+	if err != nil {
+		return cid.Undef, err
+	}
+	defer release()
+
+	headIDs, err := cidsToDBIDs(conn, heads)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	var genesisID int64
+	for i, h := range headIDs {
+		g, err := dbBlobsGetGenesis(conn, h)
+		if err != nil {
+			return cid.Undef, err
+		}
+		if g == 0 {
+			return cid.Undef, status.Errorf(codes.NotFound, "change %s is not indexed", heads[i])
+		}
+
+		if genesisID == 0 {
+			genesisID = g
+		} else if genesisID != g {
+			return cid.Undef, status.Errorf(codes.InvalidArgument, "changes of compound version %s have different genesis", NewVersion(heads...).String())
+		}
+	}
+
+	return NewLookupCache(conn).CID(genesisID)
+}
+
 // resolveLatestGeneration loads a resource's most recent generation row and
 // applies the two conditions that make a document unreadable: a redirect, and a
 // tombstone. Both surface as FailedPrecondition, and the redirect carries the
@@ -741,6 +821,11 @@ func (idx *Index) iterChangesLatest(ctx context.Context, resource IRI) (it iter.
 				break
 			}
 
+			if err := idx.bs.openChange(conn, ch); err != nil {
+				outErr = errors.Join(outErr, fmt.Errorf("WalkChanges: failed to decrypt change %s for entity %s: %w", chcid, resource, err))
+				break
+			}
+
 			rec := ChangeRecord{
 				CID:        chcid,
 				Data:       ch,
@@ -781,6 +866,35 @@ var qIterChangesFromHeads = dqb.Str(`
 	ORDER BY structural_blobs.ts;
 `)
 
+var qIterChangesBetween = dqb.Str(`
+	WITH RECURSIVE
+	changes (id) AS (
+		SELECT value FROM json_each(:heads)
+		UNION
+		SELECT target
+		FROM blob_links
+		JOIN changes ON changes.id = blob_links.source
+			AND blob_links.type = 'change/dep'
+	),
+	base (id) AS (
+		SELECT value FROM json_each(:base)
+		UNION
+		SELECT target
+		FROM blob_links
+		JOIN base ON base.id = blob_links.source
+			AND blob_links.type = 'change/dep'
+	)
+	SELECT
+		codec,
+		multihash,
+		data
+	FROM changes
+	JOIN blobs ON changes.id = blobs.id
+	LEFT JOIN structural_blobs ON structural_blobs.id = blobs.id
+	WHERE changes.id NOT IN (SELECT id FROM base)
+	ORDER BY structural_blobs.ts;
+`)
+
 // IterChanges iterates over changes starting from the given heads.
 // When no heads are provided it uses the latest generation and the latest version.
 func (idx *Index) IterChanges(ctx context.Context, resource IRI, heads []cid.Cid) (it iter.Seq[ChangeRecord], check func() error) {
@@ -806,106 +920,12 @@ func (idx *Index) IterChanges(ctx context.Context, resource IRI, heads []cid.Cid
 			return
 		}
 
-		var versionGenesis int64
-
-		for i, h := range headIDs {
-			genesis, err := dbBlobsGetGenesis(conn, h)
-			if err != nil {
-				outErr = err
-				return
-			}
-			if genesis == 0 {
-				// The query is COALESCE(genesis_blob, id), so a zero here can
-				// only mean there is no structural_blobs row for this change —
-				// it isn't indexed yet. That happens routinely mid-sync: we
-				// learn a version head from a Ref before the change itself
-				// arrives, or the change arrived and was stashed pending its
-				// genesis. It is a transient state, not a server fault, so
-				// report it as retryable rather than Internal — otherwise the
-				// UI shows a red "Something went wrong" for content that is
-				// simply still on its way.
-				outErr = status.Errorf(codes.Unavailable, "document is still syncing: change %s has not been indexed yet", heads[i])
-				return
-			}
-
-			if versionGenesis == 0 {
-				versionGenesis = genesis
-			} else if versionGenesis != genesis {
-				outErr = fmt.Errorf("changes of compound version %s have different genesis", NewVersion(heads...).String())
-				return
-			}
-		}
-
-		// Query document generations sorted by most recent.
-		lookup := NewLookupCache(conn)
-		versionGenesisCID, err := lookup.CID(versionGenesis)
+		dg, err := findVersionGeneration(conn, resource, heads, headIDs)
 		if err != nil {
 			outErr = err
 			return
 		}
 
-		q := dqb.Select(
-			"dg.resource",
-			"dg.genesis_change_time",
-			"dg.last_change_time",
-			"dg.last_tombstone_ref_time",
-			"dg.last_alive_ref_time",
-			"dg.generation",
-			"dg.genesis",
-			"dg.last_comment",
-			"dg.last_comment_time",
-			"dg.comment_count",
-			"dg.heads",
-			"dg.changes",
-			"dg.change_count",
-			"dg.authors",
-			"dg.visibility",
-			"dg.visibility_timestamp",
-		).
-			From("document_generations dg", "resources r").
-			Where("r.id = dg.resource").
-			Where("r.iri = ?").
-			Where("dg.genesis = ?").
-			OrderBy("dg.generation DESC").
-			String()
-
-		rows2, discard2, check2 := sqlitex.Query(conn, q, resource, versionGenesisCID.String()).All()
-		defer discard2(&outErr)
-
-		var dg maybe.Value[documentGeneration]
-		var foundChanges []int64
-
-		for row := range rows2 {
-			var g documentGeneration
-			if err := g.fromRow(row); err != nil {
-				outErr = err
-				return
-			}
-
-			// Check if any of our version heads are in this generation's changes.
-			if g.Changes != nil {
-				found := false
-				for _, h := range headIDs {
-					if g.Changes.Contains(uint64(h)) {
-						found = true
-						dg = maybe.New(g)
-
-						// Get all changes from this generation.
-						it := g.Changes.Iterator()
-						for it.HasNext() {
-							foundChanges = append(foundChanges, int64(it.Next())) //nolint:gosec // We know this should not overflow.
-						}
-						break
-					}
-				}
-				if found {
-					break
-				}
-			}
-		}
-
-		outErr = errors.Join(outErr, check2())
-
 		if !dg.IsSet() {
 			return
 		}
@@ -954,6 +974,11 @@ func (idx *Index) IterChanges(ctx context.Context, resource IRI, heads []cid.Cid
 				break
 			}
 
+			if err := idx.bs.openChange(conn, ch); err != nil {
+				outErr = errors.Join(outErr, fmt.Errorf("WalkChanges: failed to decrypt change %s: %w", chcid, err))
+				break
+			}
+
 			rec := ChangeRecord{
 				CID:        chcid,
 				Data:       ch,
@@ -974,6 +999,169 @@ func (idx *Index) IterChanges(ctx context.Context, resource IRI, heads []cid.Cid
 	return it, check
 }
 
+// findVersionGeneration finds the generation of the resource that contains the version with the given heads.
+// Versions that were never published, like the ones of draft branches, belong to the most recent generation with the same genesis,
+// but only as long as all of their heads can be reached from the branch Refs of the resource.
+// Such versions are private if any of their heads is only reachable from private branches.
+// Versions we can't attribute to any generation or branch are not found.
+func findVersionGeneration(conn *sqlite.Conn, resource IRI, heads []cid.Cid, headIDs []int64) (dg maybe.Value[documentGeneration], err error) {
+	var versionGenesis int64
+
+	for i, h := range headIDs {
+		genesis, err := dbBlobsGetGenesis(conn, h)
+		if err != nil {
+			return dg, err
+		}
+		if genesis == 0 {
+			// The query is COALESCE(genesis_blob, id), so a zero here can
+			// only mean there is no structural_blobs row for this change —
+			// it isn't indexed yet. That happens routinely mid-sync: we
+			// learn a version head from a Ref before the change itself
+			// arrives, or the change arrived and was stashed pending its
+			// genesis. It is a transient state, not a server fault, so
+			// report it as retryable rather than Internal — otherwise the
+			// UI shows a red "Something went wrong" for content that is
+			// simply still on its way.
+			return dg, status.Errorf(codes.Unavailable, "document is still syncing: change %s has not been indexed yet", heads[i])
+		}
+
+		if versionGenesis == 0 {
+			versionGenesis = genesis
+		} else if versionGenesis != genesis {
+			return dg, fmt.Errorf("changes of compound version %s have different genesis", NewVersion(heads...).String())
+		}
+	}
+
+	// Query document generations sorted by most recent.
+	lookup := NewLookupCache(conn)
+	versionGenesisCID, err := lookup.CID(versionGenesis)
+	if err != nil {
+		return dg, err
+	}
+
+	q := dqb.Select(
+		"dg.resource",
+		"dg.genesis_change_time",
+		"dg.last_change_time",
+		"dg.last_tombstone_ref_time",
+		"dg.last_alive_ref_time",
+		"dg.generation",
+		"dg.genesis",
+		"dg.last_comment",
+		"dg.last_comment_time",
+		"dg.comment_count",
+		"dg.heads",
+		"dg.changes",
+		"dg.change_count",
+		"dg.authors",
+		"dg.visibility",
+		"dg.visibility_timestamp",
+	).
+		From("document_generations dg", "resources r").
+		Where("r.id = dg.resource").
+		Where("r.iri = ?").
+		Where("dg.genesis = ?").
+		OrderBy("dg.generation DESC").
+		String()
+
+	rows, discard, check := sqlitex.Query(conn, q, resource, versionGenesisCID.String()).All()
+	defer discard(&err)
+
+	var latest maybe.Value[documentGeneration]
+	for row := range rows {
+		var g documentGeneration
+		if err := g.fromRow(row); err != nil {
+			return dg, err
+		}
+
+		if !latest.IsSet() {
+			latest = maybe.New(g)
+		}
+
+		// Check if any of our version heads are in this generation's changes.
+		if g.Changes != nil && slices.ContainsFunc(headIDs, func(h int64) bool { return g.Changes.Contains(uint64(h)) }) { //nolint:gosec // We know this should not overflow.
+			dg = maybe.New(g)
+			break
+		}
+	}
+	if err := check(); err != nil {
+		return dg, err
+	}
+
+	if !dg.IsSet() {
+		if !latest.IsSet() {
+			return dg, nil
+		}
+		dg = latest
+	}
+
+	// The heads that were never published must come from the branches of the document.
+	unpublished := make([]int64, 0, len(headIDs))
+	for _, h := range headIDs {
+		if dg.Value().Changes == nil || !dg.Value().Changes.Contains(uint64(h)) { //nolint:gosec // We know this should not overflow.
+			unpublished = append(unpublished, h)
+		}
+	}
+
+	if len(unpublished) == 0 {
+		return dg, nil
+	}
+
+	unpublishedJSON, err := json.Marshal(unpublished)
+	if err != nil {
+		return dg, err
+	}
+
+	var (
+		reachable int
+		private   bool
+	)
+	if err := sqlitex.Exec(conn, qBranchChangesVisibility(), func(stmt *sqlite.Stmt) error {
+		reachable++
+		private = private || stmt.ColumnInt(1) != 0
+		return nil
+	}, resource, versionGenesis, unsafeutil.StringFromBytes(unpublishedJSON)); err != nil {
+		return dg, err
+	}
+
+	if reachable != len(unpublished) {
+		return maybe.Value[documentGeneration]{}, nil
+	}
+
+	if private {
+		g := dg.Value()
+		g.Visibility = VisibilityPrivate
+		dg = maybe.New(g)
+	}
+
+	return dg, nil
+}
+
+// qBranchChangesVisibility returns the given changes that are reachable from the branch Refs of the resource,
+// and whether they are only reachable from private branches.
+var qBranchChangesVisibility = dqb.Str(`
+	WITH RECURSIVE
+	changes (id, private) AS (
+		SELECT bl.target, sb.extra_attrs->>'visibility' IS 'Private'
+		FROM structural_blobs sb
+		JOIN resources r ON r.id = sb.resource
+		JOIN blob_links bl ON bl.source = sb.id AND bl.type = 'ref/head'
+		WHERE sb.type = 'Ref'
+		AND r.iri = :resource
+		AND sb.genesis_blob = :genesis
+		AND sb.extra_attrs->>'branch' IS NOT NULL
+		UNION
+		SELECT blob_links.target, changes.private
+		FROM blob_links
+		JOIN changes ON changes.id = blob_links.source
+		WHERE blob_links.type = 'change/dep'
+	)
+	SELECT id, min(private)
+	FROM changes
+	WHERE id IN (SELECT value FROM json_each(:heads))
+	GROUP BY id
+`)
+
 // changesFromHeadIDsConn loads all changes reachable from the given head
 // change ids (e.g. a documentGeneration's merged head set), in causal
 // (timestamp) order, using the provided connection. Unlike IterChanges it
@@ -984,14 +1172,29 @@ func (idx *Index) IterChanges(ctx context.Context, resource IRI, heads []cid.Cid
 // The returned records carry the given generation so the caller can rebuild an
 // in-memory docmodel without a second lookup.
 func changesFromHeadIDsConn(conn *sqlite.Conn, bs *blockStore, headIDs []int64, generation int64) (_ []ChangeRecord, err error) {
+	return changesBetweenIDsConn(conn, bs, headIDs, nil, generation)
+}
+
+// changesBetweenIDsConn is like changesFromHeadIDsConn,
+// but it skips the changes reachable from the base change ids.
+func changesBetweenIDsConn(conn *sqlite.Conn, bs *blockStore, headIDs, baseIDs []int64, generation int64) (_ []ChangeRecord, err error) {
 	headsJSON, err := json.Marshal(headIDs)
 	if err != nil {
 		return nil, err
 	}
 
+	if baseIDs == nil {
+		baseIDs = []int64{}
+	}
+
+	baseJSON, err := json.Marshal(baseIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	var out []ChangeRecord
 	buf := make([]byte, 0, 1024*1024) // preallocating 1MB for decompression.
-	rows, discard, check := sqlitex.Query(conn, qIterChangesFromHeads(), unsafeutil.StringFromBytes(headsJSON)).All()
+	rows, discard, check := sqlitex.Query(conn, qIterChangesBetween(), unsafeutil.StringFromBytes(headsJSON), unsafeutil.StringFromBytes(baseJSON)).All()
 	defer discard(&err)
 	for row := range rows {
 		next := sqlite.NewIncrementor(0)
@@ -1020,6 +1223,11 @@ func changesFromHeadIDsConn(conn *sqlite.Conn, bs *blockStore, headIDs []int64,
 			break
 		}
 
+		if derr := bs.openChange(conn, ch); derr != nil {
+			err = errors.Join(err, fmt.Errorf("changesFromHeadIDsConn: failed to decrypt change %s: %w", chcid, derr))
+			break
+		}
+
 		out = append(out, ChangeRecord{
 			CID:        chcid,
 			Data:       ch,
@@ -1064,12 +1272,18 @@ func (idx *Index) IsValidAgent(ctx context.Context, space, agent core.Principal)
 		return false, err
 	}
 
-	valid, err = isValidAgentKey(conn, spaceID, agentID)
+	valid, err = isValidAgentKey(conn, spaceID, agentID, time.Now().UnixMilli())
 	return valid, err
 }
 
-// IsValidWriter checks whether a key is allowed to write into a given space and path.
+// IsValidWriter checks whether a key is currently allowed to write into a given space and path.
 func (idx *Index) IsValidWriter(ctx context.Context, space core.Principal, path string, writer core.Principal) (valid bool, err error) {
+	return idx.IsValidWriterAt(ctx, space, path, writer, time.Now())
+}
+
+// IsValidWriterAt checks whether a key is allowed to write into a given space and path at the given time.
+// The time is normally the timestamp of the change being authorized.
+func (idx *Index) IsValidWriterAt(ctx context.Context, space core.Principal, path string, writer core.Principal, ts time.Time) (valid bool, err error) {
 	if space.Equal(writer) {
 		return true, nil
 	}
@@ -1093,7 +1307,7 @@ func (idx *Index) IsValidWriter(ctx context.Context, space core.Principal, path
 	}
 
 	// Standalone read path (no indexing transaction in flight): no cache.
-	valid, err = isValidWriter(conn, writerID, iri, nil)
+	valid, err = isValidWriter(conn, writerID, iri, ts.UnixMilli(), nil)
 	return valid, err
 }
 
@@ -1162,66 +1376,141 @@ func cidsToDBIDs(conn *sqlite.Conn, cids []cid.Cid) ([]int64, error) {
 // breadcrumbs) tuple — profiled as the dominant cold-sync persist cost. The cache
 // collapses those repeats to a single query.
 //
+// Since capabilities can be revoked, validity depends on the timestamp of the
+// blob being checked, so the cache stores the grant windows for the tuple rather
+// than a boolean, and every Ref with a different timestamp can still be served
+// from the same entry.
+//
 // Scope and safety: the cache is confined to one batch transaction, which runs
 // single-threaded over a consistent SQLite snapshot — so it needs no locking and
 // can never serve a result from another connection's uncommitted state. It is
-// cleared whenever a Capability blob is indexed in the same batch (see
-// indexCapability). That invalidation is keyed on the blob *type*, so it already
-// covers any future capability change — including revocation — meaning a cached
-// "valid" can never outlive the grant that justified it. A nil cache disables
-// memoization and is passed by the read-only / standalone validation paths.
+// cleared whenever a Capability, a Revocation, or a KeyRotation blob is indexed in the same batch
+// (see indexCapability, indexRevocation, and indexKeyRotation), so cached grants can never outlive
+// the blobs that justified them. A nil cache disables memoization and is passed
+// by the read-only / standalone validation paths.
 type writerValidityCache struct {
-	m map[string]bool
+	m        map[string]writerGrants
+	lineages map[int64]keyLineage
+}
+
+// grantWindow is the period of time in which a capability (or a chain of them) authorizes its delegate.
+// From is inclusive, and it's the latest not-before time in the chain, or 0 if none of the capabilities has one.
+// Until is exclusive, and it's the earliest of the expire times and revocations in the chain,
+// or math.MaxInt64 if nothing in the chain expires or was revoked.
+// Both are timestamps in unix millis.
+type grantWindow struct {
+	From  int64
+	Until int64
+}
+
+func (w grantWindow) contains(ts int64) bool {
+	return w.From <= ts && ts < w.Until
+}
+
+// writerGrants are the grant windows a writer holds for a given resource.
+// Agent windows are expensive to compute, so they are only loaded when none of the direct ones match.
+type writerGrants struct {
+	Direct      []grantWindow
+	Agent       []grantWindow
+	AgentLoaded bool
+}
+
+func (g writerGrants) validAt(ts int64) bool {
+	for _, w := range g.Direct {
+		if w.contains(ts) {
+			return true
+		}
+	}
+	for _, w := range g.Agent {
+		if w.contains(ts) {
+			return true
+		}
+	}
+	return false
 }
 
 func newWriterValidityCache() *writerValidityCache {
-	return &writerValidityCache{m: make(map[string]bool)}
+	return &writerValidityCache{m: make(map[string]writerGrants), lineages: make(map[int64]keyLineage)}
 }
 
 // writerCacheKey builds the writerValidityCache key from the exact inputs that
-// determine an isValidWriter result: the owner and writer DB ids and the
+// determine the grants of a writer: the owner and writer DB ids and the
 // resource breadcrumbs JSON (all three are the bind args of the writer queries).
 func writerCacheKey(ownerID, writerID int64, parentsJSON string) string {
 	return strconv.FormatInt(ownerID, 10) + "|" + strconv.FormatInt(writerID, 10) + "|" + parentsJSON
 }
 
-func (c *writerValidityCache) get(key string) (valid bool, ok bool) {
+func (c *writerValidityCache) get(key string) (g writerGrants, ok bool) {
+	if c == nil {
+		return g, false
+	}
+	g, ok = c.m[key]
+	return g, ok
+}
+
+func (c *writerValidityCache) put(key string, g writerGrants) {
 	if c == nil {
-		return false, false
+		return
 	}
-	v, ok := c.m[key]
-	return v, ok
+	c.m[key] = g
 }
 
-func (c *writerValidityCache) put(key string, valid bool) {
+func (c *writerValidityCache) getLineage(keyID int64) (kl keyLineage, ok bool) {
+	if c == nil {
+		return kl, false
+	}
+	kl, ok = c.lineages[keyID]
+	return kl, ok
+}
+
+func (c *writerValidityCache) putLineage(keyID int64, kl keyLineage) {
 	if c == nil {
 		return
 	}
-	c.m[key] = valid
+	c.lineages[keyID] = kl
 }
 
-// clear drops all memoized results. Called when a Capability blob is indexed,
-// because that is the only event that can change a writer-validity outcome.
+// clear drops all memoized results. Called when a Capability, a Revocation, or a KeyRotation blob is indexed,
+// because those are the only events that can change a writer-validity outcome.
 func (c *writerValidityCache) clear() {
 	if c == nil {
 		return
 	}
 	clear(c.m)
+	clear(c.lineages)
+}
+
+// isValidWriter checks whether writerID is allowed to write into resource at time ts (unix millis).
+func isValidWriter(conn *sqlite.Conn, writerID int64, resource IRI, ts int64, wc *writerValidityCache) (valid bool, err error) {
+	valid, _, err = checkWriter(conn, writerID, resource, ts, wc)
+	return valid, err
 }
 
-func isValidWriter(conn *sqlite.Conn, writerID int64, resource IRI, wc *writerValidityCache) (valid bool, err error) {
+// checkWriter is like isValidWriter, but it also reports whether the writer held any grant on the resource at all,
+// regardless of the time. This allows to distinguish revoked writers from writers that were never granted anything.
+func checkWriter(conn *sqlite.Conn, writerID int64, resource IRI, ts int64, wc *writerValidityCache) (valid, hasGrants bool, err error) {
 	owner, _, err := resource.SpacePath()
 	if err != nil {
-		return false, err
+		return false, false, err
 	}
 
 	ownerID, err := DbPublicKeysLookupID(conn, owner)
 	if err != nil {
-		return false, err
+		return false, false, err
 	}
 
-	if ownerID == writerID {
-		return true, nil
+	owners, writer, err := loadWriterLineages(conn, ownerID, writerID, wc)
+	if err != nil {
+		return false, false, err
+	}
+
+	if e, ok := owners.epochOf(writerID); ok {
+		return e.contains(ts), true, nil
+	}
+
+	// Keys that were rotated away can't write anything after the rotation.
+	if writer.retiredAt(ts) {
+		return false, true, nil
 	}
 
 	parentsJSON := unsafeutil.StringFromBytes(
@@ -1230,11 +1519,15 @@ func isValidWriter(conn *sqlite.Conn, writerID int64, resource IRI, wc *writerVa
 		),
 	)
 
-	// (ownerID, writerID, parentsJSON) are exactly the bind args of both writer
-	// queries below, so they fully determine the result and form the cache key.
+	// (ownerID, writerID, parentsJSON) fully determine the bind args of both writer
+	// queries below (the rest is derived from the key rotations), so they form the cache key.
 	cacheKey := writerCacheKey(ownerID, writerID, parentsJSON)
-	if v, ok := wc.get(cacheKey); ok {
-		return v, nil
+	g, ok := wc.get(cacheKey)
+	if !ok {
+		g.Direct, err = loadLineageGrantWindows(conn, qIsValidWriterDirect(), owners, writer, parentsJSON)
+		if err != nil {
+			return false, false, err
+		}
 	}
 
 	// Two-step instead of a single UNION. The direct-delegation branch
@@ -1245,32 +1538,106 @@ func isValidWriter(conn *sqlite.Conn, writerID int64, resource IRI, wc *writerVa
 	// matched — which dominated cold structure-sync wall-clock (~6ms/Ref). Check
 	// direct first and only fall back on a miss; semantics are identical
 	// (valid = direct OR agent).
-	valid, err = capabilityRowExists(conn, qIsValidWriterDirect(), ownerID, writerID, parentsJSON)
-	if err != nil {
-		return false, err
-	}
-	if !valid {
-		valid, err = capabilityRowExists(conn, qIsValidWriterAgent(), ownerID, writerID, parentsJSON)
+	if !g.validAt(ts) && !g.AgentLoaded {
+		g.Agent, err = loadLineageGrantWindows(conn, qIsValidWriterAgent(), owners, writer, parentsJSON)
 		if err != nil {
-			return false, err
+			return false, false, err
 		}
+		g.AgentLoaded = true
 	}
-	wc.put(cacheKey, valid)
-	return valid, nil
+	wc.put(cacheKey, g)
+
+	return g.validAt(ts), len(g.Direct) > 0 || len(g.Agent) > 0, nil
 }
 
-// capabilityRowExists reports whether the capability lookup query (taking owner,
-// writer, breadcrumbs as ?1,?2,?3) returns at least one row.
-func capabilityRowExists(conn *sqlite.Conn, query string, args ...any) (found bool, err error) {
+// loadGrantWindows runs the capability lookup query (taking owner, writer, breadcrumbs as ?1,?2,?3),
+// which must return the start and the end of the grant window as the first two columns.
+func loadGrantWindows(conn *sqlite.Conn, query string, args ...any) (out []grantWindow, err error) {
 	rows, discard, check := sqlitex.Query(conn, query, args...).All()
 	defer discard(&err)
-	for range rows {
-		found = true
-		break
+	for row := range rows {
+		out = append(out, grantWindow{From: row.ColumnInt64(0), Until: row.ColumnInt64(1)})
 	}
 
 	err = errors.Join(err, check())
-	return found, err
+	return out, err
+}
+
+// loadWriterLineages loads the key rotations of the owner of a space and of a writer.
+func loadWriterLineages(conn *sqlite.Conn, ownerID, writerID int64, wc *writerValidityCache) (owners, writer keyLineage, err error) {
+	owners, err = loadKeyLineage(conn, ownerID, wc)
+	if err != nil {
+		return owners, writer, err
+	}
+
+	writer, err = loadKeyLineage(conn, writerID, wc)
+	return owners, writer, err
+}
+
+// loadLineageGrantWindows is like loadGrantWindows, but it takes key rotations into account:
+// capabilities issued by any of the keys in charge of the space count,
+// and the writer inherits the capabilities granted to the keys it was rotated from.
+func loadLineageGrantWindows(conn *sqlite.Conn, query string, owners, writer keyLineage, parentsJSON string) (out []grantWindow, err error) {
+	for _, issuer := range owners.Epochs {
+		for _, holder := range append([]int64{writer.Epochs[0].Key}, writer.Predecessors...) {
+			windows, err := loadGrantWindows(conn, query, issuer.Key, holder, parentsJSON)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, windows...)
+		}
+	}
+
+	return out, nil
+}
+
+// isValidCommenter checks whether signerID is allowed to comment on resource at time ts (unix millis).
+// Anyone who can write into the resource can also comment on it.
+func isValidCommenter(conn *sqlite.Conn, signerID int64, resource IRI, ts int64) (valid bool, err error) {
+	owner, _, err := resource.SpacePath()
+	if err != nil {
+		return false, err
+	}
+
+	ownerID, err := DbPublicKeysLookupID(conn, owner)
+	if err != nil {
+		return false, err
+	}
+
+	owners, signer, err := loadWriterLineages(conn, ownerID, signerID, nil)
+	if err != nil {
+		return false, err
+	}
+
+	if e, ok := owners.epochOf(signerID); ok {
+		return e.contains(ts), nil
+	}
+
+	if signer.retiredAt(ts) {
+		return false, nil
+	}
+
+	parentsJSON := unsafeutil.StringFromBytes(
+		must.Do2(
+			json.Marshal(resource.Breadcrumbs()),
+		),
+	)
+
+	// Same two-step approach as in checkWriter.
+	var g writerGrants
+	g.Direct, err = loadLineageGrantWindows(conn, qIsValidCommenterDirect(), owners, signer, parentsJSON)
+	if err != nil {
+		return false, err
+	}
+
+	if !g.validAt(ts) {
+		g.Agent, err = loadLineageGrantWindows(conn, qIsValidCommenterAgent(), owners, signer, parentsJSON)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return g.validAt(ts), nil
 }
 
 // CanWriteRootInDB checks whether writer is allowed to write to the root of space using an existing database connection.
@@ -1282,15 +1649,15 @@ func CanWriteRootInDB(conn *sqlite.Conn, space core.Principal, writer core.Princ
 	return IsValidWriterInDB(conn, iri, writer)
 }
 
-// canWriteRootByIDInDB checks whether writer can write to the root of ownerID's space.
-func canWriteRootByIDInDB(conn *sqlite.Conn, ownerID int64, writer core.Principal) (bool, error) {
+// canWriteRootByIDInDB checks whether writer can write to the root of ownerID's space at time ts (unix millis).
+func canWriteRootByIDInDB(conn *sqlite.Conn, ownerID int64, writer core.Principal, ts int64) (bool, error) {
 	writerID, err := DbPublicKeysLookupID(conn, writer)
 	if err != nil {
 		return false, err
 	}
 
 	var valid bool
-	rows, discard, check := sqlitex.Query(conn, qCanWriteRootByID(), ownerID, writerID).All()
+	rows, discard, check := sqlitex.Query(conn, qCanWriteRootByID(), ownerID, writerID, ts).All()
 	defer discard(&err)
 	for range rows {
 		valid = true
@@ -1307,15 +1674,28 @@ func IsValidWriterInDB(conn *sqlite.Conn, resource IRI, writer core.Principal) (
 	if err != nil {
 		return false, err
 	}
-	return isValidWriter(conn, writerID, resource, nil)
+	return isValidWriter(conn, writerID, resource, time.Now().UnixMilli(), nil)
 }
 
 // SQLCanWriteRootByOwnerID returns a SQL EXISTS expression that checks root write access.
-func SQLCanWriteRootByOwnerID(ownerIDExpr string) string {
-	return fmt.Sprintf(sqlCanWriteRootByOwnerID, ownerIDExpr)
+// The capabilities are checked to be valid at tsExpr, which must evaluate to a timestamp in unix millis.
+// Use SQLNowMillis to check the access at the current time.
+// The returned expression takes the caller principal as a positional parameter.
+func SQLCanWriteRootByOwnerID(ownerIDExpr, tsExpr string) string {
+	return fmt.Sprintf(sqlCanAccessRootByOwnerID, ownerIDExpr, tsExpr, sqlWriterRoles)
+}
+
+// SQLCanReadRootByOwnerID is like SQLCanWriteRootByOwnerID, but it checks root read access,
+// i.e. it also accepts READER and COMMENTER capabilities.
+func SQLCanReadRootByOwnerID(ownerIDExpr, tsExpr string) string {
+	return fmt.Sprintf(sqlCanAccessRootByOwnerID, ownerIDExpr, tsExpr, sqlReaderRoles)
 }
 
-const sqlCanWriteRootByOwnerID = `EXISTS (
+// SQLNowMillis is an SQL expression with the current time in unix millis.
+// SQLite evaluates it once per statement, so it's stable within a single query.
+const SQLNowMillis = "CAST(unixepoch('subsec') * 1000 AS INTEGER)"
+
+var sqlCanAccessRootByOwnerID = `EXISTS (
 	SELECT 1
 	FROM public_keys caller
 	WHERE caller.principal = ?
@@ -1330,7 +1710,8 @@ const sqlCanWriteRootByOwnerID = `EXISTS (
 			AND direct.type = 'Capability'
 			AND direct.author = %[1]s
 			AND direct.extra_attrs->>'del' = caller.id
-			AND direct.extra_attrs->>'role' IN ('WRITER', 'AGENT')
+			AND direct.extra_attrs->>'role' IN (%[3]s)
+			AND ` + sqlGrantActiveAt("direct", "%[2]s") + `
 		)
 		OR EXISTS (
 			SELECT 1
@@ -1338,6 +1719,7 @@ const sqlCanWriteRootByOwnerID = `EXISTS (
 			WHERE agent.type = 'Capability'
 			AND agent.extra_attrs->>'del' = caller.id
 			AND agent.extra_attrs->>'role' = 'AGENT'
+			AND ` + sqlGrantActiveAt("agent", "%[2]s") + `
 			AND (
 				agent.author = %[1]s
 				OR EXISTS (
@@ -1349,7 +1731,8 @@ const sqlCanWriteRootByOwnerID = `EXISTS (
 					AND parent_writer.type = 'Capability'
 					AND parent_writer.author = %[1]s
 					AND parent_writer.extra_attrs->>'del' = agent.author
-					AND parent_writer.extra_attrs->>'role' IN ('WRITER', 'AGENT')
+					AND parent_writer.extra_attrs->>'role' IN (%[3]s)
+					AND ` + sqlGrantActiveAt("parent_writer", "%[2]s") + `
 				)
 			)
 		)
@@ -1357,6 +1740,7 @@ const sqlCanWriteRootByOwnerID = `EXISTS (
 )`
 
 var qCanWriteRootByID = dqb.Str(`
+	-- owner, writer, ts
 	SELECT 1
 	WHERE ?1 = ?2
 	OR EXISTS (
@@ -1369,6 +1753,7 @@ var qCanWriteRootByID = dqb.Str(`
 		AND direct.author = ?1
 		AND direct.extra_attrs->>'del' = ?2
 		AND direct.extra_attrs->>'role' IN ('WRITER', 'AGENT')
+		AND ` + sqlGrantActiveAt("direct", "?3") + `
 	)
 	OR EXISTS (
 		SELECT 1
@@ -1376,6 +1761,7 @@ var qCanWriteRootByID = dqb.Str(`
 		WHERE agent.type = 'Capability'
 		AND agent.extra_attrs->>'del' = ?2
 		AND agent.extra_attrs->>'role' = 'AGENT'
+		AND ` + sqlGrantActiveAt("agent", "?3") + `
 		AND (
 			agent.author = ?1
 			OR EXISTS (
@@ -1388,66 +1774,133 @@ var qCanWriteRootByID = dqb.Str(`
 				AND parent_writer.author = ?1
 				AND parent_writer.extra_attrs->>'del' = agent.author
 				AND parent_writer.extra_attrs->>'role' IN ('WRITER', 'AGENT')
+				AND ` + sqlGrantActiveAt("parent_writer", "?3") + `
 			)
 		)
 	)
 `)
 
+// SQL lists of capability roles that grant a given access level.
+// Each level includes the ones above it, i.e. writers can always comment, and commenters can always read.
+const (
+	sqlWriterRoles    = `'WRITER', 'AGENT'`
+	sqlCommenterRoles = sqlWriterRoles + `, 'COMMENTER'`
+	sqlReaderRoles    = sqlCommenterRoles + `, 'READER'`
+)
+
 // qIsValidWriter is split into a cheap direct-delegation check and an expensive
 // transitive AGENT-chain check (see isValidWriter for why). The two together are
 // equivalent to the previous single UNION query: valid = direct OR agent.
+// Both return the grant window for every matching capability chain.
 
 // qIsValidWriterDirect matches when the space owner delegated WRITER/AGENT
 // directly to the writer, scoped to the resource or one of its ancestors. This
 // is a single seek on capabilities_by_delegate and covers the overwhelmingly
 // common case.
-var qIsValidWriterDirect = dqb.Str(`
-	-- owner, writer, breadcrumbs
-	SELECT 1 AS valid
+var qIsValidWriterDirect = dqb.Str(sqlGrantsDirect(sqlWriterRoles))
+
+// qIsValidWriterAgent matches the transitive case: an AGENT capability delegates
+// to the writer, and the agent's author is either the owner or itself holds a
+// WRITER/AGENT capability from the owner (the parent_writer chain). This is the
+// expensive branch (join + json_each); it only runs when the direct check misses.
+var qIsValidWriterAgent = dqb.Str(sqlGrantsAgent(sqlWriterRoles))
+
+// qIsValidCommenterDirect and qIsValidCommenterAgent are the same as the writer queries,
+// but they also accept COMMENTER capabilities.
+var (
+	qIsValidCommenterDirect = dqb.Str(sqlGrantsDirect(sqlCommenterRoles))
+	qIsValidCommenterAgent  = dqb.Str(sqlGrantsAgent(sqlCommenterRoles))
+)
+
+// sqlGrantsDirect returns the query for the grant windows of capabilities with one of the roles
+// issued by the owner directly to the delegate.
+func sqlGrantsDirect(roles string) string {
+	return `
+	-- owner, delegate, breadcrumbs
+	SELECT
+		` + sqlGrantFrom("direct") + ` AS from_ts,
+		` + sqlGrantUntil("direct") + ` AS until
 	FROM structural_blobs direct
 	WHERE direct.type = 'Capability'
 	AND direct.author = ?1
 	AND direct.extra_attrs->>'del' = ?2
-	AND direct.extra_attrs->>'role' IN ('WRITER', 'AGENT')
+	AND direct.extra_attrs->>'role' IN (` + roles + `)
 	AND direct.resource IN (
 		SELECT r.id
 		FROM resources r
 		JOIN json_each(?3) each ON each.value = r.iri
 	)
-`)
+`
+}
 
-// qIsValidWriterAgent matches the transitive case: an AGENT capability delegates
-// to the writer, and the agent's author is either the owner or itself holds a
-// WRITER/AGENT capability from the owner (the parent_writer chain). This is the
-// expensive branch (correlated subquery + json_each); it only runs when the
-// direct check misses.
-var qIsValidWriterAgent = dqb.Str(`
-	-- owner, writer, breadcrumbs
-	SELECT 1 AS valid
+// sqlGrantsAgent returns the query for the grant windows of AGENT capabilities
+// issued to the delegate by the owner, or by someone holding a capability with one of the roles from the owner.
+func sqlGrantsAgent(roles string) string {
+	return `
+	-- owner, delegate, breadcrumbs
+	SELECT
+		` + sqlGrantFrom("agent") + ` AS from_ts,
+		` + sqlGrantUntil("agent") + ` AS until
 	FROM structural_blobs agent
 	WHERE agent.type = 'Capability'
 	AND agent.extra_attrs->>'del' = ?2
 	AND agent.extra_attrs->>'role' = 'AGENT'
-	AND (
-		agent.author = ?1
-		OR EXISTS (
-			SELECT 1
-			FROM structural_blobs parent_writer
-			WHERE parent_writer.type = 'Capability'
-			AND parent_writer.author = ?1
-			AND parent_writer.extra_attrs->>'del' = agent.author
-			AND parent_writer.extra_attrs->>'role' IN ('WRITER', 'AGENT')
-			AND parent_writer.resource IN (
-				SELECT r.id
-				FROM resources r
-				JOIN json_each(?3) each ON each.value = r.iri
-			)
-		)
+	AND agent.author = ?1
+	UNION ALL
+	SELECT
+		MAX(` + sqlGrantFrom("agent") + `, ` + sqlGrantFrom("parent_writer") + `) AS from_ts,
+		MIN(` + sqlGrantUntil("agent") + `, ` + sqlGrantUntil("parent_writer") + `) AS until
+	FROM structural_blobs agent
+	JOIN structural_blobs parent_writer ON parent_writer.extra_attrs->>'del' = agent.author
+	WHERE agent.type = 'Capability'
+	AND agent.extra_attrs->>'del' = ?2
+	AND agent.extra_attrs->>'role' = 'AGENT'
+	AND agent.author != ?1
+	AND parent_writer.type = 'Capability'
+	AND parent_writer.author = ?1
+	AND parent_writer.extra_attrs->>'role' IN (` + roles + `)
+	AND parent_writer.resource IN (
+		SELECT r.id
+		FROM resources r
+		JOIN json_each(?3) each ON each.value = r.iri
 	)
-`)
+`
+}
+
+// sqlRevokedAt returns an SQL expression with the timestamp of the earliest revocation
+// of the capability identified by capID, or the max int64 value if it was never revoked.
+func sqlRevokedAt(capID string) string {
+	return `IFNULL((
+		SELECT MIN(rv.ts)
+		FROM blob_links rl
+		JOIN structural_blobs rv ON rv.id = rl.source
+		WHERE rl.target = ` + capID + `
+		AND rl.type = 'revocation/capability'
+	), 9223372036854775807)`
+}
+
+// sqlGrantFrom returns an SQL expression with the not-before time of the capability
+// aliased as capAlias, or 0 if it doesn't have one.
+func sqlGrantFrom(capAlias string) string {
+	return `IFNULL(` + capAlias + `.extra_attrs->>'nbf', 0)`
+}
 
-func isValidAgentKey(conn *sqlite.Conn, parentID int64, delegateID int64) (valid bool, err error) {
-	rows, discard, check := sqlitex.Query(conn, qIsValidAgentKey(), parentID, delegateID).All()
+// sqlGrantUntil returns an SQL expression with the end of the validity of the capability aliased as capAlias,
+// i.e. the earliest of its expire time and revocations, or the max int64 value if it's not bounded.
+func sqlGrantUntil(capAlias string) string {
+	return `MIN(IFNULL(` + capAlias + `.extra_attrs->>'exp', 9223372036854775807), ` + sqlRevokedAt(capAlias+".id") + `)`
+}
+
+// sqlGrantActiveAt returns an SQL condition that checks whether the capability aliased as capAlias
+// is valid at tsExpr (unix millis).
+func sqlGrantActiveAt(capAlias, tsExpr string) string {
+	return `(` + sqlGrantFrom(capAlias) + ` <= ` + tsExpr + ` AND ` + sqlGrantUntil(capAlias) + ` > ` + tsExpr + `)`
+}
+
+// isValidAgentKey checks whether delegateID holds an AGENT capability from parentID
+// that is valid at time ts (unix millis).
+func isValidAgentKey(conn *sqlite.Conn, parentID int64, delegateID int64, ts int64) (valid bool, err error) {
+	rows, discard, check := sqlitex.Query(conn, qIsValidAgentKey(), parentID, delegateID, ts).All()
 	defer discard(&err)
 	for range rows {
 		valid = true
@@ -1460,11 +1913,12 @@ func isValidAgentKey(conn *sqlite.Conn, parentID int64, delegateID int64) (valid
 
 var qIsValidAgentKey = dqb.Str(`
 	SELECT 1
-	FROM structural_blobs
-	WHERE type = 'Capability'
-	AND author = :issuer
-	AND extra_attrs->>'del' = :delegate
-	AND extra_attrs->>'role' = 'AGENT'
+	FROM structural_blobs sb
+	WHERE sb.type = 'Capability'
+	AND sb.author = :issuer
+	AND sb.extra_attrs->>'del' = :delegate
+	AND sb.extra_attrs->>'role' = 'AGENT'
+	AND ` + sqlGrantActiveAt("sb", ":ts") + `
 	LIMIT 1
 `)
 
@@ -1480,6 +1934,7 @@ type generation struct {
 }
 
 // WalkCapabilities walks through capabilities for a specific resource.
+// Revoked and already expired capabilities are skipped.
 func (idx *Index) WalkCapabilities(ctx context.Context, resource IRI, author core.Principal, fn func(cid.Cid, *Capability) error) error {
 	conn, release, err := idx.db.ReadConn(ctx)
 	if err != nil {
@@ -1530,10 +1985,13 @@ var qWalkCapabilities = dqb.Str(`
 	WHERE sb.type = 'Capability'
 	AND sb.resource IN (SELECT id FROM resources WHERE :iri BETWEEN iri AND iri || '~~~~~~')
 	AND sb.author = (SELECT id FROM public_keys WHERE principal = :author)
+	AND NOT EXISTS (SELECT 1 FROM blob_links WHERE target = sb.id AND type = 'revocation/capability')
+	AND IFNULL(sb.extra_attrs->>'exp', 9223372036854775807) > ` + SQLNowMillis + `
 	ORDER BY sb.ts
 `)
 
 // WalkCapabilitiesForDelegate walks through capabilities for a specific delegate.
+// Revoked and already expired capabilities are skipped.
 func (idx *Index) WalkCapabilitiesForDelegate(ctx context.Context, delegate core.Principal, fn func(cid.Cid, *Capability) error) error {
 	conn, release, err := idx.db.ReadConn(ctx)
 	if err != nil {
@@ -1576,6 +2034,7 @@ func (idx *Index) WalkCapabilitiesForDelegate(ctx context.Context, delegate core
 
 // WalkAllAccountCapabilities walks through all capabilities for a given account across all document paths.
 // This is used when the caller wants to list site-wide collaborators.
+// Revoked and already expired capabilities are skipped.
 func (idx *Index) WalkAllAccountCapabilities(ctx context.Context, accountIRI IRI, author core.Principal, fn func(cid.Cid, *Capability) error) error {
 	conn, release, err := idx.db.ReadConn(ctx)
 	if err != nil {
@@ -1627,6 +2086,8 @@ var qWalkAllAccountCapabilities = dqb.Str(`
 	WHERE sb.type = 'Capability'
 	AND sb.resource IN (SELECT id FROM resources WHERE iri BETWEEN :iri AND :iri_end)
 	AND sb.author = (SELECT id FROM public_keys WHERE principal = :author)
+	AND NOT EXISTS (SELECT 1 FROM blob_links WHERE target = sb.id AND type = 'revocation/capability')
+	AND IFNULL(sb.extra_attrs->>'exp', 9223372036854775807) > ` + SQLNowMillis + `
 	ORDER BY sb.ts
 `)
 
@@ -1639,6 +2100,8 @@ var qWalkCapabilitiesForDelegate = dqb.Str(`
 	JOIN blobs b ON b.id = sb.id
 	WHERE sb.type = 'Capability'
 	AND sb.extra_attrs->>'del' = (SELECT id FROM public_keys WHERE principal = :delegate)
+	AND NOT EXISTS (SELECT 1 FROM blob_links WHERE target = sb.id AND type = 'revocation/capability')
+	AND IFNULL(sb.extra_attrs->>'exp', 9223372036854775807) > ` + SQLNowMillis + `
 	ORDER BY sb.ts
 `)
 
@@ -1753,6 +2216,57 @@ var qStashBlob = dqb.Str(`
 	INSERT OR IGNORE INTO stashed_blobs (id, reason, extra_attrs) VALUES (?, ?, ?);
 `)
 
+// StashedBlob is a blob that is stored, but couldn't be indexed yet.
+// Stashed blobs are indexed again when the blobs or permissions they are waiting for arrive.
+type StashedBlob struct {
+	CID           cid.Cid
+	Reason        string
+	MissingBlobs  []cid.Cid
+	DeniedSigners []core.Principal
+	Details       string
+}
+
+// StashedBlobs returns the stash records of the given blobs.
+// Blobs that are not stashed are omitted, and blobs stashed for multiple reasons are returned multiple times.
+func (idx *Index) StashedBlobs(ctx context.Context, cids []cid.Cid) ([]StashedBlob, error) {
+	conn, release, err := idx.db.ReadConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var out []StashedBlob
+	for _, c := range cids {
+		if err := sqlitex.Exec(conn, qLoadStashedBlob(), func(row *sqlite.Stmt) error {
+			var meta stashMetadata
+			if err := json.Unmarshal(row.ColumnBytesUnsafe(1), &meta); err != nil {
+				return fmt.Errorf("failed to decode stash metadata of blob %s: %w", c, err)
+			}
+
+			out = append(out, StashedBlob{
+				CID:           c,
+				Reason:        row.ColumnText(0),
+				MissingBlobs:  meta.MissingBlobs,
+				DeniedSigners: meta.DeniedSigners,
+				Details:       meta.Details,
+			})
+			return nil
+		}, c.Hash()); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+var qLoadStashedBlob = dqb.Str(`
+	SELECT s.reason, s.extra_attrs
+	FROM blobs b
+	JOIN stashed_blobs s ON s.id = b.id
+	WHERE b.multihash = :multihash
+	ORDER BY s.reason
+`)
+
 func (idx *indexingCtx) SaveBlob(sb structuralBlob) error {
 	var (
 		blobAuthor   maybe.Value[int64]
@@ -31,6 +31,11 @@ func (idx *Index) ClearPeer(pid peer.ID) {
 	delete(idx.allowlistEntries, pid)
 }
 
+// IsPeerAuthenticated checks if a peer has authenticated with a specific account.
+func (idx *Index) IsPeerAuthenticated(peerID peer.ID, account core.Principal) bool {
+	return idx.peerAuth.isAuthenticated(peerID, account)
+}
+
 // isAuthenticated checks if a peer has authenticated with a specific account.
 func (idx *Index) isAuthenticated(peerID peer.ID, account core.Principal) bool {
 	return idx.peerAuth.isAuthenticated(peerID, account)
@@ -275,7 +280,7 @@ func (idx *Index) isBlobPublic(ctx context.Context, c cid.Cid) (resp struct {
 
 // GetSpacesByAccount returns the spaces each account can access.
 // This includes each account's own space plus any spaces where the account has
-// been granted access via WRITER capabilities.
+// been granted access via WRITER, COMMENTER, or READER capabilities.
 func (idx *Index) GetSpacesByAccount(ctx context.Context, accounts []core.Principal) (map[core.PrincipalUnsafeString][]core.Principal, error) {
 	spacesByAccount := make(map[core.PrincipalUnsafeString][]core.Principal, len(accounts))
 	if len(accounts) == 0 {
@@ -296,13 +301,13 @@ func (idx *Index) GetSpacesByAccount(ctx context.Context, accounts []core.Princi
 		// Query capabilities where any of the accounts is a delegate.
 		// The resource owner (space) is the signer of the capability.
 		// IMPORTANT: 'del' in extra_attrs is stored as the public_keys.id (integer), not the principal.
-		// We look for WRITER role which grants access to private content.
+		// We look for WRITER, COMMENTER, and READER roles which grant access to private content.
 		// INDEXED BY pins the partial capabilities index: the 'del' value is served
 		// from the index itself (no per-row JSON parse) and the IN list is
 		// bloom-checked before any main-table row is touched. A plain join on
 		// extra_attrs->>'del' cannot seek the expression index (SQLite only matches
 		// indexed expressions against literals/parameters), hence the IN shape.
-		const q = `
+		q := `
 			SELECT DISTINCT pk_del.principal, pk_author.principal
 			FROM structural_blobs sb INDEXED BY capabilities_by_delegate
 			JOIN public_keys pk_del ON pk_del.id = sb.extra_attrs->>'del'
@@ -312,7 +317,8 @@ func (idx *Index) GetSpacesByAccount(ctx context.Context, accounts []core.Princi
 				SELECT pk.id FROM json_each(?) AS j
 				JOIN public_keys pk ON pk.principal = unhex(j.value)
 			)
-			AND sb.extra_attrs->>'role' = 'WRITER'
+			AND sb.extra_attrs->>'role' IN ('WRITER', 'COMMENTER', 'READER')
+			AND ` + sqlGrantActiveAt("sb", SQLNowMillis) + `
 		`
 
 		rows, discard, check := sqlitex.Query(conn, q, accountsJSON).All()
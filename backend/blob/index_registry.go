@@ -22,6 +22,9 @@ type indexFunc func(ictx *indexingCtx, id int64, c cid.Cid, data []byte) error
 var (
 	indexersMap  = map[Type]int{}
 	indexersList []indexFunc
+
+	// Decoders of the registered indexers, used to verify stored blobs without indexing them.
+	verifiersList []func(cid.Cid, []byte) error
 )
 
 // registerIndexer registers an indexing function for the given blob type.
@@ -53,6 +56,25 @@ func registerIndexer[T any](
 
 	indexersList = append(indexersList, idxfn)
 	indexersMap[bt] = len(indexersList) - 1
+
+	verifiersList = append(verifiersList, func(c cid.Cid, data []byte) error {
+		_, err := decodeFunc(c, data)
+		if errors.Is(err, errSkipIndexing) {
+			return nil
+		}
+		return err
+	})
+}
+
+// verifyBlob decodes the blob with every registered decoder,
+// which checks the signatures and the validity of the known blob types.
+func verifyBlob(c cid.Cid, data []byte) error {
+	for _, fn := range verifiersList {
+		if err := fn(c, data); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // makeCBORTypeMatch returns a subslice of CBOR bytes that could be used to match
@@ -0,0 +1,468 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"seed/backend/core"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	format "github.com/ipfs/go-ipld-format"
+)
+
+// spaceKeyring unwraps space content keys with the locally held private keys.
+type spaceKeyring struct {
+	mu   sync.RWMutex
+	ks   core.KeyStore
+	keys map[cid.Cid]spaceKeys // Unwrapped content keys by the CID of the SpaceKey blob.
+}
+
+// spaceKeys are the unwrapped content keys of a SpaceKey blob.
+type spaceKeys struct {
+	Current []byte
+	History [][]byte
+}
+
+// all returns the current key followed by the previous ones.
+func (sk spaceKeys) all() [][]byte {
+	return append([][]byte{sk.Current}, sk.History...)
+}
+
+func (kr *spaceKeyring) setKeyStore(ks core.KeyStore) {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	kr.ks = ks
+	kr.keys = nil
+}
+
+func (kr *spaceKeyring) cached(c cid.Cid) (spaceKeys, bool) {
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	keys, ok := kr.keys[c]
+	return keys, ok
+}
+
+// hasKey reports whether the private key of the principal is held locally.
+func (kr *spaceKeyring) hasKey(ctx context.Context, principal core.Principal) (bool, error) {
+	kr.mu.RLock()
+	ks := kr.ks
+	kr.mu.RUnlock()
+
+	if ks == nil {
+		return false, nil
+	}
+
+	keys, err := ks.ListKeys(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, k := range keys {
+		if k.PublicKey.Equal(principal) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// unwrap opens the content key of the SpaceKey blob with any of the local keys.
+// We don't remember failures, because keys can be added to the key store at any time.
+func (kr *spaceKeyring) unwrap(c cid.Cid, sk *SpaceKey) (spaceKeys, bool, error) {
+	kr.mu.RLock()
+	ks := kr.ks
+	kr.mu.RUnlock()
+
+	if ks == nil {
+		return spaceKeys{}, false, nil
+	}
+
+	keys, err := ks.ListKeyPairs(context.Background())
+	if err != nil {
+		return spaceKeys{}, false, err
+	}
+
+	key, history, ok := sk.unwrap(keys)
+	if !ok {
+		return spaceKeys{}, false, nil
+	}
+
+	out := spaceKeys{Current: key, History: history}
+
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	if kr.keys == nil {
+		kr.keys = make(map[cid.Cid]spaceKeys)
+	}
+	kr.keys[c] = out
+
+	return out, true, nil
+}
+
+// contentKey returns the content key shared by the SpaceKey blob c,
+// if the blob is known and can be unwrapped with the local keys.
+func (b *blockStore) contentKey(conn *sqlite.Conn, c cid.Cid) (ContentKey, bool, error) {
+	keys, ok, err := b.spaceKeys(conn, c)
+	if err != nil || !ok {
+		return ContentKey{}, false, err
+	}
+
+	return ContentKey{ID: c, Key: keys.Current}, true, nil
+}
+
+// spaceKeys returns the current and the previous content keys shared by the SpaceKey blob c,
+// if the blob is known and can be unwrapped with the local keys.
+func (b *blockStore) spaceKeys(conn *sqlite.Conn, c cid.Cid) (spaceKeys, bool, error) {
+	if keys, ok := b.keys.cached(c); ok {
+		return keys, true, nil
+	}
+
+	blk, err := b.get(context.Background(), conn, c, false)
+	if err != nil {
+		if format.IsNotFound(err) {
+			return spaceKeys{}, false, nil
+		}
+		return spaceKeys{}, false, err
+	}
+
+	sk := &SpaceKey{}
+	if err := cbornode.DecodeInto(blk.RawData(), sk); err != nil {
+		return spaceKeys{}, false, fmt.Errorf("failed to decode space key %s: %w", c, err)
+	}
+
+	if sk.Type != TypeSpaceKey {
+		return spaceKeys{}, false, fmt.Errorf("blob %s is not a space key", c)
+	}
+
+	return b.keys.unwrap(c, sk)
+}
+
+// openBody decrypts the encrypted body into out, if we hold the content key for it.
+// Content keys are reused by later SpaceKeys of the same space until they are rotated,
+// and after that they're carried in the history of the later SpaceKeys,
+// so members added after the body was written find the key in a newer SpaceKey than the one the body references.
+func (b *blockStore) openBody(conn *sqlite.Conn, eb *EncryptedBody, out any) (ok bool, err error) {
+	key, ok, err := b.contentKey(conn, eb.Key)
+	if err != nil {
+		return false, err
+	}
+
+	if ok {
+		return true, key.Open(eb, out)
+	}
+
+	var shares []cid.Cid
+	if err := sqlitex.Exec(conn, qSpaceKeySiblings(), func(stmt *sqlite.Stmt) error {
+		shares = append(shares, cid.NewCidV1(uint64(stmt.ColumnInt64(0)), stmt.ColumnBytes(1))) //nolint:gosec
+		return nil
+	}, eb.Key.Hash()); err != nil {
+		return false, err
+	}
+
+	for _, c := range shares {
+		keys, ok, err := b.spaceKeys(conn, c)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			continue
+		}
+
+		// The authenticated encryption tells us whether it's the same key.
+		for _, k := range keys.all() {
+			if (ContentKey{ID: eb.Key, Key: k}).Open(eb, out) == nil {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+var qSpaceKeySiblings = dqb.Str(`
+	SELECT b.codec, b.multihash
+	FROM structural_blobs sb
+	JOIN blobs b ON b.id = sb.id
+	WHERE sb.type = 'SpaceKey'
+	AND sb.author = (
+		SELECT author FROM structural_blobs
+		WHERE id = (SELECT id FROM blobs WHERE multihash = :keyHash)
+	)
+	AND b.multihash != :keyHash
+	ORDER BY sb.ts DESC
+`)
+
+// openChange decrypts the body of an encrypted change in place.
+// The body is left empty if we don't hold the key for it.
+func (b *blockStore) openChange(conn *sqlite.Conn, ch *Change) error {
+	if ch.Encrypted == nil {
+		return nil
+	}
+
+	_, err := b.openBody(conn, ch.Encrypted, &ch.Body)
+	return err
+}
+
+// openComment decrypts the body of an encrypted comment in place.
+// The body is left empty if we don't hold the key for it.
+func (b *blockStore) openComment(conn *sqlite.Conn, cmt *Comment) error {
+	if cmt.Encrypted == nil {
+		return nil
+	}
+
+	_, err := b.openBody(conn, cmt.Encrypted, &cmt.Body)
+	return err
+}
+
+// openEncrypted decrypts the body of a blob being indexed.
+// Blobs are stashed until the SpaceKey they are encrypted with is indexed,
+// and then until we get a share of their content key, so we never index them with empty bodies.
+func (idx *indexingCtx) openEncrypted(eb *EncryptedBody, out any) error {
+	if eb == nil {
+		return nil
+	}
+
+	indexed, err := idx.IsBlobIndexed(eb.Key)
+	if err != nil {
+		return err
+	}
+
+	if !indexed {
+		return stashError{
+			Reason: stashReasonFailedPrecondition,
+			Metadata: stashMetadata{
+				MissingBlobs: []cid.Cid{eb.Key},
+			},
+		}
+	}
+
+	ok, err := idx.blockStore.openBody(idx.conn, eb, out)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		var space core.Principal
+		if err := sqlitex.Exec(idx.conn, qSpaceKeySpace(), func(stmt *sqlite.Stmt) error {
+			space = core.Principal(stmt.ColumnBytes(0))
+			return nil
+		}, eb.Key.Hash()); err != nil {
+			return err
+		}
+
+		return stashError{
+			Reason: stashReasonFailedPrecondition,
+			Metadata: stashMetadata{
+				Details: "missing content key of space " + space.String(),
+			},
+		}
+	}
+
+	return nil
+}
+
+var qSpaceKeySpace = dqb.Str(`
+	SELECT principal FROM public_keys
+	WHERE id = (
+		SELECT author FROM structural_blobs
+		WHERE id = (SELECT id FROM blobs WHERE multihash = :keyHash)
+	)
+`)
+
+// SetKeyStore installs the key store used to decrypt private content.
+// Without it encrypted blobs stay stashed, because we can't read them.
+func (idx *Index) SetKeyStore(ks core.KeyStore) {
+	idx.bs.keys.setKeyStore(ks)
+}
+
+// OpenComment decrypts the body of an encrypted comment in place, if we hold the key for it.
+func (idx *Index) OpenComment(conn *sqlite.Conn, cmt *Comment) error {
+	return idx.bs.openComment(conn, cmt)
+}
+
+// SpaceContentKey returns the current content key of the space,
+// i.e. the one from the latest SpaceKey blob, if we can unwrap it.
+func (idx *Index) SpaceContentKey(ctx context.Context, space core.Principal) (key ContentKey, ok bool, err error) {
+	conn, release, err := idx.db.ReadConn(ctx)
+	if err != nil {
+		return key, false, err
+	}
+	defer release()
+
+	return spaceContentKey(conn, idx.bs, space)
+}
+
+func spaceContentKey(conn *sqlite.Conn, bs *blockStore, space core.Principal) (key ContentKey, ok bool, err error) {
+	c, err := latestSpaceKey(conn, space)
+	if err != nil || !c.Defined() {
+		return key, false, err
+	}
+
+	return bs.contentKey(conn, c)
+}
+
+// latestSpaceKey returns the CID of the latest SpaceKey blob of the space, if any.
+func latestSpaceKey(conn *sqlite.Conn, space core.Principal) (c cid.Cid, err error) {
+	err = sqlitex.Exec(conn, qLatestSpaceKey(), func(stmt *sqlite.Stmt) error {
+		c = cid.NewCidV1(uint64(stmt.ColumnInt64(0)), stmt.ColumnBytes(1)) //nolint:gosec
+		return nil
+	}, space)
+	return c, err
+}
+
+var qLatestSpaceKey = dqb.Str(`
+	SELECT b.codec, b.multihash
+	FROM structural_blobs sb
+	JOIN blobs b ON b.id = sb.id
+	WHERE sb.type = 'SpaceKey'
+	AND sb.author = (SELECT id FROM public_keys WHERE principal = :space)
+	ORDER BY sb.ts DESC, sb.id DESC
+	LIMIT 1
+`)
+
+// SpaceMembers returns the accounts that currently hold a capability to read private content in the space.
+// Capabilities that are not valid yet are included, because their holders will need the key eventually.
+func (idx *Index) SpaceMembers(ctx context.Context, space core.Principal) (out []core.Principal, err error) {
+	conn, release, err := idx.db.ReadConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	rows, discard, check := sqlitex.Query(conn, qSpaceMembers(), space).All()
+	defer discard(&err)
+	for row := range rows {
+		out = append(out, core.Principal(row.ColumnBytes(0)))
+	}
+
+	err = errors.Join(err, check())
+	return out, err
+}
+
+var qSpaceMembers = dqb.Q(func() string {
+	return `
+	SELECT DISTINCT pk.principal
+	FROM structural_blobs sb
+	JOIN public_keys pk ON pk.id = sb.extra_attrs->>'del'
+	WHERE sb.type = 'Capability'
+	AND sb.author = (SELECT id FROM public_keys WHERE principal = :space)
+	AND sb.extra_attrs->>'role' IN (` + sqlReaderRoles + `)
+	AND ` + sqlGrantUntil("sb") + ` > ` + SQLNowMillis + `
+	ORDER BY pk.principal`
+})
+
+// ExpiredSpaceKeys returns the spaces whose current content key was shared with members
+// whose capabilities have expired since then. Their keys must be rotated to cut those members off.
+// It also returns when the next capability in a space with a content key expires, if any.
+func (idx *Index) ExpiredSpaceKeys(ctx context.Context, now time.Time) (spaces []core.Principal, next time.Time, err error) {
+	conn, release, err := idx.db.ReadConn(ctx)
+	if err != nil {
+		return nil, next, err
+	}
+	defer release()
+
+	if err := sqlitex.Exec(conn, qExpiredSpaceKeys(), func(stmt *sqlite.Stmt) error {
+		spaces = append(spaces, core.Principal(stmt.ColumnBytes(0)))
+		return nil
+	}, now.UnixMilli()); err != nil {
+		return nil, next, err
+	}
+
+	if err := sqlitex.Exec(conn, qNextSpaceMemberExpiration(), func(stmt *sqlite.Stmt) error {
+		if stmt.ColumnType(0) != sqlite.SQLITE_NULL {
+			next = time.UnixMilli(stmt.ColumnInt64(0))
+		}
+		return nil
+	}, now.UnixMilli()); err != nil {
+		return nil, next, err
+	}
+
+	return spaces, next, nil
+}
+
+var qExpiredSpaceKeys = dqb.Q(func() string {
+	return `
+	SELECT pk.principal
+	FROM (
+		SELECT author, max(ts) AS ts
+		FROM structural_blobs
+		WHERE type = 'SpaceKey'
+		GROUP BY author
+	) sk
+	JOIN public_keys pk ON pk.id = sk.author
+	WHERE EXISTS (
+		SELECT 1 FROM structural_blobs c
+		WHERE c.type = 'Capability'
+		AND c.author = sk.author
+		AND c.extra_attrs->>'role' IN (` + sqlReaderRoles + `)
+		AND c.extra_attrs->>'exp' > sk.ts
+		AND c.extra_attrs->>'exp' <= :now
+	)
+	ORDER BY pk.principal`
+})
+
+var qNextSpaceMemberExpiration = dqb.Q(func() string {
+	return `
+	SELECT min(c.extra_attrs->>'exp')
+	FROM structural_blobs c
+	WHERE c.type = 'Capability'
+	AND c.extra_attrs->>'role' IN (` + sqlReaderRoles + `)
+	AND c.extra_attrs->>'exp' > :now
+	AND c.author IN (SELECT author FROM structural_blobs WHERE type = 'SpaceKey')`
+})
+
+// ShareSpaceKey publishes a new SpaceKey blob for the owner's space, sharing the content key with the current members.
+// The current content key is reused unless rotate is true or there's none yet,
+// in which case a fresh key is generated. Rotating the key is what cuts off members who lost their access,
+// because they won't be able to read any content encrypted after that.
+// The previous content keys are shared too, so the current members can read the content encrypted before the rotations.
+func (idx *Index) ShareSpaceKey(ctx context.Context, owner *core.KeyPair, rotate bool, ts time.Time) (ContentKey, error) {
+	var prev spaceKeys
+	if err := idx.db.WithSave(ctx, func(conn *sqlite.Conn) error {
+		c, err := latestSpaceKey(conn, owner.Principal())
+		if err != nil || !c.Defined() {
+			return err
+		}
+
+		prev, _, err = idx.bs.spaceKeys(conn, c)
+		return err
+	}); err != nil {
+		return ContentKey{}, err
+	}
+
+	key, history := prev.Current, prev.History
+	if rotate && key != nil {
+		history = append([][]byte{key}, history...)
+		key = nil
+	}
+
+	if key == nil {
+		var err error
+		key, err = NewContentKey()
+		if err != nil {
+			return ContentKey{}, err
+		}
+	}
+
+	members, err := idx.SpaceMembers(ctx, owner.Principal())
+	if err != nil {
+		return ContentKey{}, err
+	}
+
+	sk, err := NewSpaceKey(owner, key, history, members, ts)
+	if err != nil {
+		return ContentKey{}, err
+	}
+
+	if err := idx.Put(ctx, sk); err != nil {
+		return ContentKey{}, err
+	}
+
+	return ContentKey{ID: sk.CID, Key: key}, nil
+}
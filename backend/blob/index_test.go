@@ -3,6 +3,7 @@ package blob
 import (
 	"crypto/rand"
 	"encoding/json"
+	"math"
 	"seed/backend/core"
 	"seed/backend/core/coretest"
 	"seed/backend/storage"
@@ -311,7 +312,7 @@ func TestWriterValidityCache(t *testing.T) {
 	var nilc *writerValidityCache
 	_, ok := nilc.get("k")
 	require.False(t, ok)
-	nilc.put("k", true)
+	nilc.put("k", writerGrants{Direct: []grantWindow{{Until: math.MaxInt64}}})
 	nilc.clear()
 	_, ok = nilc.get("k")
 	require.False(t, ok)
@@ -320,15 +321,27 @@ func TestWriterValidityCache(t *testing.T) {
 	_, ok = c.get("k")
 	require.False(t, ok, "empty cache misses")
 
-	c.put("k", true)
+	c.put("k", writerGrants{Direct: []grantWindow{{Until: math.MaxInt64}}})
 	v, ok := c.get("k")
 	require.True(t, ok)
-	require.True(t, v)
+	require.True(t, v.validAt(1))
 
-	c.put("k2", false)
+	c.put("k2", writerGrants{AgentLoaded: true})
 	v, ok = c.get("k2")
 	require.True(t, ok)
-	require.False(t, v, "negative results are cached too")
+	require.False(t, v.validAt(1), "negative results are cached too")
+
+	c.put("k3", writerGrants{Direct: []grantWindow{{Until: 100}}})
+	v, ok = c.get("k3")
+	require.True(t, ok)
+	require.True(t, v.validAt(99))
+	require.False(t, v.validAt(100), "grants are not valid at or after the revocation time")
+
+	c.put("k4", writerGrants{Direct: []grantWindow{{From: 50, Until: 100}}})
+	v, ok = c.get("k4")
+	require.True(t, ok)
+	require.False(t, v.validAt(49), "grants are not valid before the not-before time")
+	require.True(t, v.validAt(50))
 
 	c.clear()
 	_, ok = c.get("k")
@@ -339,9 +352,8 @@ func TestWriterValidityCache(t *testing.T) {
 
 // TestWriterCheck_CacheConsultedAndInvalidated verifies the isValidWriter cache
 // (a) never changes the computed result, (b) is actually consulted on a hit, and
-// (c) is dropped by clear() — the invalidation indexCapability performs whenever
-// a capability changes, which is what keeps the cache correct under a future
-// capability revocation.
+// (c) is dropped by clear() — the invalidation indexCapability and indexRevocation
+// perform whenever a capability changes.
 func TestWriterCheck_CacheConsultedAndInvalidated(t *testing.T) {
 	alice := coretest.NewTester("alice").Account
 	bob := coretest.NewTester("bob").Account
@@ -369,33 +381,34 @@ func TestWriterCheck_CacheConsultedAndInvalidated(t *testing.T) {
 
 	// (a) Correct results with a live cache.
 	cache := newWriterValidityCache()
-	got, err := isValidWriter(conn, bobID, shared, cache)
+	now := clock.MustNow().UnixMilli()
+	got, err := isValidWriter(conn, bobID, shared, now, cache)
 	require.NoError(t, err)
 	require.True(t, got, "bob holds a WRITER capability on /shared")
-	got, err = isValidWriter(conn, bobID, other, cache)
+	got, err = isValidWriter(conn, bobID, other, now, cache)
 	require.NoError(t, err)
 	require.False(t, got, "bob holds no capability on /other")
 
 	// (b) The cache is actually consulted: poison the /other entry and observe the
 	// stale value served without touching the DB.
 	otherKey := writerCacheKey(aliceID, bobID, string(must.Do2(json.Marshal(other.Breadcrumbs()))))
-	cache.put(otherKey, true)
-	got, err = isValidWriter(conn, bobID, other, cache)
+	cache.put(otherKey, writerGrants{Direct: []grantWindow{{Until: math.MaxInt64}}})
+	got, err = isValidWriter(conn, bobID, other, now, cache)
 	require.NoError(t, err)
 	require.True(t, got, "poisoned cache entry is served (proves the cache is read)")
 
-	// (c) clear() restores correctness — this is what indexCapability does on any
-	// capability change, including a future revocation.
+	// (c) clear() restores correctness — this is what indexCapability and
+	// indexRevocation do on any capability change.
 	cache.clear()
-	got, err = isValidWriter(conn, bobID, other, cache)
+	got, err = isValidWriter(conn, bobID, other, now, cache)
 	require.NoError(t, err)
 	require.False(t, got, "after clear the result is recomputed from the DB")
 
 	// A nil cache disables memoization and yields identical results.
-	got, err = isValidWriter(conn, bobID, shared, nil)
+	got, err = isValidWriter(conn, bobID, shared, now, nil)
 	require.NoError(t, err)
 	require.True(t, got)
-	got, err = isValidWriter(conn, bobID, other, nil)
+	got, err = isValidWriter(conn, bobID, other, now, nil)
 	require.NoError(t, err)
 	require.False(t, got)
 }
@@ -2,6 +2,7 @@ package blob
 
 import (
 	"context"
+	"seed/backend/core"
 	"seed/backend/core/coretest"
 	"seed/backend/storage"
 	"testing"
@@ -277,3 +278,51 @@ func TestCanPeerAccessSpace_DirectAuthentication(t *testing.T) {
 	require.NoError(t, err, "Must not error checking unauthenticated space")
 	require.False(t, canAccess, "Bob must not be able to access Alice's space without authentication")
 }
+
+// TestCanPeerAccessSpace_ReaderCapability tests access via a READER capability.
+func TestCanPeerAccessSpace_ReaderCapability(t *testing.T) {
+	ctx := context.Background()
+
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+
+	alicePeerID := alice.Device.PeerID()
+	bobPeerID := bob.Device.PeerID()
+
+	aliceDB, err := storage.OpenSQLite("file::memory:?mode=memory", 0, 1)
+	require.NoError(t, err)
+	defer aliceDB.Close()
+	require.NoError(t, storage.InitSQLiteSchema(aliceDB))
+
+	aliceIndex, err := OpenIndex(ctx, aliceDB, zap.NewNop())
+	require.NoError(t, err)
+
+	// Bob authenticates with his account on Alice's node.
+	now := time.Now().Round(ClockPrecision)
+	bobAccount := bob.Account.Principal()
+	capability, err := NewEphemeralCapability(bobPeerID, bobAccount, alicePeerID, now, nil)
+	require.NoError(t, err)
+	require.NoError(t, Sign(bob.Account, capability, &capability.Sig))
+	require.NoError(t, aliceIndex.AuthenticatePeer(bobPeerID, bobAccount, alicePeerID, now, capability.Sig))
+
+	canAccess, err := aliceIndex.canPeerAccessSpace(ctx, bobPeerID, alice.Account.Principal())
+	require.NoError(t, err)
+	require.False(t, canAccess, "Bob must not be able to access Alice's space without a capability")
+
+	cpb, err := NewCapability(alice.Account, bobAccount, alice.Account.Principal(), "", RoleReader, "", now)
+	require.NoError(t, err)
+	require.NoError(t, aliceIndex.Put(ctx, cpb))
+
+	canAccess, err = aliceIndex.canPeerAccessSpace(ctx, bobPeerID, alice.Account.Principal())
+	require.NoError(t, err)
+	require.True(t, canAccess, "READER capability must grant access to Alice's space")
+
+	spaces, err := aliceIndex.GetAuthorizedSpacesForPeer(ctx, bobPeerID, nil)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []core.Principal{bobAccount, alice.Account.Principal()}, spaces)
+
+	// Readers can't write though.
+	valid, err := aliceIndex.IsValidWriter(ctx, alice.Account.Principal(), "", bobAccount)
+	require.NoError(t, err)
+	require.False(t, valid, "READER capability must not grant write access")
+}
@@ -31,6 +31,9 @@ var derivedTables = []string{
 	storage.T_Fts,
 	storage.T_FtsIndex,
 	storage.T_BlobVisibility,
+	storage.T_CommentAnchors,
+	storage.T_SpaceUsageBlobs,
+	storage.T_SpaceUsage,
 	// The maintained RBSR index is derived: drop it on reindex and let it
 	// re-materialize lazily on the next reconcile. rbsr_item has an FK to
 	// rbsr_scope with ON DELETE CASCADE, but reindex deletes tables in list
@@ -123,6 +126,7 @@ func (idx *Index) reindex(conn *sqlite.Conn) (err error) {
 	// connection for its entire duration, so this can't meaningfully change
 	// mid-run, and firstImageDeriver() takes a RWMutex on every call.
 	deriver := idx.firstImageDeriver()
+	anchorResolver := idx.commentAnchorResolver()
 
 	if err := sqlitex.WithTx(conn, func() error {
 		truncateStart := time.Now()
@@ -204,7 +208,7 @@ func (idx *Index) reindex(conn *sqlite.Conn) (err error) {
 			// below does the same work once per generation after the loop. The nil
 			// also rides into the unstash cascade via childOpts(), which is what we
 			// want — the end pass covers every generation regardless of how it got
-			// built.
+			// built. The same goes for resolving comment anchors, done by resolveAllCommentAnchors.
 			err = indexBlob(indexOpts{}, conn, id, c, data, idx.bs, idx.log, reindexWriterCache, nil)
 			blobsIndexed++
 
@@ -221,6 +225,20 @@ func (idx *Index) reindex(conn *sqlite.Conn) (err error) {
 		}
 		blobLoopDur = time.Since(blobLoopStart)
 
+		// Blobs are indexed in the order we received them, so content signed by rotated keys
+		// could have been accepted before we got to the rotation.
+		var rotations []int64
+		if err := sqlitex.Exec(conn, qListKeyRotations(), func(stmt *sqlite.Stmt) error {
+			rotations = append(rotations, stmt.ColumnInt64(0))
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if err := idx.evictRotatedOutContent(conn, rotations); err != nil {
+			return err
+		}
+
 		coverStart := time.Now()
 		gensScanned, gensDerived, coverChangesReplay, err = deriveFirstContentImages(conn, idx.bs, idx.log, deriver)
 		if err != nil {
@@ -228,6 +246,15 @@ func (idx *Index) reindex(conn *sqlite.Conn) (err error) {
 		}
 		coverDur = time.Since(coverStart)
 
+		if err := idx.resolveAllCommentAnchors(conn, anchorResolver); err != nil {
+			return err
+		}
+
+		// The indexed hook doesn't run during the full reindex, so the storage usage is accounted at once.
+		if err := rebuildSpaceUsage(conn); err != nil {
+			return err
+		}
+
 		return dbSetReindexTime(conn, time.Now().UTC().String())
 	}); err != nil {
 		return err
@@ -0,0 +1,529 @@
+package blob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"slices"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"go.uber.org/zap"
+)
+
+// ErrReindexInProgress is returned when a reindex is requested while another one is running.
+var ErrReindexInProgress = errors.New("reindexing is already in progress")
+
+// ScopedReindexResult is the result of ReindexScope.
+type ScopedReindexResult struct {
+	Resources    int
+	BlobsIndexed int64
+}
+
+// ReindexScope reindexes the resources with the given IRI, or any IRI below it,
+// e.g. hm://<space> reindexes the entire space. It's much faster than the full Reindex,
+// when only some resources are suspected to be indexed incorrectly.
+// The progress function, if set, is called periodically with the number of processed blobs.
+func (idx *Index) ReindexScope(ctx context.Context, iri IRI, progress func(indexed, total int64)) (res ScopedReindexResult, err error) {
+	if _, _, err := iri.SpacePath(); err != nil {
+		return res, fmt.Errorf("invalid IRI to reindex %s: %w", iri, err)
+	}
+
+	conn, release, err := idx.db.WriteConn(ctx)
+	if err != nil {
+		return res, err
+	}
+	defer release()
+
+	var resources []int64
+	if err := sqlitex.Exec(conn, qListScopeResources(), func(stmt *sqlite.Stmt) error {
+		resources = append(resources, stmt.ColumnInt64(0))
+		return nil
+	}, string(iri)); err != nil {
+		return res, err
+	}
+
+	start := time.Now()
+	res.Resources = len(resources)
+	res.BlobsIndexed, err = idx.reindexResources(conn, resources, nil, progress)
+
+	idx.log.Info("ScopedReindexFinished",
+		zap.Error(err),
+		zap.String("iri", string(iri)),
+		zap.String("duration", time.Since(start).String()),
+		zap.Int("resources", res.Resources),
+		zap.Int64("blobsIndexed", res.BlobsIndexed),
+	)
+
+	return res, err
+}
+
+// Paths may contain GLOB wildcards, so the subtree is matched with a range comparison.
+var qListScopeResources = dqb.Str(`
+	SELECT id FROM resources
+	WHERE iri = :iri OR (iri >= :iri || '/' AND iri < :iri || '0')
+	ORDER BY id
+`)
+
+// reindexResources drops the derived data of the given resources, and indexes their blobs again.
+// It's the scoped counterpart of reindex: the blobs of a resource are the structural blobs attached to it,
+// and the changes of its documents. Blobs from other resources linking to these resources are left alone.
+//
+// Extra blobs can be added to the scope, e.g. the ones whose data was lost,
+// to drop their derived data even if they don't belong to any of the resources.
+//
+// Blobs that fail to be indexed again (e.g. because their data is corrupted) are logged and skipped,
+// so they end up out of the index, instead of failing the entire operation.
+// Stashed blobs waiting for any of the reindexed blobs are indexed again too.
+func (idx *Index) reindexResources(conn *sqlite.Conn, resources, blobs []int64, progress func(indexed, total int64)) (blobsIndexed int64, err error) {
+	if !idx.mu.TryLock() {
+		return 0, ErrReindexInProgress
+	}
+	defer idx.mu.Unlock()
+
+	if len(resources) == 0 && len(blobs) == 0 {
+		return 0, nil
+	}
+
+	err = sqlitex.WithTx(conn, func() error {
+		blobsIndexed, err = idx.reindexResourcesTx(conn, resources, blobs, progress)
+		if err != nil {
+			return err
+		}
+
+		// Reindexed key rotations might invalidate some content that was indexed before them.
+		var rotations []int64
+		if err := sqlitex.Exec(conn, qListReindexedKeyRotations, func(stmt *sqlite.Stmt) error {
+			rotations = append(rotations, stmt.ColumnInt64(0))
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		return idx.evictRotatedOutContent(conn, rotations)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return blobsIndexed, nil
+}
+
+// reindexResourcesTx is the body of reindexResources, that must run inside a write transaction.
+func (idx *Index) reindexResourcesTx(conn *sqlite.Conn, resources, blobs []int64, progress func(indexed, total int64)) (blobsIndexed int64, err error) {
+	opts := indexOpts{
+		// Unlike the full reindex, we derive the covers on every Ref,
+		// because the end passes of the full reindex would visit the entire database.
+		DeriveFirstContentImage: idx.firstImageDeriver(),
+	}
+
+	err = func() error {
+		for _, table := range []string{"reindex_resources", "reindex_blobs"} {
+			if err := sqlitex.Exec(conn, "CREATE TEMP TABLE IF NOT EXISTS "+table+" (id INTEGER PRIMARY KEY)", nil); err != nil {
+				return err
+			}
+			if err := sqlitex.Exec(conn, "DELETE FROM temp."+table, nil); err != nil {
+				return err
+			}
+		}
+
+		for _, id := range resources {
+			if err := sqlitex.Exec(conn, "INSERT OR IGNORE INTO reindex_resources (id) VALUES (?)", nil, id); err != nil {
+				return err
+			}
+		}
+
+		for _, id := range blobs {
+			if err := sqlitex.Exec(conn, "INSERT OR IGNORE INTO reindex_blobs (id) VALUES (?)", nil, id); err != nil {
+				return err
+			}
+		}
+
+		// The blob links must be collected before they are deleted.
+		for _, q := range [...]string{qCollectResourceBlobs, qCollectResourceChanges} {
+			if err := sqlitex.Exec(conn, q, nil); err != nil {
+				return err
+			}
+		}
+
+		// Key rotations go first, in the order that picks the winner among the rotations of the same key
+		// (see indexKeyRotation), so the losers are stashed no matter the order we got them in.
+		var rotations []int64
+		if err := sqlitex.Exec(conn, qListReindexedKeyRotations, func(stmt *sqlite.Stmt) error {
+			rotations = append(rotations, stmt.ColumnInt64(0))
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		spaces, err := loadReindexedSpaces(conn)
+		if err != nil {
+			return err
+		}
+
+		if err := collectSpaceUsageMedia(conn, "reindex_blobs"); err != nil {
+			return err
+		}
+
+		for _, q := range qDropResourceDerivedData {
+			if err := sqlitex.Exec(conn, q, nil); err != nil {
+				return err
+			}
+		}
+
+		// Failed blobs roll back their savepoints, which would abort any pending statement,
+		// so we collect the IDs before indexing the blobs.
+		ids := rotations
+		if err := sqlitex.Exec(conn, qListReindexedBlobs, func(stmt *sqlite.Stmt) error {
+			if id := stmt.ColumnInt64(0); !slices.Contains(rotations, id) {
+				ids = append(ids, id)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		total := int64(len(ids))
+		if progress != nil {
+			progress(0, total)
+		}
+
+		writerCache := newWriterValidityCache()
+		for i, id := range ids {
+			// The reporting batch size is a bit arbitrary.
+			const reportBatchSize = 30
+			if progress != nil && i > 0 && i%reportBatchSize == 0 {
+				progress(int64(i), total)
+			}
+
+			var (
+				c    cid.Cid
+				data []byte
+			)
+			if err := sqlitex.Exec(conn, qLoadReindexedBlob(), func(stmt *sqlite.Stmt) error {
+				inc := sqlite.NewIncrementor(0)
+				var (
+					codec   = stmt.ColumnInt64(inc())
+					hash    = stmt.ColumnBytes(inc())
+					size    = stmt.ColumnInt(inc())
+					rawData = stmt.ColumnBytesUnsafe(inc())
+				)
+
+				c = cid.NewCidV1(uint64(codec), hash)
+				data, err = idx.bs.decompress(rawData, size)
+				return err
+			}, id); err != nil {
+				idx.log.Warn("ScopedReindexSkippedBlob", zap.Int64("id", id), zap.Error(err))
+				continue
+			}
+
+			// On failure the savepoint of the blob is rolled back, so we can carry on with the other blobs.
+			if err := indexBlob(opts, conn, id, c, data, idx.bs, idx.log, writerCache, nil); err != nil {
+				idx.log.Warn("ScopedReindexSkippedBlob", zap.String("cid", c.String()), zap.Error(err))
+				continue
+			}
+
+			blobsIndexed++
+
+			// Most indexers unstash the blobs waiting for them on their own, but not all of them do,
+			// and the stashed blobs could have been waiting for the derived data we've just rebuilt.
+			if err := reindexStashedBlobs(opts, conn, stashReasonFailedPrecondition, c.String(), idx.bs, idx.log, writerCache, nil); err != nil {
+				idx.log.Warn("ScopedReindexUnstashFailed", zap.String("cid", c.String()), zap.Error(err))
+			}
+		}
+
+		if progress != nil {
+			progress(total, total)
+		}
+
+		for _, space := range spaces {
+			if err := sqlitex.Exec(conn, qRecomputeSpaceCommentStats(), nil, "hm://"+space, "hm://"+space+"/*", space); err != nil {
+				return err
+			}
+		}
+
+		// The reindexed blobs don't go through the indexed hook worker, so we resolve the comment anchors here.
+		if resolve := idx.commentAnchorResolver(); resolve != nil {
+			var iris []string
+			if err := sqlitex.Exec(conn, qListReindexedResourceIRIs, func(stmt *sqlite.Stmt) error {
+				iris = append(iris, stmt.ColumnText(0))
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			if err := idx.resolveDocumentCommentAnchors(conn, resolve, iris); err != nil {
+				return err
+			}
+		}
+
+		// Blobs whose data was lost or pruned are removed from the storage usage of their spaces,
+		// and the media they linked to keeps only the spaces of the remaining blobs.
+		if err := sqlitex.Exec(conn, "INSERT OR IGNORE INTO space_usage_ids SELECT id FROM reindex_blobs", nil); err != nil {
+			return err
+		}
+
+		return refreshSpaceUsage(conn, "space_usage_ids")
+	}()
+	if err != nil {
+		return 0, err
+	}
+
+	return blobsIndexed, nil
+}
+
+// evictRotatedOutContent indexes again the content signed by keys after they were rotated away,
+// along with the resources it belongs to. Such content is valid until we learn about the rotation,
+// so it has to be evicted from the index when the rotation arrives. Indexing it again stashes it,
+// because the indexers reject anything signed by a retired key.
+// The same goes for the rotations that lost to a better one of the same key (see indexKeyRotation),
+// and for the content of the keys they rotated into, which has to be validated again without them.
+// Only the keys rotated by the given rotations are considered, i.e. the ones we've just indexed.
+// It must run inside a write transaction.
+func (idx *Index) evictRotatedOutContent(conn *sqlite.Conn, rotations []int64) error {
+	if len(rotations) == 0 {
+		return nil
+	}
+
+	rotationsJSON, err := json.Marshal(rotations)
+	if err != nil {
+		return err
+	}
+
+	var blobs []int64
+	if err := sqlitex.Exec(conn, qListRotatedOutContent(), func(stmt *sqlite.Stmt) error {
+		blobs = append(blobs, stmt.ColumnInt64(0))
+		return nil
+	}, string(rotationsJSON)); err != nil {
+		return err
+	}
+
+	if len(blobs) == 0 {
+		return nil
+	}
+
+	var resources []int64
+	for _, id := range blobs {
+		if err := sqlitex.Exec(conn, qListBlobResources(), func(stmt *sqlite.Stmt) error {
+			if r := stmt.ColumnInt64(0); !slices.Contains(resources, r) {
+				resources = append(resources, r)
+			}
+			return nil
+		}, id); err != nil {
+			return err
+		}
+	}
+
+	evicted, err := idx.reindexResourcesTx(conn, resources, blobs, nil)
+	idx.log.Info("RotatedOutContentEvicted", zap.Int("blobs", len(blobs)), zap.Int64("blobsIndexed", evicted), zap.Error(err))
+	return err
+}
+
+// The winning rotation of each key is the one with the lowest timestamp and CID, like in qKeyRotationNext.
+// The losers are signed after the winner, so they are selected along with the rest of the rotated out content.
+var qListRotatedOutContent = dqb.Str(`
+	WITH winners (id) AS (
+		SELECT (
+			SELECT kr.id
+			FROM structural_blobs kr
+			JOIN blobs b ON b.id = kr.id
+			WHERE kr.author = a.author
+			AND kr.type = 'KeyRotation'
+			ORDER BY kr.ts, b.multihash
+			LIMIT 1
+		)
+		FROM (
+			SELECT DISTINCT author
+			FROM structural_blobs
+			WHERE id IN (SELECT value FROM json_each(:rotations))
+			AND type = 'KeyRotation'
+		) a
+	)
+	SELECT sb.id
+	FROM winners w
+	JOIN structural_blobs kr ON kr.id = w.id
+	JOIN structural_blobs sb ON sb.author = kr.author AND sb.ts >= kr.ts AND sb.id != kr.id
+	UNION
+	SELECT sb.id
+	FROM winners w
+	JOIN structural_blobs kr ON kr.id = w.id
+	JOIN structural_blobs l ON l.author = kr.author AND l.type = 'KeyRotation' AND l.id != kr.id
+	JOIN structural_blobs sb ON sb.author = l.extra_attrs->>'new'
+	ORDER BY 1
+`)
+
+var qListBlobResources = dqb.Str(`
+	SELECT resource FROM structural_blobs
+	WHERE id = :id
+	AND resource IS NOT NULL
+	UNION
+	SELECT id FROM resources
+	WHERE genesis_blob = (SELECT coalesce(genesis_blob, id) FROM structural_blobs WHERE id = :id)
+`)
+
+// evictRotatedOutContentAfter evicts the content rotated out by the key rotations among the newly indexed blobs.
+func (idx *Index) evictRotatedOutContentAfter(conn *sqlite.Conn, ids []int64) error {
+	var rotations []int64
+	for _, id := range ids {
+		if err := sqlitex.Exec(conn, qIsKeyRotation(), func(*sqlite.Stmt) error {
+			rotations = append(rotations, id)
+			return nil
+		}, id); err != nil {
+			return err
+		}
+	}
+
+	return idx.evictRotatedOutContent(conn, rotations)
+}
+
+var qIsKeyRotation = dqb.Str(`
+	SELECT 1 FROM structural_blobs WHERE id = :id AND type = 'KeyRotation'
+`)
+
+var qListKeyRotations = dqb.Str(`
+	SELECT id FROM structural_blobs WHERE type = 'KeyRotation' ORDER BY id
+`)
+
+// The queries on the temp tables can't be dqb.Str, because the tables don't exist in the schema.
+const qListReindexedResourceIRIs = `
+	SELECT iri FROM resources
+	WHERE id IN (SELECT id FROM reindex_resources)
+	ORDER BY id
+`
+
+const qCollectResourceBlobs = `
+	INSERT OR IGNORE INTO reindex_blobs
+	SELECT sb.id FROM structural_blobs sb
+	WHERE sb.resource IN reindex_resources
+	UNION
+	SELECT sb.id FROM structural_blobs sb
+	WHERE sb.type = 'Change'
+	AND sb.genesis_blob IN (SELECT genesis_blob FROM resources WHERE id IN reindex_resources)
+	UNION
+	SELECT genesis_blob FROM resources
+	WHERE id IN reindex_resources
+	AND genesis_blob IS NOT NULL
+`
+
+// Changes referenced by the Refs, in case some of them don't share the genesis of the resource.
+const qCollectResourceChanges = `
+	WITH RECURSIVE changes (id) AS (
+		SELECT bl.target
+		FROM reindex_blobs rb
+		CROSS JOIN blob_links bl ON bl.source = rb.id AND bl.type = 'ref/head'
+		UNION
+		SELECT bl.target
+		FROM blob_links bl
+		JOIN changes c ON c.id = bl.source AND bl.type = 'change/dep'
+	)
+	INSERT OR IGNORE INTO reindex_blobs
+	SELECT id FROM changes
+`
+
+// The order matters for the same reasons as in derivedTables.
+var qDropResourceDerivedData = []string{
+	// The vec0 table can't be indexed by fts_id, so this is a full scan, but it's cheaper than leaving
+	// embeddings behind for the rowids of the dropped full-text entries, which can be reused by unrelated entries.
+	`DELETE FROM embeddings WHERE rowid IN (
+		SELECT rowid FROM embeddings WHERE fts_id IN (SELECT rowid FROM fts_index WHERE blob_id IN reindex_blobs)
+	)`,
+	`DELETE FROM embeddings_index WHERE fts_id IN (SELECT rowid FROM fts_index WHERE blob_id IN reindex_blobs)`,
+	`DELETE FROM fts WHERE rowid IN (SELECT rowid FROM fts_index WHERE blob_id IN reindex_blobs)`,
+	`DELETE FROM fts_index WHERE blob_id IN reindex_blobs`,
+	`DELETE FROM blob_links WHERE source IN reindex_blobs`,
+	`DELETE FROM resource_links WHERE source IN reindex_blobs`,
+	`DELETE FROM comment_anchors WHERE comment IN reindex_blobs`,
+	`DELETE FROM stashed_blobs WHERE id IN reindex_blobs`,
+	`DELETE FROM blob_visibility WHERE id IN reindex_blobs`,
+	`DELETE FROM document_attributes WHERE resource IN reindex_resources`,
+	`DELETE FROM document_generations WHERE resource IN reindex_resources`,
+	// The RBSR scopes that contained the blobs are re-materialized lazily, like after the full reindex.
+	`UPDATE rbsr_scope SET materialized = 0
+	WHERE materialized = 1
+	AND (id IN (SELECT scope FROM rbsr_item WHERE blob IN reindex_blobs)
+		OR iri IN (SELECT iri FROM resources WHERE id IN reindex_resources))`,
+	`DELETE FROM rbsr_item WHERE blob IN reindex_blobs`,
+	`DELETE FROM structural_blobs WHERE id IN reindex_blobs`,
+}
+
+const qListReindexedBlobs = `
+	SELECT b.id
+	FROM reindex_blobs rb
+	JOIN blobs b ON b.id = rb.id
+	WHERE b.size > 0
+	ORDER BY b.id
+`
+
+const qListReindexedKeyRotations = `
+	SELECT sb.id
+	FROM reindex_blobs rb
+	JOIN structural_blobs sb ON sb.id = rb.id
+	JOIN blobs b ON b.id = sb.id
+	WHERE sb.type = 'KeyRotation'
+	AND b.size > 0
+	ORDER BY sb.ts, b.multihash
+`
+
+var qLoadReindexedBlob = dqb.Str(`
+	SELECT codec, multihash, size, data
+	FROM blobs
+	WHERE id = :id
+`)
+
+// loadReindexedSpaces returns the spaces of the reindexed resources.
+func loadReindexedSpaces(conn *sqlite.Conn) ([]string, error) {
+	var spaces []string
+	if err := sqlitex.Exec(conn, `SELECT iri FROM resources WHERE id IN reindex_resources`, func(stmt *sqlite.Stmt) error {
+		space, _, err := IRI(stmt.ColumnText(0)).SpacePath()
+		if err != nil {
+			return nil
+		}
+
+		if s := space.String(); !slices.Contains(spaces, s) {
+			spaces = append(spaces, s)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return spaces, nil
+}
+
+// Comment stats of the space can't be updated incrementally when its comments are indexed again,
+// so we compute them from scratch: the number of comments whose latest version is not deleted,
+// and the latest live comment.
+var qRecomputeSpaceCommentStats = dqb.Str(`
+	WITH comments AS (
+		SELECT
+			sb.id,
+			sb.ts,
+			sb.extra_attrs->>'deleted' IS NOT NULL AS is_deleted,
+			ROW_NUMBER() OVER (PARTITION BY sb.extra_attrs->>'tsid' ORDER BY sb.ts DESC, sb.id DESC) AS rank
+		FROM structural_blobs sb
+		JOIN resources r ON r.id = sb.resource
+		WHERE sb.type = 'Comment'
+		AND (r.iri = :iri OR r.iri GLOB :pattern)
+	),
+	latest AS (
+		SELECT id, ts FROM comments
+		WHERE NOT is_deleted
+		ORDER BY ts DESC, id DESC
+		LIMIT 1
+	)
+	INSERT INTO spaces (id, last_comment, last_comment_time, comment_count)
+	VALUES (
+		:space,
+		(SELECT id FROM latest),
+		COALESCE((SELECT ts FROM latest), 0),
+		(SELECT count() FROM comments WHERE rank = 1 AND NOT is_deleted)
+	)
+	ON CONFLICT (id) DO UPDATE SET
+		last_comment = excluded.last_comment,
+		last_comment_time = excluded.last_comment_time,
+		comment_count = excluded.comment_count
+`)
@@ -0,0 +1,78 @@
+package blob
+
+import (
+	"seed/backend/core/coretest"
+	"seed/backend/storage"
+	"seed/backend/util/cclock"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestReindexScope(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+	clock := cclock.New()
+	ctx := t.Context()
+
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(ctx, db, zap.NewNop())
+	require.NoError(t, err)
+
+	newDocument := func(u coretest.Tester, path string) []blocks.Block {
+		t.Helper()
+		ts := clock.MustNow()
+		change, err := NewChange(u.Account, cid.Undef, nil, 0, ChangeBody{}, ts)
+		require.NoError(t, err)
+		ref, err := NewRef(u.Account, ts.UnixMilli(), change.CID, u.Account.Principal(), path, []cid.Cid{change.CID}, ts, VisibilityPublic)
+		require.NoError(t, err)
+		return []blocks.Block{change, ref}
+	}
+
+	require.NoError(t, idx.PutMany(ctx, newDocument(alice, "")))
+	require.NoError(t, idx.PutMany(ctx, newDocument(alice, "/doc")))
+	require.NoError(t, idx.PutMany(ctx, newDocument(bob, "")))
+
+	countGenerations := func(u coretest.Tester) int64 {
+		t.Helper()
+		n, err := sqlitex.QueryOnePool[int64](ctx, db, `SELECT count() FROM document_generations dg
+			JOIN resources r ON r.id = dg.resource
+			WHERE r.iri GLOB ?`, "hm://"+u.Account.PublicKey.String()+"*")
+		require.NoError(t, err)
+		return n
+	}
+	require.Equal(t, int64(2), countGenerations(alice))
+	require.Equal(t, int64(1), countGenerations(bob))
+
+	// Simulate the index drifting.
+	require.NoError(t, db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, "DELETE FROM document_generations", nil)
+	}))
+
+	var lastProgress [2]int64
+	res, err := idx.ReindexScope(ctx, IRI("hm://"+alice.Account.PublicKey.String()), func(indexed, total int64) {
+		lastProgress = [2]int64{indexed, total}
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, res.Resources)
+	require.Equal(t, int64(4), res.BlobsIndexed)
+	require.Equal(t, [2]int64{4, 4}, lastProgress)
+	require.Equal(t, int64(2), countGenerations(alice), "documents in the scope must be reindexed")
+	require.Equal(t, int64(0), countGenerations(bob), "documents out of the scope must not be touched")
+
+	res, err = idx.ReindexScope(ctx, IRI("hm://"+bob.Account.PublicKey.String()+"/missing"), nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, res.Resources)
+
+	res, err = idx.ReindexScope(ctx, IRI("hm://"+alice.Account.PublicKey.String()+"/d?c"), nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, res.Resources, "wildcards in the path must be matched literally")
+
+	_, err = idx.ReindexScope(ctx, "not-an-iri", nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,414 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"seed/backend/core"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multicodec"
+)
+
+// SpaceUsage is the storage used by the blobs of a space.
+// Sizes are uncompressed. Media blobs linked from multiple spaces are counted in each of them.
+type SpaceUsage struct {
+	Space           core.Principal
+	StructuralBlobs int64
+	StructuralBytes int64
+	MediaBlobs      int64
+	MediaBytes      int64
+}
+
+// TotalBytes returns the size of all the blobs of the space.
+func (u SpaceUsage) TotalBytes() int64 {
+	return u.StructuralBytes + u.MediaBytes
+}
+
+// SpaceQuotas limits the storage used by spaces, in uncompressed bytes.
+// Zero means no limit.
+type SpaceQuotas struct {
+	// Default is the quota of every space without an override.
+	Default int64
+
+	// Spaces overrides the default quota, keyed by the account ID of the space.
+	Spaces map[string]int64
+}
+
+// Enabled reports whether any space has a quota.
+func (q SpaceQuotas) Enabled() bool {
+	if q.Default > 0 {
+		return true
+	}
+
+	for _, v := range q.Spaces {
+		if v > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Limit returns the quota of the space.
+func (q SpaceQuotas) Limit(space core.Principal) int64 {
+	if v, ok := q.Spaces[space.String()]; ok {
+		return v
+	}
+
+	return q.Default
+}
+
+// SetSpaceQuotas sets the storage quotas of the spaces.
+// Usage keeps being accounted regardless of the quotas, it's up to the callers to enforce them.
+func (idx *Index) SetSpaceQuotas(q SpaceQuotas) {
+	idx.hookMu.Lock()
+	idx.spaceQuotas = q
+	idx.hookMu.Unlock()
+}
+
+// SpaceQuotas returns the storage quotas of the spaces.
+func (idx *Index) SpaceQuotas() SpaceQuotas {
+	idx.hookMu.RLock()
+	defer idx.hookMu.RUnlock()
+	return idx.spaceQuotas
+}
+
+// SpaceOverQuota reports whether the space uses at least as much storage as its quota allows.
+// Spaces of the local keys are never over quota, so our own content keeps syncing from our other devices.
+func (idx *Index) SpaceOverQuota(ctx context.Context, space core.Principal) (bool, error) {
+	limit := idx.SpaceQuotas().Limit(space)
+	if limit <= 0 {
+		return false, nil
+	}
+
+	local, err := idx.bs.keys.hasKey(ctx, space)
+	if err != nil {
+		return false, err
+	}
+	if local {
+		return false, nil
+	}
+
+	usage, err := idx.GetSpaceUsage(ctx, space)
+	if err != nil {
+		return false, err
+	}
+
+	return usage.TotalBytes() >= limit, nil
+}
+
+// GetSpaceUsage returns the storage used by the blobs of the space.
+// Spaces we don't have any blobs of have zero usage.
+func (idx *Index) GetSpaceUsage(ctx context.Context, space core.Principal) (SpaceUsage, error) {
+	out := SpaceUsage{Space: space}
+	if err := idx.db.WithSave(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, qGetSpaceUsage(), func(stmt *sqlite.Stmt) error {
+			out.StructuralBlobs = stmt.ColumnInt64(0)
+			out.StructuralBytes = stmt.ColumnInt64(1)
+			out.MediaBlobs = stmt.ColumnInt64(2)
+			out.MediaBytes = stmt.ColumnInt64(3)
+			return nil
+		}, space.String())
+	}); err != nil {
+		return SpaceUsage{}, err
+	}
+
+	return out, nil
+}
+
+var qGetSpaceUsage = dqb.Str(`
+	SELECT structural_blobs, structural_bytes, media_blobs, media_bytes
+	FROM space_usage
+	WHERE space = :space
+`)
+
+// ListSpaceUsage returns the storage used by each space, largest first.
+func (idx *Index) ListSpaceUsage(ctx context.Context) (out []SpaceUsage, err error) {
+	if err := idx.db.WithSave(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, qListSpaceUsage(), func(stmt *sqlite.Stmt) error {
+			space, err := core.DecodePrincipal(stmt.ColumnText(0))
+			if err != nil {
+				return fmt.Errorf("failed to decode space %s: %w", stmt.ColumnText(0), err)
+			}
+
+			out = append(out, SpaceUsage{
+				Space:           space,
+				StructuralBlobs: stmt.ColumnInt64(1),
+				StructuralBytes: stmt.ColumnInt64(2),
+				MediaBlobs:      stmt.ColumnInt64(3),
+				MediaBytes:      stmt.ColumnInt64(4),
+			})
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+var qListSpaceUsage = dqb.Str(`
+	SELECT space, structural_blobs, structural_bytes, media_blobs, media_bytes
+	FROM space_usage
+	ORDER BY structural_bytes + media_bytes DESC, space
+`)
+
+// ContentSpace returns the space named by a content blob, i.e. a Ref, Comment, Reaction, or Snapshot,
+// without indexing it. The space defaults to the signer when the blob doesn't name it explicitly.
+// Other blobs don't name their space, or are access control blobs that must never be refused because of quotas.
+func ContentSpace(c cid.Cid, data []byte) (core.Principal, bool) {
+	if multicodec.Code(c.Prefix().Codec) != multicodec.DagCbor {
+		return nil, false
+	}
+
+	var v map[string]any
+	if err := cbornode.DecodeInto(data, &v); err != nil {
+		return nil, false
+	}
+
+	switch t, _ := v["type"].(string); Type(t) {
+	case TypeRef, TypeComment, TypeReaction, TypeSnapshot:
+	default:
+		return nil, false
+	}
+
+	if space, _ := v["space"].([]byte); len(space) > 0 {
+		return core.Principal(space), true
+	}
+
+	if signer, _ := v["signer"].([]byte); len(signer) > 0 {
+		return core.Principal(signer), true
+	}
+
+	return nil, false
+}
+
+// ContentLinks returns the CIDs linked from a DAG-CBOR blob without indexing it,
+// e.g. the genesis and heads of a Ref, or the dependencies and media of a Change.
+// Other blobs don't have links.
+func ContentLinks(c cid.Cid, data []byte) []cid.Cid {
+	if multicodec.Code(c.Prefix().Codec) != multicodec.DagCbor {
+		return nil
+	}
+
+	nd, err := cbornode.Decode(data, c.Prefix().MhType, c.Prefix().MhLength)
+	if err != nil {
+		return nil
+	}
+
+	links := nd.Links()
+	out := make([]cid.Cid, len(links))
+	for i, l := range links {
+		out[i] = l.Cid
+	}
+
+	return out
+}
+
+// resetSpaceUsageIDs creates or clears the temp table with the blobs whose storage is refreshed.
+func resetSpaceUsageIDs(conn *sqlite.Conn) error {
+	if err := sqlitex.Exec(conn, "CREATE TEMP TABLE IF NOT EXISTS space_usage_ids (id INTEGER PRIMARY KEY)", nil); err != nil {
+		return err
+	}
+
+	return sqlitex.Exec(conn, "DELETE FROM temp.space_usage_ids", nil)
+}
+
+// collectSpaceUsageMedia adds the media linked from the blobs in the given table to space_usage_ids.
+// It must be called before the links of the blobs are dropped,
+// so that the media is attributed again without the spaces of the dropped blobs.
+func collectSpaceUsageMedia(conn *sqlite.Conn, table string) error {
+	if err := resetSpaceUsageIDs(conn); err != nil {
+		return err
+	}
+
+	return sqlitex.Exec(conn, qCollectLinkedMedia(table), nil)
+}
+
+// maintainSpaceUsage accounts the storage of freshly indexed blobs.
+// It's applied by the indexed hook worker, off the foreground write path.
+func maintainSpaceUsage(conn *sqlite.Conn, ids []int64) error {
+	if err := resetSpaceUsageIDs(conn); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := sqlitex.Exec(conn, "INSERT OR IGNORE INTO space_usage_ids (id) VALUES (?)", nil, id); err != nil {
+			return err
+		}
+	}
+
+	return refreshSpaceUsage(conn, "space_usage_ids")
+}
+
+// rebuildSpaceUsage accounts the storage of all the blobs from scratch, after the full reindex.
+func rebuildSpaceUsage(conn *sqlite.Conn) error {
+	if err := resetSpaceUsageIDs(conn); err != nil {
+		return err
+	}
+
+	// Media blobs are reached from the structural blobs linking to them.
+	if err := sqlitex.Exec(conn, "INSERT INTO space_usage_ids SELECT id FROM structural_blobs WHERE type != 'DagPB'", nil); err != nil {
+		return err
+	}
+
+	return attributeSpaceUsage(conn, "space_usage_ids")
+}
+
+// refreshSpaceUsage attributes the blobs in the given table to the spaces whose storage they use,
+// replacing their previous attribution, and updates the usage of the affected spaces.
+// The table must have a single id column, and it's extended with the changes attributed along the way.
+// Blobs whose data is missing or was pruned are removed from the usage.
+func refreshSpaceUsage(conn *sqlite.Conn, table string) error {
+	// Changes don't have a resource, so they can only be attributed once a Ref of their document is known.
+	if err := sqlitex.Exec(conn, qCollectUnattributedChanges(table), nil); err != nil {
+		return err
+	}
+
+	return attributeSpaceUsage(conn, table)
+}
+
+func attributeSpaceUsage(conn *sqlite.Conn, table string) error {
+	if err := sqlitex.Exec(conn, `CREATE TEMP TABLE IF NOT EXISTS space_usage_staged (
+		space TEXT NOT NULL,
+		id INTEGER NOT NULL,
+		is_media BOOLEAN NOT NULL,
+		size INTEGER NOT NULL,
+		PRIMARY KEY (space, id)
+	) WITHOUT ROWID`, nil); err != nil {
+		return err
+	}
+	if err := sqlitex.Exec(conn, "DELETE FROM temp.space_usage_staged", nil); err != nil {
+		return err
+	}
+
+	for _, q := range [...]string{
+		qApplySpaceUsage("-", "SELECT space, is_media, size FROM space_usage_blobs WHERE id IN "+table),
+		"DELETE FROM space_usage_blobs WHERE id IN " + table,
+		qStageStructuralUsage(table),
+		qStageLinkedMediaUsage(table),
+		qStageMediaClosureUsage,
+		qApplySpaceUsage("", `SELECT space, is_media, size FROM space_usage_staged s
+			WHERE NOT EXISTS (SELECT 1 FROM space_usage_blobs u WHERE u.space = s.space AND u.id = s.id)`),
+		"INSERT OR IGNORE INTO space_usage_blobs (space, id, is_media, size) SELECT space, id, is_media, size FROM space_usage_staged",
+		"DELETE FROM space_usage WHERE structural_blobs <= 0 AND media_blobs <= 0",
+	} {
+		if err := sqlitex.Exec(conn, q, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// The queries on the temp tables can't be dqb.Str, because the tables don't exist in the schema.
+// Structural blobs are the ones with a structural_blobs row, except DagPB files, which are media.
+
+// spaceOfIRI extracts the space account ID from the hm:// IRI of a resource.
+const spaceOfIRI = `substr(r.iri, 6, instr(substr(r.iri, 6) || '/', '/') - 1)`
+
+func qCollectLinkedMedia(table string) string {
+	return `WITH RECURSIVE media (id) AS (
+		SELECT id FROM ` + table + `
+		UNION
+		SELECT bl.target
+		FROM media m
+		JOIN blob_links bl ON bl.source = m.id
+		WHERE NOT EXISTS (SELECT 1 FROM structural_blobs sb WHERE sb.id = bl.target AND sb.type != 'DagPB')
+	)
+	INSERT OR IGNORE INTO space_usage_ids (id)
+	SELECT m.id
+	FROM media m
+	WHERE EXISTS (SELECT 1 FROM space_usage_blobs u WHERE u.id = m.id AND u.is_media)`
+}
+
+func qCollectUnattributedChanges(table string) string {
+	return `INSERT OR IGNORE INTO ` + table + ` (id)
+	SELECT ch.id
+	FROM ` + table + ` t
+	JOIN structural_blobs sb ON sb.id = t.id AND sb.type = 'Ref'
+	JOIN resources r ON r.id = sb.resource
+	JOIN structural_blobs ch ON ch.genesis_blob = r.genesis_blob AND ch.type = 'Change'
+	WHERE NOT EXISTS (SELECT 1 FROM space_usage_blobs u WHERE u.id = ch.id)
+	UNION
+	SELECT r.genesis_blob
+	FROM ` + table + ` t
+	JOIN structural_blobs sb ON sb.id = t.id AND sb.type = 'Ref'
+	JOIN resources r ON r.id = sb.resource
+	WHERE r.genesis_blob IS NOT NULL
+	AND NOT EXISTS (SELECT 1 FROM space_usage_blobs u WHERE u.id = r.genesis_blob)`
+}
+
+// qApplySpaceUsage adds the aggregated attribution rows to the usage of their spaces, with the given sign.
+func qApplySpaceUsage(sign, rows string) string {
+	return `INSERT INTO space_usage (space, structural_blobs, structural_bytes, media_blobs, media_bytes)
+	SELECT
+		space,
+		` + sign + `count() FILTER (WHERE NOT is_media),
+		` + sign + `coalesce(sum(size) FILTER (WHERE NOT is_media), 0),
+		` + sign + `count() FILTER (WHERE is_media),
+		` + sign + `coalesce(sum(size) FILTER (WHERE is_media), 0)
+	FROM (` + rows + `)
+	WHERE true
+	GROUP BY space
+	ON CONFLICT (space) DO UPDATE SET
+		structural_blobs = structural_blobs + excluded.structural_blobs,
+		structural_bytes = structural_bytes + excluded.structural_bytes,
+		media_blobs = media_blobs + excluded.media_blobs,
+		media_bytes = media_bytes + excluded.media_bytes`
+}
+
+// Structural blobs belong to the space of their resource. Changes don't have one,
+// so they belong to the spaces of the resources of their document.
+func qStageStructuralUsage(table string) string {
+	return `INSERT OR IGNORE INTO space_usage_staged (space, id, is_media, size)
+	SELECT ` + spaceOfIRI + `, sb.id, false, b.size
+	FROM ` + table + ` t
+	JOIN structural_blobs sb ON sb.id = t.id AND sb.type != 'DagPB'
+	JOIN resources r ON r.id = sb.resource
+	JOIN blobs b ON b.id = sb.id
+	WHERE b.size > 0
+	AND r.iri GLOB 'hm://?*'
+	UNION ALL
+	SELECT ` + spaceOfIRI + `, sb.id, false, b.size
+	FROM ` + table + ` t
+	JOIN structural_blobs sb ON sb.id = t.id AND sb.type != 'DagPB' AND sb.resource IS NULL
+	JOIN resources r ON r.genesis_blob = coalesce(sb.genesis_blob, sb.id)
+	JOIN blobs b ON b.id = sb.id
+	WHERE b.size > 0
+	AND r.iri GLOB 'hm://?*'`
+}
+
+// Media blobs belong to the spaces of the blobs linking to them.
+func qStageLinkedMediaUsage(table string) string {
+	return `INSERT OR IGNORE INTO space_usage_staged (space, id, is_media, size)
+	SELECT u.space, b.id, true, b.size
+	FROM ` + table + ` t
+	JOIN blobs b ON b.id = t.id
+	JOIN blob_links bl ON bl.target = t.id
+	JOIN space_usage_blobs u ON u.id = bl.source
+	WHERE b.size > 0
+	AND NOT EXISTS (SELECT 1 FROM structural_blobs sb WHERE sb.id = t.id AND sb.type != 'DagPB')`
+}
+
+// The media linked from the staged blobs, e.g. Change -> DagPB file -> Raw chunk, belongs to the same spaces.
+// The links between structural blobs are not followed, because they can cross spaces.
+const qStageMediaClosureUsage = `
+	WITH RECURSIVE media (space, id) AS (
+		SELECT space, id FROM space_usage_staged
+		UNION
+		SELECT m.space, bl.target
+		FROM media m
+		JOIN blob_links bl ON bl.source = m.id
+		WHERE NOT EXISTS (SELECT 1 FROM structural_blobs sb WHERE sb.id = bl.target AND sb.type != 'DagPB')
+	)
+	INSERT OR IGNORE INTO space_usage_staged (space, id, is_media, size)
+	SELECT m.space, m.id, true, b.size
+	FROM media m
+	JOIN blobs b ON b.id = m.id
+	WHERE b.size > 0
+`
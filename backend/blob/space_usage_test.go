@@ -0,0 +1,142 @@
+package blob
+
+import (
+	"seed/backend/core"
+	"seed/backend/core/coretest"
+	"seed/backend/core/keystore"
+	"seed/backend/ipfs"
+	"seed/backend/storage"
+	"seed/backend/util/cclock"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"testing"
+	"time"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multicodec"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSpaceUsage(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+	clock := cclock.New()
+	ctx := t.Context()
+
+	db := storage.MakeTestDB(t)
+	idx, err := OpenIndex(ctx, db, zap.NewNop())
+	require.NoError(t, err)
+
+	media := ipfs.NewBlock(multicodec.Raw, []byte("cover image used by both spaces"))
+
+	newDocument := func(u coretest.Tester, path string) []blocks.Block {
+		t.Helper()
+		ts := clock.MustNow()
+		change, err := NewChange(u.Account, cid.Undef, nil, 0, ChangeBody{Ops: []OpMap{
+			NewOpSetAttributes("", []KeyValue{{Key: []string{"cover"}, Value: "ipfs://" + media.Cid().String()}}),
+		}}, ts)
+		require.NoError(t, err)
+		ref, err := NewRef(u.Account, ts.UnixMilli(), change.CID, u.Account.Principal(), path, []cid.Cid{change.CID}, ts, VisibilityPublic)
+		require.NoError(t, err)
+		return []blocks.Block{change, ref}
+	}
+
+	size := func(blks ...blocks.Block) (n int64) {
+		for _, blk := range blks {
+			n += int64(len(blk.RawData()))
+		}
+		return n
+	}
+
+	requireUsage := func(u coretest.Tester, want SpaceUsage) {
+		t.Helper()
+		require.NoError(t, idx.WaitIndexedHook(ctx))
+		want.Space = u.Account.Principal()
+		got, err := idx.GetSpaceUsage(ctx, u.Account.Principal())
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+
+	// The change arrives before the ref, so it's only attributed once the ref is indexed.
+	aliceDoc := newDocument(alice, "/doc")
+	require.NoError(t, idx.Put(ctx, media))
+	require.NoError(t, idx.Put(ctx, aliceDoc[0]))
+	requireUsage(alice, SpaceUsage{})
+	require.NoError(t, idx.Put(ctx, aliceDoc[1]))
+	requireUsage(alice, SpaceUsage{StructuralBlobs: 2, StructuralBytes: size(aliceDoc...), MediaBlobs: 1, MediaBytes: size(media)})
+
+	bobDoc := newDocument(bob, "")
+	require.NoError(t, idx.PutMany(ctx, bobDoc))
+	requireUsage(bob, SpaceUsage{StructuralBlobs: 2, StructuralBytes: size(bobDoc...), MediaBlobs: 1, MediaBytes: size(media)})
+
+	list, err := idx.ListSpaceUsage(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.GreaterOrEqual(t, list[0].TotalBytes(), list[1].TotalBytes(), "spaces must be sorted by size")
+
+	// The full reindex must account the same usage from scratch.
+	require.NoError(t, idx.Reindex(ctx))
+	requireUsage(alice, SpaceUsage{StructuralBlobs: 2, StructuralBytes: size(aliceDoc...), MediaBlobs: 1, MediaBytes: size(media)})
+	requireUsage(bob, SpaceUsage{StructuralBlobs: 2, StructuralBytes: size(bobDoc...), MediaBlobs: 1, MediaBytes: size(media)})
+
+	// Quotas.
+	over, err := idx.SpaceOverQuota(ctx, bob.Account.Principal())
+	require.NoError(t, err)
+	require.False(t, over, "spaces without quota are never over it")
+
+	idx.SetSpaceQuotas(SpaceQuotas{
+		Default: size(media),
+		Spaces:  map[string]int64{alice.Account.PublicKey.String(): 0},
+	})
+	require.True(t, idx.SpaceQuotas().Enabled())
+	over, err = idx.SpaceOverQuota(ctx, bob.Account.Principal())
+	require.NoError(t, err)
+	require.True(t, over)
+	over, err = idx.SpaceOverQuota(ctx, alice.Account.Principal())
+	require.NoError(t, err)
+	require.False(t, over, "overrides must take precedence over the default quota")
+
+	ks := keystore.NewMemory()
+	require.NoError(t, ks.StoreKey(ctx, "main", bob.Account))
+	idx.SetKeyStore(ks)
+	over, err = idx.SpaceOverQuota(ctx, bob.Account.Principal())
+	require.NoError(t, err)
+	require.False(t, over, "spaces of the local keys are never over quota")
+	idx.SetKeyStore(nil)
+
+	// Collected blobs are removed from the usage.
+	idx.SetCollectRelatedBlobs(func(*sqlite.Conn, []GCRoot) ([]int64, error) { return nil, nil })
+	require.NoError(t, db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, "UPDATE blobs SET insert_time = insert_time - 7200", nil)
+	}))
+	_, err = idx.CollectGarbage(ctx, GCOptions{Accounts: []core.Principal{alice.Account.Principal()}, GracePeriod: time.Hour})
+	require.NoError(t, err)
+	requireUsage(alice, SpaceUsage{StructuralBlobs: 2, StructuralBytes: size(aliceDoc...), MediaBlobs: 1, MediaBytes: size(media)})
+	requireUsage(bob, SpaceUsage{})
+}
+
+func TestContentSpace(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+	ts := time.Now().Round(ClockPrecision)
+
+	change, err := NewChange(alice.Account, cid.Undef, nil, 0, ChangeBody{}, ts)
+	require.NoError(t, err)
+	ref, err := NewRef(alice.Account, ts.UnixMilli(), change.CID, bob.Account.Principal(), "/doc", []cid.Cid{change.CID}, ts, VisibilityPublic)
+	require.NoError(t, err)
+
+	space, ok := ContentSpace(ref.CID, ref.Data)
+	require.True(t, ok)
+	require.Equal(t, bob.Account.Principal(), space)
+
+	_, ok = ContentSpace(change.CID, change.Data)
+	require.False(t, ok, "changes don't name their space")
+	require.Contains(t, ContentLinks(ref.CID, ref.Data), change.CID, "refs link to their genesis and heads")
+
+	raw := ipfs.NewBlock(multicodec.Raw, []byte("hello"))
+	_, ok = ContentSpace(raw.Cid(), raw.RawData())
+	require.False(t, ok)
+	require.Empty(t, ContentLinks(raw.Cid(), raw.RawData()))
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"seed/backend/config"
+	daemon "seed/backend/genproto/daemon/v1alpha"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Most subcommands talk to the gRPC API of a running daemon, which owns the database.
+// The fsck subcommand works on the data directory directly, while the daemon is stopped.
+var subcommands = map[string]func(ctx context.Context, args []string) error{
+	"export-space":   runExportSpace,
+	"import-archive": runImportArchive,
+	"fsck":           runFsck,
+	"gc":             runGC,
+	"usage":          runUsage,
+}
+
+func runExportSpace(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("seed-daemon export-space", flag.ExitOnError)
+	grpcPort := fs.Int("grpc.port", config.GRPC{}.Default().Port, "Port of the gRPC server of the running daemon")
+	account := fs.String("account", "", "ID of the space to export (required)")
+	path := fs.String("path", "", "Path of the document to export with all of its children (default: the entire space)")
+	out := fs.String("out", "", "Path of the .car file to write (required)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *account == "" || *out == "" {
+		fs.Usage()
+		return errors.New("account and out flags are required")
+	}
+
+	file, err := filepath.Abs(*out)
+	if err != nil {
+		return err
+	}
+
+	client, done, err := dialDaemon(*grpcPort)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.ExportSpace(ctx, &daemon.ExportSpaceRequest{
+		Account:  *account,
+		Path:     *path,
+		FilePath: file,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %d blobs with %d documents into %s\n", resp.BlobCount, len(resp.Roots), file)
+	return nil
+}
+
+func runImportArchive(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("seed-daemon import-archive", flag.ExitOnError)
+	grpcPort := fs.Int("grpc.port", config.GRPC{}.Default().Port, "Port of the gRPC server of the running daemon")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: seed-daemon import-archive [flags] <file.car>")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("path of the archive is required")
+	}
+
+	file, err := filepath.Abs(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	client, done, err := dialDaemon(*grpcPort)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.ImportArchive(ctx, &daemon.ImportArchiveRequest{FilePath: file})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %d blobs from %s\n", resp.BlobCount, file)
+	if len(resp.StashedBlobs) > 0 {
+		fmt.Printf("%d blobs couldn't be indexed, and will be indexed when the missing data arrives:\n", len(resp.StashedBlobs))
+		for _, sb := range resp.StashedBlobs {
+			fmt.Printf("  %s\t%s\tmissing=%v denied=%v %s\n", sb.Cid, sb.Reason, sb.MissingBlobs, sb.DeniedSigners, sb.Details)
+		}
+	}
+
+	return nil
+}
+
+func runGC(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("seed-daemon gc", flag.ExitOnError)
+	grpcPort := fs.Int("grpc.port", config.GRPC{}.Default().Port, "Port of the gRPC server of the running daemon")
+	account := fs.String("account", "", "ID of the space to collect the garbage of (default: all the blobs)")
+	dryRun := fs.Bool("dry-run", false, "Only report what would be collected")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, done, err := dialDaemon(*grpcPort)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.CollectGarbage(ctx, &daemon.CollectGarbageRequest{
+		DryRun:  *dryRun,
+		Account: *account,
+	})
+	if err != nil {
+		return err
+	}
+
+	verb := "Collected"
+	if *dryRun {
+		verb = "Would collect"
+	}
+
+	fmt.Printf("Blob store: %d blobs, %d bytes (%d bytes on disk)\n", resp.BlobsTotal, resp.BytesTotal, resp.StoredBytesTotal)
+	fmt.Printf("%s %d blobs, %d bytes (%d bytes on disk)\n", verb, resp.BlobsPruned, resp.BytesPruned, resp.StoredBytesPruned)
+	if !*dryRun {
+		fmt.Printf("Reindexed %d resources, expired %d tombstones\n", resp.ReindexedResources, resp.TombstonesExpired)
+	}
+
+	return nil
+}
+
+func runUsage(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("seed-daemon usage", flag.ExitOnError)
+	grpcPort := fs.Int("grpc.port", config.GRPC{}.Default().Port, "Port of the gRPC server of the running daemon")
+	account := fs.String("account", "", "ID of the space to report (default: all the spaces)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, done, err := dialDaemon(*grpcPort)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	var spaces []*daemon.SpaceUsage
+	if *account != "" {
+		u, err := client.GetSpaceUsage(ctx, &daemon.GetSpaceUsageRequest{Account: *account})
+		if err != nil {
+			return err
+		}
+		spaces = append(spaces, u)
+	} else {
+		resp, err := client.ListSpaceUsage(ctx, &daemon.ListSpaceUsageRequest{})
+		if err != nil {
+			return err
+		}
+		spaces = resp.Spaces
+	}
+
+	for _, u := range spaces {
+		quota := "no quota"
+		if u.Quota > 0 {
+			quota = fmt.Sprintf("quota %d bytes", u.Quota)
+		}
+		fmt.Printf("%s: %d bytes (%s), structural %d blobs / %d bytes, media %d blobs / %d bytes\n",
+			u.Account, u.StructuralBytes+u.MediaBytes, quota, u.StructuralBlobs, u.StructuralBytes, u.MediaBlobs, u.MediaBytes)
+	}
+
+	return nil
+}
+
+func dialDaemon(port int) (daemon.DaemonClient, func(), error) {
+	conn, err := grpc.NewClient("localhost:"+strconv.Itoa(port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to the daemon: %w", err)
+	}
+
+	return daemon.NewDaemonClient(conn), func() { _ = conn.Close() }, nil
+}
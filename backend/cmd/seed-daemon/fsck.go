@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+
+	"seed/backend/blob"
+	"seed/backend/config"
+	"seed/backend/core/keystore"
+	"seed/backend/logging"
+	"seed/backend/storage"
+)
+
+// runFsck verifies the blob store offline, working directly on the data directory.
+// Use the VerifyStore RPC to verify the store of a running daemon.
+func runFsck(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("seed-daemon fsck", flag.ExitOnError)
+	base := config.Base{}.Default()
+	fs.StringVar(&base.DataDir, "data-dir", base.DataDir, "Path to the data directory of the daemon")
+	fs.StringVar(&base.LogLevel, "log-level", base.LogLevel, "Log verbosity debug | info | warning | error")
+	repair := fs.Bool("repair", false, "Reindex the resources affected by the problems, and mark the corrupted blobs as missing")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: seed-daemon fsck [flags]")
+		fmt.Fprintln(fs.Output(), "The daemon must be stopped while the check is running.")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := base.ExpandDataDir(); err != nil {
+		return err
+	}
+
+	// The keys are not needed to verify the store.
+	store, err := storage.Open(base.DataDir, nil, keystore.NewMemory(), base.LogLevel)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	idx, err := blob.OpenIndex(ctx, store.DB(), logging.New("seed/fsck", base.LogLevel))
+	if err != nil {
+		return err
+	}
+
+	report, err := idx.VerifyStore(ctx, blob.VerifyOptions{
+		Repair: *repair,
+		Progress: func(checked, total int64) {
+			fmt.Printf("\rChecked %d/%d blobs", checked, total)
+		},
+	})
+	fmt.Println()
+	if err != nil {
+		return err
+	}
+
+	for _, p := range report.Problems {
+		fmt.Printf("%s\t%s", p.Kind, p.CID)
+		if p.Target.Defined() {
+			fmt.Printf(" -> %s", p.Target)
+		}
+		if p.Resource != "" {
+			fmt.Printf("\t%s", p.Resource)
+		}
+		fmt.Printf("\t%s\n", p.Details)
+	}
+
+	reasons := make([]string, 0, len(report.StashedBlobs))
+	for reason := range report.StashedBlobs {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	for _, reason := range reasons {
+		fmt.Printf("Stashed blobs (%s): %d\n", reason, report.StashedBlobs[reason])
+	}
+
+	fmt.Printf("Checked %d blobs, found %d problems\n", report.BlobsChecked, len(report.Problems))
+	for _, iri := range report.ReindexedResources {
+		fmt.Printf("Reindexed %s\n", iri)
+	}
+
+	return nil
+}
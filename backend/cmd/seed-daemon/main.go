@@ -47,6 +47,15 @@ func init() {
 func main() {
 	pprofx.UseRecommendedSettings()
 
+	if len(os.Args) > 1 {
+		if cmd, ok := subcommands[os.Args[1]]; ok {
+			mainutil.Run(func() error {
+				return cmd(mainutil.TrapSignals(), os.Args[2:])
+			})
+			return
+		}
+	}
+
 	const envVarPrefix = "SEED"
 
 	mainutil.Run(func() error {
@@ -8,6 +8,7 @@ import (
 	"os"
 	"seed/backend/ipfs"
 	"seed/backend/util/must"
+	"strconv"
 	"strings"
 	"time"
 
@@ -66,6 +67,7 @@ type Config struct {
 	LLM     LLM
 	Lndhub  Lndhub
 	Syncing Syncing
+	GC      GC
 	Debug   Debug
 }
 
@@ -83,6 +85,7 @@ func (c *Config) BindFlags(fs *flag.FlagSet) {
 	c.LLM.BindFlags(fs)
 	c.Lndhub.BindFlags(fs)
 	c.Syncing.BindFlags(fs)
+	c.GC.BindFlags(fs)
 	c.Debug.BindFlags(fs)
 }
 
@@ -96,6 +99,7 @@ func Default() Config {
 		LLM:     LLM{}.Default(),
 		Lndhub:  Lndhub{}.Default(),
 		Syncing: Syncing{}.Default(),
+		GC:      GC{}.Default(),
 		Debug:   Debug{}.Default(),
 	}
 }
@@ -143,6 +147,54 @@ func newAddrsFlag(val []multiaddr.Multiaddr, p *[]multiaddr.Multiaddr) flag.Valu
 	return (*addrsFlag)(p)
 }
 
+type quotasFlag map[string]int64
+
+func (q *quotasFlag) String() string {
+	if q == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	for k, v := range *q {
+		if sb.Len() > 0 {
+			sb.WriteByte(',')
+		}
+		fmt.Fprintf(&sb, "%s=%d", k, v)
+	}
+
+	return sb.String()
+}
+
+func (q *quotasFlag) Set(s string) error {
+	out := make(map[string]int64)
+	for entry := range strings.SplitSeq(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		k, v, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("invalid quota '%s': must be <account>=<bytes>", entry)
+		}
+
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid quota '%s': bytes must be a non-negative integer", entry)
+		}
+
+		out[strings.TrimSpace(k)] = n
+	}
+
+	*q = out
+	return nil
+}
+
+func newQuotasFlag(val map[string]int64, p *map[string]int64) flag.Value {
+	*p = val
+	return (*quotasFlag)(p)
+}
+
 type urlFlag url.URL
 
 func (al *urlFlag) String() string {
@@ -312,6 +364,13 @@ type Syncing struct {
 	// under-advertising peer can go undetected. Zero means the built-in
 	// default.
 	ExhaustiveWaveInterval time.Duration
+
+	// SpaceQuota is the storage quota of each space, in uncompressed bytes.
+	// Content of spaces over their quota is not synced, except for the spaces of the local keys. Zero means no limit.
+	SpaceQuota int64
+
+	// SpaceQuotas overrides SpaceQuota for specific spaces, keyed by their account ID.
+	SpaceQuotas map[string]int64
 }
 
 func (c Syncing) Default() Syncing {
@@ -337,6 +396,8 @@ func (c *Syncing) BindFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&c.NoPull, "syncing.no-pull", c.NoPull, "Disables periodic content pulling.")
 	fs.BoolVar(&c.NoDiscovery, "syncing.no-discovery", c.NoDiscovery, "Disables the ability to discover content from other peers")
 	fs.DurationVar(&c.ExhaustiveWaveInterval, "syncing.exhaustive-wave-interval", c.ExhaustiveWaveInterval, "How often a settled subscription still runs one full-width, all-tier discovery wave")
+	fs.Int64Var(&c.SpaceQuota, "syncing.space-quota", c.SpaceQuota, "Storage quota of each space in bytes, after which its content is not synced. Zero means no limit")
+	fs.Var(newQuotasFlag(c.SpaceQuotas, &c.SpaceQuotas), "syncing.space-quotas", "Comma-separated storage quotas of specific spaces as <account>=<bytes>, overriding the default quota")
 
 	// Deprecated flags. Still defined here to avoid errors if these flags are passed.
 	fs.Bool("syncing.smart", true, "Deprecated (doesn't do anything): Enables subscription-based syncing and deactivates dumb syncing")
@@ -454,6 +515,27 @@ func (p2p P2P) IsBootstrap(p peer.ID) bool {
 	return false
 }
 
+// GC configuration for the garbage collection of the blob store.
+type GC struct {
+	// Interval of the scheduled garbage collection. Zero disables it.
+	Interval time.Duration
+	// GracePeriod protects recently received blobs from being collected,
+	// and keeps the tombstones of the collected ones for as long.
+	GracePeriod time.Duration
+}
+
+func (c GC) Default() GC {
+	return GC{
+		GracePeriod: time.Hour * 24 * 30,
+	}
+}
+
+// BindFlags binds the flags to the given FlagSet.
+func (c *GC) BindFlags(fs *flag.FlagSet) {
+	fs.DurationVar(&c.Interval, "gc.interval", c.Interval, "Periodic interval of the garbage collection of unreachable blobs. Zero disables it")
+	fs.DurationVar(&c.GracePeriod, "gc.grace-period", c.GracePeriod, "Minimum age of the blobs to be garbage collected, and for how long their tombstones are kept")
+}
+
 // Debug configuration.
 type Debug struct {
 	DBReindexProfileDir string
@@ -0,0 +1,170 @@
+package core
+
+import (
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+// sealInfo is the HKDF info string for the sealed box encryption.
+// It must be changed if the format of the sealed boxes ever changes.
+const sealInfo = "seed-sealed-box-v1"
+
+// SealTo encrypts data so that only the holder of the private key corresponding to pub can decrypt it.
+// It's an anonymous sealed box: a fresh ephemeral key is used for the key agreement with the recipient,
+// and the resulting box is the ephemeral public key followed by the ciphertext.
+// Ed25519 recipients are converted to X25519, and P-256 recipients use ECDH on the same curve.
+func SealTo(pub PublicKey, data []byte) ([]byte, error) {
+	recipient, err := pub.ecdhPublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	eph, err := recipient.Curve().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := eph.ECDH(recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	ephPub := eph.PublicKey().Bytes()
+
+	aead, err := sealCipher(shared, ephPub, recipient.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// The key is unique for every box, so we can use a fixed nonce.
+	nonce := make([]byte, aead.NonceSize())
+	return aead.Seal(ephPub, nonce, data, nil), nil
+}
+
+// Open decrypts a box created with [SealTo] for the public key of this key pair.
+func (kp *KeyPair) Open(box []byte) ([]byte, error) {
+	priv, err := kp.ecdhPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	ephSize := len(priv.PublicKey().Bytes())
+	if len(box) < ephSize+chacha20poly1305.Overhead {
+		return nil, fmt.Errorf("sealed box is too short")
+	}
+
+	eph, err := priv.Curve().NewPublicKey(box[:ephSize])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ephemeral key in sealed box: %w", err)
+	}
+
+	shared, err := priv.ECDH(eph)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := sealCipher(shared, box[:ephSize], priv.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	out, err := aead.Open(nil, nonce, box[ephSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sealed box: %w", err)
+	}
+
+	return out, nil
+}
+
+// sealCipher derives the box cipher from the shared secret, binding it to both public keys.
+func sealCipher(shared, ephPub, recipientPub []byte) (cipher.AEAD, error) {
+	salt := slices.Concat(ephPub, recipientPub)
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared, salt, []byte(sealInfo)), key); err != nil {
+		return nil, err
+	}
+
+	return chacha20poly1305.New(key)
+}
+
+// ecdhPublicKey converts the public key into a key that can be used for key agreement.
+func (p PublicKey) ecdhPublicKey() (*ecdh.PublicKey, error) {
+	switch k := p.inner.(type) {
+	case ed25519.PublicKey:
+		u, err := ed25519PublicToX25519(k)
+		if err != nil {
+			return nil, err
+		}
+		return ecdh.X25519().NewPublicKey(u)
+	case ecdsa.PublicKey:
+		return k.ECDH()
+	default:
+		return nil, fmt.Errorf("key agreement is not supported for public key type %T", p.inner)
+	}
+}
+
+// ecdhPrivateKey converts the private key into a key that can be used for key agreement.
+func (kp *KeyPair) ecdhPrivateKey() (*ecdh.PrivateKey, error) {
+	switch k := kp.inner.(type) {
+	case ed25519.PrivateKey:
+		// The X25519 scalar is derived from the Ed25519 seed the same way Ed25519 itself does it (RFC 8032).
+		// Clamping is done by X25519.
+		h := sha512.Sum512(k.Seed())
+		return ecdh.X25519().NewPrivateKey(h[:curve25519.ScalarSize])
+	case *ecdsa.PrivateKey:
+		return k.ECDH()
+	default:
+		return nil, fmt.Errorf("key agreement is not supported for private key type %T", kp.inner)
+	}
+}
+
+var curve25519P = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+// ed25519PublicToX25519 converts an Ed25519 public key (an Edwards point)
+// into the corresponding X25519 public key (the Montgomery u-coordinate),
+// using the birational map u = (1 + y) / (1 - y).
+func ed25519PublicToX25519(pub ed25519.PublicKey) ([]byte, error) {
+	if len(pub) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid ed25519 public key size %d", len(pub))
+	}
+
+	// The point is encoded as the little-endian y coordinate, with the sign of x in the top bit.
+	le := slices.Clone(pub)
+	le[31] &= 0x7f
+	slices.Reverse(le)
+	y := new(big.Int).SetBytes(le)
+	if y.Cmp(curve25519P) >= 0 {
+		return nil, fmt.Errorf("invalid ed25519 public key: non-canonical encoding")
+	}
+
+	one := big.NewInt(1)
+	den := new(big.Int).Sub(one, y)
+	den.Mod(den, curve25519P)
+	if den.Sign() == 0 {
+		return nil, fmt.Errorf("invalid ed25519 public key: can't be converted to X25519")
+	}
+	den.ModInverse(den, curve25519P)
+
+	u := new(big.Int).Add(one, y)
+	u.Mul(u, den)
+	u.Mod(u, curve25519P)
+
+	out := make([]byte, curve25519.PointSize)
+	u.FillBytes(out)
+	slices.Reverse(out)
+	return out, nil
+}
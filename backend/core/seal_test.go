@@ -0,0 +1,69 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha512"
+	"seed/backend/util/must"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/curve25519"
+)
+
+func TestSealedBox(t *testing.T) {
+	_, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	p256Priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	keys := map[string]*KeyPair{
+		"ed25519": NewKeyPair(edPriv),
+		"p256":    NewKeyPair(p256Priv),
+	}
+
+	other := must.Do2(GenerateKeyPair(Ed25519, nil))
+
+	for name, kp := range keys {
+		t.Run(name, func(t *testing.T) {
+			msg := []byte("Hello World")
+
+			box, err := SealTo(kp.PublicKey, msg)
+			require.NoError(t, err)
+			require.NotContains(t, string(box), string(msg))
+
+			got, err := kp.Open(box)
+			require.NoError(t, err)
+			require.Equal(t, msg, got)
+
+			box2, err := SealTo(kp.PublicKey, msg)
+			require.NoError(t, err)
+			require.NotEqual(t, box, box2, "boxes must be randomized")
+
+			_, err = other.Open(box)
+			require.Error(t, err, "other keys must not open the box")
+
+			box[len(box)-1] ^= 0xff
+			_, err = kp.Open(box)
+			require.Error(t, err, "tampered boxes must not be opened")
+		})
+	}
+}
+
+func TestEd25519PublicToX25519(t *testing.T) {
+	for range 10 {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		h := sha512.Sum512(priv.Seed())
+		want, err := curve25519.X25519(h[:32], curve25519.Basepoint)
+		require.NoError(t, err)
+
+		got, err := ed25519PublicToX25519(pub)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+}
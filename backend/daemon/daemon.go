@@ -160,7 +160,13 @@ func Load(ctx context.Context, cfg config.Config, r *storage.Store, oo ...Option
 	// start: a migration-triggered backfill reindex must derive
 	// the fallback cover for every document, and the documents server that
 	// also wires this is constructed only later in initGRPC.
+	// The same goes for the comment anchor resolver.
 	a.Index.SetDeriveFirstContentImage(documentsv3.DeriveFirstContentImage)
+	a.Index.SetResolveCommentAnchors(documentsv3.ResolveCommentAnchors)
+	// Private content is decrypted during indexing with the local keys.
+	a.Index.SetKeyStore(a.Storage.KeyStore())
+	// Syncing doesn't fetch content of spaces over their storage quota.
+	a.Index.SetSpaceQuotas(blob.SpaceQuotas{Default: cfg.Syncing.SpaceQuota, Spaces: cfg.Syncing.SpaceQuotas})
 	a.clean.Add(a.Index.Domains)
 	a.taskMgr.UpdateGlobalState(daemon.State_STARTING)
 
@@ -209,13 +215,38 @@ func Load(ctx context.Context, cfg config.Config, r *storage.Store, oo ...Option
 		lightEmbedder = embedder
 	}
 
-	a.GRPCServer, a.GRPCListener, a.RPC, err = initGRPC(cfg.Base, cfg.GRPC.Port, &a.clean, a.g, a.Storage, a.Index, a.Net,
+	a.GRPCServer, a.GRPCListener, a.RPC, err = initGRPC(cfg.Base, cfg.GC, cfg.GRPC.Port, &a.clean, a.g, a.Storage, a.Index, a.Net,
 		a.Syncing, activitySrv, cfg.LogLevel, cfg.Lndhub.Mainnet, opts.grpc, a.taskMgr, lightEmbedder)
 	if err != nil {
 		return nil, err
 	}
 
 	a.Syncing.SetDocGetter(a.RPC.DocumentsV3)
+
+	// Publish scheduled Refs in the background once the database is migrated.
+	a.g.Go(func() error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-migratedc:
+		}
+
+		return a.RPC.DocumentsV3.RunScheduler(ctx)
+	})
+
+	// Collect garbage periodically once the database is migrated, if enabled.
+	if cfg.GC.Interval > 0 {
+		a.g.Go(func() error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-migratedc:
+			}
+
+			return a.RPC.Daemon.RunGarbageCollector(ctx, cfg.GC.Interval)
+		})
+	}
+
 	var fm *hmnet.FileManager
 	{
 		var e exchange.Interface = a.Net.Bitswap()
@@ -362,6 +393,7 @@ func initSyncing(
 
 func initGRPC(
 	cfg config.Base,
+	gcCfg config.GC,
 	port int,
 	clean *cleanup.Stack,
 	g *errgroup.Group,
@@ -382,7 +414,7 @@ func initGRPC(
 	}
 
 	srv = grpc.NewServer(opts.serverOptions...)
-	apis = api.New(cfg, repo, idx, node, sync, activity, LogLevel, isMainnet, taskMgr, embedder)
+	apis = api.New(cfg, gcCfg, repo, idx, node, sync, activity, LogLevel, isMainnet, taskMgr, embedder)
 	apis.Register(srv)
 
 	if remoteVault, ok := repo.KeyStore().(*vault.Vault); ok {
@@ -18,6 +18,9 @@ const (
 	blobTaskID          = "blob_reindex"
 	blobTaskDescription = "Reindexing blobs"
 	blobTaskUpdateEvery = 100 * time.Millisecond
+
+	scopedTaskID          = "blob_reindex_scoped"
+	scopedTaskDescription = "Reindexing "
 )
 
 type reindexInfoProvider interface {
@@ -85,3 +88,34 @@ func RunBlobReindexTask(
 
 	return err
 }
+
+// RunScopedReindexTask executes a scoped reindex operation while exposing its progress through the task manager.
+// Only one scoped reindex can be tracked at a time, so it fails with taskmanager.ErrTaskExists if another one is running.
+func RunScopedReindexTask(
+	ctx context.Context,
+	scope string,
+	tasks *taskmanager.TaskManager,
+	log *zap.Logger,
+	run func(ctx context.Context, progress func(indexed, total int64)) error,
+) error {
+	if log == nil {
+		log = zap.NewNop()
+	}
+
+	if _, err := tasks.AddTask(scopedTaskID, daemonpb.TaskName_REINDEXING, scopedTaskDescription+scope, 0); err != nil {
+		return err
+	}
+
+	err := run(ctx, func(indexed, total int64) {
+		if _, err := tasks.UpdateProgress(scopedTaskID, total, indexed); err != nil && !errors.Is(err, taskmanager.ErrTaskMissing) {
+			log.Warn("Failed to update scoped reindex task progress", zap.Error(err))
+		}
+	})
+
+	if _, derr := tasks.DeleteTask(scopedTaskID); derr != nil && !errors.Is(derr, taskmanager.ErrTaskMissing) {
+		log.Warn("Failed to delete scoped reindex task", zap.Error(derr))
+		err = errors.Join(err, derr)
+	}
+
+	return err
+}
@@ -195,6 +195,10 @@ const (
 	TaskName_EMBEDDING TaskName = 2
 	// Task for loading a machine learning model.
 	TaskName_LOADING_MODEL TaskName = 3
+	// Task for verifying the integrity of the blob store.
+	TaskName_VERIFYING_STORE TaskName = 4
+	// Task for collecting garbage in the blob store.
+	TaskName_COLLECTING_GARBAGE TaskName = 5
 )
 
 // Enum value maps for TaskName.
@@ -204,12 +208,16 @@ var (
 		1: "REINDEXING",
 		2: "EMBEDDING",
 		3: "LOADING_MODEL",
+		4: "VERIFYING_STORE",
+		5: "COLLECTING_GARBAGE",
 	}
 	TaskName_value = map[string]int32{
 		"TASK_NAME_UNSPECIFIED": 0,
 		"REINDEXING":            1,
 		"EMBEDDING":             2,
 		"LOADING_MODEL":         3,
+		"VERIFYING_STORE":       4,
+		"COLLECTING_GARBAGE":    5,
 	}
 )
 
@@ -1556,7 +1564,12 @@ func (*SetVaultNotificationServerResponse) Descriptor() ([]byte, []int) {
 
 // Request to force reindexing of the entire database.
 type ForceReindexRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional. ID of the space to reindex. Reindexes the entire database if empty.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Optional. Path of the document to reindex with all of its children.
+	// Reindexes the entire space if empty. Requires account.
+	Path          string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1591,28 +1604,1216 @@ func (*ForceReindexRequest) Descriptor() ([]byte, []int) {
 	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{27}
 }
 
-// Response after forcing reindexing.
-type ForceReindexResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *ForceReindexRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *ForceReindexRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+// Response after forcing reindexing.
+type ForceReindexResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of reindexed resources. Only set for scoped reindexing.
+	ResourcesReindexed int64 `protobuf:"varint,1,opt,name=resources_reindexed,json=resourcesReindexed,proto3" json:"resources_reindexed,omitempty"`
+	// Number of indexed blobs. Only set for scoped reindexing.
+	BlobsIndexed  int64 `protobuf:"varint,2,opt,name=blobs_indexed,json=blobsIndexed,proto3" json:"blobs_indexed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceReindexResponse) Reset() {
+	*x = ForceReindexResponse{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceReindexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceReindexResponse) ProtoMessage() {}
+
+func (x *ForceReindexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceReindexResponse.ProtoReflect.Descriptor instead.
+func (*ForceReindexResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ForceReindexResponse) GetResourcesReindexed() int64 {
+	if x != nil {
+		return x.ResourcesReindexed
+	}
+	return 0
+}
+
+func (x *ForceReindexResponse) GetBlobsIndexed() int64 {
+	if x != nil {
+		return x.BlobsIndexed
+	}
+	return 0
+}
+
+// Request to delete all keys.
+type DeleteAllKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAllKeysRequest) Reset() {
+	*x = DeleteAllKeysRequest{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAllKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAllKeysRequest) ProtoMessage() {}
+
+func (x *DeleteAllKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAllKeysRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAllKeysRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{29}
+}
+
+// Request to list signing keys.
+type ListKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListKeysRequest) Reset() {
+	*x = ListKeysRequest{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKeysRequest) ProtoMessage() {}
+
+func (x *ListKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListKeysRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{30}
+}
+
+// Response with the list of registered signing keys.
+type ListKeysResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// List of registered keys.
+	Keys          []*NamedKey `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListKeysResponse) Reset() {
+	*x = ListKeysResponse{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKeysResponse) ProtoMessage() {}
+
+func (x *ListKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListKeysResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListKeysResponse) GetKeys() []*NamedKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// Request to change the key name.
+type UpdateKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Current name of the key.
+	CurrentName string `protobuf:"bytes,1,opt,name=current_name,json=currentName,proto3" json:"current_name,omitempty"`
+	// New name for the key.
+	NewName       string `protobuf:"bytes,2,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateKeyRequest) Reset() {
+	*x = UpdateKeyRequest{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateKeyRequest) ProtoMessage() {}
+
+func (x *UpdateKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateKeyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateKeyRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdateKeyRequest) GetCurrentName() string {
+	if x != nil {
+		return x.CurrentName
+	}
+	return ""
+}
+
+func (x *UpdateKeyRequest) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+// Request to delete an existing key.
+type DeleteKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the key to delete.
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteKeyRequest) Reset() {
+	*x = DeleteKeyRequest{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteKeyRequest) ProtoMessage() {}
+
+func (x *DeleteKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteKeyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteKeyRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeleteKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Request to store blobs.
+type StoreBlobsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. List of blobs to be stored.
+	// The request is atomic: either all blobs are stored or none of them.
+	Blobs         []*Blob `protobuf:"bytes,1,rep,name=blobs,proto3" json:"blobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreBlobsRequest) Reset() {
+	*x = StoreBlobsRequest{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreBlobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreBlobsRequest) ProtoMessage() {}
+
+func (x *StoreBlobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreBlobsRequest.ProtoReflect.Descriptor instead.
+func (*StoreBlobsRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *StoreBlobsRequest) GetBlobs() []*Blob {
+	if x != nil {
+		return x.Blobs
+	}
+	return nil
+}
+
+// Response after storing blobs.
+type StoreBlobsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// List of CIDs for the stored blobs.
+	// The order is the same as in the request.
+	Cids          []string `protobuf:"bytes,1,rep,name=cids,proto3" json:"cids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreBlobsResponse) Reset() {
+	*x = StoreBlobsResponse{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreBlobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreBlobsResponse) ProtoMessage() {}
+
+func (x *StoreBlobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreBlobsResponse.ProtoReflect.Descriptor instead.
+func (*StoreBlobsResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *StoreBlobsResponse) GetCids() []string {
+	if x != nil {
+		return x.Cids
+	}
+	return nil
+}
+
+// Request to export a space into a CAR file.
+type ExportSpaceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the space to export.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Optional. Path of the document to export along with all of its children.
+	// By default the entire space is exported.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Required. Absolute path to a .car file to write.
+	// Existing files are overwritten.
+	FilePath      string `protobuf:"bytes,3,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSpaceRequest) Reset() {
+	*x = ExportSpaceRequest{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSpaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSpaceRequest) ProtoMessage() {}
+
+func (x *ExportSpaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSpaceRequest.ProtoReflect.Descriptor instead.
+func (*ExportSpaceRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ExportSpaceRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *ExportSpaceRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ExportSpaceRequest) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+// Response after exporting a space.
+type ExportSpaceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of blobs written into the file.
+	BlobCount int64 `protobuf:"varint,1,opt,name=blob_count,json=blobCount,proto3" json:"blob_count,omitempty"`
+	// CIDs of the roots of the archive, which are the Refs of the exported documents.
+	Roots         []string `protobuf:"bytes,2,rep,name=roots,proto3" json:"roots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSpaceResponse) Reset() {
+	*x = ExportSpaceResponse{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSpaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSpaceResponse) ProtoMessage() {}
+
+func (x *ExportSpaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSpaceResponse.ProtoReflect.Descriptor instead.
+func (*ExportSpaceResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ExportSpaceResponse) GetBlobCount() int64 {
+	if x != nil {
+		return x.BlobCount
+	}
+	return 0
+}
+
+func (x *ExportSpaceResponse) GetRoots() []string {
+	if x != nil {
+		return x.Roots
+	}
+	return nil
+}
+
+// Request to import a CAR file.
+type ImportArchiveRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. Absolute path to a .car file to read.
+	FilePath      string `protobuf:"bytes,1,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportArchiveRequest) Reset() {
+	*x = ImportArchiveRequest{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportArchiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportArchiveRequest) ProtoMessage() {}
+
+func (x *ImportArchiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportArchiveRequest.ProtoReflect.Descriptor instead.
+func (*ImportArchiveRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ImportArchiveRequest) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+// Response after importing a CAR file.
+type ImportArchiveResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of blobs read from the file.
+	BlobCount int64 `protobuf:"varint,1,opt,name=blob_count,json=blobCount,proto3" json:"blob_count,omitempty"`
+	// Blobs from the archive that were stored, but couldn't be indexed,
+	// e.g. because their signers don't have permissions in the space,
+	// or because the blobs they depend on are missing.
+	// They'll be indexed when the missing pieces arrive.
+	StashedBlobs  []*StashedBlob `protobuf:"bytes,2,rep,name=stashed_blobs,json=stashedBlobs,proto3" json:"stashed_blobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportArchiveResponse) Reset() {
+	*x = ImportArchiveResponse{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportArchiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportArchiveResponse) ProtoMessage() {}
+
+func (x *ImportArchiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportArchiveResponse.ProtoReflect.Descriptor instead.
+func (*ImportArchiveResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ImportArchiveResponse) GetBlobCount() int64 {
+	if x != nil {
+		return x.BlobCount
+	}
+	return 0
+}
+
+func (x *ImportArchiveResponse) GetStashedBlobs() []*StashedBlob {
+	if x != nil {
+		return x.StashedBlobs
+	}
+	return nil
+}
+
+// Blob that couldn't be indexed.
+type StashedBlob struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CID of the blob.
+	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Reason why the blob couldn't be indexed.
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// CIDs of the blobs this blob is waiting for.
+	MissingBlobs []string `protobuf:"bytes,3,rep,name=missing_blobs,json=missingBlobs,proto3" json:"missing_blobs,omitempty"`
+	// Signers that lack permissions to produce this blob.
+	DeniedSigners []string `protobuf:"bytes,4,rep,name=denied_signers,json=deniedSigners,proto3" json:"denied_signers,omitempty"`
+	// Extra details about the failure.
+	Details       string `protobuf:"bytes,5,opt,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StashedBlob) Reset() {
+	*x = StashedBlob{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StashedBlob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StashedBlob) ProtoMessage() {}
+
+func (x *StashedBlob) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StashedBlob.ProtoReflect.Descriptor instead.
+func (*StashedBlob) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *StashedBlob) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *StashedBlob) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StashedBlob) GetMissingBlobs() []string {
+	if x != nil {
+		return x.MissingBlobs
+	}
+	return nil
+}
+
+func (x *StashedBlob) GetDeniedSigners() []string {
+	if x != nil {
+		return x.DeniedSigners
+	}
+	return nil
+}
+
+func (x *StashedBlob) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+// Request to verify the blob store.
+type VerifyStoreRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional. Reindex the resources affected by the problems,
+	// and mark the corrupted blobs as missing, so they can be fetched again from the network.
+	Repair        bool `protobuf:"varint,1,opt,name=repair,proto3" json:"repair,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyStoreRequest) Reset() {
+	*x = VerifyStoreRequest{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyStoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyStoreRequest) ProtoMessage() {}
+
+func (x *VerifyStoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyStoreRequest.ProtoReflect.Descriptor instead.
+func (*VerifyStoreRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *VerifyStoreRequest) GetRepair() bool {
+	if x != nil {
+		return x.Repair
+	}
+	return false
+}
+
+// Progress of the store verification.
+type VerifyStoreResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of blobs to check.
+	BlobsTotal int64 `protobuf:"varint,1,opt,name=blobs_total,json=blobsTotal,proto3" json:"blobs_total,omitempty"`
+	// Number of blobs checked so far.
+	BlobsChecked int64 `protobuf:"varint,2,opt,name=blobs_checked,json=blobsChecked,proto3" json:"blobs_checked,omitempty"`
+	// Report of the verification. Only set in the last message of the stream.
+	Report        *StoreReport `protobuf:"bytes,3,opt,name=report,proto3" json:"report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyStoreResponse) Reset() {
+	*x = VerifyStoreResponse{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyStoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyStoreResponse) ProtoMessage() {}
+
+func (x *VerifyStoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyStoreResponse.ProtoReflect.Descriptor instead.
+func (*VerifyStoreResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *VerifyStoreResponse) GetBlobsTotal() int64 {
+	if x != nil {
+		return x.BlobsTotal
+	}
+	return 0
+}
+
+func (x *VerifyStoreResponse) GetBlobsChecked() int64 {
+	if x != nil {
+		return x.BlobsChecked
+	}
+	return 0
+}
+
+func (x *VerifyStoreResponse) GetReport() *StoreReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+// Result of the store verification.
+type StoreReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of blobs checked.
+	BlobsChecked int64 `protobuf:"varint,1,opt,name=blobs_checked,json=blobsChecked,proto3" json:"blobs_checked,omitempty"`
+	// Problems found in the store.
+	Problems []*StoreProblem `protobuf:"bytes,2,rep,name=problems,proto3" json:"problems,omitempty"`
+	// Number of stashed blobs by reason.
+	StashedBlobs map[string]int64 `protobuf:"bytes,3,rep,name=stashed_blobs,json=stashedBlobs,proto3" json:"stashed_blobs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// IRIs of the resources reindexed by the repair.
+	ReindexedResources []string `protobuf:"bytes,4,rep,name=reindexed_resources,json=reindexedResources,proto3" json:"reindexed_resources,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *StoreReport) Reset() {
+	*x = StoreReport{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreReport) ProtoMessage() {}
+
+func (x *StoreReport) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreReport.ProtoReflect.Descriptor instead.
+func (*StoreReport) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *StoreReport) GetBlobsChecked() int64 {
+	if x != nil {
+		return x.BlobsChecked
+	}
+	return 0
+}
+
+func (x *StoreReport) GetProblems() []*StoreProblem {
+	if x != nil {
+		return x.Problems
+	}
+	return nil
+}
+
+func (x *StoreReport) GetStashedBlobs() map[string]int64 {
+	if x != nil {
+		return x.StashedBlobs
+	}
+	return nil
+}
+
+func (x *StoreReport) GetReindexedResources() []string {
+	if x != nil {
+		return x.ReindexedResources
+	}
+	return nil
+}
+
+// Inconsistency found in the blob store or in the index.
+type StoreProblem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Kind of the problem, e.g. CorruptData, InvalidBlob, DanglingLink, MissingChangeDep, OrphanedStructuralBlob.
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// CID of the blob with the problem.
+	Cid string `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	// CID of the linked blob, for link problems.
+	Target string `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	// IRI of the affected resource, if known.
+	Resource string `protobuf:"bytes,4,opt,name=resource,proto3" json:"resource,omitempty"`
+	// Extra details about the problem.
+	Details       string `protobuf:"bytes,5,opt,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreProblem) Reset() {
+	*x = StoreProblem{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreProblem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreProblem) ProtoMessage() {}
+
+func (x *StoreProblem) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreProblem.ProtoReflect.Descriptor instead.
+func (*StoreProblem) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *StoreProblem) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *StoreProblem) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *StoreProblem) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *StoreProblem) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *StoreProblem) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+// Request to collect garbage.
+type CollectGarbageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional. Only report what would be collected, without changing anything.
+	DryRun bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Optional. Only collect the blobs of the space of this account.
+	Account       string `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectGarbageRequest) Reset() {
+	*x = CollectGarbageRequest{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectGarbageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectGarbageRequest) ProtoMessage() {}
+
+func (x *CollectGarbageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectGarbageRequest.ProtoReflect.Descriptor instead.
+func (*CollectGarbageRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CollectGarbageRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *CollectGarbageRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+// Result of the garbage collection.
+// In dry-run mode the pruned fields describe the blobs that would be collected.
+type CollectGarbageResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of resources the reachable blobs were collected from.
+	Roots int64 `protobuf:"varint,1,opt,name=roots,proto3" json:"roots,omitempty"`
+	// Number of blobs in the store before the collection.
+	BlobsTotal int64 `protobuf:"varint,2,opt,name=blobs_total,json=blobsTotal,proto3" json:"blobs_total,omitempty"`
+	// Uncompressed size of the blobs in the store before the collection.
+	BytesTotal int64 `protobuf:"varint,3,opt,name=bytes_total,json=bytesTotal,proto3" json:"bytes_total,omitempty"`
+	// Size of the blobs on disk before the collection.
+	StoredBytesTotal int64 `protobuf:"varint,4,opt,name=stored_bytes_total,json=storedBytesTotal,proto3" json:"stored_bytes_total,omitempty"`
+	// Number of collected blobs.
+	BlobsPruned int64 `protobuf:"varint,5,opt,name=blobs_pruned,json=blobsPruned,proto3" json:"blobs_pruned,omitempty"`
+	// Uncompressed size of the collected blobs.
+	BytesPruned int64 `protobuf:"varint,6,opt,name=bytes_pruned,json=bytesPruned,proto3" json:"bytes_pruned,omitempty"`
+	// Size of the collected blobs on disk.
+	StoredBytesPruned int64 `protobuf:"varint,7,opt,name=stored_bytes_pruned,json=storedBytesPruned,proto3" json:"stored_bytes_pruned,omitempty"`
+	// Number of tombstones removed after the grace period.
+	TombstonesExpired int64 `protobuf:"varint,8,opt,name=tombstones_expired,json=tombstonesExpired,proto3" json:"tombstones_expired,omitempty"`
+	// Number of resources reindexed after their blobs were collected.
+	ReindexedResources int64 `protobuf:"varint,9,opt,name=reindexed_resources,json=reindexedResources,proto3" json:"reindexed_resources,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CollectGarbageResponse) Reset() {
+	*x = CollectGarbageResponse{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectGarbageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectGarbageResponse) ProtoMessage() {}
+
+func (x *CollectGarbageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectGarbageResponse.ProtoReflect.Descriptor instead.
+func (*CollectGarbageResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CollectGarbageResponse) GetRoots() int64 {
+	if x != nil {
+		return x.Roots
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetBlobsTotal() int64 {
+	if x != nil {
+		return x.BlobsTotal
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetBytesTotal() int64 {
+	if x != nil {
+		return x.BytesTotal
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetStoredBytesTotal() int64 {
+	if x != nil {
+		return x.StoredBytesTotal
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetBlobsPruned() int64 {
+	if x != nil {
+		return x.BlobsPruned
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetBytesPruned() int64 {
+	if x != nil {
+		return x.BytesPruned
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetStoredBytesPruned() int64 {
+	if x != nil {
+		return x.StoredBytesPruned
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetTombstonesExpired() int64 {
+	if x != nil {
+		return x.TombstonesExpired
+	}
+	return 0
+}
+
+func (x *CollectGarbageResponse) GetReindexedResources() int64 {
+	if x != nil {
+		return x.ReindexedResources
+	}
+	return 0
+}
+
+// Request to pin a resource.
+type PinResourceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. IRI of the resource to pin.
+	Iri string `protobuf:"bytes,1,opt,name=iri,proto3" json:"iri,omitempty"`
+	// Optional. Pin all the resources below the IRI too.
+	Recursive     bool `protobuf:"varint,2,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinResourceRequest) Reset() {
+	*x = PinResourceRequest{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinResourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinResourceRequest) ProtoMessage() {}
+
+func (x *PinResourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinResourceRequest.ProtoReflect.Descriptor instead.
+func (*PinResourceRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *PinResourceRequest) GetIri() string {
+	if x != nil {
+		return x.Iri
+	}
+	return ""
+}
+
+func (x *PinResourceRequest) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
+}
+
+// Request to unpin a resource.
+type UnpinResourceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. IRI of the pinned resource.
+	Iri           string `protobuf:"bytes,1,opt,name=iri,proto3" json:"iri,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ForceReindexResponse) Reset() {
-	*x = ForceReindexResponse{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[28]
+func (x *UnpinResourceRequest) Reset() {
+	*x = UnpinResourceRequest{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ForceReindexResponse) String() string {
+func (x *UnpinResourceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ForceReindexResponse) ProtoMessage() {}
+func (*UnpinResourceRequest) ProtoMessage() {}
 
-func (x *ForceReindexResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[28]
+func (x *UnpinResourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1623,33 +2824,40 @@ func (x *ForceReindexResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ForceReindexResponse.ProtoReflect.Descriptor instead.
-func (*ForceReindexResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use UnpinResourceRequest.ProtoReflect.Descriptor instead.
+func (*UnpinResourceRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{48}
 }
 
-// Request to delete all keys.
-type DeleteAllKeysRequest struct {
+func (x *UnpinResourceRequest) GetIri() string {
+	if x != nil {
+		return x.Iri
+	}
+	return ""
+}
+
+// Request to list pinned resources.
+type ListPinnedResourcesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteAllKeysRequest) Reset() {
-	*x = DeleteAllKeysRequest{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[29]
+func (x *ListPinnedResourcesRequest) Reset() {
+	*x = ListPinnedResourcesRequest{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAllKeysRequest) String() string {
+func (x *ListPinnedResourcesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAllKeysRequest) ProtoMessage() {}
+func (*ListPinnedResourcesRequest) ProtoMessage() {}
 
-func (x *DeleteAllKeysRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[29]
+func (x *ListPinnedResourcesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1660,33 +2868,34 @@ func (x *DeleteAllKeysRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAllKeysRequest.ProtoReflect.Descriptor instead.
-func (*DeleteAllKeysRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use ListPinnedResourcesRequest.ProtoReflect.Descriptor instead.
+func (*ListPinnedResourcesRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{49}
 }
 
-// Request to list signing keys.
-type ListKeysRequest struct {
+// List of pinned resources.
+type ListPinnedResourcesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pins          []*PinnedResource      `protobuf:"bytes,1,rep,name=pins,proto3" json:"pins,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListKeysRequest) Reset() {
-	*x = ListKeysRequest{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[30]
+func (x *ListPinnedResourcesResponse) Reset() {
+	*x = ListPinnedResourcesResponse{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListKeysRequest) String() string {
+func (x *ListPinnedResourcesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListKeysRequest) ProtoMessage() {}
+func (*ListPinnedResourcesResponse) ProtoMessage() {}
 
-func (x *ListKeysRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[30]
+func (x *ListPinnedResourcesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1697,35 +2906,42 @@ func (x *ListKeysRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListKeysRequest.ProtoReflect.Descriptor instead.
-func (*ListKeysRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use ListPinnedResourcesResponse.ProtoReflect.Descriptor instead.
+func (*ListPinnedResourcesResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{50}
 }
 
-// Response with the list of registered signing keys.
-type ListKeysResponse struct {
+func (x *ListPinnedResourcesResponse) GetPins() []*PinnedResource {
+	if x != nil {
+		return x.Pins
+	}
+	return nil
+}
+
+// Request to get the storage usage of a space.
+type GetSpaceUsageRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// List of registered keys.
-	Keys          []*NamedKey `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	// Required. Account ID of the space.
+	Account       string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListKeysResponse) Reset() {
-	*x = ListKeysResponse{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[31]
+func (x *GetSpaceUsageRequest) Reset() {
+	*x = GetSpaceUsageRequest{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListKeysResponse) String() string {
+func (x *GetSpaceUsageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListKeysResponse) ProtoMessage() {}
+func (*GetSpaceUsageRequest) ProtoMessage() {}
 
-func (x *ListKeysResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[31]
+func (x *GetSpaceUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1736,44 +2952,40 @@ func (x *ListKeysResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListKeysResponse.ProtoReflect.Descriptor instead.
-func (*ListKeysResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use GetSpaceUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetSpaceUsageRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *ListKeysResponse) GetKeys() []*NamedKey {
+func (x *GetSpaceUsageRequest) GetAccount() string {
 	if x != nil {
-		return x.Keys
+		return x.Account
 	}
-	return nil
+	return ""
 }
 
-// Request to change the key name.
-type UpdateKeyRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Current name of the key.
-	CurrentName string `protobuf:"bytes,1,opt,name=current_name,json=currentName,proto3" json:"current_name,omitempty"`
-	// New name for the key.
-	NewName       string `protobuf:"bytes,2,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+// Request to list the storage usage of spaces.
+type ListSpaceUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateKeyRequest) Reset() {
-	*x = UpdateKeyRequest{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[32]
+func (x *ListSpaceUsageRequest) Reset() {
+	*x = ListSpaceUsageRequest{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateKeyRequest) String() string {
+func (x *ListSpaceUsageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateKeyRequest) ProtoMessage() {}
+func (*ListSpaceUsageRequest) ProtoMessage() {}
 
-func (x *UpdateKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[32]
+func (x *ListSpaceUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1784,49 +2996,34 @@ func (x *UpdateKeyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateKeyRequest.ProtoReflect.Descriptor instead.
-func (*UpdateKeyRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{32}
-}
-
-func (x *UpdateKeyRequest) GetCurrentName() string {
-	if x != nil {
-		return x.CurrentName
-	}
-	return ""
-}
-
-func (x *UpdateKeyRequest) GetNewName() string {
-	if x != nil {
-		return x.NewName
-	}
-	return ""
+// Deprecated: Use ListSpaceUsageRequest.ProtoReflect.Descriptor instead.
+func (*ListSpaceUsageRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{52}
 }
 
-// Request to delete an existing key.
-type DeleteKeyRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Name of the key to delete.
-	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+// Storage usage of spaces.
+type ListSpaceUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Spaces        []*SpaceUsage          `protobuf:"bytes,1,rep,name=spaces,proto3" json:"spaces,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteKeyRequest) Reset() {
-	*x = DeleteKeyRequest{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[33]
+func (x *ListSpaceUsageResponse) Reset() {
+	*x = ListSpaceUsageResponse{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteKeyRequest) String() string {
+func (x *ListSpaceUsageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteKeyRequest) ProtoMessage() {}
+func (*ListSpaceUsageResponse) ProtoMessage() {}
 
-func (x *DeleteKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[33]
+func (x *ListSpaceUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1837,43 +3034,53 @@ func (x *DeleteKeyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteKeyRequest.ProtoReflect.Descriptor instead.
-func (*DeleteKeyRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use ListSpaceUsageResponse.ProtoReflect.Descriptor instead.
+func (*ListSpaceUsageResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *DeleteKeyRequest) GetName() string {
+func (x *ListSpaceUsageResponse) GetSpaces() []*SpaceUsage {
 	if x != nil {
-		return x.Name
+		return x.Spaces
 	}
-	return ""
+	return nil
 }
 
-// Request to store blobs.
-type StoreBlobsRequest struct {
+// Storage used by the blobs of a space.
+// Sizes are uncompressed. Media blobs shared by multiple spaces are counted in each of them.
+type SpaceUsage struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Required. List of blobs to be stored.
-	// The request is atomic: either all blobs are stored or none of them.
-	Blobs         []*Blob `protobuf:"bytes,1,rep,name=blobs,proto3" json:"blobs,omitempty"`
+	// Account ID of the space.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Number of structural blobs, e.g. changes, refs, and comments.
+	StructuralBlobs int64 `protobuf:"varint,2,opt,name=structural_blobs,json=structuralBlobs,proto3" json:"structural_blobs,omitempty"`
+	// Size of the structural blobs.
+	StructuralBytes int64 `protobuf:"varint,3,opt,name=structural_bytes,json=structuralBytes,proto3" json:"structural_bytes,omitempty"`
+	// Number of media blobs, e.g. images and files.
+	MediaBlobs int64 `protobuf:"varint,4,opt,name=media_blobs,json=mediaBlobs,proto3" json:"media_blobs,omitempty"`
+	// Size of the media blobs.
+	MediaBytes int64 `protobuf:"varint,5,opt,name=media_bytes,json=mediaBytes,proto3" json:"media_bytes,omitempty"`
+	// Storage quota of the space in bytes. Zero means no limit.
+	Quota         int64 `protobuf:"varint,6,opt,name=quota,proto3" json:"quota,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StoreBlobsRequest) Reset() {
-	*x = StoreBlobsRequest{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[34]
+func (x *SpaceUsage) Reset() {
+	*x = SpaceUsage{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StoreBlobsRequest) String() string {
+func (x *SpaceUsage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StoreBlobsRequest) ProtoMessage() {}
+func (*SpaceUsage) ProtoMessage() {}
 
-func (x *StoreBlobsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[34]
+func (x *SpaceUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1884,43 +3091,79 @@ func (x *StoreBlobsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StoreBlobsRequest.ProtoReflect.Descriptor instead.
-func (*StoreBlobsRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use SpaceUsage.ProtoReflect.Descriptor instead.
+func (*SpaceUsage) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *StoreBlobsRequest) GetBlobs() []*Blob {
+func (x *SpaceUsage) GetAccount() string {
 	if x != nil {
-		return x.Blobs
+		return x.Account
 	}
-	return nil
+	return ""
 }
 
-// Response after storing blobs.
-type StoreBlobsResponse struct {
+func (x *SpaceUsage) GetStructuralBlobs() int64 {
+	if x != nil {
+		return x.StructuralBlobs
+	}
+	return 0
+}
+
+func (x *SpaceUsage) GetStructuralBytes() int64 {
+	if x != nil {
+		return x.StructuralBytes
+	}
+	return 0
+}
+
+func (x *SpaceUsage) GetMediaBlobs() int64 {
+	if x != nil {
+		return x.MediaBlobs
+	}
+	return 0
+}
+
+func (x *SpaceUsage) GetMediaBytes() int64 {
+	if x != nil {
+		return x.MediaBytes
+	}
+	return 0
+}
+
+func (x *SpaceUsage) GetQuota() int64 {
+	if x != nil {
+		return x.Quota
+	}
+	return 0
+}
+
+// Resource protected from garbage collection.
+type PinnedResource struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// List of CIDs for the stored blobs.
-	// The order is the same as in the request.
-	Cids          []string `protobuf:"bytes,1,rep,name=cids,proto3" json:"cids,omitempty"`
+	// IRI of the resource.
+	Iri string `protobuf:"bytes,1,opt,name=iri,proto3" json:"iri,omitempty"`
+	// Whether the resources below the IRI are pinned too.
+	Recursive     bool `protobuf:"varint,2,opt,name=recursive,proto3" json:"recursive,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StoreBlobsResponse) Reset() {
-	*x = StoreBlobsResponse{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[35]
+func (x *PinnedResource) Reset() {
+	*x = PinnedResource{}
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StoreBlobsResponse) String() string {
+func (x *PinnedResource) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StoreBlobsResponse) ProtoMessage() {}
+func (*PinnedResource) ProtoMessage() {}
 
-func (x *StoreBlobsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[35]
+func (x *PinnedResource) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1931,16 +3174,23 @@ func (x *StoreBlobsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StoreBlobsResponse.ProtoReflect.Descriptor instead.
-func (*StoreBlobsResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use PinnedResource.ProtoReflect.Descriptor instead.
+func (*PinnedResource) Descriptor() ([]byte, []int) {
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *StoreBlobsResponse) GetCids() []string {
+func (x *PinnedResource) GetIri() string {
 	if x != nil {
-		return x.Cids
+		return x.Iri
 	}
-	return nil
+	return ""
+}
+
+func (x *PinnedResource) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
 }
 
 // Request to sign data.
@@ -1956,7 +3206,7 @@ type SignDataRequest struct {
 
 func (x *SignDataRequest) Reset() {
 	*x = SignDataRequest{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[36]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1968,7 +3218,7 @@ func (x *SignDataRequest) String() string {
 func (*SignDataRequest) ProtoMessage() {}
 
 func (x *SignDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[36]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1981,7 +3231,7 @@ func (x *SignDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignDataRequest.ProtoReflect.Descriptor instead.
 func (*SignDataRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{36}
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *SignDataRequest) GetSigningKeyName() string {
@@ -2009,7 +3259,7 @@ type SignDataResponse struct {
 
 func (x *SignDataResponse) Reset() {
 	*x = SignDataResponse{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[37]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2021,7 +3271,7 @@ func (x *SignDataResponse) String() string {
 func (*SignDataResponse) ProtoMessage() {}
 
 func (x *SignDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[37]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2034,7 +3284,7 @@ func (x *SignDataResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignDataResponse.ProtoReflect.Descriptor instead.
 func (*SignDataResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{37}
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *SignDataResponse) GetSignature() []byte {
@@ -2055,7 +3305,7 @@ type AddrInfo struct {
 
 func (x *AddrInfo) Reset() {
 	*x = AddrInfo{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[38]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2067,7 +3317,7 @@ func (x *AddrInfo) String() string {
 func (*AddrInfo) ProtoMessage() {}
 
 func (x *AddrInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[38]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2080,7 +3330,7 @@ func (x *AddrInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddrInfo.ProtoReflect.Descriptor instead.
 func (*AddrInfo) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{38}
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *AddrInfo) GetPeerId() string {
@@ -2112,7 +3362,7 @@ type Blob struct {
 
 func (x *Blob) Reset() {
 	*x = Blob{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[39]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2124,7 +3374,7 @@ func (x *Blob) String() string {
 func (*Blob) ProtoMessage() {}
 
 func (x *Blob) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[39]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2137,7 +3387,7 @@ func (x *Blob) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Blob.ProtoReflect.Descriptor instead.
 func (*Blob) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{39}
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *Blob) GetCid() string {
@@ -2173,7 +3423,7 @@ type Info struct {
 
 func (x *Info) Reset() {
 	*x = Info{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[40]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2185,7 +3435,7 @@ func (x *Info) String() string {
 func (*Info) ProtoMessage() {}
 
 func (x *Info) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[40]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2198,7 +3448,7 @@ func (x *Info) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info.ProtoReflect.Descriptor instead.
 func (*Info) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{40}
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *Info) GetState() State {
@@ -2253,7 +3503,7 @@ type VaultSyncStatus struct {
 
 func (x *VaultSyncStatus) Reset() {
 	*x = VaultSyncStatus{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[41]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2265,7 +3515,7 @@ func (x *VaultSyncStatus) String() string {
 func (*VaultSyncStatus) ProtoMessage() {}
 
 func (x *VaultSyncStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[41]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2278,7 +3528,7 @@ func (x *VaultSyncStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VaultSyncStatus.ProtoReflect.Descriptor instead.
 func (*VaultSyncStatus) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{41}
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{61}
 }
 
 func (x *VaultSyncStatus) GetLocalVersion() int64 {
@@ -2330,7 +3580,7 @@ type GetVaultStatusResponse struct {
 
 func (x *GetVaultStatusResponse) Reset() {
 	*x = GetVaultStatusResponse{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[42]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2342,7 +3592,7 @@ func (x *GetVaultStatusResponse) String() string {
 func (*GetVaultStatusResponse) ProtoMessage() {}
 
 func (x *GetVaultStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[42]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2355,7 +3605,7 @@ func (x *GetVaultStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVaultStatusResponse.ProtoReflect.Descriptor instead.
 func (*GetVaultStatusResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{42}
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{62}
 }
 
 func (x *GetVaultStatusResponse) GetBackendMode() VaultBackendMode {
@@ -2410,7 +3660,7 @@ type Task struct {
 
 func (x *Task) Reset() {
 	*x = Task{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[43]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2422,7 +3672,7 @@ func (x *Task) String() string {
 func (*Task) ProtoMessage() {}
 
 func (x *Task) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[43]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2435,7 +3685,7 @@ func (x *Task) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Task.ProtoReflect.Descriptor instead.
 func (*Task) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{43}
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{63}
 }
 
 func (x *Task) GetTaskName() TaskName {
@@ -2482,7 +3732,7 @@ type NamedKey struct {
 
 func (x *NamedKey) Reset() {
 	*x = NamedKey{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[44]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2494,7 +3744,7 @@ func (x *NamedKey) String() string {
 func (*NamedKey) ProtoMessage() {}
 
 func (x *NamedKey) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[44]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2507,7 +3757,7 @@ func (x *NamedKey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NamedKey.ProtoReflect.Descriptor instead.
 func (*NamedKey) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{44}
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{64}
 }
 
 func (x *NamedKey) GetPublicKey() string {
@@ -2542,7 +3792,7 @@ type GetDomainRequest struct {
 
 func (x *GetDomainRequest) Reset() {
 	*x = GetDomainRequest{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[45]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2554,7 +3804,7 @@ func (x *GetDomainRequest) String() string {
 func (*GetDomainRequest) ProtoMessage() {}
 
 func (x *GetDomainRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[45]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2567,7 +3817,7 @@ func (x *GetDomainRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDomainRequest.ProtoReflect.Descriptor instead.
 func (*GetDomainRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{45}
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{65}
 }
 
 func (x *GetDomainRequest) GetDomain() string {
@@ -2586,7 +3836,7 @@ type ListDomainsRequest struct {
 
 func (x *ListDomainsRequest) Reset() {
 	*x = ListDomainsRequest{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[46]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2598,7 +3848,7 @@ func (x *ListDomainsRequest) String() string {
 func (*ListDomainsRequest) ProtoMessage() {}
 
 func (x *ListDomainsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[46]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2611,7 +3861,7 @@ func (x *ListDomainsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDomainsRequest.ProtoReflect.Descriptor instead.
 func (*ListDomainsRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{46}
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{66}
 }
 
 // Response with the list of tracked domains.
@@ -2625,7 +3875,7 @@ type ListDomainsResponse struct {
 
 func (x *ListDomainsResponse) Reset() {
 	*x = ListDomainsResponse{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[47]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2637,7 +3887,7 @@ func (x *ListDomainsResponse) String() string {
 func (*ListDomainsResponse) ProtoMessage() {}
 
 func (x *ListDomainsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[47]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2650,7 +3900,7 @@ func (x *ListDomainsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDomainsResponse.ProtoReflect.Descriptor instead.
 func (*ListDomainsResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{47}
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{67}
 }
 
 func (x *ListDomainsResponse) GetDomains() []*DomainInfo {
@@ -2671,7 +3921,7 @@ type AddDomainRequest struct {
 
 func (x *AddDomainRequest) Reset() {
 	*x = AddDomainRequest{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[48]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2683,7 +3933,7 @@ func (x *AddDomainRequest) String() string {
 func (*AddDomainRequest) ProtoMessage() {}
 
 func (x *AddDomainRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[48]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2696,7 +3946,7 @@ func (x *AddDomainRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddDomainRequest.ProtoReflect.Descriptor instead.
 func (*AddDomainRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{48}
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{68}
 }
 
 func (x *AddDomainRequest) GetDomain() string {
@@ -2717,7 +3967,7 @@ type RemoveDomainRequest struct {
 
 func (x *RemoveDomainRequest) Reset() {
 	*x = RemoveDomainRequest{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[49]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2729,7 +3979,7 @@ func (x *RemoveDomainRequest) String() string {
 func (*RemoveDomainRequest) ProtoMessage() {}
 
 func (x *RemoveDomainRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[49]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2742,7 +3992,7 @@ func (x *RemoveDomainRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveDomainRequest.ProtoReflect.Descriptor instead.
 func (*RemoveDomainRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{49}
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{69}
 }
 
 func (x *RemoveDomainRequest) GetDomain() string {
@@ -2763,7 +4013,7 @@ type CheckDomainRequest struct {
 
 func (x *CheckDomainRequest) Reset() {
 	*x = CheckDomainRequest{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[50]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2775,7 +4025,7 @@ func (x *CheckDomainRequest) String() string {
 func (*CheckDomainRequest) ProtoMessage() {}
 
 func (x *CheckDomainRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[50]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2788,7 +4038,7 @@ func (x *CheckDomainRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckDomainRequest.ProtoReflect.Descriptor instead.
 func (*CheckDomainRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{50}
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{70}
 }
 
 func (x *CheckDomainRequest) GetDomain() string {
@@ -2823,7 +4073,7 @@ type DomainInfo struct {
 
 func (x *DomainInfo) Reset() {
 	*x = DomainInfo{}
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[51]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2835,7 +4085,7 @@ func (x *DomainInfo) String() string {
 func (*DomainInfo) ProtoMessage() {}
 
 func (x *DomainInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[51]
+	mi := &file_daemon_v1alpha_daemon_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2848,7 +4098,7 @@ func (x *DomainInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DomainInfo.ProtoReflect.Descriptor instead.
 func (*DomainInfo) Descriptor() ([]byte, []int) {
-	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{51}
+	return file_daemon_v1alpha_daemon_proto_rawDescGZIP(), []int{71}
 }
 
 func (x *DomainInfo) GetDomain() string {
@@ -2979,9 +4229,13 @@ const file_daemon_v1alpha_daemon_proto_rawDesc = "" +
 	"\x03url\x18\x01 \x01(\tR\x03url\"5\n" +
 	"!SetVaultNotificationServerRequest\x12\x10\n" +
 	"\x03url\x18\x01 \x01(\tR\x03url\"$\n" +
-	"\"SetVaultNotificationServerResponse\"\x15\n" +
-	"\x13ForceReindexRequest\"\x16\n" +
-	"\x14ForceReindexResponse\"\x16\n" +
+	"\"SetVaultNotificationServerResponse\"C\n" +
+	"\x13ForceReindexRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\"l\n" +
+	"\x14ForceReindexResponse\x12/\n" +
+	"\x13resources_reindexed\x18\x01 \x01(\x03R\x12resourcesReindexed\x12#\n" +
+	"\rblobs_indexed\x18\x02 \x01(\x03R\fblobsIndexed\"\x16\n" +
 	"\x14DeleteAllKeysRequest\"\x11\n" +
 	"\x0fListKeysRequest\"I\n" +
 	"\x10ListKeysResponse\x125\n" +
@@ -2994,7 +4248,89 @@ const file_daemon_v1alpha_daemon_proto_rawDesc = "" +
 	"\x11StoreBlobsRequest\x123\n" +
 	"\x05blobs\x18\x01 \x03(\v2\x1d.com.seed.daemon.v1alpha.BlobR\x05blobs\"(\n" +
 	"\x12StoreBlobsResponse\x12\x12\n" +
-	"\x04cids\x18\x01 \x03(\tR\x04cids\"O\n" +
+	"\x04cids\x18\x01 \x03(\tR\x04cids\"_\n" +
+	"\x12ExportSpaceRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1b\n" +
+	"\tfile_path\x18\x03 \x01(\tR\bfilePath\"J\n" +
+	"\x13ExportSpaceResponse\x12\x1d\n" +
+	"\n" +
+	"blob_count\x18\x01 \x01(\x03R\tblobCount\x12\x14\n" +
+	"\x05roots\x18\x02 \x03(\tR\x05roots\"3\n" +
+	"\x14ImportArchiveRequest\x12\x1b\n" +
+	"\tfile_path\x18\x01 \x01(\tR\bfilePath\"\x81\x01\n" +
+	"\x15ImportArchiveResponse\x12\x1d\n" +
+	"\n" +
+	"blob_count\x18\x01 \x01(\x03R\tblobCount\x12I\n" +
+	"\rstashed_blobs\x18\x02 \x03(\v2$.com.seed.daemon.v1alpha.StashedBlobR\fstashedBlobs\"\x9d\x01\n" +
+	"\vStashedBlob\x12\x10\n" +
+	"\x03cid\x18\x01 \x01(\tR\x03cid\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12#\n" +
+	"\rmissing_blobs\x18\x03 \x03(\tR\fmissingBlobs\x12%\n" +
+	"\x0edenied_signers\x18\x04 \x03(\tR\rdeniedSigners\x12\x18\n" +
+	"\adetails\x18\x05 \x01(\tR\adetails\",\n" +
+	"\x12VerifyStoreRequest\x12\x16\n" +
+	"\x06repair\x18\x01 \x01(\bR\x06repair\"\x99\x01\n" +
+	"\x13VerifyStoreResponse\x12\x1f\n" +
+	"\vblobs_total\x18\x01 \x01(\x03R\n" +
+	"blobsTotal\x12#\n" +
+	"\rblobs_checked\x18\x02 \x01(\x03R\fblobsChecked\x12<\n" +
+	"\x06report\x18\x03 \x01(\v2$.com.seed.daemon.v1alpha.StoreReportR\x06report\"\xc4\x02\n" +
+	"\vStoreReport\x12#\n" +
+	"\rblobs_checked\x18\x01 \x01(\x03R\fblobsChecked\x12A\n" +
+	"\bproblems\x18\x02 \x03(\v2%.com.seed.daemon.v1alpha.StoreProblemR\bproblems\x12[\n" +
+	"\rstashed_blobs\x18\x03 \x03(\v26.com.seed.daemon.v1alpha.StoreReport.StashedBlobsEntryR\fstashedBlobs\x12/\n" +
+	"\x13reindexed_resources\x18\x04 \x03(\tR\x12reindexedResources\x1a?\n" +
+	"\x11StashedBlobsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x82\x01\n" +
+	"\fStoreProblem\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x10\n" +
+	"\x03cid\x18\x02 \x01(\tR\x03cid\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\tR\x06target\x12\x1a\n" +
+	"\bresource\x18\x04 \x01(\tR\bresource\x12\x18\n" +
+	"\adetails\x18\x05 \x01(\tR\adetails\"J\n" +
+	"\x15CollectGarbageRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x18\n" +
+	"\aaccount\x18\x02 \x01(\tR\aaccount\"\xf4\x02\n" +
+	"\x16CollectGarbageResponse\x12\x14\n" +
+	"\x05roots\x18\x01 \x01(\x03R\x05roots\x12\x1f\n" +
+	"\vblobs_total\x18\x02 \x01(\x03R\n" +
+	"blobsTotal\x12\x1f\n" +
+	"\vbytes_total\x18\x03 \x01(\x03R\n" +
+	"bytesTotal\x12,\n" +
+	"\x12stored_bytes_total\x18\x04 \x01(\x03R\x10storedBytesTotal\x12!\n" +
+	"\fblobs_pruned\x18\x05 \x01(\x03R\vblobsPruned\x12!\n" +
+	"\fbytes_pruned\x18\x06 \x01(\x03R\vbytesPruned\x12.\n" +
+	"\x13stored_bytes_pruned\x18\a \x01(\x03R\x11storedBytesPruned\x12-\n" +
+	"\x12tombstones_expired\x18\b \x01(\x03R\x11tombstonesExpired\x12/\n" +
+	"\x13reindexed_resources\x18\t \x01(\x03R\x12reindexedResources\"D\n" +
+	"\x12PinResourceRequest\x12\x10\n" +
+	"\x03iri\x18\x01 \x01(\tR\x03iri\x12\x1c\n" +
+	"\trecursive\x18\x02 \x01(\bR\trecursive\"(\n" +
+	"\x14UnpinResourceRequest\x12\x10\n" +
+	"\x03iri\x18\x01 \x01(\tR\x03iri\"\x1c\n" +
+	"\x1aListPinnedResourcesRequest\"Z\n" +
+	"\x1bListPinnedResourcesResponse\x12;\n" +
+	"\x04pins\x18\x01 \x03(\v2'.com.seed.daemon.v1alpha.PinnedResourceR\x04pins\"0\n" +
+	"\x14GetSpaceUsageRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\"\x17\n" +
+	"\x15ListSpaceUsageRequest\"U\n" +
+	"\x16ListSpaceUsageResponse\x12;\n" +
+	"\x06spaces\x18\x01 \x03(\v2#.com.seed.daemon.v1alpha.SpaceUsageR\x06spaces\"\xd4\x01\n" +
+	"\n" +
+	"SpaceUsage\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12)\n" +
+	"\x10structural_blobs\x18\x02 \x01(\x03R\x0fstructuralBlobs\x12)\n" +
+	"\x10structural_bytes\x18\x03 \x01(\x03R\x0fstructuralBytes\x12\x1f\n" +
+	"\vmedia_blobs\x18\x04 \x01(\x03R\n" +
+	"mediaBlobs\x12\x1f\n" +
+	"\vmedia_bytes\x18\x05 \x01(\x03R\n" +
+	"mediaBytes\x12\x14\n" +
+	"\x05quota\x18\x06 \x01(\x03R\x05quota\"@\n" +
+	"\x0ePinnedResource\x12\x10\n" +
+	"\x03iri\x18\x01 \x01(\tR\x03iri\x12\x1c\n" +
+	"\trecursive\x18\x02 \x01(\bR\trecursive\"O\n" +
 	"\x0fSignDataRequest\x12(\n" +
 	"\x10signing_key_name\x18\x01 \x01(\tR\x0esigningKeyName\x12\x12\n" +
 	"\x04data\x18\x02 \x01(\fR\x04data\"0\n" +
@@ -3073,13 +4409,15 @@ const file_daemon_v1alpha_daemon_proto_rawDesc = "" +
 	"\x15VaultConnectionStatus\x12'\n" +
 	"#VAULT_CONNECTION_STATUS_UNSPECIFIED\x10\x00\x12(\n" +
 	"$VAULT_CONNECTION_STATUS_DISCONNECTED\x10\x01\x12%\n" +
-	"!VAULT_CONNECTION_STATUS_CONNECTED\x10\x02*W\n" +
+	"!VAULT_CONNECTION_STATUS_CONNECTED\x10\x02*\x84\x01\n" +
 	"\bTaskName\x12\x19\n" +
 	"\x15TASK_NAME_UNSPECIFIED\x10\x00\x12\x0e\n" +
 	"\n" +
 	"REINDEXING\x10\x01\x12\r\n" +
 	"\tEMBEDDING\x10\x02\x12\x11\n" +
-	"\rLOADING_MODEL\x10\x032\xa2\x18\n" +
+	"\rLOADING_MODEL\x10\x03\x12\x13\n" +
+	"\x0fVERIFYING_STORE\x10\x04\x12\x16\n" +
+	"\x12COLLECTING_GARBAGE\x10\x052\xe2\x1f\n" +
 	"\x06Daemon\x12h\n" +
 	"\vGenMnemonic\x12+.com.seed.daemon.v1alpha.GenMnemonicRequest\x1a,.com.seed.daemon.v1alpha.GenMnemonicResponse\x12]\n" +
 	"\vRegisterKey\x12+.com.seed.daemon.v1alpha.RegisterKeyRequest\x1a!.com.seed.daemon.v1alpha.NamedKey\x12Y\n" +
@@ -3104,7 +4442,16 @@ const file_daemon_v1alpha_daemon_proto_rawDesc = "" +
 	"\tDeleteKey\x12).com.seed.daemon.v1alpha.DeleteKeyRequest\x1a\x16.google.protobuf.Empty\x12V\n" +
 	"\rDeleteAllKeys\x12-.com.seed.daemon.v1alpha.DeleteAllKeysRequest\x1a\x16.google.protobuf.Empty\x12e\n" +
 	"\n" +
-	"StoreBlobs\x12*.com.seed.daemon.v1alpha.StoreBlobsRequest\x1a+.com.seed.daemon.v1alpha.StoreBlobsResponse\x12_\n" +
+	"StoreBlobs\x12*.com.seed.daemon.v1alpha.StoreBlobsRequest\x1a+.com.seed.daemon.v1alpha.StoreBlobsResponse\x12h\n" +
+	"\vExportSpace\x12+.com.seed.daemon.v1alpha.ExportSpaceRequest\x1a,.com.seed.daemon.v1alpha.ExportSpaceResponse\x12n\n" +
+	"\rImportArchive\x12-.com.seed.daemon.v1alpha.ImportArchiveRequest\x1a..com.seed.daemon.v1alpha.ImportArchiveResponse\x12j\n" +
+	"\vVerifyStore\x12+.com.seed.daemon.v1alpha.VerifyStoreRequest\x1a,.com.seed.daemon.v1alpha.VerifyStoreResponse0\x01\x12q\n" +
+	"\x0eCollectGarbage\x12..com.seed.daemon.v1alpha.CollectGarbageRequest\x1a/.com.seed.daemon.v1alpha.CollectGarbageResponse\x12R\n" +
+	"\vPinResource\x12+.com.seed.daemon.v1alpha.PinResourceRequest\x1a\x16.google.protobuf.Empty\x12V\n" +
+	"\rUnpinResource\x12-.com.seed.daemon.v1alpha.UnpinResourceRequest\x1a\x16.google.protobuf.Empty\x12\x80\x01\n" +
+	"\x13ListPinnedResources\x123.com.seed.daemon.v1alpha.ListPinnedResourcesRequest\x1a4.com.seed.daemon.v1alpha.ListPinnedResourcesResponse\x12c\n" +
+	"\rGetSpaceUsage\x12-.com.seed.daemon.v1alpha.GetSpaceUsageRequest\x1a#.com.seed.daemon.v1alpha.SpaceUsage\x12q\n" +
+	"\x0eListSpaceUsage\x12..com.seed.daemon.v1alpha.ListSpaceUsageRequest\x1a/.com.seed.daemon.v1alpha.ListSpaceUsageResponse\x12_\n" +
 	"\bSignData\x12(.com.seed.daemon.v1alpha.SignDataRequest\x1a).com.seed.daemon.v1alpha.SignDataResponse\x12[\n" +
 	"\tGetDomain\x12).com.seed.daemon.v1alpha.GetDomainRequest\x1a#.com.seed.daemon.v1alpha.DomainInfo\x12h\n" +
 	"\vListDomains\x12+.com.seed.daemon.v1alpha.ListDomainsRequest\x1a,.com.seed.daemon.v1alpha.ListDomainsResponse\x12[\n" +
@@ -3125,7 +4472,7 @@ func file_daemon_v1alpha_daemon_proto_rawDescGZIP() []byte {
 }
 
 var file_daemon_v1alpha_daemon_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_daemon_v1alpha_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
+var file_daemon_v1alpha_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 73)
 var file_daemon_v1alpha_daemon_proto_goTypes = []any{
 	(State)(0),                                 // 0: com.seed.daemon.v1alpha.State
 	(VaultBackendMode)(0),                      // 1: com.seed.daemon.v1alpha.VaultBackendMode
@@ -3167,106 +4514,151 @@ var file_daemon_v1alpha_daemon_proto_goTypes = []any{
 	(*DeleteKeyRequest)(nil),                   // 37: com.seed.daemon.v1alpha.DeleteKeyRequest
 	(*StoreBlobsRequest)(nil),                  // 38: com.seed.daemon.v1alpha.StoreBlobsRequest
 	(*StoreBlobsResponse)(nil),                 // 39: com.seed.daemon.v1alpha.StoreBlobsResponse
-	(*SignDataRequest)(nil),                    // 40: com.seed.daemon.v1alpha.SignDataRequest
-	(*SignDataResponse)(nil),                   // 41: com.seed.daemon.v1alpha.SignDataResponse
-	(*AddrInfo)(nil),                           // 42: com.seed.daemon.v1alpha.AddrInfo
-	(*Blob)(nil),                               // 43: com.seed.daemon.v1alpha.Blob
-	(*Info)(nil),                               // 44: com.seed.daemon.v1alpha.Info
-	(*VaultSyncStatus)(nil),                    // 45: com.seed.daemon.v1alpha.VaultSyncStatus
-	(*GetVaultStatusResponse)(nil),             // 46: com.seed.daemon.v1alpha.GetVaultStatusResponse
-	(*Task)(nil),                               // 47: com.seed.daemon.v1alpha.Task
-	(*NamedKey)(nil),                           // 48: com.seed.daemon.v1alpha.NamedKey
-	(*GetDomainRequest)(nil),                   // 49: com.seed.daemon.v1alpha.GetDomainRequest
-	(*ListDomainsRequest)(nil),                 // 50: com.seed.daemon.v1alpha.ListDomainsRequest
-	(*ListDomainsResponse)(nil),                // 51: com.seed.daemon.v1alpha.ListDomainsResponse
-	(*AddDomainRequest)(nil),                   // 52: com.seed.daemon.v1alpha.AddDomainRequest
-	(*RemoveDomainRequest)(nil),                // 53: com.seed.daemon.v1alpha.RemoveDomainRequest
-	(*CheckDomainRequest)(nil),                 // 54: com.seed.daemon.v1alpha.CheckDomainRequest
-	(*DomainInfo)(nil),                         // 55: com.seed.daemon.v1alpha.DomainInfo
-	(*timestamppb.Timestamp)(nil),              // 56: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                      // 57: google.protobuf.Empty
+	(*ExportSpaceRequest)(nil),                 // 40: com.seed.daemon.v1alpha.ExportSpaceRequest
+	(*ExportSpaceResponse)(nil),                // 41: com.seed.daemon.v1alpha.ExportSpaceResponse
+	(*ImportArchiveRequest)(nil),               // 42: com.seed.daemon.v1alpha.ImportArchiveRequest
+	(*ImportArchiveResponse)(nil),              // 43: com.seed.daemon.v1alpha.ImportArchiveResponse
+	(*StashedBlob)(nil),                        // 44: com.seed.daemon.v1alpha.StashedBlob
+	(*VerifyStoreRequest)(nil),                 // 45: com.seed.daemon.v1alpha.VerifyStoreRequest
+	(*VerifyStoreResponse)(nil),                // 46: com.seed.daemon.v1alpha.VerifyStoreResponse
+	(*StoreReport)(nil),                        // 47: com.seed.daemon.v1alpha.StoreReport
+	(*StoreProblem)(nil),                       // 48: com.seed.daemon.v1alpha.StoreProblem
+	(*CollectGarbageRequest)(nil),              // 49: com.seed.daemon.v1alpha.CollectGarbageRequest
+	(*CollectGarbageResponse)(nil),             // 50: com.seed.daemon.v1alpha.CollectGarbageResponse
+	(*PinResourceRequest)(nil),                 // 51: com.seed.daemon.v1alpha.PinResourceRequest
+	(*UnpinResourceRequest)(nil),               // 52: com.seed.daemon.v1alpha.UnpinResourceRequest
+	(*ListPinnedResourcesRequest)(nil),         // 53: com.seed.daemon.v1alpha.ListPinnedResourcesRequest
+	(*ListPinnedResourcesResponse)(nil),        // 54: com.seed.daemon.v1alpha.ListPinnedResourcesResponse
+	(*GetSpaceUsageRequest)(nil),               // 55: com.seed.daemon.v1alpha.GetSpaceUsageRequest
+	(*ListSpaceUsageRequest)(nil),              // 56: com.seed.daemon.v1alpha.ListSpaceUsageRequest
+	(*ListSpaceUsageResponse)(nil),             // 57: com.seed.daemon.v1alpha.ListSpaceUsageResponse
+	(*SpaceUsage)(nil),                         // 58: com.seed.daemon.v1alpha.SpaceUsage
+	(*PinnedResource)(nil),                     // 59: com.seed.daemon.v1alpha.PinnedResource
+	(*SignDataRequest)(nil),                    // 60: com.seed.daemon.v1alpha.SignDataRequest
+	(*SignDataResponse)(nil),                   // 61: com.seed.daemon.v1alpha.SignDataResponse
+	(*AddrInfo)(nil),                           // 62: com.seed.daemon.v1alpha.AddrInfo
+	(*Blob)(nil),                               // 63: com.seed.daemon.v1alpha.Blob
+	(*Info)(nil),                               // 64: com.seed.daemon.v1alpha.Info
+	(*VaultSyncStatus)(nil),                    // 65: com.seed.daemon.v1alpha.VaultSyncStatus
+	(*GetVaultStatusResponse)(nil),             // 66: com.seed.daemon.v1alpha.GetVaultStatusResponse
+	(*Task)(nil),                               // 67: com.seed.daemon.v1alpha.Task
+	(*NamedKey)(nil),                           // 68: com.seed.daemon.v1alpha.NamedKey
+	(*GetDomainRequest)(nil),                   // 69: com.seed.daemon.v1alpha.GetDomainRequest
+	(*ListDomainsRequest)(nil),                 // 70: com.seed.daemon.v1alpha.ListDomainsRequest
+	(*ListDomainsResponse)(nil),                // 71: com.seed.daemon.v1alpha.ListDomainsResponse
+	(*AddDomainRequest)(nil),                   // 72: com.seed.daemon.v1alpha.AddDomainRequest
+	(*RemoveDomainRequest)(nil),                // 73: com.seed.daemon.v1alpha.RemoveDomainRequest
+	(*CheckDomainRequest)(nil),                 // 74: com.seed.daemon.v1alpha.CheckDomainRequest
+	(*DomainInfo)(nil),                         // 75: com.seed.daemon.v1alpha.DomainInfo
+	nil,                                        // 76: com.seed.daemon.v1alpha.StoreReport.StashedBlobsEntry
+	(*timestamppb.Timestamp)(nil),              // 77: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                      // 78: google.protobuf.Empty
 }
 var file_daemon_v1alpha_daemon_proto_depIdxs = []int32{
-	56, // 0: com.seed.daemon.v1alpha.AuthenticateResponse.expire_time:type_name -> google.protobuf.Timestamp
-	56, // 1: com.seed.daemon.v1alpha.StartVaultConnectionResponse.expire_time:type_name -> google.protobuf.Timestamp
-	56, // 2: com.seed.daemon.v1alpha.ChangeVaultEmailStartResponse.expire_time:type_name -> google.protobuf.Timestamp
-	56, // 3: com.seed.daemon.v1alpha.ChangeVaultEmailStartResponse.resend_allowed_time:type_name -> google.protobuf.Timestamp
-	48, // 4: com.seed.daemon.v1alpha.ListKeysResponse.keys:type_name -> com.seed.daemon.v1alpha.NamedKey
-	43, // 5: com.seed.daemon.v1alpha.StoreBlobsRequest.blobs:type_name -> com.seed.daemon.v1alpha.Blob
-	0,  // 6: com.seed.daemon.v1alpha.Info.state:type_name -> com.seed.daemon.v1alpha.State
-	56, // 7: com.seed.daemon.v1alpha.Info.start_time:type_name -> google.protobuf.Timestamp
-	47, // 8: com.seed.daemon.v1alpha.Info.tasks:type_name -> com.seed.daemon.v1alpha.Task
-	56, // 9: com.seed.daemon.v1alpha.VaultSyncStatus.last_sync_time:type_name -> google.protobuf.Timestamp
-	1,  // 10: com.seed.daemon.v1alpha.GetVaultStatusResponse.backend_mode:type_name -> com.seed.daemon.v1alpha.VaultBackendMode
-	2,  // 11: com.seed.daemon.v1alpha.GetVaultStatusResponse.connection_status:type_name -> com.seed.daemon.v1alpha.VaultConnectionStatus
-	45, // 12: com.seed.daemon.v1alpha.GetVaultStatusResponse.sync_status:type_name -> com.seed.daemon.v1alpha.VaultSyncStatus
-	3,  // 13: com.seed.daemon.v1alpha.Task.task_name:type_name -> com.seed.daemon.v1alpha.TaskName
-	55, // 14: com.seed.daemon.v1alpha.ListDomainsResponse.domains:type_name -> com.seed.daemon.v1alpha.DomainInfo
-	56, // 15: com.seed.daemon.v1alpha.DomainInfo.last_check:type_name -> google.protobuf.Timestamp
-	56, // 16: com.seed.daemon.v1alpha.DomainInfo.last_success:type_name -> google.protobuf.Timestamp
-	4,  // 17: com.seed.daemon.v1alpha.Daemon.GenMnemonic:input_type -> com.seed.daemon.v1alpha.GenMnemonicRequest
-	8,  // 18: com.seed.daemon.v1alpha.Daemon.RegisterKey:input_type -> com.seed.daemon.v1alpha.RegisterKeyRequest
-	9,  // 19: com.seed.daemon.v1alpha.Daemon.ImportKey:input_type -> com.seed.daemon.v1alpha.ImportKeyRequest
-	10, // 20: com.seed.daemon.v1alpha.Daemon.ExportKey:input_type -> com.seed.daemon.v1alpha.ExportKeyRequest
-	11, // 21: com.seed.daemon.v1alpha.Daemon.GetInfo:input_type -> com.seed.daemon.v1alpha.GetInfoRequest
-	6,  // 22: com.seed.daemon.v1alpha.Daemon.Authenticate:input_type -> com.seed.daemon.v1alpha.AuthenticateRequest
-	12, // 23: com.seed.daemon.v1alpha.Daemon.GetVaultStatus:input_type -> com.seed.daemon.v1alpha.GetVaultStatusRequest
-	13, // 24: com.seed.daemon.v1alpha.Daemon.StartVaultConnection:input_type -> com.seed.daemon.v1alpha.StartVaultConnectionRequest
-	15, // 25: com.seed.daemon.v1alpha.Daemon.DisconnectVault:input_type -> com.seed.daemon.v1alpha.DisconnectVaultRequest
-	16, // 26: com.seed.daemon.v1alpha.Daemon.ForceSync:input_type -> com.seed.daemon.v1alpha.ForceSyncRequest
-	17, // 27: com.seed.daemon.v1alpha.Daemon.GetVaultEmail:input_type -> com.seed.daemon.v1alpha.GetVaultEmailRequest
-	19, // 28: com.seed.daemon.v1alpha.Daemon.ChangeVaultEmailStart:input_type -> com.seed.daemon.v1alpha.ChangeVaultEmailStartRequest
-	21, // 29: com.seed.daemon.v1alpha.Daemon.ChangeVaultEmailVerify:input_type -> com.seed.daemon.v1alpha.ChangeVaultEmailVerifyRequest
-	23, // 30: com.seed.daemon.v1alpha.Daemon.GetVaultPasswordStatus:input_type -> com.seed.daemon.v1alpha.GetVaultPasswordStatusRequest
-	25, // 31: com.seed.daemon.v1alpha.Daemon.SetVaultMasterPassword:input_type -> com.seed.daemon.v1alpha.SetVaultMasterPasswordRequest
-	27, // 32: com.seed.daemon.v1alpha.Daemon.GetVaultNotificationServer:input_type -> com.seed.daemon.v1alpha.GetVaultNotificationServerRequest
-	29, // 33: com.seed.daemon.v1alpha.Daemon.SetVaultNotificationServer:input_type -> com.seed.daemon.v1alpha.SetVaultNotificationServerRequest
-	31, // 34: com.seed.daemon.v1alpha.Daemon.ForceReindex:input_type -> com.seed.daemon.v1alpha.ForceReindexRequest
-	34, // 35: com.seed.daemon.v1alpha.Daemon.ListKeys:input_type -> com.seed.daemon.v1alpha.ListKeysRequest
-	36, // 36: com.seed.daemon.v1alpha.Daemon.UpdateKey:input_type -> com.seed.daemon.v1alpha.UpdateKeyRequest
-	37, // 37: com.seed.daemon.v1alpha.Daemon.DeleteKey:input_type -> com.seed.daemon.v1alpha.DeleteKeyRequest
-	33, // 38: com.seed.daemon.v1alpha.Daemon.DeleteAllKeys:input_type -> com.seed.daemon.v1alpha.DeleteAllKeysRequest
-	38, // 39: com.seed.daemon.v1alpha.Daemon.StoreBlobs:input_type -> com.seed.daemon.v1alpha.StoreBlobsRequest
-	40, // 40: com.seed.daemon.v1alpha.Daemon.SignData:input_type -> com.seed.daemon.v1alpha.SignDataRequest
-	49, // 41: com.seed.daemon.v1alpha.Daemon.GetDomain:input_type -> com.seed.daemon.v1alpha.GetDomainRequest
-	50, // 42: com.seed.daemon.v1alpha.Daemon.ListDomains:input_type -> com.seed.daemon.v1alpha.ListDomainsRequest
-	52, // 43: com.seed.daemon.v1alpha.Daemon.AddDomain:input_type -> com.seed.daemon.v1alpha.AddDomainRequest
-	53, // 44: com.seed.daemon.v1alpha.Daemon.RemoveDomain:input_type -> com.seed.daemon.v1alpha.RemoveDomainRequest
-	54, // 45: com.seed.daemon.v1alpha.Daemon.CheckDomain:input_type -> com.seed.daemon.v1alpha.CheckDomainRequest
-	5,  // 46: com.seed.daemon.v1alpha.Daemon.GenMnemonic:output_type -> com.seed.daemon.v1alpha.GenMnemonicResponse
-	48, // 47: com.seed.daemon.v1alpha.Daemon.RegisterKey:output_type -> com.seed.daemon.v1alpha.NamedKey
-	48, // 48: com.seed.daemon.v1alpha.Daemon.ImportKey:output_type -> com.seed.daemon.v1alpha.NamedKey
-	57, // 49: com.seed.daemon.v1alpha.Daemon.ExportKey:output_type -> google.protobuf.Empty
-	44, // 50: com.seed.daemon.v1alpha.Daemon.GetInfo:output_type -> com.seed.daemon.v1alpha.Info
-	7,  // 51: com.seed.daemon.v1alpha.Daemon.Authenticate:output_type -> com.seed.daemon.v1alpha.AuthenticateResponse
-	46, // 52: com.seed.daemon.v1alpha.Daemon.GetVaultStatus:output_type -> com.seed.daemon.v1alpha.GetVaultStatusResponse
-	14, // 53: com.seed.daemon.v1alpha.Daemon.StartVaultConnection:output_type -> com.seed.daemon.v1alpha.StartVaultConnectionResponse
-	57, // 54: com.seed.daemon.v1alpha.Daemon.DisconnectVault:output_type -> google.protobuf.Empty
-	57, // 55: com.seed.daemon.v1alpha.Daemon.ForceSync:output_type -> google.protobuf.Empty
-	18, // 56: com.seed.daemon.v1alpha.Daemon.GetVaultEmail:output_type -> com.seed.daemon.v1alpha.GetVaultEmailResponse
-	20, // 57: com.seed.daemon.v1alpha.Daemon.ChangeVaultEmailStart:output_type -> com.seed.daemon.v1alpha.ChangeVaultEmailStartResponse
-	22, // 58: com.seed.daemon.v1alpha.Daemon.ChangeVaultEmailVerify:output_type -> com.seed.daemon.v1alpha.ChangeVaultEmailVerifyResponse
-	24, // 59: com.seed.daemon.v1alpha.Daemon.GetVaultPasswordStatus:output_type -> com.seed.daemon.v1alpha.GetVaultPasswordStatusResponse
-	26, // 60: com.seed.daemon.v1alpha.Daemon.SetVaultMasterPassword:output_type -> com.seed.daemon.v1alpha.SetVaultMasterPasswordResponse
-	28, // 61: com.seed.daemon.v1alpha.Daemon.GetVaultNotificationServer:output_type -> com.seed.daemon.v1alpha.GetVaultNotificationServerResponse
-	30, // 62: com.seed.daemon.v1alpha.Daemon.SetVaultNotificationServer:output_type -> com.seed.daemon.v1alpha.SetVaultNotificationServerResponse
-	32, // 63: com.seed.daemon.v1alpha.Daemon.ForceReindex:output_type -> com.seed.daemon.v1alpha.ForceReindexResponse
-	35, // 64: com.seed.daemon.v1alpha.Daemon.ListKeys:output_type -> com.seed.daemon.v1alpha.ListKeysResponse
-	48, // 65: com.seed.daemon.v1alpha.Daemon.UpdateKey:output_type -> com.seed.daemon.v1alpha.NamedKey
-	57, // 66: com.seed.daemon.v1alpha.Daemon.DeleteKey:output_type -> google.protobuf.Empty
-	57, // 67: com.seed.daemon.v1alpha.Daemon.DeleteAllKeys:output_type -> google.protobuf.Empty
-	39, // 68: com.seed.daemon.v1alpha.Daemon.StoreBlobs:output_type -> com.seed.daemon.v1alpha.StoreBlobsResponse
-	41, // 69: com.seed.daemon.v1alpha.Daemon.SignData:output_type -> com.seed.daemon.v1alpha.SignDataResponse
-	55, // 70: com.seed.daemon.v1alpha.Daemon.GetDomain:output_type -> com.seed.daemon.v1alpha.DomainInfo
-	51, // 71: com.seed.daemon.v1alpha.Daemon.ListDomains:output_type -> com.seed.daemon.v1alpha.ListDomainsResponse
-	55, // 72: com.seed.daemon.v1alpha.Daemon.AddDomain:output_type -> com.seed.daemon.v1alpha.DomainInfo
-	57, // 73: com.seed.daemon.v1alpha.Daemon.RemoveDomain:output_type -> google.protobuf.Empty
-	55, // 74: com.seed.daemon.v1alpha.Daemon.CheckDomain:output_type -> com.seed.daemon.v1alpha.DomainInfo
-	46, // [46:75] is the sub-list for method output_type
-	17, // [17:46] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	77, // 0: com.seed.daemon.v1alpha.AuthenticateResponse.expire_time:type_name -> google.protobuf.Timestamp
+	77, // 1: com.seed.daemon.v1alpha.StartVaultConnectionResponse.expire_time:type_name -> google.protobuf.Timestamp
+	77, // 2: com.seed.daemon.v1alpha.ChangeVaultEmailStartResponse.expire_time:type_name -> google.protobuf.Timestamp
+	77, // 3: com.seed.daemon.v1alpha.ChangeVaultEmailStartResponse.resend_allowed_time:type_name -> google.protobuf.Timestamp
+	68, // 4: com.seed.daemon.v1alpha.ListKeysResponse.keys:type_name -> com.seed.daemon.v1alpha.NamedKey
+	63, // 5: com.seed.daemon.v1alpha.StoreBlobsRequest.blobs:type_name -> com.seed.daemon.v1alpha.Blob
+	44, // 6: com.seed.daemon.v1alpha.ImportArchiveResponse.stashed_blobs:type_name -> com.seed.daemon.v1alpha.StashedBlob
+	47, // 7: com.seed.daemon.v1alpha.VerifyStoreResponse.report:type_name -> com.seed.daemon.v1alpha.StoreReport
+	48, // 8: com.seed.daemon.v1alpha.StoreReport.problems:type_name -> com.seed.daemon.v1alpha.StoreProblem
+	76, // 9: com.seed.daemon.v1alpha.StoreReport.stashed_blobs:type_name -> com.seed.daemon.v1alpha.StoreReport.StashedBlobsEntry
+	59, // 10: com.seed.daemon.v1alpha.ListPinnedResourcesResponse.pins:type_name -> com.seed.daemon.v1alpha.PinnedResource
+	58, // 11: com.seed.daemon.v1alpha.ListSpaceUsageResponse.spaces:type_name -> com.seed.daemon.v1alpha.SpaceUsage
+	0,  // 12: com.seed.daemon.v1alpha.Info.state:type_name -> com.seed.daemon.v1alpha.State
+	77, // 13: com.seed.daemon.v1alpha.Info.start_time:type_name -> google.protobuf.Timestamp
+	67, // 14: com.seed.daemon.v1alpha.Info.tasks:type_name -> com.seed.daemon.v1alpha.Task
+	77, // 15: com.seed.daemon.v1alpha.VaultSyncStatus.last_sync_time:type_name -> google.protobuf.Timestamp
+	1,  // 16: com.seed.daemon.v1alpha.GetVaultStatusResponse.backend_mode:type_name -> com.seed.daemon.v1alpha.VaultBackendMode
+	2,  // 17: com.seed.daemon.v1alpha.GetVaultStatusResponse.connection_status:type_name -> com.seed.daemon.v1alpha.VaultConnectionStatus
+	65, // 18: com.seed.daemon.v1alpha.GetVaultStatusResponse.sync_status:type_name -> com.seed.daemon.v1alpha.VaultSyncStatus
+	3,  // 19: com.seed.daemon.v1alpha.Task.task_name:type_name -> com.seed.daemon.v1alpha.TaskName
+	75, // 20: com.seed.daemon.v1alpha.ListDomainsResponse.domains:type_name -> com.seed.daemon.v1alpha.DomainInfo
+	77, // 21: com.seed.daemon.v1alpha.DomainInfo.last_check:type_name -> google.protobuf.Timestamp
+	77, // 22: com.seed.daemon.v1alpha.DomainInfo.last_success:type_name -> google.protobuf.Timestamp
+	4,  // 23: com.seed.daemon.v1alpha.Daemon.GenMnemonic:input_type -> com.seed.daemon.v1alpha.GenMnemonicRequest
+	8,  // 24: com.seed.daemon.v1alpha.Daemon.RegisterKey:input_type -> com.seed.daemon.v1alpha.RegisterKeyRequest
+	9,  // 25: com.seed.daemon.v1alpha.Daemon.ImportKey:input_type -> com.seed.daemon.v1alpha.ImportKeyRequest
+	10, // 26: com.seed.daemon.v1alpha.Daemon.ExportKey:input_type -> com.seed.daemon.v1alpha.ExportKeyRequest
+	11, // 27: com.seed.daemon.v1alpha.Daemon.GetInfo:input_type -> com.seed.daemon.v1alpha.GetInfoRequest
+	6,  // 28: com.seed.daemon.v1alpha.Daemon.Authenticate:input_type -> com.seed.daemon.v1alpha.AuthenticateRequest
+	12, // 29: com.seed.daemon.v1alpha.Daemon.GetVaultStatus:input_type -> com.seed.daemon.v1alpha.GetVaultStatusRequest
+	13, // 30: com.seed.daemon.v1alpha.Daemon.StartVaultConnection:input_type -> com.seed.daemon.v1alpha.StartVaultConnectionRequest
+	15, // 31: com.seed.daemon.v1alpha.Daemon.DisconnectVault:input_type -> com.seed.daemon.v1alpha.DisconnectVaultRequest
+	16, // 32: com.seed.daemon.v1alpha.Daemon.ForceSync:input_type -> com.seed.daemon.v1alpha.ForceSyncRequest
+	17, // 33: com.seed.daemon.v1alpha.Daemon.GetVaultEmail:input_type -> com.seed.daemon.v1alpha.GetVaultEmailRequest
+	19, // 34: com.seed.daemon.v1alpha.Daemon.ChangeVaultEmailStart:input_type -> com.seed.daemon.v1alpha.ChangeVaultEmailStartRequest
+	21, // 35: com.seed.daemon.v1alpha.Daemon.ChangeVaultEmailVerify:input_type -> com.seed.daemon.v1alpha.ChangeVaultEmailVerifyRequest
+	23, // 36: com.seed.daemon.v1alpha.Daemon.GetVaultPasswordStatus:input_type -> com.seed.daemon.v1alpha.GetVaultPasswordStatusRequest
+	25, // 37: com.seed.daemon.v1alpha.Daemon.SetVaultMasterPassword:input_type -> com.seed.daemon.v1alpha.SetVaultMasterPasswordRequest
+	27, // 38: com.seed.daemon.v1alpha.Daemon.GetVaultNotificationServer:input_type -> com.seed.daemon.v1alpha.GetVaultNotificationServerRequest
+	29, // 39: com.seed.daemon.v1alpha.Daemon.SetVaultNotificationServer:input_type -> com.seed.daemon.v1alpha.SetVaultNotificationServerRequest
+	31, // 40: com.seed.daemon.v1alpha.Daemon.ForceReindex:input_type -> com.seed.daemon.v1alpha.ForceReindexRequest
+	34, // 41: com.seed.daemon.v1alpha.Daemon.ListKeys:input_type -> com.seed.daemon.v1alpha.ListKeysRequest
+	36, // 42: com.seed.daemon.v1alpha.Daemon.UpdateKey:input_type -> com.seed.daemon.v1alpha.UpdateKeyRequest
+	37, // 43: com.seed.daemon.v1alpha.Daemon.DeleteKey:input_type -> com.seed.daemon.v1alpha.DeleteKeyRequest
+	33, // 44: com.seed.daemon.v1alpha.Daemon.DeleteAllKeys:input_type -> com.seed.daemon.v1alpha.DeleteAllKeysRequest
+	38, // 45: com.seed.daemon.v1alpha.Daemon.StoreBlobs:input_type -> com.seed.daemon.v1alpha.StoreBlobsRequest
+	40, // 46: com.seed.daemon.v1alpha.Daemon.ExportSpace:input_type -> com.seed.daemon.v1alpha.ExportSpaceRequest
+	42, // 47: com.seed.daemon.v1alpha.Daemon.ImportArchive:input_type -> com.seed.daemon.v1alpha.ImportArchiveRequest
+	45, // 48: com.seed.daemon.v1alpha.Daemon.VerifyStore:input_type -> com.seed.daemon.v1alpha.VerifyStoreRequest
+	49, // 49: com.seed.daemon.v1alpha.Daemon.CollectGarbage:input_type -> com.seed.daemon.v1alpha.CollectGarbageRequest
+	51, // 50: com.seed.daemon.v1alpha.Daemon.PinResource:input_type -> com.seed.daemon.v1alpha.PinResourceRequest
+	52, // 51: com.seed.daemon.v1alpha.Daemon.UnpinResource:input_type -> com.seed.daemon.v1alpha.UnpinResourceRequest
+	53, // 52: com.seed.daemon.v1alpha.Daemon.ListPinnedResources:input_type -> com.seed.daemon.v1alpha.ListPinnedResourcesRequest
+	55, // 53: com.seed.daemon.v1alpha.Daemon.GetSpaceUsage:input_type -> com.seed.daemon.v1alpha.GetSpaceUsageRequest
+	56, // 54: com.seed.daemon.v1alpha.Daemon.ListSpaceUsage:input_type -> com.seed.daemon.v1alpha.ListSpaceUsageRequest
+	60, // 55: com.seed.daemon.v1alpha.Daemon.SignData:input_type -> com.seed.daemon.v1alpha.SignDataRequest
+	69, // 56: com.seed.daemon.v1alpha.Daemon.GetDomain:input_type -> com.seed.daemon.v1alpha.GetDomainRequest
+	70, // 57: com.seed.daemon.v1alpha.Daemon.ListDomains:input_type -> com.seed.daemon.v1alpha.ListDomainsRequest
+	72, // 58: com.seed.daemon.v1alpha.Daemon.AddDomain:input_type -> com.seed.daemon.v1alpha.AddDomainRequest
+	73, // 59: com.seed.daemon.v1alpha.Daemon.RemoveDomain:input_type -> com.seed.daemon.v1alpha.RemoveDomainRequest
+	74, // 60: com.seed.daemon.v1alpha.Daemon.CheckDomain:input_type -> com.seed.daemon.v1alpha.CheckDomainRequest
+	5,  // 61: com.seed.daemon.v1alpha.Daemon.GenMnemonic:output_type -> com.seed.daemon.v1alpha.GenMnemonicResponse
+	68, // 62: com.seed.daemon.v1alpha.Daemon.RegisterKey:output_type -> com.seed.daemon.v1alpha.NamedKey
+	68, // 63: com.seed.daemon.v1alpha.Daemon.ImportKey:output_type -> com.seed.daemon.v1alpha.NamedKey
+	78, // 64: com.seed.daemon.v1alpha.Daemon.ExportKey:output_type -> google.protobuf.Empty
+	64, // 65: com.seed.daemon.v1alpha.Daemon.GetInfo:output_type -> com.seed.daemon.v1alpha.Info
+	7,  // 66: com.seed.daemon.v1alpha.Daemon.Authenticate:output_type -> com.seed.daemon.v1alpha.AuthenticateResponse
+	66, // 67: com.seed.daemon.v1alpha.Daemon.GetVaultStatus:output_type -> com.seed.daemon.v1alpha.GetVaultStatusResponse
+	14, // 68: com.seed.daemon.v1alpha.Daemon.StartVaultConnection:output_type -> com.seed.daemon.v1alpha.StartVaultConnectionResponse
+	78, // 69: com.seed.daemon.v1alpha.Daemon.DisconnectVault:output_type -> google.protobuf.Empty
+	78, // 70: com.seed.daemon.v1alpha.Daemon.ForceSync:output_type -> google.protobuf.Empty
+	18, // 71: com.seed.daemon.v1alpha.Daemon.GetVaultEmail:output_type -> com.seed.daemon.v1alpha.GetVaultEmailResponse
+	20, // 72: com.seed.daemon.v1alpha.Daemon.ChangeVaultEmailStart:output_type -> com.seed.daemon.v1alpha.ChangeVaultEmailStartResponse
+	22, // 73: com.seed.daemon.v1alpha.Daemon.ChangeVaultEmailVerify:output_type -> com.seed.daemon.v1alpha.ChangeVaultEmailVerifyResponse
+	24, // 74: com.seed.daemon.v1alpha.Daemon.GetVaultPasswordStatus:output_type -> com.seed.daemon.v1alpha.GetVaultPasswordStatusResponse
+	26, // 75: com.seed.daemon.v1alpha.Daemon.SetVaultMasterPassword:output_type -> com.seed.daemon.v1alpha.SetVaultMasterPasswordResponse
+	28, // 76: com.seed.daemon.v1alpha.Daemon.GetVaultNotificationServer:output_type -> com.seed.daemon.v1alpha.GetVaultNotificationServerResponse
+	30, // 77: com.seed.daemon.v1alpha.Daemon.SetVaultNotificationServer:output_type -> com.seed.daemon.v1alpha.SetVaultNotificationServerResponse
+	32, // 78: com.seed.daemon.v1alpha.Daemon.ForceReindex:output_type -> com.seed.daemon.v1alpha.ForceReindexResponse
+	35, // 79: com.seed.daemon.v1alpha.Daemon.ListKeys:output_type -> com.seed.daemon.v1alpha.ListKeysResponse
+	68, // 80: com.seed.daemon.v1alpha.Daemon.UpdateKey:output_type -> com.seed.daemon.v1alpha.NamedKey
+	78, // 81: com.seed.daemon.v1alpha.Daemon.DeleteKey:output_type -> google.protobuf.Empty
+	78, // 82: com.seed.daemon.v1alpha.Daemon.DeleteAllKeys:output_type -> google.protobuf.Empty
+	39, // 83: com.seed.daemon.v1alpha.Daemon.StoreBlobs:output_type -> com.seed.daemon.v1alpha.StoreBlobsResponse
+	41, // 84: com.seed.daemon.v1alpha.Daemon.ExportSpace:output_type -> com.seed.daemon.v1alpha.ExportSpaceResponse
+	43, // 85: com.seed.daemon.v1alpha.Daemon.ImportArchive:output_type -> com.seed.daemon.v1alpha.ImportArchiveResponse
+	46, // 86: com.seed.daemon.v1alpha.Daemon.VerifyStore:output_type -> com.seed.daemon.v1alpha.VerifyStoreResponse
+	50, // 87: com.seed.daemon.v1alpha.Daemon.CollectGarbage:output_type -> com.seed.daemon.v1alpha.CollectGarbageResponse
+	78, // 88: com.seed.daemon.v1alpha.Daemon.PinResource:output_type -> google.protobuf.Empty
+	78, // 89: com.seed.daemon.v1alpha.Daemon.UnpinResource:output_type -> google.protobuf.Empty
+	54, // 90: com.seed.daemon.v1alpha.Daemon.ListPinnedResources:output_type -> com.seed.daemon.v1alpha.ListPinnedResourcesResponse
+	58, // 91: com.seed.daemon.v1alpha.Daemon.GetSpaceUsage:output_type -> com.seed.daemon.v1alpha.SpaceUsage
+	57, // 92: com.seed.daemon.v1alpha.Daemon.ListSpaceUsage:output_type -> com.seed.daemon.v1alpha.ListSpaceUsageResponse
+	61, // 93: com.seed.daemon.v1alpha.Daemon.SignData:output_type -> com.seed.daemon.v1alpha.SignDataResponse
+	75, // 94: com.seed.daemon.v1alpha.Daemon.GetDomain:output_type -> com.seed.daemon.v1alpha.DomainInfo
+	71, // 95: com.seed.daemon.v1alpha.Daemon.ListDomains:output_type -> com.seed.daemon.v1alpha.ListDomainsResponse
+	75, // 96: com.seed.daemon.v1alpha.Daemon.AddDomain:output_type -> com.seed.daemon.v1alpha.DomainInfo
+	78, // 97: com.seed.daemon.v1alpha.Daemon.RemoveDomain:output_type -> google.protobuf.Empty
+	75, // 98: com.seed.daemon.v1alpha.Daemon.CheckDomain:output_type -> com.seed.daemon.v1alpha.DomainInfo
+	61, // [61:99] is the sub-list for method output_type
+	23, // [23:61] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_daemon_v1alpha_daemon_proto_init() }
@@ -3280,7 +4672,7 @@ func file_daemon_v1alpha_daemon_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_daemon_v1alpha_daemon_proto_rawDesc), len(file_daemon_v1alpha_daemon_proto_rawDesc)),
 			NumEnums:      4,
-			NumMessages:   52,
+			NumMessages:   73,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
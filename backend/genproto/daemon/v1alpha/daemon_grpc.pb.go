@@ -43,6 +43,15 @@ const (
 	Daemon_DeleteKey_FullMethodName                  = "/com.seed.daemon.v1alpha.Daemon/DeleteKey"
 	Daemon_DeleteAllKeys_FullMethodName              = "/com.seed.daemon.v1alpha.Daemon/DeleteAllKeys"
 	Daemon_StoreBlobs_FullMethodName                 = "/com.seed.daemon.v1alpha.Daemon/StoreBlobs"
+	Daemon_ExportSpace_FullMethodName                = "/com.seed.daemon.v1alpha.Daemon/ExportSpace"
+	Daemon_ImportArchive_FullMethodName              = "/com.seed.daemon.v1alpha.Daemon/ImportArchive"
+	Daemon_VerifyStore_FullMethodName                = "/com.seed.daemon.v1alpha.Daemon/VerifyStore"
+	Daemon_CollectGarbage_FullMethodName             = "/com.seed.daemon.v1alpha.Daemon/CollectGarbage"
+	Daemon_PinResource_FullMethodName                = "/com.seed.daemon.v1alpha.Daemon/PinResource"
+	Daemon_UnpinResource_FullMethodName              = "/com.seed.daemon.v1alpha.Daemon/UnpinResource"
+	Daemon_ListPinnedResources_FullMethodName        = "/com.seed.daemon.v1alpha.Daemon/ListPinnedResources"
+	Daemon_GetSpaceUsage_FullMethodName              = "/com.seed.daemon.v1alpha.Daemon/GetSpaceUsage"
+	Daemon_ListSpaceUsage_FullMethodName             = "/com.seed.daemon.v1alpha.Daemon/ListSpaceUsage"
 	Daemon_SignData_FullMethodName                   = "/com.seed.daemon.v1alpha.Daemon/SignData"
 	Daemon_GetDomain_FullMethodName                  = "/com.seed.daemon.v1alpha.Daemon/GetDomain"
 	Daemon_ListDomains_FullMethodName                = "/com.seed.daemon.v1alpha.Daemon/ListDomains"
@@ -98,7 +107,8 @@ type DaemonClient interface {
 	// Sets the notification server URL in the vault state and syncs it to the
 	// remote vault, so it stays consistent across devices and the web vault.
 	SetVaultNotificationServer(ctx context.Context, in *SetVaultNotificationServerRequest, opts ...grpc.CallOption) (*SetVaultNotificationServerResponse, error)
-	// Forces the daemon to reindex the entire database.
+	// Forces the daemon to reindex the entire database,
+	// or only the resources of a space (or a subtree of it) if the account is specified.
 	ForceReindex(ctx context.Context, in *ForceReindexRequest, opts ...grpc.CallOption) (*ForceReindexResponse, error)
 	// Lists all the signing keys registered on this Daemon.
 	ListKeys(ctx context.Context, in *ListKeysRequest, opts ...grpc.CallOption) (*ListKeysResponse, error)
@@ -111,6 +121,27 @@ type DaemonClient interface {
 	// Receives raw blobs to be stored.
 	// The request may fail if blobs can't be recognized by the daemon.
 	StoreBlobs(ctx context.Context, in *StoreBlobsRequest, opts ...grpc.CallOption) (*StoreBlobsResponse, error)
+	// Exports the blobs of a space (or a subtree of it) into a CAR file on disk.
+	ExportSpace(ctx context.Context, in *ExportSpaceRequest, opts ...grpc.CallOption) (*ExportSpaceResponse, error)
+	// Imports the blobs from a CAR file on disk.
+	// Blobs are indexed like any other blobs, so signatures and permissions are verified.
+	ImportArchive(ctx context.Context, in *ImportArchiveRequest, opts ...grpc.CallOption) (*ImportArchiveResponse, error)
+	// Verifies the integrity of the blob store and of the index, optionally repairing the index.
+	// Progress is streamed while the blobs are checked, and the last message contains the report.
+	VerifyStore(ctx context.Context, in *VerifyStoreRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VerifyStoreResponse], error)
+	// Removes the data of the blobs not reachable from the local keys' spaces, the subscriptions, and the pinned resources.
+	// Collected blobs are tombstoned, so they are not fetched again from the network right away.
+	CollectGarbage(ctx context.Context, in *CollectGarbageRequest, opts ...grpc.CallOption) (*CollectGarbageResponse, error)
+	// Protects a resource from being garbage collected.
+	PinResource(ctx context.Context, in *PinResourceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Removes the pin of a resource.
+	UnpinResource(ctx context.Context, in *UnpinResourceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Lists the pinned resources.
+	ListPinnedResources(ctx context.Context, in *ListPinnedResourcesRequest, opts ...grpc.CallOption) (*ListPinnedResourcesResponse, error)
+	// Returns the storage used by the blobs of a space.
+	GetSpaceUsage(ctx context.Context, in *GetSpaceUsageRequest, opts ...grpc.CallOption) (*SpaceUsage, error)
+	// Lists the storage used by each space, largest first.
+	ListSpaceUsage(ctx context.Context, in *ListSpaceUsageRequest, opts ...grpc.CallOption) (*ListSpaceUsageResponse, error)
 	// Sign arbitrary data with an existing signing key.
 	SignData(ctx context.Context, in *SignDataRequest, opts ...grpc.CallOption) (*SignDataResponse, error)
 	// Gets cached information about a domain.
@@ -363,6 +394,105 @@ func (c *daemonClient) StoreBlobs(ctx context.Context, in *StoreBlobsRequest, op
 	return out, nil
 }
 
+func (c *daemonClient) ExportSpace(ctx context.Context, in *ExportSpaceRequest, opts ...grpc.CallOption) (*ExportSpaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportSpaceResponse)
+	err := c.cc.Invoke(ctx, Daemon_ExportSpace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) ImportArchive(ctx context.Context, in *ImportArchiveRequest, opts ...grpc.CallOption) (*ImportArchiveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportArchiveResponse)
+	err := c.cc.Invoke(ctx, Daemon_ImportArchive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) VerifyStore(ctx context.Context, in *VerifyStoreRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VerifyStoreResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[0], Daemon_VerifyStore_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[VerifyStoreRequest, VerifyStoreResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_VerifyStoreClient = grpc.ServerStreamingClient[VerifyStoreResponse]
+
+func (c *daemonClient) CollectGarbage(ctx context.Context, in *CollectGarbageRequest, opts ...grpc.CallOption) (*CollectGarbageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectGarbageResponse)
+	err := c.cc.Invoke(ctx, Daemon_CollectGarbage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) PinResource(ctx context.Context, in *PinResourceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Daemon_PinResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) UnpinResource(ctx context.Context, in *UnpinResourceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Daemon_UnpinResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) ListPinnedResources(ctx context.Context, in *ListPinnedResourcesRequest, opts ...grpc.CallOption) (*ListPinnedResourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPinnedResourcesResponse)
+	err := c.cc.Invoke(ctx, Daemon_ListPinnedResources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) GetSpaceUsage(ctx context.Context, in *GetSpaceUsageRequest, opts ...grpc.CallOption) (*SpaceUsage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SpaceUsage)
+	err := c.cc.Invoke(ctx, Daemon_GetSpaceUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) ListSpaceUsage(ctx context.Context, in *ListSpaceUsageRequest, opts ...grpc.CallOption) (*ListSpaceUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSpaceUsageResponse)
+	err := c.cc.Invoke(ctx, Daemon_ListSpaceUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *daemonClient) SignData(ctx context.Context, in *SignDataRequest, opts ...grpc.CallOption) (*SignDataResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SignDataResponse)
@@ -470,7 +600,8 @@ type DaemonServer interface {
 	// Sets the notification server URL in the vault state and syncs it to the
 	// remote vault, so it stays consistent across devices and the web vault.
 	SetVaultNotificationServer(context.Context, *SetVaultNotificationServerRequest) (*SetVaultNotificationServerResponse, error)
-	// Forces the daemon to reindex the entire database.
+	// Forces the daemon to reindex the entire database,
+	// or only the resources of a space (or a subtree of it) if the account is specified.
 	ForceReindex(context.Context, *ForceReindexRequest) (*ForceReindexResponse, error)
 	// Lists all the signing keys registered on this Daemon.
 	ListKeys(context.Context, *ListKeysRequest) (*ListKeysResponse, error)
@@ -483,6 +614,27 @@ type DaemonServer interface {
 	// Receives raw blobs to be stored.
 	// The request may fail if blobs can't be recognized by the daemon.
 	StoreBlobs(context.Context, *StoreBlobsRequest) (*StoreBlobsResponse, error)
+	// Exports the blobs of a space (or a subtree of it) into a CAR file on disk.
+	ExportSpace(context.Context, *ExportSpaceRequest) (*ExportSpaceResponse, error)
+	// Imports the blobs from a CAR file on disk.
+	// Blobs are indexed like any other blobs, so signatures and permissions are verified.
+	ImportArchive(context.Context, *ImportArchiveRequest) (*ImportArchiveResponse, error)
+	// Verifies the integrity of the blob store and of the index, optionally repairing the index.
+	// Progress is streamed while the blobs are checked, and the last message contains the report.
+	VerifyStore(*VerifyStoreRequest, grpc.ServerStreamingServer[VerifyStoreResponse]) error
+	// Removes the data of the blobs not reachable from the local keys' spaces, the subscriptions, and the pinned resources.
+	// Collected blobs are tombstoned, so they are not fetched again from the network right away.
+	CollectGarbage(context.Context, *CollectGarbageRequest) (*CollectGarbageResponse, error)
+	// Protects a resource from being garbage collected.
+	PinResource(context.Context, *PinResourceRequest) (*emptypb.Empty, error)
+	// Removes the pin of a resource.
+	UnpinResource(context.Context, *UnpinResourceRequest) (*emptypb.Empty, error)
+	// Lists the pinned resources.
+	ListPinnedResources(context.Context, *ListPinnedResourcesRequest) (*ListPinnedResourcesResponse, error)
+	// Returns the storage used by the blobs of a space.
+	GetSpaceUsage(context.Context, *GetSpaceUsageRequest) (*SpaceUsage, error)
+	// Lists the storage used by each space, largest first.
+	ListSpaceUsage(context.Context, *ListSpaceUsageRequest) (*ListSpaceUsageResponse, error)
 	// Sign arbitrary data with an existing signing key.
 	SignData(context.Context, *SignDataRequest) (*SignDataResponse, error)
 	// Gets cached information about a domain.
@@ -573,6 +725,33 @@ func (UnimplementedDaemonServer) DeleteAllKeys(context.Context, *DeleteAllKeysRe
 func (UnimplementedDaemonServer) StoreBlobs(context.Context, *StoreBlobsRequest) (*StoreBlobsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StoreBlobs not implemented")
 }
+func (UnimplementedDaemonServer) ExportSpace(context.Context, *ExportSpaceRequest) (*ExportSpaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportSpace not implemented")
+}
+func (UnimplementedDaemonServer) ImportArchive(context.Context, *ImportArchiveRequest) (*ImportArchiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportArchive not implemented")
+}
+func (UnimplementedDaemonServer) VerifyStore(*VerifyStoreRequest, grpc.ServerStreamingServer[VerifyStoreResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method VerifyStore not implemented")
+}
+func (UnimplementedDaemonServer) CollectGarbage(context.Context, *CollectGarbageRequest) (*CollectGarbageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CollectGarbage not implemented")
+}
+func (UnimplementedDaemonServer) PinResource(context.Context, *PinResourceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinResource not implemented")
+}
+func (UnimplementedDaemonServer) UnpinResource(context.Context, *UnpinResourceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnpinResource not implemented")
+}
+func (UnimplementedDaemonServer) ListPinnedResources(context.Context, *ListPinnedResourcesRequest) (*ListPinnedResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPinnedResources not implemented")
+}
+func (UnimplementedDaemonServer) GetSpaceUsage(context.Context, *GetSpaceUsageRequest) (*SpaceUsage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSpaceUsage not implemented")
+}
+func (UnimplementedDaemonServer) ListSpaceUsage(context.Context, *ListSpaceUsageRequest) (*ListSpaceUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSpaceUsage not implemented")
+}
 func (UnimplementedDaemonServer) SignData(context.Context, *SignDataRequest) (*SignDataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignData not implemented")
 }
@@ -1025,6 +1204,161 @@ func _Daemon_StoreBlobs_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Daemon_ExportSpace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportSpaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ExportSpace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_ExportSpace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ExportSpace(ctx, req.(*ExportSpaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ImportArchive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportArchiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ImportArchive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_ImportArchive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ImportArchive(ctx, req.(*ImportArchiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_VerifyStore_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(VerifyStoreRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).VerifyStore(m, &grpc.GenericServerStream[VerifyStoreRequest, VerifyStoreResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_VerifyStoreServer = grpc.ServerStreamingServer[VerifyStoreResponse]
+
+func _Daemon_CollectGarbage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectGarbageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).CollectGarbage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_CollectGarbage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).CollectGarbage(ctx, req.(*CollectGarbageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_PinResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).PinResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_PinResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).PinResource(ctx, req.(*PinResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_UnpinResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpinResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).UnpinResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_UnpinResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).UnpinResource(ctx, req.(*UnpinResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ListPinnedResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPinnedResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ListPinnedResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_ListPinnedResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ListPinnedResources(ctx, req.(*ListPinnedResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_GetSpaceUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSpaceUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).GetSpaceUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_GetSpaceUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).GetSpaceUsage(ctx, req.(*GetSpaceUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ListSpaceUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSpaceUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ListSpaceUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_ListSpaceUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ListSpaceUsage(ctx, req.(*ListSpaceUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Daemon_SignData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SignDataRequest)
 	if err := dec(in); err != nil {
@@ -1232,6 +1566,38 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StoreBlobs",
 			Handler:    _Daemon_StoreBlobs_Handler,
 		},
+		{
+			MethodName: "ExportSpace",
+			Handler:    _Daemon_ExportSpace_Handler,
+		},
+		{
+			MethodName: "ImportArchive",
+			Handler:    _Daemon_ImportArchive_Handler,
+		},
+		{
+			MethodName: "CollectGarbage",
+			Handler:    _Daemon_CollectGarbage_Handler,
+		},
+		{
+			MethodName: "PinResource",
+			Handler:    _Daemon_PinResource_Handler,
+		},
+		{
+			MethodName: "UnpinResource",
+			Handler:    _Daemon_UnpinResource_Handler,
+		},
+		{
+			MethodName: "ListPinnedResources",
+			Handler:    _Daemon_ListPinnedResources_Handler,
+		},
+		{
+			MethodName: "GetSpaceUsage",
+			Handler:    _Daemon_GetSpaceUsage_Handler,
+		},
+		{
+			MethodName: "ListSpaceUsage",
+			Handler:    _Daemon_ListSpaceUsage_Handler,
+		},
 		{
 			MethodName: "SignData",
 			Handler:    _Daemon_SignData_Handler,
@@ -1257,6 +1623,12 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Daemon_CheckDomain_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "VerifyStore",
+			Handler:       _Daemon_VerifyStore_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "daemon/v1alpha/daemon.proto",
 }
@@ -9,6 +9,7 @@ package documents
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -32,6 +33,10 @@ const (
 	// Grants full authority over the key,
 	// including the idea to act on behalf of the issuing key.
 	Role_AGENT Role = 3
+	// Has read access to private documents.
+	Role_READER Role = 4
+	// Has read access to private documents, and can comment on them.
+	Role_COMMENTER Role = 5
 )
 
 // Enum value maps for Role.
@@ -40,11 +45,15 @@ var (
 		0: "ROLE_UNSPECIFIED",
 		2: "WRITER",
 		3: "AGENT",
+		4: "READER",
+		5: "COMMENTER",
 	}
 	Role_value = map[string]int32{
 		"ROLE_UNSPECIFIED": 0,
 		"WRITER":           2,
 		"AGENT":            3,
+		"READER":           4,
+		"COMMENTER":        5,
 	}
 )
 
@@ -300,7 +309,13 @@ type CreateCapabilityRequest struct {
 	NoRecursive bool `protobuf:"varint,6,opt,name=no_recursive,json=noRecursive,proto3" json:"no_recursive,omitempty"`
 	// Optional. Short, user-provided label for the capability for user's convenience to identify them later.
 	// The label is public and cannot be changed.
-	Label         string `protobuf:"bytes,7,opt,name=label,proto3" json:"label,omitempty"`
+	Label string `protobuf:"bytes,7,opt,name=label,proto3" json:"label,omitempty"`
+	// Optional. Time from which the capability becomes valid.
+	// Changes signed by the delegate before this time are not authorized by the capability.
+	NotBefore *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	// Optional. Time when the capability expires.
+	// Changes signed by the delegate at or after this time are not authorized by the capability.
+	ExpireTime    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -384,6 +399,20 @@ func (x *CreateCapabilityRequest) GetLabel() string {
 	return ""
 }
 
+func (x *CreateCapabilityRequest) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+func (x *CreateCapabilityRequest) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
 // Request to get a single capability.
 type GetCapabilityRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -430,6 +459,62 @@ func (x *GetCapabilityRequest) GetId() string {
 	return ""
 }
 
+// Request to revoke a capability.
+type RevokeCapabilityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. Name of the key to use for signing the revocation.
+	// Must be the same key that issued the capability.
+	SigningKeyName string `protobuf:"bytes,1,opt,name=signing_key_name,json=signingKeyName,proto3" json:"signing_key_name,omitempty"`
+	// Required. ID of the capability to revoke.
+	Id            string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeCapabilityRequest) Reset() {
+	*x = RevokeCapabilityRequest{}
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeCapabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeCapabilityRequest) ProtoMessage() {}
+
+func (x *RevokeCapabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeCapabilityRequest.ProtoReflect.Descriptor instead.
+func (*RevokeCapabilityRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_access_control_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RevokeCapabilityRequest) GetSigningKeyName() string {
+	if x != nil {
+		return x.SigningKeyName
+	}
+	return ""
+}
+
+func (x *RevokeCapabilityRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 // Capability is an unforgeable token that grants access to a specific path within an account.
 type Capability struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -453,14 +538,18 @@ type Capability struct {
 	// Timestamp when this capability was issued.
 	CreateTime *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
 	// Optional. Short, user-provided label for the capability for user's convenience to identify different capabilities.
-	Label         string `protobuf:"bytes,9,opt,name=label,proto3" json:"label,omitempty"`
+	Label string `protobuf:"bytes,9,opt,name=label,proto3" json:"label,omitempty"`
+	// Optional. Time from which the capability is valid, if any.
+	NotBefore *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	// Optional. Time when the capability expires, if any.
+	ExpireTime    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Capability) Reset() {
 	*x = Capability{}
-	mi := &file_documents_v3alpha_access_control_proto_msgTypes[5]
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -472,7 +561,7 @@ func (x *Capability) String() string {
 func (*Capability) ProtoMessage() {}
 
 func (x *Capability) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_access_control_proto_msgTypes[5]
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -485,7 +574,7 @@ func (x *Capability) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Capability.ProtoReflect.Descriptor instead.
 func (*Capability) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_access_control_proto_rawDescGZIP(), []int{5}
+	return file_documents_v3alpha_access_control_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Capability) GetId() string {
@@ -551,58 +640,1153 @@ func (x *Capability) GetLabel() string {
 	return ""
 }
 
-var File_documents_v3alpha_access_control_proto protoreflect.FileDescriptor
+func (x *Capability) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
 
-const file_documents_v3alpha_access_control_proto_rawDesc = "" +
-	"\n" +
-	"&documents/v3alpha/access_control.proto\x12\x1acom.seed.documents.v3alpha\x1a\x1fgoogle/protobuf/timestamp.proto\"\xae\x01\n" +
-	"\x17ListCapabilitiesRequest\x12\x18\n" +
-	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
-	"\x04path\x18\x02 \x01(\tR\x04path\x12)\n" +
-	"\x10ignore_inherited\x18\x03 \x01(\bR\x0fignoreInherited\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
-	"\n" +
-	"page_token\x18\x05 \x01(\tR\tpageToken\"\x8e\x01\n" +
-	"\x18ListCapabilitiesResponse\x12J\n" +
-	"\fcapabilities\x18\x01 \x03(\v2&.com.seed.documents.v3alpha.CapabilityR\fcapabilities\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"|\n" +
-	"\"ListCapabilitiesForDelegateRequest\x12\x1a\n" +
-	"\bdelegate\x18\x01 \x01(\tR\bdelegate\x12\x1b\n" +
-	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
-	"\n" +
-	"page_token\x18\x03 \x01(\tR\tpageToken\"\xfc\x01\n" +
-	"\x17CreateCapabilityRequest\x12(\n" +
-	"\x10signing_key_name\x18\x01 \x01(\tR\x0esigningKeyName\x12\x1a\n" +
-	"\bdelegate\x18\x02 \x01(\tR\bdelegate\x12\x18\n" +
-	"\aaccount\x18\x03 \x01(\tR\aaccount\x12\x12\n" +
-	"\x04path\x18\x04 \x01(\tR\x04path\x124\n" +
-	"\x04role\x18\x05 \x01(\x0e2 .com.seed.documents.v3alpha.RoleR\x04role\x12!\n" +
-	"\fno_recursive\x18\x06 \x01(\bR\vnoRecursive\x12\x14\n" +
-	"\x05label\x18\a \x01(\tR\x05label\"&\n" +
-	"\x14GetCapabilityRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xa2\x02\n" +
-	"\n" +
-	"Capability\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
-	"\x06issuer\x18\x02 \x01(\tR\x06issuer\x12\x1a\n" +
-	"\bdelegate\x18\x03 \x01(\tR\bdelegate\x12\x18\n" +
-	"\aaccount\x18\x04 \x01(\tR\aaccount\x12\x12\n" +
-	"\x04path\x18\x05 \x01(\tR\x04path\x124\n" +
-	"\x04role\x18\x06 \x01(\x0e2 .com.seed.documents.v3alpha.RoleR\x04role\x12\x19\n" +
-	"\bis_exact\x18\a \x01(\bR\aisExact\x12;\n" +
-	"\vcreate_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"createTime\x12\x14\n" +
-	"\x05label\x18\t \x01(\tR\x05label*3\n" +
-	"\x04Role\x12\x14\n" +
-	"\x10ROLE_UNSPECIFIED\x10\x00\x12\n" +
-	"\n" +
-	"\x06WRITER\x10\x02\x12\t\n" +
-	"\x05AGENT\x10\x032\x80\x04\n" +
+func (x *Capability) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+// Revocation of a previously issued capability.
+type Revocation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of this revocation.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ID of the revoked capability.
+	Capability string `protobuf:"bytes,2,opt,name=capability,proto3" json:"capability,omitempty"`
+	// ID of the account that issued the revocation.
+	Issuer string `protobuf:"bytes,3,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	// ID of the account whose capability was revoked.
+	Delegate string `protobuf:"bytes,4,opt,name=delegate,proto3" json:"delegate,omitempty"`
+	// Account ID that the revoked capability granted access to.
+	Account string `protobuf:"bytes,5,opt,name=account,proto3" json:"account,omitempty"`
+	// Path within the account which the revoked capability granted access to.
+	Path string `protobuf:"bytes,6,opt,name=path,proto3" json:"path,omitempty"`
+	// Timestamp when the capability was revoked.
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Revocation) Reset() {
+	*x = Revocation{}
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Revocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Revocation) ProtoMessage() {}
+
+func (x *Revocation) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Revocation.ProtoReflect.Descriptor instead.
+func (*Revocation) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_access_control_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Revocation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Revocation) GetCapability() string {
+	if x != nil {
+		return x.Capability
+	}
+	return ""
+}
+
+func (x *Revocation) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *Revocation) GetDelegate() string {
+	if x != nil {
+		return x.Delegate
+	}
+	return ""
+}
+
+func (x *Revocation) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *Revocation) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Revocation) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+// Request to create an invite.
+type CreateInviteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. Name of the key to use for signing the invite and the capabilities issued for it.
+	SigningKeyName string `protobuf:"bytes,1,opt,name=signing_key_name,json=signingKeyName,proto3" json:"signing_key_name,omitempty"`
+	// Required. Account ID to which the invite gives access.
+	Account string `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	// Required. Path within the account that the invite grants access to.
+	// Empty string means root document.
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	// Required. Role that the capabilities issued for the invite grant.
+	Role Role `protobuf:"varint,4,opt,name=role,proto3,enum=com.seed.documents.v3alpha.Role" json:"role,omitempty"`
+	// Optional. Short, user-provided label used for the invite and the issued capabilities.
+	Label string `protobuf:"bytes,5,opt,name=label,proto3" json:"label,omitempty"`
+	// Optional. Time after which the invite can't be redeemed anymore.
+	ExpireTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	// Optional. Maximum number of invitees that can redeem the invite.
+	// Zero means unlimited.
+	MaxUses       int32 `protobuf:"varint,7,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInviteRequest) Reset() {
+	*x = CreateInviteRequest{}
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInviteRequest) ProtoMessage() {}
+
+func (x *CreateInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInviteRequest.ProtoReflect.Descriptor instead.
+func (*CreateInviteRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_access_control_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateInviteRequest) GetSigningKeyName() string {
+	if x != nil {
+		return x.SigningKeyName
+	}
+	return ""
+}
+
+func (x *CreateInviteRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *CreateInviteRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *CreateInviteRequest) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *CreateInviteRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *CreateInviteRequest) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+func (x *CreateInviteRequest) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+// Request to list invites.
+type ListInvitesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional. Only list invites for this account.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Optional. Number of results per page.
+	// Ignored while pagination is not implemented.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Optional. Token for the page to return.
+	// Ignored while pagination is not implemented.
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitesRequest) Reset() {
+	*x = ListInvitesRequest{}
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitesRequest) ProtoMessage() {}
+
+func (x *ListInvitesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitesRequest.ProtoReflect.Descriptor instead.
+func (*ListInvitesRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_access_control_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListInvitesRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *ListInvitesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListInvitesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Response with invites.
+type ListInvitesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Outstanding invites, most recent first.
+	// Expired and fully used invites are not included.
+	Invites []*Invite `protobuf:"bytes,1,rep,name=invites,proto3" json:"invites,omitempty"`
+	// Optional. Token for fetching the next page.
+	// Empty while pagination is not implemented.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitesResponse) Reset() {
+	*x = ListInvitesResponse{}
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitesResponse) ProtoMessage() {}
+
+func (x *ListInvitesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitesResponse.ProtoReflect.Descriptor instead.
+func (*ListInvitesResponse) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_access_control_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListInvitesResponse) GetInvites() []*Invite {
+	if x != nil {
+		return x.Invites
+	}
+	return nil
+}
+
+func (x *ListInvitesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Request to revoke an invite.
+type RevokeInviteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the invite to revoke.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeInviteRequest) Reset() {
+	*x = RevokeInviteRequest{}
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInviteRequest) ProtoMessage() {}
+
+func (x *RevokeInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeInviteRequest.ProtoReflect.Descriptor instead.
+func (*RevokeInviteRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_access_control_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RevokeInviteRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Request to redeem an invite.
+type RedeemInviteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. Token of the invite.
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Required. Name of the key that will receive the capability.
+	SigningKeyName string `protobuf:"bytes,2,opt,name=signing_key_name,json=signingKeyName,proto3" json:"signing_key_name,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RedeemInviteRequest) Reset() {
+	*x = RedeemInviteRequest{}
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemInviteRequest) ProtoMessage() {}
+
+func (x *RedeemInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemInviteRequest.ProtoReflect.Descriptor instead.
+func (*RedeemInviteRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_access_control_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RedeemInviteRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RedeemInviteRequest) GetSigningKeyName() string {
+	if x != nil {
+		return x.SigningKeyName
+	}
+	return ""
+}
+
+// Invite is a token that can be redeemed for a Capability with the daemon that issued it.
+type Invite struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the invite.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Token to share with the invitees.
+	// Anyone holding it can redeem the invite.
+	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// Account ID that the invite gives access to.
+	Account string `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
+	// Path within the account which the invite grants access to.
+	Path string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	// Role granted by the capabilities issued for the invite.
+	Role Role `protobuf:"varint,5,opt,name=role,proto3,enum=com.seed.documents.v3alpha.Role" json:"role,omitempty"`
+	// Label of the invite.
+	Label string `protobuf:"bytes,6,opt,name=label,proto3" json:"label,omitempty"`
+	// Time after which the invite can't be redeemed, if any.
+	ExpireTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	// Maximum number of redemptions. Zero means unlimited.
+	MaxUses int32 `protobuf:"varint,8,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	// Number of invitees who redeemed the invite.
+	UseCount int32 `protobuf:"varint,9,opt,name=use_count,json=useCount,proto3" json:"use_count,omitempty"`
+	// Time when the invite was created.
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Invite) Reset() {
+	*x = Invite{}
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invite) ProtoMessage() {}
+
+func (x *Invite) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invite.ProtoReflect.Descriptor instead.
+func (*Invite) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_access_control_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Invite) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Invite) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Invite) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *Invite) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Invite) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *Invite) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Invite) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+func (x *Invite) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *Invite) GetUseCount() int32 {
+	if x != nil {
+		return x.UseCount
+	}
+	return 0
+}
+
+func (x *Invite) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+// Request to ask for access to a space.
+type RequestAccessRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. Name of the key that is asking for access, and that will receive the capability.
+	SigningKeyName string `protobuf:"bytes,1,opt,name=signing_key_name,json=signingKeyName,proto3" json:"signing_key_name,omitempty"`
+	// Required. Account ID of the space to request access to.
+	Account string `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	// Optional. Path within the account to request access to.
+	// Empty string means root document.
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	// Optional. Requested role. Defaults to READER.
+	Role Role `protobuf:"varint,4,opt,name=role,proto3,enum=com.seed.documents.v3alpha.Role" json:"role,omitempty"`
+	// Optional. Message for the owner of the space.
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// Required. ID of the peer of the space owner, where the request is sent to.
+	PeerId        string `protobuf:"bytes,6,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestAccessRequest) Reset() {
+	*x = RequestAccessRequest{}
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestAccessRequest) ProtoMessage() {}
+
+func (x *RequestAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestAccessRequest.ProtoReflect.Descriptor instead.
+func (*RequestAccessRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_access_control_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RequestAccessRequest) GetSigningKeyName() string {
+	if x != nil {
+		return x.SigningKeyName
+	}
+	return ""
+}
+
+func (x *RequestAccessRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *RequestAccessRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RequestAccessRequest) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *RequestAccessRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RequestAccessRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+// Request to list access requests.
+type ListAccessRequestsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional. Only list requests for this account.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Optional. Number of results per page.
+	// Ignored while pagination is not implemented.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Optional. Token for the page to return.
+	// Ignored while pagination is not implemented.
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccessRequestsRequest) Reset() {
+	*x = ListAccessRequestsRequest{}
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessRequestsRequest) ProtoMessage() {}
+
+func (x *ListAccessRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccessRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListAccessRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_access_control_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListAccessRequestsRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *ListAccessRequestsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAccessRequestsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Response with access requests.
+type ListAccessRequestsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Pending access requests, most recent first.
+	AccessRequests []*AccessRequest `protobuf:"bytes,1,rep,name=access_requests,json=accessRequests,proto3" json:"access_requests,omitempty"`
+	// Optional. Token for fetching the next page.
+	// Empty while pagination is not implemented.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccessRequestsResponse) Reset() {
+	*x = ListAccessRequestsResponse{}
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessRequestsResponse) ProtoMessage() {}
+
+func (x *ListAccessRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccessRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListAccessRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_access_control_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListAccessRequestsResponse) GetAccessRequests() []*AccessRequest {
+	if x != nil {
+		return x.AccessRequests
+	}
+	return nil
+}
+
+func (x *ListAccessRequestsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Request to approve an access request.
+type ApproveAccessRequestRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the access request.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Required. Name of the key to use for signing the capability.
+	// Must be the owner of the requested space.
+	SigningKeyName string `protobuf:"bytes,2,opt,name=signing_key_name,json=signingKeyName,proto3" json:"signing_key_name,omitempty"`
+	// Optional. Role to grant instead of the requested one.
+	Role          Role `protobuf:"varint,3,opt,name=role,proto3,enum=com.seed.documents.v3alpha.Role" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveAccessRequestRequest) Reset() {
+	*x = ApproveAccessRequestRequest{}
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveAccessRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveAccessRequestRequest) ProtoMessage() {}
+
+func (x *ApproveAccessRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveAccessRequestRequest.ProtoReflect.Descriptor instead.
+func (*ApproveAccessRequestRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_access_control_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ApproveAccessRequestRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApproveAccessRequestRequest) GetSigningKeyName() string {
+	if x != nil {
+		return x.SigningKeyName
+	}
+	return ""
+}
+
+func (x *ApproveAccessRequestRequest) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+// Request to deny an access request.
+type DenyAccessRequestRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the access request.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DenyAccessRequestRequest) Reset() {
+	*x = DenyAccessRequestRequest{}
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DenyAccessRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DenyAccessRequestRequest) ProtoMessage() {}
+
+func (x *DenyAccessRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DenyAccessRequestRequest.ProtoReflect.Descriptor instead.
+func (*DenyAccessRequestRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_access_control_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DenyAccessRequestRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Request of some account to be granted access to a space.
+type AccessRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the access request.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Account ID of the space the access is requested for.
+	Account string `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	// Path within the account the access is requested for.
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	// Requested role.
+	Role Role `protobuf:"varint,4,opt,name=role,proto3,enum=com.seed.documents.v3alpha.Role" json:"role,omitempty"`
+	// Account ID that is requesting access.
+	Requester string `protobuf:"bytes,5,opt,name=requester,proto3" json:"requester,omitempty"`
+	// Message for the owner of the space.
+	Message string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	// Time when the request was created.
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessRequest) Reset() {
+	*x = AccessRequest{}
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessRequest) ProtoMessage() {}
+
+func (x *AccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_access_control_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessRequest.ProtoReflect.Descriptor instead.
+func (*AccessRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_access_control_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AccessRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AccessRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *AccessRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *AccessRequest) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *AccessRequest) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+func (x *AccessRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AccessRequest) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+var File_documents_v3alpha_access_control_proto protoreflect.FileDescriptor
+
+const file_documents_v3alpha_access_control_proto_rawDesc = "" +
+	"\n" +
+	"&documents/v3alpha/access_control.proto\x12\x1acom.seed.documents.v3alpha\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xae\x01\n" +
+	"\x17ListCapabilitiesRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12)\n" +
+	"\x10ignore_inherited\x18\x03 \x01(\bR\x0fignoreInherited\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\"\x8e\x01\n" +
+	"\x18ListCapabilitiesResponse\x12J\n" +
+	"\fcapabilities\x18\x01 \x03(\v2&.com.seed.documents.v3alpha.CapabilityR\fcapabilities\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"|\n" +
+	"\"ListCapabilitiesForDelegateRequest\x12\x1a\n" +
+	"\bdelegate\x18\x01 \x01(\tR\bdelegate\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\xf4\x02\n" +
+	"\x17CreateCapabilityRequest\x12(\n" +
+	"\x10signing_key_name\x18\x01 \x01(\tR\x0esigningKeyName\x12\x1a\n" +
+	"\bdelegate\x18\x02 \x01(\tR\bdelegate\x12\x18\n" +
+	"\aaccount\x18\x03 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x124\n" +
+	"\x04role\x18\x05 \x01(\x0e2 .com.seed.documents.v3alpha.RoleR\x04role\x12!\n" +
+	"\fno_recursive\x18\x06 \x01(\bR\vnoRecursive\x12\x14\n" +
+	"\x05label\x18\a \x01(\tR\x05label\x129\n" +
+	"\n" +
+	"not_before\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tnotBefore\x12;\n" +
+	"\vexpire_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\"&\n" +
+	"\x14GetCapabilityRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"S\n" +
+	"\x17RevokeCapabilityRequest\x12(\n" +
+	"\x10signing_key_name\x18\x01 \x01(\tR\x0esigningKeyName\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\x9a\x03\n" +
+	"\n" +
+	"Capability\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06issuer\x18\x02 \x01(\tR\x06issuer\x12\x1a\n" +
+	"\bdelegate\x18\x03 \x01(\tR\bdelegate\x12\x18\n" +
+	"\aaccount\x18\x04 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x05 \x01(\tR\x04path\x124\n" +
+	"\x04role\x18\x06 \x01(\x0e2 .com.seed.documents.v3alpha.RoleR\x04role\x12\x19\n" +
+	"\bis_exact\x18\a \x01(\bR\aisExact\x12;\n" +
+	"\vcreate_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12\x14\n" +
+	"\x05label\x18\t \x01(\tR\x05label\x129\n" +
+	"\n" +
+	"not_before\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tnotBefore\x12;\n" +
+	"\vexpire_time\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\"\xdb\x01\n" +
+	"\n" +
+	"Revocation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
+	"\n" +
+	"capability\x18\x02 \x01(\tR\n" +
+	"capability\x12\x16\n" +
+	"\x06issuer\x18\x03 \x01(\tR\x06issuer\x12\x1a\n" +
+	"\bdelegate\x18\x04 \x01(\tR\bdelegate\x12\x18\n" +
+	"\aaccount\x18\x05 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x06 \x01(\tR\x04path\x12;\n" +
+	"\vcreate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\"\x91\x02\n" +
+	"\x13CreateInviteRequest\x12(\n" +
+	"\x10signing_key_name\x18\x01 \x01(\tR\x0esigningKeyName\x12\x18\n" +
+	"\aaccount\x18\x02 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x124\n" +
+	"\x04role\x18\x04 \x01(\x0e2 .com.seed.documents.v3alpha.RoleR\x04role\x12\x14\n" +
+	"\x05label\x18\x05 \x01(\tR\x05label\x12;\n" +
+	"\vexpire_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\x12\x19\n" +
+	"\bmax_uses\x18\a \x01(\x05R\amaxUses\"j\n" +
+	"\x12ListInvitesRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"{\n" +
+	"\x13ListInvitesResponse\x12<\n" +
+	"\ainvites\x18\x01 \x03(\v2\".com.seed.documents.v3alpha.InviteR\ainvites\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"%\n" +
+	"\x13RevokeInviteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"U\n" +
+	"\x13RedeemInviteRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12(\n" +
+	"\x10signing_key_name\x18\x02 \x01(\tR\x0esigningKeyName\"\xda\x02\n" +
+	"\x06Invite\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x18\n" +
+	"\aaccount\x18\x03 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x124\n" +
+	"\x04role\x18\x05 \x01(\x0e2 .com.seed.documents.v3alpha.RoleR\x04role\x12\x14\n" +
+	"\x05label\x18\x06 \x01(\tR\x05label\x12;\n" +
+	"\vexpire_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\x12\x19\n" +
+	"\bmax_uses\x18\b \x01(\x05R\amaxUses\x12\x1b\n" +
+	"\tuse_count\x18\t \x01(\x05R\buseCount\x12;\n" +
+	"\vcreate_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\"\xd7\x01\n" +
+	"\x14RequestAccessRequest\x12(\n" +
+	"\x10signing_key_name\x18\x01 \x01(\tR\x0esigningKeyName\x12\x18\n" +
+	"\aaccount\x18\x02 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x124\n" +
+	"\x04role\x18\x04 \x01(\x0e2 .com.seed.documents.v3alpha.RoleR\x04role\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x17\n" +
+	"\apeer_id\x18\x06 \x01(\tR\x06peerId\"q\n" +
+	"\x19ListAccessRequestsRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x98\x01\n" +
+	"\x1aListAccessRequestsResponse\x12R\n" +
+	"\x0faccess_requests\x18\x01 \x03(\v2).com.seed.documents.v3alpha.AccessRequestR\x0eaccessRequests\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x8d\x01\n" +
+	"\x1bApproveAccessRequestRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\x10signing_key_name\x18\x02 \x01(\tR\x0esigningKeyName\x124\n" +
+	"\x04role\x18\x03 \x01(\x0e2 .com.seed.documents.v3alpha.RoleR\x04role\"*\n" +
+	"\x18DenyAccessRequestRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xf8\x01\n" +
+	"\rAccessRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aaccount\x18\x02 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x124\n" +
+	"\x04role\x18\x04 \x01(\x0e2 .com.seed.documents.v3alpha.RoleR\x04role\x12\x1c\n" +
+	"\trequester\x18\x05 \x01(\tR\trequester\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x12;\n" +
+	"\vcreate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime*N\n" +
+	"\x04Role\x12\x14\n" +
+	"\x10ROLE_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06WRITER\x10\x02\x12\t\n" +
+	"\x05AGENT\x10\x03\x12\n" +
+	"\n" +
+	"\x06READER\x10\x04\x12\r\n" +
+	"\tCOMMENTER\x10\x052\xd8\v\n" +
 	"\rAccessControl\x12}\n" +
 	"\x10ListCapabilities\x123.com.seed.documents.v3alpha.ListCapabilitiesRequest\x1a4.com.seed.documents.v3alpha.ListCapabilitiesResponse\x12\x93\x01\n" +
 	"\x1bListCapabilitiesForDelegate\x12>.com.seed.documents.v3alpha.ListCapabilitiesForDelegateRequest\x1a4.com.seed.documents.v3alpha.ListCapabilitiesResponse\x12o\n" +
 	"\x10CreateCapability\x123.com.seed.documents.v3alpha.CreateCapabilityRequest\x1a&.com.seed.documents.v3alpha.Capability\x12i\n" +
-	"\rGetCapability\x120.com.seed.documents.v3alpha.GetCapabilityRequest\x1a&.com.seed.documents.v3alpha.CapabilityB3Z1seed/backend/genproto/documents/v3alpha;documentsb\x06proto3"
+	"\rGetCapability\x120.com.seed.documents.v3alpha.GetCapabilityRequest\x1a&.com.seed.documents.v3alpha.Capability\x12o\n" +
+	"\x10RevokeCapability\x123.com.seed.documents.v3alpha.RevokeCapabilityRequest\x1a&.com.seed.documents.v3alpha.Revocation\x12c\n" +
+	"\fCreateInvite\x12/.com.seed.documents.v3alpha.CreateInviteRequest\x1a\".com.seed.documents.v3alpha.Invite\x12n\n" +
+	"\vListInvites\x12..com.seed.documents.v3alpha.ListInvitesRequest\x1a/.com.seed.documents.v3alpha.ListInvitesResponse\x12W\n" +
+	"\fRevokeInvite\x12/.com.seed.documents.v3alpha.RevokeInviteRequest\x1a\x16.google.protobuf.Empty\x12g\n" +
+	"\fRedeemInvite\x12/.com.seed.documents.v3alpha.RedeemInviteRequest\x1a&.com.seed.documents.v3alpha.Capability\x12l\n" +
+	"\rRequestAccess\x120.com.seed.documents.v3alpha.RequestAccessRequest\x1a).com.seed.documents.v3alpha.AccessRequest\x12\x83\x01\n" +
+	"\x12ListAccessRequests\x125.com.seed.documents.v3alpha.ListAccessRequestsRequest\x1a6.com.seed.documents.v3alpha.ListAccessRequestsResponse\x12w\n" +
+	"\x14ApproveAccessRequest\x127.com.seed.documents.v3alpha.ApproveAccessRequestRequest\x1a&.com.seed.documents.v3alpha.Capability\x12a\n" +
+	"\x11DenyAccessRequest\x124.com.seed.documents.v3alpha.DenyAccessRequestRequest\x1a\x16.google.protobuf.EmptyB3Z1seed/backend/genproto/documents/v3alpha;documentsb\x06proto3"
 
 var (
 	file_documents_v3alpha_access_control_proto_rawDescOnce sync.Once
@@ -617,7 +1801,7 @@ func file_documents_v3alpha_access_control_proto_rawDescGZIP() []byte {
 }
 
 var file_documents_v3alpha_access_control_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_documents_v3alpha_access_control_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_documents_v3alpha_access_control_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_documents_v3alpha_access_control_proto_goTypes = []any{
 	(Role)(0),                                  // 0: com.seed.documents.v3alpha.Role
 	(*ListCapabilitiesRequest)(nil),            // 1: com.seed.documents.v3alpha.ListCapabilitiesRequest
@@ -625,27 +1809,76 @@ var file_documents_v3alpha_access_control_proto_goTypes = []any{
 	(*ListCapabilitiesForDelegateRequest)(nil), // 3: com.seed.documents.v3alpha.ListCapabilitiesForDelegateRequest
 	(*CreateCapabilityRequest)(nil),            // 4: com.seed.documents.v3alpha.CreateCapabilityRequest
 	(*GetCapabilityRequest)(nil),               // 5: com.seed.documents.v3alpha.GetCapabilityRequest
-	(*Capability)(nil),                         // 6: com.seed.documents.v3alpha.Capability
-	(*timestamppb.Timestamp)(nil),              // 7: google.protobuf.Timestamp
+	(*RevokeCapabilityRequest)(nil),            // 6: com.seed.documents.v3alpha.RevokeCapabilityRequest
+	(*Capability)(nil),                         // 7: com.seed.documents.v3alpha.Capability
+	(*Revocation)(nil),                         // 8: com.seed.documents.v3alpha.Revocation
+	(*CreateInviteRequest)(nil),                // 9: com.seed.documents.v3alpha.CreateInviteRequest
+	(*ListInvitesRequest)(nil),                 // 10: com.seed.documents.v3alpha.ListInvitesRequest
+	(*ListInvitesResponse)(nil),                // 11: com.seed.documents.v3alpha.ListInvitesResponse
+	(*RevokeInviteRequest)(nil),                // 12: com.seed.documents.v3alpha.RevokeInviteRequest
+	(*RedeemInviteRequest)(nil),                // 13: com.seed.documents.v3alpha.RedeemInviteRequest
+	(*Invite)(nil),                             // 14: com.seed.documents.v3alpha.Invite
+	(*RequestAccessRequest)(nil),               // 15: com.seed.documents.v3alpha.RequestAccessRequest
+	(*ListAccessRequestsRequest)(nil),          // 16: com.seed.documents.v3alpha.ListAccessRequestsRequest
+	(*ListAccessRequestsResponse)(nil),         // 17: com.seed.documents.v3alpha.ListAccessRequestsResponse
+	(*ApproveAccessRequestRequest)(nil),        // 18: com.seed.documents.v3alpha.ApproveAccessRequestRequest
+	(*DenyAccessRequestRequest)(nil),           // 19: com.seed.documents.v3alpha.DenyAccessRequestRequest
+	(*AccessRequest)(nil),                      // 20: com.seed.documents.v3alpha.AccessRequest
+	(*timestamppb.Timestamp)(nil),              // 21: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                      // 22: google.protobuf.Empty
 }
 var file_documents_v3alpha_access_control_proto_depIdxs = []int32{
-	6, // 0: com.seed.documents.v3alpha.ListCapabilitiesResponse.capabilities:type_name -> com.seed.documents.v3alpha.Capability
-	0, // 1: com.seed.documents.v3alpha.CreateCapabilityRequest.role:type_name -> com.seed.documents.v3alpha.Role
-	0, // 2: com.seed.documents.v3alpha.Capability.role:type_name -> com.seed.documents.v3alpha.Role
-	7, // 3: com.seed.documents.v3alpha.Capability.create_time:type_name -> google.protobuf.Timestamp
-	1, // 4: com.seed.documents.v3alpha.AccessControl.ListCapabilities:input_type -> com.seed.documents.v3alpha.ListCapabilitiesRequest
-	3, // 5: com.seed.documents.v3alpha.AccessControl.ListCapabilitiesForDelegate:input_type -> com.seed.documents.v3alpha.ListCapabilitiesForDelegateRequest
-	4, // 6: com.seed.documents.v3alpha.AccessControl.CreateCapability:input_type -> com.seed.documents.v3alpha.CreateCapabilityRequest
-	5, // 7: com.seed.documents.v3alpha.AccessControl.GetCapability:input_type -> com.seed.documents.v3alpha.GetCapabilityRequest
-	2, // 8: com.seed.documents.v3alpha.AccessControl.ListCapabilities:output_type -> com.seed.documents.v3alpha.ListCapabilitiesResponse
-	2, // 9: com.seed.documents.v3alpha.AccessControl.ListCapabilitiesForDelegate:output_type -> com.seed.documents.v3alpha.ListCapabilitiesResponse
-	6, // 10: com.seed.documents.v3alpha.AccessControl.CreateCapability:output_type -> com.seed.documents.v3alpha.Capability
-	6, // 11: com.seed.documents.v3alpha.AccessControl.GetCapability:output_type -> com.seed.documents.v3alpha.Capability
-	8, // [8:12] is the sub-list for method output_type
-	4, // [4:8] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	7,  // 0: com.seed.documents.v3alpha.ListCapabilitiesResponse.capabilities:type_name -> com.seed.documents.v3alpha.Capability
+	0,  // 1: com.seed.documents.v3alpha.CreateCapabilityRequest.role:type_name -> com.seed.documents.v3alpha.Role
+	21, // 2: com.seed.documents.v3alpha.CreateCapabilityRequest.not_before:type_name -> google.protobuf.Timestamp
+	21, // 3: com.seed.documents.v3alpha.CreateCapabilityRequest.expire_time:type_name -> google.protobuf.Timestamp
+	0,  // 4: com.seed.documents.v3alpha.Capability.role:type_name -> com.seed.documents.v3alpha.Role
+	21, // 5: com.seed.documents.v3alpha.Capability.create_time:type_name -> google.protobuf.Timestamp
+	21, // 6: com.seed.documents.v3alpha.Capability.not_before:type_name -> google.protobuf.Timestamp
+	21, // 7: com.seed.documents.v3alpha.Capability.expire_time:type_name -> google.protobuf.Timestamp
+	21, // 8: com.seed.documents.v3alpha.Revocation.create_time:type_name -> google.protobuf.Timestamp
+	0,  // 9: com.seed.documents.v3alpha.CreateInviteRequest.role:type_name -> com.seed.documents.v3alpha.Role
+	21, // 10: com.seed.documents.v3alpha.CreateInviteRequest.expire_time:type_name -> google.protobuf.Timestamp
+	14, // 11: com.seed.documents.v3alpha.ListInvitesResponse.invites:type_name -> com.seed.documents.v3alpha.Invite
+	0,  // 12: com.seed.documents.v3alpha.Invite.role:type_name -> com.seed.documents.v3alpha.Role
+	21, // 13: com.seed.documents.v3alpha.Invite.expire_time:type_name -> google.protobuf.Timestamp
+	21, // 14: com.seed.documents.v3alpha.Invite.create_time:type_name -> google.protobuf.Timestamp
+	0,  // 15: com.seed.documents.v3alpha.RequestAccessRequest.role:type_name -> com.seed.documents.v3alpha.Role
+	20, // 16: com.seed.documents.v3alpha.ListAccessRequestsResponse.access_requests:type_name -> com.seed.documents.v3alpha.AccessRequest
+	0,  // 17: com.seed.documents.v3alpha.ApproveAccessRequestRequest.role:type_name -> com.seed.documents.v3alpha.Role
+	0,  // 18: com.seed.documents.v3alpha.AccessRequest.role:type_name -> com.seed.documents.v3alpha.Role
+	21, // 19: com.seed.documents.v3alpha.AccessRequest.create_time:type_name -> google.protobuf.Timestamp
+	1,  // 20: com.seed.documents.v3alpha.AccessControl.ListCapabilities:input_type -> com.seed.documents.v3alpha.ListCapabilitiesRequest
+	3,  // 21: com.seed.documents.v3alpha.AccessControl.ListCapabilitiesForDelegate:input_type -> com.seed.documents.v3alpha.ListCapabilitiesForDelegateRequest
+	4,  // 22: com.seed.documents.v3alpha.AccessControl.CreateCapability:input_type -> com.seed.documents.v3alpha.CreateCapabilityRequest
+	5,  // 23: com.seed.documents.v3alpha.AccessControl.GetCapability:input_type -> com.seed.documents.v3alpha.GetCapabilityRequest
+	6,  // 24: com.seed.documents.v3alpha.AccessControl.RevokeCapability:input_type -> com.seed.documents.v3alpha.RevokeCapabilityRequest
+	9,  // 25: com.seed.documents.v3alpha.AccessControl.CreateInvite:input_type -> com.seed.documents.v3alpha.CreateInviteRequest
+	10, // 26: com.seed.documents.v3alpha.AccessControl.ListInvites:input_type -> com.seed.documents.v3alpha.ListInvitesRequest
+	12, // 27: com.seed.documents.v3alpha.AccessControl.RevokeInvite:input_type -> com.seed.documents.v3alpha.RevokeInviteRequest
+	13, // 28: com.seed.documents.v3alpha.AccessControl.RedeemInvite:input_type -> com.seed.documents.v3alpha.RedeemInviteRequest
+	15, // 29: com.seed.documents.v3alpha.AccessControl.RequestAccess:input_type -> com.seed.documents.v3alpha.RequestAccessRequest
+	16, // 30: com.seed.documents.v3alpha.AccessControl.ListAccessRequests:input_type -> com.seed.documents.v3alpha.ListAccessRequestsRequest
+	18, // 31: com.seed.documents.v3alpha.AccessControl.ApproveAccessRequest:input_type -> com.seed.documents.v3alpha.ApproveAccessRequestRequest
+	19, // 32: com.seed.documents.v3alpha.AccessControl.DenyAccessRequest:input_type -> com.seed.documents.v3alpha.DenyAccessRequestRequest
+	2,  // 33: com.seed.documents.v3alpha.AccessControl.ListCapabilities:output_type -> com.seed.documents.v3alpha.ListCapabilitiesResponse
+	2,  // 34: com.seed.documents.v3alpha.AccessControl.ListCapabilitiesForDelegate:output_type -> com.seed.documents.v3alpha.ListCapabilitiesResponse
+	7,  // 35: com.seed.documents.v3alpha.AccessControl.CreateCapability:output_type -> com.seed.documents.v3alpha.Capability
+	7,  // 36: com.seed.documents.v3alpha.AccessControl.GetCapability:output_type -> com.seed.documents.v3alpha.Capability
+	8,  // 37: com.seed.documents.v3alpha.AccessControl.RevokeCapability:output_type -> com.seed.documents.v3alpha.Revocation
+	14, // 38: com.seed.documents.v3alpha.AccessControl.CreateInvite:output_type -> com.seed.documents.v3alpha.Invite
+	11, // 39: com.seed.documents.v3alpha.AccessControl.ListInvites:output_type -> com.seed.documents.v3alpha.ListInvitesResponse
+	22, // 40: com.seed.documents.v3alpha.AccessControl.RevokeInvite:output_type -> google.protobuf.Empty
+	7,  // 41: com.seed.documents.v3alpha.AccessControl.RedeemInvite:output_type -> com.seed.documents.v3alpha.Capability
+	20, // 42: com.seed.documents.v3alpha.AccessControl.RequestAccess:output_type -> com.seed.documents.v3alpha.AccessRequest
+	17, // 43: com.seed.documents.v3alpha.AccessControl.ListAccessRequests:output_type -> com.seed.documents.v3alpha.ListAccessRequestsResponse
+	7,  // 44: com.seed.documents.v3alpha.AccessControl.ApproveAccessRequest:output_type -> com.seed.documents.v3alpha.Capability
+	22, // 45: com.seed.documents.v3alpha.AccessControl.DenyAccessRequest:output_type -> google.protobuf.Empty
+	33, // [33:46] is the sub-list for method output_type
+	20, // [20:33] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_documents_v3alpha_access_control_proto_init() }
@@ -659,7 +1892,7 @@ func file_documents_v3alpha_access_control_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_documents_v3alpha_access_control_proto_rawDesc), len(file_documents_v3alpha_access_control_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   6,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
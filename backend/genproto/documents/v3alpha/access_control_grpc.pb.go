@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -23,6 +24,15 @@ const (
 	AccessControl_ListCapabilitiesForDelegate_FullMethodName = "/com.seed.documents.v3alpha.AccessControl/ListCapabilitiesForDelegate"
 	AccessControl_CreateCapability_FullMethodName            = "/com.seed.documents.v3alpha.AccessControl/CreateCapability"
 	AccessControl_GetCapability_FullMethodName               = "/com.seed.documents.v3alpha.AccessControl/GetCapability"
+	AccessControl_RevokeCapability_FullMethodName            = "/com.seed.documents.v3alpha.AccessControl/RevokeCapability"
+	AccessControl_CreateInvite_FullMethodName                = "/com.seed.documents.v3alpha.AccessControl/CreateInvite"
+	AccessControl_ListInvites_FullMethodName                 = "/com.seed.documents.v3alpha.AccessControl/ListInvites"
+	AccessControl_RevokeInvite_FullMethodName                = "/com.seed.documents.v3alpha.AccessControl/RevokeInvite"
+	AccessControl_RedeemInvite_FullMethodName                = "/com.seed.documents.v3alpha.AccessControl/RedeemInvite"
+	AccessControl_RequestAccess_FullMethodName               = "/com.seed.documents.v3alpha.AccessControl/RequestAccess"
+	AccessControl_ListAccessRequests_FullMethodName          = "/com.seed.documents.v3alpha.AccessControl/ListAccessRequests"
+	AccessControl_ApproveAccessRequest_FullMethodName        = "/com.seed.documents.v3alpha.AccessControl/ApproveAccessRequest"
+	AccessControl_DenyAccessRequest_FullMethodName           = "/com.seed.documents.v3alpha.AccessControl/DenyAccessRequest"
 )
 
 // AccessControlClient is the client API for AccessControl service.
@@ -39,6 +49,28 @@ type AccessControlClient interface {
 	CreateCapability(ctx context.Context, in *CreateCapabilityRequest, opts ...grpc.CallOption) (*Capability, error)
 	// Get a single capability by ID.
 	GetCapability(ctx context.Context, in *GetCapabilityRequest, opts ...grpc.CallOption) (*Capability, error)
+	// Revokes a previously issued capability.
+	// Blobs signed by the delegate after the revocation are no longer authorized by the capability.
+	RevokeCapability(ctx context.Context, in *RevokeCapabilityRequest, opts ...grpc.CallOption) (*Revocation, error)
+	// Creates an invite which anyone holding its token can redeem for a Capability,
+	// without knowing the key of the invitee up front.
+	// Invites are kept by the daemon of the issuer, which must be reachable by the invitees to redeem them.
+	CreateInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*Invite, error)
+	// Lists outstanding invites issued by this daemon.
+	ListInvites(ctx context.Context, in *ListInvitesRequest, opts ...grpc.CallOption) (*ListInvitesResponse, error)
+	// Revokes an invite, so it can't be redeemed anymore.
+	// Capabilities already issued for the invite are not affected, and must be revoked separately.
+	RevokeInvite(ctx context.Context, in *RevokeInviteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Redeems an invite token with the daemon of the issuer, and stores the issued Capability locally.
+	RedeemInvite(ctx context.Context, in *RedeemInviteRequest, opts ...grpc.CallOption) (*Capability, error)
+	// Sends a request for access to a space to the daemon of its owner.
+	RequestAccess(ctx context.Context, in *RequestAccessRequest, opts ...grpc.CallOption) (*AccessRequest, error)
+	// Lists pending access requests received by this daemon.
+	ListAccessRequests(ctx context.Context, in *ListAccessRequestsRequest, opts ...grpc.CallOption) (*ListAccessRequestsResponse, error)
+	// Approves an access request, issuing a Capability for the requester.
+	ApproveAccessRequest(ctx context.Context, in *ApproveAccessRequestRequest, opts ...grpc.CallOption) (*Capability, error)
+	// Denies an access request.
+	DenyAccessRequest(ctx context.Context, in *DenyAccessRequestRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type accessControlClient struct {
@@ -89,6 +121,96 @@ func (c *accessControlClient) GetCapability(ctx context.Context, in *GetCapabili
 	return out, nil
 }
 
+func (c *accessControlClient) RevokeCapability(ctx context.Context, in *RevokeCapabilityRequest, opts ...grpc.CallOption) (*Revocation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Revocation)
+	err := c.cc.Invoke(ctx, AccessControl_RevokeCapability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlClient) CreateInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*Invite, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Invite)
+	err := c.cc.Invoke(ctx, AccessControl_CreateInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlClient) ListInvites(ctx context.Context, in *ListInvitesRequest, opts ...grpc.CallOption) (*ListInvitesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInvitesResponse)
+	err := c.cc.Invoke(ctx, AccessControl_ListInvites_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlClient) RevokeInvite(ctx context.Context, in *RevokeInviteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AccessControl_RevokeInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlClient) RedeemInvite(ctx context.Context, in *RedeemInviteRequest, opts ...grpc.CallOption) (*Capability, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Capability)
+	err := c.cc.Invoke(ctx, AccessControl_RedeemInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlClient) RequestAccess(ctx context.Context, in *RequestAccessRequest, opts ...grpc.CallOption) (*AccessRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccessRequest)
+	err := c.cc.Invoke(ctx, AccessControl_RequestAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlClient) ListAccessRequests(ctx context.Context, in *ListAccessRequestsRequest, opts ...grpc.CallOption) (*ListAccessRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccessRequestsResponse)
+	err := c.cc.Invoke(ctx, AccessControl_ListAccessRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlClient) ApproveAccessRequest(ctx context.Context, in *ApproveAccessRequestRequest, opts ...grpc.CallOption) (*Capability, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Capability)
+	err := c.cc.Invoke(ctx, AccessControl_ApproveAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessControlClient) DenyAccessRequest(ctx context.Context, in *DenyAccessRequestRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AccessControl_DenyAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AccessControlServer is the server API for AccessControl service.
 // All implementations should embed UnimplementedAccessControlServer
 // for forward compatibility.
@@ -103,6 +225,28 @@ type AccessControlServer interface {
 	CreateCapability(context.Context, *CreateCapabilityRequest) (*Capability, error)
 	// Get a single capability by ID.
 	GetCapability(context.Context, *GetCapabilityRequest) (*Capability, error)
+	// Revokes a previously issued capability.
+	// Blobs signed by the delegate after the revocation are no longer authorized by the capability.
+	RevokeCapability(context.Context, *RevokeCapabilityRequest) (*Revocation, error)
+	// Creates an invite which anyone holding its token can redeem for a Capability,
+	// without knowing the key of the invitee up front.
+	// Invites are kept by the daemon of the issuer, which must be reachable by the invitees to redeem them.
+	CreateInvite(context.Context, *CreateInviteRequest) (*Invite, error)
+	// Lists outstanding invites issued by this daemon.
+	ListInvites(context.Context, *ListInvitesRequest) (*ListInvitesResponse, error)
+	// Revokes an invite, so it can't be redeemed anymore.
+	// Capabilities already issued for the invite are not affected, and must be revoked separately.
+	RevokeInvite(context.Context, *RevokeInviteRequest) (*emptypb.Empty, error)
+	// Redeems an invite token with the daemon of the issuer, and stores the issued Capability locally.
+	RedeemInvite(context.Context, *RedeemInviteRequest) (*Capability, error)
+	// Sends a request for access to a space to the daemon of its owner.
+	RequestAccess(context.Context, *RequestAccessRequest) (*AccessRequest, error)
+	// Lists pending access requests received by this daemon.
+	ListAccessRequests(context.Context, *ListAccessRequestsRequest) (*ListAccessRequestsResponse, error)
+	// Approves an access request, issuing a Capability for the requester.
+	ApproveAccessRequest(context.Context, *ApproveAccessRequestRequest) (*Capability, error)
+	// Denies an access request.
+	DenyAccessRequest(context.Context, *DenyAccessRequestRequest) (*emptypb.Empty, error)
 }
 
 // UnimplementedAccessControlServer should be embedded to have
@@ -124,6 +268,33 @@ func (UnimplementedAccessControlServer) CreateCapability(context.Context, *Creat
 func (UnimplementedAccessControlServer) GetCapability(context.Context, *GetCapabilityRequest) (*Capability, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCapability not implemented")
 }
+func (UnimplementedAccessControlServer) RevokeCapability(context.Context, *RevokeCapabilityRequest) (*Revocation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeCapability not implemented")
+}
+func (UnimplementedAccessControlServer) CreateInvite(context.Context, *CreateInviteRequest) (*Invite, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInvite not implemented")
+}
+func (UnimplementedAccessControlServer) ListInvites(context.Context, *ListInvitesRequest) (*ListInvitesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvites not implemented")
+}
+func (UnimplementedAccessControlServer) RevokeInvite(context.Context, *RevokeInviteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeInvite not implemented")
+}
+func (UnimplementedAccessControlServer) RedeemInvite(context.Context, *RedeemInviteRequest) (*Capability, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemInvite not implemented")
+}
+func (UnimplementedAccessControlServer) RequestAccess(context.Context, *RequestAccessRequest) (*AccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestAccess not implemented")
+}
+func (UnimplementedAccessControlServer) ListAccessRequests(context.Context, *ListAccessRequestsRequest) (*ListAccessRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessRequests not implemented")
+}
+func (UnimplementedAccessControlServer) ApproveAccessRequest(context.Context, *ApproveAccessRequestRequest) (*Capability, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveAccessRequest not implemented")
+}
+func (UnimplementedAccessControlServer) DenyAccessRequest(context.Context, *DenyAccessRequestRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DenyAccessRequest not implemented")
+}
 func (UnimplementedAccessControlServer) testEmbeddedByValue() {}
 
 // UnsafeAccessControlServer may be embedded to opt out of forward compatibility for this service.
@@ -216,6 +387,168 @@ func _AccessControl_GetCapability_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AccessControl_RevokeCapability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCapabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlServer).RevokeCapability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessControl_RevokeCapability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlServer).RevokeCapability(ctx, req.(*RevokeCapabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControl_CreateInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlServer).CreateInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessControl_CreateInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlServer).CreateInvite(ctx, req.(*CreateInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControl_ListInvites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvitesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlServer).ListInvites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessControl_ListInvites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlServer).ListInvites(ctx, req.(*ListInvitesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControl_RevokeInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlServer).RevokeInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessControl_RevokeInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlServer).RevokeInvite(ctx, req.(*RevokeInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControl_RedeemInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlServer).RedeemInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessControl_RedeemInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlServer).RedeemInvite(ctx, req.(*RedeemInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControl_RequestAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlServer).RequestAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessControl_RequestAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlServer).RequestAccess(ctx, req.(*RequestAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControl_ListAccessRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccessRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlServer).ListAccessRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessControl_ListAccessRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlServer).ListAccessRequests(ctx, req.(*ListAccessRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControl_ApproveAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveAccessRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlServer).ApproveAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessControl_ApproveAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlServer).ApproveAccessRequest(ctx, req.(*ApproveAccessRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessControl_DenyAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DenyAccessRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlServer).DenyAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessControl_DenyAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlServer).DenyAccessRequest(ctx, req.(*DenyAccessRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AccessControl_ServiceDesc is the grpc.ServiceDesc for AccessControl service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -239,6 +572,42 @@ var AccessControl_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCapability",
 			Handler:    _AccessControl_GetCapability_Handler,
 		},
+		{
+			MethodName: "RevokeCapability",
+			Handler:    _AccessControl_RevokeCapability_Handler,
+		},
+		{
+			MethodName: "CreateInvite",
+			Handler:    _AccessControl_CreateInvite_Handler,
+		},
+		{
+			MethodName: "ListInvites",
+			Handler:    _AccessControl_ListInvites_Handler,
+		},
+		{
+			MethodName: "RevokeInvite",
+			Handler:    _AccessControl_RevokeInvite_Handler,
+		},
+		{
+			MethodName: "RedeemInvite",
+			Handler:    _AccessControl_RedeemInvite_Handler,
+		},
+		{
+			MethodName: "RequestAccess",
+			Handler:    _AccessControl_RequestAccess_Handler,
+		},
+		{
+			MethodName: "ListAccessRequests",
+			Handler:    _AccessControl_ListAccessRequests_Handler,
+		},
+		{
+			MethodName: "ApproveAccessRequest",
+			Handler:    _AccessControl_ApproveAccessRequest_Handler,
+		},
+		{
+			MethodName: "DenyAccessRequest",
+			Handler:    _AccessControl_DenyAccessRequest_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "documents/v3alpha/access_control.proto",
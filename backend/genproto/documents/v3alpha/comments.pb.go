@@ -23,6 +23,63 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Status of a comment anchor in the latest version of the target document.
+type CommentAnchorStatus int32
+
+const (
+	CommentAnchorStatus_COMMENT_ANCHOR_STATUS_UNSPECIFIED CommentAnchorStatus = 0
+	// The anchored block and text are the same as in the target version.
+	CommentAnchorStatus_COMMENT_ANCHOR_STATUS_VALID CommentAnchorStatus = 1
+	// The anchored content still exists, but the block was moved, or the text was edited.
+	// Resolved range points to the anchored text in the latest version.
+	CommentAnchorStatus_COMMENT_ANCHOR_STATUS_MOVED CommentAnchorStatus = 2
+	// The anchored block or all of the anchored text was deleted.
+	CommentAnchorStatus_COMMENT_ANCHOR_STATUS_ORPHANED CommentAnchorStatus = 3
+)
+
+// Enum value maps for CommentAnchorStatus.
+var (
+	CommentAnchorStatus_name = map[int32]string{
+		0: "COMMENT_ANCHOR_STATUS_UNSPECIFIED",
+		1: "COMMENT_ANCHOR_STATUS_VALID",
+		2: "COMMENT_ANCHOR_STATUS_MOVED",
+		3: "COMMENT_ANCHOR_STATUS_ORPHANED",
+	}
+	CommentAnchorStatus_value = map[string]int32{
+		"COMMENT_ANCHOR_STATUS_UNSPECIFIED": 0,
+		"COMMENT_ANCHOR_STATUS_VALID":       1,
+		"COMMENT_ANCHOR_STATUS_MOVED":       2,
+		"COMMENT_ANCHOR_STATUS_ORPHANED":    3,
+	}
+)
+
+func (x CommentAnchorStatus) Enum() *CommentAnchorStatus {
+	p := new(CommentAnchorStatus)
+	*p = x
+	return p
+}
+
+func (x CommentAnchorStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CommentAnchorStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_documents_v3alpha_comments_proto_enumTypes[0].Descriptor()
+}
+
+func (CommentAnchorStatus) Type() protoreflect.EnumType {
+	return &file_documents_v3alpha_comments_proto_enumTypes[0]
+}
+
+func (x CommentAnchorStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CommentAnchorStatus.Descriptor instead.
+func (CommentAnchorStatus) EnumDescriptor() ([]byte, []int) {
+	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{0}
+}
+
 // Request to create a comment.
 type CreateCommentRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -42,7 +99,10 @@ type CreateCommentRequest struct {
 	SigningKeyName string `protobuf:"bytes,6,opt,name=signing_key_name,json=signingKeyName,proto3" json:"signing_key_name,omitempty"`
 	// Optional. ID of the capability that allows publishing comments for the target account and path.
 	// Anyone can create comments to anything, but having a capability to comment makes sure your comments are propagated along with the content.
-	Capability    string `protobuf:"bytes,7,opt,name=capability,proto3" json:"capability,omitempty"`
+	Capability string `protobuf:"bytes,7,opt,name=capability,proto3" json:"capability,omitempty"`
+	// Optional. Block and range of text in the target version the comment refers to.
+	// Only the input fields of the anchor are used.
+	Anchor        *CommentAnchor `protobuf:"bytes,8,opt,name=anchor,proto3" json:"anchor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -126,6 +186,13 @@ func (x *CreateCommentRequest) GetCapability() string {
 	return ""
 }
 
+func (x *CreateCommentRequest) GetAnchor() *CommentAnchor {
+	if x != nil {
+		return x.Anchor
+	}
+	return nil
+}
+
 // Request to get a comment.
 type GetCommentRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -493,7 +560,11 @@ type Comment struct {
 	UpdateTime *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
 	// Visibility of the comment, inherited from the target document at creation time.
 	// Empty string means public visibility.
-	Visibility    string `protobuf:"bytes,15,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Visibility string `protobuf:"bytes,15,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	// Output only. Aggregated counts of the reactions to this comment.
+	Reactions []*ReactionCount `protobuf:"bytes,16,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	// Optional. Block and range of text in the target version the comment refers to.
+	Anchor        *CommentAnchor `protobuf:"bytes,17,opt,name=anchor,proto3" json:"anchor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -633,6 +704,131 @@ func (x *Comment) GetVisibility() string {
 	return ""
 }
 
+func (x *Comment) GetReactions() []*ReactionCount {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+func (x *Comment) GetAnchor() *CommentAnchor {
+	if x != nil {
+		return x.Anchor
+	}
+	return nil
+}
+
+// Anchor of a comment to a block of the target document, or to a range of text within the block.
+type CommentAnchor struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the block the comment refers to.
+	BlockId string `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	// Optional. Start of the range of text within the block, in Unicode code points.
+	Start int32 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	// Optional. End of the range of text within the block, in Unicode code points, exclusive.
+	// Zero start and end refer to the whole block.
+	End int32 `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	// Output only. Revision of the block in the target version the anchor was made against.
+	BlockRevision string `protobuf:"bytes,4,opt,name=block_revision,json=blockRevision,proto3" json:"block_revision,omitempty"`
+	// Output only. What happened to the anchored content in the latest version of the document.
+	// Unspecified while the anchor wasn't resolved yet, e.g. when the target version is not available locally.
+	Status CommentAnchorStatus `protobuf:"varint,5,opt,name=status,proto3,enum=com.seed.documents.v3alpha.CommentAnchorStatus" json:"status,omitempty"`
+	// Output only. Start of the anchored range of text in the latest version of the document.
+	ResolvedStart int32 `protobuf:"varint,6,opt,name=resolved_start,json=resolvedStart,proto3" json:"resolved_start,omitempty"`
+	// Output only. End of the anchored range of text in the latest version of the document.
+	ResolvedEnd int32 `protobuf:"varint,7,opt,name=resolved_end,json=resolvedEnd,proto3" json:"resolved_end,omitempty"`
+	// Output only. Version of the document the anchor was resolved against.
+	ResolvedVersion string `protobuf:"bytes,8,opt,name=resolved_version,json=resolvedVersion,proto3" json:"resolved_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CommentAnchor) Reset() {
+	*x = CommentAnchor{}
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommentAnchor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommentAnchor) ProtoMessage() {}
+
+func (x *CommentAnchor) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommentAnchor.ProtoReflect.Descriptor instead.
+func (*CommentAnchor) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CommentAnchor) GetBlockId() string {
+	if x != nil {
+		return x.BlockId
+	}
+	return ""
+}
+
+func (x *CommentAnchor) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *CommentAnchor) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *CommentAnchor) GetBlockRevision() string {
+	if x != nil {
+		return x.BlockRevision
+	}
+	return ""
+}
+
+func (x *CommentAnchor) GetStatus() CommentAnchorStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CommentAnchorStatus_COMMENT_ANCHOR_STATUS_UNSPECIFIED
+}
+
+func (x *CommentAnchor) GetResolvedStart() int32 {
+	if x != nil {
+		return x.ResolvedStart
+	}
+	return 0
+}
+
+func (x *CommentAnchor) GetResolvedEnd() int32 {
+	if x != nil {
+		return x.ResolvedEnd
+	}
+	return 0
+}
+
+func (x *CommentAnchor) GetResolvedVersion() string {
+	if x != nil {
+		return x.ResolvedVersion
+	}
+	return ""
+}
+
 // Request to update a comment.
 type UpdateCommentRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -648,7 +844,7 @@ type UpdateCommentRequest struct {
 
 func (x *UpdateCommentRequest) Reset() {
 	*x = UpdateCommentRequest{}
-	mi := &file_documents_v3alpha_comments_proto_msgTypes[8]
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -660,7 +856,7 @@ func (x *UpdateCommentRequest) String() string {
 func (*UpdateCommentRequest) ProtoMessage() {}
 
 func (x *UpdateCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_comments_proto_msgTypes[8]
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -673,7 +869,7 @@ func (x *UpdateCommentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCommentRequest.ProtoReflect.Descriptor instead.
 func (*UpdateCommentRequest) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{8}
+	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UpdateCommentRequest) GetComment() *Comment {
@@ -703,7 +899,7 @@ type DeleteCommentRequest struct {
 
 func (x *DeleteCommentRequest) Reset() {
 	*x = DeleteCommentRequest{}
-	mi := &file_documents_v3alpha_comments_proto_msgTypes[9]
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -715,7 +911,7 @@ func (x *DeleteCommentRequest) String() string {
 func (*DeleteCommentRequest) ProtoMessage() {}
 
 func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_comments_proto_msgTypes[9]
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -728,7 +924,7 @@ func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
 func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{9}
+	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DeleteCommentRequest) GetId() string {
@@ -757,7 +953,7 @@ type GetCommentReplyCountRequest struct {
 
 func (x *GetCommentReplyCountRequest) Reset() {
 	*x = GetCommentReplyCountRequest{}
-	mi := &file_documents_v3alpha_comments_proto_msgTypes[10]
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -769,7 +965,7 @@ func (x *GetCommentReplyCountRequest) String() string {
 func (*GetCommentReplyCountRequest) ProtoMessage() {}
 
 func (x *GetCommentReplyCountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_comments_proto_msgTypes[10]
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -782,7 +978,7 @@ func (x *GetCommentReplyCountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCommentReplyCountRequest.ProtoReflect.Descriptor instead.
 func (*GetCommentReplyCountRequest) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{10}
+	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetCommentReplyCountRequest) GetId() string {
@@ -803,7 +999,7 @@ type GetCommentReplyCountResponse struct {
 
 func (x *GetCommentReplyCountResponse) Reset() {
 	*x = GetCommentReplyCountResponse{}
-	mi := &file_documents_v3alpha_comments_proto_msgTypes[11]
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -815,7 +1011,7 @@ func (x *GetCommentReplyCountResponse) String() string {
 func (*GetCommentReplyCountResponse) ProtoMessage() {}
 
 func (x *GetCommentReplyCountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_comments_proto_msgTypes[11]
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -828,7 +1024,7 @@ func (x *GetCommentReplyCountResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCommentReplyCountResponse.ProtoReflect.Descriptor instead.
 func (*GetCommentReplyCountResponse) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{11}
+	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetCommentReplyCountResponse) GetReplyCount() int64 {
@@ -849,7 +1045,7 @@ type ListCommentVersionsRequest struct {
 
 func (x *ListCommentVersionsRequest) Reset() {
 	*x = ListCommentVersionsRequest{}
-	mi := &file_documents_v3alpha_comments_proto_msgTypes[12]
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -861,7 +1057,7 @@ func (x *ListCommentVersionsRequest) String() string {
 func (*ListCommentVersionsRequest) ProtoMessage() {}
 
 func (x *ListCommentVersionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_comments_proto_msgTypes[12]
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -874,7 +1070,7 @@ func (x *ListCommentVersionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCommentVersionsRequest.ProtoReflect.Descriptor instead.
 func (*ListCommentVersionsRequest) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{12}
+	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListCommentVersionsRequest) GetId() string {
@@ -895,7 +1091,7 @@ type ListCommentVersionsResponse struct {
 
 func (x *ListCommentVersionsResponse) Reset() {
 	*x = ListCommentVersionsResponse{}
-	mi := &file_documents_v3alpha_comments_proto_msgTypes[13]
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -907,7 +1103,7 @@ func (x *ListCommentVersionsResponse) String() string {
 func (*ListCommentVersionsResponse) ProtoMessage() {}
 
 func (x *ListCommentVersionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_comments_proto_msgTypes[13]
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -920,7 +1116,7 @@ func (x *ListCommentVersionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCommentVersionsResponse.ProtoReflect.Descriptor instead.
 func (*ListCommentVersionsResponse) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{13}
+	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ListCommentVersionsResponse) GetVersions() []*Comment {
@@ -930,11 +1126,451 @@ func (x *ListCommentVersionsResponse) GetVersions() []*Comment {
 	return nil
 }
 
+// Request to create a reaction.
+type CreateReactionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. Account ID of the target document.
+	TargetAccount string `protobuf:"bytes,1,opt,name=target_account,json=targetAccount,proto3" json:"target_account,omitempty"`
+	// Required. Path of the target document within the account.
+	TargetPath string `protobuf:"bytes,2,opt,name=target_path,json=targetPath,proto3" json:"target_path,omitempty"`
+	// Required. Version of the document at the time of the reaction.
+	TargetVersion string `protobuf:"bytes,3,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"`
+	// Optional. ID of the block within the document to react to.
+	TargetBlock string `protobuf:"bytes,4,opt,name=target_block,json=targetBlock,proto3" json:"target_block,omitempty"`
+	// Optional. ID of the comment on the document to react to.
+	// Can't be used together with target_block.
+	TargetComment string `protobuf:"bytes,5,opt,name=target_comment,json=targetComment,proto3" json:"target_comment,omitempty"`
+	// Required. Emoji or a short token of the reaction.
+	Value string `protobuf:"bytes,6,opt,name=value,proto3" json:"value,omitempty"`
+	// Required. Name of the key to use for signing the reaction.
+	SigningKeyName string `protobuf:"bytes,7,opt,name=signing_key_name,json=signingKeyName,proto3" json:"signing_key_name,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateReactionRequest) Reset() {
+	*x = CreateReactionRequest{}
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReactionRequest) ProtoMessage() {}
+
+func (x *CreateReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReactionRequest.ProtoReflect.Descriptor instead.
+func (*CreateReactionRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateReactionRequest) GetTargetAccount() string {
+	if x != nil {
+		return x.TargetAccount
+	}
+	return ""
+}
+
+func (x *CreateReactionRequest) GetTargetPath() string {
+	if x != nil {
+		return x.TargetPath
+	}
+	return ""
+}
+
+func (x *CreateReactionRequest) GetTargetVersion() string {
+	if x != nil {
+		return x.TargetVersion
+	}
+	return ""
+}
+
+func (x *CreateReactionRequest) GetTargetBlock() string {
+	if x != nil {
+		return x.TargetBlock
+	}
+	return ""
+}
+
+func (x *CreateReactionRequest) GetTargetComment() string {
+	if x != nil {
+		return x.TargetComment
+	}
+	return ""
+}
+
+func (x *CreateReactionRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *CreateReactionRequest) GetSigningKeyName() string {
+	if x != nil {
+		return x.SigningKeyName
+	}
+	return ""
+}
+
+// Request to delete a reaction.
+type DeleteReactionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the reaction to delete.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Required. Name of the key to use for signing the reaction deletion.
+	SigningKeyName string `protobuf:"bytes,2,opt,name=signing_key_name,json=signingKeyName,proto3" json:"signing_key_name,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteReactionRequest) Reset() {
+	*x = DeleteReactionRequest{}
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteReactionRequest) ProtoMessage() {}
+
+func (x *DeleteReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteReactionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteReactionRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteReactionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteReactionRequest) GetSigningKeyName() string {
+	if x != nil {
+		return x.SigningKeyName
+	}
+	return ""
+}
+
+// Request to list reactions.
+type ListReactionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. Account ID of the target document.
+	TargetAccount string `protobuf:"bytes,1,opt,name=target_account,json=targetAccount,proto3" json:"target_account,omitempty"`
+	// Required. Path of the target document within the account.
+	TargetPath string `protobuf:"bytes,2,opt,name=target_path,json=targetPath,proto3" json:"target_path,omitempty"`
+	// Optional. ID of the block to list the reactions for.
+	// If empty, reactions to the document itself are listed.
+	TargetBlock string `protobuf:"bytes,3,opt,name=target_block,json=targetBlock,proto3" json:"target_block,omitempty"`
+	// Optional. ID of the comment to list the reactions for.
+	TargetComment string `protobuf:"bytes,4,opt,name=target_comment,json=targetComment,proto3" json:"target_comment,omitempty"`
+	// Optional. The maximum number of reactions to return.
+	PageSize int32 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Optional. The page token obtained from a previous request (if any).
+	PageToken     string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReactionsRequest) Reset() {
+	*x = ListReactionsRequest{}
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReactionsRequest) ProtoMessage() {}
+
+func (x *ListReactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListReactionsRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListReactionsRequest) GetTargetAccount() string {
+	if x != nil {
+		return x.TargetAccount
+	}
+	return ""
+}
+
+func (x *ListReactionsRequest) GetTargetPath() string {
+	if x != nil {
+		return x.TargetPath
+	}
+	return ""
+}
+
+func (x *ListReactionsRequest) GetTargetBlock() string {
+	if x != nil {
+		return x.TargetBlock
+	}
+	return ""
+}
+
+func (x *ListReactionsRequest) GetTargetComment() string {
+	if x != nil {
+		return x.TargetComment
+	}
+	return ""
+}
+
+func (x *ListReactionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListReactionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Response with a list of reactions.
+type ListReactionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// List of reactions, most recent first.
+	Reactions []*Reaction `protobuf:"bytes,1,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	// Aggregated counts of all the reactions to the target.
+	// Only returned with the first page.
+	Counts []*ReactionCount `protobuf:"bytes,2,rep,name=counts,proto3" json:"counts,omitempty"`
+	// Token to retrieve the next page of reactions (if necessary).
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReactionsResponse) Reset() {
+	*x = ListReactionsResponse{}
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReactionsResponse) ProtoMessage() {}
+
+func (x *ListReactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListReactionsResponse) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListReactionsResponse) GetReactions() []*Reaction {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+func (x *ListReactionsResponse) GetCounts() []*ReactionCount {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *ListReactionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Reaction to a document, a block, or a comment.
+type Reaction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the reaction.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Account ID of the target document.
+	TargetAccount string `protobuf:"bytes,2,opt,name=target_account,json=targetAccount,proto3" json:"target_account,omitempty"`
+	// Path of the target document within the account.
+	TargetPath string `protobuf:"bytes,3,opt,name=target_path,json=targetPath,proto3" json:"target_path,omitempty"`
+	// Version of the document at the time of the reaction.
+	TargetVersion string `protobuf:"bytes,4,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"`
+	// ID of the block the reaction is about, if any.
+	TargetBlock string `protobuf:"bytes,5,opt,name=target_block,json=targetBlock,proto3" json:"target_block,omitempty"`
+	// ID of the comment the reaction is about, if any.
+	TargetComment string `protobuf:"bytes,6,opt,name=target_comment,json=targetComment,proto3" json:"target_comment,omitempty"`
+	// Account ID of the author of the reaction.
+	Author string `protobuf:"bytes,7,opt,name=author,proto3" json:"author,omitempty"`
+	// Emoji or a short token of the reaction.
+	Value string `protobuf:"bytes,8,opt,name=value,proto3" json:"value,omitempty"`
+	// Timestamp when the reaction was created.
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// Version of this reaction.
+	Version       string `protobuf:"bytes,10,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reaction) Reset() {
+	*x = Reaction{}
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reaction) ProtoMessage() {}
+
+func (x *Reaction) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_comments_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reaction.ProtoReflect.Descriptor instead.
+func (*Reaction) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_comments_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Reaction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reaction) GetTargetAccount() string {
+	if x != nil {
+		return x.TargetAccount
+	}
+	return ""
+}
+
+func (x *Reaction) GetTargetPath() string {
+	if x != nil {
+		return x.TargetPath
+	}
+	return ""
+}
+
+func (x *Reaction) GetTargetVersion() string {
+	if x != nil {
+		return x.TargetVersion
+	}
+	return ""
+}
+
+func (x *Reaction) GetTargetBlock() string {
+	if x != nil {
+		return x.TargetBlock
+	}
+	return ""
+}
+
+func (x *Reaction) GetTargetComment() string {
+	if x != nil {
+		return x.TargetComment
+	}
+	return ""
+}
+
+func (x *Reaction) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Reaction) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Reaction) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Reaction) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
 var File_documents_v3alpha_comments_proto protoreflect.FileDescriptor
 
 const file_documents_v3alpha_comments_proto_rawDesc = "" +
 	"\n" +
-	" documents/v3alpha/comments.proto\x12\x1acom.seed.documents.v3alpha\x1a!documents/v3alpha/documents.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xb3\x02\n" +
+	" documents/v3alpha/comments.proto\x12\x1acom.seed.documents.v3alpha\x1a!documents/v3alpha/documents.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xf6\x02\n" +
 	"\x14CreateCommentRequest\x12%\n" +
 	"\x0etarget_account\x18\x01 \x01(\tR\rtargetAccount\x12\x1f\n" +
 	"\vtarget_path\x18\x02 \x01(\tR\n" +
@@ -945,7 +1581,8 @@ const file_documents_v3alpha_comments_proto_rawDesc = "" +
 	"\x10signing_key_name\x18\x06 \x01(\tR\x0esigningKeyName\x12\x1e\n" +
 	"\n" +
 	"capability\x18\a \x01(\tR\n" +
-	"capability\"#\n" +
+	"capability\x12A\n" +
+	"\x06anchor\x18\b \x01(\v2).com.seed.documents.v3alpha.CommentAnchorR\x06anchor\"#\n" +
 	"\x11GetCommentRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"+\n" +
 	"\x17BatchGetCommentsRequest\x12\x10\n" +
@@ -966,7 +1603,7 @@ const file_documents_v3alpha_comments_proto_rawDesc = "" +
 	"page_token\x18\x03 \x01(\tR\tpageToken\"\x7f\n" +
 	"\x14ListCommentsResponse\x12?\n" +
 	"\bcomments\x18\x01 \x03(\v2#.com.seed.documents.v3alpha.CommentR\bcomments\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xdb\x04\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xe7\x05\n" +
 	"\aComment\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
 	"\x0etarget_account\x18\x02 \x01(\tR\rtargetAccount\x12\x1f\n" +
@@ -991,7 +1628,18 @@ const file_documents_v3alpha_comments_proto_rawDesc = "" +
 	"updateTime\x12\x1e\n" +
 	"\n" +
 	"visibility\x18\x0f \x01(\tR\n" +
-	"visibility\"\x7f\n" +
+	"visibility\x12G\n" +
+	"\treactions\x18\x10 \x03(\v2).com.seed.documents.v3alpha.ReactionCountR\treactions\x12A\n" +
+	"\x06anchor\x18\x11 \x01(\v2).com.seed.documents.v3alpha.CommentAnchorR\x06anchor\"\xb7\x02\n" +
+	"\rCommentAnchor\x12\x19\n" +
+	"\bblock_id\x18\x01 \x01(\tR\ablockId\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\x05R\x03end\x12%\n" +
+	"\x0eblock_revision\x18\x04 \x01(\tR\rblockRevision\x12G\n" +
+	"\x06status\x18\x05 \x01(\x0e2/.com.seed.documents.v3alpha.CommentAnchorStatusR\x06status\x12%\n" +
+	"\x0eresolved_start\x18\x06 \x01(\x05R\rresolvedStart\x12!\n" +
+	"\fresolved_end\x18\a \x01(\x05R\vresolvedEnd\x12)\n" +
+	"\x10resolved_version\x18\b \x01(\tR\x0fresolvedVersion\"\x7f\n" +
 	"\x14UpdateCommentRequest\x12=\n" +
 	"\acomment\x18\x01 \x01(\v2#.com.seed.documents.v3alpha.CommentR\acomment\x12(\n" +
 	"\x10signing_key_name\x18\x02 \x01(\tR\x0esigningKeyName\"P\n" +
@@ -1006,7 +1654,52 @@ const file_documents_v3alpha_comments_proto_rawDesc = "" +
 	"\x1aListCommentVersionsRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"^\n" +
 	"\x1bListCommentVersionsResponse\x12?\n" +
-	"\bversions\x18\x01 \x03(\v2#.com.seed.documents.v3alpha.CommentR\bversions2\xa2\b\n" +
+	"\bversions\x18\x01 \x03(\v2#.com.seed.documents.v3alpha.CommentR\bversions\"\x90\x02\n" +
+	"\x15CreateReactionRequest\x12%\n" +
+	"\x0etarget_account\x18\x01 \x01(\tR\rtargetAccount\x12\x1f\n" +
+	"\vtarget_path\x18\x02 \x01(\tR\n" +
+	"targetPath\x12%\n" +
+	"\x0etarget_version\x18\x03 \x01(\tR\rtargetVersion\x12!\n" +
+	"\ftarget_block\x18\x04 \x01(\tR\vtargetBlock\x12%\n" +
+	"\x0etarget_comment\x18\x05 \x01(\tR\rtargetComment\x12\x14\n" +
+	"\x05value\x18\x06 \x01(\tR\x05value\x12(\n" +
+	"\x10signing_key_name\x18\a \x01(\tR\x0esigningKeyName\"Q\n" +
+	"\x15DeleteReactionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\x10signing_key_name\x18\x02 \x01(\tR\x0esigningKeyName\"\xe4\x01\n" +
+	"\x14ListReactionsRequest\x12%\n" +
+	"\x0etarget_account\x18\x01 \x01(\tR\rtargetAccount\x12\x1f\n" +
+	"\vtarget_path\x18\x02 \x01(\tR\n" +
+	"targetPath\x12!\n" +
+	"\ftarget_block\x18\x03 \x01(\tR\vtargetBlock\x12%\n" +
+	"\x0etarget_comment\x18\x04 \x01(\tR\rtargetComment\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\"\xc6\x01\n" +
+	"\x15ListReactionsResponse\x12B\n" +
+	"\treactions\x18\x01 \x03(\v2$.com.seed.documents.v3alpha.ReactionR\treactions\x12A\n" +
+	"\x06counts\x18\x02 \x03(\v2).com.seed.documents.v3alpha.ReactionCountR\x06counts\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"\xd8\x02\n" +
+	"\bReaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0etarget_account\x18\x02 \x01(\tR\rtargetAccount\x12\x1f\n" +
+	"\vtarget_path\x18\x03 \x01(\tR\n" +
+	"targetPath\x12%\n" +
+	"\x0etarget_version\x18\x04 \x01(\tR\rtargetVersion\x12!\n" +
+	"\ftarget_block\x18\x05 \x01(\tR\vtargetBlock\x12%\n" +
+	"\x0etarget_comment\x18\x06 \x01(\tR\rtargetComment\x12\x16\n" +
+	"\x06author\x18\a \x01(\tR\x06author\x12\x14\n" +
+	"\x05value\x18\b \x01(\tR\x05value\x12;\n" +
+	"\vcreate_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\tR\aversion*\xa2\x01\n" +
+	"\x13CommentAnchorStatus\x12%\n" +
+	"!COMMENT_ANCHOR_STATUS_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bCOMMENT_ANCHOR_STATUS_VALID\x10\x01\x12\x1f\n" +
+	"\x1bCOMMENT_ANCHOR_STATUS_MOVED\x10\x02\x12\"\n" +
+	"\x1eCOMMENT_ANCHOR_STATUS_ORPHANED\x10\x032\xe0\n" +
+	"\n" +
 	"\bComments\x12f\n" +
 	"\rCreateComment\x120.com.seed.documents.v3alpha.CreateCommentRequest\x1a#.com.seed.documents.v3alpha.Comment\x12`\n" +
 	"\n" +
@@ -1017,7 +1710,10 @@ const file_documents_v3alpha_comments_proto_rawDesc = "" +
 	"\rUpdateComment\x120.com.seed.documents.v3alpha.UpdateCommentRequest\x1a#.com.seed.documents.v3alpha.Comment\x12Y\n" +
 	"\rDeleteComment\x120.com.seed.documents.v3alpha.DeleteCommentRequest\x1a\x16.google.protobuf.Empty\x12\x89\x01\n" +
 	"\x14GetCommentReplyCount\x127.com.seed.documents.v3alpha.GetCommentReplyCountRequest\x1a8.com.seed.documents.v3alpha.GetCommentReplyCountResponse\x12\x86\x01\n" +
-	"\x13ListCommentVersions\x126.com.seed.documents.v3alpha.ListCommentVersionsRequest\x1a7.com.seed.documents.v3alpha.ListCommentVersionsResponseB3Z1seed/backend/genproto/documents/v3alpha;documentsb\x06proto3"
+	"\x13ListCommentVersions\x126.com.seed.documents.v3alpha.ListCommentVersionsRequest\x1a7.com.seed.documents.v3alpha.ListCommentVersionsResponse\x12i\n" +
+	"\x0eCreateReaction\x121.com.seed.documents.v3alpha.CreateReactionRequest\x1a$.com.seed.documents.v3alpha.Reaction\x12[\n" +
+	"\x0eDeleteReaction\x121.com.seed.documents.v3alpha.DeleteReactionRequest\x1a\x16.google.protobuf.Empty\x12t\n" +
+	"\rListReactions\x120.com.seed.documents.v3alpha.ListReactionsRequest\x1a1.com.seed.documents.v3alpha.ListReactionsResponseB3Z1seed/backend/genproto/documents/v3alpha;documentsb\x06proto3"
 
 var (
 	file_documents_v3alpha_comments_proto_rawDescOnce sync.Once
@@ -1031,58 +1727,80 @@ func file_documents_v3alpha_comments_proto_rawDescGZIP() []byte {
 	return file_documents_v3alpha_comments_proto_rawDescData
 }
 
-var file_documents_v3alpha_comments_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_documents_v3alpha_comments_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_documents_v3alpha_comments_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_documents_v3alpha_comments_proto_goTypes = []any{
-	(*CreateCommentRequest)(nil),         // 0: com.seed.documents.v3alpha.CreateCommentRequest
-	(*GetCommentRequest)(nil),            // 1: com.seed.documents.v3alpha.GetCommentRequest
-	(*BatchGetCommentsRequest)(nil),      // 2: com.seed.documents.v3alpha.BatchGetCommentsRequest
-	(*BatchGetCommentsResponse)(nil),     // 3: com.seed.documents.v3alpha.BatchGetCommentsResponse
-	(*ListCommentsRequest)(nil),          // 4: com.seed.documents.v3alpha.ListCommentsRequest
-	(*ListCommentsByAuthorRequest)(nil),  // 5: com.seed.documents.v3alpha.ListCommentsByAuthorRequest
-	(*ListCommentsResponse)(nil),         // 6: com.seed.documents.v3alpha.ListCommentsResponse
-	(*Comment)(nil),                      // 7: com.seed.documents.v3alpha.Comment
-	(*UpdateCommentRequest)(nil),         // 8: com.seed.documents.v3alpha.UpdateCommentRequest
-	(*DeleteCommentRequest)(nil),         // 9: com.seed.documents.v3alpha.DeleteCommentRequest
-	(*GetCommentReplyCountRequest)(nil),  // 10: com.seed.documents.v3alpha.GetCommentReplyCountRequest
-	(*GetCommentReplyCountResponse)(nil), // 11: com.seed.documents.v3alpha.GetCommentReplyCountResponse
-	(*ListCommentVersionsRequest)(nil),   // 12: com.seed.documents.v3alpha.ListCommentVersionsRequest
-	(*ListCommentVersionsResponse)(nil),  // 13: com.seed.documents.v3alpha.ListCommentVersionsResponse
-	(*BlockNode)(nil),                    // 14: com.seed.documents.v3alpha.BlockNode
-	(*timestamppb.Timestamp)(nil),        // 15: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                // 16: google.protobuf.Empty
+	(CommentAnchorStatus)(0),             // 0: com.seed.documents.v3alpha.CommentAnchorStatus
+	(*CreateCommentRequest)(nil),         // 1: com.seed.documents.v3alpha.CreateCommentRequest
+	(*GetCommentRequest)(nil),            // 2: com.seed.documents.v3alpha.GetCommentRequest
+	(*BatchGetCommentsRequest)(nil),      // 3: com.seed.documents.v3alpha.BatchGetCommentsRequest
+	(*BatchGetCommentsResponse)(nil),     // 4: com.seed.documents.v3alpha.BatchGetCommentsResponse
+	(*ListCommentsRequest)(nil),          // 5: com.seed.documents.v3alpha.ListCommentsRequest
+	(*ListCommentsByAuthorRequest)(nil),  // 6: com.seed.documents.v3alpha.ListCommentsByAuthorRequest
+	(*ListCommentsResponse)(nil),         // 7: com.seed.documents.v3alpha.ListCommentsResponse
+	(*Comment)(nil),                      // 8: com.seed.documents.v3alpha.Comment
+	(*CommentAnchor)(nil),                // 9: com.seed.documents.v3alpha.CommentAnchor
+	(*UpdateCommentRequest)(nil),         // 10: com.seed.documents.v3alpha.UpdateCommentRequest
+	(*DeleteCommentRequest)(nil),         // 11: com.seed.documents.v3alpha.DeleteCommentRequest
+	(*GetCommentReplyCountRequest)(nil),  // 12: com.seed.documents.v3alpha.GetCommentReplyCountRequest
+	(*GetCommentReplyCountResponse)(nil), // 13: com.seed.documents.v3alpha.GetCommentReplyCountResponse
+	(*ListCommentVersionsRequest)(nil),   // 14: com.seed.documents.v3alpha.ListCommentVersionsRequest
+	(*ListCommentVersionsResponse)(nil),  // 15: com.seed.documents.v3alpha.ListCommentVersionsResponse
+	(*CreateReactionRequest)(nil),        // 16: com.seed.documents.v3alpha.CreateReactionRequest
+	(*DeleteReactionRequest)(nil),        // 17: com.seed.documents.v3alpha.DeleteReactionRequest
+	(*ListReactionsRequest)(nil),         // 18: com.seed.documents.v3alpha.ListReactionsRequest
+	(*ListReactionsResponse)(nil),        // 19: com.seed.documents.v3alpha.ListReactionsResponse
+	(*Reaction)(nil),                     // 20: com.seed.documents.v3alpha.Reaction
+	(*BlockNode)(nil),                    // 21: com.seed.documents.v3alpha.BlockNode
+	(*timestamppb.Timestamp)(nil),        // 22: google.protobuf.Timestamp
+	(*ReactionCount)(nil),                // 23: com.seed.documents.v3alpha.ReactionCount
+	(*emptypb.Empty)(nil),                // 24: google.protobuf.Empty
 }
 var file_documents_v3alpha_comments_proto_depIdxs = []int32{
-	14, // 0: com.seed.documents.v3alpha.CreateCommentRequest.content:type_name -> com.seed.documents.v3alpha.BlockNode
-	7,  // 1: com.seed.documents.v3alpha.BatchGetCommentsResponse.comments:type_name -> com.seed.documents.v3alpha.Comment
-	7,  // 2: com.seed.documents.v3alpha.ListCommentsResponse.comments:type_name -> com.seed.documents.v3alpha.Comment
-	14, // 3: com.seed.documents.v3alpha.Comment.content:type_name -> com.seed.documents.v3alpha.BlockNode
-	15, // 4: com.seed.documents.v3alpha.Comment.create_time:type_name -> google.protobuf.Timestamp
-	15, // 5: com.seed.documents.v3alpha.Comment.update_time:type_name -> google.protobuf.Timestamp
-	7,  // 6: com.seed.documents.v3alpha.UpdateCommentRequest.comment:type_name -> com.seed.documents.v3alpha.Comment
-	7,  // 7: com.seed.documents.v3alpha.ListCommentVersionsResponse.versions:type_name -> com.seed.documents.v3alpha.Comment
-	0,  // 8: com.seed.documents.v3alpha.Comments.CreateComment:input_type -> com.seed.documents.v3alpha.CreateCommentRequest
-	1,  // 9: com.seed.documents.v3alpha.Comments.GetComment:input_type -> com.seed.documents.v3alpha.GetCommentRequest
-	2,  // 10: com.seed.documents.v3alpha.Comments.BatchGetComments:input_type -> com.seed.documents.v3alpha.BatchGetCommentsRequest
-	4,  // 11: com.seed.documents.v3alpha.Comments.ListComments:input_type -> com.seed.documents.v3alpha.ListCommentsRequest
-	5,  // 12: com.seed.documents.v3alpha.Comments.ListCommentsByAuthor:input_type -> com.seed.documents.v3alpha.ListCommentsByAuthorRequest
-	8,  // 13: com.seed.documents.v3alpha.Comments.UpdateComment:input_type -> com.seed.documents.v3alpha.UpdateCommentRequest
-	9,  // 14: com.seed.documents.v3alpha.Comments.DeleteComment:input_type -> com.seed.documents.v3alpha.DeleteCommentRequest
-	10, // 15: com.seed.documents.v3alpha.Comments.GetCommentReplyCount:input_type -> com.seed.documents.v3alpha.GetCommentReplyCountRequest
-	12, // 16: com.seed.documents.v3alpha.Comments.ListCommentVersions:input_type -> com.seed.documents.v3alpha.ListCommentVersionsRequest
-	7,  // 17: com.seed.documents.v3alpha.Comments.CreateComment:output_type -> com.seed.documents.v3alpha.Comment
-	7,  // 18: com.seed.documents.v3alpha.Comments.GetComment:output_type -> com.seed.documents.v3alpha.Comment
-	3,  // 19: com.seed.documents.v3alpha.Comments.BatchGetComments:output_type -> com.seed.documents.v3alpha.BatchGetCommentsResponse
-	6,  // 20: com.seed.documents.v3alpha.Comments.ListComments:output_type -> com.seed.documents.v3alpha.ListCommentsResponse
-	6,  // 21: com.seed.documents.v3alpha.Comments.ListCommentsByAuthor:output_type -> com.seed.documents.v3alpha.ListCommentsResponse
-	7,  // 22: com.seed.documents.v3alpha.Comments.UpdateComment:output_type -> com.seed.documents.v3alpha.Comment
-	16, // 23: com.seed.documents.v3alpha.Comments.DeleteComment:output_type -> google.protobuf.Empty
-	11, // 24: com.seed.documents.v3alpha.Comments.GetCommentReplyCount:output_type -> com.seed.documents.v3alpha.GetCommentReplyCountResponse
-	13, // 25: com.seed.documents.v3alpha.Comments.ListCommentVersions:output_type -> com.seed.documents.v3alpha.ListCommentVersionsResponse
-	17, // [17:26] is the sub-list for method output_type
-	8,  // [8:17] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	21, // 0: com.seed.documents.v3alpha.CreateCommentRequest.content:type_name -> com.seed.documents.v3alpha.BlockNode
+	9,  // 1: com.seed.documents.v3alpha.CreateCommentRequest.anchor:type_name -> com.seed.documents.v3alpha.CommentAnchor
+	8,  // 2: com.seed.documents.v3alpha.BatchGetCommentsResponse.comments:type_name -> com.seed.documents.v3alpha.Comment
+	8,  // 3: com.seed.documents.v3alpha.ListCommentsResponse.comments:type_name -> com.seed.documents.v3alpha.Comment
+	21, // 4: com.seed.documents.v3alpha.Comment.content:type_name -> com.seed.documents.v3alpha.BlockNode
+	22, // 5: com.seed.documents.v3alpha.Comment.create_time:type_name -> google.protobuf.Timestamp
+	22, // 6: com.seed.documents.v3alpha.Comment.update_time:type_name -> google.protobuf.Timestamp
+	23, // 7: com.seed.documents.v3alpha.Comment.reactions:type_name -> com.seed.documents.v3alpha.ReactionCount
+	9,  // 8: com.seed.documents.v3alpha.Comment.anchor:type_name -> com.seed.documents.v3alpha.CommentAnchor
+	0,  // 9: com.seed.documents.v3alpha.CommentAnchor.status:type_name -> com.seed.documents.v3alpha.CommentAnchorStatus
+	8,  // 10: com.seed.documents.v3alpha.UpdateCommentRequest.comment:type_name -> com.seed.documents.v3alpha.Comment
+	8,  // 11: com.seed.documents.v3alpha.ListCommentVersionsResponse.versions:type_name -> com.seed.documents.v3alpha.Comment
+	20, // 12: com.seed.documents.v3alpha.ListReactionsResponse.reactions:type_name -> com.seed.documents.v3alpha.Reaction
+	23, // 13: com.seed.documents.v3alpha.ListReactionsResponse.counts:type_name -> com.seed.documents.v3alpha.ReactionCount
+	22, // 14: com.seed.documents.v3alpha.Reaction.create_time:type_name -> google.protobuf.Timestamp
+	1,  // 15: com.seed.documents.v3alpha.Comments.CreateComment:input_type -> com.seed.documents.v3alpha.CreateCommentRequest
+	2,  // 16: com.seed.documents.v3alpha.Comments.GetComment:input_type -> com.seed.documents.v3alpha.GetCommentRequest
+	3,  // 17: com.seed.documents.v3alpha.Comments.BatchGetComments:input_type -> com.seed.documents.v3alpha.BatchGetCommentsRequest
+	5,  // 18: com.seed.documents.v3alpha.Comments.ListComments:input_type -> com.seed.documents.v3alpha.ListCommentsRequest
+	6,  // 19: com.seed.documents.v3alpha.Comments.ListCommentsByAuthor:input_type -> com.seed.documents.v3alpha.ListCommentsByAuthorRequest
+	10, // 20: com.seed.documents.v3alpha.Comments.UpdateComment:input_type -> com.seed.documents.v3alpha.UpdateCommentRequest
+	11, // 21: com.seed.documents.v3alpha.Comments.DeleteComment:input_type -> com.seed.documents.v3alpha.DeleteCommentRequest
+	12, // 22: com.seed.documents.v3alpha.Comments.GetCommentReplyCount:input_type -> com.seed.documents.v3alpha.GetCommentReplyCountRequest
+	14, // 23: com.seed.documents.v3alpha.Comments.ListCommentVersions:input_type -> com.seed.documents.v3alpha.ListCommentVersionsRequest
+	16, // 24: com.seed.documents.v3alpha.Comments.CreateReaction:input_type -> com.seed.documents.v3alpha.CreateReactionRequest
+	17, // 25: com.seed.documents.v3alpha.Comments.DeleteReaction:input_type -> com.seed.documents.v3alpha.DeleteReactionRequest
+	18, // 26: com.seed.documents.v3alpha.Comments.ListReactions:input_type -> com.seed.documents.v3alpha.ListReactionsRequest
+	8,  // 27: com.seed.documents.v3alpha.Comments.CreateComment:output_type -> com.seed.documents.v3alpha.Comment
+	8,  // 28: com.seed.documents.v3alpha.Comments.GetComment:output_type -> com.seed.documents.v3alpha.Comment
+	4,  // 29: com.seed.documents.v3alpha.Comments.BatchGetComments:output_type -> com.seed.documents.v3alpha.BatchGetCommentsResponse
+	7,  // 30: com.seed.documents.v3alpha.Comments.ListComments:output_type -> com.seed.documents.v3alpha.ListCommentsResponse
+	7,  // 31: com.seed.documents.v3alpha.Comments.ListCommentsByAuthor:output_type -> com.seed.documents.v3alpha.ListCommentsResponse
+	8,  // 32: com.seed.documents.v3alpha.Comments.UpdateComment:output_type -> com.seed.documents.v3alpha.Comment
+	24, // 33: com.seed.documents.v3alpha.Comments.DeleteComment:output_type -> google.protobuf.Empty
+	13, // 34: com.seed.documents.v3alpha.Comments.GetCommentReplyCount:output_type -> com.seed.documents.v3alpha.GetCommentReplyCountResponse
+	15, // 35: com.seed.documents.v3alpha.Comments.ListCommentVersions:output_type -> com.seed.documents.v3alpha.ListCommentVersionsResponse
+	20, // 36: com.seed.documents.v3alpha.Comments.CreateReaction:output_type -> com.seed.documents.v3alpha.Reaction
+	24, // 37: com.seed.documents.v3alpha.Comments.DeleteReaction:output_type -> google.protobuf.Empty
+	19, // 38: com.seed.documents.v3alpha.Comments.ListReactions:output_type -> com.seed.documents.v3alpha.ListReactionsResponse
+	27, // [27:39] is the sub-list for method output_type
+	15, // [15:27] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_documents_v3alpha_comments_proto_init() }
@@ -1096,13 +1814,14 @@ func file_documents_v3alpha_comments_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_documents_v3alpha_comments_proto_rawDesc), len(file_documents_v3alpha_comments_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   14,
+			NumEnums:      1,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_documents_v3alpha_comments_proto_goTypes,
 		DependencyIndexes: file_documents_v3alpha_comments_proto_depIdxs,
+		EnumInfos:         file_documents_v3alpha_comments_proto_enumTypes,
 		MessageInfos:      file_documents_v3alpha_comments_proto_msgTypes,
 	}.Build()
 	File_documents_v3alpha_comments_proto = out.File
@@ -29,6 +29,9 @@ const (
 	Comments_DeleteComment_FullMethodName        = "/com.seed.documents.v3alpha.Comments/DeleteComment"
 	Comments_GetCommentReplyCount_FullMethodName = "/com.seed.documents.v3alpha.Comments/GetCommentReplyCount"
 	Comments_ListCommentVersions_FullMethodName  = "/com.seed.documents.v3alpha.Comments/ListCommentVersions"
+	Comments_CreateReaction_FullMethodName       = "/com.seed.documents.v3alpha.Comments/CreateReaction"
+	Comments_DeleteReaction_FullMethodName       = "/com.seed.documents.v3alpha.Comments/DeleteReaction"
+	Comments_ListReactions_FullMethodName        = "/com.seed.documents.v3alpha.Comments/ListReactions"
 )
 
 // CommentsClient is the client API for Comments service.
@@ -55,6 +58,12 @@ type CommentsClient interface {
 	GetCommentReplyCount(ctx context.Context, in *GetCommentReplyCountRequest, opts ...grpc.CallOption) (*GetCommentReplyCountResponse, error)
 	// Lists all versions of a comment (edit history).
 	ListCommentVersions(ctx context.Context, in *ListCommentVersionsRequest, opts ...grpc.CallOption) (*ListCommentVersionsResponse, error)
+	// Creates a reaction to a document, a block, or a comment.
+	CreateReaction(ctx context.Context, in *CreateReactionRequest, opts ...grpc.CallOption) (*Reaction, error)
+	// Deletes a reaction.
+	DeleteReaction(ctx context.Context, in *DeleteReactionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Lists reactions for a given target, along with their aggregated counts.
+	ListReactions(ctx context.Context, in *ListReactionsRequest, opts ...grpc.CallOption) (*ListReactionsResponse, error)
 }
 
 type commentsClient struct {
@@ -155,6 +164,36 @@ func (c *commentsClient) ListCommentVersions(ctx context.Context, in *ListCommen
 	return out, nil
 }
 
+func (c *commentsClient) CreateReaction(ctx context.Context, in *CreateReactionRequest, opts ...grpc.CallOption) (*Reaction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reaction)
+	err := c.cc.Invoke(ctx, Comments_CreateReaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentsClient) DeleteReaction(ctx context.Context, in *DeleteReactionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Comments_DeleteReaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentsClient) ListReactions(ctx context.Context, in *ListReactionsRequest, opts ...grpc.CallOption) (*ListReactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReactionsResponse)
+	err := c.cc.Invoke(ctx, Comments_ListReactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CommentsServer is the server API for Comments service.
 // All implementations should embed UnimplementedCommentsServer
 // for forward compatibility.
@@ -179,6 +218,12 @@ type CommentsServer interface {
 	GetCommentReplyCount(context.Context, *GetCommentReplyCountRequest) (*GetCommentReplyCountResponse, error)
 	// Lists all versions of a comment (edit history).
 	ListCommentVersions(context.Context, *ListCommentVersionsRequest) (*ListCommentVersionsResponse, error)
+	// Creates a reaction to a document, a block, or a comment.
+	CreateReaction(context.Context, *CreateReactionRequest) (*Reaction, error)
+	// Deletes a reaction.
+	DeleteReaction(context.Context, *DeleteReactionRequest) (*emptypb.Empty, error)
+	// Lists reactions for a given target, along with their aggregated counts.
+	ListReactions(context.Context, *ListReactionsRequest) (*ListReactionsResponse, error)
 }
 
 // UnimplementedCommentsServer should be embedded to have
@@ -215,6 +260,15 @@ func (UnimplementedCommentsServer) GetCommentReplyCount(context.Context, *GetCom
 func (UnimplementedCommentsServer) ListCommentVersions(context.Context, *ListCommentVersionsRequest) (*ListCommentVersionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListCommentVersions not implemented")
 }
+func (UnimplementedCommentsServer) CreateReaction(context.Context, *CreateReactionRequest) (*Reaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReaction not implemented")
+}
+func (UnimplementedCommentsServer) DeleteReaction(context.Context, *DeleteReactionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteReaction not implemented")
+}
+func (UnimplementedCommentsServer) ListReactions(context.Context, *ListReactionsRequest) (*ListReactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReactions not implemented")
+}
 func (UnimplementedCommentsServer) testEmbeddedByValue() {}
 
 // UnsafeCommentsServer may be embedded to opt out of forward compatibility for this service.
@@ -397,6 +451,60 @@ func _Comments_ListCommentVersions_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Comments_CreateReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServer).CreateReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Comments_CreateReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServer).CreateReaction(ctx, req.(*CreateReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comments_DeleteReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServer).DeleteReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Comments_DeleteReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServer).DeleteReaction(ctx, req.(*DeleteReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comments_ListReactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServer).ListReactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Comments_ListReactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServer).ListReactions(ctx, req.(*ListReactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Comments_ServiceDesc is the grpc.ServiceDesc for Comments service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -440,6 +548,18 @@ var Comments_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListCommentVersions",
 			Handler:    _Comments_ListCommentVersions_Handler,
 		},
+		{
+			MethodName: "CreateReaction",
+			Handler:    _Comments_CreateReaction_Handler,
+		},
+		{
+			MethodName: "DeleteReaction",
+			Handler:    _Comments_DeleteReaction_Handler,
+		},
+		{
+			MethodName: "ListReactions",
+			Handler:    _Comments_ListReactions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "documents/v3alpha/comments.proto",
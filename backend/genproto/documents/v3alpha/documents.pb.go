@@ -195,6 +195,118 @@ func (DocumentAttributeKind) EnumDescriptor() ([]byte, []int) {
 	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{2}
 }
 
+// Kind of the difference of a block between two versions.
+type BlockDiffKind int32
+
+const (
+	BlockDiffKind_BLOCK_DIFF_KIND_UNSPECIFIED BlockDiffKind = 0
+	// Block only exists in the target version.
+	BlockDiffKind_BLOCK_DIFF_KIND_ADDED BlockDiffKind = 1
+	// Block only exists in the base version.
+	BlockDiffKind_BLOCK_DIFF_KIND_REMOVED BlockDiffKind = 2
+	// Block exists in both versions but it was moved and/or modified.
+	BlockDiffKind_BLOCK_DIFF_KIND_CHANGED BlockDiffKind = 3
+)
+
+// Enum value maps for BlockDiffKind.
+var (
+	BlockDiffKind_name = map[int32]string{
+		0: "BLOCK_DIFF_KIND_UNSPECIFIED",
+		1: "BLOCK_DIFF_KIND_ADDED",
+		2: "BLOCK_DIFF_KIND_REMOVED",
+		3: "BLOCK_DIFF_KIND_CHANGED",
+	}
+	BlockDiffKind_value = map[string]int32{
+		"BLOCK_DIFF_KIND_UNSPECIFIED": 0,
+		"BLOCK_DIFF_KIND_ADDED":       1,
+		"BLOCK_DIFF_KIND_REMOVED":     2,
+		"BLOCK_DIFF_KIND_CHANGED":     3,
+	}
+)
+
+func (x BlockDiffKind) Enum() *BlockDiffKind {
+	p := new(BlockDiffKind)
+	*p = x
+	return p
+}
+
+func (x BlockDiffKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BlockDiffKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_documents_v3alpha_documents_proto_enumTypes[3].Descriptor()
+}
+
+func (BlockDiffKind) Type() protoreflect.EnumType {
+	return &file_documents_v3alpha_documents_proto_enumTypes[3]
+}
+
+func (x BlockDiffKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BlockDiffKind.Descriptor instead.
+func (BlockDiffKind) EnumDescriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{3}
+}
+
+// Kind of a text difference segment.
+type TextDiffKind int32
+
+const (
+	TextDiffKind_TEXT_DIFF_KIND_UNSPECIFIED TextDiffKind = 0
+	// Text present in both versions.
+	TextDiffKind_TEXT_DIFF_KIND_EQUAL TextDiffKind = 1
+	// Text only present in the target version.
+	TextDiffKind_TEXT_DIFF_KIND_INSERTED TextDiffKind = 2
+	// Text only present in the base version.
+	TextDiffKind_TEXT_DIFF_KIND_DELETED TextDiffKind = 3
+)
+
+// Enum value maps for TextDiffKind.
+var (
+	TextDiffKind_name = map[int32]string{
+		0: "TEXT_DIFF_KIND_UNSPECIFIED",
+		1: "TEXT_DIFF_KIND_EQUAL",
+		2: "TEXT_DIFF_KIND_INSERTED",
+		3: "TEXT_DIFF_KIND_DELETED",
+	}
+	TextDiffKind_value = map[string]int32{
+		"TEXT_DIFF_KIND_UNSPECIFIED": 0,
+		"TEXT_DIFF_KIND_EQUAL":       1,
+		"TEXT_DIFF_KIND_INSERTED":    2,
+		"TEXT_DIFF_KIND_DELETED":     3,
+	}
+)
+
+func (x TextDiffKind) Enum() *TextDiffKind {
+	p := new(TextDiffKind)
+	*p = x
+	return p
+}
+
+func (x TextDiffKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TextDiffKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_documents_v3alpha_documents_proto_enumTypes[4].Descriptor()
+}
+
+func (TextDiffKind) Type() protoreflect.EnumType {
+	return &file_documents_v3alpha_documents_proto_enumTypes[4]
+}
+
+func (x TextDiffKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TextDiffKind.Descriptor instead.
+func (TextDiffKind) EnumDescriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{4}
+}
+
 // Supported comparison operators.
 type DocumentFilter_Comparison_Operator int32
 
@@ -248,11 +360,11 @@ func (x DocumentFilter_Comparison_Operator) String() string {
 }
 
 func (DocumentFilter_Comparison_Operator) Descriptor() protoreflect.EnumDescriptor {
-	return file_documents_v3alpha_documents_proto_enumTypes[3].Descriptor()
+	return file_documents_v3alpha_documents_proto_enumTypes[5].Descriptor()
 }
 
 func (DocumentFilter_Comparison_Operator) Type() protoreflect.EnumType {
-	return &file_documents_v3alpha_documents_proto_enumTypes[3]
+	return &file_documents_v3alpha_documents_proto_enumTypes[5]
 }
 
 func (x DocumentFilter_Comparison_Operator) Number() protoreflect.EnumNumber {
@@ -646,8 +758,15 @@ type PrepareChangeResponse struct {
 	// The raw CBOR bytes of the Change blob without the signature field.
 	// The client must add the signer and signature fields before hashing.
 	UnsignedChange []byte `protobuf:"bytes,1,opt,name=unsigned_change,json=unsignedChange,proto3" json:"unsigned_change,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Number of member signatures the Ref for this change needs,
+	// when the document is the root of a multi-signature organization.
+	// The signatures are collected with the CreateRef API by passing the `partial_ref` between the members.
+	RequiredSignatures int32 `protobuf:"varint,2,opt,name=required_signatures,json=requiredSignatures,proto3" json:"required_signatures,omitempty"`
+	// Members of the organization who can sign the Ref.
+	// Only set along with `required_signatures`.
+	Members       []string `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PrepareChangeResponse) Reset() {
@@ -687,6 +806,20 @@ func (x *PrepareChangeResponse) GetUnsignedChange() []byte {
 	return nil
 }
 
+func (x *PrepareChangeResponse) GetRequiredSignatures() int32 {
+	if x != nil {
+		return x.RequiredSignatures
+	}
+	return 0
+}
+
+func (x *PrepareChangeResponse) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
 type DeleteDocumentRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Required. ID of the account to delete the document from.
@@ -3429,35 +3562,35 @@ func (x *GetDocumentChangeRequest) GetId() string {
 	return ""
 }
 
-// Request to update document's read status.
-type UpdateDocumentReadStatusRequest struct {
+// Request to compare two versions of a document.
+type DiffDocumentRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Required. ID of the account to update the document in.
+	// Required. ID of the account the document belongs to.
 	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
-	// Required. Path of the document to update.
+	// Required. Path of the document.
 	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
-	// Required. New read status of the document.
-	IsRead bool `protobuf:"varint,3,opt,name=is_read,json=isRead,proto3" json:"is_read,omitempty"`
-	// Optional. Whether the status should be updated recursively for children of the path.
-	IsRecursive   bool `protobuf:"varint,4,opt,name=is_recursive,json=isRecursive,proto3" json:"is_recursive,omitempty"`
+	// Required. Version to compare from.
+	BaseVersion string `protobuf:"bytes,3,opt,name=base_version,json=baseVersion,proto3" json:"base_version,omitempty"`
+	// Optional. Version to compare to. Defaults to the latest version.
+	TargetVersion string `protobuf:"bytes,4,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateDocumentReadStatusRequest) Reset() {
-	*x = UpdateDocumentReadStatusRequest{}
+func (x *DiffDocumentRequest) Reset() {
+	*x = DiffDocumentRequest{}
 	mi := &file_documents_v3alpha_documents_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateDocumentReadStatusRequest) String() string {
+func (x *DiffDocumentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateDocumentReadStatusRequest) ProtoMessage() {}
+func (*DiffDocumentRequest) ProtoMessage() {}
 
-func (x *UpdateDocumentReadStatusRequest) ProtoReflect() protoreflect.Message {
+func (x *DiffDocumentRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_documents_v3alpha_documents_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3469,83 +3602,69 @@ func (x *UpdateDocumentReadStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateDocumentReadStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateDocumentReadStatusRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DiffDocumentRequest.ProtoReflect.Descriptor instead.
+func (*DiffDocumentRequest) Descriptor() ([]byte, []int) {
 	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *UpdateDocumentReadStatusRequest) GetAccount() string {
+func (x *DiffDocumentRequest) GetAccount() string {
 	if x != nil {
 		return x.Account
 	}
 	return ""
 }
 
-func (x *UpdateDocumentReadStatusRequest) GetPath() string {
+func (x *DiffDocumentRequest) GetPath() string {
 	if x != nil {
 		return x.Path
 	}
 	return ""
 }
 
-func (x *UpdateDocumentReadStatusRequest) GetIsRead() bool {
+func (x *DiffDocumentRequest) GetBaseVersion() string {
 	if x != nil {
-		return x.IsRead
+		return x.BaseVersion
 	}
-	return false
+	return ""
 }
 
-func (x *UpdateDocumentReadStatusRequest) GetIsRecursive() bool {
+func (x *DiffDocumentRequest) GetTargetVersion() string {
 	if x != nil {
-		return x.IsRecursive
+		return x.TargetVersion
 	}
-	return false
+	return ""
 }
 
-// Request to create a Ref.
-type CreateRefRequest struct {
+// Differences between two versions of a document.
+type DiffDocumentResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Required. The ID of the account/space in which to create the Ref.
-	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
-	// Required. Path of the new Ref.
-	// Empty string means root document.
-	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
-	// Required. Target for the new Ref.
-	Target *RefTarget `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
-	// Required. Name of the signing key to use for signing the Ref.
-	SigningKeyName string `protobuf:"bytes,4,opt,name=signing_key_name,json=signingKeyName,proto3" json:"signing_key_name,omitempty"`
-	// Optional. ID of the Capability blob that grants the necessary rights to the signin key
-	// to write Refs for the requested account + path.
-	Capability string `protobuf:"bytes,5,opt,name=capability,proto3" json:"capability,omitempty"`
-	// Optional. A timestamp of the Ref blob.
-	// If not specified the server will use the current time.
-	// The provided timestamp will be rounded to the nearest millisecond,
-	// so the final timestamp in the resulting Ref blob may not be exactly as provided.
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	// Optional. The generation number for the Ref.
-	// If not specified, the server will use the generation number of the most recent generation,
-	// or will create a fresh generation number if there are no existing Refs for this path.
-	Generation int64 `protobuf:"varint,7,opt,name=generation,proto3" json:"generation,omitempty"`
-	// Output only. Visibility of the document.
-	Visibility    ResourceVisibility `protobuf:"varint,8,opt,name=visibility,proto3,enum=com.seed.documents.v3alpha.ResourceVisibility" json:"visibility,omitempty"`
+	// The version compared from.
+	BaseVersion string `protobuf:"bytes,1,opt,name=base_version,json=baseVersion,proto3" json:"base_version,omitempty"`
+	// The version compared to.
+	TargetVersion string `protobuf:"bytes,2,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"`
+	// Changed metadata attributes.
+	Metadata []*AttributeDiff `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty"`
+	// Blocks that differ between the versions,
+	// in the order they appear in the target version, followed by the removed blocks.
+	Blocks        []*BlockDiff `protobuf:"bytes,4,rep,name=blocks,proto3" json:"blocks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateRefRequest) Reset() {
-	*x = CreateRefRequest{}
+func (x *DiffDocumentResponse) Reset() {
+	*x = DiffDocumentResponse{}
 	mi := &file_documents_v3alpha_documents_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateRefRequest) String() string {
+func (x *DiffDocumentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRefRequest) ProtoMessage() {}
+func (*DiffDocumentResponse) ProtoMessage() {}
 
-func (x *CreateRefRequest) ProtoReflect() protoreflect.Message {
+func (x *DiffDocumentResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_documents_v3alpha_documents_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3557,90 +3676,88 @@ func (x *CreateRefRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRefRequest.ProtoReflect.Descriptor instead.
-func (*CreateRefRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DiffDocumentResponse.ProtoReflect.Descriptor instead.
+func (*DiffDocumentResponse) Descriptor() ([]byte, []int) {
 	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *CreateRefRequest) GetAccount() string {
+func (x *DiffDocumentResponse) GetBaseVersion() string {
 	if x != nil {
-		return x.Account
+		return x.BaseVersion
 	}
 	return ""
 }
 
-func (x *CreateRefRequest) GetPath() string {
+func (x *DiffDocumentResponse) GetTargetVersion() string {
 	if x != nil {
-		return x.Path
+		return x.TargetVersion
 	}
 	return ""
 }
 
-func (x *CreateRefRequest) GetTarget() *RefTarget {
+func (x *DiffDocumentResponse) GetMetadata() []*AttributeDiff {
 	if x != nil {
-		return x.Target
+		return x.Metadata
 	}
 	return nil
 }
 
-func (x *CreateRefRequest) GetSigningKeyName() string {
-	if x != nil {
-		return x.SigningKeyName
-	}
-	return ""
-}
-
-func (x *CreateRefRequest) GetCapability() string {
-	if x != nil {
-		return x.Capability
-	}
-	return ""
-}
-
-func (x *CreateRefRequest) GetTimestamp() *timestamppb.Timestamp {
+func (x *DiffDocumentResponse) GetBlocks() []*BlockDiff {
 	if x != nil {
-		return x.Timestamp
+		return x.Blocks
 	}
 	return nil
 }
 
-func (x *CreateRefRequest) GetGeneration() int64 {
-	if x != nil {
-		return x.Generation
-	}
-	return 0
-}
-
-func (x *CreateRefRequest) GetVisibility() ResourceVisibility {
-	if x != nil {
-		return x.Visibility
-	}
-	return ResourceVisibility_RESOURCE_VISIBILITY_UNSPECIFIED
-}
-
-// Request to get a Ref by ID.
-type GetRefRequest struct {
+// Difference of a single block between two versions.
+type BlockDiff struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Required. ID of the Ref blob to retrieve.
-	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetRefRequest) Reset() {
-	*x = GetRefRequest{}
+	// ID of the block.
+	BlockId string `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	// Kind of the difference.
+	Kind BlockDiffKind `protobuf:"varint,2,opt,name=kind,proto3,enum=com.seed.documents.v3alpha.BlockDiffKind" json:"kind,omitempty"`
+	// The block in the base version. Empty for added blocks.
+	BaseBlock *Block `protobuf:"bytes,3,opt,name=base_block,json=baseBlock,proto3" json:"base_block,omitempty"`
+	// The block in the target version. Empty for removed blocks.
+	TargetBlock *Block `protobuf:"bytes,4,opt,name=target_block,json=targetBlock,proto3" json:"target_block,omitempty"`
+	// Whether the block has a different parent or position among its siblings.
+	Moved bool `protobuf:"varint,5,opt,name=moved,proto3" json:"moved,omitempty"`
+	// Parent block of the block in the base version. Empty for top-level blocks.
+	BaseParent string `protobuf:"bytes,6,opt,name=base_parent,json=baseParent,proto3" json:"base_parent,omitempty"`
+	// Parent block of the block in the target version. Empty for top-level blocks.
+	TargetParent string `protobuf:"bytes,7,opt,name=target_parent,json=targetParent,proto3" json:"target_parent,omitempty"`
+	// Position of the block among its siblings in the base version.
+	BaseIndex int32 `protobuf:"varint,8,opt,name=base_index,json=baseIndex,proto3" json:"base_index,omitempty"`
+	// Position of the block among its siblings in the target version.
+	TargetIndex int32 `protobuf:"varint,9,opt,name=target_index,json=targetIndex,proto3" json:"target_index,omitempty"`
+	// Whether the content of the block is different (type, text, link, attributes or annotations).
+	Modified bool `protobuf:"varint,10,opt,name=modified,proto3" json:"modified,omitempty"`
+	// Character-level difference of the block text.
+	// Only set when the text is different.
+	Text []*TextDiff `protobuf:"bytes,11,rep,name=text,proto3" json:"text,omitempty"`
+	// Changed fields and attributes of the block.
+	// Type and link are reported as top-level keys, attributes under the "attributes" key.
+	Attributes []*AttributeDiff `protobuf:"bytes,12,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	// Whether the annotations of the block are different.
+	AnnotationsChanged bool `protobuf:"varint,13,opt,name=annotations_changed,json=annotationsChanged,proto3" json:"annotations_changed,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BlockDiff) Reset() {
+	*x = BlockDiff{}
 	mi := &file_documents_v3alpha_documents_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRefRequest) String() string {
+func (x *BlockDiff) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRefRequest) ProtoMessage() {}
+func (*BlockDiff) ProtoMessage() {}
 
-func (x *GetRefRequest) ProtoReflect() protoreflect.Message {
+func (x *BlockDiff) ProtoReflect() protoreflect.Message {
 	mi := &file_documents_v3alpha_documents_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3652,121 +3769,2503 @@ func (x *GetRefRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRefRequest.ProtoReflect.Descriptor instead.
-func (*GetRefRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BlockDiff.ProtoReflect.Descriptor instead.
+func (*BlockDiff) Descriptor() ([]byte, []int) {
 	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *GetRefRequest) GetId() string {
+func (x *BlockDiff) GetBlockId() string {
 	if x != nil {
-		return x.Id
+		return x.BlockId
 	}
 	return ""
 }
 
-// Request to list Refs for a document.
-type ListRefsRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Required. Account ID where the document is located.
-	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
-	// Required. Path of the document.
-	// Empty string means root document.
-	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
-	// Optional. Number of items to return per page.
-	// Pagination parameters are currently accepted but ignored.
-	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	// Optional. Token from a previous ListRefsResponse.next_page_token.
-	// Pagination parameters are currently accepted but ignored.
-	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListRefsRequest) Reset() {
-	*x = ListRefsRequest{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[49]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *BlockDiff) GetKind() BlockDiffKind {
+	if x != nil {
+		return x.Kind
+	}
+	return BlockDiffKind_BLOCK_DIFF_KIND_UNSPECIFIED
 }
 
-func (x *ListRefsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *BlockDiff) GetBaseBlock() *Block {
+	if x != nil {
+		return x.BaseBlock
+	}
+	return nil
 }
 
-func (*ListRefsRequest) ProtoMessage() {}
-
-func (x *ListRefsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[49]
+func (x *BlockDiff) GetTargetBlock() *Block {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.TargetBlock
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use ListRefsRequest.ProtoReflect.Descriptor instead.
-func (*ListRefsRequest) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{49}
+func (x *BlockDiff) GetMoved() bool {
+	if x != nil {
+		return x.Moved
+	}
+	return false
 }
 
-func (x *ListRefsRequest) GetAccount() string {
+func (x *BlockDiff) GetBaseParent() string {
 	if x != nil {
-		return x.Account
+		return x.BaseParent
 	}
 	return ""
 }
 
-func (x *ListRefsRequest) GetPath() string {
+func (x *BlockDiff) GetTargetParent() string {
 	if x != nil {
-		return x.Path
+		return x.TargetParent
 	}
 	return ""
 }
 
-func (x *ListRefsRequest) GetPageSize() int32 {
+func (x *BlockDiff) GetBaseIndex() int32 {
 	if x != nil {
-		return x.PageSize
+		return x.BaseIndex
 	}
 	return 0
 }
 
-func (x *ListRefsRequest) GetPageToken() string {
+func (x *BlockDiff) GetTargetIndex() int32 {
 	if x != nil {
-		return x.PageToken
+		return x.TargetIndex
 	}
-	return ""
+	return 0
 }
 
-// Response to list Refs for a document.
-type ListRefsResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Refs for the requested document.
-	Refs []*Ref `protobuf:"bytes,1,rep,name=refs,proto3" json:"refs,omitempty"`
-	// Optional. Token for fetching the next page.
-	// Empty while pagination is not implemented.
-	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *BlockDiff) GetModified() bool {
+	if x != nil {
+		return x.Modified
+	}
+	return false
+}
+
+func (x *BlockDiff) GetText() []*TextDiff {
+	if x != nil {
+		return x.Text
+	}
+	return nil
+}
+
+func (x *BlockDiff) GetAttributes() []*AttributeDiff {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *BlockDiff) GetAnnotationsChanged() bool {
+	if x != nil {
+		return x.AnnotationsChanged
+	}
+	return false
+}
+
+// Segment of a text difference.
+// Concatenating the equal and deleted segments gives the base text,
+// and concatenating the equal and inserted segments gives the target text.
+type TextDiff struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Kind of the segment.
+	Kind TextDiffKind `protobuf:"varint,1,opt,name=kind,proto3,enum=com.seed.documents.v3alpha.TextDiffKind" json:"kind,omitempty"`
+	// Text of the segment.
+	Text          string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TextDiff) Reset() {
+	*x = TextDiff{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextDiff) ProtoMessage() {}
+
+func (x *TextDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextDiff.ProtoReflect.Descriptor instead.
+func (*TextDiff) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *TextDiff) GetKind() TextDiffKind {
+	if x != nil {
+		return x.Kind
+	}
+	return TextDiffKind_TEXT_DIFF_KIND_UNSPECIFIED
+}
+
+func (x *TextDiff) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// Difference of a single attribute value.
+type AttributeDiff struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path of the attribute.
+	Key []string `protobuf:"bytes,1,rep,name=key,proto3" json:"key,omitempty"`
+	// Value in the base version. Empty if the attribute was added.
+	BaseValue *structpb.Value `protobuf:"bytes,2,opt,name=base_value,json=baseValue,proto3" json:"base_value,omitempty"`
+	// Value in the target version. Empty if the attribute was removed.
+	TargetValue   *structpb.Value `protobuf:"bytes,3,opt,name=target_value,json=targetValue,proto3" json:"target_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeDiff) Reset() {
+	*x = AttributeDiff{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeDiff) ProtoMessage() {}
+
+func (x *AttributeDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeDiff.ProtoReflect.Descriptor instead.
+func (*AttributeDiff) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *AttributeDiff) GetKey() []string {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *AttributeDiff) GetBaseValue() *structpb.Value {
+	if x != nil {
+		return x.BaseValue
+	}
+	return nil
+}
+
+func (x *AttributeDiff) GetTargetValue() *structpb.Value {
+	if x != nil {
+		return x.TargetValue
+	}
+	return nil
+}
+
+// Request to get the blame of a document.
+type GetDocumentBlameRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the account the document belongs to.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Required. Path of the document.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Optional. Version of the document. Defaults to the latest version.
+	Version       string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDocumentBlameRequest) Reset() {
+	*x = GetDocumentBlameRequest{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDocumentBlameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDocumentBlameRequest) ProtoMessage() {}
+
+func (x *GetDocumentBlameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDocumentBlameRequest.ProtoReflect.Descriptor instead.
+func (*GetDocumentBlameRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetDocumentBlameRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *GetDocumentBlameRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *GetDocumentBlameRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// Attribution of the blocks of a document version.
+type DocumentBlame struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Version of the document.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Blocks in the order they appear in the document, followed by the detached blocks.
+	Blocks        []*BlockBlame `protobuf:"bytes,2,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentBlame) Reset() {
+	*x = DocumentBlame{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentBlame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentBlame) ProtoMessage() {}
+
+func (x *DocumentBlame) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentBlame.ProtoReflect.Descriptor instead.
+func (*DocumentBlame) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DocumentBlame) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *DocumentBlame) GetBlocks() []*BlockBlame {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+// Attribution of a single block.
+type BlockBlame struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the block.
+	BlockId string `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	// Current revision of the block, same as in the Block message.
+	Revision string `protobuf:"bytes,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	// The change that set the current content of the block.
+	Content *Attribution `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// The change that last moved the block. Empty for detached blocks which were never moved.
+	Position *Attribution `protobuf:"bytes,4,opt,name=position,proto3" json:"position,omitempty"`
+	// The changes that last modified each of the block fields and attributes, sorted by key.
+	// Type and link are reported as top-level keys, attributes under the "attributes" key.
+	Attributes []*AttributeBlame `protobuf:"bytes,5,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	// The changes that wrote each range of the block text.
+	// Ranges are contiguous and cover the entire text.
+	Text          []*TextBlame `protobuf:"bytes,6,rep,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockBlame) Reset() {
+	*x = BlockBlame{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockBlame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockBlame) ProtoMessage() {}
+
+func (x *BlockBlame) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockBlame.ProtoReflect.Descriptor instead.
+func (*BlockBlame) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *BlockBlame) GetBlockId() string {
+	if x != nil {
+		return x.BlockId
+	}
+	return ""
+}
+
+func (x *BlockBlame) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+func (x *BlockBlame) GetContent() *Attribution {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *BlockBlame) GetPosition() *Attribution {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *BlockBlame) GetAttributes() []*AttributeBlame {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *BlockBlame) GetText() []*TextBlame {
+	if x != nil {
+		return x.Text
+	}
+	return nil
+}
+
+// Identifies the change that produced some part of a document.
+type Attribution struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CID of the change.
+	ChangeId string `protobuf:"bytes,1,opt,name=change_id,json=changeId,proto3" json:"change_id,omitempty"`
+	// Author of the change.
+	Author string `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	// Time when the change was created (as claimed by the author).
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attribution) Reset() {
+	*x = Attribution{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attribution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attribution) ProtoMessage() {}
+
+func (x *Attribution) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attribution.ProtoReflect.Descriptor instead.
+func (*Attribution) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *Attribution) GetChangeId() string {
+	if x != nil {
+		return x.ChangeId
+	}
+	return ""
+}
+
+func (x *Attribution) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Attribution) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+// Attribution of a single attribute of a block.
+type AttributeBlame struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path of the attribute.
+	Key []string `protobuf:"bytes,1,rep,name=key,proto3" json:"key,omitempty"`
+	// The change that last modified the attribute.
+	Attribution   *Attribution `protobuf:"bytes,2,opt,name=attribution,proto3" json:"attribution,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeBlame) Reset() {
+	*x = AttributeBlame{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeBlame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeBlame) ProtoMessage() {}
+
+func (x *AttributeBlame) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeBlame.ProtoReflect.Descriptor instead.
+func (*AttributeBlame) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *AttributeBlame) GetKey() []string {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *AttributeBlame) GetAttribution() *Attribution {
+	if x != nil {
+		return x.Attribution
+	}
+	return nil
+}
+
+// Attribution of a range of block text.
+type TextBlame struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Start offset of the range in Unicode code points, like annotation spans.
+	Start int32 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	// End offset of the range (exclusive).
+	End int32 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	// The change that wrote the text in the range.
+	Attribution   *Attribution `protobuf:"bytes,3,opt,name=attribution,proto3" json:"attribution,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TextBlame) Reset() {
+	*x = TextBlame{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextBlame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextBlame) ProtoMessage() {}
+
+func (x *TextBlame) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextBlame.ProtoReflect.Descriptor instead.
+func (*TextBlame) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *TextBlame) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *TextBlame) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *TextBlame) GetAttribution() *Attribution {
+	if x != nil {
+		return x.Attribution
+	}
+	return nil
+}
+
+// Request to revert a document to a previous version.
+type RevertDocumentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the account the document belongs to.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Required. Path of the document.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Required. Name of the signing key to use for signing the new change.
+	SigningKeyName string `protobuf:"bytes,3,opt,name=signing_key_name,json=signingKeyName,proto3" json:"signing_key_name,omitempty"`
+	// Required. Version of the document to restore.
+	TargetVersion string `protobuf:"bytes,4,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"`
+	// Optional. Version to create the new change on top of. Defaults to the latest version.
+	BaseVersion string `protobuf:"bytes,5,opt,name=base_version,json=baseVersion,proto3" json:"base_version,omitempty"`
+	// Optional. IDs of the blocks to restore. Their content and position are restored,
+	// and the ones that didn't exist in the target version are deleted.
+	// If empty, the entire document is restored, including the metadata.
+	BlockIds      []string `protobuf:"bytes,6,rep,name=block_ids,json=blockIds,proto3" json:"block_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertDocumentRequest) Reset() {
+	*x = RevertDocumentRequest{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertDocumentRequest) ProtoMessage() {}
+
+func (x *RevertDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertDocumentRequest.ProtoReflect.Descriptor instead.
+func (*RevertDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *RevertDocumentRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *RevertDocumentRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RevertDocumentRequest) GetSigningKeyName() string {
+	if x != nil {
+		return x.SigningKeyName
+	}
+	return ""
+}
+
+func (x *RevertDocumentRequest) GetTargetVersion() string {
+	if x != nil {
+		return x.TargetVersion
+	}
+	return ""
+}
+
+func (x *RevertDocumentRequest) GetBaseVersion() string {
+	if x != nil {
+		return x.BaseVersion
+	}
+	return ""
+}
+
+func (x *RevertDocumentRequest) GetBlockIds() []string {
+	if x != nil {
+		return x.BlockIds
+	}
+	return nil
+}
+
+// Response with the result of reverting a document.
+type RevertDocumentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CID of the new change.
+	ChangeId string `protobuf:"bytes,1,opt,name=change_id,json=changeId,proto3" json:"change_id,omitempty"`
+	// Ref pointing to the new change.
+	// For the root document of a multi-signature organization it may be partially signed,
+	// and must be co-signed by other members before it's published.
+	Ref *Ref `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	// The document after the revert.
+	Document      *Document `protobuf:"bytes,3,opt,name=document,proto3" json:"document,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertDocumentResponse) Reset() {
+	*x = RevertDocumentResponse{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertDocumentResponse) ProtoMessage() {}
+
+func (x *RevertDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertDocumentResponse.ProtoReflect.Descriptor instead.
+func (*RevertDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *RevertDocumentResponse) GetChangeId() string {
+	if x != nil {
+		return x.ChangeId
+	}
+	return ""
+}
+
+func (x *RevertDocumentResponse) GetRef() *Ref {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+func (x *RevertDocumentResponse) GetDocument() *Document {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+// Request to create or update a branch of a document.
+type CreateBranchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the account the document belongs to.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Required. Path of the document.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Required. Name of the branch.
+	// Names can use ASCII letters, digits, dots, dashes and underscores, up to 64 characters,
+	// and must start with a letter or a digit. The name "main" is reserved for the published version.
+	Branch string `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
+	// Required. Name of the signing key to use for signing the branch Ref.
+	SigningKeyName string `protobuf:"bytes,4,opt,name=signing_key_name,json=signingKeyName,proto3" json:"signing_key_name,omitempty"`
+	// Optional. Version the branch must point to.
+	// Defaults to the latest published version, which is how new branches are started.
+	Version string `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	// Optional. Visibility of the branch. Defaults to public.
+	// Private branches are only synced between the devices and the writers of the account,
+	// even if the document itself is public.
+	Visibility    ResourceVisibility `protobuf:"varint,6,opt,name=visibility,proto3,enum=com.seed.documents.v3alpha.ResourceVisibility" json:"visibility,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBranchRequest) Reset() {
+	*x = CreateBranchRequest{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBranchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBranchRequest) ProtoMessage() {}
+
+func (x *CreateBranchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBranchRequest.ProtoReflect.Descriptor instead.
+func (*CreateBranchRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CreateBranchRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *CreateBranchRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *CreateBranchRequest) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *CreateBranchRequest) GetSigningKeyName() string {
+	if x != nil {
+		return x.SigningKeyName
+	}
+	return ""
+}
+
+func (x *CreateBranchRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *CreateBranchRequest) GetVisibility() ResourceVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return ResourceVisibility_RESOURCE_VISIBILITY_UNSPECIFIED
+}
+
+// A named branch of a document.
+type Branch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the branch.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// ID of the account the document belongs to.
+	Account string `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	// Path of the document.
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	// Genesis change of the document.
+	Genesis string `protobuf:"bytes,4,opt,name=genesis,proto3" json:"genesis,omitempty"`
+	// Version the branch points to.
+	Version string `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	// Author of the latest Ref of the branch.
+	Author string `protobuf:"bytes,6,opt,name=author,proto3" json:"author,omitempty"`
+	// Time of the latest Ref of the branch.
+	UpdateTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// Visibility of the branch.
+	Visibility ResourceVisibility `protobuf:"varint,8,opt,name=visibility,proto3,enum=com.seed.documents.v3alpha.ResourceVisibility" json:"visibility,omitempty"`
+	// ID of the latest Ref of the branch.
+	RefId         string `protobuf:"bytes,9,opt,name=ref_id,json=refId,proto3" json:"ref_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Branch) Reset() {
+	*x = Branch{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Branch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Branch) ProtoMessage() {}
+
+func (x *Branch) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Branch.ProtoReflect.Descriptor instead.
+func (*Branch) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *Branch) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Branch) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *Branch) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Branch) GetGenesis() string {
+	if x != nil {
+		return x.Genesis
+	}
+	return ""
+}
+
+func (x *Branch) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Branch) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Branch) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *Branch) GetVisibility() ResourceVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return ResourceVisibility_RESOURCE_VISIBILITY_UNSPECIFIED
+}
+
+func (x *Branch) GetRefId() string {
+	if x != nil {
+		return x.RefId
+	}
+	return ""
+}
+
+// Request to list branches of a document.
+type ListBranchesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the account the document belongs to.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Required. Path of the document.
+	Path          string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBranchesRequest) Reset() {
+	*x = ListBranchesRequest{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBranchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBranchesRequest) ProtoMessage() {}
+
+func (x *ListBranchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBranchesRequest.ProtoReflect.Descriptor instead.
+func (*ListBranchesRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListBranchesRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *ListBranchesRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+// Response with the branches of a document.
+type ListBranchesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Branches sorted by name.
+	Branches      []*Branch `protobuf:"bytes,1,rep,name=branches,proto3" json:"branches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBranchesResponse) Reset() {
+	*x = ListBranchesResponse{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBranchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBranchesResponse) ProtoMessage() {}
+
+func (x *ListBranchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBranchesResponse.ProtoReflect.Descriptor instead.
+func (*ListBranchesResponse) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListBranchesResponse) GetBranches() []*Branch {
+	if x != nil {
+		return x.Branches
+	}
+	return nil
+}
+
+// Request to compare a branch with the published version of the document.
+type DiffBranchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the account the document belongs to.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Required. Path of the document.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Required. Name of the branch. It's the target of the resulting diff,
+	// and the published version is the base.
+	Branch        string `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffBranchRequest) Reset() {
+	*x = DiffBranchRequest{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffBranchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffBranchRequest) ProtoMessage() {}
+
+func (x *DiffBranchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffBranchRequest.ProtoReflect.Descriptor instead.
+func (*DiffBranchRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *DiffBranchRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *DiffBranchRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DiffBranchRequest) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+// Request to merge a branch into the published version of the document.
+type MergeBranchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the account the document belongs to.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Required. Path of the document.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Required. Name of the branch to merge.
+	Branch string `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
+	// Required. Name of the signing key to use for signing the new Ref.
+	SigningKeyName string `protobuf:"bytes,4,opt,name=signing_key_name,json=signingKeyName,proto3" json:"signing_key_name,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MergeBranchRequest) Reset() {
+	*x = MergeBranchRequest{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeBranchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeBranchRequest) ProtoMessage() {}
+
+func (x *MergeBranchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeBranchRequest.ProtoReflect.Descriptor instead.
+func (*MergeBranchRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *MergeBranchRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *MergeBranchRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *MergeBranchRequest) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *MergeBranchRequest) GetSigningKeyName() string {
+	if x != nil {
+		return x.SigningKeyName
+	}
+	return ""
+}
+
+// Request to move a tree of documents.
+type MoveDocumentTreeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the account the documents belong to.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Required. Path of the document tree to move.
+	// The document at this path, if any, and all the documents under it are moved.
+	SourcePath string `protobuf:"bytes,2,opt,name=source_path,json=sourcePath,proto3" json:"source_path,omitempty"`
+	// Required. New path for the document tree.
+	// It must not be inside the source tree, and no documents must exist at the new paths.
+	TargetPath string `protobuf:"bytes,3,opt,name=target_path,json=targetPath,proto3" json:"target_path,omitempty"`
+	// Required. Name of the signing key to use for signing the new Refs.
+	SigningKeyName string `protobuf:"bytes,4,opt,name=signing_key_name,json=signingKeyName,proto3" json:"signing_key_name,omitempty"`
+	// Optional. Whether to update the links to the moved documents
+	// in the documents of the same account.
+	RewriteLinks  bool `protobuf:"varint,5,opt,name=rewrite_links,json=rewriteLinks,proto3" json:"rewrite_links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveDocumentTreeRequest) Reset() {
+	*x = MoveDocumentTreeRequest{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveDocumentTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveDocumentTreeRequest) ProtoMessage() {}
+
+func (x *MoveDocumentTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveDocumentTreeRequest.ProtoReflect.Descriptor instead.
+func (*MoveDocumentTreeRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *MoveDocumentTreeRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *MoveDocumentTreeRequest) GetSourcePath() string {
+	if x != nil {
+		return x.SourcePath
+	}
+	return ""
+}
+
+func (x *MoveDocumentTreeRequest) GetTargetPath() string {
+	if x != nil {
+		return x.TargetPath
+	}
+	return ""
+}
+
+func (x *MoveDocumentTreeRequest) GetSigningKeyName() string {
+	if x != nil {
+		return x.SigningKeyName
+	}
+	return ""
+}
+
+func (x *MoveDocumentTreeRequest) GetRewriteLinks() bool {
+	if x != nil {
+		return x.RewriteLinks
+	}
+	return false
+}
+
+// Response with the result of moving a tree of documents.
+type MoveDocumentTreeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Moved documents sorted by their old path.
+	MovedDocuments []*MovedDocument `protobuf:"bytes,1,rep,name=moved_documents,json=movedDocuments,proto3" json:"moved_documents,omitempty"`
+	// Paths of the documents whose links were updated to point to the new paths.
+	RewrittenDocuments []string `protobuf:"bytes,2,rep,name=rewritten_documents,json=rewrittenDocuments,proto3" json:"rewritten_documents,omitempty"`
+	// Paths of the documents linking to the moved documents
+	// that couldn't be updated, because the signing key has no write access to them.
+	SkippedDocuments []string `protobuf:"bytes,3,rep,name=skipped_documents,json=skippedDocuments,proto3" json:"skipped_documents,omitempty"`
+	// Partially signed Refs of the documents whose links were updated,
+	// but which need more signatures to be published, i.e. the root document of a multi-signature organization.
+	// They must be passed to other members of the organization with the CreateRef API.
+	PartialRefs   []*Ref `protobuf:"bytes,4,rep,name=partial_refs,json=partialRefs,proto3" json:"partial_refs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveDocumentTreeResponse) Reset() {
+	*x = MoveDocumentTreeResponse{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveDocumentTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveDocumentTreeResponse) ProtoMessage() {}
+
+func (x *MoveDocumentTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveDocumentTreeResponse.ProtoReflect.Descriptor instead.
+func (*MoveDocumentTreeResponse) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *MoveDocumentTreeResponse) GetMovedDocuments() []*MovedDocument {
+	if x != nil {
+		return x.MovedDocuments
+	}
+	return nil
+}
+
+func (x *MoveDocumentTreeResponse) GetRewrittenDocuments() []string {
+	if x != nil {
+		return x.RewrittenDocuments
+	}
+	return nil
+}
+
+func (x *MoveDocumentTreeResponse) GetSkippedDocuments() []string {
+	if x != nil {
+		return x.SkippedDocuments
+	}
+	return nil
+}
+
+func (x *MoveDocumentTreeResponse) GetPartialRefs() []*Ref {
+	if x != nil {
+		return x.PartialRefs
+	}
+	return nil
+}
+
+// Describes a document moved by MoveDocumentTree.
+type MovedDocument struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Old path of the document.
+	SourcePath string `protobuf:"bytes,1,opt,name=source_path,json=sourcePath,proto3" json:"source_path,omitempty"`
+	// New path of the document.
+	TargetPath string `protobuf:"bytes,2,opt,name=target_path,json=targetPath,proto3" json:"target_path,omitempty"`
+	// The Ref published at the new path.
+	Ref *Ref `protobuf:"bytes,3,opt,name=ref,proto3" json:"ref,omitempty"`
+	// The redirect Ref published at the old path.
+	Redirect      *Ref `protobuf:"bytes,4,opt,name=redirect,proto3" json:"redirect,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MovedDocument) Reset() {
+	*x = MovedDocument{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MovedDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovedDocument) ProtoMessage() {}
+
+func (x *MovedDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovedDocument.ProtoReflect.Descriptor instead.
+func (*MovedDocument) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *MovedDocument) GetSourcePath() string {
+	if x != nil {
+		return x.SourcePath
+	}
+	return ""
+}
+
+func (x *MovedDocument) GetTargetPath() string {
+	if x != nil {
+		return x.TargetPath
+	}
+	return ""
+}
+
+func (x *MovedDocument) GetRef() *Ref {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+func (x *MovedDocument) GetRedirect() *Ref {
+	if x != nil {
+		return x.Redirect
+	}
+	return nil
+}
+
+// Request to copy a document.
+type CopyDocumentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the account the source document belongs to.
+	SourceAccount string `protobuf:"bytes,1,opt,name=source_account,json=sourceAccount,proto3" json:"source_account,omitempty"`
+	// Required. Path of the source document.
+	SourcePath string `protobuf:"bytes,2,opt,name=source_path,json=sourcePath,proto3" json:"source_path,omitempty"`
+	// Optional. Version of the source document to copy.
+	// Latest version is used by default.
+	// Documents under the source document are always copied at their latest versions.
+	SourceVersion string `protobuf:"bytes,3,opt,name=source_version,json=sourceVersion,proto3" json:"source_version,omitempty"`
+	// Optional. ID of the account to create the copy in.
+	// The source account is used by default.
+	TargetAccount string `protobuf:"bytes,4,opt,name=target_account,json=targetAccount,proto3" json:"target_account,omitempty"`
+	// Required. Path of the new document.
+	// No document must exist at this path, or at any of the paths of the copied children.
+	TargetPath string `protobuf:"bytes,5,opt,name=target_path,json=targetPath,proto3" json:"target_path,omitempty"`
+	// Required. Name of the signing key to use for signing the new documents.
+	SigningKeyName string `protobuf:"bytes,6,opt,name=signing_key_name,json=signingKeyName,proto3" json:"signing_key_name,omitempty"`
+	// Optional. Whether to also copy all the documents under the source document.
+	Recursive     bool `protobuf:"varint,7,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CopyDocumentRequest) Reset() {
+	*x = CopyDocumentRequest{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CopyDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyDocumentRequest) ProtoMessage() {}
+
+func (x *CopyDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyDocumentRequest.ProtoReflect.Descriptor instead.
+func (*CopyDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *CopyDocumentRequest) GetSourceAccount() string {
+	if x != nil {
+		return x.SourceAccount
+	}
+	return ""
+}
+
+func (x *CopyDocumentRequest) GetSourcePath() string {
+	if x != nil {
+		return x.SourcePath
+	}
+	return ""
+}
+
+func (x *CopyDocumentRequest) GetSourceVersion() string {
+	if x != nil {
+		return x.SourceVersion
+	}
+	return ""
+}
+
+func (x *CopyDocumentRequest) GetTargetAccount() string {
+	if x != nil {
+		return x.TargetAccount
+	}
+	return ""
+}
+
+func (x *CopyDocumentRequest) GetTargetPath() string {
+	if x != nil {
+		return x.TargetPath
+	}
+	return ""
+}
+
+func (x *CopyDocumentRequest) GetSigningKeyName() string {
+	if x != nil {
+		return x.SigningKeyName
+	}
+	return ""
+}
+
+func (x *CopyDocumentRequest) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
+}
+
+// Response with the result of copying documents.
+type CopyDocumentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Copied documents sorted by their source path, starting with the requested document.
+	CopiedDocuments []*CopiedDocument `protobuf:"bytes,1,rep,name=copied_documents,json=copiedDocuments,proto3" json:"copied_documents,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CopyDocumentResponse) Reset() {
+	*x = CopyDocumentResponse{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CopyDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyDocumentResponse) ProtoMessage() {}
+
+func (x *CopyDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyDocumentResponse.ProtoReflect.Descriptor instead.
+func (*CopyDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *CopyDocumentResponse) GetCopiedDocuments() []*CopiedDocument {
+	if x != nil {
+		return x.CopiedDocuments
+	}
+	return nil
+}
+
+// Describes a document created by CopyDocument.
+type CopiedDocument struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path of the source document.
+	SourcePath string `protobuf:"bytes,1,opt,name=source_path,json=sourcePath,proto3" json:"source_path,omitempty"`
+	// Version of the source document that was copied.
+	SourceVersion string `protobuf:"bytes,2,opt,name=source_version,json=sourceVersion,proto3" json:"source_version,omitempty"`
+	// Path of the new document.
+	TargetPath string `protobuf:"bytes,3,opt,name=target_path,json=targetPath,proto3" json:"target_path,omitempty"`
+	// The Ref of the new document.
+	Ref           *Ref `protobuf:"bytes,4,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CopiedDocument) Reset() {
+	*x = CopiedDocument{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CopiedDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopiedDocument) ProtoMessage() {}
+
+func (x *CopiedDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopiedDocument.ProtoReflect.Descriptor instead.
+func (*CopiedDocument) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *CopiedDocument) GetSourcePath() string {
+	if x != nil {
+		return x.SourcePath
+	}
+	return ""
+}
+
+func (x *CopiedDocument) GetSourceVersion() string {
+	if x != nil {
+		return x.SourceVersion
+	}
+	return ""
+}
+
+func (x *CopiedDocument) GetTargetPath() string {
+	if x != nil {
+		return x.TargetPath
+	}
+	return ""
+}
+
+func (x *CopiedDocument) GetRef() *Ref {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+// Request to create a document from a template.
+type CreateFromTemplateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the account the template belongs to.
+	TemplateAccount string `protobuf:"bytes,1,opt,name=template_account,json=templateAccount,proto3" json:"template_account,omitempty"`
+	// Required. Path of the template.
+	TemplatePath string `protobuf:"bytes,2,opt,name=template_path,json=templatePath,proto3" json:"template_path,omitempty"`
+	// Optional. Version of the template to use.
+	// Latest version is used by default.
+	TemplateVersion string `protobuf:"bytes,3,opt,name=template_version,json=templateVersion,proto3" json:"template_version,omitempty"`
+	// Optional. ID of the account to create the document in.
+	// The template account is used by default.
+	Account string `protobuf:"bytes,4,opt,name=account,proto3" json:"account,omitempty"`
+	// Required. Path of the new document.
+	Path string `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+	// Required. Name of the signing key to use for signing the new document.
+	SigningKeyName string `protobuf:"bytes,6,opt,name=signing_key_name,json=signingKeyName,proto3" json:"signing_key_name,omitempty"`
+	// Optional. Values for the {{variable}} placeholders of the template.
+	// Placeholders without a value are kept as is.
+	Variables map[string]string `protobuf:"bytes,7,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Optional. Visibility of the new document. Public by default.
+	Visibility    ResourceVisibility `protobuf:"varint,8,opt,name=visibility,proto3,enum=com.seed.documents.v3alpha.ResourceVisibility" json:"visibility,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFromTemplateRequest) Reset() {
+	*x = CreateFromTemplateRequest{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFromTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFromTemplateRequest) ProtoMessage() {}
+
+func (x *CreateFromTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFromTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateFromTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *CreateFromTemplateRequest) GetTemplateAccount() string {
+	if x != nil {
+		return x.TemplateAccount
+	}
+	return ""
+}
+
+func (x *CreateFromTemplateRequest) GetTemplatePath() string {
+	if x != nil {
+		return x.TemplatePath
+	}
+	return ""
+}
+
+func (x *CreateFromTemplateRequest) GetTemplateVersion() string {
+	if x != nil {
+		return x.TemplateVersion
+	}
+	return ""
+}
+
+func (x *CreateFromTemplateRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *CreateFromTemplateRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *CreateFromTemplateRequest) GetSigningKeyName() string {
+	if x != nil {
+		return x.SigningKeyName
+	}
+	return ""
+}
+
+func (x *CreateFromTemplateRequest) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *CreateFromTemplateRequest) GetVisibility() ResourceVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return ResourceVisibility_RESOURCE_VISIBILITY_UNSPECIFIED
+}
+
+// Request to update document's read status.
+type UpdateDocumentReadStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the account to update the document in.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Required. Path of the document to update.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Required. New read status of the document.
+	IsRead bool `protobuf:"varint,3,opt,name=is_read,json=isRead,proto3" json:"is_read,omitempty"`
+	// Optional. Whether the status should be updated recursively for children of the path.
+	IsRecursive   bool `protobuf:"varint,4,opt,name=is_recursive,json=isRecursive,proto3" json:"is_recursive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDocumentReadStatusRequest) Reset() {
+	*x = UpdateDocumentReadStatusRequest{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDocumentReadStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDocumentReadStatusRequest) ProtoMessage() {}
+
+func (x *UpdateDocumentReadStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDocumentReadStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDocumentReadStatusRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *UpdateDocumentReadStatusRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *UpdateDocumentReadStatusRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *UpdateDocumentReadStatusRequest) GetIsRead() bool {
+	if x != nil {
+		return x.IsRead
+	}
+	return false
+}
+
+func (x *UpdateDocumentReadStatusRequest) GetIsRecursive() bool {
+	if x != nil {
+		return x.IsRecursive
+	}
+	return false
+}
+
+// Request to create a Ref.
+type CreateRefRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The ID of the account/space in which to create the Ref.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Required. Path of the new Ref.
+	// Empty string means root document.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Required. Target for the new Ref.
+	Target *RefTarget `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	// Required. Name of the signing key to use for signing the Ref.
+	SigningKeyName string `protobuf:"bytes,4,opt,name=signing_key_name,json=signingKeyName,proto3" json:"signing_key_name,omitempty"`
+	// Optional. ID of the Capability blob that grants the necessary rights to the signin key
+	// to write Refs for the requested account + path.
+	Capability string `protobuf:"bytes,5,opt,name=capability,proto3" json:"capability,omitempty"`
+	// Optional. A timestamp of the Ref blob.
+	// If not specified the server will use the current time.
+	// The provided timestamp will be rounded to the nearest millisecond,
+	// so the final timestamp in the resulting Ref blob may not be exactly as provided.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Optional. The generation number for the Ref.
+	// If not specified, the server will use the generation number of the most recent generation,
+	// or will create a fresh generation number if there are no existing Refs for this path.
+	Generation int64 `protobuf:"varint,7,opt,name=generation,proto3" json:"generation,omitempty"`
+	// Output only. Visibility of the document.
+	Visibility ResourceVisibility `protobuf:"varint,8,opt,name=visibility,proto3,enum=com.seed.documents.v3alpha.ResourceVisibility" json:"visibility,omitempty"`
+	// Optional. Partially signed Ref of a multi-signature organization, obtained from another member.
+	// When specified, the signing key adds its signature to this Ref instead of creating a new one,
+	// and all the other fields except `signing_key_name` are ignored.
+	PartialRef    []byte `protobuf:"bytes,9,opt,name=partial_ref,json=partialRef,proto3" json:"partial_ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRefRequest) Reset() {
+	*x = CreateRefRequest{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRefRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRefRequest) ProtoMessage() {}
+
+func (x *CreateRefRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRefRequest.ProtoReflect.Descriptor instead.
+func (*CreateRefRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *CreateRefRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *CreateRefRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *CreateRefRequest) GetTarget() *RefTarget {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *CreateRefRequest) GetSigningKeyName() string {
+	if x != nil {
+		return x.SigningKeyName
+	}
+	return ""
+}
+
+func (x *CreateRefRequest) GetCapability() string {
+	if x != nil {
+		return x.Capability
+	}
+	return ""
+}
+
+func (x *CreateRefRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *CreateRefRequest) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *CreateRefRequest) GetVisibility() ResourceVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return ResourceVisibility_RESOURCE_VISIBILITY_UNSPECIFIED
+}
+
+func (x *CreateRefRequest) GetPartialRef() []byte {
+	if x != nil {
+		return x.PartialRef
+	}
+	return nil
+}
+
+// Request to get a Ref by ID.
+type GetRefRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the Ref blob to retrieve.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRefRequest) Reset() {
+	*x = GetRefRequest{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRefRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRefRequest) ProtoMessage() {}
+
+func (x *GetRefRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRefRequest.ProtoReflect.Descriptor instead.
+func (*GetRefRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *GetRefRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Request to list Refs for a document.
+type ListRefsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. Account ID where the document is located.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Required. Path of the document.
+	// Empty string means root document.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Optional. Number of items to return per page.
+	// Pagination parameters are currently accepted but ignored.
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Optional. Token from a previous ListRefsResponse.next_page_token.
+	// Pagination parameters are currently accepted but ignored.
+	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRefsRequest) Reset() {
+	*x = ListRefsRequest{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRefsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRefsRequest) ProtoMessage() {}
+
+func (x *ListRefsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRefsRequest.ProtoReflect.Descriptor instead.
+func (*ListRefsRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ListRefsRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *ListRefsRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ListRefsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRefsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Response to list Refs for a document.
+type ListRefsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Refs for the requested document.
+	Refs []*Ref `protobuf:"bytes,1,rep,name=refs,proto3" json:"refs,omitempty"`
+	// Optional. Token for fetching the next page.
+	// Empty while pagination is not implemented.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListRefsResponse) Reset() {
 	*x = ListRefsResponse{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[50]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRefsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRefsResponse) ProtoMessage() {}
+
+func (x *ListRefsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRefsResponse.ProtoReflect.Descriptor instead.
+func (*ListRefsResponse) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ListRefsResponse) GetRefs() []*Ref {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+func (x *ListRefsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Request to schedule a Ref for publishing.
+type ScheduleRefRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. Raw bytes of the signed Ref blob.
+	Ref []byte `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	// Optional. Blobs the Ref depends on, like the Changes it points to,
+	// that must not be published before the Ref.
+	// Heads of the Ref must either be among these blobs or already exist locally.
+	Blobs []*ScheduledBlob `protobuf:"bytes,2,rep,name=blobs,proto3" json:"blobs,omitempty"`
+	// Required. Time when the Ref must be published.
+	PublishTime   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=publish_time,json=publishTime,proto3" json:"publish_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleRefRequest) Reset() {
+	*x = ScheduleRefRequest{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleRefRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleRefRequest) ProtoMessage() {}
+
+func (x *ScheduleRefRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleRefRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleRefRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ScheduleRefRequest) GetRef() []byte {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+func (x *ScheduleRefRequest) GetBlobs() []*ScheduledBlob {
+	if x != nil {
+		return x.Blobs
+	}
+	return nil
+}
+
+func (x *ScheduleRefRequest) GetPublishTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishTime
+	}
+	return nil
+}
+
+// Raw blob held back together with a scheduled Ref.
+type ScheduledBlob struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional. CID of the blob (the server will verify it).
+	// If not provided, the data is assumed to be DAG-CBOR encoded, and the server will generate a CID
+	// using its default hash function.
+	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Required. Raw data of the blob.
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledBlob) Reset() {
+	*x = ScheduledBlob{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledBlob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledBlob) ProtoMessage() {}
+
+func (x *ScheduledBlob) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledBlob.ProtoReflect.Descriptor instead.
+func (*ScheduledBlob) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ScheduledBlob) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *ScheduledBlob) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Request to list scheduled Refs.
+type ListScheduledRefsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional. Only list Refs for this account.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Optional. Number of results per page.
+	// Ignored while pagination is not implemented.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Optional. Token for the page to return.
+	// Ignored while pagination is not implemented.
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledRefsRequest) Reset() {
+	*x = ListScheduledRefsRequest{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledRefsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledRefsRequest) ProtoMessage() {}
+
+func (x *ListScheduledRefsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledRefsRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledRefsRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ListScheduledRefsRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *ListScheduledRefsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListScheduledRefsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Response with scheduled Refs.
+type ListScheduledRefsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Scheduled Refs ordered by their publish time.
+	ScheduledRefs []*ScheduledRef `protobuf:"bytes,1,rep,name=scheduled_refs,json=scheduledRefs,proto3" json:"scheduled_refs,omitempty"`
+	// Optional. Token for fetching the next page.
+	// Empty while pagination is not implemented.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledRefsResponse) Reset() {
+	*x = ListScheduledRefsResponse{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledRefsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledRefsResponse) ProtoMessage() {}
+
+func (x *ListScheduledRefsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledRefsResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledRefsResponse) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ListScheduledRefsResponse) GetScheduledRefs() []*ScheduledRef {
+	if x != nil {
+		return x.ScheduledRefs
+	}
+	return nil
+}
+
+func (x *ListScheduledRefsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Request to cancel a scheduled Ref.
+type CancelScheduledRefRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. ID of the scheduled Ref blob.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledRefRequest) Reset() {
+	*x = CancelScheduledRefRequest{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledRefRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledRefRequest) ProtoMessage() {}
+
+func (x *CancelScheduledRefRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledRefRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledRefRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *CancelScheduledRefRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// A Ref waiting to be published.
+type ScheduledRef struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The scheduled Ref. It's not available with the GetRef API until it's published.
+	Ref *Ref `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	// Time when the Ref will be published.
+	PublishTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=publish_time,json=publishTime,proto3" json:"publish_time,omitempty"`
+	// Time when the Ref was scheduled.
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// CIDs of the blobs held back together with the Ref.
+	Blobs []string `protobuf:"bytes,4,rep,name=blobs,proto3" json:"blobs,omitempty"`
+	// Error of the last attempt to publish the Ref.
+	// Refs that failed to be published stay scheduled, and are retried with a backoff until they're published or cancelled.
+	PublishError string `protobuf:"bytes,5,opt,name=publish_error,json=publishError,proto3" json:"publish_error,omitempty"`
+	// Time of the next attempt to publish the Ref after a failed one.
+	RetryTime     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=retry_time,json=retryTime,proto3" json:"retry_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledRef) Reset() {
+	*x = ScheduledRef{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRefsResponse) String() string {
+func (x *ScheduledRef) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRefsResponse) ProtoMessage() {}
+func (*ScheduledRef) ProtoMessage() {}
 
-func (x *ListRefsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[50]
+func (x *ScheduledRef) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3777,25 +6276,53 @@ func (x *ListRefsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRefsResponse.ProtoReflect.Descriptor instead.
-func (*ListRefsResponse) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{50}
+// Deprecated: Use ScheduledRef.ProtoReflect.Descriptor instead.
+func (*ScheduledRef) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{82}
 }
 
-func (x *ListRefsResponse) GetRefs() []*Ref {
+func (x *ScheduledRef) GetRef() *Ref {
 	if x != nil {
-		return x.Refs
+		return x.Ref
 	}
 	return nil
 }
 
-func (x *ListRefsResponse) GetNextPageToken() string {
+func (x *ScheduledRef) GetPublishTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.NextPageToken
+		return x.PublishTime
+	}
+	return nil
+}
+
+func (x *ScheduledRef) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *ScheduledRef) GetBlobs() []string {
+	if x != nil {
+		return x.Blobs
+	}
+	return nil
+}
+
+func (x *ScheduledRef) GetPublishError() string {
+	if x != nil {
+		return x.PublishError
 	}
 	return ""
 }
 
+func (x *ScheduledRef) GetRetryTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RetryTime
+	}
+	return nil
+}
+
 // Information about a particular document version.
 type DocumentChangeInfo struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -3813,7 +6340,7 @@ type DocumentChangeInfo struct {
 
 func (x *DocumentChangeInfo) Reset() {
 	*x = DocumentChangeInfo{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[51]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3825,7 +6352,7 @@ func (x *DocumentChangeInfo) String() string {
 func (*DocumentChangeInfo) ProtoMessage() {}
 
 func (x *DocumentChangeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[51]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3838,7 +6365,7 @@ func (x *DocumentChangeInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentChangeInfo.ProtoReflect.Descriptor instead.
 func (*DocumentChangeInfo) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{51}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{83}
 }
 
 func (x *DocumentChangeInfo) GetId() string {
@@ -3916,13 +6443,16 @@ type DocumentInfo struct {
 	// Unset means the indexer hasn't derived it (yet); an empty string means
 	// the document is known to have no content image.
 	FirstImageInContent *string `protobuf:"bytes,15,opt,name=first_image_in_content,json=firstImageInContent,proto3,oneof" json:"first_image_in_content,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	// Output only. Aggregated counts of the reactions to the document itself,
+	// excluding the reactions to its blocks and comments.
+	Reactions     []*ReactionCount `protobuf:"bytes,16,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DocumentInfo) Reset() {
 	*x = DocumentInfo{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[52]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3934,7 +6464,7 @@ func (x *DocumentInfo) String() string {
 func (*DocumentInfo) ProtoMessage() {}
 
 func (x *DocumentInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[52]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3947,7 +6477,7 @@ func (x *DocumentInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentInfo.ProtoReflect.Descriptor instead.
 func (*DocumentInfo) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{52}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{84}
 }
 
 func (x *DocumentInfo) GetAccount() string {
@@ -4048,6 +6578,68 @@ func (x *DocumentInfo) GetFirstImageInContent() string {
 	return ""
 }
 
+func (x *DocumentInfo) GetReactions() []*ReactionCount {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+// Number of distinct authors who reacted with a given value.
+type ReactionCount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Emoji or a short token of the reaction.
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// Number of distinct authors who reacted with this value.
+	Count         int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactionCount) Reset() {
+	*x = ReactionCount{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactionCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionCount) ProtoMessage() {}
+
+func (x *ReactionCount) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionCount.ProtoReflect.Descriptor instead.
+func (*ReactionCount) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *ReactionCount) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ReactionCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
 // Information about the generation of a document.
 type GenerationInfo struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -4063,7 +6655,7 @@ type GenerationInfo struct {
 
 func (x *GenerationInfo) Reset() {
 	*x = GenerationInfo{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[53]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4075,7 +6667,7 @@ func (x *GenerationInfo) String() string {
 func (*GenerationInfo) ProtoMessage() {}
 
 func (x *GenerationInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[53]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4088,7 +6680,7 @@ func (x *GenerationInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerationInfo.ProtoReflect.Descriptor instead.
 func (*GenerationInfo) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{53}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{86}
 }
 
 func (x *GenerationInfo) GetGenesis() string {
@@ -4128,7 +6720,7 @@ type ActivitySummary struct {
 
 func (x *ActivitySummary) Reset() {
 	*x = ActivitySummary{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[54]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4140,7 +6732,7 @@ func (x *ActivitySummary) String() string {
 func (*ActivitySummary) ProtoMessage() {}
 
 func (x *ActivitySummary) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[54]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4153,7 +6745,7 @@ func (x *ActivitySummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ActivitySummary.ProtoReflect.Descriptor instead.
 func (*ActivitySummary) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{54}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{87}
 }
 
 func (x *ActivitySummary) GetLatestCommentTime() *timestamppb.Timestamp {
@@ -4213,7 +6805,7 @@ type Breadcrumb struct {
 
 func (x *Breadcrumb) Reset() {
 	*x = Breadcrumb{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[55]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4225,7 +6817,7 @@ func (x *Breadcrumb) String() string {
 func (*Breadcrumb) ProtoMessage() {}
 
 func (x *Breadcrumb) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[55]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4238,7 +6830,7 @@ func (x *Breadcrumb) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Breadcrumb.ProtoReflect.Descriptor instead.
 func (*Breadcrumb) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{55}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{88}
 }
 
 func (x *Breadcrumb) GetName() string {
@@ -4297,7 +6889,7 @@ type Document struct {
 
 func (x *Document) Reset() {
 	*x = Document{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[56]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4309,7 +6901,7 @@ func (x *Document) String() string {
 func (*Document) ProtoMessage() {}
 
 func (x *Document) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[56]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4322,7 +6914,7 @@ func (x *Document) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Document.ProtoReflect.Descriptor instead.
 func (*Document) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{56}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{89}
 }
 
 func (x *Document) GetAccount() string {
@@ -4422,7 +7014,7 @@ type BlockNode struct {
 
 func (x *BlockNode) Reset() {
 	*x = BlockNode{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[57]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4434,7 +7026,7 @@ func (x *BlockNode) String() string {
 func (*BlockNode) ProtoMessage() {}
 
 func (x *BlockNode) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[57]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4447,7 +7039,7 @@ func (x *BlockNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockNode.ProtoReflect.Descriptor instead.
 func (*BlockNode) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{57}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{90}
 }
 
 func (x *BlockNode) GetBlock() *Block {
@@ -4496,7 +7088,7 @@ type Block struct {
 
 func (x *Block) Reset() {
 	*x = Block{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[58]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4508,7 +7100,7 @@ func (x *Block) String() string {
 func (*Block) ProtoMessage() {}
 
 func (x *Block) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[58]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4521,7 +7113,7 @@ func (x *Block) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Block.ProtoReflect.Descriptor instead.
 func (*Block) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{58}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{91}
 }
 
 func (x *Block) GetId() string {
@@ -4602,7 +7194,7 @@ type Annotation struct {
 
 func (x *Annotation) Reset() {
 	*x = Annotation{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[59]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4614,7 +7206,7 @@ func (x *Annotation) String() string {
 func (*Annotation) ProtoMessage() {}
 
 func (x *Annotation) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[59]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4627,7 +7219,7 @@ func (x *Annotation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Annotation.ProtoReflect.Descriptor instead.
 func (*Annotation) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{59}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{92}
 }
 
 func (x *Annotation) GetType() string {
@@ -4675,6 +7267,7 @@ type DocumentChange struct {
 	//	*DocumentChange_ReplaceBlock
 	//	*DocumentChange_DeleteBlock
 	//	*DocumentChange_SetAttribute_
+	//	*DocumentChange_SpliceText_
 	Op            isDocumentChange_Op `protobuf_oneof:"op"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -4682,7 +7275,7 @@ type DocumentChange struct {
 
 func (x *DocumentChange) Reset() {
 	*x = DocumentChange{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[60]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4694,7 +7287,7 @@ func (x *DocumentChange) String() string {
 func (*DocumentChange) ProtoMessage() {}
 
 func (x *DocumentChange) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[60]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4707,7 +7300,7 @@ func (x *DocumentChange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentChange.ProtoReflect.Descriptor instead.
 func (*DocumentChange) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{60}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{93}
 }
 
 func (x *DocumentChange) GetOp() isDocumentChange_Op {
@@ -4762,6 +7355,15 @@ func (x *DocumentChange) GetSetAttribute() *DocumentChange_SetAttribute {
 	return nil
 }
 
+func (x *DocumentChange) GetSpliceText() *DocumentChange_SpliceText {
+	if x != nil {
+		if x, ok := x.Op.(*DocumentChange_SpliceText_); ok {
+			return x.SpliceText
+		}
+	}
+	return nil
+}
+
 type isDocumentChange_Op interface {
 	isDocumentChange_Op()
 }
@@ -4793,6 +7395,11 @@ type DocumentChange_SetAttribute_ struct {
 	SetAttribute *DocumentChange_SetAttribute `protobuf:"bytes,5,opt,name=set_attribute,json=setAttribute,proto3,oneof"`
 }
 
+type DocumentChange_SpliceText_ struct {
+	// Edits the text of a block at the character level.
+	SpliceText *DocumentChange_SpliceText `protobuf:"bytes,6,opt,name=splice_text,json=spliceText,proto3,oneof"`
+}
+
 func (*DocumentChange_SetMetadata_) isDocumentChange_Op() {}
 
 func (*DocumentChange_MoveBlock_) isDocumentChange_Op() {}
@@ -4803,6 +7410,8 @@ func (*DocumentChange_DeleteBlock) isDocumentChange_Op() {}
 
 func (*DocumentChange_SetAttribute_) isDocumentChange_Op() {}
 
+func (*DocumentChange_SpliceText_) isDocumentChange_Op() {}
+
 // Description of a Ref blob.
 type Ref struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -4822,13 +7431,21 @@ type Ref struct {
 	Timestamp *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	// Information about the generation of the Ref.
 	GenerationInfo *GenerationInfo `protobuf:"bytes,8,opt,name=generation_info,json=generationInfo,proto3" json:"generation_info,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Other members of a multi-signature organization who signed the Ref.
+	Cosigners []string `protobuf:"bytes,9,rep,name=cosigners,proto3" json:"cosigners,omitempty"`
+	// Output only. Raw bytes of the Ref, when it doesn't have enough signatures to be published yet.
+	// In this case the ID is empty, and the Ref must be passed to other members
+	// of the organization with the CreateRef API to collect the remaining signatures.
+	PartialRef []byte `protobuf:"bytes,10,opt,name=partial_ref,json=partialRef,proto3" json:"partial_ref,omitempty"`
+	// Name of the branch the Ref is for. Empty for the published version of the document.
+	Branch        string `protobuf:"bytes,11,opt,name=branch,proto3" json:"branch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Ref) Reset() {
 	*x = Ref{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[61]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4840,7 +7457,7 @@ func (x *Ref) String() string {
 func (*Ref) ProtoMessage() {}
 
 func (x *Ref) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[61]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4853,7 +7470,7 @@ func (x *Ref) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Ref.ProtoReflect.Descriptor instead.
 func (*Ref) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{61}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{94}
 }
 
 func (x *Ref) GetId() string {
@@ -4912,6 +7529,27 @@ func (x *Ref) GetGenerationInfo() *GenerationInfo {
 	return nil
 }
 
+func (x *Ref) GetCosigners() []string {
+	if x != nil {
+		return x.Cosigners
+	}
+	return nil
+}
+
+func (x *Ref) GetPartialRef() []byte {
+	if x != nil {
+		return x.PartialRef
+	}
+	return nil
+}
+
+func (x *Ref) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
 // Description of where the Ref points to.
 type RefTarget struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -4929,7 +7567,7 @@ type RefTarget struct {
 
 func (x *RefTarget) Reset() {
 	*x = RefTarget{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[62]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4941,7 +7579,7 @@ func (x *RefTarget) String() string {
 func (*RefTarget) ProtoMessage() {}
 
 func (x *RefTarget) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[62]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4954,7 +7592,7 @@ func (x *RefTarget) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefTarget.ProtoReflect.Descriptor instead.
 func (*RefTarget) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{62}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{95}
 }
 
 func (x *RefTarget) GetTarget() isRefTarget_Target {
@@ -5032,7 +7670,7 @@ type DocumentFilter_And struct {
 
 func (x *DocumentFilter_And) Reset() {
 	*x = DocumentFilter_And{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[65]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5044,7 +7682,7 @@ func (x *DocumentFilter_And) String() string {
 func (*DocumentFilter_And) ProtoMessage() {}
 
 func (x *DocumentFilter_And) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[65]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5078,7 +7716,7 @@ type DocumentFilter_Or struct {
 
 func (x *DocumentFilter_Or) Reset() {
 	*x = DocumentFilter_Or{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[66]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5090,7 +7728,7 @@ func (x *DocumentFilter_Or) String() string {
 func (*DocumentFilter_Or) ProtoMessage() {}
 
 func (x *DocumentFilter_Or) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[66]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5124,7 +7762,7 @@ type DocumentFilter_Not struct {
 
 func (x *DocumentFilter_Not) Reset() {
 	*x = DocumentFilter_Not{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[67]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5136,7 +7774,7 @@ func (x *DocumentFilter_Not) String() string {
 func (*DocumentFilter_Not) ProtoMessage() {}
 
 func (x *DocumentFilter_Not) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[67]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5174,7 +7812,7 @@ type DocumentFilter_Comparison struct {
 
 func (x *DocumentFilter_Comparison) Reset() {
 	*x = DocumentFilter_Comparison{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[68]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5186,7 +7824,7 @@ func (x *DocumentFilter_Comparison) String() string {
 func (*DocumentFilter_Comparison) ProtoMessage() {}
 
 func (x *DocumentFilter_Comparison) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[68]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5234,7 +7872,7 @@ type DocumentFilter_Presence struct {
 
 func (x *DocumentFilter_Presence) Reset() {
 	*x = DocumentFilter_Presence{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[69]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5246,7 +7884,7 @@ func (x *DocumentFilter_Presence) String() string {
 func (*DocumentFilter_Presence) ProtoMessage() {}
 
 func (x *DocumentFilter_Presence) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[69]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5286,7 +7924,7 @@ type DocumentFilter_StringMatch struct {
 
 func (x *DocumentFilter_StringMatch) Reset() {
 	*x = DocumentFilter_StringMatch{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[70]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5298,7 +7936,7 @@ func (x *DocumentFilter_StringMatch) String() string {
 func (*DocumentFilter_StringMatch) ProtoMessage() {}
 
 func (x *DocumentFilter_StringMatch) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[70]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5355,7 +7993,7 @@ type DocumentFilter_URLMatch struct {
 
 func (x *DocumentFilter_URLMatch) Reset() {
 	*x = DocumentFilter_URLMatch{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[71]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5367,7 +8005,7 @@ func (x *DocumentFilter_URLMatch) String() string {
 func (*DocumentFilter_URLMatch) ProtoMessage() {}
 
 func (x *DocumentFilter_URLMatch) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[71]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5408,7 +8046,7 @@ type DocumentFilter_SpaceMatch struct {
 
 func (x *DocumentFilter_SpaceMatch) Reset() {
 	*x = DocumentFilter_SpaceMatch{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[72]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5420,7 +8058,7 @@ func (x *DocumentFilter_SpaceMatch) String() string {
 func (*DocumentFilter_SpaceMatch) ProtoMessage() {}
 
 func (x *DocumentFilter_SpaceMatch) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[72]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5456,7 +8094,7 @@ type DocumentFilter_PathMatch struct {
 
 func (x *DocumentFilter_PathMatch) Reset() {
 	*x = DocumentFilter_PathMatch{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[73]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5468,7 +8106,7 @@ func (x *DocumentFilter_PathMatch) String() string {
 func (*DocumentFilter_PathMatch) ProtoMessage() {}
 
 func (x *DocumentFilter_PathMatch) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[73]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5515,7 +8153,7 @@ type DocumentChange_MoveBlock struct {
 
 func (x *DocumentChange_MoveBlock) Reset() {
 	*x = DocumentChange_MoveBlock{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[75]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[109]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5527,7 +8165,7 @@ func (x *DocumentChange_MoveBlock) String() string {
 func (*DocumentChange_MoveBlock) ProtoMessage() {}
 
 func (x *DocumentChange_MoveBlock) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[75]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[109]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5540,7 +8178,7 @@ func (x *DocumentChange_MoveBlock) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentChange_MoveBlock.ProtoReflect.Descriptor instead.
 func (*DocumentChange_MoveBlock) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{60, 0}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{93, 0}
 }
 
 func (x *DocumentChange_MoveBlock) GetBlockId() string {
@@ -5578,7 +8216,7 @@ type DocumentChange_SetMetadata struct {
 
 func (x *DocumentChange_SetMetadata) Reset() {
 	*x = DocumentChange_SetMetadata{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[76]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[110]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5590,7 +8228,7 @@ func (x *DocumentChange_SetMetadata) String() string {
 func (*DocumentChange_SetMetadata) ProtoMessage() {}
 
 func (x *DocumentChange_SetMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[76]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[110]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5603,7 +8241,7 @@ func (x *DocumentChange_SetMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentChange_SetMetadata.ProtoReflect.Descriptor instead.
 func (*DocumentChange_SetMetadata) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{60, 1}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{93, 1}
 }
 
 func (x *DocumentChange_SetMetadata) GetKey() string {
@@ -5646,7 +8284,7 @@ type DocumentChange_SetAttribute struct {
 
 func (x *DocumentChange_SetAttribute) Reset() {
 	*x = DocumentChange_SetAttribute{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[77]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[111]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5658,7 +8296,7 @@ func (x *DocumentChange_SetAttribute) String() string {
 func (*DocumentChange_SetAttribute) ProtoMessage() {}
 
 func (x *DocumentChange_SetAttribute) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[77]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[111]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5671,7 +8309,7 @@ func (x *DocumentChange_SetAttribute) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentChange_SetAttribute.ProtoReflect.Descriptor instead.
 func (*DocumentChange_SetAttribute) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{60, 2}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{93, 2}
 }
 
 func (x *DocumentChange_SetAttribute) GetBlockId() string {
@@ -5759,6 +8397,82 @@ func (*DocumentChange_SetAttribute_BoolValue) isDocumentChange_SetAttribute_Valu
 
 func (*DocumentChange_SetAttribute_NullValue) isDocumentChange_SetAttribute_Value() {}
 
+// Operation to edit the text of an existing block at the character level.
+// Unlike replacing the block, concurrent splices of the same block are merged,
+// and the annotations of the block are adjusted to the edited text.
+// A block can only be spliced once per change, and not together with replacing it.
+type DocumentChange_SpliceText struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the block to edit.
+	BlockId string `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	// Position in the text of the block where the edit starts, in Unicode code points.
+	Offset int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Number of characters to delete at the offset, in Unicode code points.
+	DeleteCount int32 `protobuf:"varint,3,opt,name=delete_count,json=deleteCount,proto3" json:"delete_count,omitempty"`
+	// Text to insert at the offset, after deleting the characters.
+	Insert        string `protobuf:"bytes,4,opt,name=insert,proto3" json:"insert,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentChange_SpliceText) Reset() {
+	*x = DocumentChange_SpliceText{}
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentChange_SpliceText) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentChange_SpliceText) ProtoMessage() {}
+
+func (x *DocumentChange_SpliceText) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentChange_SpliceText.ProtoReflect.Descriptor instead.
+func (*DocumentChange_SpliceText) Descriptor() ([]byte, []int) {
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{93, 3}
+}
+
+func (x *DocumentChange_SpliceText) GetBlockId() string {
+	if x != nil {
+		return x.BlockId
+	}
+	return ""
+}
+
+func (x *DocumentChange_SpliceText) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DocumentChange_SpliceText) GetDeleteCount() int32 {
+	if x != nil {
+		return x.DeleteCount
+	}
+	return 0
+}
+
+func (x *DocumentChange_SpliceText) GetInsert() string {
+	if x != nil {
+		return x.Insert
+	}
+	return ""
+}
+
 type RefTarget_Version struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Required. ID of the genesis Change.
@@ -5772,7 +8486,7 @@ type RefTarget_Version struct {
 
 func (x *RefTarget_Version) Reset() {
 	*x = RefTarget_Version{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[78]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[113]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5784,7 +8498,7 @@ func (x *RefTarget_Version) String() string {
 func (*RefTarget_Version) ProtoMessage() {}
 
 func (x *RefTarget_Version) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[78]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[113]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5797,7 +8511,7 @@ func (x *RefTarget_Version) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefTarget_Version.ProtoReflect.Descriptor instead.
 func (*RefTarget_Version) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{62, 0}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{95, 0}
 }
 
 func (x *RefTarget_Version) GetGenesis() string {
@@ -5834,7 +8548,7 @@ type RefTarget_Redirect struct {
 
 func (x *RefTarget_Redirect) Reset() {
 	*x = RefTarget_Redirect{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[79]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[114]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5846,7 +8560,7 @@ func (x *RefTarget_Redirect) String() string {
 func (*RefTarget_Redirect) ProtoMessage() {}
 
 func (x *RefTarget_Redirect) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[79]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[114]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5859,7 +8573,7 @@ func (x *RefTarget_Redirect) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefTarget_Redirect.ProtoReflect.Descriptor instead.
 func (*RefTarget_Redirect) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{62, 1}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{95, 1}
 }
 
 func (x *RefTarget_Redirect) GetAccount() string {
@@ -5891,7 +8605,7 @@ type RefTarget_Tombstone struct {
 
 func (x *RefTarget_Tombstone) Reset() {
 	*x = RefTarget_Tombstone{}
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[80]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[115]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5903,7 +8617,7 @@ func (x *RefTarget_Tombstone) String() string {
 func (*RefTarget_Tombstone) ProtoMessage() {}
 
 func (x *RefTarget_Tombstone) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v3alpha_documents_proto_msgTypes[80]
+	mi := &file_documents_v3alpha_documents_proto_msgTypes[115]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5916,7 +8630,7 @@ func (x *RefTarget_Tombstone) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefTarget_Tombstone.ProtoReflect.Descriptor instead.
 func (*RefTarget_Tombstone) Descriptor() ([]byte, []int) {
-	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{62, 2}
+	return file_documents_v3alpha_documents_proto_rawDescGZIP(), []int{95, 2}
 }
 
 var File_documents_v3alpha_documents_proto protoreflect.FileDescriptor
@@ -5950,9 +8664,11 @@ const file_documents_v3alpha_documents_proto_rawDesc = "" +
 	"capability\x12N\n" +
 	"\n" +
 	"visibility\x18\x06 \x01(\x0e2..com.seed.documents.v3alpha.ResourceVisibilityR\n" +
-	"visibility\"@\n" +
+	"visibility\"\x8b\x01\n" +
 	"\x15PrepareChangeResponse\x12'\n" +
-	"\x0funsigned_change\x18\x01 \x01(\fR\x0eunsignedChange\"E\n" +
+	"\x0funsigned_change\x18\x01 \x01(\fR\x0eunsignedChange\x12/\n" +
+	"\x13required_signatures\x18\x02 \x01(\x05R\x12requiredSignatures\x12\x18\n" +
+	"\amembers\x18\x03 \x03(\tR\amembers\"E\n" +
 	"\x15DeleteDocumentRequest\x12\x18\n" +
 	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
 	"\x04path\x18\x02 \x01(\tR\x04path\"V\n" +
@@ -6180,12 +8896,179 @@ const file_documents_v3alpha_documents_proto_rawDesc = "" +
 	"\achanges\x18\x01 \x03(\v2..com.seed.documents.v3alpha.DocumentChangeInfoR\achanges\x12&\n" +
 	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"*\n" +
 	"\x18GetDocumentChangeRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x8b\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x8d\x01\n" +
+	"\x13DiffDocumentRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12!\n" +
+	"\fbase_version\x18\x03 \x01(\tR\vbaseVersion\x12%\n" +
+	"\x0etarget_version\x18\x04 \x01(\tR\rtargetVersion\"\xe6\x01\n" +
+	"\x14DiffDocumentResponse\x12!\n" +
+	"\fbase_version\x18\x01 \x01(\tR\vbaseVersion\x12%\n" +
+	"\x0etarget_version\x18\x02 \x01(\tR\rtargetVersion\x12E\n" +
+	"\bmetadata\x18\x03 \x03(\v2).com.seed.documents.v3alpha.AttributeDiffR\bmetadata\x12=\n" +
+	"\x06blocks\x18\x04 \x03(\v2%.com.seed.documents.v3alpha.BlockDiffR\x06blocks\"\xdd\x04\n" +
+	"\tBlockDiff\x12\x19\n" +
+	"\bblock_id\x18\x01 \x01(\tR\ablockId\x12=\n" +
+	"\x04kind\x18\x02 \x01(\x0e2).com.seed.documents.v3alpha.BlockDiffKindR\x04kind\x12@\n" +
+	"\n" +
+	"base_block\x18\x03 \x01(\v2!.com.seed.documents.v3alpha.BlockR\tbaseBlock\x12D\n" +
+	"\ftarget_block\x18\x04 \x01(\v2!.com.seed.documents.v3alpha.BlockR\vtargetBlock\x12\x14\n" +
+	"\x05moved\x18\x05 \x01(\bR\x05moved\x12\x1f\n" +
+	"\vbase_parent\x18\x06 \x01(\tR\n" +
+	"baseParent\x12#\n" +
+	"\rtarget_parent\x18\a \x01(\tR\ftargetParent\x12\x1d\n" +
+	"\n" +
+	"base_index\x18\b \x01(\x05R\tbaseIndex\x12!\n" +
+	"\ftarget_index\x18\t \x01(\x05R\vtargetIndex\x12\x1a\n" +
+	"\bmodified\x18\n" +
+	" \x01(\bR\bmodified\x128\n" +
+	"\x04text\x18\v \x03(\v2$.com.seed.documents.v3alpha.TextDiffR\x04text\x12I\n" +
+	"\n" +
+	"attributes\x18\f \x03(\v2).com.seed.documents.v3alpha.AttributeDiffR\n" +
+	"attributes\x12/\n" +
+	"\x13annotations_changed\x18\r \x01(\bR\x12annotationsChanged\"\\\n" +
+	"\bTextDiff\x12<\n" +
+	"\x04kind\x18\x01 \x01(\x0e2(.com.seed.documents.v3alpha.TextDiffKindR\x04kind\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"\x93\x01\n" +
+	"\rAttributeDiff\x12\x10\n" +
+	"\x03key\x18\x01 \x03(\tR\x03key\x125\n" +
+	"\n" +
+	"base_value\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\tbaseValue\x129\n" +
+	"\ftarget_value\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\vtargetValue\"a\n" +
+	"\x17GetDocumentBlameRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\"i\n" +
+	"\rDocumentBlame\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12>\n" +
+	"\x06blocks\x18\x02 \x03(\v2&.com.seed.documents.v3alpha.BlockBlameR\x06blocks\"\xd2\x02\n" +
+	"\n" +
+	"BlockBlame\x12\x19\n" +
+	"\bblock_id\x18\x01 \x01(\tR\ablockId\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\tR\brevision\x12A\n" +
+	"\acontent\x18\x03 \x01(\v2'.com.seed.documents.v3alpha.AttributionR\acontent\x12C\n" +
+	"\bposition\x18\x04 \x01(\v2'.com.seed.documents.v3alpha.AttributionR\bposition\x12J\n" +
+	"\n" +
+	"attributes\x18\x05 \x03(\v2*.com.seed.documents.v3alpha.AttributeBlameR\n" +
+	"attributes\x129\n" +
+	"\x04text\x18\x06 \x03(\v2%.com.seed.documents.v3alpha.TextBlameR\x04text\"\x7f\n" +
+	"\vAttribution\x12\x1b\n" +
+	"\tchange_id\x18\x01 \x01(\tR\bchangeId\x12\x16\n" +
+	"\x06author\x18\x02 \x01(\tR\x06author\x12;\n" +
+	"\vcreate_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\"m\n" +
+	"\x0eAttributeBlame\x12\x10\n" +
+	"\x03key\x18\x01 \x03(\tR\x03key\x12I\n" +
+	"\vattribution\x18\x02 \x01(\v2'.com.seed.documents.v3alpha.AttributionR\vattribution\"~\n" +
+	"\tTextBlame\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\x05R\x03end\x12I\n" +
+	"\vattribution\x18\x03 \x01(\v2'.com.seed.documents.v3alpha.AttributionR\vattribution\"\xd6\x01\n" +
+	"\x15RevertDocumentRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12(\n" +
+	"\x10signing_key_name\x18\x03 \x01(\tR\x0esigningKeyName\x12%\n" +
+	"\x0etarget_version\x18\x04 \x01(\tR\rtargetVersion\x12!\n" +
+	"\fbase_version\x18\x05 \x01(\tR\vbaseVersion\x12\x1b\n" +
+	"\tblock_ids\x18\x06 \x03(\tR\bblockIds\"\xaa\x01\n" +
+	"\x16RevertDocumentResponse\x12\x1b\n" +
+	"\tchange_id\x18\x01 \x01(\tR\bchangeId\x121\n" +
+	"\x03ref\x18\x02 \x01(\v2\x1f.com.seed.documents.v3alpha.RefR\x03ref\x12@\n" +
+	"\bdocument\x18\x03 \x01(\v2$.com.seed.documents.v3alpha.DocumentR\bdocument\"\xef\x01\n" +
+	"\x13CreateBranchRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x16\n" +
+	"\x06branch\x18\x03 \x01(\tR\x06branch\x12(\n" +
+	"\x10signing_key_name\x18\x04 \x01(\tR\x0esigningKeyName\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\tR\aversion\x12N\n" +
+	"\n" +
+	"visibility\x18\x06 \x01(\x0e2..com.seed.documents.v3alpha.ResourceVisibilityR\n" +
+	"visibility\"\xba\x02\n" +
+	"\x06Branch\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aaccount\x18\x02 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12\x18\n" +
+	"\agenesis\x18\x04 \x01(\tR\agenesis\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\tR\aversion\x12\x16\n" +
+	"\x06author\x18\x06 \x01(\tR\x06author\x12;\n" +
+	"\vupdate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\x12N\n" +
+	"\n" +
+	"visibility\x18\b \x01(\x0e2..com.seed.documents.v3alpha.ResourceVisibilityR\n" +
+	"visibility\x12\x15\n" +
+	"\x06ref_id\x18\t \x01(\tR\x05refId\"C\n" +
+	"\x13ListBranchesRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\"V\n" +
+	"\x14ListBranchesResponse\x12>\n" +
+	"\bbranches\x18\x01 \x03(\v2\".com.seed.documents.v3alpha.BranchR\bbranches\"Y\n" +
+	"\x11DiffBranchRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x16\n" +
+	"\x06branch\x18\x03 \x01(\tR\x06branch\"\x84\x01\n" +
+	"\x12MergeBranchRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x16\n" +
+	"\x06branch\x18\x03 \x01(\tR\x06branch\x12(\n" +
+	"\x10signing_key_name\x18\x04 \x01(\tR\x0esigningKeyName\"\xc4\x01\n" +
+	"\x17MoveDocumentTreeRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x1f\n" +
+	"\vsource_path\x18\x02 \x01(\tR\n" +
+	"sourcePath\x12\x1f\n" +
+	"\vtarget_path\x18\x03 \x01(\tR\n" +
+	"targetPath\x12(\n" +
+	"\x10signing_key_name\x18\x04 \x01(\tR\x0esigningKeyName\x12#\n" +
+	"\rrewrite_links\x18\x05 \x01(\bR\frewriteLinks\"\x90\x02\n" +
+	"\x18MoveDocumentTreeResponse\x12R\n" +
+	"\x0fmoved_documents\x18\x01 \x03(\v2).com.seed.documents.v3alpha.MovedDocumentR\x0emovedDocuments\x12/\n" +
+	"\x13rewritten_documents\x18\x02 \x03(\tR\x12rewrittenDocuments\x12+\n" +
+	"\x11skipped_documents\x18\x03 \x03(\tR\x10skippedDocuments\x12B\n" +
+	"\fpartial_refs\x18\x04 \x03(\v2\x1f.com.seed.documents.v3alpha.RefR\vpartialRefs\"\xc1\x01\n" +
+	"\rMovedDocument\x12\x1f\n" +
+	"\vsource_path\x18\x01 \x01(\tR\n" +
+	"sourcePath\x12\x1f\n" +
+	"\vtarget_path\x18\x02 \x01(\tR\n" +
+	"targetPath\x121\n" +
+	"\x03ref\x18\x03 \x01(\v2\x1f.com.seed.documents.v3alpha.RefR\x03ref\x12;\n" +
+	"\bredirect\x18\x04 \x01(\v2\x1f.com.seed.documents.v3alpha.RefR\bredirect\"\x94\x02\n" +
+	"\x13CopyDocumentRequest\x12%\n" +
+	"\x0esource_account\x18\x01 \x01(\tR\rsourceAccount\x12\x1f\n" +
+	"\vsource_path\x18\x02 \x01(\tR\n" +
+	"sourcePath\x12%\n" +
+	"\x0esource_version\x18\x03 \x01(\tR\rsourceVersion\x12%\n" +
+	"\x0etarget_account\x18\x04 \x01(\tR\rtargetAccount\x12\x1f\n" +
+	"\vtarget_path\x18\x05 \x01(\tR\n" +
+	"targetPath\x12(\n" +
+	"\x10signing_key_name\x18\x06 \x01(\tR\x0esigningKeyName\x12\x1c\n" +
+	"\trecursive\x18\a \x01(\bR\trecursive\"m\n" +
+	"\x14CopyDocumentResponse\x12U\n" +
+	"\x10copied_documents\x18\x01 \x03(\v2*.com.seed.documents.v3alpha.CopiedDocumentR\x0fcopiedDocuments\"\xac\x01\n" +
+	"\x0eCopiedDocument\x12\x1f\n" +
+	"\vsource_path\x18\x01 \x01(\tR\n" +
+	"sourcePath\x12%\n" +
+	"\x0esource_version\x18\x02 \x01(\tR\rsourceVersion\x12\x1f\n" +
+	"\vtarget_path\x18\x03 \x01(\tR\n" +
+	"targetPath\x121\n" +
+	"\x03ref\x18\x04 \x01(\v2\x1f.com.seed.documents.v3alpha.RefR\x03ref\"\xe0\x03\n" +
+	"\x19CreateFromTemplateRequest\x12)\n" +
+	"\x10template_account\x18\x01 \x01(\tR\x0ftemplateAccount\x12#\n" +
+	"\rtemplate_path\x18\x02 \x01(\tR\ftemplatePath\x12)\n" +
+	"\x10template_version\x18\x03 \x01(\tR\x0ftemplateVersion\x12\x18\n" +
+	"\aaccount\x18\x04 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04path\x18\x05 \x01(\tR\x04path\x12(\n" +
+	"\x10signing_key_name\x18\x06 \x01(\tR\x0esigningKeyName\x12b\n" +
+	"\tvariables\x18\a \x03(\v2D.com.seed.documents.v3alpha.CreateFromTemplateRequest.VariablesEntryR\tvariables\x12N\n" +
+	"\n" +
+	"visibility\x18\b \x01(\x0e2..com.seed.documents.v3alpha.ResourceVisibilityR\n" +
+	"visibility\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8b\x01\n" +
 	"\x1fUpdateDocumentReadStatusRequest\x12\x18\n" +
 	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
 	"\x04path\x18\x02 \x01(\tR\x04path\x12\x17\n" +
 	"\ais_read\x18\x03 \x01(\bR\x06isRead\x12!\n" +
-	"\fis_recursive\x18\x04 \x01(\bR\visRecursive\"\xf3\x02\n" +
+	"\fis_recursive\x18\x04 \x01(\bR\visRecursive\"\x94\x03\n" +
 	"\x10CreateRefRequest\x12\x18\n" +
 	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
 	"\x04path\x18\x02 \x01(\tR\x04path\x12=\n" +
@@ -6200,7 +9083,9 @@ const file_documents_v3alpha_documents_proto_rawDesc = "" +
 	"generation\x12N\n" +
 	"\n" +
 	"visibility\x18\b \x01(\x0e2..com.seed.documents.v3alpha.ResourceVisibilityR\n" +
-	"visibility\"\x1f\n" +
+	"visibility\x12\x1f\n" +
+	"\vpartial_ref\x18\t \x01(\fR\n" +
+	"partialRef\"\x1f\n" +
 	"\rGetRefRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"{\n" +
 	"\x0fListRefsRequest\x12\x18\n" +
@@ -6211,13 +9096,39 @@ const file_documents_v3alpha_documents_proto_rawDesc = "" +
 	"page_token\x18\x04 \x01(\tR\tpageToken\"o\n" +
 	"\x10ListRefsResponse\x123\n" +
 	"\x04refs\x18\x01 \x03(\v2\x1f.com.seed.documents.v3alpha.RefR\x04refs\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x8d\x01\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xa6\x01\n" +
+	"\x12ScheduleRefRequest\x12\x10\n" +
+	"\x03ref\x18\x01 \x01(\fR\x03ref\x12?\n" +
+	"\x05blobs\x18\x02 \x03(\v2).com.seed.documents.v3alpha.ScheduledBlobR\x05blobs\x12=\n" +
+	"\fpublish_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vpublishTime\"5\n" +
+	"\rScheduledBlob\x12\x10\n" +
+	"\x03cid\x18\x01 \x01(\tR\x03cid\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"p\n" +
+	"\x18ListScheduledRefsRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x94\x01\n" +
+	"\x19ListScheduledRefsResponse\x12O\n" +
+	"\x0escheduled_refs\x18\x01 \x03(\v2(.com.seed.documents.v3alpha.ScheduledRefR\rscheduledRefs\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"+\n" +
+	"\x19CancelScheduledRefRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xb3\x02\n" +
+	"\fScheduledRef\x121\n" +
+	"\x03ref\x18\x01 \x01(\v2\x1f.com.seed.documents.v3alpha.RefR\x03ref\x12=\n" +
+	"\fpublish_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vpublishTime\x12;\n" +
+	"\vcreate_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12\x14\n" +
+	"\x05blobs\x18\x04 \x03(\tR\x05blobs\x12#\n" +
+	"\rpublish_error\x18\x05 \x01(\tR\fpublishError\x129\n" +
+	"\n" +
+	"retry_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tretryTime\"\x8d\x01\n" +
 	"\x12DocumentChangeInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06author\x18\x02 \x01(\tR\x06author\x12\x12\n" +
 	"\x04deps\x18\x03 \x03(\tR\x04deps\x12;\n" +
 	"\vcreate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"createTime\"\xaa\x06\n" +
+	"createTime\"\xf3\x06\n" +
 	"\fDocumentInfo\x12\x18\n" +
 	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
 	"\x04path\x18\x02 \x01(\tR\x04path\x123\n" +
@@ -6237,8 +9148,12 @@ const file_documents_v3alpha_documents_proto_rawDesc = "" +
 	"\n" +
 	"visibility\x18\x0e \x01(\x0e2..com.seed.documents.v3alpha.ResourceVisibilityR\n" +
 	"visibility\x128\n" +
-	"\x16first_image_in_content\x18\x0f \x01(\tH\x00R\x13firstImageInContent\x88\x01\x01B\x19\n" +
-	"\x17_first_image_in_content\"J\n" +
+	"\x16first_image_in_content\x18\x0f \x01(\tH\x00R\x13firstImageInContent\x88\x01\x01\x12G\n" +
+	"\treactions\x18\x10 \x03(\v2).com.seed.documents.v3alpha.ReactionCountR\treactionsB\x19\n" +
+	"\x17_first_image_in_content\";\n" +
+	"\rReactionCount\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"J\n" +
 	"\x0eGenerationInfo\x12\x18\n" +
 	"\agenesis\x18\x01 \x01(\tR\agenesis\x12\x1e\n" +
 	"\n" +
@@ -6299,14 +9214,16 @@ const file_documents_v3alpha_documents_proto_rawDesc = "" +
 	"attributes\x18\x02 \x01(\v2\x17.google.protobuf.StructR\n" +
 	"attributes\x12\x16\n" +
 	"\x06starts\x18\x03 \x03(\x05R\x06starts\x12\x12\n" +
-	"\x04ends\x18\x04 \x03(\x05R\x04ends\"\x98\x06\n" +
+	"\x04ends\x18\x04 \x03(\x05R\x04ends\"\xee\a\n" +
 	"\x0eDocumentChange\x12[\n" +
 	"\fset_metadata\x18\x01 \x01(\v26.com.seed.documents.v3alpha.DocumentChange.SetMetadataH\x00R\vsetMetadata\x12U\n" +
 	"\n" +
 	"move_block\x18\x02 \x01(\v24.com.seed.documents.v3alpha.DocumentChange.MoveBlockH\x00R\tmoveBlock\x12H\n" +
 	"\rreplace_block\x18\x03 \x01(\v2!.com.seed.documents.v3alpha.BlockH\x00R\freplaceBlock\x12#\n" +
 	"\fdelete_block\x18\x04 \x01(\tH\x00R\vdeleteBlock\x12^\n" +
-	"\rset_attribute\x18\x05 \x01(\v27.com.seed.documents.v3alpha.DocumentChange.SetAttributeH\x00R\fsetAttribute\x1aa\n" +
+	"\rset_attribute\x18\x05 \x01(\v27.com.seed.documents.v3alpha.DocumentChange.SetAttributeH\x00R\fsetAttribute\x12X\n" +
+	"\vsplice_text\x18\x06 \x01(\v25.com.seed.documents.v3alpha.DocumentChange.SpliceTextH\x00R\n" +
+	"spliceText\x1aa\n" +
 	"\tMoveBlock\x12\x19\n" +
 	"\bblock_id\x18\x01 \x01(\tR\ablockId\x12\x16\n" +
 	"\x06parent\x18\x02 \x01(\tR\x06parent\x12!\n" +
@@ -6323,8 +9240,14 @@ const file_documents_v3alpha_documents_proto_rawDesc = "" +
 	"bool_value\x18\x05 \x01(\bH\x00R\tboolValue\x127\n" +
 	"\n" +
 	"null_value\x18\x06 \x01(\v2\x16.google.protobuf.EmptyH\x00R\tnullValueB\a\n" +
-	"\x05valueB\x04\n" +
-	"\x02op\"\xc9\x02\n" +
+	"\x05value\x1az\n" +
+	"\n" +
+	"SpliceText\x12\x19\n" +
+	"\bblock_id\x18\x01 \x01(\tR\ablockId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12!\n" +
+	"\fdelete_count\x18\x03 \x01(\x05R\vdeleteCount\x12\x16\n" +
+	"\x06insert\x18\x04 \x01(\tR\x06insertB\x04\n" +
+	"\x02op\"\xa0\x03\n" +
 	"\x03Ref\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\aaccount\x18\x02 \x01(\tR\aaccount\x12\x12\n" +
@@ -6335,7 +9258,12 @@ const file_documents_v3alpha_documents_proto_rawDesc = "" +
 	"capability\x18\x06 \x01(\tR\n" +
 	"capability\x128\n" +
 	"\ttimestamp\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12S\n" +
-	"\x0fgeneration_info\x18\b \x01(\v2*.com.seed.documents.v3alpha.GenerationInfoR\x0egenerationInfo\"\xa3\x03\n" +
+	"\x0fgeneration_info\x18\b \x01(\v2*.com.seed.documents.v3alpha.GenerationInfoR\x0egenerationInfo\x12\x1c\n" +
+	"\tcosigners\x18\t \x03(\tR\tcosigners\x12\x1f\n" +
+	"\vpartial_ref\x18\n" +
+	" \x01(\fR\n" +
+	"partialRef\x12\x16\n" +
+	"\x06branch\x18\v \x01(\tR\x06branch\"\xa3\x03\n" +
 	"\tRefTarget\x12I\n" +
 	"\aversion\x18\x01 \x01(\v2-.com.seed.documents.v3alpha.RefTarget.VersionH\x00R\aversion\x12L\n" +
 	"\bredirect\x18\x02 \x01(\v2..com.seed.documents.v3alpha.RefTarget.RedirectH\x00R\bredirect\x12O\n" +
@@ -6362,7 +9290,17 @@ const file_documents_v3alpha_documents_proto_rawDesc = "" +
 	"\x1eDOCUMENT_ATTRIBUTE_KIND_OBJECT\x10\x01\x12\"\n" +
 	"\x1eDOCUMENT_ATTRIBUTE_KIND_STRING\x10\x02\x12\x1f\n" +
 	"\x1bDOCUMENT_ATTRIBUTE_KIND_INT\x10\x03\x12 \n" +
-	"\x1cDOCUMENT_ATTRIBUTE_KIND_BOOL\x10\x042\x8d\x18\n" +
+	"\x1cDOCUMENT_ATTRIBUTE_KIND_BOOL\x10\x04*\x85\x01\n" +
+	"\rBlockDiffKind\x12\x1f\n" +
+	"\x1bBLOCK_DIFF_KIND_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15BLOCK_DIFF_KIND_ADDED\x10\x01\x12\x1b\n" +
+	"\x17BLOCK_DIFF_KIND_REMOVED\x10\x02\x12\x1b\n" +
+	"\x17BLOCK_DIFF_KIND_CHANGED\x10\x03*\x81\x01\n" +
+	"\fTextDiffKind\x12\x1e\n" +
+	"\x1aTEXT_DIFF_KIND_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14TEXT_DIFF_KIND_EQUAL\x10\x01\x12\x1b\n" +
+	"\x17TEXT_DIFF_KIND_INSERTED\x10\x02\x12\x1a\n" +
+	"\x16TEXT_DIFF_KIND_DELETED\x10\x032\xca#\n" +
 	"\tDocuments\x12c\n" +
 	"\vGetDocument\x12..com.seed.documents.v3alpha.GetDocumentRequest\x1a$.com.seed.documents.v3alpha.Document\x12o\n" +
 	"\x0fGetDocumentInfo\x122.com.seed.documents.v3alpha.GetDocumentInfoRequest\x1a(.com.seed.documents.v3alpha.DocumentInfo\x12\x89\x01\n" +
@@ -6388,11 +9326,25 @@ const file_documents_v3alpha_documents_proto_rawDesc = "" +
 	"\x1aListDocumentAttributeNames\x12=.com.seed.documents.v3alpha.ListDocumentAttributeNamesRequest\x1a>.com.seed.documents.v3alpha.ListDocumentAttributeNamesResponse\x12\x9e\x01\n" +
 	"\x1bListDocumentAttributeValues\x12>.com.seed.documents.v3alpha.ListDocumentAttributeValuesRequest\x1a?.com.seed.documents.v3alpha.ListDocumentAttributeValuesResponse\x12\x86\x01\n" +
 	"\x13ListDocumentChanges\x126.com.seed.documents.v3alpha.ListDocumentChangesRequest\x1a7.com.seed.documents.v3alpha.ListDocumentChangesResponse\x12y\n" +
-	"\x11GetDocumentChange\x124.com.seed.documents.v3alpha.GetDocumentChangeRequest\x1a..com.seed.documents.v3alpha.DocumentChangeInfo\x12o\n" +
+	"\x11GetDocumentChange\x124.com.seed.documents.v3alpha.GetDocumentChangeRequest\x1a..com.seed.documents.v3alpha.DocumentChangeInfo\x12q\n" +
+	"\fDiffDocument\x12/.com.seed.documents.v3alpha.DiffDocumentRequest\x1a0.com.seed.documents.v3alpha.DiffDocumentResponse\x12r\n" +
+	"\x10GetDocumentBlame\x123.com.seed.documents.v3alpha.GetDocumentBlameRequest\x1a).com.seed.documents.v3alpha.DocumentBlame\x12w\n" +
+	"\x0eRevertDocument\x121.com.seed.documents.v3alpha.RevertDocumentRequest\x1a2.com.seed.documents.v3alpha.RevertDocumentResponse\x12c\n" +
+	"\fCreateBranch\x12/.com.seed.documents.v3alpha.CreateBranchRequest\x1a\".com.seed.documents.v3alpha.Branch\x12q\n" +
+	"\fListBranches\x12/.com.seed.documents.v3alpha.ListBranchesRequest\x1a0.com.seed.documents.v3alpha.ListBranchesResponse\x12m\n" +
+	"\n" +
+	"DiffBranch\x12-.com.seed.documents.v3alpha.DiffBranchRequest\x1a0.com.seed.documents.v3alpha.DiffDocumentResponse\x12^\n" +
+	"\vMergeBranch\x12..com.seed.documents.v3alpha.MergeBranchRequest\x1a\x1f.com.seed.documents.v3alpha.Ref\x12}\n" +
+	"\x10MoveDocumentTree\x123.com.seed.documents.v3alpha.MoveDocumentTreeRequest\x1a4.com.seed.documents.v3alpha.MoveDocumentTreeResponse\x12q\n" +
+	"\fCopyDocument\x12/.com.seed.documents.v3alpha.CopyDocumentRequest\x1a0.com.seed.documents.v3alpha.CopyDocumentResponse\x12q\n" +
+	"\x12CreateFromTemplate\x125.com.seed.documents.v3alpha.CreateFromTemplateRequest\x1a$.com.seed.documents.v3alpha.Document\x12o\n" +
 	"\x18UpdateDocumentReadStatus\x12;.com.seed.documents.v3alpha.UpdateDocumentReadStatusRequest\x1a\x16.google.protobuf.Empty\x12Z\n" +
 	"\tCreateRef\x12,.com.seed.documents.v3alpha.CreateRefRequest\x1a\x1f.com.seed.documents.v3alpha.Ref\x12T\n" +
 	"\x06GetRef\x12).com.seed.documents.v3alpha.GetRefRequest\x1a\x1f.com.seed.documents.v3alpha.Ref\x12e\n" +
-	"\bListRefs\x12+.com.seed.documents.v3alpha.ListRefsRequest\x1a,.com.seed.documents.v3alpha.ListRefsResponseB3Z1seed/backend/genproto/documents/v3alpha;documentsb\x06proto3"
+	"\bListRefs\x12+.com.seed.documents.v3alpha.ListRefsRequest\x1a,.com.seed.documents.v3alpha.ListRefsResponse\x12g\n" +
+	"\vScheduleRef\x12..com.seed.documents.v3alpha.ScheduleRefRequest\x1a(.com.seed.documents.v3alpha.ScheduledRef\x12\x80\x01\n" +
+	"\x11ListScheduledRefs\x124.com.seed.documents.v3alpha.ListScheduledRefsRequest\x1a5.com.seed.documents.v3alpha.ListScheduledRefsResponse\x12c\n" +
+	"\x12CancelScheduledRef\x125.com.seed.documents.v3alpha.CancelScheduledRefRequest\x1a\x16.google.protobuf.EmptyB3Z1seed/backend/genproto/documents/v3alpha;documentsb\x06proto3"
 
 var (
 	file_documents_v3alpha_documents_proto_rawDescOnce sync.Once
@@ -6406,248 +9358,353 @@ func file_documents_v3alpha_documents_proto_rawDescGZIP() []byte {
 	return file_documents_v3alpha_documents_proto_rawDescData
 }
 
-var file_documents_v3alpha_documents_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_documents_v3alpha_documents_proto_msgTypes = make([]protoimpl.MessageInfo, 81)
+var file_documents_v3alpha_documents_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_documents_v3alpha_documents_proto_msgTypes = make([]protoimpl.MessageInfo, 116)
 var file_documents_v3alpha_documents_proto_goTypes = []any{
 	(ResourceVisibility)(0),                     // 0: com.seed.documents.v3alpha.ResourceVisibility
 	(SortAttribute)(0),                          // 1: com.seed.documents.v3alpha.SortAttribute
 	(DocumentAttributeKind)(0),                  // 2: com.seed.documents.v3alpha.DocumentAttributeKind
-	(DocumentFilter_Comparison_Operator)(0),     // 3: com.seed.documents.v3alpha.DocumentFilter.Comparison.Operator
-	(*GetDocumentRequest)(nil),                  // 4: com.seed.documents.v3alpha.GetDocumentRequest
-	(*RedirectErrorDetails)(nil),                // 5: com.seed.documents.v3alpha.RedirectErrorDetails
-	(*GetDocumentInfoRequest)(nil),              // 6: com.seed.documents.v3alpha.GetDocumentInfoRequest
-	(*BatchGetDocumentInfoRequest)(nil),         // 7: com.seed.documents.v3alpha.BatchGetDocumentInfoRequest
-	(*BatchGetDocumentInfoResponse)(nil),        // 8: com.seed.documents.v3alpha.BatchGetDocumentInfoResponse
-	(*PrepareChangeRequest)(nil),                // 9: com.seed.documents.v3alpha.PrepareChangeRequest
-	(*PrepareChangeResponse)(nil),               // 10: com.seed.documents.v3alpha.PrepareChangeResponse
-	(*DeleteDocumentRequest)(nil),               // 11: com.seed.documents.v3alpha.DeleteDocumentRequest
-	(*ListRootDocumentsRequest)(nil),            // 12: com.seed.documents.v3alpha.ListRootDocumentsRequest
-	(*ListRootDocumentsResponse)(nil),           // 13: com.seed.documents.v3alpha.ListRootDocumentsResponse
-	(*ListAccountsRequest)(nil),                 // 14: com.seed.documents.v3alpha.ListAccountsRequest
-	(*ListAccountsResponse)(nil),                // 15: com.seed.documents.v3alpha.ListAccountsResponse
-	(*GetAccountRequest)(nil),                   // 16: com.seed.documents.v3alpha.GetAccountRequest
-	(*BatchGetAccountsRequest)(nil),             // 17: com.seed.documents.v3alpha.BatchGetAccountsRequest
-	(*BatchGetAccountsResponse)(nil),            // 18: com.seed.documents.v3alpha.BatchGetAccountsResponse
-	(*UpdateProfileRequest)(nil),                // 19: com.seed.documents.v3alpha.UpdateProfileRequest
-	(*Account)(nil),                             // 20: com.seed.documents.v3alpha.Account
-	(*Profile)(nil),                             // 21: com.seed.documents.v3alpha.Profile
-	(*CreateAliasRequest)(nil),                  // 22: com.seed.documents.v3alpha.CreateAliasRequest
-	(*CreateContactRequest)(nil),                // 23: com.seed.documents.v3alpha.CreateContactRequest
-	(*GetContactRequest)(nil),                   // 24: com.seed.documents.v3alpha.GetContactRequest
-	(*UpdateContactRequest)(nil),                // 25: com.seed.documents.v3alpha.UpdateContactRequest
-	(*DeleteContactRequest)(nil),                // 26: com.seed.documents.v3alpha.DeleteContactRequest
-	(*ListContactsRequest)(nil),                 // 27: com.seed.documents.v3alpha.ListContactsRequest
-	(*ListContactsResponse)(nil),                // 28: com.seed.documents.v3alpha.ListContactsResponse
-	(*Contact)(nil),                             // 29: com.seed.documents.v3alpha.Contact
-	(*ListDirectoryRequest)(nil),                // 30: com.seed.documents.v3alpha.ListDirectoryRequest
-	(*SortOptions)(nil),                         // 31: com.seed.documents.v3alpha.SortOptions
-	(*ListDirectoryResponse)(nil),               // 32: com.seed.documents.v3alpha.ListDirectoryResponse
-	(*ListDocumentsRequest)(nil),                // 33: com.seed.documents.v3alpha.ListDocumentsRequest
-	(*ListDocumentsResponse)(nil),               // 34: com.seed.documents.v3alpha.ListDocumentsResponse
-	(*AttributeValue)(nil),                      // 35: com.seed.documents.v3alpha.AttributeValue
-	(*DocumentFilter)(nil),                      // 36: com.seed.documents.v3alpha.DocumentFilter
-	(*DocumentSort)(nil),                        // 37: com.seed.documents.v3alpha.DocumentSort
-	(*QueryDocumentsRequest)(nil),               // 38: com.seed.documents.v3alpha.QueryDocumentsRequest
-	(*QueryDocumentsResponse)(nil),              // 39: com.seed.documents.v3alpha.QueryDocumentsResponse
-	(*DocumentAttributeKindUsage)(nil),          // 40: com.seed.documents.v3alpha.DocumentAttributeKindUsage
-	(*ListDocumentAttributeNamesRequest)(nil),   // 41: com.seed.documents.v3alpha.ListDocumentAttributeNamesRequest
-	(*DocumentAttributeName)(nil),               // 42: com.seed.documents.v3alpha.DocumentAttributeName
-	(*ListDocumentAttributeNamesResponse)(nil),  // 43: com.seed.documents.v3alpha.ListDocumentAttributeNamesResponse
-	(*ListDocumentAttributeValuesRequest)(nil),  // 44: com.seed.documents.v3alpha.ListDocumentAttributeValuesRequest
-	(*DocumentAttributeValue)(nil),              // 45: com.seed.documents.v3alpha.DocumentAttributeValue
-	(*ListDocumentAttributeValuesResponse)(nil), // 46: com.seed.documents.v3alpha.ListDocumentAttributeValuesResponse
-	(*ListDocumentChangesRequest)(nil),          // 47: com.seed.documents.v3alpha.ListDocumentChangesRequest
-	(*ListDocumentChangesResponse)(nil),         // 48: com.seed.documents.v3alpha.ListDocumentChangesResponse
-	(*GetDocumentChangeRequest)(nil),            // 49: com.seed.documents.v3alpha.GetDocumentChangeRequest
-	(*UpdateDocumentReadStatusRequest)(nil),     // 50: com.seed.documents.v3alpha.UpdateDocumentReadStatusRequest
-	(*CreateRefRequest)(nil),                    // 51: com.seed.documents.v3alpha.CreateRefRequest
-	(*GetRefRequest)(nil),                       // 52: com.seed.documents.v3alpha.GetRefRequest
-	(*ListRefsRequest)(nil),                     // 53: com.seed.documents.v3alpha.ListRefsRequest
-	(*ListRefsResponse)(nil),                    // 54: com.seed.documents.v3alpha.ListRefsResponse
-	(*DocumentChangeInfo)(nil),                  // 55: com.seed.documents.v3alpha.DocumentChangeInfo
-	(*DocumentInfo)(nil),                        // 56: com.seed.documents.v3alpha.DocumentInfo
-	(*GenerationInfo)(nil),                      // 57: com.seed.documents.v3alpha.GenerationInfo
-	(*ActivitySummary)(nil),                     // 58: com.seed.documents.v3alpha.ActivitySummary
-	(*Breadcrumb)(nil),                          // 59: com.seed.documents.v3alpha.Breadcrumb
-	(*Document)(nil),                            // 60: com.seed.documents.v3alpha.Document
-	(*BlockNode)(nil),                           // 61: com.seed.documents.v3alpha.BlockNode
-	(*Block)(nil),                               // 62: com.seed.documents.v3alpha.Block
-	(*Annotation)(nil),                          // 63: com.seed.documents.v3alpha.Annotation
-	(*DocumentChange)(nil),                      // 64: com.seed.documents.v3alpha.DocumentChange
-	(*Ref)(nil),                                 // 65: com.seed.documents.v3alpha.Ref
-	(*RefTarget)(nil),                           // 66: com.seed.documents.v3alpha.RefTarget
-	nil,                                         // 67: com.seed.documents.v3alpha.BatchGetAccountsResponse.AccountsEntry
-	nil,                                         // 68: com.seed.documents.v3alpha.BatchGetAccountsResponse.ErrorsEntry
-	(*DocumentFilter_And)(nil),                  // 69: com.seed.documents.v3alpha.DocumentFilter.And
-	(*DocumentFilter_Or)(nil),                   // 70: com.seed.documents.v3alpha.DocumentFilter.Or
-	(*DocumentFilter_Not)(nil),                  // 71: com.seed.documents.v3alpha.DocumentFilter.Not
-	(*DocumentFilter_Comparison)(nil),           // 72: com.seed.documents.v3alpha.DocumentFilter.Comparison
-	(*DocumentFilter_Presence)(nil),             // 73: com.seed.documents.v3alpha.DocumentFilter.Presence
-	(*DocumentFilter_StringMatch)(nil),          // 74: com.seed.documents.v3alpha.DocumentFilter.StringMatch
-	(*DocumentFilter_URLMatch)(nil),             // 75: com.seed.documents.v3alpha.DocumentFilter.URLMatch
-	(*DocumentFilter_SpaceMatch)(nil),           // 76: com.seed.documents.v3alpha.DocumentFilter.SpaceMatch
-	(*DocumentFilter_PathMatch)(nil),            // 77: com.seed.documents.v3alpha.DocumentFilter.PathMatch
-	nil,                                         // 78: com.seed.documents.v3alpha.Document.DetachedBlocksEntry
-	(*DocumentChange_MoveBlock)(nil),            // 79: com.seed.documents.v3alpha.DocumentChange.MoveBlock
-	(*DocumentChange_SetMetadata)(nil),          // 80: com.seed.documents.v3alpha.DocumentChange.SetMetadata
-	(*DocumentChange_SetAttribute)(nil),         // 81: com.seed.documents.v3alpha.DocumentChange.SetAttribute
-	(*RefTarget_Version)(nil),                   // 82: com.seed.documents.v3alpha.RefTarget.Version
-	(*RefTarget_Redirect)(nil),                  // 83: com.seed.documents.v3alpha.RefTarget.Redirect
-	(*RefTarget_Tombstone)(nil),                 // 84: com.seed.documents.v3alpha.RefTarget.Tombstone
-	(*structpb.Struct)(nil),                     // 85: google.protobuf.Struct
-	(*timestamppb.Timestamp)(nil),               // 86: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                       // 87: google.protobuf.Empty
+	(BlockDiffKind)(0),                          // 3: com.seed.documents.v3alpha.BlockDiffKind
+	(TextDiffKind)(0),                           // 4: com.seed.documents.v3alpha.TextDiffKind
+	(DocumentFilter_Comparison_Operator)(0),     // 5: com.seed.documents.v3alpha.DocumentFilter.Comparison.Operator
+	(*GetDocumentRequest)(nil),                  // 6: com.seed.documents.v3alpha.GetDocumentRequest
+	(*RedirectErrorDetails)(nil),                // 7: com.seed.documents.v3alpha.RedirectErrorDetails
+	(*GetDocumentInfoRequest)(nil),              // 8: com.seed.documents.v3alpha.GetDocumentInfoRequest
+	(*BatchGetDocumentInfoRequest)(nil),         // 9: com.seed.documents.v3alpha.BatchGetDocumentInfoRequest
+	(*BatchGetDocumentInfoResponse)(nil),        // 10: com.seed.documents.v3alpha.BatchGetDocumentInfoResponse
+	(*PrepareChangeRequest)(nil),                // 11: com.seed.documents.v3alpha.PrepareChangeRequest
+	(*PrepareChangeResponse)(nil),               // 12: com.seed.documents.v3alpha.PrepareChangeResponse
+	(*DeleteDocumentRequest)(nil),               // 13: com.seed.documents.v3alpha.DeleteDocumentRequest
+	(*ListRootDocumentsRequest)(nil),            // 14: com.seed.documents.v3alpha.ListRootDocumentsRequest
+	(*ListRootDocumentsResponse)(nil),           // 15: com.seed.documents.v3alpha.ListRootDocumentsResponse
+	(*ListAccountsRequest)(nil),                 // 16: com.seed.documents.v3alpha.ListAccountsRequest
+	(*ListAccountsResponse)(nil),                // 17: com.seed.documents.v3alpha.ListAccountsResponse
+	(*GetAccountRequest)(nil),                   // 18: com.seed.documents.v3alpha.GetAccountRequest
+	(*BatchGetAccountsRequest)(nil),             // 19: com.seed.documents.v3alpha.BatchGetAccountsRequest
+	(*BatchGetAccountsResponse)(nil),            // 20: com.seed.documents.v3alpha.BatchGetAccountsResponse
+	(*UpdateProfileRequest)(nil),                // 21: com.seed.documents.v3alpha.UpdateProfileRequest
+	(*Account)(nil),                             // 22: com.seed.documents.v3alpha.Account
+	(*Profile)(nil),                             // 23: com.seed.documents.v3alpha.Profile
+	(*CreateAliasRequest)(nil),                  // 24: com.seed.documents.v3alpha.CreateAliasRequest
+	(*CreateContactRequest)(nil),                // 25: com.seed.documents.v3alpha.CreateContactRequest
+	(*GetContactRequest)(nil),                   // 26: com.seed.documents.v3alpha.GetContactRequest
+	(*UpdateContactRequest)(nil),                // 27: com.seed.documents.v3alpha.UpdateContactRequest
+	(*DeleteContactRequest)(nil),                // 28: com.seed.documents.v3alpha.DeleteContactRequest
+	(*ListContactsRequest)(nil),                 // 29: com.seed.documents.v3alpha.ListContactsRequest
+	(*ListContactsResponse)(nil),                // 30: com.seed.documents.v3alpha.ListContactsResponse
+	(*Contact)(nil),                             // 31: com.seed.documents.v3alpha.Contact
+	(*ListDirectoryRequest)(nil),                // 32: com.seed.documents.v3alpha.ListDirectoryRequest
+	(*SortOptions)(nil),                         // 33: com.seed.documents.v3alpha.SortOptions
+	(*ListDirectoryResponse)(nil),               // 34: com.seed.documents.v3alpha.ListDirectoryResponse
+	(*ListDocumentsRequest)(nil),                // 35: com.seed.documents.v3alpha.ListDocumentsRequest
+	(*ListDocumentsResponse)(nil),               // 36: com.seed.documents.v3alpha.ListDocumentsResponse
+	(*AttributeValue)(nil),                      // 37: com.seed.documents.v3alpha.AttributeValue
+	(*DocumentFilter)(nil),                      // 38: com.seed.documents.v3alpha.DocumentFilter
+	(*DocumentSort)(nil),                        // 39: com.seed.documents.v3alpha.DocumentSort
+	(*QueryDocumentsRequest)(nil),               // 40: com.seed.documents.v3alpha.QueryDocumentsRequest
+	(*QueryDocumentsResponse)(nil),              // 41: com.seed.documents.v3alpha.QueryDocumentsResponse
+	(*DocumentAttributeKindUsage)(nil),          // 42: com.seed.documents.v3alpha.DocumentAttributeKindUsage
+	(*ListDocumentAttributeNamesRequest)(nil),   // 43: com.seed.documents.v3alpha.ListDocumentAttributeNamesRequest
+	(*DocumentAttributeName)(nil),               // 44: com.seed.documents.v3alpha.DocumentAttributeName
+	(*ListDocumentAttributeNamesResponse)(nil),  // 45: com.seed.documents.v3alpha.ListDocumentAttributeNamesResponse
+	(*ListDocumentAttributeValuesRequest)(nil),  // 46: com.seed.documents.v3alpha.ListDocumentAttributeValuesRequest
+	(*DocumentAttributeValue)(nil),              // 47: com.seed.documents.v3alpha.DocumentAttributeValue
+	(*ListDocumentAttributeValuesResponse)(nil), // 48: com.seed.documents.v3alpha.ListDocumentAttributeValuesResponse
+	(*ListDocumentChangesRequest)(nil),          // 49: com.seed.documents.v3alpha.ListDocumentChangesRequest
+	(*ListDocumentChangesResponse)(nil),         // 50: com.seed.documents.v3alpha.ListDocumentChangesResponse
+	(*GetDocumentChangeRequest)(nil),            // 51: com.seed.documents.v3alpha.GetDocumentChangeRequest
+	(*DiffDocumentRequest)(nil),                 // 52: com.seed.documents.v3alpha.DiffDocumentRequest
+	(*DiffDocumentResponse)(nil),                // 53: com.seed.documents.v3alpha.DiffDocumentResponse
+	(*BlockDiff)(nil),                           // 54: com.seed.documents.v3alpha.BlockDiff
+	(*TextDiff)(nil),                            // 55: com.seed.documents.v3alpha.TextDiff
+	(*AttributeDiff)(nil),                       // 56: com.seed.documents.v3alpha.AttributeDiff
+	(*GetDocumentBlameRequest)(nil),             // 57: com.seed.documents.v3alpha.GetDocumentBlameRequest
+	(*DocumentBlame)(nil),                       // 58: com.seed.documents.v3alpha.DocumentBlame
+	(*BlockBlame)(nil),                          // 59: com.seed.documents.v3alpha.BlockBlame
+	(*Attribution)(nil),                         // 60: com.seed.documents.v3alpha.Attribution
+	(*AttributeBlame)(nil),                      // 61: com.seed.documents.v3alpha.AttributeBlame
+	(*TextBlame)(nil),                           // 62: com.seed.documents.v3alpha.TextBlame
+	(*RevertDocumentRequest)(nil),               // 63: com.seed.documents.v3alpha.RevertDocumentRequest
+	(*RevertDocumentResponse)(nil),              // 64: com.seed.documents.v3alpha.RevertDocumentResponse
+	(*CreateBranchRequest)(nil),                 // 65: com.seed.documents.v3alpha.CreateBranchRequest
+	(*Branch)(nil),                              // 66: com.seed.documents.v3alpha.Branch
+	(*ListBranchesRequest)(nil),                 // 67: com.seed.documents.v3alpha.ListBranchesRequest
+	(*ListBranchesResponse)(nil),                // 68: com.seed.documents.v3alpha.ListBranchesResponse
+	(*DiffBranchRequest)(nil),                   // 69: com.seed.documents.v3alpha.DiffBranchRequest
+	(*MergeBranchRequest)(nil),                  // 70: com.seed.documents.v3alpha.MergeBranchRequest
+	(*MoveDocumentTreeRequest)(nil),             // 71: com.seed.documents.v3alpha.MoveDocumentTreeRequest
+	(*MoveDocumentTreeResponse)(nil),            // 72: com.seed.documents.v3alpha.MoveDocumentTreeResponse
+	(*MovedDocument)(nil),                       // 73: com.seed.documents.v3alpha.MovedDocument
+	(*CopyDocumentRequest)(nil),                 // 74: com.seed.documents.v3alpha.CopyDocumentRequest
+	(*CopyDocumentResponse)(nil),                // 75: com.seed.documents.v3alpha.CopyDocumentResponse
+	(*CopiedDocument)(nil),                      // 76: com.seed.documents.v3alpha.CopiedDocument
+	(*CreateFromTemplateRequest)(nil),           // 77: com.seed.documents.v3alpha.CreateFromTemplateRequest
+	(*UpdateDocumentReadStatusRequest)(nil),     // 78: com.seed.documents.v3alpha.UpdateDocumentReadStatusRequest
+	(*CreateRefRequest)(nil),                    // 79: com.seed.documents.v3alpha.CreateRefRequest
+	(*GetRefRequest)(nil),                       // 80: com.seed.documents.v3alpha.GetRefRequest
+	(*ListRefsRequest)(nil),                     // 81: com.seed.documents.v3alpha.ListRefsRequest
+	(*ListRefsResponse)(nil),                    // 82: com.seed.documents.v3alpha.ListRefsResponse
+	(*ScheduleRefRequest)(nil),                  // 83: com.seed.documents.v3alpha.ScheduleRefRequest
+	(*ScheduledBlob)(nil),                       // 84: com.seed.documents.v3alpha.ScheduledBlob
+	(*ListScheduledRefsRequest)(nil),            // 85: com.seed.documents.v3alpha.ListScheduledRefsRequest
+	(*ListScheduledRefsResponse)(nil),           // 86: com.seed.documents.v3alpha.ListScheduledRefsResponse
+	(*CancelScheduledRefRequest)(nil),           // 87: com.seed.documents.v3alpha.CancelScheduledRefRequest
+	(*ScheduledRef)(nil),                        // 88: com.seed.documents.v3alpha.ScheduledRef
+	(*DocumentChangeInfo)(nil),                  // 89: com.seed.documents.v3alpha.DocumentChangeInfo
+	(*DocumentInfo)(nil),                        // 90: com.seed.documents.v3alpha.DocumentInfo
+	(*ReactionCount)(nil),                       // 91: com.seed.documents.v3alpha.ReactionCount
+	(*GenerationInfo)(nil),                      // 92: com.seed.documents.v3alpha.GenerationInfo
+	(*ActivitySummary)(nil),                     // 93: com.seed.documents.v3alpha.ActivitySummary
+	(*Breadcrumb)(nil),                          // 94: com.seed.documents.v3alpha.Breadcrumb
+	(*Document)(nil),                            // 95: com.seed.documents.v3alpha.Document
+	(*BlockNode)(nil),                           // 96: com.seed.documents.v3alpha.BlockNode
+	(*Block)(nil),                               // 97: com.seed.documents.v3alpha.Block
+	(*Annotation)(nil),                          // 98: com.seed.documents.v3alpha.Annotation
+	(*DocumentChange)(nil),                      // 99: com.seed.documents.v3alpha.DocumentChange
+	(*Ref)(nil),                                 // 100: com.seed.documents.v3alpha.Ref
+	(*RefTarget)(nil),                           // 101: com.seed.documents.v3alpha.RefTarget
+	nil,                                         // 102: com.seed.documents.v3alpha.BatchGetAccountsResponse.AccountsEntry
+	nil,                                         // 103: com.seed.documents.v3alpha.BatchGetAccountsResponse.ErrorsEntry
+	(*DocumentFilter_And)(nil),                  // 104: com.seed.documents.v3alpha.DocumentFilter.And
+	(*DocumentFilter_Or)(nil),                   // 105: com.seed.documents.v3alpha.DocumentFilter.Or
+	(*DocumentFilter_Not)(nil),                  // 106: com.seed.documents.v3alpha.DocumentFilter.Not
+	(*DocumentFilter_Comparison)(nil),           // 107: com.seed.documents.v3alpha.DocumentFilter.Comparison
+	(*DocumentFilter_Presence)(nil),             // 108: com.seed.documents.v3alpha.DocumentFilter.Presence
+	(*DocumentFilter_StringMatch)(nil),          // 109: com.seed.documents.v3alpha.DocumentFilter.StringMatch
+	(*DocumentFilter_URLMatch)(nil),             // 110: com.seed.documents.v3alpha.DocumentFilter.URLMatch
+	(*DocumentFilter_SpaceMatch)(nil),           // 111: com.seed.documents.v3alpha.DocumentFilter.SpaceMatch
+	(*DocumentFilter_PathMatch)(nil),            // 112: com.seed.documents.v3alpha.DocumentFilter.PathMatch
+	nil,                                         // 113: com.seed.documents.v3alpha.CreateFromTemplateRequest.VariablesEntry
+	nil,                                         // 114: com.seed.documents.v3alpha.Document.DetachedBlocksEntry
+	(*DocumentChange_MoveBlock)(nil),            // 115: com.seed.documents.v3alpha.DocumentChange.MoveBlock
+	(*DocumentChange_SetMetadata)(nil),          // 116: com.seed.documents.v3alpha.DocumentChange.SetMetadata
+	(*DocumentChange_SetAttribute)(nil),         // 117: com.seed.documents.v3alpha.DocumentChange.SetAttribute
+	(*DocumentChange_SpliceText)(nil),           // 118: com.seed.documents.v3alpha.DocumentChange.SpliceText
+	(*RefTarget_Version)(nil),                   // 119: com.seed.documents.v3alpha.RefTarget.Version
+	(*RefTarget_Redirect)(nil),                  // 120: com.seed.documents.v3alpha.RefTarget.Redirect
+	(*RefTarget_Tombstone)(nil),                 // 121: com.seed.documents.v3alpha.RefTarget.Tombstone
+	(*structpb.Struct)(nil),                     // 122: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),               // 123: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                       // 124: google.protobuf.Empty
+	(*structpb.Value)(nil),                      // 125: google.protobuf.Value
 }
 var file_documents_v3alpha_documents_proto_depIdxs = []int32{
-	6,   // 0: com.seed.documents.v3alpha.BatchGetDocumentInfoRequest.requests:type_name -> com.seed.documents.v3alpha.GetDocumentInfoRequest
-	56,  // 1: com.seed.documents.v3alpha.BatchGetDocumentInfoResponse.documents:type_name -> com.seed.documents.v3alpha.DocumentInfo
-	64,  // 2: com.seed.documents.v3alpha.PrepareChangeRequest.changes:type_name -> com.seed.documents.v3alpha.DocumentChange
+	8,   // 0: com.seed.documents.v3alpha.BatchGetDocumentInfoRequest.requests:type_name -> com.seed.documents.v3alpha.GetDocumentInfoRequest
+	90,  // 1: com.seed.documents.v3alpha.BatchGetDocumentInfoResponse.documents:type_name -> com.seed.documents.v3alpha.DocumentInfo
+	99,  // 2: com.seed.documents.v3alpha.PrepareChangeRequest.changes:type_name -> com.seed.documents.v3alpha.DocumentChange
 	0,   // 3: com.seed.documents.v3alpha.PrepareChangeRequest.visibility:type_name -> com.seed.documents.v3alpha.ResourceVisibility
-	56,  // 4: com.seed.documents.v3alpha.ListRootDocumentsResponse.documents:type_name -> com.seed.documents.v3alpha.DocumentInfo
-	31,  // 5: com.seed.documents.v3alpha.ListAccountsRequest.sort_options:type_name -> com.seed.documents.v3alpha.SortOptions
-	20,  // 6: com.seed.documents.v3alpha.ListAccountsResponse.accounts:type_name -> com.seed.documents.v3alpha.Account
-	67,  // 7: com.seed.documents.v3alpha.BatchGetAccountsResponse.accounts:type_name -> com.seed.documents.v3alpha.BatchGetAccountsResponse.AccountsEntry
-	68,  // 8: com.seed.documents.v3alpha.BatchGetAccountsResponse.errors:type_name -> com.seed.documents.v3alpha.BatchGetAccountsResponse.ErrorsEntry
-	21,  // 9: com.seed.documents.v3alpha.UpdateProfileRequest.profile:type_name -> com.seed.documents.v3alpha.Profile
-	85,  // 10: com.seed.documents.v3alpha.Account.metadata:type_name -> google.protobuf.Struct
-	58,  // 11: com.seed.documents.v3alpha.Account.activity_summary:type_name -> com.seed.documents.v3alpha.ActivitySummary
-	21,  // 12: com.seed.documents.v3alpha.Account.profile:type_name -> com.seed.documents.v3alpha.Profile
-	56,  // 13: com.seed.documents.v3alpha.Account.home_document_info:type_name -> com.seed.documents.v3alpha.DocumentInfo
-	86,  // 14: com.seed.documents.v3alpha.Profile.update_time:type_name -> google.protobuf.Timestamp
-	29,  // 15: com.seed.documents.v3alpha.UpdateContactRequest.contact:type_name -> com.seed.documents.v3alpha.Contact
-	29,  // 16: com.seed.documents.v3alpha.ListContactsResponse.contacts:type_name -> com.seed.documents.v3alpha.Contact
-	86,  // 17: com.seed.documents.v3alpha.Contact.create_time:type_name -> google.protobuf.Timestamp
-	86,  // 18: com.seed.documents.v3alpha.Contact.update_time:type_name -> google.protobuf.Timestamp
-	85,  // 19: com.seed.documents.v3alpha.Contact.metadata:type_name -> google.protobuf.Struct
-	31,  // 20: com.seed.documents.v3alpha.ListDirectoryRequest.sort_options:type_name -> com.seed.documents.v3alpha.SortOptions
+	90,  // 4: com.seed.documents.v3alpha.ListRootDocumentsResponse.documents:type_name -> com.seed.documents.v3alpha.DocumentInfo
+	33,  // 5: com.seed.documents.v3alpha.ListAccountsRequest.sort_options:type_name -> com.seed.documents.v3alpha.SortOptions
+	22,  // 6: com.seed.documents.v3alpha.ListAccountsResponse.accounts:type_name -> com.seed.documents.v3alpha.Account
+	102, // 7: com.seed.documents.v3alpha.BatchGetAccountsResponse.accounts:type_name -> com.seed.documents.v3alpha.BatchGetAccountsResponse.AccountsEntry
+	103, // 8: com.seed.documents.v3alpha.BatchGetAccountsResponse.errors:type_name -> com.seed.documents.v3alpha.BatchGetAccountsResponse.ErrorsEntry
+	23,  // 9: com.seed.documents.v3alpha.UpdateProfileRequest.profile:type_name -> com.seed.documents.v3alpha.Profile
+	122, // 10: com.seed.documents.v3alpha.Account.metadata:type_name -> google.protobuf.Struct
+	93,  // 11: com.seed.documents.v3alpha.Account.activity_summary:type_name -> com.seed.documents.v3alpha.ActivitySummary
+	23,  // 12: com.seed.documents.v3alpha.Account.profile:type_name -> com.seed.documents.v3alpha.Profile
+	90,  // 13: com.seed.documents.v3alpha.Account.home_document_info:type_name -> com.seed.documents.v3alpha.DocumentInfo
+	123, // 14: com.seed.documents.v3alpha.Profile.update_time:type_name -> google.protobuf.Timestamp
+	31,  // 15: com.seed.documents.v3alpha.UpdateContactRequest.contact:type_name -> com.seed.documents.v3alpha.Contact
+	31,  // 16: com.seed.documents.v3alpha.ListContactsResponse.contacts:type_name -> com.seed.documents.v3alpha.Contact
+	123, // 17: com.seed.documents.v3alpha.Contact.create_time:type_name -> google.protobuf.Timestamp
+	123, // 18: com.seed.documents.v3alpha.Contact.update_time:type_name -> google.protobuf.Timestamp
+	122, // 19: com.seed.documents.v3alpha.Contact.metadata:type_name -> google.protobuf.Struct
+	33,  // 20: com.seed.documents.v3alpha.ListDirectoryRequest.sort_options:type_name -> com.seed.documents.v3alpha.SortOptions
 	1,   // 21: com.seed.documents.v3alpha.SortOptions.attribute:type_name -> com.seed.documents.v3alpha.SortAttribute
-	56,  // 22: com.seed.documents.v3alpha.ListDirectoryResponse.documents:type_name -> com.seed.documents.v3alpha.DocumentInfo
-	56,  // 23: com.seed.documents.v3alpha.ListDocumentsResponse.documents:type_name -> com.seed.documents.v3alpha.DocumentInfo
-	87,  // 24: com.seed.documents.v3alpha.AttributeValue.null_value:type_name -> google.protobuf.Empty
-	69,  // 25: com.seed.documents.v3alpha.DocumentFilter.and:type_name -> com.seed.documents.v3alpha.DocumentFilter.And
-	70,  // 26: com.seed.documents.v3alpha.DocumentFilter.or:type_name -> com.seed.documents.v3alpha.DocumentFilter.Or
-	71,  // 27: com.seed.documents.v3alpha.DocumentFilter.not:type_name -> com.seed.documents.v3alpha.DocumentFilter.Not
-	72,  // 28: com.seed.documents.v3alpha.DocumentFilter.comparison:type_name -> com.seed.documents.v3alpha.DocumentFilter.Comparison
-	73,  // 29: com.seed.documents.v3alpha.DocumentFilter.exists:type_name -> com.seed.documents.v3alpha.DocumentFilter.Presence
-	73,  // 30: com.seed.documents.v3alpha.DocumentFilter.missing:type_name -> com.seed.documents.v3alpha.DocumentFilter.Presence
-	74,  // 31: com.seed.documents.v3alpha.DocumentFilter.string_match:type_name -> com.seed.documents.v3alpha.DocumentFilter.StringMatch
-	75,  // 32: com.seed.documents.v3alpha.DocumentFilter.url_match:type_name -> com.seed.documents.v3alpha.DocumentFilter.URLMatch
-	76,  // 33: com.seed.documents.v3alpha.DocumentFilter.space_match:type_name -> com.seed.documents.v3alpha.DocumentFilter.SpaceMatch
-	77,  // 34: com.seed.documents.v3alpha.DocumentFilter.path_match:type_name -> com.seed.documents.v3alpha.DocumentFilter.PathMatch
-	36,  // 35: com.seed.documents.v3alpha.QueryDocumentsRequest.filter:type_name -> com.seed.documents.v3alpha.DocumentFilter
-	37,  // 36: com.seed.documents.v3alpha.QueryDocumentsRequest.sort:type_name -> com.seed.documents.v3alpha.DocumentSort
-	56,  // 37: com.seed.documents.v3alpha.QueryDocumentsResponse.documents:type_name -> com.seed.documents.v3alpha.DocumentInfo
+	90,  // 22: com.seed.documents.v3alpha.ListDirectoryResponse.documents:type_name -> com.seed.documents.v3alpha.DocumentInfo
+	90,  // 23: com.seed.documents.v3alpha.ListDocumentsResponse.documents:type_name -> com.seed.documents.v3alpha.DocumentInfo
+	124, // 24: com.seed.documents.v3alpha.AttributeValue.null_value:type_name -> google.protobuf.Empty
+	104, // 25: com.seed.documents.v3alpha.DocumentFilter.and:type_name -> com.seed.documents.v3alpha.DocumentFilter.And
+	105, // 26: com.seed.documents.v3alpha.DocumentFilter.or:type_name -> com.seed.documents.v3alpha.DocumentFilter.Or
+	106, // 27: com.seed.documents.v3alpha.DocumentFilter.not:type_name -> com.seed.documents.v3alpha.DocumentFilter.Not
+	107, // 28: com.seed.documents.v3alpha.DocumentFilter.comparison:type_name -> com.seed.documents.v3alpha.DocumentFilter.Comparison
+	108, // 29: com.seed.documents.v3alpha.DocumentFilter.exists:type_name -> com.seed.documents.v3alpha.DocumentFilter.Presence
+	108, // 30: com.seed.documents.v3alpha.DocumentFilter.missing:type_name -> com.seed.documents.v3alpha.DocumentFilter.Presence
+	109, // 31: com.seed.documents.v3alpha.DocumentFilter.string_match:type_name -> com.seed.documents.v3alpha.DocumentFilter.StringMatch
+	110, // 32: com.seed.documents.v3alpha.DocumentFilter.url_match:type_name -> com.seed.documents.v3alpha.DocumentFilter.URLMatch
+	111, // 33: com.seed.documents.v3alpha.DocumentFilter.space_match:type_name -> com.seed.documents.v3alpha.DocumentFilter.SpaceMatch
+	112, // 34: com.seed.documents.v3alpha.DocumentFilter.path_match:type_name -> com.seed.documents.v3alpha.DocumentFilter.PathMatch
+	38,  // 35: com.seed.documents.v3alpha.QueryDocumentsRequest.filter:type_name -> com.seed.documents.v3alpha.DocumentFilter
+	39,  // 36: com.seed.documents.v3alpha.QueryDocumentsRequest.sort:type_name -> com.seed.documents.v3alpha.DocumentSort
+	90,  // 37: com.seed.documents.v3alpha.QueryDocumentsResponse.documents:type_name -> com.seed.documents.v3alpha.DocumentInfo
 	2,   // 38: com.seed.documents.v3alpha.DocumentAttributeKindUsage.kind:type_name -> com.seed.documents.v3alpha.DocumentAttributeKind
-	40,  // 39: com.seed.documents.v3alpha.DocumentAttributeName.kinds:type_name -> com.seed.documents.v3alpha.DocumentAttributeKindUsage
-	42,  // 40: com.seed.documents.v3alpha.ListDocumentAttributeNamesResponse.names:type_name -> com.seed.documents.v3alpha.DocumentAttributeName
+	42,  // 39: com.seed.documents.v3alpha.DocumentAttributeName.kinds:type_name -> com.seed.documents.v3alpha.DocumentAttributeKindUsage
+	44,  // 40: com.seed.documents.v3alpha.ListDocumentAttributeNamesResponse.names:type_name -> com.seed.documents.v3alpha.DocumentAttributeName
 	2,   // 41: com.seed.documents.v3alpha.ListDocumentAttributeValuesRequest.kind:type_name -> com.seed.documents.v3alpha.DocumentAttributeKind
-	35,  // 42: com.seed.documents.v3alpha.DocumentAttributeValue.value:type_name -> com.seed.documents.v3alpha.AttributeValue
-	45,  // 43: com.seed.documents.v3alpha.ListDocumentAttributeValuesResponse.values:type_name -> com.seed.documents.v3alpha.DocumentAttributeValue
-	55,  // 44: com.seed.documents.v3alpha.ListDocumentChangesResponse.changes:type_name -> com.seed.documents.v3alpha.DocumentChangeInfo
-	66,  // 45: com.seed.documents.v3alpha.CreateRefRequest.target:type_name -> com.seed.documents.v3alpha.RefTarget
-	86,  // 46: com.seed.documents.v3alpha.CreateRefRequest.timestamp:type_name -> google.protobuf.Timestamp
-	0,   // 47: com.seed.documents.v3alpha.CreateRefRequest.visibility:type_name -> com.seed.documents.v3alpha.ResourceVisibility
-	65,  // 48: com.seed.documents.v3alpha.ListRefsResponse.refs:type_name -> com.seed.documents.v3alpha.Ref
-	86,  // 49: com.seed.documents.v3alpha.DocumentChangeInfo.create_time:type_name -> google.protobuf.Timestamp
-	85,  // 50: com.seed.documents.v3alpha.DocumentInfo.metadata:type_name -> google.protobuf.Struct
-	86,  // 51: com.seed.documents.v3alpha.DocumentInfo.create_time:type_name -> google.protobuf.Timestamp
-	86,  // 52: com.seed.documents.v3alpha.DocumentInfo.update_time:type_name -> google.protobuf.Timestamp
-	59,  // 53: com.seed.documents.v3alpha.DocumentInfo.breadcrumbs:type_name -> com.seed.documents.v3alpha.Breadcrumb
-	58,  // 54: com.seed.documents.v3alpha.DocumentInfo.activity_summary:type_name -> com.seed.documents.v3alpha.ActivitySummary
-	57,  // 55: com.seed.documents.v3alpha.DocumentInfo.generation_info:type_name -> com.seed.documents.v3alpha.GenerationInfo
-	83,  // 56: com.seed.documents.v3alpha.DocumentInfo.redirect_info:type_name -> com.seed.documents.v3alpha.RefTarget.Redirect
-	0,   // 57: com.seed.documents.v3alpha.DocumentInfo.visibility:type_name -> com.seed.documents.v3alpha.ResourceVisibility
-	86,  // 58: com.seed.documents.v3alpha.ActivitySummary.latest_comment_time:type_name -> google.protobuf.Timestamp
-	86,  // 59: com.seed.documents.v3alpha.ActivitySummary.latest_change_time:type_name -> google.protobuf.Timestamp
-	85,  // 60: com.seed.documents.v3alpha.Document.metadata:type_name -> google.protobuf.Struct
-	61,  // 61: com.seed.documents.v3alpha.Document.content:type_name -> com.seed.documents.v3alpha.BlockNode
-	78,  // 62: com.seed.documents.v3alpha.Document.detached_blocks:type_name -> com.seed.documents.v3alpha.Document.DetachedBlocksEntry
-	86,  // 63: com.seed.documents.v3alpha.Document.create_time:type_name -> google.protobuf.Timestamp
-	86,  // 64: com.seed.documents.v3alpha.Document.update_time:type_name -> google.protobuf.Timestamp
-	57,  // 65: com.seed.documents.v3alpha.Document.generation_info:type_name -> com.seed.documents.v3alpha.GenerationInfo
-	0,   // 66: com.seed.documents.v3alpha.Document.visibility:type_name -> com.seed.documents.v3alpha.ResourceVisibility
-	62,  // 67: com.seed.documents.v3alpha.BlockNode.block:type_name -> com.seed.documents.v3alpha.Block
-	61,  // 68: com.seed.documents.v3alpha.BlockNode.children:type_name -> com.seed.documents.v3alpha.BlockNode
-	85,  // 69: com.seed.documents.v3alpha.Block.attributes:type_name -> google.protobuf.Struct
-	63,  // 70: com.seed.documents.v3alpha.Block.annotations:type_name -> com.seed.documents.v3alpha.Annotation
-	85,  // 71: com.seed.documents.v3alpha.Annotation.attributes:type_name -> google.protobuf.Struct
-	80,  // 72: com.seed.documents.v3alpha.DocumentChange.set_metadata:type_name -> com.seed.documents.v3alpha.DocumentChange.SetMetadata
-	79,  // 73: com.seed.documents.v3alpha.DocumentChange.move_block:type_name -> com.seed.documents.v3alpha.DocumentChange.MoveBlock
-	62,  // 74: com.seed.documents.v3alpha.DocumentChange.replace_block:type_name -> com.seed.documents.v3alpha.Block
-	81,  // 75: com.seed.documents.v3alpha.DocumentChange.set_attribute:type_name -> com.seed.documents.v3alpha.DocumentChange.SetAttribute
-	66,  // 76: com.seed.documents.v3alpha.Ref.target:type_name -> com.seed.documents.v3alpha.RefTarget
-	86,  // 77: com.seed.documents.v3alpha.Ref.timestamp:type_name -> google.protobuf.Timestamp
-	57,  // 78: com.seed.documents.v3alpha.Ref.generation_info:type_name -> com.seed.documents.v3alpha.GenerationInfo
-	82,  // 79: com.seed.documents.v3alpha.RefTarget.version:type_name -> com.seed.documents.v3alpha.RefTarget.Version
-	83,  // 80: com.seed.documents.v3alpha.RefTarget.redirect:type_name -> com.seed.documents.v3alpha.RefTarget.Redirect
-	84,  // 81: com.seed.documents.v3alpha.RefTarget.tombstone:type_name -> com.seed.documents.v3alpha.RefTarget.Tombstone
-	20,  // 82: com.seed.documents.v3alpha.BatchGetAccountsResponse.AccountsEntry.value:type_name -> com.seed.documents.v3alpha.Account
-	36,  // 83: com.seed.documents.v3alpha.DocumentFilter.And.filters:type_name -> com.seed.documents.v3alpha.DocumentFilter
-	36,  // 84: com.seed.documents.v3alpha.DocumentFilter.Or.filters:type_name -> com.seed.documents.v3alpha.DocumentFilter
-	36,  // 85: com.seed.documents.v3alpha.DocumentFilter.Not.filter:type_name -> com.seed.documents.v3alpha.DocumentFilter
-	3,   // 86: com.seed.documents.v3alpha.DocumentFilter.Comparison.operator:type_name -> com.seed.documents.v3alpha.DocumentFilter.Comparison.Operator
-	35,  // 87: com.seed.documents.v3alpha.DocumentFilter.Comparison.value:type_name -> com.seed.documents.v3alpha.AttributeValue
-	61,  // 88: com.seed.documents.v3alpha.Document.DetachedBlocksEntry.value:type_name -> com.seed.documents.v3alpha.BlockNode
-	87,  // 89: com.seed.documents.v3alpha.DocumentChange.SetAttribute.null_value:type_name -> google.protobuf.Empty
-	4,   // 90: com.seed.documents.v3alpha.Documents.GetDocument:input_type -> com.seed.documents.v3alpha.GetDocumentRequest
-	6,   // 91: com.seed.documents.v3alpha.Documents.GetDocumentInfo:input_type -> com.seed.documents.v3alpha.GetDocumentInfoRequest
-	7,   // 92: com.seed.documents.v3alpha.Documents.BatchGetDocumentInfo:input_type -> com.seed.documents.v3alpha.BatchGetDocumentInfoRequest
-	9,   // 93: com.seed.documents.v3alpha.Documents.PrepareChange:input_type -> com.seed.documents.v3alpha.PrepareChangeRequest
-	11,  // 94: com.seed.documents.v3alpha.Documents.DeleteDocument:input_type -> com.seed.documents.v3alpha.DeleteDocumentRequest
-	14,  // 95: com.seed.documents.v3alpha.Documents.ListAccounts:input_type -> com.seed.documents.v3alpha.ListAccountsRequest
-	16,  // 96: com.seed.documents.v3alpha.Documents.GetAccount:input_type -> com.seed.documents.v3alpha.GetAccountRequest
-	17,  // 97: com.seed.documents.v3alpha.Documents.BatchGetAccounts:input_type -> com.seed.documents.v3alpha.BatchGetAccountsRequest
-	19,  // 98: com.seed.documents.v3alpha.Documents.UpdateProfile:input_type -> com.seed.documents.v3alpha.UpdateProfileRequest
-	22,  // 99: com.seed.documents.v3alpha.Documents.CreateAlias:input_type -> com.seed.documents.v3alpha.CreateAliasRequest
-	23,  // 100: com.seed.documents.v3alpha.Documents.CreateContact:input_type -> com.seed.documents.v3alpha.CreateContactRequest
-	24,  // 101: com.seed.documents.v3alpha.Documents.GetContact:input_type -> com.seed.documents.v3alpha.GetContactRequest
-	25,  // 102: com.seed.documents.v3alpha.Documents.UpdateContact:input_type -> com.seed.documents.v3alpha.UpdateContactRequest
-	26,  // 103: com.seed.documents.v3alpha.Documents.DeleteContact:input_type -> com.seed.documents.v3alpha.DeleteContactRequest
-	27,  // 104: com.seed.documents.v3alpha.Documents.ListContacts:input_type -> com.seed.documents.v3alpha.ListContactsRequest
-	30,  // 105: com.seed.documents.v3alpha.Documents.ListDirectory:input_type -> com.seed.documents.v3alpha.ListDirectoryRequest
-	33,  // 106: com.seed.documents.v3alpha.Documents.ListDocuments:input_type -> com.seed.documents.v3alpha.ListDocumentsRequest
-	12,  // 107: com.seed.documents.v3alpha.Documents.ListRootDocuments:input_type -> com.seed.documents.v3alpha.ListRootDocumentsRequest
-	38,  // 108: com.seed.documents.v3alpha.Documents.QueryDocuments:input_type -> com.seed.documents.v3alpha.QueryDocumentsRequest
-	41,  // 109: com.seed.documents.v3alpha.Documents.ListDocumentAttributeNames:input_type -> com.seed.documents.v3alpha.ListDocumentAttributeNamesRequest
-	44,  // 110: com.seed.documents.v3alpha.Documents.ListDocumentAttributeValues:input_type -> com.seed.documents.v3alpha.ListDocumentAttributeValuesRequest
-	47,  // 111: com.seed.documents.v3alpha.Documents.ListDocumentChanges:input_type -> com.seed.documents.v3alpha.ListDocumentChangesRequest
-	49,  // 112: com.seed.documents.v3alpha.Documents.GetDocumentChange:input_type -> com.seed.documents.v3alpha.GetDocumentChangeRequest
-	50,  // 113: com.seed.documents.v3alpha.Documents.UpdateDocumentReadStatus:input_type -> com.seed.documents.v3alpha.UpdateDocumentReadStatusRequest
-	51,  // 114: com.seed.documents.v3alpha.Documents.CreateRef:input_type -> com.seed.documents.v3alpha.CreateRefRequest
-	52,  // 115: com.seed.documents.v3alpha.Documents.GetRef:input_type -> com.seed.documents.v3alpha.GetRefRequest
-	53,  // 116: com.seed.documents.v3alpha.Documents.ListRefs:input_type -> com.seed.documents.v3alpha.ListRefsRequest
-	60,  // 117: com.seed.documents.v3alpha.Documents.GetDocument:output_type -> com.seed.documents.v3alpha.Document
-	56,  // 118: com.seed.documents.v3alpha.Documents.GetDocumentInfo:output_type -> com.seed.documents.v3alpha.DocumentInfo
-	8,   // 119: com.seed.documents.v3alpha.Documents.BatchGetDocumentInfo:output_type -> com.seed.documents.v3alpha.BatchGetDocumentInfoResponse
-	10,  // 120: com.seed.documents.v3alpha.Documents.PrepareChange:output_type -> com.seed.documents.v3alpha.PrepareChangeResponse
-	87,  // 121: com.seed.documents.v3alpha.Documents.DeleteDocument:output_type -> google.protobuf.Empty
-	15,  // 122: com.seed.documents.v3alpha.Documents.ListAccounts:output_type -> com.seed.documents.v3alpha.ListAccountsResponse
-	20,  // 123: com.seed.documents.v3alpha.Documents.GetAccount:output_type -> com.seed.documents.v3alpha.Account
-	18,  // 124: com.seed.documents.v3alpha.Documents.BatchGetAccounts:output_type -> com.seed.documents.v3alpha.BatchGetAccountsResponse
-	20,  // 125: com.seed.documents.v3alpha.Documents.UpdateProfile:output_type -> com.seed.documents.v3alpha.Account
-	87,  // 126: com.seed.documents.v3alpha.Documents.CreateAlias:output_type -> google.protobuf.Empty
-	29,  // 127: com.seed.documents.v3alpha.Documents.CreateContact:output_type -> com.seed.documents.v3alpha.Contact
-	29,  // 128: com.seed.documents.v3alpha.Documents.GetContact:output_type -> com.seed.documents.v3alpha.Contact
-	29,  // 129: com.seed.documents.v3alpha.Documents.UpdateContact:output_type -> com.seed.documents.v3alpha.Contact
-	87,  // 130: com.seed.documents.v3alpha.Documents.DeleteContact:output_type -> google.protobuf.Empty
-	28,  // 131: com.seed.documents.v3alpha.Documents.ListContacts:output_type -> com.seed.documents.v3alpha.ListContactsResponse
-	32,  // 132: com.seed.documents.v3alpha.Documents.ListDirectory:output_type -> com.seed.documents.v3alpha.ListDirectoryResponse
-	34,  // 133: com.seed.documents.v3alpha.Documents.ListDocuments:output_type -> com.seed.documents.v3alpha.ListDocumentsResponse
-	13,  // 134: com.seed.documents.v3alpha.Documents.ListRootDocuments:output_type -> com.seed.documents.v3alpha.ListRootDocumentsResponse
-	39,  // 135: com.seed.documents.v3alpha.Documents.QueryDocuments:output_type -> com.seed.documents.v3alpha.QueryDocumentsResponse
-	43,  // 136: com.seed.documents.v3alpha.Documents.ListDocumentAttributeNames:output_type -> com.seed.documents.v3alpha.ListDocumentAttributeNamesResponse
-	46,  // 137: com.seed.documents.v3alpha.Documents.ListDocumentAttributeValues:output_type -> com.seed.documents.v3alpha.ListDocumentAttributeValuesResponse
-	48,  // 138: com.seed.documents.v3alpha.Documents.ListDocumentChanges:output_type -> com.seed.documents.v3alpha.ListDocumentChangesResponse
-	55,  // 139: com.seed.documents.v3alpha.Documents.GetDocumentChange:output_type -> com.seed.documents.v3alpha.DocumentChangeInfo
-	87,  // 140: com.seed.documents.v3alpha.Documents.UpdateDocumentReadStatus:output_type -> google.protobuf.Empty
-	65,  // 141: com.seed.documents.v3alpha.Documents.CreateRef:output_type -> com.seed.documents.v3alpha.Ref
-	65,  // 142: com.seed.documents.v3alpha.Documents.GetRef:output_type -> com.seed.documents.v3alpha.Ref
-	54,  // 143: com.seed.documents.v3alpha.Documents.ListRefs:output_type -> com.seed.documents.v3alpha.ListRefsResponse
-	117, // [117:144] is the sub-list for method output_type
-	90,  // [90:117] is the sub-list for method input_type
-	90,  // [90:90] is the sub-list for extension type_name
-	90,  // [90:90] is the sub-list for extension extendee
-	0,   // [0:90] is the sub-list for field type_name
+	37,  // 42: com.seed.documents.v3alpha.DocumentAttributeValue.value:type_name -> com.seed.documents.v3alpha.AttributeValue
+	47,  // 43: com.seed.documents.v3alpha.ListDocumentAttributeValuesResponse.values:type_name -> com.seed.documents.v3alpha.DocumentAttributeValue
+	89,  // 44: com.seed.documents.v3alpha.ListDocumentChangesResponse.changes:type_name -> com.seed.documents.v3alpha.DocumentChangeInfo
+	56,  // 45: com.seed.documents.v3alpha.DiffDocumentResponse.metadata:type_name -> com.seed.documents.v3alpha.AttributeDiff
+	54,  // 46: com.seed.documents.v3alpha.DiffDocumentResponse.blocks:type_name -> com.seed.documents.v3alpha.BlockDiff
+	3,   // 47: com.seed.documents.v3alpha.BlockDiff.kind:type_name -> com.seed.documents.v3alpha.BlockDiffKind
+	97,  // 48: com.seed.documents.v3alpha.BlockDiff.base_block:type_name -> com.seed.documents.v3alpha.Block
+	97,  // 49: com.seed.documents.v3alpha.BlockDiff.target_block:type_name -> com.seed.documents.v3alpha.Block
+	55,  // 50: com.seed.documents.v3alpha.BlockDiff.text:type_name -> com.seed.documents.v3alpha.TextDiff
+	56,  // 51: com.seed.documents.v3alpha.BlockDiff.attributes:type_name -> com.seed.documents.v3alpha.AttributeDiff
+	4,   // 52: com.seed.documents.v3alpha.TextDiff.kind:type_name -> com.seed.documents.v3alpha.TextDiffKind
+	125, // 53: com.seed.documents.v3alpha.AttributeDiff.base_value:type_name -> google.protobuf.Value
+	125, // 54: com.seed.documents.v3alpha.AttributeDiff.target_value:type_name -> google.protobuf.Value
+	59,  // 55: com.seed.documents.v3alpha.DocumentBlame.blocks:type_name -> com.seed.documents.v3alpha.BlockBlame
+	60,  // 56: com.seed.documents.v3alpha.BlockBlame.content:type_name -> com.seed.documents.v3alpha.Attribution
+	60,  // 57: com.seed.documents.v3alpha.BlockBlame.position:type_name -> com.seed.documents.v3alpha.Attribution
+	61,  // 58: com.seed.documents.v3alpha.BlockBlame.attributes:type_name -> com.seed.documents.v3alpha.AttributeBlame
+	62,  // 59: com.seed.documents.v3alpha.BlockBlame.text:type_name -> com.seed.documents.v3alpha.TextBlame
+	123, // 60: com.seed.documents.v3alpha.Attribution.create_time:type_name -> google.protobuf.Timestamp
+	60,  // 61: com.seed.documents.v3alpha.AttributeBlame.attribution:type_name -> com.seed.documents.v3alpha.Attribution
+	60,  // 62: com.seed.documents.v3alpha.TextBlame.attribution:type_name -> com.seed.documents.v3alpha.Attribution
+	100, // 63: com.seed.documents.v3alpha.RevertDocumentResponse.ref:type_name -> com.seed.documents.v3alpha.Ref
+	95,  // 64: com.seed.documents.v3alpha.RevertDocumentResponse.document:type_name -> com.seed.documents.v3alpha.Document
+	0,   // 65: com.seed.documents.v3alpha.CreateBranchRequest.visibility:type_name -> com.seed.documents.v3alpha.ResourceVisibility
+	123, // 66: com.seed.documents.v3alpha.Branch.update_time:type_name -> google.protobuf.Timestamp
+	0,   // 67: com.seed.documents.v3alpha.Branch.visibility:type_name -> com.seed.documents.v3alpha.ResourceVisibility
+	66,  // 68: com.seed.documents.v3alpha.ListBranchesResponse.branches:type_name -> com.seed.documents.v3alpha.Branch
+	73,  // 69: com.seed.documents.v3alpha.MoveDocumentTreeResponse.moved_documents:type_name -> com.seed.documents.v3alpha.MovedDocument
+	100, // 70: com.seed.documents.v3alpha.MoveDocumentTreeResponse.partial_refs:type_name -> com.seed.documents.v3alpha.Ref
+	100, // 71: com.seed.documents.v3alpha.MovedDocument.ref:type_name -> com.seed.documents.v3alpha.Ref
+	100, // 72: com.seed.documents.v3alpha.MovedDocument.redirect:type_name -> com.seed.documents.v3alpha.Ref
+	76,  // 73: com.seed.documents.v3alpha.CopyDocumentResponse.copied_documents:type_name -> com.seed.documents.v3alpha.CopiedDocument
+	100, // 74: com.seed.documents.v3alpha.CopiedDocument.ref:type_name -> com.seed.documents.v3alpha.Ref
+	113, // 75: com.seed.documents.v3alpha.CreateFromTemplateRequest.variables:type_name -> com.seed.documents.v3alpha.CreateFromTemplateRequest.VariablesEntry
+	0,   // 76: com.seed.documents.v3alpha.CreateFromTemplateRequest.visibility:type_name -> com.seed.documents.v3alpha.ResourceVisibility
+	101, // 77: com.seed.documents.v3alpha.CreateRefRequest.target:type_name -> com.seed.documents.v3alpha.RefTarget
+	123, // 78: com.seed.documents.v3alpha.CreateRefRequest.timestamp:type_name -> google.protobuf.Timestamp
+	0,   // 79: com.seed.documents.v3alpha.CreateRefRequest.visibility:type_name -> com.seed.documents.v3alpha.ResourceVisibility
+	100, // 80: com.seed.documents.v3alpha.ListRefsResponse.refs:type_name -> com.seed.documents.v3alpha.Ref
+	84,  // 81: com.seed.documents.v3alpha.ScheduleRefRequest.blobs:type_name -> com.seed.documents.v3alpha.ScheduledBlob
+	123, // 82: com.seed.documents.v3alpha.ScheduleRefRequest.publish_time:type_name -> google.protobuf.Timestamp
+	88,  // 83: com.seed.documents.v3alpha.ListScheduledRefsResponse.scheduled_refs:type_name -> com.seed.documents.v3alpha.ScheduledRef
+	100, // 84: com.seed.documents.v3alpha.ScheduledRef.ref:type_name -> com.seed.documents.v3alpha.Ref
+	123, // 85: com.seed.documents.v3alpha.ScheduledRef.publish_time:type_name -> google.protobuf.Timestamp
+	123, // 86: com.seed.documents.v3alpha.ScheduledRef.create_time:type_name -> google.protobuf.Timestamp
+	123, // 87: com.seed.documents.v3alpha.ScheduledRef.retry_time:type_name -> google.protobuf.Timestamp
+	123, // 88: com.seed.documents.v3alpha.DocumentChangeInfo.create_time:type_name -> google.protobuf.Timestamp
+	122, // 89: com.seed.documents.v3alpha.DocumentInfo.metadata:type_name -> google.protobuf.Struct
+	123, // 90: com.seed.documents.v3alpha.DocumentInfo.create_time:type_name -> google.protobuf.Timestamp
+	123, // 91: com.seed.documents.v3alpha.DocumentInfo.update_time:type_name -> google.protobuf.Timestamp
+	94,  // 92: com.seed.documents.v3alpha.DocumentInfo.breadcrumbs:type_name -> com.seed.documents.v3alpha.Breadcrumb
+	93,  // 93: com.seed.documents.v3alpha.DocumentInfo.activity_summary:type_name -> com.seed.documents.v3alpha.ActivitySummary
+	92,  // 94: com.seed.documents.v3alpha.DocumentInfo.generation_info:type_name -> com.seed.documents.v3alpha.GenerationInfo
+	120, // 95: com.seed.documents.v3alpha.DocumentInfo.redirect_info:type_name -> com.seed.documents.v3alpha.RefTarget.Redirect
+	0,   // 96: com.seed.documents.v3alpha.DocumentInfo.visibility:type_name -> com.seed.documents.v3alpha.ResourceVisibility
+	91,  // 97: com.seed.documents.v3alpha.DocumentInfo.reactions:type_name -> com.seed.documents.v3alpha.ReactionCount
+	123, // 98: com.seed.documents.v3alpha.ActivitySummary.latest_comment_time:type_name -> google.protobuf.Timestamp
+	123, // 99: com.seed.documents.v3alpha.ActivitySummary.latest_change_time:type_name -> google.protobuf.Timestamp
+	122, // 100: com.seed.documents.v3alpha.Document.metadata:type_name -> google.protobuf.Struct
+	96,  // 101: com.seed.documents.v3alpha.Document.content:type_name -> com.seed.documents.v3alpha.BlockNode
+	114, // 102: com.seed.documents.v3alpha.Document.detached_blocks:type_name -> com.seed.documents.v3alpha.Document.DetachedBlocksEntry
+	123, // 103: com.seed.documents.v3alpha.Document.create_time:type_name -> google.protobuf.Timestamp
+	123, // 104: com.seed.documents.v3alpha.Document.update_time:type_name -> google.protobuf.Timestamp
+	92,  // 105: com.seed.documents.v3alpha.Document.generation_info:type_name -> com.seed.documents.v3alpha.GenerationInfo
+	0,   // 106: com.seed.documents.v3alpha.Document.visibility:type_name -> com.seed.documents.v3alpha.ResourceVisibility
+	97,  // 107: com.seed.documents.v3alpha.BlockNode.block:type_name -> com.seed.documents.v3alpha.Block
+	96,  // 108: com.seed.documents.v3alpha.BlockNode.children:type_name -> com.seed.documents.v3alpha.BlockNode
+	122, // 109: com.seed.documents.v3alpha.Block.attributes:type_name -> google.protobuf.Struct
+	98,  // 110: com.seed.documents.v3alpha.Block.annotations:type_name -> com.seed.documents.v3alpha.Annotation
+	122, // 111: com.seed.documents.v3alpha.Annotation.attributes:type_name -> google.protobuf.Struct
+	116, // 112: com.seed.documents.v3alpha.DocumentChange.set_metadata:type_name -> com.seed.documents.v3alpha.DocumentChange.SetMetadata
+	115, // 113: com.seed.documents.v3alpha.DocumentChange.move_block:type_name -> com.seed.documents.v3alpha.DocumentChange.MoveBlock
+	97,  // 114: com.seed.documents.v3alpha.DocumentChange.replace_block:type_name -> com.seed.documents.v3alpha.Block
+	117, // 115: com.seed.documents.v3alpha.DocumentChange.set_attribute:type_name -> com.seed.documents.v3alpha.DocumentChange.SetAttribute
+	118, // 116: com.seed.documents.v3alpha.DocumentChange.splice_text:type_name -> com.seed.documents.v3alpha.DocumentChange.SpliceText
+	101, // 117: com.seed.documents.v3alpha.Ref.target:type_name -> com.seed.documents.v3alpha.RefTarget
+	123, // 118: com.seed.documents.v3alpha.Ref.timestamp:type_name -> google.protobuf.Timestamp
+	92,  // 119: com.seed.documents.v3alpha.Ref.generation_info:type_name -> com.seed.documents.v3alpha.GenerationInfo
+	119, // 120: com.seed.documents.v3alpha.RefTarget.version:type_name -> com.seed.documents.v3alpha.RefTarget.Version
+	120, // 121: com.seed.documents.v3alpha.RefTarget.redirect:type_name -> com.seed.documents.v3alpha.RefTarget.Redirect
+	121, // 122: com.seed.documents.v3alpha.RefTarget.tombstone:type_name -> com.seed.documents.v3alpha.RefTarget.Tombstone
+	22,  // 123: com.seed.documents.v3alpha.BatchGetAccountsResponse.AccountsEntry.value:type_name -> com.seed.documents.v3alpha.Account
+	38,  // 124: com.seed.documents.v3alpha.DocumentFilter.And.filters:type_name -> com.seed.documents.v3alpha.DocumentFilter
+	38,  // 125: com.seed.documents.v3alpha.DocumentFilter.Or.filters:type_name -> com.seed.documents.v3alpha.DocumentFilter
+	38,  // 126: com.seed.documents.v3alpha.DocumentFilter.Not.filter:type_name -> com.seed.documents.v3alpha.DocumentFilter
+	5,   // 127: com.seed.documents.v3alpha.DocumentFilter.Comparison.operator:type_name -> com.seed.documents.v3alpha.DocumentFilter.Comparison.Operator
+	37,  // 128: com.seed.documents.v3alpha.DocumentFilter.Comparison.value:type_name -> com.seed.documents.v3alpha.AttributeValue
+	96,  // 129: com.seed.documents.v3alpha.Document.DetachedBlocksEntry.value:type_name -> com.seed.documents.v3alpha.BlockNode
+	124, // 130: com.seed.documents.v3alpha.DocumentChange.SetAttribute.null_value:type_name -> google.protobuf.Empty
+	6,   // 131: com.seed.documents.v3alpha.Documents.GetDocument:input_type -> com.seed.documents.v3alpha.GetDocumentRequest
+	8,   // 132: com.seed.documents.v3alpha.Documents.GetDocumentInfo:input_type -> com.seed.documents.v3alpha.GetDocumentInfoRequest
+	9,   // 133: com.seed.documents.v3alpha.Documents.BatchGetDocumentInfo:input_type -> com.seed.documents.v3alpha.BatchGetDocumentInfoRequest
+	11,  // 134: com.seed.documents.v3alpha.Documents.PrepareChange:input_type -> com.seed.documents.v3alpha.PrepareChangeRequest
+	13,  // 135: com.seed.documents.v3alpha.Documents.DeleteDocument:input_type -> com.seed.documents.v3alpha.DeleteDocumentRequest
+	16,  // 136: com.seed.documents.v3alpha.Documents.ListAccounts:input_type -> com.seed.documents.v3alpha.ListAccountsRequest
+	18,  // 137: com.seed.documents.v3alpha.Documents.GetAccount:input_type -> com.seed.documents.v3alpha.GetAccountRequest
+	19,  // 138: com.seed.documents.v3alpha.Documents.BatchGetAccounts:input_type -> com.seed.documents.v3alpha.BatchGetAccountsRequest
+	21,  // 139: com.seed.documents.v3alpha.Documents.UpdateProfile:input_type -> com.seed.documents.v3alpha.UpdateProfileRequest
+	24,  // 140: com.seed.documents.v3alpha.Documents.CreateAlias:input_type -> com.seed.documents.v3alpha.CreateAliasRequest
+	25,  // 141: com.seed.documents.v3alpha.Documents.CreateContact:input_type -> com.seed.documents.v3alpha.CreateContactRequest
+	26,  // 142: com.seed.documents.v3alpha.Documents.GetContact:input_type -> com.seed.documents.v3alpha.GetContactRequest
+	27,  // 143: com.seed.documents.v3alpha.Documents.UpdateContact:input_type -> com.seed.documents.v3alpha.UpdateContactRequest
+	28,  // 144: com.seed.documents.v3alpha.Documents.DeleteContact:input_type -> com.seed.documents.v3alpha.DeleteContactRequest
+	29,  // 145: com.seed.documents.v3alpha.Documents.ListContacts:input_type -> com.seed.documents.v3alpha.ListContactsRequest
+	32,  // 146: com.seed.documents.v3alpha.Documents.ListDirectory:input_type -> com.seed.documents.v3alpha.ListDirectoryRequest
+	35,  // 147: com.seed.documents.v3alpha.Documents.ListDocuments:input_type -> com.seed.documents.v3alpha.ListDocumentsRequest
+	14,  // 148: com.seed.documents.v3alpha.Documents.ListRootDocuments:input_type -> com.seed.documents.v3alpha.ListRootDocumentsRequest
+	40,  // 149: com.seed.documents.v3alpha.Documents.QueryDocuments:input_type -> com.seed.documents.v3alpha.QueryDocumentsRequest
+	43,  // 150: com.seed.documents.v3alpha.Documents.ListDocumentAttributeNames:input_type -> com.seed.documents.v3alpha.ListDocumentAttributeNamesRequest
+	46,  // 151: com.seed.documents.v3alpha.Documents.ListDocumentAttributeValues:input_type -> com.seed.documents.v3alpha.ListDocumentAttributeValuesRequest
+	49,  // 152: com.seed.documents.v3alpha.Documents.ListDocumentChanges:input_type -> com.seed.documents.v3alpha.ListDocumentChangesRequest
+	51,  // 153: com.seed.documents.v3alpha.Documents.GetDocumentChange:input_type -> com.seed.documents.v3alpha.GetDocumentChangeRequest
+	52,  // 154: com.seed.documents.v3alpha.Documents.DiffDocument:input_type -> com.seed.documents.v3alpha.DiffDocumentRequest
+	57,  // 155: com.seed.documents.v3alpha.Documents.GetDocumentBlame:input_type -> com.seed.documents.v3alpha.GetDocumentBlameRequest
+	63,  // 156: com.seed.documents.v3alpha.Documents.RevertDocument:input_type -> com.seed.documents.v3alpha.RevertDocumentRequest
+	65,  // 157: com.seed.documents.v3alpha.Documents.CreateBranch:input_type -> com.seed.documents.v3alpha.CreateBranchRequest
+	67,  // 158: com.seed.documents.v3alpha.Documents.ListBranches:input_type -> com.seed.documents.v3alpha.ListBranchesRequest
+	69,  // 159: com.seed.documents.v3alpha.Documents.DiffBranch:input_type -> com.seed.documents.v3alpha.DiffBranchRequest
+	70,  // 160: com.seed.documents.v3alpha.Documents.MergeBranch:input_type -> com.seed.documents.v3alpha.MergeBranchRequest
+	71,  // 161: com.seed.documents.v3alpha.Documents.MoveDocumentTree:input_type -> com.seed.documents.v3alpha.MoveDocumentTreeRequest
+	74,  // 162: com.seed.documents.v3alpha.Documents.CopyDocument:input_type -> com.seed.documents.v3alpha.CopyDocumentRequest
+	77,  // 163: com.seed.documents.v3alpha.Documents.CreateFromTemplate:input_type -> com.seed.documents.v3alpha.CreateFromTemplateRequest
+	78,  // 164: com.seed.documents.v3alpha.Documents.UpdateDocumentReadStatus:input_type -> com.seed.documents.v3alpha.UpdateDocumentReadStatusRequest
+	79,  // 165: com.seed.documents.v3alpha.Documents.CreateRef:input_type -> com.seed.documents.v3alpha.CreateRefRequest
+	80,  // 166: com.seed.documents.v3alpha.Documents.GetRef:input_type -> com.seed.documents.v3alpha.GetRefRequest
+	81,  // 167: com.seed.documents.v3alpha.Documents.ListRefs:input_type -> com.seed.documents.v3alpha.ListRefsRequest
+	83,  // 168: com.seed.documents.v3alpha.Documents.ScheduleRef:input_type -> com.seed.documents.v3alpha.ScheduleRefRequest
+	85,  // 169: com.seed.documents.v3alpha.Documents.ListScheduledRefs:input_type -> com.seed.documents.v3alpha.ListScheduledRefsRequest
+	87,  // 170: com.seed.documents.v3alpha.Documents.CancelScheduledRef:input_type -> com.seed.documents.v3alpha.CancelScheduledRefRequest
+	95,  // 171: com.seed.documents.v3alpha.Documents.GetDocument:output_type -> com.seed.documents.v3alpha.Document
+	90,  // 172: com.seed.documents.v3alpha.Documents.GetDocumentInfo:output_type -> com.seed.documents.v3alpha.DocumentInfo
+	10,  // 173: com.seed.documents.v3alpha.Documents.BatchGetDocumentInfo:output_type -> com.seed.documents.v3alpha.BatchGetDocumentInfoResponse
+	12,  // 174: com.seed.documents.v3alpha.Documents.PrepareChange:output_type -> com.seed.documents.v3alpha.PrepareChangeResponse
+	124, // 175: com.seed.documents.v3alpha.Documents.DeleteDocument:output_type -> google.protobuf.Empty
+	17,  // 176: com.seed.documents.v3alpha.Documents.ListAccounts:output_type -> com.seed.documents.v3alpha.ListAccountsResponse
+	22,  // 177: com.seed.documents.v3alpha.Documents.GetAccount:output_type -> com.seed.documents.v3alpha.Account
+	20,  // 178: com.seed.documents.v3alpha.Documents.BatchGetAccounts:output_type -> com.seed.documents.v3alpha.BatchGetAccountsResponse
+	22,  // 179: com.seed.documents.v3alpha.Documents.UpdateProfile:output_type -> com.seed.documents.v3alpha.Account
+	124, // 180: com.seed.documents.v3alpha.Documents.CreateAlias:output_type -> google.protobuf.Empty
+	31,  // 181: com.seed.documents.v3alpha.Documents.CreateContact:output_type -> com.seed.documents.v3alpha.Contact
+	31,  // 182: com.seed.documents.v3alpha.Documents.GetContact:output_type -> com.seed.documents.v3alpha.Contact
+	31,  // 183: com.seed.documents.v3alpha.Documents.UpdateContact:output_type -> com.seed.documents.v3alpha.Contact
+	124, // 184: com.seed.documents.v3alpha.Documents.DeleteContact:output_type -> google.protobuf.Empty
+	30,  // 185: com.seed.documents.v3alpha.Documents.ListContacts:output_type -> com.seed.documents.v3alpha.ListContactsResponse
+	34,  // 186: com.seed.documents.v3alpha.Documents.ListDirectory:output_type -> com.seed.documents.v3alpha.ListDirectoryResponse
+	36,  // 187: com.seed.documents.v3alpha.Documents.ListDocuments:output_type -> com.seed.documents.v3alpha.ListDocumentsResponse
+	15,  // 188: com.seed.documents.v3alpha.Documents.ListRootDocuments:output_type -> com.seed.documents.v3alpha.ListRootDocumentsResponse
+	41,  // 189: com.seed.documents.v3alpha.Documents.QueryDocuments:output_type -> com.seed.documents.v3alpha.QueryDocumentsResponse
+	45,  // 190: com.seed.documents.v3alpha.Documents.ListDocumentAttributeNames:output_type -> com.seed.documents.v3alpha.ListDocumentAttributeNamesResponse
+	48,  // 191: com.seed.documents.v3alpha.Documents.ListDocumentAttributeValues:output_type -> com.seed.documents.v3alpha.ListDocumentAttributeValuesResponse
+	50,  // 192: com.seed.documents.v3alpha.Documents.ListDocumentChanges:output_type -> com.seed.documents.v3alpha.ListDocumentChangesResponse
+	89,  // 193: com.seed.documents.v3alpha.Documents.GetDocumentChange:output_type -> com.seed.documents.v3alpha.DocumentChangeInfo
+	53,  // 194: com.seed.documents.v3alpha.Documents.DiffDocument:output_type -> com.seed.documents.v3alpha.DiffDocumentResponse
+	58,  // 195: com.seed.documents.v3alpha.Documents.GetDocumentBlame:output_type -> com.seed.documents.v3alpha.DocumentBlame
+	64,  // 196: com.seed.documents.v3alpha.Documents.RevertDocument:output_type -> com.seed.documents.v3alpha.RevertDocumentResponse
+	66,  // 197: com.seed.documents.v3alpha.Documents.CreateBranch:output_type -> com.seed.documents.v3alpha.Branch
+	68,  // 198: com.seed.documents.v3alpha.Documents.ListBranches:output_type -> com.seed.documents.v3alpha.ListBranchesResponse
+	53,  // 199: com.seed.documents.v3alpha.Documents.DiffBranch:output_type -> com.seed.documents.v3alpha.DiffDocumentResponse
+	100, // 200: com.seed.documents.v3alpha.Documents.MergeBranch:output_type -> com.seed.documents.v3alpha.Ref
+	72,  // 201: com.seed.documents.v3alpha.Documents.MoveDocumentTree:output_type -> com.seed.documents.v3alpha.MoveDocumentTreeResponse
+	75,  // 202: com.seed.documents.v3alpha.Documents.CopyDocument:output_type -> com.seed.documents.v3alpha.CopyDocumentResponse
+	95,  // 203: com.seed.documents.v3alpha.Documents.CreateFromTemplate:output_type -> com.seed.documents.v3alpha.Document
+	124, // 204: com.seed.documents.v3alpha.Documents.UpdateDocumentReadStatus:output_type -> google.protobuf.Empty
+	100, // 205: com.seed.documents.v3alpha.Documents.CreateRef:output_type -> com.seed.documents.v3alpha.Ref
+	100, // 206: com.seed.documents.v3alpha.Documents.GetRef:output_type -> com.seed.documents.v3alpha.Ref
+	82,  // 207: com.seed.documents.v3alpha.Documents.ListRefs:output_type -> com.seed.documents.v3alpha.ListRefsResponse
+	88,  // 208: com.seed.documents.v3alpha.Documents.ScheduleRef:output_type -> com.seed.documents.v3alpha.ScheduledRef
+	86,  // 209: com.seed.documents.v3alpha.Documents.ListScheduledRefs:output_type -> com.seed.documents.v3alpha.ListScheduledRefsResponse
+	124, // 210: com.seed.documents.v3alpha.Documents.CancelScheduledRef:output_type -> google.protobuf.Empty
+	171, // [171:211] is the sub-list for method output_type
+	131, // [131:171] is the sub-list for method input_type
+	131, // [131:131] is the sub-list for extension type_name
+	131, // [131:131] is the sub-list for extension extendee
+	0,   // [0:131] is the sub-list for field type_name
 }
 
 func init() { file_documents_v3alpha_documents_proto_init() }
@@ -6677,20 +9734,21 @@ func file_documents_v3alpha_documents_proto_init() {
 		(*DocumentFilter_SpaceMatch_)(nil),
 		(*DocumentFilter_PathMatch_)(nil),
 	}
-	file_documents_v3alpha_documents_proto_msgTypes[52].OneofWrappers = []any{}
-	file_documents_v3alpha_documents_proto_msgTypes[60].OneofWrappers = []any{
+	file_documents_v3alpha_documents_proto_msgTypes[84].OneofWrappers = []any{}
+	file_documents_v3alpha_documents_proto_msgTypes[93].OneofWrappers = []any{
 		(*DocumentChange_SetMetadata_)(nil),
 		(*DocumentChange_MoveBlock_)(nil),
 		(*DocumentChange_ReplaceBlock)(nil),
 		(*DocumentChange_DeleteBlock)(nil),
 		(*DocumentChange_SetAttribute_)(nil),
+		(*DocumentChange_SpliceText_)(nil),
 	}
-	file_documents_v3alpha_documents_proto_msgTypes[62].OneofWrappers = []any{
+	file_documents_v3alpha_documents_proto_msgTypes[95].OneofWrappers = []any{
 		(*RefTarget_Version_)(nil),
 		(*RefTarget_Redirect_)(nil),
 		(*RefTarget_Tombstone_)(nil),
 	}
-	file_documents_v3alpha_documents_proto_msgTypes[77].OneofWrappers = []any{
+	file_documents_v3alpha_documents_proto_msgTypes[111].OneofWrappers = []any{
 		(*DocumentChange_SetAttribute_StringValue)(nil),
 		(*DocumentChange_SetAttribute_IntValue)(nil),
 		(*DocumentChange_SetAttribute_BoolValue)(nil),
@@ -6701,8 +9759,8 @@ func file_documents_v3alpha_documents_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_documents_v3alpha_documents_proto_rawDesc), len(file_documents_v3alpha_documents_proto_rawDesc)),
-			NumEnums:      4,
-			NumMessages:   81,
+			NumEnums:      6,
+			NumMessages:   116,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
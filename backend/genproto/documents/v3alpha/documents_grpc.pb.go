@@ -43,10 +43,23 @@ const (
 	Documents_ListDocumentAttributeValues_FullMethodName = "/com.seed.documents.v3alpha.Documents/ListDocumentAttributeValues"
 	Documents_ListDocumentChanges_FullMethodName         = "/com.seed.documents.v3alpha.Documents/ListDocumentChanges"
 	Documents_GetDocumentChange_FullMethodName           = "/com.seed.documents.v3alpha.Documents/GetDocumentChange"
+	Documents_DiffDocument_FullMethodName                = "/com.seed.documents.v3alpha.Documents/DiffDocument"
+	Documents_GetDocumentBlame_FullMethodName            = "/com.seed.documents.v3alpha.Documents/GetDocumentBlame"
+	Documents_RevertDocument_FullMethodName              = "/com.seed.documents.v3alpha.Documents/RevertDocument"
+	Documents_CreateBranch_FullMethodName                = "/com.seed.documents.v3alpha.Documents/CreateBranch"
+	Documents_ListBranches_FullMethodName                = "/com.seed.documents.v3alpha.Documents/ListBranches"
+	Documents_DiffBranch_FullMethodName                  = "/com.seed.documents.v3alpha.Documents/DiffBranch"
+	Documents_MergeBranch_FullMethodName                 = "/com.seed.documents.v3alpha.Documents/MergeBranch"
+	Documents_MoveDocumentTree_FullMethodName            = "/com.seed.documents.v3alpha.Documents/MoveDocumentTree"
+	Documents_CopyDocument_FullMethodName                = "/com.seed.documents.v3alpha.Documents/CopyDocument"
+	Documents_CreateFromTemplate_FullMethodName          = "/com.seed.documents.v3alpha.Documents/CreateFromTemplate"
 	Documents_UpdateDocumentReadStatus_FullMethodName    = "/com.seed.documents.v3alpha.Documents/UpdateDocumentReadStatus"
 	Documents_CreateRef_FullMethodName                   = "/com.seed.documents.v3alpha.Documents/CreateRef"
 	Documents_GetRef_FullMethodName                      = "/com.seed.documents.v3alpha.Documents/GetRef"
 	Documents_ListRefs_FullMethodName                    = "/com.seed.documents.v3alpha.Documents/ListRefs"
+	Documents_ScheduleRef_FullMethodName                 = "/com.seed.documents.v3alpha.Documents/ScheduleRef"
+	Documents_ListScheduledRefs_FullMethodName           = "/com.seed.documents.v3alpha.Documents/ListScheduledRefs"
+	Documents_CancelScheduledRef_FullMethodName          = "/com.seed.documents.v3alpha.Documents/CancelScheduledRef"
 )
 
 // DocumentsClient is the client API for Documents service.
@@ -110,6 +123,31 @@ type DocumentsClient interface {
 	ListDocumentChanges(ctx context.Context, in *ListDocumentChangesRequest, opts ...grpc.CallOption) (*ListDocumentChangesResponse, error)
 	// Gets a single document change by ID.
 	GetDocumentChange(ctx context.Context, in *GetDocumentChangeRequest, opts ...grpc.CallOption) (*DocumentChangeInfo, error)
+	// Compares two versions of a document block by block.
+	DiffDocument(ctx context.Context, in *DiffDocumentRequest, opts ...grpc.CallOption) (*DiffDocumentResponse, error)
+	// Attributes each block of a document version to the changes that last touched it.
+	GetDocumentBlame(ctx context.Context, in *GetDocumentBlameRequest, opts ...grpc.CallOption) (*DocumentBlame, error)
+	// Restores a previous version of a document, or some of its blocks, by creating a new change on top of the current version.
+	RevertDocument(ctx context.Context, in *RevertDocumentRequest, opts ...grpc.CallOption) (*RevertDocumentResponse, error)
+	// Creates or updates a named branch of a document.
+	// Branches are drafts that don't change the published version of the document until they are merged.
+	CreateBranch(ctx context.Context, in *CreateBranchRequest, opts ...grpc.CallOption) (*Branch, error)
+	// Lists the branches of a document.
+	ListBranches(ctx context.Context, in *ListBranchesRequest, opts ...grpc.CallOption) (*ListBranchesResponse, error)
+	// Compares a branch with the published version of the document.
+	DiffBranch(ctx context.Context, in *DiffBranchRequest, opts ...grpc.CallOption) (*DiffDocumentResponse, error)
+	// Publishes the changes of a branch, creating a Ref that combines the heads of the branch and the published version.
+	MergeBranch(ctx context.Context, in *MergeBranchRequest, opts ...grpc.CallOption) (*Ref, error)
+	// Moves a document and all the documents under its path to a new path within the same account.
+	// Documents are republished at the new paths with the same genesis, and redirects are left at the old paths.
+	MoveDocumentTree(ctx context.Context, in *MoveDocumentTreeRequest, opts ...grpc.CallOption) (*MoveDocumentTreeResponse, error)
+	// Creates a new document with the content of an existing one, optionally together with all the documents under it.
+	// Copies are new documents with their own genesis, and they record where they were copied from in the `copiedFrom` metadata attribute.
+	CopyDocument(ctx context.Context, in *CopyDocumentRequest, opts ...grpc.CallOption) (*CopyDocumentResponse, error)
+	// Creates a new document from a template, replacing the {{variable}} placeholders in block texts and metadata.
+	// Templates are documents with the `template` metadata attribute set to true, and can be found with QueryDocuments.
+	// The new document records the template version it was created from in the `createdFromTemplate` metadata attribute.
+	CreateFromTemplate(ctx context.Context, in *CreateFromTemplateRequest, opts ...grpc.CallOption) (*Document, error)
 	// Updates the read status of a document.
 	UpdateDocumentReadStatus(ctx context.Context, in *UpdateDocumentReadStatusRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Creates a Ref blob for the specified account + path.
@@ -118,6 +156,14 @@ type DocumentsClient interface {
 	GetRef(ctx context.Context, in *GetRefRequest, opts ...grpc.CallOption) (*Ref, error)
 	// Lists Refs for a document.
 	ListRefs(ctx context.Context, in *ListRefsRequest, opts ...grpc.CallOption) (*ListRefsResponse, error)
+	// Holds a signed Ref, together with the blobs it depends on, in local storage until its publish time.
+	// Nothing is indexed or announced to other peers before that time.
+	// Scheduled Refs survive restarts of the daemon.
+	ScheduleRef(ctx context.Context, in *ScheduleRefRequest, opts ...grpc.CallOption) (*ScheduledRef, error)
+	// Lists the Refs that are scheduled for publishing.
+	ListScheduledRefs(ctx context.Context, in *ListScheduledRefsRequest, opts ...grpc.CallOption) (*ListScheduledRefsResponse, error)
+	// Cancels a scheduled Ref, discarding it together with the blobs held for it.
+	CancelScheduledRef(ctx context.Context, in *CancelScheduledRefRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type documentsClient struct {
@@ -359,6 +405,106 @@ func (c *documentsClient) GetDocumentChange(ctx context.Context, in *GetDocument
 	return out, nil
 }
 
+func (c *documentsClient) DiffDocument(ctx context.Context, in *DiffDocumentRequest, opts ...grpc.CallOption) (*DiffDocumentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffDocumentResponse)
+	err := c.cc.Invoke(ctx, Documents_DiffDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentsClient) GetDocumentBlame(ctx context.Context, in *GetDocumentBlameRequest, opts ...grpc.CallOption) (*DocumentBlame, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DocumentBlame)
+	err := c.cc.Invoke(ctx, Documents_GetDocumentBlame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentsClient) RevertDocument(ctx context.Context, in *RevertDocumentRequest, opts ...grpc.CallOption) (*RevertDocumentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevertDocumentResponse)
+	err := c.cc.Invoke(ctx, Documents_RevertDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentsClient) CreateBranch(ctx context.Context, in *CreateBranchRequest, opts ...grpc.CallOption) (*Branch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Branch)
+	err := c.cc.Invoke(ctx, Documents_CreateBranch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentsClient) ListBranches(ctx context.Context, in *ListBranchesRequest, opts ...grpc.CallOption) (*ListBranchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBranchesResponse)
+	err := c.cc.Invoke(ctx, Documents_ListBranches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentsClient) DiffBranch(ctx context.Context, in *DiffBranchRequest, opts ...grpc.CallOption) (*DiffDocumentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffDocumentResponse)
+	err := c.cc.Invoke(ctx, Documents_DiffBranch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentsClient) MergeBranch(ctx context.Context, in *MergeBranchRequest, opts ...grpc.CallOption) (*Ref, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ref)
+	err := c.cc.Invoke(ctx, Documents_MergeBranch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentsClient) MoveDocumentTree(ctx context.Context, in *MoveDocumentTreeRequest, opts ...grpc.CallOption) (*MoveDocumentTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveDocumentTreeResponse)
+	err := c.cc.Invoke(ctx, Documents_MoveDocumentTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentsClient) CopyDocument(ctx context.Context, in *CopyDocumentRequest, opts ...grpc.CallOption) (*CopyDocumentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CopyDocumentResponse)
+	err := c.cc.Invoke(ctx, Documents_CopyDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentsClient) CreateFromTemplate(ctx context.Context, in *CreateFromTemplateRequest, opts ...grpc.CallOption) (*Document, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Document)
+	err := c.cc.Invoke(ctx, Documents_CreateFromTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *documentsClient) UpdateDocumentReadStatus(ctx context.Context, in *UpdateDocumentReadStatusRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -399,6 +545,36 @@ func (c *documentsClient) ListRefs(ctx context.Context, in *ListRefsRequest, opt
 	return out, nil
 }
 
+func (c *documentsClient) ScheduleRef(ctx context.Context, in *ScheduleRefRequest, opts ...grpc.CallOption) (*ScheduledRef, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledRef)
+	err := c.cc.Invoke(ctx, Documents_ScheduleRef_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentsClient) ListScheduledRefs(ctx context.Context, in *ListScheduledRefsRequest, opts ...grpc.CallOption) (*ListScheduledRefsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledRefsResponse)
+	err := c.cc.Invoke(ctx, Documents_ListScheduledRefs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentsClient) CancelScheduledRef(ctx context.Context, in *CancelScheduledRefRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Documents_CancelScheduledRef_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DocumentsServer is the server API for Documents service.
 // All implementations should embed UnimplementedDocumentsServer
 // for forward compatibility.
@@ -460,6 +636,31 @@ type DocumentsServer interface {
 	ListDocumentChanges(context.Context, *ListDocumentChangesRequest) (*ListDocumentChangesResponse, error)
 	// Gets a single document change by ID.
 	GetDocumentChange(context.Context, *GetDocumentChangeRequest) (*DocumentChangeInfo, error)
+	// Compares two versions of a document block by block.
+	DiffDocument(context.Context, *DiffDocumentRequest) (*DiffDocumentResponse, error)
+	// Attributes each block of a document version to the changes that last touched it.
+	GetDocumentBlame(context.Context, *GetDocumentBlameRequest) (*DocumentBlame, error)
+	// Restores a previous version of a document, or some of its blocks, by creating a new change on top of the current version.
+	RevertDocument(context.Context, *RevertDocumentRequest) (*RevertDocumentResponse, error)
+	// Creates or updates a named branch of a document.
+	// Branches are drafts that don't change the published version of the document until they are merged.
+	CreateBranch(context.Context, *CreateBranchRequest) (*Branch, error)
+	// Lists the branches of a document.
+	ListBranches(context.Context, *ListBranchesRequest) (*ListBranchesResponse, error)
+	// Compares a branch with the published version of the document.
+	DiffBranch(context.Context, *DiffBranchRequest) (*DiffDocumentResponse, error)
+	// Publishes the changes of a branch, creating a Ref that combines the heads of the branch and the published version.
+	MergeBranch(context.Context, *MergeBranchRequest) (*Ref, error)
+	// Moves a document and all the documents under its path to a new path within the same account.
+	// Documents are republished at the new paths with the same genesis, and redirects are left at the old paths.
+	MoveDocumentTree(context.Context, *MoveDocumentTreeRequest) (*MoveDocumentTreeResponse, error)
+	// Creates a new document with the content of an existing one, optionally together with all the documents under it.
+	// Copies are new documents with their own genesis, and they record where they were copied from in the `copiedFrom` metadata attribute.
+	CopyDocument(context.Context, *CopyDocumentRequest) (*CopyDocumentResponse, error)
+	// Creates a new document from a template, replacing the {{variable}} placeholders in block texts and metadata.
+	// Templates are documents with the `template` metadata attribute set to true, and can be found with QueryDocuments.
+	// The new document records the template version it was created from in the `createdFromTemplate` metadata attribute.
+	CreateFromTemplate(context.Context, *CreateFromTemplateRequest) (*Document, error)
 	// Updates the read status of a document.
 	UpdateDocumentReadStatus(context.Context, *UpdateDocumentReadStatusRequest) (*emptypb.Empty, error)
 	// Creates a Ref blob for the specified account + path.
@@ -468,6 +669,14 @@ type DocumentsServer interface {
 	GetRef(context.Context, *GetRefRequest) (*Ref, error)
 	// Lists Refs for a document.
 	ListRefs(context.Context, *ListRefsRequest) (*ListRefsResponse, error)
+	// Holds a signed Ref, together with the blobs it depends on, in local storage until its publish time.
+	// Nothing is indexed or announced to other peers before that time.
+	// Scheduled Refs survive restarts of the daemon.
+	ScheduleRef(context.Context, *ScheduleRefRequest) (*ScheduledRef, error)
+	// Lists the Refs that are scheduled for publishing.
+	ListScheduledRefs(context.Context, *ListScheduledRefsRequest) (*ListScheduledRefsResponse, error)
+	// Cancels a scheduled Ref, discarding it together with the blobs held for it.
+	CancelScheduledRef(context.Context, *CancelScheduledRefRequest) (*emptypb.Empty, error)
 }
 
 // UnimplementedDocumentsServer should be embedded to have
@@ -546,6 +755,36 @@ func (UnimplementedDocumentsServer) ListDocumentChanges(context.Context, *ListDo
 func (UnimplementedDocumentsServer) GetDocumentChange(context.Context, *GetDocumentChangeRequest) (*DocumentChangeInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDocumentChange not implemented")
 }
+func (UnimplementedDocumentsServer) DiffDocument(context.Context, *DiffDocumentRequest) (*DiffDocumentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffDocument not implemented")
+}
+func (UnimplementedDocumentsServer) GetDocumentBlame(context.Context, *GetDocumentBlameRequest) (*DocumentBlame, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDocumentBlame not implemented")
+}
+func (UnimplementedDocumentsServer) RevertDocument(context.Context, *RevertDocumentRequest) (*RevertDocumentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertDocument not implemented")
+}
+func (UnimplementedDocumentsServer) CreateBranch(context.Context, *CreateBranchRequest) (*Branch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBranch not implemented")
+}
+func (UnimplementedDocumentsServer) ListBranches(context.Context, *ListBranchesRequest) (*ListBranchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBranches not implemented")
+}
+func (UnimplementedDocumentsServer) DiffBranch(context.Context, *DiffBranchRequest) (*DiffDocumentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffBranch not implemented")
+}
+func (UnimplementedDocumentsServer) MergeBranch(context.Context, *MergeBranchRequest) (*Ref, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeBranch not implemented")
+}
+func (UnimplementedDocumentsServer) MoveDocumentTree(context.Context, *MoveDocumentTreeRequest) (*MoveDocumentTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveDocumentTree not implemented")
+}
+func (UnimplementedDocumentsServer) CopyDocument(context.Context, *CopyDocumentRequest) (*CopyDocumentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyDocument not implemented")
+}
+func (UnimplementedDocumentsServer) CreateFromTemplate(context.Context, *CreateFromTemplateRequest) (*Document, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFromTemplate not implemented")
+}
 func (UnimplementedDocumentsServer) UpdateDocumentReadStatus(context.Context, *UpdateDocumentReadStatusRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateDocumentReadStatus not implemented")
 }
@@ -558,6 +797,15 @@ func (UnimplementedDocumentsServer) GetRef(context.Context, *GetRefRequest) (*Re
 func (UnimplementedDocumentsServer) ListRefs(context.Context, *ListRefsRequest) (*ListRefsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListRefs not implemented")
 }
+func (UnimplementedDocumentsServer) ScheduleRef(context.Context, *ScheduleRefRequest) (*ScheduledRef, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleRef not implemented")
+}
+func (UnimplementedDocumentsServer) ListScheduledRefs(context.Context, *ListScheduledRefsRequest) (*ListScheduledRefsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledRefs not implemented")
+}
+func (UnimplementedDocumentsServer) CancelScheduledRef(context.Context, *CancelScheduledRefRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledRef not implemented")
+}
 func (UnimplementedDocumentsServer) testEmbeddedByValue() {}
 
 // UnsafeDocumentsServer may be embedded to opt out of forward compatibility for this service.
@@ -992,6 +1240,186 @@ func _Documents_GetDocumentChange_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Documents_DiffDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentsServer).DiffDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Documents_DiffDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentsServer).DiffDocument(ctx, req.(*DiffDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Documents_GetDocumentBlame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDocumentBlameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentsServer).GetDocumentBlame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Documents_GetDocumentBlame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentsServer).GetDocumentBlame(ctx, req.(*GetDocumentBlameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Documents_RevertDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentsServer).RevertDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Documents_RevertDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentsServer).RevertDocument(ctx, req.(*RevertDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Documents_CreateBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBranchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentsServer).CreateBranch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Documents_CreateBranch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentsServer).CreateBranch(ctx, req.(*CreateBranchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Documents_ListBranches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBranchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentsServer).ListBranches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Documents_ListBranches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentsServer).ListBranches(ctx, req.(*ListBranchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Documents_DiffBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffBranchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentsServer).DiffBranch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Documents_DiffBranch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentsServer).DiffBranch(ctx, req.(*DiffBranchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Documents_MergeBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeBranchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentsServer).MergeBranch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Documents_MergeBranch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentsServer).MergeBranch(ctx, req.(*MergeBranchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Documents_MoveDocumentTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveDocumentTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentsServer).MoveDocumentTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Documents_MoveDocumentTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentsServer).MoveDocumentTree(ctx, req.(*MoveDocumentTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Documents_CopyDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentsServer).CopyDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Documents_CopyDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentsServer).CopyDocument(ctx, req.(*CopyDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Documents_CreateFromTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFromTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentsServer).CreateFromTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Documents_CreateFromTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentsServer).CreateFromTemplate(ctx, req.(*CreateFromTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Documents_UpdateDocumentReadStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateDocumentReadStatusRequest)
 	if err := dec(in); err != nil {
@@ -1064,6 +1492,60 @@ func _Documents_ListRefs_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Documents_ScheduleRef_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleRefRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentsServer).ScheduleRef(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Documents_ScheduleRef_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentsServer).ScheduleRef(ctx, req.(*ScheduleRefRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Documents_ListScheduledRefs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledRefsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentsServer).ListScheduledRefs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Documents_ListScheduledRefs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentsServer).ListScheduledRefs(ctx, req.(*ListScheduledRefsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Documents_CancelScheduledRef_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledRefRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentsServer).CancelScheduledRef(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Documents_CancelScheduledRef_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentsServer).CancelScheduledRef(ctx, req.(*CancelScheduledRefRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Documents_ServiceDesc is the grpc.ServiceDesc for Documents service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1163,6 +1645,46 @@ var Documents_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDocumentChange",
 			Handler:    _Documents_GetDocumentChange_Handler,
 		},
+		{
+			MethodName: "DiffDocument",
+			Handler:    _Documents_DiffDocument_Handler,
+		},
+		{
+			MethodName: "GetDocumentBlame",
+			Handler:    _Documents_GetDocumentBlame_Handler,
+		},
+		{
+			MethodName: "RevertDocument",
+			Handler:    _Documents_RevertDocument_Handler,
+		},
+		{
+			MethodName: "CreateBranch",
+			Handler:    _Documents_CreateBranch_Handler,
+		},
+		{
+			MethodName: "ListBranches",
+			Handler:    _Documents_ListBranches_Handler,
+		},
+		{
+			MethodName: "DiffBranch",
+			Handler:    _Documents_DiffBranch_Handler,
+		},
+		{
+			MethodName: "MergeBranch",
+			Handler:    _Documents_MergeBranch_Handler,
+		},
+		{
+			MethodName: "MoveDocumentTree",
+			Handler:    _Documents_MoveDocumentTree_Handler,
+		},
+		{
+			MethodName: "CopyDocument",
+			Handler:    _Documents_CopyDocument_Handler,
+		},
+		{
+			MethodName: "CreateFromTemplate",
+			Handler:    _Documents_CreateFromTemplate_Handler,
+		},
 		{
 			MethodName: "UpdateDocumentReadStatus",
 			Handler:    _Documents_UpdateDocumentReadStatus_Handler,
@@ -1179,6 +1701,18 @@ var Documents_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListRefs",
 			Handler:    _Documents_ListRefs_Handler,
 		},
+		{
+			MethodName: "ScheduleRef",
+			Handler:    _Documents_ScheduleRef_Handler,
+		},
+		{
+			MethodName: "ListScheduledRefs",
+			Handler:    _Documents_ListScheduledRefs_Handler,
+		},
+		{
+			MethodName: "CancelScheduledRef",
+			Handler:    _Documents_CancelScheduledRef_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "documents/v3alpha/documents.proto",
@@ -616,6 +616,191 @@ func (*AuthenticateResponse) Descriptor() ([]byte, []int) {
 	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{8}
 }
 
+// Request to redeem an invite.
+type RedeemInviteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Raw bytes of the signed invite.
+	Invite []byte `protobuf:"bytes,1,opt,name=invite,proto3" json:"invite,omitempty"`
+	// Raw bytes of the signed invite redemption.
+	// It proves that the caller holds the key that will receive the Capability.
+	Redemption    []byte `protobuf:"bytes,2,opt,name=redemption,proto3" json:"redemption,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemInviteRequest) Reset() {
+	*x = RedeemInviteRequest{}
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemInviteRequest) ProtoMessage() {}
+
+func (x *RedeemInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemInviteRequest.ProtoReflect.Descriptor instead.
+func (*RedeemInviteRequest) Descriptor() ([]byte, []int) {
+	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RedeemInviteRequest) GetInvite() []byte {
+	if x != nil {
+		return x.Invite
+	}
+	return nil
+}
+
+func (x *RedeemInviteRequest) GetRedemption() []byte {
+	if x != nil {
+		return x.Redemption
+	}
+	return nil
+}
+
+// Response to redeem an invite.
+type RedeemInviteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Raw bytes of the Capability blob issued for the invitee.
+	Capability    []byte `protobuf:"bytes,1,opt,name=capability,proto3" json:"capability,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemInviteResponse) Reset() {
+	*x = RedeemInviteResponse{}
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemInviteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemInviteResponse) ProtoMessage() {}
+
+func (x *RedeemInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemInviteResponse.ProtoReflect.Descriptor instead.
+func (*RedeemInviteResponse) Descriptor() ([]byte, []int) {
+	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RedeemInviteResponse) GetCapability() []byte {
+	if x != nil {
+		return x.Capability
+	}
+	return nil
+}
+
+// Request to ask for access to a space.
+type RequestAccessRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Raw bytes of the signed access request.
+	AccessRequest []byte `protobuf:"bytes,1,opt,name=access_request,json=accessRequest,proto3" json:"access_request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestAccessRequest) Reset() {
+	*x = RequestAccessRequest{}
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestAccessRequest) ProtoMessage() {}
+
+func (x *RequestAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestAccessRequest.ProtoReflect.Descriptor instead.
+func (*RequestAccessRequest) Descriptor() ([]byte, []int) {
+	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RequestAccessRequest) GetAccessRequest() []byte {
+	if x != nil {
+		return x.AccessRequest
+	}
+	return nil
+}
+
+// Response to ask for access to a space.
+type RequestAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestAccessResponse) Reset() {
+	*x = RequestAccessResponse{}
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestAccessResponse) ProtoMessage() {}
+
+func (x *RequestAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestAccessResponse.ProtoReflect.Descriptor instead.
+func (*RequestAccessResponse) Descriptor() ([]byte, []int) {
+	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{12}
+}
+
 type Blob struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// CID of the blob.
@@ -630,7 +815,7 @@ type Blob struct {
 
 func (x *Blob) Reset() {
 	*x = Blob{}
-	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[9]
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -642,7 +827,7 @@ func (x *Blob) String() string {
 func (*Blob) ProtoMessage() {}
 
 func (x *Blob) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[9]
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -655,7 +840,7 @@ func (x *Blob) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Blob.ProtoReflect.Descriptor instead.
 func (*Blob) Descriptor() ([]byte, []int) {
-	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{9}
+	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Blob) GetCid() []byte {
@@ -689,7 +874,7 @@ type PeerInfo struct {
 
 func (x *PeerInfo) Reset() {
 	*x = PeerInfo{}
-	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[10]
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -701,7 +886,7 @@ func (x *PeerInfo) String() string {
 func (*PeerInfo) ProtoMessage() {}
 
 func (x *PeerInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[10]
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -714,7 +899,7 @@ func (x *PeerInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerInfo.ProtoReflect.Descriptor instead.
 func (*PeerInfo) Descriptor() ([]byte, []int) {
-	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{10}
+	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *PeerInfo) GetId() string {
@@ -781,7 +966,19 @@ const file_p2p_v1alpha_p2p_proto_rawDesc = "" +
 	"\aaccount\x18\x01 \x01(\fR\aaccount\x12\x1c\n" +
 	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\x1c\n" +
 	"\tsignature\x18\x03 \x01(\fR\tsignature\"\x16\n" +
-	"\x14AuthenticateResponse\"0\n" +
+	"\x14AuthenticateResponse\"M\n" +
+	"\x13RedeemInviteRequest\x12\x16\n" +
+	"\x06invite\x18\x01 \x01(\fR\x06invite\x12\x1e\n" +
+	"\n" +
+	"redemption\x18\x02 \x01(\fR\n" +
+	"redemption\"6\n" +
+	"\x14RedeemInviteResponse\x12\x1e\n" +
+	"\n" +
+	"capability\x18\x01 \x01(\fR\n" +
+	"capability\"=\n" +
+	"\x14RequestAccessRequest\x12%\n" +
+	"\x0eaccess_request\x18\x01 \x01(\fR\raccessRequest\"\x17\n" +
+	"\x15RequestAccessResponse\"0\n" +
 	"\x04Blob\x12\x10\n" +
 	"\x03cid\x18\x01 \x01(\fR\x03cid\x12\x16\n" +
 	"\x06cursor\x18\x02 \x01(\tR\x06cursor\"\xc0\x01\n" +
@@ -796,14 +993,16 @@ const file_p2p_v1alpha_p2p_proto_rawDesc = "" +
 	"\tCONNECTED\x10\x01\x12\x0f\n" +
 	"\vCAN_CONNECT\x10\x02\x12\x12\n" +
 	"\x0eCANNOT_CONNECT\x10\x03\x12\v\n" +
-	"\aLIMITED\x10\x042\xeb\x03\n" +
+	"\aLIMITED\x10\x042\xbc\x05\n" +
 	"\x03P2P\x12Q\n" +
 	"\tListBlobs\x12&.com.seed.p2p.v1alpha.ListBlobsRequest\x1a\x1a.com.seed.p2p.v1alpha.Blob0\x01\x12\\\n" +
 	"\tListPeers\x12&.com.seed.p2p.v1alpha.ListPeersRequest\x1a'.com.seed.p2p.v1alpha.ListPeersResponse\x12_\n" +
 	"\n" +
 	"ListSpaces\x12'.com.seed.p2p.v1alpha.ListSpacesRequest\x1a(.com.seed.p2p.v1alpha.ListSpacesResponse\x12k\n" +
 	"\x0eRequestInvoice\x12+.com.seed.p2p.v1alpha.RequestInvoiceRequest\x1a,.com.seed.p2p.v1alpha.RequestInvoiceResponse\x12e\n" +
-	"\fAuthenticate\x12).com.seed.p2p.v1alpha.AuthenticateRequest\x1a*.com.seed.p2p.v1alpha.AuthenticateResponseB'Z%seed/backend/genproto/p2p/v1alpha;p2pb\x06proto3"
+	"\fAuthenticate\x12).com.seed.p2p.v1alpha.AuthenticateRequest\x1a*.com.seed.p2p.v1alpha.AuthenticateResponse\x12e\n" +
+	"\fRedeemInvite\x12).com.seed.p2p.v1alpha.RedeemInviteRequest\x1a*.com.seed.p2p.v1alpha.RedeemInviteResponse\x12h\n" +
+	"\rRequestAccess\x12*.com.seed.p2p.v1alpha.RequestAccessRequest\x1a+.com.seed.p2p.v1alpha.RequestAccessResponseB'Z%seed/backend/genproto/p2p/v1alpha;p2pb\x06proto3"
 
 var (
 	file_p2p_v1alpha_p2p_proto_rawDescOnce sync.Once
@@ -818,7 +1017,7 @@ func file_p2p_v1alpha_p2p_proto_rawDescGZIP() []byte {
 }
 
 var file_p2p_v1alpha_p2p_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_p2p_v1alpha_p2p_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_p2p_v1alpha_p2p_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_p2p_v1alpha_p2p_proto_goTypes = []any{
 	(ConnectionStatus)(0),          // 0: com.seed.p2p.v1alpha.ConnectionStatus
 	(*ListBlobsRequest)(nil),       // 1: com.seed.p2p.v1alpha.ListBlobsRequest
@@ -830,26 +1029,34 @@ var file_p2p_v1alpha_p2p_proto_goTypes = []any{
 	(*ListPeersResponse)(nil),      // 7: com.seed.p2p.v1alpha.ListPeersResponse
 	(*AuthenticateRequest)(nil),    // 8: com.seed.p2p.v1alpha.AuthenticateRequest
 	(*AuthenticateResponse)(nil),   // 9: com.seed.p2p.v1alpha.AuthenticateResponse
-	(*Blob)(nil),                   // 10: com.seed.p2p.v1alpha.Blob
-	(*PeerInfo)(nil),               // 11: com.seed.p2p.v1alpha.PeerInfo
-	(*timestamppb.Timestamp)(nil),  // 12: google.protobuf.Timestamp
+	(*RedeemInviteRequest)(nil),    // 10: com.seed.p2p.v1alpha.RedeemInviteRequest
+	(*RedeemInviteResponse)(nil),   // 11: com.seed.p2p.v1alpha.RedeemInviteResponse
+	(*RequestAccessRequest)(nil),   // 12: com.seed.p2p.v1alpha.RequestAccessRequest
+	(*RequestAccessResponse)(nil),  // 13: com.seed.p2p.v1alpha.RequestAccessResponse
+	(*Blob)(nil),                   // 14: com.seed.p2p.v1alpha.Blob
+	(*PeerInfo)(nil),               // 15: com.seed.p2p.v1alpha.PeerInfo
+	(*timestamppb.Timestamp)(nil),  // 16: google.protobuf.Timestamp
 }
 var file_p2p_v1alpha_p2p_proto_depIdxs = []int32{
-	11, // 0: com.seed.p2p.v1alpha.ListPeersResponse.peers:type_name -> com.seed.p2p.v1alpha.PeerInfo
+	15, // 0: com.seed.p2p.v1alpha.ListPeersResponse.peers:type_name -> com.seed.p2p.v1alpha.PeerInfo
 	0,  // 1: com.seed.p2p.v1alpha.PeerInfo.connection_status:type_name -> com.seed.p2p.v1alpha.ConnectionStatus
-	12, // 2: com.seed.p2p.v1alpha.PeerInfo.updated_at:type_name -> google.protobuf.Timestamp
+	16, // 2: com.seed.p2p.v1alpha.PeerInfo.updated_at:type_name -> google.protobuf.Timestamp
 	1,  // 3: com.seed.p2p.v1alpha.P2P.ListBlobs:input_type -> com.seed.p2p.v1alpha.ListBlobsRequest
 	2,  // 4: com.seed.p2p.v1alpha.P2P.ListPeers:input_type -> com.seed.p2p.v1alpha.ListPeersRequest
 	3,  // 5: com.seed.p2p.v1alpha.P2P.ListSpaces:input_type -> com.seed.p2p.v1alpha.ListSpacesRequest
 	5,  // 6: com.seed.p2p.v1alpha.P2P.RequestInvoice:input_type -> com.seed.p2p.v1alpha.RequestInvoiceRequest
 	8,  // 7: com.seed.p2p.v1alpha.P2P.Authenticate:input_type -> com.seed.p2p.v1alpha.AuthenticateRequest
-	10, // 8: com.seed.p2p.v1alpha.P2P.ListBlobs:output_type -> com.seed.p2p.v1alpha.Blob
-	7,  // 9: com.seed.p2p.v1alpha.P2P.ListPeers:output_type -> com.seed.p2p.v1alpha.ListPeersResponse
-	4,  // 10: com.seed.p2p.v1alpha.P2P.ListSpaces:output_type -> com.seed.p2p.v1alpha.ListSpacesResponse
-	6,  // 11: com.seed.p2p.v1alpha.P2P.RequestInvoice:output_type -> com.seed.p2p.v1alpha.RequestInvoiceResponse
-	9,  // 12: com.seed.p2p.v1alpha.P2P.Authenticate:output_type -> com.seed.p2p.v1alpha.AuthenticateResponse
-	8,  // [8:13] is the sub-list for method output_type
-	3,  // [3:8] is the sub-list for method input_type
+	10, // 8: com.seed.p2p.v1alpha.P2P.RedeemInvite:input_type -> com.seed.p2p.v1alpha.RedeemInviteRequest
+	12, // 9: com.seed.p2p.v1alpha.P2P.RequestAccess:input_type -> com.seed.p2p.v1alpha.RequestAccessRequest
+	14, // 10: com.seed.p2p.v1alpha.P2P.ListBlobs:output_type -> com.seed.p2p.v1alpha.Blob
+	7,  // 11: com.seed.p2p.v1alpha.P2P.ListPeers:output_type -> com.seed.p2p.v1alpha.ListPeersResponse
+	4,  // 12: com.seed.p2p.v1alpha.P2P.ListSpaces:output_type -> com.seed.p2p.v1alpha.ListSpacesResponse
+	6,  // 13: com.seed.p2p.v1alpha.P2P.RequestInvoice:output_type -> com.seed.p2p.v1alpha.RequestInvoiceResponse
+	9,  // 14: com.seed.p2p.v1alpha.P2P.Authenticate:output_type -> com.seed.p2p.v1alpha.AuthenticateResponse
+	11, // 15: com.seed.p2p.v1alpha.P2P.RedeemInvite:output_type -> com.seed.p2p.v1alpha.RedeemInviteResponse
+	13, // 16: com.seed.p2p.v1alpha.P2P.RequestAccess:output_type -> com.seed.p2p.v1alpha.RequestAccessResponse
+	10, // [10:17] is the sub-list for method output_type
+	3,  // [3:10] is the sub-list for method input_type
 	3,  // [3:3] is the sub-list for extension type_name
 	3,  // [3:3] is the sub-list for extension extendee
 	0,  // [0:3] is the sub-list for field type_name
@@ -866,7 +1073,7 @@ func file_p2p_v1alpha_p2p_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_p2p_v1alpha_p2p_proto_rawDesc), len(file_p2p_v1alpha_p2p_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   11,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
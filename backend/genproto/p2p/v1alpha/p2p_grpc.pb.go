@@ -24,6 +24,8 @@ const (
 	P2P_ListSpaces_FullMethodName     = "/com.seed.p2p.v1alpha.P2P/ListSpaces"
 	P2P_RequestInvoice_FullMethodName = "/com.seed.p2p.v1alpha.P2P/RequestInvoice"
 	P2P_Authenticate_FullMethodName   = "/com.seed.p2p.v1alpha.P2P/Authenticate"
+	P2P_RedeemInvite_FullMethodName   = "/com.seed.p2p.v1alpha.P2P/RedeemInvite"
+	P2P_RequestAccess_FullMethodName  = "/com.seed.p2p.v1alpha.P2P/RequestAccess"
 )
 
 // P2PClient is the client API for P2P service.
@@ -47,6 +49,11 @@ type P2PClient interface {
 	RequestInvoice(ctx context.Context, in *RequestInvoiceRequest, opts ...grpc.CallOption) (*RequestInvoiceResponse, error)
 	// Lets a peer to authenticate itself with an account key.
 	Authenticate(ctx context.Context, in *AuthenticateRequest, opts ...grpc.CallOption) (*AuthenticateResponse, error)
+	// Redeems an invite issued by this peer, in exchange for a Capability for the key of the caller.
+	RedeemInvite(ctx context.Context, in *RedeemInviteRequest, opts ...grpc.CallOption) (*RedeemInviteResponse, error)
+	// Asks the owner of a space to grant access to it.
+	// The caller must be authenticated with the account that signed the access request.
+	RequestAccess(ctx context.Context, in *RequestAccessRequest, opts ...grpc.CallOption) (*RequestAccessResponse, error)
 }
 
 type p2PClient struct {
@@ -116,6 +123,26 @@ func (c *p2PClient) Authenticate(ctx context.Context, in *AuthenticateRequest, o
 	return out, nil
 }
 
+func (c *p2PClient) RedeemInvite(ctx context.Context, in *RedeemInviteRequest, opts ...grpc.CallOption) (*RedeemInviteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RedeemInviteResponse)
+	err := c.cc.Invoke(ctx, P2P_RedeemInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *p2PClient) RequestAccess(ctx context.Context, in *RequestAccessRequest, opts ...grpc.CallOption) (*RequestAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestAccessResponse)
+	err := c.cc.Invoke(ctx, P2P_RequestAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // P2PServer is the server API for P2P service.
 // All implementations should embed UnimplementedP2PServer
 // for forward compatibility.
@@ -137,6 +164,11 @@ type P2PServer interface {
 	RequestInvoice(context.Context, *RequestInvoiceRequest) (*RequestInvoiceResponse, error)
 	// Lets a peer to authenticate itself with an account key.
 	Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error)
+	// Redeems an invite issued by this peer, in exchange for a Capability for the key of the caller.
+	RedeemInvite(context.Context, *RedeemInviteRequest) (*RedeemInviteResponse, error)
+	// Asks the owner of a space to grant access to it.
+	// The caller must be authenticated with the account that signed the access request.
+	RequestAccess(context.Context, *RequestAccessRequest) (*RequestAccessResponse, error)
 }
 
 // UnimplementedP2PServer should be embedded to have
@@ -161,6 +193,12 @@ func (UnimplementedP2PServer) RequestInvoice(context.Context, *RequestInvoiceReq
 func (UnimplementedP2PServer) Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Authenticate not implemented")
 }
+func (UnimplementedP2PServer) RedeemInvite(context.Context, *RedeemInviteRequest) (*RedeemInviteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemInvite not implemented")
+}
+func (UnimplementedP2PServer) RequestAccess(context.Context, *RequestAccessRequest) (*RequestAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestAccess not implemented")
+}
 func (UnimplementedP2PServer) testEmbeddedByValue() {}
 
 // UnsafeP2PServer may be embedded to opt out of forward compatibility for this service.
@@ -264,6 +302,42 @@ func _P2P_Authenticate_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _P2P_RedeemInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(P2PServer).RedeemInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: P2P_RedeemInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(P2PServer).RedeemInvite(ctx, req.(*RedeemInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _P2P_RequestAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(P2PServer).RequestAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: P2P_RequestAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(P2PServer).RequestAccess(ctx, req.(*RequestAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // P2P_ServiceDesc is the grpc.ServiceDesc for P2P service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -287,6 +361,14 @@ var P2P_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Authenticate",
 			Handler:    _P2P_Authenticate_Handler,
 		},
+		{
+			MethodName: "RedeemInvite",
+			Handler:    _P2P_RedeemInvite_Handler,
+		},
+		{
+			MethodName: "RequestAccess",
+			Handler:    _P2P_RequestAccess_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
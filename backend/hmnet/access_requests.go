@@ -0,0 +1,30 @@
+package hmnet
+
+import (
+	"context"
+	p2p "seed/backend/genproto/p2p/v1alpha"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AccessRequestHandler receives access requests for the spaces owned by the keys of this peer.
+type AccessRequestHandler interface {
+	ReceiveAccessRequest(ctx context.Context, from peer.ID, in *p2p.RequestAccessRequest) (*p2p.RequestAccessResponse, error)
+}
+
+// RequestAccess receives a request for access to a local space.
+func (srv *rpcMux) RequestAccess(ctx context.Context, in *p2p.RequestAccessRequest) (*p2p.RequestAccessResponse, error) {
+	n := srv.Node
+	if n.accessRequests == nil {
+		return nil, status.Errorf(codes.Unimplemented, "method RequestAccess not ready yet")
+	}
+
+	pid, err := getRemoteID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.accessRequests.ReceiveAccessRequest(ctx, pid, in)
+}
@@ -112,6 +112,8 @@ type Node struct {
 	keys                core.KeyStore
 	cfg                 config.P2P
 	invoicer            Invoicer
+	inviteIssuer        InviteIssuer
+	accessRequests      AccessRequestHandler
 	client              *Client
 	protocol            ProtocolInfo
 	p2p                 *ipfs.Libp2p
@@ -246,6 +248,16 @@ func (n *Node) SetInvoicer(inv Invoicer) {
 	n.invoicer = inv
 }
 
+// SetInviteIssuer assigns a service that issues capabilities for redeemed invites.
+func (n *Node) SetInviteIssuer(iss InviteIssuer) {
+	n.inviteIssuer = iss
+}
+
+// SetAccessRequestHandler assigns a service that receives access requests from remote peers.
+func (n *Node) SetAccessRequestHandler(h AccessRequestHandler) {
+	n.accessRequests = h
+}
+
 // ProtocolID returns the supported protocol ID.
 func (n *Node) ProtocolID() protocol.ID {
 	return n.protocol.ID
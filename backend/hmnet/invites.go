@@ -0,0 +1,25 @@
+package hmnet
+
+import (
+	"context"
+	p2p "seed/backend/genproto/p2p/v1alpha"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// InviteIssuer issues capabilities for the invites this peer has created.
+// It is used when a remote peer wants to redeem one of our invites.
+type InviteIssuer interface {
+	IssueInvitedCapability(ctx context.Context, in *p2p.RedeemInviteRequest) (*p2p.RedeemInviteResponse, error)
+}
+
+// RedeemInvite issues a capability for a local invite.
+func (srv *rpcMux) RedeemInvite(ctx context.Context, in *p2p.RedeemInviteRequest) (*p2p.RedeemInviteResponse, error) {
+	n := srv.Node
+	if n.inviteIssuer == nil {
+		return nil, status.Errorf(codes.Unimplemented, "method RedeemInvite not ready yet")
+	}
+
+	return n.inviteIssuer.IssueInvitedCapability(ctx, in)
+}
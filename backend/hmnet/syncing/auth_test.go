@@ -210,6 +210,18 @@ func (f *fakeAuthIndex) ReindexInfo() blob.ReindexInfo {
 	return blob.ReindexInfo{}
 }
 
+func (f *fakeAuthIndex) IsTombstoned(context.Context, cid.Cid) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeAuthIndex) ClearTombstones(context.Context, blob.IRI, bool) error {
+	return nil
+}
+
+func (f *fakeAuthIndex) SpaceOverQuota(context.Context, core.Principal) (bool, error) {
+	return false, nil
+}
+
 func (f *fakeAuthIndex) GetAuthorizedSpacesForPeer(context.Context, peer.ID, []blob.IRI) ([]core.Principal, error) {
 	panic("unexpected GetAuthorizedSpacesForPeer call")
 }
@@ -101,6 +101,9 @@ func (s *Service) DiscoverObject(ctx context.Context, entityID blob.IRI, version
 	return s.DiscoverObjectWithProgress(ctx, entityID, version, recursive, depthOne, blobTypes, prog)
 }
 
+// ErrSpaceOverQuota is returned when discovering the content of a space over its storage quota.
+var ErrSpaceOverQuota = errors.New("space is over its storage quota")
+
 // docStructureTypes is the blob-type allowlist for the root-first discovery
 // phases: just the document itself (its version Refs and change history). It
 // deliberately excludes Comment so the page text assembles fast without dragging
@@ -115,6 +118,23 @@ func (s *Service) DiscoverObjectWithProgress(ctx context.Context, entityID blob.
 		return "", fmt.Errorf("remote content discovery is disabled")
 	}
 
+	space, _, spaceErr := entityID.SpacePath()
+
+	// Spaces over their storage quota are not synced until they fit again,
+	// e.g. after their quota is raised, or their blobs are garbage collected.
+	// The index never reports the spaces of our own keys as over quota, so they keep syncing from our other devices.
+	if spaceErr == nil {
+		over, err := s.index.SpaceOverQuota(ctx, space)
+		if err != nil {
+			return "", fmt.Errorf("failed to check the storage quota of space %s: %w", space, err)
+		}
+		if over {
+			syncperf.Default.RecordQuotaDeferral()
+			s.log.Debug("DiscoveryDeferredOverQuota", zap.String("iri", string(entityID)))
+			return "", fmt.Errorf("%w: %s", ErrSpaceOverQuota, space)
+		}
+	}
+
 	discoverStart := time.Now()
 	// This is the single entry point for every sync session — scheduler tasks,
 	// gRPC DiscoverEntity, and direct subscription calls all funnel here — so
@@ -122,7 +142,7 @@ func (s *Service) DiscoverObjectWithProgress(ctx context.Context, entityID blob.
 	// accounting. Concurrent sessions are handled by the tracker: it unions
 	// their intervals instead of summing them.
 	sessionSite := ""
-	if space, _, err := entityID.SpacePath(); err == nil {
+	if spaceErr == nil {
 		sessionSite = space.String()
 	}
 	endSession := syncperf.Default.SessionStart(sessionSite)
@@ -144,6 +164,11 @@ func (s *Service) DiscoverObjectWithProgress(ctx context.Context, entityID blob.
 		)
 	}()
 
+	// Discovering a resource again means we want its blobs back, even if they were garbage collected.
+	if err := s.index.ClearTombstones(ctx, entityID, recursive || depthOne); err != nil {
+		s.log.Warn("Failed to clear tombstones", zap.String("iri", string(entityID)), zap.Error(err))
+	}
+
 	ctxLocalPeers, cancel := context.WithTimeout(ctx, DefaultSyncingTimeout)
 	defer cancel()
 	c, err := ipfs.NewCID(uint64(multicodec.Raw), uint64(multicodec.Identity), []byte(entityID))
@@ -940,6 +965,33 @@ func GetRelatedMaterial(conn *sqlite.Conn, dkeys map[DiscoveryKey]struct{}, incl
 	return cids, nil
 }
 
+// RelatedBlobIDs returns the IDs of all the blobs we would sync for the given discovery keys,
+// including the linked and cited resources, the authors, and the media files.
+// Visibility is not checked, so private blobs are included too.
+func RelatedBlobIDs(conn *sqlite.Conn, dkeys map[DiscoveryKey]struct{}) (ids []int64, err error) {
+	if err := collectBlobs(conn, dkeys, true); err != nil {
+		return nil, err
+	}
+
+	if err := sqlitex.Exec(conn, "SELECT id FROM rbsr_blobs ORDER BY id", func(stmt *sqlite.Stmt) error {
+		ids = append(ids, stmt.ColumnInt64(0))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func collectGCRelatedBlobs(conn *sqlite.Conn, roots []blob.GCRoot) ([]int64, error) {
+	dkeys := make(map[DiscoveryKey]struct{}, len(roots))
+	for _, root := range roots {
+		dkeys[DiscoveryKey{IRI: root.IRI, Recursive: root.Recursive}] = struct{}{}
+	}
+
+	return RelatedBlobIDs(conn, dkeys)
+}
+
 func collectBlobs(conn *sqlite.Conn, dkeys map[DiscoveryKey]struct{}, includeLinksCitationsAccounts bool) (err error) {
 	// List of data to sync here https://seedteamtalks.hyper.media/discussions/things-to-sync-when-pushing-to-a-server?v=bafy2bzacebddt2wpn4vxfqc7zxqvxbq32tyjne23eirpn62vvqo2ce72mjf3g&l
 	if err := ensureTempTable(conn, "rbsr_iris"); err != nil {
@@ -1173,8 +1225,8 @@ func fillTables(conn *sqlite.Conn, dkeys map[DiscoveryKey]struct{}, includeAccou
 			// TODO(burdiyan): this query doesn't do anything, I forget why it's here.
 		}
 	*/
-	// Fill resource-scoped structural blobs (Refs + Capability + Comment +
-	// Profile + Contact) in one INSERT, gated by the type allowlist. Each
+	// Fill resource-scoped structural blobs (Refs + Capability + Revocation +
+	// SpaceKey + Comment + Reaction + Profile + Contact + KeyRotation + Membership + Snapshot) in one INSERT, gated by the type allowlist. Each
 	// has the same shape (WHERE resource IN rbsr_iris AND type = ?); merging
 	// removes one prepare/exec round-trip and one temp-table scan compared
 	// to running two same-shape INSERTs.
@@ -1186,7 +1238,7 @@ func fillTables(conn *sqlite.Conn, dkeys map[DiscoveryKey]struct{}, includeAccou
 	// blob_links, so the seed-arm `WHERE bl.type='ref/head'` filter
 	// naturally excludes them.
 	{
-		resourceTypes := []string{"Ref", "Capability", "Comment", "Profile", "Contact"}
+		resourceTypes := []string{"Ref", "Capability", "Revocation", "SpaceKey", "Comment", "Reaction", "Profile", "Contact", "KeyRotation", "Membership", "Snapshot"}
 		var allowed []string
 		for _, t := range resourceTypes {
 			if hasType(typeFilter, t) {
@@ -42,11 +42,17 @@ import (
 // INSERT). Changes are deliberately absent: they carry no resource and enter
 // only through the ref/head+change/dep closure.
 var resourceScopedTypes = map[string]struct{}{
-	"Ref":        {},
-	"Capability": {},
-	"Comment":    {},
-	"Profile":    {},
-	"Contact":    {},
+	"Ref":         {},
+	"Capability":  {},
+	"Revocation":  {},
+	"SpaceKey":    {},
+	"Comment":     {},
+	"Reaction":    {},
+	"Profile":     {},
+	"Contact":     {},
+	"KeyRotation": {},
+	"Membership":  {},
+	"Snapshot":    {},
 }
 
 // scopeCovers reports whether scope s includes the resource identified by iri
@@ -30,6 +30,7 @@ import (
 	"seed/backend/util/longrunning"
 	"seed/backend/util/sqlite"
 	"seed/backend/util/sqlite/sqlitex"
+	"seed/backend/util/syncperf"
 	"seed/backend/util/unsafeutil"
 )
 
@@ -46,6 +47,8 @@ type blobIndex interface {
 	PutMany(context.Context, []blocks.Block) error
 	GetAuthorizedSpacesForPeer(context.Context, peer.ID, []blob.IRI) ([]core.Principal, error)
 	ReindexInfo() blob.ReindexInfo
+	SpaceQuotas() blob.SpaceQuotas
+	SpaceOverQuota(context.Context, core.Principal) (bool, error)
 }
 
 // NewServer creates a new RPC handler instance.
@@ -56,6 +59,8 @@ func NewServer(db *sqlitex.Pool, index *blob.Index, bswap bitswap, maxInboundRec
 	// right after the indexing transaction commits, so reconciliation serves a
 	// fresh set without rebuilding it and without slowing down writes.
 	index.SetIndexedHook(MaintainRBSRIndex)
+	// The garbage collector keeps the same related material we sync.
+	index.SetCollectRelatedBlobs(collectGCRelatedBlobs)
 
 	return &Server{
 		db:               db,
@@ -148,6 +153,94 @@ func (l *inboundReconcileLimiter) acquire(ctx context.Context) (func(), error) {
 	}
 }
 
+// refuseOverQuota drops the pushed blobs of spaces over their storage quota.
+// Refs, Comments, Reactions, and Snapshots name their space. Other blobs, like Changes and media, belong to the spaces
+// of the pushed blobs linking to them, directly or through other pushed blobs, e.g. a Ref links to its genesis and heads,
+// and a Change to its dependencies and media. These blobs are only dropped when all of their spaces are over quota,
+// and the ones nothing in the push links to are kept. The order of the kept blobs is preserved.
+func (s *Server) refuseOverQuota(ctx context.Context, blks []blocks.Block) (kept []blocks.Block, refused int, err error) {
+	if !s.index.SpaceQuotas().Enabled() {
+		return blks, 0, nil
+	}
+
+	byCID := make(map[cid.Cid]int, len(blks))
+	for i, blk := range blks {
+		byCID[blk.Cid()] = i
+	}
+
+	var (
+		named  = make([]bool, len(blks))
+		spaces = make([][]core.Principal, len(blks))
+	)
+	for i, blk := range blks {
+		if space, ok := blob.ContentSpace(blk.Cid(), blk.RawData()); ok {
+			named[i] = true
+			spaces[i] = []core.Principal{space}
+		}
+	}
+
+	// Attributes the blob and the unnamed blobs it links to, transitively, to the space.
+	var attribute func(i int, space core.Principal)
+	attribute = func(i int, space core.Principal) {
+		for _, l := range blob.ContentLinks(blks[i].Cid(), blks[i].RawData()) {
+			j, ok := byCID[l]
+			if !ok || named[j] || slices.ContainsFunc(spaces[j], space.Equal) {
+				continue
+			}
+			spaces[j] = append(spaces[j], space)
+			attribute(j, space)
+		}
+	}
+
+	for i := range blks {
+		if named[i] {
+			attribute(i, spaces[i][0])
+		}
+	}
+
+	overQuota := make(map[core.PrincipalUnsafeString]bool)
+	drop := make([]bool, len(blks))
+	for i := range blks {
+		if len(spaces[i]) == 0 {
+			continue
+		}
+
+		drop[i] = true
+		for _, space := range spaces[i] {
+			over, seen := overQuota[space.UnsafeString()]
+			if !seen {
+				over, err = s.index.SpaceOverQuota(ctx, space)
+				if err != nil {
+					return nil, 0, err
+				}
+				overQuota[space.UnsafeString()] = over
+			}
+
+			if !over {
+				drop[i] = false
+				break
+			}
+		}
+
+		if drop[i] {
+			refused++
+		}
+	}
+
+	if refused == 0 {
+		return blks, 0, nil
+	}
+
+	kept = make([]blocks.Block, 0, len(blks)-refused)
+	for i, blk := range blks {
+		if !drop[i] {
+			kept = append(kept, blk)
+		}
+	}
+
+	return kept, refused, nil
+}
+
 // RegisterServer registers the instance with the gRPC server.
 func (s *Server) RegisterServer(srv grpc.ServiceRegistrar) {
 	p2p.RegisterSyncingServer(srv, s)
@@ -284,6 +377,18 @@ func (s *Server) AnnounceBlobs(in *p2p.AnnounceBlobsRequest, stream grpc.ServerS
 		return nil
 	}
 
+	downloaded, refused, err := s.refuseOverQuota(ctx, downloaded)
+	if err != nil {
+		return fmt.Errorf("failed to check space quotas: %w", err)
+	}
+	if refused > 0 {
+		syncperf.Default.RecordQuotaRefusal(refused)
+		s.log.Info("PushedBlobsRefusedOverQuota", zap.Int("refusedCount", refused), zap.Int("keptCount", len(downloaded)))
+	}
+	if len(downloaded) == 0 {
+		return status.Error(codes.ResourceExhausted, "pushed blobs belong to spaces over their storage quota")
+	}
+
 	fields := []zap.Field{
 		zap.Int("announcedCount", len(allAnnounced)),
 		zap.Int("wantedCount", len(wants)),
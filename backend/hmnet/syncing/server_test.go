@@ -3,11 +3,13 @@ package syncing
 import (
 	"context"
 	"runtime"
+	"slices"
 	"testing"
 	"time"
 
 	"seed/backend/blob"
 	"seed/backend/core"
+	"seed/backend/core/coretest"
 	p2p "seed/backend/genproto/p2p/v1alpha"
 	"seed/backend/storage"
 	"seed/backend/testutil"
@@ -107,9 +109,70 @@ func TestAnnounceBlobsUnavailableDuringReindex(t *testing.T) {
 	require.Equal(t, 0, idx.putManyCalls)
 }
 
+func TestRefuseOverQuota(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+	ts := time.Now().Round(blob.ClockPrecision)
+
+	newDocument := func(u coretest.Tester) []blocks.Block {
+		t.Helper()
+		change, err := blob.NewChange(u.Account, cid.Undef, nil, 0, blob.ChangeBody{}, ts)
+		require.NoError(t, err)
+		ref, err := blob.NewRef(u.Account, ts.UnixMilli(), change.CID, u.Account.Principal(), "/doc", []cid.Cid{change.CID}, ts, blob.VisibilityPublic)
+		require.NoError(t, err)
+		return []blocks.Block{change, ref}
+	}
+
+	aliceDoc := newDocument(alice)
+	bobDoc := newDocument(bob)
+
+	idx := &fakeServerIndex{overQuota: map[string]bool{bob.Account.PublicKey.String(): true}}
+	srv := &Server{index: idx, log: zap.NewNop()}
+
+	kept, refused, err := srv.refuseOverQuota(t.Context(), bobDoc)
+	require.NoError(t, err)
+	require.Equal(t, bobDoc, kept, "nothing must be refused without quotas")
+	require.Equal(t, 0, refused)
+
+	idx.quotas = blob.SpaceQuotas{Default: 1}
+
+	kept, refused, err = srv.refuseOverQuota(t.Context(), append(slices.Clone(aliceDoc), bobDoc...))
+	require.NoError(t, err)
+	require.Equal(t, aliceDoc, kept, "the changes of the space over quota must be refused along with its refs")
+	require.Equal(t, 2, refused)
+
+	kept, refused, err = srv.refuseOverQuota(t.Context(), append(slices.Clone(bobDoc), aliceDoc[0]))
+	require.NoError(t, err)
+	require.Equal(t, []blocks.Block{aliceDoc[0]}, kept, "changes of other spaces must be kept")
+	require.Equal(t, 2, refused)
+
+	kept, refused, err = srv.refuseOverQuota(t.Context(), bobDoc[:1])
+	require.NoError(t, err)
+	require.Equal(t, bobDoc[:1], kept, "changes that can't be attributed within the push must be kept")
+	require.Equal(t, 0, refused)
+
+	// Bob's change is also a head of a Ref in alice's space.
+	shared, err := blob.NewRef(alice.Account, ts.UnixMilli(), bobDoc[0].Cid(), alice.Account.Principal(), "/shared", []cid.Cid{bobDoc[0].Cid()}, ts, blob.VisibilityPublic)
+	require.NoError(t, err)
+	kept, refused, err = srv.refuseOverQuota(t.Context(), append(slices.Clone(bobDoc), shared))
+	require.NoError(t, err)
+	require.Equal(t, []blocks.Block{bobDoc[0], shared}, kept, "blobs must be kept while any of their spaces is within quota")
+	require.Equal(t, 1, refused)
+}
+
 type fakeServerIndex struct {
 	reindexInfo  blob.ReindexInfo
 	putManyCalls int
+	quotas       blob.SpaceQuotas
+	overQuota    map[string]bool
+}
+
+func (f *fakeServerIndex) SpaceQuotas() blob.SpaceQuotas {
+	return f.quotas
+}
+
+func (f *fakeServerIndex) SpaceOverQuota(_ context.Context, space core.Principal) (bool, error) {
+	return f.overQuota[space.String()], nil
 }
 
 func (f *fakeServerIndex) PutMany(context.Context, []blocks.Block) error {
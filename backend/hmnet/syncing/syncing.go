@@ -485,6 +485,11 @@ type Index interface {
 	// ReindexInfo lets the shadow-verify trickle pause while the derived
 	// tables are being torn down and rebuilt by a full reindex.
 	ReindexInfo() blob.ReindexInfo
+	// IsTombstoned lets the pre-flight filter skip the blobs removed by the garbage collector.
+	IsTombstoned(context.Context, cid.Cid) (bool, error)
+	ClearTombstones(ctx context.Context, iri blob.IRI, recursive bool) error
+	// SpaceOverQuota lets discovery defer the spaces over their storage quota.
+	SpaceOverQuota(context.Context, core.Principal) (bool, error)
 }
 
 type protocolChecker struct {
@@ -1626,6 +1631,11 @@ func syncResources(
 			preflightSkipped++
 			continue
 		}
+		// Blobs removed by the garbage collector are not fetched again until their tombstones are cleared.
+		if tombstoned, terr := idx.IsTombstoned(ctx, wc); terr == nil && tombstoned {
+			preflightSkipped++
+			continue
+		}
 		filtered = append(filtered, wc)
 	}
 	allWants = filtered
@@ -2154,11 +2164,16 @@ func (s *Service) authenticateWithPeer(ctx context.Context, pinfo peer.AddrInfo,
 		return err
 	}
 
-	localPeerID := s.host.ID()
+	return AuthenticateWithPeer(ctx, client, s.host.ID(), pinfo.ID, kp)
+}
+
+// AuthenticateWithPeer authenticates the local peer with the account of the keypair
+// on the remote peer of the given client.
+func AuthenticateWithPeer(ctx context.Context, client p2p.P2PClient, local, remote peer.ID, kp *core.KeyPair) error {
 	now := time.Now().Round(blob.ClockPrecision)
 
 	// Create ephemeral capability for authentication.
-	cpb, err := blob.NewEphemeralCapability(localPeerID, kp.Principal(), pinfo.ID, now, nil)
+	cpb, err := blob.NewEphemeralCapability(local, kp.Principal(), remote, now, nil)
 	if err != nil {
 		return err
 	}
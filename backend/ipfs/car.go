@@ -0,0 +1,240 @@
+package ipfs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+)
+
+// This file implements the subset of the CAR format (https://ipld.io/specs/transport/car/)
+// needed to export and import archives of blobs: CARv2 files without an index are written,
+// and both CARv1 and CARv2 files are read.
+
+const (
+	carMaxHeaderSize  = 32 << 10 // 32 KiB.
+	carMaxSectionSize = 8 << 20  // 8 MiB, way more than the biggest block we ever produce.
+	carV2HeaderSize   = 40
+)
+
+// carV2Pragma is the fixed prefix of CARv2 files: a CARv1-like header declaring the version 2.
+var carV2Pragma = []byte{0x0a, 0xa1, 0x67, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x02}
+
+type carHeader struct {
+	Roots   []cid.Cid `refmt:"roots"`
+	Version uint64    `refmt:"version"`
+}
+
+func init() {
+	cbornode.RegisterCborType(carHeader{})
+}
+
+// CARWriter writes blocks into a CARv2 file.
+// The size of the data payload is written into the header on Close,
+// so the underlying writer must be seekable.
+type CARWriter struct {
+	w    io.WriteSeeker
+	buf  *bufio.Writer
+	size uint64
+}
+
+// NewCARWriter writes the headers of a CARv2 file with the given roots.
+func NewCARWriter(w io.WriteSeeker, roots []cid.Cid) (*CARWriter, error) {
+	if len(roots) == 0 {
+		return nil, errors.New("CAR file must have at least one root")
+	}
+
+	cw := &CARWriter{
+		w:   w,
+		buf: bufio.NewWriter(w),
+	}
+
+	if _, err := cw.buf.Write(carV2Pragma); err != nil {
+		return nil, err
+	}
+
+	// The real header is written on Close, when we know the size of the data.
+	if _, err := cw.buf.Write(make([]byte, carV2HeaderSize)); err != nil {
+		return nil, err
+	}
+
+	header, err := cbornode.DumpObject(carHeader{Roots: roots, Version: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode CAR header: %w", err)
+	}
+
+	if err := cw.writeSection(header); err != nil {
+		return nil, err
+	}
+
+	return cw, nil
+}
+
+// Put writes a block into the file.
+func (cw *CARWriter) Put(blk blocks.Block) error {
+	return cw.writeSection(blk.Cid().Bytes(), blk.RawData())
+}
+
+func (cw *CARWriter) writeSection(parts ...[]byte) error {
+	var size int
+	for _, p := range parts {
+		size += len(p)
+	}
+
+	n, err := cw.buf.Write(binary.AppendUvarint(nil, uint64(size)))
+	if err != nil {
+		return err
+	}
+	cw.size += uint64(n)
+
+	for _, p := range parts {
+		n, err := cw.buf.Write(p)
+		if err != nil {
+			return err
+		}
+		cw.size += uint64(n)
+	}
+
+	return nil
+}
+
+// Close flushes the data and finalizes the header of the file.
+// It doesn't close the underlying writer.
+func (cw *CARWriter) Close() error {
+	if err := cw.buf.Flush(); err != nil {
+		return err
+	}
+
+	if _, err := cw.w.Seek(int64(len(carV2Pragma)), io.SeekStart); err != nil {
+		return err
+	}
+
+	// Characteristics (16 bytes) stay zero, and there's no index.
+	header := make([]byte, carV2HeaderSize)
+	binary.LittleEndian.PutUint64(header[16:], uint64(len(carV2Pragma)+carV2HeaderSize))
+	binary.LittleEndian.PutUint64(header[24:], cw.size)
+	if _, err := cw.w.Write(header); err != nil {
+		return err
+	}
+
+	_, err := cw.w.Seek(0, io.SeekEnd)
+	return err
+}
+
+// CARReader reads blocks from CARv1 or CARv2 files.
+type CARReader struct {
+	r     *bufio.Reader
+	roots []cid.Cid
+}
+
+// NewCARReader reads the headers of a CAR file.
+func NewCARReader(r io.Reader) (*CARReader, error) {
+	br := bufio.NewReader(r)
+
+	header, err := readCARSection(br, carMaxHeaderSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CAR header: %w", err)
+	}
+
+	if bytes.Equal(header, carV2Pragma[1:]) {
+		v2 := make([]byte, carV2HeaderSize)
+		if _, err := io.ReadFull(br, v2); err != nil {
+			return nil, fmt.Errorf("failed to read CARv2 header: %w", err)
+		}
+
+		var (
+			dataOffset = binary.LittleEndian.Uint64(v2[16:])
+			dataSize   = binary.LittleEndian.Uint64(v2[24:])
+			read       = uint64(len(carV2Pragma) + carV2HeaderSize)
+		)
+		if dataOffset < read {
+			return nil, fmt.Errorf("invalid CARv2 data offset %d", dataOffset)
+		}
+
+		if _, err := br.Discard(int(dataOffset - read)); err != nil {
+			return nil, fmt.Errorf("failed to seek to CARv2 data: %w", err)
+		}
+
+		// Everything after the data payload (i.e. the index) is ignored.
+		br = bufio.NewReader(io.LimitReader(br, int64(dataSize)))
+
+		header, err = readCARSection(br, carMaxHeaderSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CAR header: %w", err)
+		}
+	}
+
+	var h carHeader
+	if err := cbornode.DecodeInto(header, &h); err != nil {
+		return nil, fmt.Errorf("failed to decode CAR header: %w", err)
+	}
+
+	if h.Version != 1 {
+		return nil, fmt.Errorf("unsupported CAR version %d", h.Version)
+	}
+
+	return &CARReader{r: br, roots: h.Roots}, nil
+}
+
+// Roots returns the roots declared in the header of the file.
+func (cr *CARReader) Roots() []cid.Cid {
+	return cr.roots
+}
+
+// Next returns the next block in the file, or io.EOF when there're no more blocks.
+// The data of the blocks is verified against their CIDs.
+func (cr *CARReader) Next() (blocks.Block, error) {
+	section, err := readCARSection(cr.r, carMaxSectionSize)
+	if err != nil {
+		return nil, err
+	}
+
+	n, c, err := cid.CidFromBytes(section)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read block CID: %w", err)
+	}
+
+	data := section[n:]
+	cc, err := c.Prefix().Sum(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash block %s: %w", c, err)
+	}
+
+	if !c.Equals(cc) {
+		return nil, fmt.Errorf("block %s doesn't match its data", c)
+	}
+
+	return blocks.NewBlockWithCid(data, c)
+}
+
+// readCARSection reads a varint-prefixed section of the file.
+// Returns io.EOF only if the file ends cleanly before the section.
+func readCARSection(r *bufio.Reader, limit uint64) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+
+	if size == 0 || size > limit {
+		return nil, fmt.Errorf("invalid CAR section size %d", size)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	return data, nil
+}
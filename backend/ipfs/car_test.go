@@ -0,0 +1,85 @@
+package ipfs
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multicodec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCARRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	blks := []blocks.Block{
+		NewBlock(multicodec.DagCbor, []byte{0xa0}),
+		NewBlock(cid.Raw, []byte("raw-block")),
+		NewBlock(multicodec.DagPb, []byte{0x0a, 0x02, 0x08, 0x01}),
+	}
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "test.car"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	w, err := NewCARWriter(f, []cid.Cid{blks[0].Cid()})
+	require.NoError(t, err)
+	for _, blk := range blks {
+		require.NoError(t, w.Put(blk))
+	}
+	require.NoError(t, w.Close())
+
+	data, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	require.Equal(t, carV2Pragma, data[:len(carV2Pragma)])
+
+	read := func(data []byte) []blocks.Block {
+		t.Helper()
+		r, err := NewCARReader(bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, []cid.Cid{blks[0].Cid()}, r.Roots())
+
+		var out []blocks.Block
+		for {
+			blk, err := r.Next()
+			if errors.Is(err, io.EOF) {
+				return out
+			}
+			require.NoError(t, err)
+			out = append(out, blk)
+		}
+	}
+
+	got := read(data)
+	require.Len(t, got, len(blks))
+	for i := range blks {
+		require.Equal(t, blks[i].Cid(), got[i].Cid())
+		require.Equal(t, blks[i].RawData(), got[i].RawData())
+	}
+
+	// The CARv1 payload inside of the CARv2 file is readable on its own.
+	v1 := data[len(carV2Pragma)+carV2HeaderSize:]
+	require.Len(t, read(v1), len(blks))
+
+	// Anything after the data payload must be ignored.
+	require.Len(t, read(append(bytes.Clone(data), 0x01, 0x02, 0x03)), len(blks))
+
+	// Tampered data must be rejected.
+	tampered := bytes.Clone(data)
+	tampered[len(tampered)-1] ^= 0xff
+	r, err := NewCARReader(bytes.NewReader(tampered))
+	require.NoError(t, err)
+	for {
+		_, err = r.Next()
+		if err != nil {
+			break
+		}
+	}
+	require.Error(t, err)
+	require.NotErrorIs(t, err, io.EOF)
+}
@@ -6,6 +6,32 @@ import (
 	"seed/backend/util/sqlitegen"
 )
 
+// Table access_requests.
+const (
+	AccessRequests           sqlitegen.Table  = "access_requests"
+	AccessRequestsCapability sqlitegen.Column = "access_requests.capability"
+	AccessRequestsCreateTime sqlitegen.Column = "access_requests.create_time"
+	AccessRequestsData       sqlitegen.Column = "access_requests.data"
+	AccessRequestsDecideTime sqlitegen.Column = "access_requests.decide_time"
+	AccessRequestsID         sqlitegen.Column = "access_requests.id"
+	AccessRequestsIRI        sqlitegen.Column = "access_requests.iri"
+	AccessRequestsRequester  sqlitegen.Column = "access_requests.requester"
+	AccessRequestsStatus     sqlitegen.Column = "access_requests.status"
+)
+
+// Table access_requests. Plain strings.
+const (
+	T_AccessRequests           = "access_requests"
+	C_AccessRequestsCapability = "access_requests.capability"
+	C_AccessRequestsCreateTime = "access_requests.create_time"
+	C_AccessRequestsData       = "access_requests.data"
+	C_AccessRequestsDecideTime = "access_requests.decide_time"
+	C_AccessRequestsID         = "access_requests.id"
+	C_AccessRequestsIRI        = "access_requests.iri"
+	C_AccessRequestsRequester  = "access_requests.requester"
+	C_AccessRequestsStatus     = "access_requests.status"
+)
+
 // Table blob_links.
 const (
 	BlobLinks       sqlitegen.Table  = "blob_links"
@@ -42,6 +68,26 @@ const (
 	C_BlobLinksWithTypesTargetType = "blob_links_with_types.target_type"
 )
 
+// Table blob_tombstones.
+const (
+	BlobTombstones           sqlitegen.Table  = "blob_tombstones"
+	BlobTombstonesDeleteTime sqlitegen.Column = "blob_tombstones.delete_time"
+	BlobTombstonesID         sqlitegen.Column = "blob_tombstones.id"
+	BlobTombstonesIRI        sqlitegen.Column = "blob_tombstones.iri"
+	BlobTombstonesSize       sqlitegen.Column = "blob_tombstones.size"
+	BlobTombstonesStoredSize sqlitegen.Column = "blob_tombstones.stored_size"
+)
+
+// Table blob_tombstones. Plain strings.
+const (
+	T_BlobTombstones           = "blob_tombstones"
+	C_BlobTombstonesDeleteTime = "blob_tombstones.delete_time"
+	C_BlobTombstonesID         = "blob_tombstones.id"
+	C_BlobTombstonesIRI        = "blob_tombstones.iri"
+	C_BlobTombstonesSize       = "blob_tombstones.size"
+	C_BlobTombstonesStoredSize = "blob_tombstones.stored_size"
+)
+
 // Table blob_visibility.
 const (
 	BlobVisibility      sqlitegen.Table  = "blob_visibility"
@@ -94,6 +140,36 @@ const (
 	C_BlobsSize       = "blobs.size"
 )
 
+// Table comment_anchors.
+const (
+	CommentAnchors                sqlitegen.Table  = "comment_anchors"
+	CommentAnchorsBlock           sqlitegen.Column = "comment_anchors.block"
+	CommentAnchorsComment         sqlitegen.Column = "comment_anchors.comment"
+	CommentAnchorsIRI             sqlitegen.Column = "comment_anchors.iri"
+	CommentAnchorsRangeEnd        sqlitegen.Column = "comment_anchors.range_end"
+	CommentAnchorsRangeStart      sqlitegen.Column = "comment_anchors.range_start"
+	CommentAnchorsResolvedEnd     sqlitegen.Column = "comment_anchors.resolved_end"
+	CommentAnchorsResolvedStart   sqlitegen.Column = "comment_anchors.resolved_start"
+	CommentAnchorsResolvedVersion sqlitegen.Column = "comment_anchors.resolved_version"
+	CommentAnchorsStatus          sqlitegen.Column = "comment_anchors.status"
+	CommentAnchorsVersion         sqlitegen.Column = "comment_anchors.version"
+)
+
+// Table comment_anchors. Plain strings.
+const (
+	T_CommentAnchors                = "comment_anchors"
+	C_CommentAnchorsBlock           = "comment_anchors.block"
+	C_CommentAnchorsComment         = "comment_anchors.comment"
+	C_CommentAnchorsIRI             = "comment_anchors.iri"
+	C_CommentAnchorsRangeEnd        = "comment_anchors.range_end"
+	C_CommentAnchorsRangeStart      = "comment_anchors.range_start"
+	C_CommentAnchorsResolvedEnd     = "comment_anchors.resolved_end"
+	C_CommentAnchorsResolvedStart   = "comment_anchors.resolved_start"
+	C_CommentAnchorsResolvedVersion = "comment_anchors.resolved_version"
+	C_CommentAnchorsStatus          = "comment_anchors.status"
+	C_CommentAnchorsVersion         = "comment_anchors.version"
+)
+
 // Table document_attribute_keys.
 const (
 	DocumentAttributeKeys          sqlitegen.Table  = "document_attribute_keys"
@@ -440,6 +516,62 @@ const (
 	C_FtsIndexVersion     = "fts_index.version"
 )
 
+// Table gc_pins.
+const (
+	GcPins            sqlitegen.Table  = "gc_pins"
+	GcPinsInsertTime  sqlitegen.Column = "gc_pins.insert_time"
+	GcPinsIRI         sqlitegen.Column = "gc_pins.iri"
+	GcPinsIsRecursive sqlitegen.Column = "gc_pins.is_recursive"
+)
+
+// Table gc_pins. Plain strings.
+const (
+	T_GcPins            = "gc_pins"
+	C_GcPinsInsertTime  = "gc_pins.insert_time"
+	C_GcPinsIRI         = "gc_pins.iri"
+	C_GcPinsIsRecursive = "gc_pins.is_recursive"
+)
+
+// Table invite_redemptions.
+const (
+	InviteRedemptions           sqlitegen.Table  = "invite_redemptions"
+	InviteRedemptionsCapability sqlitegen.Column = "invite_redemptions.capability"
+	InviteRedemptionsDelegate   sqlitegen.Column = "invite_redemptions.delegate"
+	InviteRedemptionsInvite     sqlitegen.Column = "invite_redemptions.invite"
+	InviteRedemptionsRedeemTime sqlitegen.Column = "invite_redemptions.redeem_time"
+)
+
+// Table invite_redemptions. Plain strings.
+const (
+	T_InviteRedemptions           = "invite_redemptions"
+	C_InviteRedemptionsCapability = "invite_redemptions.capability"
+	C_InviteRedemptionsDelegate   = "invite_redemptions.delegate"
+	C_InviteRedemptionsInvite     = "invite_redemptions.invite"
+	C_InviteRedemptionsRedeemTime = "invite_redemptions.redeem_time"
+)
+
+// Table invites.
+const (
+	Invites           sqlitegen.Table  = "invites"
+	InvitesCreateTime sqlitegen.Column = "invites.create_time"
+	InvitesData       sqlitegen.Column = "invites.data"
+	InvitesExpireTime sqlitegen.Column = "invites.expire_time"
+	InvitesID         sqlitegen.Column = "invites.id"
+	InvitesIRI        sqlitegen.Column = "invites.iri"
+	InvitesMaxUses    sqlitegen.Column = "invites.max_uses"
+)
+
+// Table invites. Plain strings.
+const (
+	T_Invites           = "invites"
+	C_InvitesCreateTime = "invites.create_time"
+	C_InvitesData       = "invites.data"
+	C_InvitesExpireTime = "invites.expire_time"
+	C_InvitesID         = "invites.id"
+	C_InvitesIRI        = "invites.iri"
+	C_InvitesMaxUses    = "invites.max_uses"
+)
+
 // Table kv.
 const (
 	KV      sqlitegen.Table  = "kv"
@@ -578,6 +710,88 @@ const (
 	C_ResourcesOwner       = "resources.owner"
 )
 
+// Table scheduled_ref_blobs.
+const (
+	ScheduledRefBlobs             sqlitegen.Table  = "scheduled_ref_blobs"
+	ScheduledRefBlobsCID          sqlitegen.Column = "scheduled_ref_blobs.cid"
+	ScheduledRefBlobsData         sqlitegen.Column = "scheduled_ref_blobs.data"
+	ScheduledRefBlobsScheduledRef sqlitegen.Column = "scheduled_ref_blobs.scheduled_ref"
+)
+
+// Table scheduled_ref_blobs. Plain strings.
+const (
+	T_ScheduledRefBlobs             = "scheduled_ref_blobs"
+	C_ScheduledRefBlobsCID          = "scheduled_ref_blobs.cid"
+	C_ScheduledRefBlobsData         = "scheduled_ref_blobs.data"
+	C_ScheduledRefBlobsScheduledRef = "scheduled_ref_blobs.scheduled_ref"
+)
+
+// Table scheduled_refs.
+const (
+	ScheduledRefs                sqlitegen.Table  = "scheduled_refs"
+	ScheduledRefsCID             sqlitegen.Column = "scheduled_refs.cid"
+	ScheduledRefsCreateTime      sqlitegen.Column = "scheduled_refs.create_time"
+	ScheduledRefsData            sqlitegen.Column = "scheduled_refs.data"
+	ScheduledRefsID              sqlitegen.Column = "scheduled_refs.id"
+	ScheduledRefsIRI             sqlitegen.Column = "scheduled_refs.iri"
+	ScheduledRefsNextAttemptTime sqlitegen.Column = "scheduled_refs.next_attempt_time"
+	ScheduledRefsPublishAttempts sqlitegen.Column = "scheduled_refs.publish_attempts"
+	ScheduledRefsPublishError    sqlitegen.Column = "scheduled_refs.publish_error"
+	ScheduledRefsPublishTime     sqlitegen.Column = "scheduled_refs.publish_time"
+)
+
+// Table scheduled_refs. Plain strings.
+const (
+	T_ScheduledRefs                = "scheduled_refs"
+	C_ScheduledRefsCID             = "scheduled_refs.cid"
+	C_ScheduledRefsCreateTime      = "scheduled_refs.create_time"
+	C_ScheduledRefsData            = "scheduled_refs.data"
+	C_ScheduledRefsID              = "scheduled_refs.id"
+	C_ScheduledRefsIRI             = "scheduled_refs.iri"
+	C_ScheduledRefsNextAttemptTime = "scheduled_refs.next_attempt_time"
+	C_ScheduledRefsPublishAttempts = "scheduled_refs.publish_attempts"
+	C_ScheduledRefsPublishError    = "scheduled_refs.publish_error"
+	C_ScheduledRefsPublishTime     = "scheduled_refs.publish_time"
+)
+
+// Table space_usage.
+const (
+	SpaceUsage                sqlitegen.Table  = "space_usage"
+	SpaceUsageMediaBlobs      sqlitegen.Column = "space_usage.media_blobs"
+	SpaceUsageMediaBytes      sqlitegen.Column = "space_usage.media_bytes"
+	SpaceUsageSpace           sqlitegen.Column = "space_usage.space"
+	SpaceUsageStructuralBlobs sqlitegen.Column = "space_usage.structural_blobs"
+	SpaceUsageStructuralBytes sqlitegen.Column = "space_usage.structural_bytes"
+)
+
+// Table space_usage. Plain strings.
+const (
+	T_SpaceUsage                = "space_usage"
+	C_SpaceUsageMediaBlobs      = "space_usage.media_blobs"
+	C_SpaceUsageMediaBytes      = "space_usage.media_bytes"
+	C_SpaceUsageSpace           = "space_usage.space"
+	C_SpaceUsageStructuralBlobs = "space_usage.structural_blobs"
+	C_SpaceUsageStructuralBytes = "space_usage.structural_bytes"
+)
+
+// Table space_usage_blobs.
+const (
+	SpaceUsageBlobs        sqlitegen.Table  = "space_usage_blobs"
+	SpaceUsageBlobsID      sqlitegen.Column = "space_usage_blobs.id"
+	SpaceUsageBlobsIsMedia sqlitegen.Column = "space_usage_blobs.is_media"
+	SpaceUsageBlobsSize    sqlitegen.Column = "space_usage_blobs.size"
+	SpaceUsageBlobsSpace   sqlitegen.Column = "space_usage_blobs.space"
+)
+
+// Table space_usage_blobs. Plain strings.
+const (
+	T_SpaceUsageBlobs        = "space_usage_blobs"
+	C_SpaceUsageBlobsID      = "space_usage_blobs.id"
+	C_SpaceUsageBlobsIsMedia = "space_usage_blobs.is_media"
+	C_SpaceUsageBlobsSize    = "space_usage_blobs.size"
+	C_SpaceUsageBlobsSpace   = "space_usage_blobs.space"
+)
+
 // Table spaces.
 const (
 	Spaces                sqlitegen.Table  = "spaces"
@@ -711,6 +925,14 @@ const (
 // Schema describes SQLite columns.
 var Schema = sqlitegen.Schema{
 	Columns: map[sqlitegen.Column]sqlitegen.ColumnInfo{
+		AccessRequestsCapability:                {Table: AccessRequests, SQLType: "TEXT"},
+		AccessRequestsCreateTime:                {Table: AccessRequests, SQLType: "INTEGER"},
+		AccessRequestsData:                      {Table: AccessRequests, SQLType: "BLOB"},
+		AccessRequestsDecideTime:                {Table: AccessRequests, SQLType: "INTEGER"},
+		AccessRequestsID:                        {Table: AccessRequests, SQLType: "TEXT"},
+		AccessRequestsIRI:                       {Table: AccessRequests, SQLType: "TEXT"},
+		AccessRequestsRequester:                 {Table: AccessRequests, SQLType: "BLOB"},
+		AccessRequestsStatus:                    {Table: AccessRequests, SQLType: "TEXT"},
 		BlobLinksSource:                         {Table: BlobLinks, SQLType: "INTEGER"},
 		BlobLinksTarget:                         {Table: BlobLinks, SQLType: "INTEGER"},
 		BlobLinksType:                           {Table: BlobLinks, SQLType: "TEXT"},
@@ -719,6 +941,11 @@ var Schema = sqlitegen.Schema{
 		BlobLinksWithTypesSourceType:            {Table: BlobLinksWithTypes, SQLType: ""},
 		BlobLinksWithTypesTarget:                {Table: BlobLinksWithTypes, SQLType: "INTEGER"},
 		BlobLinksWithTypesTargetType:            {Table: BlobLinksWithTypes, SQLType: ""},
+		BlobTombstonesDeleteTime:                {Table: BlobTombstones, SQLType: "INTEGER"},
+		BlobTombstonesID:                        {Table: BlobTombstones, SQLType: "INTEGER"},
+		BlobTombstonesIRI:                       {Table: BlobTombstones, SQLType: "TEXT"},
+		BlobTombstonesSize:                      {Table: BlobTombstones, SQLType: "INTEGER"},
+		BlobTombstonesStoredSize:                {Table: BlobTombstones, SQLType: "INTEGER"},
 		BlobVisibilityID:                        {Table: BlobVisibility, SQLType: "INTEGER"},
 		BlobVisibilitySpace:                     {Table: BlobVisibility, SQLType: "INTEGER"},
 		BlobVisibilityRulesLinkType:             {Table: BlobVisibilityRules, SQLType: "TEXT"},
@@ -730,6 +957,16 @@ var Schema = sqlitegen.Schema{
 		BlobsInsertTime:                         {Table: Blobs, SQLType: "INTEGER"},
 		BlobsMultihash:                          {Table: Blobs, SQLType: "BLOB"},
 		BlobsSize:                               {Table: Blobs, SQLType: "INTEGER"},
+		CommentAnchorsBlock:                     {Table: CommentAnchors, SQLType: "TEXT"},
+		CommentAnchorsComment:                   {Table: CommentAnchors, SQLType: "INTEGER"},
+		CommentAnchorsIRI:                       {Table: CommentAnchors, SQLType: "TEXT"},
+		CommentAnchorsRangeEnd:                  {Table: CommentAnchors, SQLType: "INTEGER"},
+		CommentAnchorsRangeStart:                {Table: CommentAnchors, SQLType: "INTEGER"},
+		CommentAnchorsResolvedEnd:               {Table: CommentAnchors, SQLType: "INTEGER"},
+		CommentAnchorsResolvedStart:             {Table: CommentAnchors, SQLType: "INTEGER"},
+		CommentAnchorsResolvedVersion:           {Table: CommentAnchors, SQLType: "TEXT"},
+		CommentAnchorsStatus:                    {Table: CommentAnchors, SQLType: "TEXT"},
+		CommentAnchorsVersion:                   {Table: CommentAnchors, SQLType: "TEXT"},
 		DocumentAttributeKeysID:                 {Table: DocumentAttributeKeys, SQLType: "INTEGER"},
 		DocumentAttributeKeysKey:                {Table: DocumentAttributeKeys, SQLType: "TEXT"},
 		DocumentAttributeKeysSearchKey:          {Table: DocumentAttributeKeys, SQLType: "TEXT"},
@@ -813,6 +1050,19 @@ var Schema = sqlitegen.Schema{
 		FtsIndexTs:                              {Table: FtsIndex, SQLType: "INTEGER"},
 		FtsIndexType:                            {Table: FtsIndex, SQLType: "TEXT"},
 		FtsIndexVersion:                         {Table: FtsIndex, SQLType: "TEXT"},
+		GcPinsInsertTime:                        {Table: GcPins, SQLType: "INTEGER"},
+		GcPinsIRI:                               {Table: GcPins, SQLType: "TEXT"},
+		GcPinsIsRecursive:                       {Table: GcPins, SQLType: "BOOLEAN"},
+		InviteRedemptionsCapability:             {Table: InviteRedemptions, SQLType: "TEXT"},
+		InviteRedemptionsDelegate:               {Table: InviteRedemptions, SQLType: "BLOB"},
+		InviteRedemptionsInvite:                 {Table: InviteRedemptions, SQLType: "TEXT"},
+		InviteRedemptionsRedeemTime:             {Table: InviteRedemptions, SQLType: "INTEGER"},
+		InvitesCreateTime:                       {Table: Invites, SQLType: "INTEGER"},
+		InvitesData:                             {Table: Invites, SQLType: "BLOB"},
+		InvitesExpireTime:                       {Table: Invites, SQLType: "INTEGER"},
+		InvitesID:                               {Table: Invites, SQLType: "TEXT"},
+		InvitesIRI:                              {Table: Invites, SQLType: "TEXT"},
+		InvitesMaxUses:                          {Table: Invites, SQLType: "INTEGER"},
 		KVKey:                                   {Table: KV, SQLType: "TEXT"},
 		KVValue:                                 {Table: KV, SQLType: "TEXT"},
 		PeersAddresses:                          {Table: Peers, SQLType: "TEXT"},
@@ -842,6 +1092,27 @@ var Schema = sqlitegen.Schema{
 		ResourcesID:                             {Table: Resources, SQLType: "INTEGER"},
 		ResourcesIRI:                            {Table: Resources, SQLType: "TEXT"},
 		ResourcesOwner:                          {Table: Resources, SQLType: "INTEGER"},
+		ScheduledRefBlobsCID:                    {Table: ScheduledRefBlobs, SQLType: "TEXT"},
+		ScheduledRefBlobsData:                   {Table: ScheduledRefBlobs, SQLType: "BLOB"},
+		ScheduledRefBlobsScheduledRef:           {Table: ScheduledRefBlobs, SQLType: "INTEGER"},
+		ScheduledRefsCID:                        {Table: ScheduledRefs, SQLType: "TEXT"},
+		ScheduledRefsCreateTime:                 {Table: ScheduledRefs, SQLType: "INTEGER"},
+		ScheduledRefsData:                       {Table: ScheduledRefs, SQLType: "BLOB"},
+		ScheduledRefsID:                         {Table: ScheduledRefs, SQLType: "INTEGER"},
+		ScheduledRefsIRI:                        {Table: ScheduledRefs, SQLType: "TEXT"},
+		ScheduledRefsNextAttemptTime:            {Table: ScheduledRefs, SQLType: "INTEGER"},
+		ScheduledRefsPublishAttempts:            {Table: ScheduledRefs, SQLType: "INTEGER"},
+		ScheduledRefsPublishError:               {Table: ScheduledRefs, SQLType: "TEXT"},
+		ScheduledRefsPublishTime:                {Table: ScheduledRefs, SQLType: "INTEGER"},
+		SpaceUsageMediaBlobs:                    {Table: SpaceUsage, SQLType: "INTEGER"},
+		SpaceUsageMediaBytes:                    {Table: SpaceUsage, SQLType: "INTEGER"},
+		SpaceUsageSpace:                         {Table: SpaceUsage, SQLType: "TEXT"},
+		SpaceUsageStructuralBlobs:               {Table: SpaceUsage, SQLType: "INTEGER"},
+		SpaceUsageStructuralBytes:               {Table: SpaceUsage, SQLType: "INTEGER"},
+		SpaceUsageBlobsID:                       {Table: SpaceUsageBlobs, SQLType: "INTEGER"},
+		SpaceUsageBlobsIsMedia:                  {Table: SpaceUsageBlobs, SQLType: "BOOLEAN"},
+		SpaceUsageBlobsSize:                     {Table: SpaceUsageBlobs, SQLType: "INTEGER"},
+		SpaceUsageBlobsSpace:                    {Table: SpaceUsageBlobs, SQLType: "TEXT"},
 		SpacesCommentCount:                      {Table: Spaces, SQLType: "INTEGER"},
 		SpacesID:                                {Table: Spaces, SQLType: "TEXT"},
 		SpacesLastChangeTime:                    {Table: Spaces, SQLType: "INTEGER"},
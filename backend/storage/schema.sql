@@ -54,6 +54,22 @@ CREATE TABLE blob_visibility (
 -- Index for efficient lookup of blobs by space.
 CREATE INDEX blob_visibility_by_space ON blob_visibility (space, id);
 
+-- Stores the blobs whose data was removed by the garbage collector.
+-- Syncing doesn't fetch tombstoned blobs again until the tombstone expires,
+-- or until the user subscribes to the resource again.
+CREATE TABLE blob_tombstones (
+    id INTEGER PRIMARY KEY REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    -- Resource the blob belonged to, if known.
+    iri TEXT,
+    -- Byte size of the removed data, uncompressed and as stored.
+    size INTEGER NOT NULL,
+    stored_size INTEGER NOT NULL,
+    delete_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL
+) WITHOUT ROWID;
+
+CREATE INDEX blob_tombstones_by_iri ON blob_tombstones (iri);
+CREATE INDEX blob_tombstones_by_delete_time ON blob_tombstones (delete_time);
+
 -- Public blobs view for backwards compatibility.
 -- When a blob has space = 0, it's public.
 CREATE VIEW public_blobs AS
@@ -133,6 +149,116 @@ CREATE TABLE stashed_blobs (
     PRIMARY KEY (id, reason, extra_attrs)
 ) WITHOUT ROWID;
 
+-- Stores signed Refs that are held back locally until their publish time.
+-- Nothing from here is indexed or announced to other peers before the Ref is published.
+CREATE TABLE scheduled_refs (
+    id INTEGER PRIMARY KEY,
+    -- CID of the Ref blob.
+    cid TEXT UNIQUE NOT NULL,
+    -- Raw data of the Ref blob.
+    data BLOB NOT NULL,
+    -- IRI of the document the Ref is applied to.
+    iri TEXT NOT NULL,
+    -- Unix timestamp in milliseconds when the Ref must be published.
+    publish_time INTEGER NOT NULL,
+    -- Unix timestamp in milliseconds when the Ref was scheduled.
+    create_time INTEGER NOT NULL,
+    -- Error of the last attempt to publish the Ref.
+    publish_error TEXT,
+    -- Number of failed attempts to publish the Ref.
+    publish_attempts INTEGER NOT NULL DEFAULT 0,
+    -- Unix timestamp in milliseconds of the next attempt to publish the Ref.
+    -- It's the publish time until an attempt fails, and then it's pushed back with a backoff.
+    next_attempt_time INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX scheduled_refs_by_next_attempt_time ON scheduled_refs (next_attempt_time);
+
+-- Stores the blobs scheduled Refs depend on, e.g. the Changes they point to.
+-- They are published together with the Ref.
+CREATE TABLE scheduled_ref_blobs (
+    scheduled_ref INTEGER REFERENCES scheduled_refs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    cid TEXT NOT NULL,
+    data BLOB NOT NULL,
+    PRIMARY KEY (scheduled_ref, cid)
+) WITHOUT ROWID;
+
+-- Stores invites issued by this peer.
+-- Invites are bearer tokens, so they are never indexed or shared with other peers.
+CREATE TABLE invites (
+    -- CID of the signed invite.
+    id TEXT PRIMARY KEY,
+    -- Raw data of the signed invite.
+    data BLOB NOT NULL,
+    -- IRI of the resource the invite gives access to.
+    iri TEXT NOT NULL,
+    -- Maximum number of redemptions. Zero means unlimited.
+    max_uses INTEGER NOT NULL DEFAULT 0,
+    -- Unix timestamp in milliseconds after which the invite can't be redeemed.
+    expire_time INTEGER,
+    -- Unix timestamp in milliseconds when the invite was created.
+    create_time INTEGER NOT NULL
+) WITHOUT ROWID;
+
+-- Stores the keys that redeemed invites, and the capabilities issued for them.
+CREATE TABLE invite_redemptions (
+    invite TEXT REFERENCES invites (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    -- Principal of the invitee.
+    delegate BLOB NOT NULL,
+    -- CID of the capability issued for the invitee.
+    capability TEXT NOT NULL,
+    -- Unix timestamp in milliseconds when the invite was redeemed.
+    redeem_time INTEGER NOT NULL,
+    PRIMARY KEY (invite, delegate)
+) WITHOUT ROWID;
+
+-- Stores access requests received for the spaces owned by the keys of this peer.
+CREATE TABLE access_requests (
+    -- CID of the signed access request.
+    id TEXT PRIMARY KEY,
+    -- Raw data of the signed access request.
+    data BLOB NOT NULL,
+    -- IRI of the resource the access is requested for.
+    iri TEXT NOT NULL,
+    -- Principal of the requester.
+    requester BLOB NOT NULL,
+    -- One of 'pending', 'approved', or 'denied'.
+    status TEXT NOT NULL DEFAULT 'pending',
+    -- CID of the capability issued when the request was approved.
+    capability TEXT,
+    -- Unix timestamp in milliseconds when the request was received.
+    create_time INTEGER NOT NULL,
+    -- Unix timestamp in milliseconds when the request was approved or denied.
+    decide_time INTEGER
+) WITHOUT ROWID;
+
+CREATE INDEX access_requests_by_iri ON access_requests (iri, requester);
+
+-- Stores anchors of comments to blocks of their target documents,
+-- along with their positions in the latest version of the document.
+CREATE TABLE comment_anchors (
+    -- Comment blob with the anchor.
+    comment INTEGER PRIMARY KEY REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    -- IRI of the target document.
+    iri TEXT NOT NULL,
+    -- Version of the target document the anchor was made against.
+    version TEXT NOT NULL,
+    -- ID of the anchored block.
+    block TEXT NOT NULL,
+    -- Anchored range of text, in Unicode code points. Both zero for the whole block.
+    range_start INTEGER NOT NULL DEFAULT 0,
+    range_end INTEGER NOT NULL DEFAULT 0,
+    -- One of 'valid', 'moved', or 'orphaned'. NULL until the anchor is resolved.
+    status TEXT,
+    -- Anchored range of text in the version the anchor was resolved against.
+    resolved_start INTEGER,
+    resolved_end INTEGER,
+    -- Version of the document the anchor was resolved against.
+    resolved_version TEXT
+) WITHOUT ROWID;
+
+CREATE INDEX comment_anchors_by_iri ON comment_anchors (iri);
+
 -- Stores hypermedia resources.
 -- All resources are identified by an IRI[iri],
 -- might have an owner identified by a public key.
@@ -168,6 +294,29 @@ CREATE TABLE spaces (
 -- Index to fullfill the rule of having an index on all foreign keys.
 CREATE INDEX spaces_by_last_comment ON spaces (last_comment) WHERE last_comment IS NOT NULL;
 
+-- Attributes blobs to the spaces whose storage they use.
+-- Structural blobs belong to the space of their resource,
+-- and media blobs to the spaces of the blobs linking to them, so they can be attributed to more than one space.
+CREATE TABLE space_usage_blobs (
+    space TEXT NOT NULL CHECK (space != ''),
+    id INTEGER REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    is_media BOOLEAN NOT NULL,
+    -- Uncompressed size of the blob.
+    size INTEGER NOT NULL,
+    PRIMARY KEY (space, id)
+) WITHOUT ROWID;
+
+CREATE INDEX space_usage_blobs_by_id ON space_usage_blobs (id);
+
+-- Storage used by each space, aggregated from space_usage_blobs.
+CREATE TABLE space_usage (
+    space TEXT PRIMARY KEY CHECK (space != ''),
+    structural_blobs INTEGER NOT NULL DEFAULT (0),
+    structural_bytes INTEGER NOT NULL DEFAULT (0),
+    media_blobs INTEGER NOT NULL DEFAULT (0),
+    media_bytes INTEGER NOT NULL DEFAULT (0)
+) WITHOUT ROWID;
+
 -- Stores document generations, with lots of consolidated information.
 CREATE TABLE document_generations (
     resource INTEGER REFERENCES resources (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
@@ -293,6 +442,15 @@ CREATE TABLE subscriptions (
     insert_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL
 );
 
+-- Stores resources pinned by the user.
+-- Pinned resources and their related blobs are never garbage collected.
+CREATE TABLE gc_pins (
+    iri TEXT PRIMARY KEY CHECK (iri NOT LIKE '%/'),
+    -- Whether all the documents in the directory are pinned too.
+    is_recursive BOOLEAN DEFAULT false NOT NULL,
+    insert_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL
+) WITHOUT ROWID;
+
 -- Stores seed peers we know about.
 CREATE TABLE peers (
     -- Internal index used for pagination
@@ -63,6 +63,134 @@ type migration struct {
 //
 // In case of even the most minor doubts, consult with the team before adding a new migration, and submit the code to review if needed.
 var migrations = []migration{
+	// Retry scheduled Refs that failed to be published.
+	{Version: "2026-10-17.160000", Run: func(_ *Store, conn *sqlite.Conn) error {
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			ALTER TABLE scheduled_refs ADD COLUMN publish_attempts INTEGER NOT NULL DEFAULT 0;
+			ALTER TABLE scheduled_refs ADD COLUMN next_attempt_time INTEGER NOT NULL DEFAULT 0;
+			UPDATE scheduled_refs SET next_attempt_time = publish_time;
+			DROP INDEX IF EXISTS scheduled_refs_by_publish_time;
+			CREATE INDEX IF NOT EXISTS scheduled_refs_by_next_attempt_time ON scheduled_refs (next_attempt_time);
+		`))
+	}},
+	// Add tables for storage usage of spaces. The reindex fills them for the existing blobs.
+	{Version: "2026-10-17.150000", Run: func(_ *Store, conn *sqlite.Conn) error {
+		if err := sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS space_usage_blobs (
+				space TEXT NOT NULL CHECK (space != ''),
+				id INTEGER REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+				is_media BOOLEAN NOT NULL,
+				size INTEGER NOT NULL,
+				PRIMARY KEY (space, id)
+			) WITHOUT ROWID;
+			CREATE INDEX IF NOT EXISTS space_usage_blobs_by_id ON space_usage_blobs (id);
+			CREATE TABLE IF NOT EXISTS space_usage (
+				space TEXT PRIMARY KEY CHECK (space != ''),
+				structural_blobs INTEGER NOT NULL DEFAULT (0),
+				structural_bytes INTEGER NOT NULL DEFAULT (0),
+				media_blobs INTEGER NOT NULL DEFAULT (0),
+				media_bytes INTEGER NOT NULL DEFAULT (0)
+			) WITHOUT ROWID;
+		`)); err != nil {
+			return err
+		}
+
+		return scheduleReindex(conn)
+	}},
+	// Add tables for garbage collection of unreachable blobs.
+	{Version: "2026-10-17.140000", Run: func(_ *Store, conn *sqlite.Conn) error {
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS gc_pins (
+				iri TEXT PRIMARY KEY CHECK (iri NOT LIKE '%/'),
+				is_recursive BOOLEAN DEFAULT false NOT NULL,
+				insert_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL
+			) WITHOUT ROWID;
+			CREATE TABLE IF NOT EXISTS blob_tombstones (
+				id INTEGER PRIMARY KEY REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+				iri TEXT,
+				size INTEGER NOT NULL,
+				stored_size INTEGER NOT NULL,
+				delete_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL
+			) WITHOUT ROWID;
+			CREATE INDEX IF NOT EXISTS blob_tombstones_by_iri ON blob_tombstones (iri);
+			CREATE INDEX IF NOT EXISTS blob_tombstones_by_delete_time ON blob_tombstones (delete_time);
+		`))
+	}},
+	// Add table for anchors of comments to document blocks.
+	{Version: "2026-10-17.130000", Run: func(_ *Store, conn *sqlite.Conn) error {
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS comment_anchors (
+				comment INTEGER PRIMARY KEY REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+				iri TEXT NOT NULL,
+				version TEXT NOT NULL,
+				block TEXT NOT NULL,
+				range_start INTEGER NOT NULL DEFAULT 0,
+				range_end INTEGER NOT NULL DEFAULT 0,
+				status TEXT,
+				resolved_start INTEGER,
+				resolved_end INTEGER,
+				resolved_version TEXT
+			) WITHOUT ROWID;
+			CREATE INDEX IF NOT EXISTS comment_anchors_by_iri ON comment_anchors (iri);
+		`))
+	}},
+	// Add table for access requests received from other peers.
+	{Version: "2026-10-17.120000", Run: func(_ *Store, conn *sqlite.Conn) error {
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS access_requests (
+				id TEXT PRIMARY KEY,
+				data BLOB NOT NULL,
+				iri TEXT NOT NULL,
+				requester BLOB NOT NULL,
+				status TEXT NOT NULL DEFAULT 'pending',
+				capability TEXT,
+				create_time INTEGER NOT NULL,
+				decide_time INTEGER
+			) WITHOUT ROWID;
+			CREATE INDEX IF NOT EXISTS access_requests_by_iri ON access_requests (iri, requester);
+		`))
+	}},
+	// Add tables for invites that can be redeemed for capabilities.
+	{Version: "2026-10-17.110000", Run: func(_ *Store, conn *sqlite.Conn) error {
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS invites (
+				id TEXT PRIMARY KEY,
+				data BLOB NOT NULL,
+				iri TEXT NOT NULL,
+				max_uses INTEGER NOT NULL DEFAULT 0,
+				expire_time INTEGER,
+				create_time INTEGER NOT NULL
+			) WITHOUT ROWID;
+			CREATE TABLE IF NOT EXISTS invite_redemptions (
+				invite TEXT REFERENCES invites (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+				delegate BLOB NOT NULL,
+				capability TEXT NOT NULL,
+				redeem_time INTEGER NOT NULL,
+				PRIMARY KEY (invite, delegate)
+			) WITHOUT ROWID;
+		`))
+	}},
+	// Add tables for Refs that are scheduled to be published at a later time.
+	{Version: "2026-10-17.100000", Run: func(_ *Store, conn *sqlite.Conn) error {
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS scheduled_refs (
+				id INTEGER PRIMARY KEY,
+				cid TEXT UNIQUE NOT NULL,
+				data BLOB NOT NULL,
+				iri TEXT NOT NULL,
+				publish_time INTEGER NOT NULL,
+				create_time INTEGER NOT NULL,
+				publish_error TEXT
+			);
+			CREATE INDEX IF NOT EXISTS scheduled_refs_by_publish_time ON scheduled_refs (publish_time) WHERE publish_error IS NULL;
+			CREATE TABLE IF NOT EXISTS scheduled_ref_blobs (
+				scheduled_ref INTEGER REFERENCES scheduled_refs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+				cid TEXT NOT NULL,
+				data BLOB NOT NULL,
+				PRIMARY KEY (scheduled_ref, cid)
+			) WITHOUT ROWID;
+		`))
+	}},
 	// Stale-mark every maintained RBSR scope so it re-materializes lazily on
 	// its next serve: earlier builds could leave permanent holes in rbsr_item
 	// (Capability/Contact blobs missing from the advertised set — the oracle's
@@ -0,0 +1,100 @@
+// Package seqdiff computes edit scripts between sequences.
+package seqdiff
+
+// Kind of an edit operation.
+type Kind byte
+
+// Kinds of edit operations.
+const (
+	Equal Kind = iota
+	Delete
+	Insert
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Equal:
+		return "Equal"
+	case Delete:
+		return "Delete"
+	case Insert:
+		return "Insert"
+	default:
+		return "Unknown"
+	}
+}
+
+// Op is a single step of the edit script.
+type Op[T comparable] struct {
+	Kind  Kind
+	Value T
+}
+
+// MaxCells limits the size of the table for computing the longest common subsequence.
+// Larger inputs are reported as entirely replaced, which is still correct, just not minimal.
+const MaxCells = 4 << 20
+
+// Diff computes a minimal edit script turning a into b, using the longest common subsequence.
+// Applying the Equal and Delete ops gives a, and applying the Equal and Insert ops gives b.
+// Deletions come before insertions when both happen at the same position.
+func Diff[T comparable](a, b []T) []Op[T] {
+	var prefix, suffix int
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	out := make([]Op[T], 0, len(a)+len(b))
+	for _, v := range a[:prefix] {
+		out = append(out, Op[T]{Kind: Equal, Value: v})
+	}
+
+	am, bm := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	n, m := len(am), len(bm)
+
+	if (n+1)*(m+1) > MaxCells {
+		for _, v := range am {
+			out = append(out, Op[T]{Kind: Delete, Value: v})
+		}
+		for _, v := range bm {
+			out = append(out, Op[T]{Kind: Insert, Value: v})
+		}
+	} else {
+		// lcs[i*(m+1)+j] is the length of the longest common subsequence of am[i:] and bm[j:].
+		lcs := make([]int, (n+1)*(m+1))
+		at := func(i, j int) int { return lcs[i*(m+1)+j] }
+		for i := n - 1; i >= 0; i-- {
+			for j := m - 1; j >= 0; j-- {
+				if am[i] == bm[j] {
+					lcs[i*(m+1)+j] = at(i+1, j+1) + 1
+				} else {
+					lcs[i*(m+1)+j] = max(at(i+1, j), at(i, j+1))
+				}
+			}
+		}
+
+		var i, j int
+		for i < n || j < m {
+			switch {
+			case i < n && j < m && am[i] == bm[j]:
+				out = append(out, Op[T]{Kind: Equal, Value: am[i]})
+				i++
+				j++
+			case i < n && (j == m || at(i+1, j) >= at(i, j+1)):
+				out = append(out, Op[T]{Kind: Delete, Value: am[i]})
+				i++
+			default:
+				out = append(out, Op[T]{Kind: Insert, Value: bm[j]})
+				j++
+			}
+		}
+	}
+
+	for _, v := range a[len(a)-suffix:] {
+		out = append(out, Op[T]{Kind: Equal, Value: v})
+	}
+
+	return out
+}
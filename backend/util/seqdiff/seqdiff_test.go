@@ -0,0 +1,69 @@
+package seqdiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	format := func(ops []Op[rune]) string {
+		var sb strings.Builder
+		for _, op := range ops {
+			switch op.Kind {
+			case Delete:
+				sb.WriteString("-")
+			case Insert:
+				sb.WriteString("+")
+			}
+			sb.WriteRune(op.Value)
+		}
+		return sb.String()
+	}
+
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{"", "", ""},
+		{"abc", "abc", "abc"},
+		{"", "abc", "+a+b+c"},
+		{"abc", "", "-a-b-c"},
+		{"kitten", "sitting", "-k+sitt-e+in+g"},
+		{"Hello world", "Hello, world", "Hello+, world"},
+		{"мир", "мор", "м-и+ор"},
+	}
+
+	for _, tt := range tests {
+		ops := Diff([]rune(tt.a), []rune(tt.b))
+		require.Equal(t, tt.want, format(ops), "a=%q b=%q", tt.a, tt.b)
+
+		var a, b []rune
+		for _, op := range ops {
+			if op.Kind != Insert {
+				a = append(a, op.Value)
+			}
+			if op.Kind != Delete {
+				b = append(b, op.Value)
+			}
+		}
+		require.Equal(t, tt.a, string(a))
+		require.Equal(t, tt.b, string(b))
+	}
+}
+
+func TestDiffTooLarge(t *testing.T) {
+	var common []int
+	for i := 0; i*i < MaxCells; i++ {
+		common = append(common, i)
+	}
+
+	a := append(append([]int{-1}, common...), -2)
+	b := append(append([]int{-3}, common...), -4)
+
+	ops := Diff(a, b)
+	require.Len(t, ops, len(a)+len(b), "too large inputs must be replaced entirely")
+	require.Equal(t, Op[int]{Kind: Delete, Value: -1}, ops[0])
+	require.Equal(t, Op[int]{Kind: Insert, Value: -4}, ops[len(ops)-1])
+}
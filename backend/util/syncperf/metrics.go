@@ -59,6 +59,20 @@ var (
 		Name: "seed_sync_blob_delay_skipped_total",
 		Help: "Blob arrivals excluded from the delay histogram, by reason.",
 	}, []string{"reason"})
+
+	// mQuotaRefusedBlobs and mQuotaDeferredSyncs count the work the syncing
+	// server turned down because a space was over its storage quota: pushed
+	// blobs dropped instead of stored, and sync sessions put off until the
+	// space fits again. Unlabelled on purpose — spaces are unbounded, and
+	// which ones hit their quota is visible in the space usage API.
+	mQuotaRefusedBlobs = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "seed_sync_quota_refused_blobs_total",
+		Help: "Pushed blobs refused because their space was over its storage quota.",
+	})
+	mQuotaDeferredSyncs = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "seed_sync_quota_deferred_syncs_total",
+		Help: "Sync sessions deferred because their space was over its storage quota.",
+	})
 )
 
 // Skip reasons. Exported so callers outside this package (the unstash cascade)
@@ -74,6 +88,7 @@ const (
 func init() {
 	prometheus.MustRegister(
 		mDelay, mAge, mDelaySkipped,
+		mQuotaRefusedBlobs, mQuotaDeferredSyncs,
 		mWrittenBytesByKind, mWrittenBlobsByKind,
 		newCollector(Default),
 	)
@@ -85,6 +100,18 @@ func (t *Tracker) SkipDelay(reason string) {
 	mDelaySkipped.WithLabelValues(reason).Inc()
 }
 
+// RecordQuotaRefusal records pushed blobs refused because their space was
+// over its storage quota.
+func (t *Tracker) RecordQuotaRefusal(blobs int) {
+	mQuotaRefusedBlobs.Add(float64(blobs))
+}
+
+// RecordQuotaDeferral records a sync session deferred because its space was
+// over its storage quota.
+func (t *Tracker) RecordQuotaDeferral() {
+	mQuotaDeferredSyncs.Inc()
+}
+
 // RecordDelay observes one blob landing locally, feeding both arrival
 // histograms from a single subtraction.
 //
@@ -248,6 +248,10 @@ function getTaskLabel(taskName: TaskName): string {
       return 'Generating Embeddings'
     case TaskName.LOADING_MODEL:
       return 'Loading AI Model'
+    case TaskName.VERIFYING_STORE:
+      return 'Verifying Data'
+    case TaskName.COLLECTING_GARBAGE:
+      return 'Cleaning Up Storage'
     default:
       return 'Background Task'
   }
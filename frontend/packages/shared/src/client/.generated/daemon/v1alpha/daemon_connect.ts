@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { AddDomainRequest, AuthenticateRequest, AuthenticateResponse, ChangeVaultEmailStartRequest, ChangeVaultEmailStartResponse, ChangeVaultEmailVerifyRequest, ChangeVaultEmailVerifyResponse, CheckDomainRequest, DeleteAllKeysRequest, DeleteKeyRequest, DisconnectVaultRequest, DomainInfo, ExportKeyRequest, ForceReindexRequest, ForceReindexResponse, ForceSyncRequest, GenMnemonicRequest, GenMnemonicResponse, GetDomainRequest, GetInfoRequest, GetVaultEmailRequest, GetVaultEmailResponse, GetVaultNotificationServerRequest, GetVaultNotificationServerResponse, GetVaultPasswordStatusRequest, GetVaultPasswordStatusResponse, GetVaultStatusRequest, GetVaultStatusResponse, ImportKeyRequest, Info, ListDomainsRequest, ListDomainsResponse, ListKeysRequest, ListKeysResponse, NamedKey, RegisterKeyRequest, RemoveDomainRequest, SetVaultMasterPasswordRequest, SetVaultMasterPasswordResponse, SetVaultNotificationServerRequest, SetVaultNotificationServerResponse, SignDataRequest, SignDataResponse, StartVaultConnectionRequest, StartVaultConnectionResponse, StoreBlobsRequest, StoreBlobsResponse, UpdateKeyRequest } from "./daemon_pb";
+import { AddDomainRequest, AuthenticateRequest, AuthenticateResponse, ChangeVaultEmailStartRequest, ChangeVaultEmailStartResponse, ChangeVaultEmailVerifyRequest, ChangeVaultEmailVerifyResponse, CheckDomainRequest, CollectGarbageRequest, CollectGarbageResponse, DeleteAllKeysRequest, DeleteKeyRequest, DisconnectVaultRequest, DomainInfo, ExportKeyRequest, ExportSpaceRequest, ExportSpaceResponse, ForceReindexRequest, ForceReindexResponse, ForceSyncRequest, GenMnemonicRequest, GenMnemonicResponse, GetDomainRequest, GetInfoRequest, GetSpaceUsageRequest, GetVaultEmailRequest, GetVaultEmailResponse, GetVaultNotificationServerRequest, GetVaultNotificationServerResponse, GetVaultPasswordStatusRequest, GetVaultPasswordStatusResponse, GetVaultStatusRequest, GetVaultStatusResponse, ImportArchiveRequest, ImportArchiveResponse, ImportKeyRequest, Info, ListDomainsRequest, ListDomainsResponse, ListKeysRequest, ListKeysResponse, ListPinnedResourcesRequest, ListPinnedResourcesResponse, ListSpaceUsageRequest, ListSpaceUsageResponse, NamedKey, PinResourceRequest, RegisterKeyRequest, RemoveDomainRequest, SetVaultMasterPasswordRequest, SetVaultMasterPasswordResponse, SetVaultNotificationServerRequest, SetVaultNotificationServerResponse, SignDataRequest, SignDataResponse, SpaceUsage, StartVaultConnectionRequest, StartVaultConnectionResponse, StoreBlobsRequest, StoreBlobsResponse, UnpinResourceRequest, UpdateKeyRequest, VerifyStoreRequest, VerifyStoreResponse } from "./daemon_pb";
 import { Empty, MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -209,7 +209,8 @@ export const Daemon = {
       kind: MethodKind.Unary,
     },
     /**
-     * Forces the daemon to reindex the entire database.
+     * Forces the daemon to reindex the entire database,
+     * or only the resources of a space (or a subtree of it) if the account is specified.
      *
      * @generated from rpc com.seed.daemon.v1alpha.Daemon.ForceReindex
      */
@@ -275,6 +276,108 @@ export const Daemon = {
       O: StoreBlobsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Exports the blobs of a space (or a subtree of it) into a CAR file on disk.
+     *
+     * @generated from rpc com.seed.daemon.v1alpha.Daemon.ExportSpace
+     */
+    exportSpace: {
+      name: "ExportSpace",
+      I: ExportSpaceRequest,
+      O: ExportSpaceResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Imports the blobs from a CAR file on disk.
+     * Blobs are indexed like any other blobs, so signatures and permissions are verified.
+     *
+     * @generated from rpc com.seed.daemon.v1alpha.Daemon.ImportArchive
+     */
+    importArchive: {
+      name: "ImportArchive",
+      I: ImportArchiveRequest,
+      O: ImportArchiveResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Verifies the integrity of the blob store and of the index, optionally repairing the index.
+     * Progress is streamed while the blobs are checked, and the last message contains the report.
+     *
+     * @generated from rpc com.seed.daemon.v1alpha.Daemon.VerifyStore
+     */
+    verifyStore: {
+      name: "VerifyStore",
+      I: VerifyStoreRequest,
+      O: VerifyStoreResponse,
+      kind: MethodKind.ServerStreaming,
+    },
+    /**
+     * Removes the data of the blobs not reachable from the local keys' spaces, the subscriptions, and the pinned resources.
+     * Collected blobs are tombstoned, so they are not fetched again from the network right away.
+     *
+     * @generated from rpc com.seed.daemon.v1alpha.Daemon.CollectGarbage
+     */
+    collectGarbage: {
+      name: "CollectGarbage",
+      I: CollectGarbageRequest,
+      O: CollectGarbageResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Protects a resource from being garbage collected.
+     *
+     * @generated from rpc com.seed.daemon.v1alpha.Daemon.PinResource
+     */
+    pinResource: {
+      name: "PinResource",
+      I: PinResourceRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Removes the pin of a resource.
+     *
+     * @generated from rpc com.seed.daemon.v1alpha.Daemon.UnpinResource
+     */
+    unpinResource: {
+      name: "UnpinResource",
+      I: UnpinResourceRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Lists the pinned resources.
+     *
+     * @generated from rpc com.seed.daemon.v1alpha.Daemon.ListPinnedResources
+     */
+    listPinnedResources: {
+      name: "ListPinnedResources",
+      I: ListPinnedResourcesRequest,
+      O: ListPinnedResourcesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Returns the storage used by the blobs of a space.
+     *
+     * @generated from rpc com.seed.daemon.v1alpha.Daemon.GetSpaceUsage
+     */
+    getSpaceUsage: {
+      name: "GetSpaceUsage",
+      I: GetSpaceUsageRequest,
+      O: SpaceUsage,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Lists the storage used by each space, largest first.
+     *
+     * @generated from rpc com.seed.daemon.v1alpha.Daemon.ListSpaceUsage
+     */
+    listSpaceUsage: {
+      name: "ListSpaceUsage",
+      I: ListSpaceUsageRequest,
+      O: ListSpaceUsageResponse,
+      kind: MethodKind.Unary,
+    },
     /**
      * Sign arbitrary data with an existing signing key.
      *
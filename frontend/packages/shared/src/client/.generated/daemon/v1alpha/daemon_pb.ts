@@ -142,6 +142,20 @@ export enum TaskName {
    * @generated from enum value: LOADING_MODEL = 3;
    */
   LOADING_MODEL = 3,
+
+  /**
+   * Task for verifying the integrity of the blob store.
+   *
+   * @generated from enum value: VERIFYING_STORE = 4;
+   */
+  VERIFYING_STORE = 4,
+
+  /**
+   * Task for collecting garbage in the blob store.
+   *
+   * @generated from enum value: COLLECTING_GARBAGE = 5;
+   */
+  COLLECTING_GARBAGE = 5,
 }
 // Retrieve enum metadata with: proto3.getEnumType(TaskName)
 proto3.util.setEnumType(TaskName, "com.seed.daemon.v1alpha.TaskName", [
@@ -149,6 +163,8 @@ proto3.util.setEnumType(TaskName, "com.seed.daemon.v1alpha.TaskName", [
   { no: 1, name: "REINDEXING" },
   { no: 2, name: "EMBEDDING" },
   { no: 3, name: "LOADING_MODEL" },
+  { no: 4, name: "VERIFYING_STORE" },
+  { no: 5, name: "COLLECTING_GARBAGE" },
 ]);
 
 /**
@@ -1329,6 +1345,21 @@ export class SetVaultNotificationServerResponse extends Message<SetVaultNotifica
  * @generated from message com.seed.daemon.v1alpha.ForceReindexRequest
  */
 export class ForceReindexRequest extends Message<ForceReindexRequest> {
+  /**
+   * Optional. ID of the space to reindex. Reindexes the entire database if empty.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Optional. Path of the document to reindex with all of its children.
+   * Reindexes the entire space if empty. Requires account.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
   constructor(data?: PartialMessage<ForceReindexRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1337,6 +1368,8 @@ export class ForceReindexRequest extends Message<ForceReindexRequest> {
   static readonly runtime: typeof proto3 = proto3;
   static readonly typeName = "com.seed.daemon.v1alpha.ForceReindexRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ForceReindexRequest {
@@ -1362,6 +1395,20 @@ export class ForceReindexRequest extends Message<ForceReindexRequest> {
  * @generated from message com.seed.daemon.v1alpha.ForceReindexResponse
  */
 export class ForceReindexResponse extends Message<ForceReindexResponse> {
+  /**
+   * Number of reindexed resources. Only set for scoped reindexing.
+   *
+   * @generated from field: int64 resources_reindexed = 1;
+   */
+  resourcesReindexed = protoInt64.zero;
+
+  /**
+   * Number of indexed blobs. Only set for scoped reindexing.
+   *
+   * @generated from field: int64 blobs_indexed = 2;
+   */
+  blobsIndexed = protoInt64.zero;
+
   constructor(data?: PartialMessage<ForceReindexResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1370,6 +1417,8 @@ export class ForceReindexResponse extends Message<ForceReindexResponse> {
   static readonly runtime: typeof proto3 = proto3;
   static readonly typeName = "com.seed.daemon.v1alpha.ForceReindexResponse";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "resources_reindexed", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "blobs_indexed", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ForceReindexResponse {
@@ -1670,6 +1719,1078 @@ export class StoreBlobsResponse extends Message<StoreBlobsResponse> {
   }
 }
 
+/**
+ * Request to export a space into a CAR file.
+ *
+ * @generated from message com.seed.daemon.v1alpha.ExportSpaceRequest
+ */
+export class ExportSpaceRequest extends Message<ExportSpaceRequest> {
+  /**
+   * Required. ID of the space to export.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Optional. Path of the document to export along with all of its children.
+   * By default the entire space is exported.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * Required. Absolute path to a .car file to write.
+   * Existing files are overwritten.
+   *
+   * @generated from field: string file_path = 3;
+   */
+  filePath = "";
+
+  constructor(data?: PartialMessage<ExportSpaceRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.ExportSpaceRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "file_path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ExportSpaceRequest {
+    return new ExportSpaceRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ExportSpaceRequest {
+    return new ExportSpaceRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ExportSpaceRequest {
+    return new ExportSpaceRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ExportSpaceRequest | PlainMessage<ExportSpaceRequest> | undefined, b: ExportSpaceRequest | PlainMessage<ExportSpaceRequest> | undefined): boolean {
+    return proto3.util.equals(ExportSpaceRequest, a, b);
+  }
+}
+
+/**
+ * Response after exporting a space.
+ *
+ * @generated from message com.seed.daemon.v1alpha.ExportSpaceResponse
+ */
+export class ExportSpaceResponse extends Message<ExportSpaceResponse> {
+  /**
+   * Number of blobs written into the file.
+   *
+   * @generated from field: int64 blob_count = 1;
+   */
+  blobCount = protoInt64.zero;
+
+  /**
+   * CIDs of the roots of the archive, which are the Refs of the exported documents.
+   *
+   * @generated from field: repeated string roots = 2;
+   */
+  roots: string[] = [];
+
+  constructor(data?: PartialMessage<ExportSpaceResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.ExportSpaceResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "blob_count", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "roots", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ExportSpaceResponse {
+    return new ExportSpaceResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ExportSpaceResponse {
+    return new ExportSpaceResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ExportSpaceResponse {
+    return new ExportSpaceResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ExportSpaceResponse | PlainMessage<ExportSpaceResponse> | undefined, b: ExportSpaceResponse | PlainMessage<ExportSpaceResponse> | undefined): boolean {
+    return proto3.util.equals(ExportSpaceResponse, a, b);
+  }
+}
+
+/**
+ * Request to import a CAR file.
+ *
+ * @generated from message com.seed.daemon.v1alpha.ImportArchiveRequest
+ */
+export class ImportArchiveRequest extends Message<ImportArchiveRequest> {
+  /**
+   * Required. Absolute path to a .car file to read.
+   *
+   * @generated from field: string file_path = 1;
+   */
+  filePath = "";
+
+  constructor(data?: PartialMessage<ImportArchiveRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.ImportArchiveRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "file_path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ImportArchiveRequest {
+    return new ImportArchiveRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ImportArchiveRequest {
+    return new ImportArchiveRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ImportArchiveRequest {
+    return new ImportArchiveRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ImportArchiveRequest | PlainMessage<ImportArchiveRequest> | undefined, b: ImportArchiveRequest | PlainMessage<ImportArchiveRequest> | undefined): boolean {
+    return proto3.util.equals(ImportArchiveRequest, a, b);
+  }
+}
+
+/**
+ * Response after importing a CAR file.
+ *
+ * @generated from message com.seed.daemon.v1alpha.ImportArchiveResponse
+ */
+export class ImportArchiveResponse extends Message<ImportArchiveResponse> {
+  /**
+   * Number of blobs read from the file.
+   *
+   * @generated from field: int64 blob_count = 1;
+   */
+  blobCount = protoInt64.zero;
+
+  /**
+   * Blobs from the archive that were stored, but couldn't be indexed,
+   * e.g. because their signers don't have permissions in the space,
+   * or because the blobs they depend on are missing.
+   * They'll be indexed when the missing pieces arrive.
+   *
+   * @generated from field: repeated com.seed.daemon.v1alpha.StashedBlob stashed_blobs = 2;
+   */
+  stashedBlobs: StashedBlob[] = [];
+
+  constructor(data?: PartialMessage<ImportArchiveResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.ImportArchiveResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "blob_count", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "stashed_blobs", kind: "message", T: StashedBlob, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ImportArchiveResponse {
+    return new ImportArchiveResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ImportArchiveResponse {
+    return new ImportArchiveResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ImportArchiveResponse {
+    return new ImportArchiveResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ImportArchiveResponse | PlainMessage<ImportArchiveResponse> | undefined, b: ImportArchiveResponse | PlainMessage<ImportArchiveResponse> | undefined): boolean {
+    return proto3.util.equals(ImportArchiveResponse, a, b);
+  }
+}
+
+/**
+ * Blob that couldn't be indexed.
+ *
+ * @generated from message com.seed.daemon.v1alpha.StashedBlob
+ */
+export class StashedBlob extends Message<StashedBlob> {
+  /**
+   * CID of the blob.
+   *
+   * @generated from field: string cid = 1;
+   */
+  cid = "";
+
+  /**
+   * Reason why the blob couldn't be indexed.
+   *
+   * @generated from field: string reason = 2;
+   */
+  reason = "";
+
+  /**
+   * CIDs of the blobs this blob is waiting for.
+   *
+   * @generated from field: repeated string missing_blobs = 3;
+   */
+  missingBlobs: string[] = [];
+
+  /**
+   * Signers that lack permissions to produce this blob.
+   *
+   * @generated from field: repeated string denied_signers = 4;
+   */
+  deniedSigners: string[] = [];
+
+  /**
+   * Extra details about the failure.
+   *
+   * @generated from field: string details = 5;
+   */
+  details = "";
+
+  constructor(data?: PartialMessage<StashedBlob>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.StashedBlob";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "cid", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "missing_blobs", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 4, name: "denied_signers", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 5, name: "details", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StashedBlob {
+    return new StashedBlob().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): StashedBlob {
+    return new StashedBlob().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): StashedBlob {
+    return new StashedBlob().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: StashedBlob | PlainMessage<StashedBlob> | undefined, b: StashedBlob | PlainMessage<StashedBlob> | undefined): boolean {
+    return proto3.util.equals(StashedBlob, a, b);
+  }
+}
+
+/**
+ * Request to verify the blob store.
+ *
+ * @generated from message com.seed.daemon.v1alpha.VerifyStoreRequest
+ */
+export class VerifyStoreRequest extends Message<VerifyStoreRequest> {
+  /**
+   * Optional. Reindex the resources affected by the problems,
+   * and mark the corrupted blobs as missing, so they can be fetched again from the network.
+   *
+   * @generated from field: bool repair = 1;
+   */
+  repair = false;
+
+  constructor(data?: PartialMessage<VerifyStoreRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.VerifyStoreRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repair", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): VerifyStoreRequest {
+    return new VerifyStoreRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): VerifyStoreRequest {
+    return new VerifyStoreRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): VerifyStoreRequest {
+    return new VerifyStoreRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: VerifyStoreRequest | PlainMessage<VerifyStoreRequest> | undefined, b: VerifyStoreRequest | PlainMessage<VerifyStoreRequest> | undefined): boolean {
+    return proto3.util.equals(VerifyStoreRequest, a, b);
+  }
+}
+
+/**
+ * Progress of the store verification.
+ *
+ * @generated from message com.seed.daemon.v1alpha.VerifyStoreResponse
+ */
+export class VerifyStoreResponse extends Message<VerifyStoreResponse> {
+  /**
+   * Number of blobs to check.
+   *
+   * @generated from field: int64 blobs_total = 1;
+   */
+  blobsTotal = protoInt64.zero;
+
+  /**
+   * Number of blobs checked so far.
+   *
+   * @generated from field: int64 blobs_checked = 2;
+   */
+  blobsChecked = protoInt64.zero;
+
+  /**
+   * Report of the verification. Only set in the last message of the stream.
+   *
+   * @generated from field: com.seed.daemon.v1alpha.StoreReport report = 3;
+   */
+  report?: StoreReport;
+
+  constructor(data?: PartialMessage<VerifyStoreResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.VerifyStoreResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "blobs_total", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "blobs_checked", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "report", kind: "message", T: StoreReport },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): VerifyStoreResponse {
+    return new VerifyStoreResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): VerifyStoreResponse {
+    return new VerifyStoreResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): VerifyStoreResponse {
+    return new VerifyStoreResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: VerifyStoreResponse | PlainMessage<VerifyStoreResponse> | undefined, b: VerifyStoreResponse | PlainMessage<VerifyStoreResponse> | undefined): boolean {
+    return proto3.util.equals(VerifyStoreResponse, a, b);
+  }
+}
+
+/**
+ * Result of the store verification.
+ *
+ * @generated from message com.seed.daemon.v1alpha.StoreReport
+ */
+export class StoreReport extends Message<StoreReport> {
+  /**
+   * Number of blobs checked.
+   *
+   * @generated from field: int64 blobs_checked = 1;
+   */
+  blobsChecked = protoInt64.zero;
+
+  /**
+   * Problems found in the store.
+   *
+   * @generated from field: repeated com.seed.daemon.v1alpha.StoreProblem problems = 2;
+   */
+  problems: StoreProblem[] = [];
+
+  /**
+   * Number of stashed blobs by reason.
+   *
+   * @generated from field: map<string, int64> stashed_blobs = 3;
+   */
+  stashedBlobs: { [key: string]: bigint } = {};
+
+  /**
+   * IRIs of the resources reindexed by the repair.
+   *
+   * @generated from field: repeated string reindexed_resources = 4;
+   */
+  reindexedResources: string[] = [];
+
+  constructor(data?: PartialMessage<StoreReport>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.StoreReport";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "blobs_checked", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "problems", kind: "message", T: StoreProblem, repeated: true },
+    { no: 3, name: "stashed_blobs", kind: "map", K: 9 /* ScalarType.STRING */, V: {kind: "scalar", T: 3 /* ScalarType.INT64 */} },
+    { no: 4, name: "reindexed_resources", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StoreReport {
+    return new StoreReport().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): StoreReport {
+    return new StoreReport().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): StoreReport {
+    return new StoreReport().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: StoreReport | PlainMessage<StoreReport> | undefined, b: StoreReport | PlainMessage<StoreReport> | undefined): boolean {
+    return proto3.util.equals(StoreReport, a, b);
+  }
+}
+
+/**
+ * Inconsistency found in the blob store or in the index.
+ *
+ * @generated from message com.seed.daemon.v1alpha.StoreProblem
+ */
+export class StoreProblem extends Message<StoreProblem> {
+  /**
+   * Kind of the problem, e.g. CorruptData, InvalidBlob, DanglingLink, MissingChangeDep, OrphanedStructuralBlob.
+   *
+   * @generated from field: string kind = 1;
+   */
+  kind = "";
+
+  /**
+   * CID of the blob with the problem.
+   *
+   * @generated from field: string cid = 2;
+   */
+  cid = "";
+
+  /**
+   * CID of the linked blob, for link problems.
+   *
+   * @generated from field: string target = 3;
+   */
+  target = "";
+
+  /**
+   * IRI of the affected resource, if known.
+   *
+   * @generated from field: string resource = 4;
+   */
+  resource = "";
+
+  /**
+   * Extra details about the problem.
+   *
+   * @generated from field: string details = 5;
+   */
+  details = "";
+
+  constructor(data?: PartialMessage<StoreProblem>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.StoreProblem";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "kind", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "cid", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "target", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "resource", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "details", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StoreProblem {
+    return new StoreProblem().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): StoreProblem {
+    return new StoreProblem().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): StoreProblem {
+    return new StoreProblem().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: StoreProblem | PlainMessage<StoreProblem> | undefined, b: StoreProblem | PlainMessage<StoreProblem> | undefined): boolean {
+    return proto3.util.equals(StoreProblem, a, b);
+  }
+}
+
+/**
+ * Request to collect garbage.
+ *
+ * @generated from message com.seed.daemon.v1alpha.CollectGarbageRequest
+ */
+export class CollectGarbageRequest extends Message<CollectGarbageRequest> {
+  /**
+   * Optional. Only report what would be collected, without changing anything.
+   *
+   * @generated from field: bool dry_run = 1;
+   */
+  dryRun = false;
+
+  /**
+   * Optional. Only collect the blobs of the space of this account.
+   *
+   * @generated from field: string account = 2;
+   */
+  account = "";
+
+  constructor(data?: PartialMessage<CollectGarbageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.CollectGarbageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "dry_run", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 2, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CollectGarbageRequest {
+    return new CollectGarbageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CollectGarbageRequest {
+    return new CollectGarbageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CollectGarbageRequest {
+    return new CollectGarbageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CollectGarbageRequest | PlainMessage<CollectGarbageRequest> | undefined, b: CollectGarbageRequest | PlainMessage<CollectGarbageRequest> | undefined): boolean {
+    return proto3.util.equals(CollectGarbageRequest, a, b);
+  }
+}
+
+/**
+ * Result of the garbage collection.
+ * In dry-run mode the pruned fields describe the blobs that would be collected.
+ *
+ * @generated from message com.seed.daemon.v1alpha.CollectGarbageResponse
+ */
+export class CollectGarbageResponse extends Message<CollectGarbageResponse> {
+  /**
+   * Number of resources the reachable blobs were collected from.
+   *
+   * @generated from field: int64 roots = 1;
+   */
+  roots = protoInt64.zero;
+
+  /**
+   * Number of blobs in the store before the collection.
+   *
+   * @generated from field: int64 blobs_total = 2;
+   */
+  blobsTotal = protoInt64.zero;
+
+  /**
+   * Uncompressed size of the blobs in the store before the collection.
+   *
+   * @generated from field: int64 bytes_total = 3;
+   */
+  bytesTotal = protoInt64.zero;
+
+  /**
+   * Size of the blobs on disk before the collection.
+   *
+   * @generated from field: int64 stored_bytes_total = 4;
+   */
+  storedBytesTotal = protoInt64.zero;
+
+  /**
+   * Number of collected blobs.
+   *
+   * @generated from field: int64 blobs_pruned = 5;
+   */
+  blobsPruned = protoInt64.zero;
+
+  /**
+   * Uncompressed size of the collected blobs.
+   *
+   * @generated from field: int64 bytes_pruned = 6;
+   */
+  bytesPruned = protoInt64.zero;
+
+  /**
+   * Size of the collected blobs on disk.
+   *
+   * @generated from field: int64 stored_bytes_pruned = 7;
+   */
+  storedBytesPruned = protoInt64.zero;
+
+  /**
+   * Number of tombstones removed after the grace period.
+   *
+   * @generated from field: int64 tombstones_expired = 8;
+   */
+  tombstonesExpired = protoInt64.zero;
+
+  /**
+   * Number of resources reindexed after their blobs were collected.
+   *
+   * @generated from field: int64 reindexed_resources = 9;
+   */
+  reindexedResources = protoInt64.zero;
+
+  constructor(data?: PartialMessage<CollectGarbageResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.CollectGarbageResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "roots", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "blobs_total", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "bytes_total", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "stored_bytes_total", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "blobs_pruned", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 6, name: "bytes_pruned", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 7, name: "stored_bytes_pruned", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 8, name: "tombstones_expired", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 9, name: "reindexed_resources", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CollectGarbageResponse {
+    return new CollectGarbageResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CollectGarbageResponse {
+    return new CollectGarbageResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CollectGarbageResponse {
+    return new CollectGarbageResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CollectGarbageResponse | PlainMessage<CollectGarbageResponse> | undefined, b: CollectGarbageResponse | PlainMessage<CollectGarbageResponse> | undefined): boolean {
+    return proto3.util.equals(CollectGarbageResponse, a, b);
+  }
+}
+
+/**
+ * Request to pin a resource.
+ *
+ * @generated from message com.seed.daemon.v1alpha.PinResourceRequest
+ */
+export class PinResourceRequest extends Message<PinResourceRequest> {
+  /**
+   * Required. IRI of the resource to pin.
+   *
+   * @generated from field: string iri = 1;
+   */
+  iri = "";
+
+  /**
+   * Optional. Pin all the resources below the IRI too.
+   *
+   * @generated from field: bool recursive = 2;
+   */
+  recursive = false;
+
+  constructor(data?: PartialMessage<PinResourceRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.PinResourceRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "iri", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "recursive", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PinResourceRequest {
+    return new PinResourceRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PinResourceRequest {
+    return new PinResourceRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PinResourceRequest {
+    return new PinResourceRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PinResourceRequest | PlainMessage<PinResourceRequest> | undefined, b: PinResourceRequest | PlainMessage<PinResourceRequest> | undefined): boolean {
+    return proto3.util.equals(PinResourceRequest, a, b);
+  }
+}
+
+/**
+ * Request to unpin a resource.
+ *
+ * @generated from message com.seed.daemon.v1alpha.UnpinResourceRequest
+ */
+export class UnpinResourceRequest extends Message<UnpinResourceRequest> {
+  /**
+   * Required. IRI of the pinned resource.
+   *
+   * @generated from field: string iri = 1;
+   */
+  iri = "";
+
+  constructor(data?: PartialMessage<UnpinResourceRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.UnpinResourceRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "iri", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UnpinResourceRequest {
+    return new UnpinResourceRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UnpinResourceRequest {
+    return new UnpinResourceRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UnpinResourceRequest {
+    return new UnpinResourceRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: UnpinResourceRequest | PlainMessage<UnpinResourceRequest> | undefined, b: UnpinResourceRequest | PlainMessage<UnpinResourceRequest> | undefined): boolean {
+    return proto3.util.equals(UnpinResourceRequest, a, b);
+  }
+}
+
+/**
+ * Request to list pinned resources.
+ *
+ * @generated from message com.seed.daemon.v1alpha.ListPinnedResourcesRequest
+ */
+export class ListPinnedResourcesRequest extends Message<ListPinnedResourcesRequest> {
+  constructor(data?: PartialMessage<ListPinnedResourcesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.ListPinnedResourcesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListPinnedResourcesRequest {
+    return new ListPinnedResourcesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListPinnedResourcesRequest {
+    return new ListPinnedResourcesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListPinnedResourcesRequest {
+    return new ListPinnedResourcesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListPinnedResourcesRequest | PlainMessage<ListPinnedResourcesRequest> | undefined, b: ListPinnedResourcesRequest | PlainMessage<ListPinnedResourcesRequest> | undefined): boolean {
+    return proto3.util.equals(ListPinnedResourcesRequest, a, b);
+  }
+}
+
+/**
+ * List of pinned resources.
+ *
+ * @generated from message com.seed.daemon.v1alpha.ListPinnedResourcesResponse
+ */
+export class ListPinnedResourcesResponse extends Message<ListPinnedResourcesResponse> {
+  /**
+   * @generated from field: repeated com.seed.daemon.v1alpha.PinnedResource pins = 1;
+   */
+  pins: PinnedResource[] = [];
+
+  constructor(data?: PartialMessage<ListPinnedResourcesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.ListPinnedResourcesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "pins", kind: "message", T: PinnedResource, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListPinnedResourcesResponse {
+    return new ListPinnedResourcesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListPinnedResourcesResponse {
+    return new ListPinnedResourcesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListPinnedResourcesResponse {
+    return new ListPinnedResourcesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListPinnedResourcesResponse | PlainMessage<ListPinnedResourcesResponse> | undefined, b: ListPinnedResourcesResponse | PlainMessage<ListPinnedResourcesResponse> | undefined): boolean {
+    return proto3.util.equals(ListPinnedResourcesResponse, a, b);
+  }
+}
+
+/**
+ * Request to get the storage usage of a space.
+ *
+ * @generated from message com.seed.daemon.v1alpha.GetSpaceUsageRequest
+ */
+export class GetSpaceUsageRequest extends Message<GetSpaceUsageRequest> {
+  /**
+   * Required. Account ID of the space.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  constructor(data?: PartialMessage<GetSpaceUsageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.GetSpaceUsageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetSpaceUsageRequest {
+    return new GetSpaceUsageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetSpaceUsageRequest {
+    return new GetSpaceUsageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetSpaceUsageRequest {
+    return new GetSpaceUsageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetSpaceUsageRequest | PlainMessage<GetSpaceUsageRequest> | undefined, b: GetSpaceUsageRequest | PlainMessage<GetSpaceUsageRequest> | undefined): boolean {
+    return proto3.util.equals(GetSpaceUsageRequest, a, b);
+  }
+}
+
+/**
+ * Request to list the storage usage of spaces.
+ *
+ * @generated from message com.seed.daemon.v1alpha.ListSpaceUsageRequest
+ */
+export class ListSpaceUsageRequest extends Message<ListSpaceUsageRequest> {
+  constructor(data?: PartialMessage<ListSpaceUsageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.ListSpaceUsageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListSpaceUsageRequest {
+    return new ListSpaceUsageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListSpaceUsageRequest {
+    return new ListSpaceUsageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListSpaceUsageRequest {
+    return new ListSpaceUsageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListSpaceUsageRequest | PlainMessage<ListSpaceUsageRequest> | undefined, b: ListSpaceUsageRequest | PlainMessage<ListSpaceUsageRequest> | undefined): boolean {
+    return proto3.util.equals(ListSpaceUsageRequest, a, b);
+  }
+}
+
+/**
+ * Storage usage of spaces.
+ *
+ * @generated from message com.seed.daemon.v1alpha.ListSpaceUsageResponse
+ */
+export class ListSpaceUsageResponse extends Message<ListSpaceUsageResponse> {
+  /**
+   * @generated from field: repeated com.seed.daemon.v1alpha.SpaceUsage spaces = 1;
+   */
+  spaces: SpaceUsage[] = [];
+
+  constructor(data?: PartialMessage<ListSpaceUsageResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.ListSpaceUsageResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "spaces", kind: "message", T: SpaceUsage, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListSpaceUsageResponse {
+    return new ListSpaceUsageResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListSpaceUsageResponse {
+    return new ListSpaceUsageResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListSpaceUsageResponse {
+    return new ListSpaceUsageResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListSpaceUsageResponse | PlainMessage<ListSpaceUsageResponse> | undefined, b: ListSpaceUsageResponse | PlainMessage<ListSpaceUsageResponse> | undefined): boolean {
+    return proto3.util.equals(ListSpaceUsageResponse, a, b);
+  }
+}
+
+/**
+ * Storage used by the blobs of a space.
+ * Sizes are uncompressed. Media blobs shared by multiple spaces are counted in each of them.
+ *
+ * @generated from message com.seed.daemon.v1alpha.SpaceUsage
+ */
+export class SpaceUsage extends Message<SpaceUsage> {
+  /**
+   * Account ID of the space.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Number of structural blobs, e.g. changes, refs, and comments.
+   *
+   * @generated from field: int64 structural_blobs = 2;
+   */
+  structuralBlobs = protoInt64.zero;
+
+  /**
+   * Size of the structural blobs.
+   *
+   * @generated from field: int64 structural_bytes = 3;
+   */
+  structuralBytes = protoInt64.zero;
+
+  /**
+   * Number of media blobs, e.g. images and files.
+   *
+   * @generated from field: int64 media_blobs = 4;
+   */
+  mediaBlobs = protoInt64.zero;
+
+  /**
+   * Size of the media blobs.
+   *
+   * @generated from field: int64 media_bytes = 5;
+   */
+  mediaBytes = protoInt64.zero;
+
+  /**
+   * Storage quota of the space in bytes. Zero means no limit.
+   *
+   * @generated from field: int64 quota = 6;
+   */
+  quota = protoInt64.zero;
+
+  constructor(data?: PartialMessage<SpaceUsage>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.SpaceUsage";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "structural_blobs", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "structural_bytes", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "media_blobs", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "media_bytes", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 6, name: "quota", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SpaceUsage {
+    return new SpaceUsage().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SpaceUsage {
+    return new SpaceUsage().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SpaceUsage {
+    return new SpaceUsage().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SpaceUsage | PlainMessage<SpaceUsage> | undefined, b: SpaceUsage | PlainMessage<SpaceUsage> | undefined): boolean {
+    return proto3.util.equals(SpaceUsage, a, b);
+  }
+}
+
+/**
+ * Resource protected from garbage collection.
+ *
+ * @generated from message com.seed.daemon.v1alpha.PinnedResource
+ */
+export class PinnedResource extends Message<PinnedResource> {
+  /**
+   * IRI of the resource.
+   *
+   * @generated from field: string iri = 1;
+   */
+  iri = "";
+
+  /**
+   * Whether the resources below the IRI are pinned too.
+   *
+   * @generated from field: bool recursive = 2;
+   */
+  recursive = false;
+
+  constructor(data?: PartialMessage<PinnedResource>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.daemon.v1alpha.PinnedResource";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "iri", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "recursive", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PinnedResource {
+    return new PinnedResource().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PinnedResource {
+    return new PinnedResource().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PinnedResource {
+    return new PinnedResource().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PinnedResource | PlainMessage<PinnedResource> | undefined, b: PinnedResource | PlainMessage<PinnedResource> | undefined): boolean {
+    return proto3.util.equals(PinnedResource, a, b);
+  }
+}
+
 /**
  * Request to sign data.
  *
@@ -3,8 +3,8 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { Capability, CreateCapabilityRequest, GetCapabilityRequest, ListCapabilitiesForDelegateRequest, ListCapabilitiesRequest, ListCapabilitiesResponse } from "./access_control_pb";
-import { MethodKind } from "@bufbuild/protobuf";
+import { AccessRequest, ApproveAccessRequestRequest, Capability, CreateCapabilityRequest, CreateInviteRequest, DenyAccessRequestRequest, GetCapabilityRequest, Invite, ListAccessRequestsRequest, ListAccessRequestsResponse, ListCapabilitiesForDelegateRequest, ListCapabilitiesRequest, ListCapabilitiesResponse, ListInvitesRequest, ListInvitesResponse, RedeemInviteRequest, RequestAccessRequest, Revocation, RevokeCapabilityRequest, RevokeInviteRequest } from "./access_control_pb";
+import { Empty, MethodKind } from "@bufbuild/protobuf";
 
 /**
  * Access Control service provides management API for issuing and revoking Capabilities.
@@ -58,6 +58,109 @@ export const AccessControl = {
       O: Capability,
       kind: MethodKind.Unary,
     },
+    /**
+     * Revokes a previously issued capability.
+     * Blobs signed by the delegate after the revocation are no longer authorized by the capability.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.AccessControl.RevokeCapability
+     */
+    revokeCapability: {
+      name: "RevokeCapability",
+      I: RevokeCapabilityRequest,
+      O: Revocation,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Creates an invite which anyone holding its token can redeem for a Capability,
+     * without knowing the key of the invitee up front.
+     * Invites are kept by the daemon of the issuer, which must be reachable by the invitees to redeem them.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.AccessControl.CreateInvite
+     */
+    createInvite: {
+      name: "CreateInvite",
+      I: CreateInviteRequest,
+      O: Invite,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Lists outstanding invites issued by this daemon.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.AccessControl.ListInvites
+     */
+    listInvites: {
+      name: "ListInvites",
+      I: ListInvitesRequest,
+      O: ListInvitesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Revokes an invite, so it can't be redeemed anymore.
+     * Capabilities already issued for the invite are not affected, and must be revoked separately.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.AccessControl.RevokeInvite
+     */
+    revokeInvite: {
+      name: "RevokeInvite",
+      I: RevokeInviteRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Redeems an invite token with the daemon of the issuer, and stores the issued Capability locally.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.AccessControl.RedeemInvite
+     */
+    redeemInvite: {
+      name: "RedeemInvite",
+      I: RedeemInviteRequest,
+      O: Capability,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Sends a request for access to a space to the daemon of its owner.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.AccessControl.RequestAccess
+     */
+    requestAccess: {
+      name: "RequestAccess",
+      I: RequestAccessRequest,
+      O: AccessRequest,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Lists pending access requests received by this daemon.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.AccessControl.ListAccessRequests
+     */
+    listAccessRequests: {
+      name: "ListAccessRequests",
+      I: ListAccessRequestsRequest,
+      O: ListAccessRequestsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Approves an access request, issuing a Capability for the requester.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.AccessControl.ApproveAccessRequest
+     */
+    approveAccessRequest: {
+      name: "ApproveAccessRequest",
+      I: ApproveAccessRequestRequest,
+      O: Capability,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Denies an access request.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.AccessControl.DenyAccessRequest
+     */
+    denyAccessRequest: {
+      name: "DenyAccessRequest",
+      I: DenyAccessRequestRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
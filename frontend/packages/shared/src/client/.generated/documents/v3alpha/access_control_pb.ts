@@ -31,12 +31,28 @@ export enum Role {
    * @generated from enum value: AGENT = 3;
    */
   AGENT = 3,
+
+  /**
+   * Has read access to private documents.
+   *
+   * @generated from enum value: READER = 4;
+   */
+  READER = 4,
+
+  /**
+   * Has read access to private documents, and can comment on them.
+   *
+   * @generated from enum value: COMMENTER = 5;
+   */
+  COMMENTER = 5,
 }
 // Retrieve enum metadata with: proto3.getEnumType(Role)
 proto3.util.setEnumType(Role, "com.seed.documents.v3alpha.Role", [
   { no: 0, name: "ROLE_UNSPECIFIED" },
   { no: 2, name: "WRITER" },
   { no: 3, name: "AGENT" },
+  { no: 4, name: "READER" },
+  { no: 5, name: "COMMENTER" },
 ]);
 
 /**
@@ -281,6 +297,22 @@ export class CreateCapabilityRequest extends Message<CreateCapabilityRequest> {
    */
   label = "";
 
+  /**
+   * Optional. Time from which the capability becomes valid.
+   * Changes signed by the delegate before this time are not authorized by the capability.
+   *
+   * @generated from field: google.protobuf.Timestamp not_before = 8;
+   */
+  notBefore?: Timestamp;
+
+  /**
+   * Optional. Time when the capability expires.
+   * Changes signed by the delegate at or after this time are not authorized by the capability.
+   *
+   * @generated from field: google.protobuf.Timestamp expire_time = 9;
+   */
+  expireTime?: Timestamp;
+
   constructor(data?: PartialMessage<CreateCapabilityRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -296,6 +328,8 @@ export class CreateCapabilityRequest extends Message<CreateCapabilityRequest> {
     { no: 5, name: "role", kind: "enum", T: proto3.getEnumType(Role) },
     { no: 6, name: "no_recursive", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
     { no: 7, name: "label", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "not_before", kind: "message", T: Timestamp },
+    { no: 9, name: "expire_time", kind: "message", T: Timestamp },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateCapabilityRequest {
@@ -356,6 +390,56 @@ export class GetCapabilityRequest extends Message<GetCapabilityRequest> {
   }
 }
 
+/**
+ * Request to revoke a capability.
+ *
+ * @generated from message com.seed.documents.v3alpha.RevokeCapabilityRequest
+ */
+export class RevokeCapabilityRequest extends Message<RevokeCapabilityRequest> {
+  /**
+   * Required. Name of the key to use for signing the revocation.
+   * Must be the same key that issued the capability.
+   *
+   * @generated from field: string signing_key_name = 1;
+   */
+  signingKeyName = "";
+
+  /**
+   * Required. ID of the capability to revoke.
+   *
+   * @generated from field: string id = 2;
+   */
+  id = "";
+
+  constructor(data?: PartialMessage<RevokeCapabilityRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.RevokeCapabilityRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RevokeCapabilityRequest {
+    return new RevokeCapabilityRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RevokeCapabilityRequest {
+    return new RevokeCapabilityRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RevokeCapabilityRequest {
+    return new RevokeCapabilityRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RevokeCapabilityRequest | PlainMessage<RevokeCapabilityRequest> | undefined, b: RevokeCapabilityRequest | PlainMessage<RevokeCapabilityRequest> | undefined): boolean {
+    return proto3.util.equals(RevokeCapabilityRequest, a, b);
+  }
+}
+
 /**
  * Capability is an unforgeable token that grants access to a specific path within an account.
  *
@@ -428,6 +512,20 @@ export class Capability extends Message<Capability> {
    */
   label = "";
 
+  /**
+   * Optional. Time from which the capability is valid, if any.
+   *
+   * @generated from field: google.protobuf.Timestamp not_before = 10;
+   */
+  notBefore?: Timestamp;
+
+  /**
+   * Optional. Time when the capability expires, if any.
+   *
+   * @generated from field: google.protobuf.Timestamp expire_time = 11;
+   */
+  expireTime?: Timestamp;
+
   constructor(data?: PartialMessage<Capability>) {
     super();
     proto3.util.initPartial(data, this);
@@ -445,6 +543,8 @@ export class Capability extends Message<Capability> {
     { no: 7, name: "is_exact", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
     { no: 8, name: "create_time", kind: "message", T: Timestamp },
     { no: 9, name: "label", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 10, name: "not_before", kind: "message", T: Timestamp },
+    { no: 11, name: "expire_time", kind: "message", T: Timestamp },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Capability {
@@ -464,3 +564,876 @@ export class Capability extends Message<Capability> {
   }
 }
 
+/**
+ * Revocation of a previously issued capability.
+ *
+ * @generated from message com.seed.documents.v3alpha.Revocation
+ */
+export class Revocation extends Message<Revocation> {
+  /**
+   * ID of this revocation.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * ID of the revoked capability.
+   *
+   * @generated from field: string capability = 2;
+   */
+  capability = "";
+
+  /**
+   * ID of the account that issued the revocation.
+   *
+   * @generated from field: string issuer = 3;
+   */
+  issuer = "";
+
+  /**
+   * ID of the account whose capability was revoked.
+   *
+   * @generated from field: string delegate = 4;
+   */
+  delegate = "";
+
+  /**
+   * Account ID that the revoked capability granted access to.
+   *
+   * @generated from field: string account = 5;
+   */
+  account = "";
+
+  /**
+   * Path within the account which the revoked capability granted access to.
+   *
+   * @generated from field: string path = 6;
+   */
+  path = "";
+
+  /**
+   * Timestamp when the capability was revoked.
+   *
+   * @generated from field: google.protobuf.Timestamp create_time = 7;
+   */
+  createTime?: Timestamp;
+
+  constructor(data?: PartialMessage<Revocation>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.Revocation";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "capability", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "issuer", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "delegate", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "create_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Revocation {
+    return new Revocation().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Revocation {
+    return new Revocation().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Revocation {
+    return new Revocation().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Revocation | PlainMessage<Revocation> | undefined, b: Revocation | PlainMessage<Revocation> | undefined): boolean {
+    return proto3.util.equals(Revocation, a, b);
+  }
+}
+
+/**
+ * Request to create an invite.
+ *
+ * @generated from message com.seed.documents.v3alpha.CreateInviteRequest
+ */
+export class CreateInviteRequest extends Message<CreateInviteRequest> {
+  /**
+   * Required. Name of the key to use for signing the invite and the capabilities issued for it.
+   *
+   * @generated from field: string signing_key_name = 1;
+   */
+  signingKeyName = "";
+
+  /**
+   * Required. Account ID to which the invite gives access.
+   *
+   * @generated from field: string account = 2;
+   */
+  account = "";
+
+  /**
+   * Required. Path within the account that the invite grants access to.
+   * Empty string means root document.
+   *
+   * @generated from field: string path = 3;
+   */
+  path = "";
+
+  /**
+   * Required. Role that the capabilities issued for the invite grant.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Role role = 4;
+   */
+  role = Role.ROLE_UNSPECIFIED;
+
+  /**
+   * Optional. Short, user-provided label used for the invite and the issued capabilities.
+   *
+   * @generated from field: string label = 5;
+   */
+  label = "";
+
+  /**
+   * Optional. Time after which the invite can't be redeemed anymore.
+   *
+   * @generated from field: google.protobuf.Timestamp expire_time = 6;
+   */
+  expireTime?: Timestamp;
+
+  /**
+   * Optional. Maximum number of invitees that can redeem the invite.
+   * Zero means unlimited.
+   *
+   * @generated from field: int32 max_uses = 7;
+   */
+  maxUses = 0;
+
+  constructor(data?: PartialMessage<CreateInviteRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.CreateInviteRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "role", kind: "enum", T: proto3.getEnumType(Role) },
+    { no: 5, name: "label", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "expire_time", kind: "message", T: Timestamp },
+    { no: 7, name: "max_uses", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateInviteRequest {
+    return new CreateInviteRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateInviteRequest {
+    return new CreateInviteRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateInviteRequest {
+    return new CreateInviteRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateInviteRequest | PlainMessage<CreateInviteRequest> | undefined, b: CreateInviteRequest | PlainMessage<CreateInviteRequest> | undefined): boolean {
+    return proto3.util.equals(CreateInviteRequest, a, b);
+  }
+}
+
+/**
+ * Request to list invites.
+ *
+ * @generated from message com.seed.documents.v3alpha.ListInvitesRequest
+ */
+export class ListInvitesRequest extends Message<ListInvitesRequest> {
+  /**
+   * Optional. Only list invites for this account.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Optional. Number of results per page.
+   * Ignored while pagination is not implemented.
+   *
+   * @generated from field: int32 page_size = 2;
+   */
+  pageSize = 0;
+
+  /**
+   * Optional. Token for the page to return.
+   * Ignored while pagination is not implemented.
+   *
+   * @generated from field: string page_token = 3;
+   */
+  pageToken = "";
+
+  constructor(data?: PartialMessage<ListInvitesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ListInvitesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListInvitesRequest {
+    return new ListInvitesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListInvitesRequest {
+    return new ListInvitesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListInvitesRequest {
+    return new ListInvitesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListInvitesRequest | PlainMessage<ListInvitesRequest> | undefined, b: ListInvitesRequest | PlainMessage<ListInvitesRequest> | undefined): boolean {
+    return proto3.util.equals(ListInvitesRequest, a, b);
+  }
+}
+
+/**
+ * Response with invites.
+ *
+ * @generated from message com.seed.documents.v3alpha.ListInvitesResponse
+ */
+export class ListInvitesResponse extends Message<ListInvitesResponse> {
+  /**
+   * Outstanding invites, most recent first.
+   * Expired and fully used invites are not included.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.Invite invites = 1;
+   */
+  invites: Invite[] = [];
+
+  /**
+   * Optional. Token for fetching the next page.
+   * Empty while pagination is not implemented.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken = "";
+
+  constructor(data?: PartialMessage<ListInvitesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ListInvitesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "invites", kind: "message", T: Invite, repeated: true },
+    { no: 2, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListInvitesResponse {
+    return new ListInvitesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListInvitesResponse {
+    return new ListInvitesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListInvitesResponse {
+    return new ListInvitesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListInvitesResponse | PlainMessage<ListInvitesResponse> | undefined, b: ListInvitesResponse | PlainMessage<ListInvitesResponse> | undefined): boolean {
+    return proto3.util.equals(ListInvitesResponse, a, b);
+  }
+}
+
+/**
+ * Request to revoke an invite.
+ *
+ * @generated from message com.seed.documents.v3alpha.RevokeInviteRequest
+ */
+export class RevokeInviteRequest extends Message<RevokeInviteRequest> {
+  /**
+   * Required. ID of the invite to revoke.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  constructor(data?: PartialMessage<RevokeInviteRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.RevokeInviteRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RevokeInviteRequest {
+    return new RevokeInviteRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RevokeInviteRequest {
+    return new RevokeInviteRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RevokeInviteRequest {
+    return new RevokeInviteRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RevokeInviteRequest | PlainMessage<RevokeInviteRequest> | undefined, b: RevokeInviteRequest | PlainMessage<RevokeInviteRequest> | undefined): boolean {
+    return proto3.util.equals(RevokeInviteRequest, a, b);
+  }
+}
+
+/**
+ * Request to redeem an invite.
+ *
+ * @generated from message com.seed.documents.v3alpha.RedeemInviteRequest
+ */
+export class RedeemInviteRequest extends Message<RedeemInviteRequest> {
+  /**
+   * Required. Token of the invite.
+   *
+   * @generated from field: string token = 1;
+   */
+  token = "";
+
+  /**
+   * Required. Name of the key that will receive the capability.
+   *
+   * @generated from field: string signing_key_name = 2;
+   */
+  signingKeyName = "";
+
+  constructor(data?: PartialMessage<RedeemInviteRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.RedeemInviteRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RedeemInviteRequest {
+    return new RedeemInviteRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RedeemInviteRequest {
+    return new RedeemInviteRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RedeemInviteRequest {
+    return new RedeemInviteRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RedeemInviteRequest | PlainMessage<RedeemInviteRequest> | undefined, b: RedeemInviteRequest | PlainMessage<RedeemInviteRequest> | undefined): boolean {
+    return proto3.util.equals(RedeemInviteRequest, a, b);
+  }
+}
+
+/**
+ * Invite is a token that can be redeemed for a Capability with the daemon that issued it.
+ *
+ * @generated from message com.seed.documents.v3alpha.Invite
+ */
+export class Invite extends Message<Invite> {
+  /**
+   * ID of the invite.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * Token to share with the invitees.
+   * Anyone holding it can redeem the invite.
+   *
+   * @generated from field: string token = 2;
+   */
+  token = "";
+
+  /**
+   * Account ID that the invite gives access to.
+   *
+   * @generated from field: string account = 3;
+   */
+  account = "";
+
+  /**
+   * Path within the account which the invite grants access to.
+   *
+   * @generated from field: string path = 4;
+   */
+  path = "";
+
+  /**
+   * Role granted by the capabilities issued for the invite.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Role role = 5;
+   */
+  role = Role.ROLE_UNSPECIFIED;
+
+  /**
+   * Label of the invite.
+   *
+   * @generated from field: string label = 6;
+   */
+  label = "";
+
+  /**
+   * Time after which the invite can't be redeemed, if any.
+   *
+   * @generated from field: google.protobuf.Timestamp expire_time = 7;
+   */
+  expireTime?: Timestamp;
+
+  /**
+   * Maximum number of redemptions. Zero means unlimited.
+   *
+   * @generated from field: int32 max_uses = 8;
+   */
+  maxUses = 0;
+
+  /**
+   * Number of invitees who redeemed the invite.
+   *
+   * @generated from field: int32 use_count = 9;
+   */
+  useCount = 0;
+
+  /**
+   * Time when the invite was created.
+   *
+   * @generated from field: google.protobuf.Timestamp create_time = 10;
+   */
+  createTime?: Timestamp;
+
+  constructor(data?: PartialMessage<Invite>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.Invite";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "role", kind: "enum", T: proto3.getEnumType(Role) },
+    { no: 6, name: "label", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "expire_time", kind: "message", T: Timestamp },
+    { no: 8, name: "max_uses", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 9, name: "use_count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 10, name: "create_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Invite {
+    return new Invite().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Invite {
+    return new Invite().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Invite {
+    return new Invite().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Invite | PlainMessage<Invite> | undefined, b: Invite | PlainMessage<Invite> | undefined): boolean {
+    return proto3.util.equals(Invite, a, b);
+  }
+}
+
+/**
+ * Request to ask for access to a space.
+ *
+ * @generated from message com.seed.documents.v3alpha.RequestAccessRequest
+ */
+export class RequestAccessRequest extends Message<RequestAccessRequest> {
+  /**
+   * Required. Name of the key that is asking for access, and that will receive the capability.
+   *
+   * @generated from field: string signing_key_name = 1;
+   */
+  signingKeyName = "";
+
+  /**
+   * Required. Account ID of the space to request access to.
+   *
+   * @generated from field: string account = 2;
+   */
+  account = "";
+
+  /**
+   * Optional. Path within the account to request access to.
+   * Empty string means root document.
+   *
+   * @generated from field: string path = 3;
+   */
+  path = "";
+
+  /**
+   * Optional. Requested role. Defaults to READER.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Role role = 4;
+   */
+  role = Role.ROLE_UNSPECIFIED;
+
+  /**
+   * Optional. Message for the owner of the space.
+   *
+   * @generated from field: string message = 5;
+   */
+  message = "";
+
+  /**
+   * Required. ID of the peer of the space owner, where the request is sent to.
+   *
+   * @generated from field: string peer_id = 6;
+   */
+  peerId = "";
+
+  constructor(data?: PartialMessage<RequestAccessRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.RequestAccessRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "role", kind: "enum", T: proto3.getEnumType(Role) },
+    { no: 5, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "peer_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RequestAccessRequest {
+    return new RequestAccessRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RequestAccessRequest {
+    return new RequestAccessRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RequestAccessRequest {
+    return new RequestAccessRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RequestAccessRequest | PlainMessage<RequestAccessRequest> | undefined, b: RequestAccessRequest | PlainMessage<RequestAccessRequest> | undefined): boolean {
+    return proto3.util.equals(RequestAccessRequest, a, b);
+  }
+}
+
+/**
+ * Request to list access requests.
+ *
+ * @generated from message com.seed.documents.v3alpha.ListAccessRequestsRequest
+ */
+export class ListAccessRequestsRequest extends Message<ListAccessRequestsRequest> {
+  /**
+   * Optional. Only list requests for this account.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Optional. Number of results per page.
+   * Ignored while pagination is not implemented.
+   *
+   * @generated from field: int32 page_size = 2;
+   */
+  pageSize = 0;
+
+  /**
+   * Optional. Token for the page to return.
+   * Ignored while pagination is not implemented.
+   *
+   * @generated from field: string page_token = 3;
+   */
+  pageToken = "";
+
+  constructor(data?: PartialMessage<ListAccessRequestsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ListAccessRequestsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListAccessRequestsRequest {
+    return new ListAccessRequestsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListAccessRequestsRequest {
+    return new ListAccessRequestsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListAccessRequestsRequest {
+    return new ListAccessRequestsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListAccessRequestsRequest | PlainMessage<ListAccessRequestsRequest> | undefined, b: ListAccessRequestsRequest | PlainMessage<ListAccessRequestsRequest> | undefined): boolean {
+    return proto3.util.equals(ListAccessRequestsRequest, a, b);
+  }
+}
+
+/**
+ * Response with access requests.
+ *
+ * @generated from message com.seed.documents.v3alpha.ListAccessRequestsResponse
+ */
+export class ListAccessRequestsResponse extends Message<ListAccessRequestsResponse> {
+  /**
+   * Pending access requests, most recent first.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.AccessRequest access_requests = 1;
+   */
+  accessRequests: AccessRequest[] = [];
+
+  /**
+   * Optional. Token for fetching the next page.
+   * Empty while pagination is not implemented.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken = "";
+
+  constructor(data?: PartialMessage<ListAccessRequestsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ListAccessRequestsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "access_requests", kind: "message", T: AccessRequest, repeated: true },
+    { no: 2, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListAccessRequestsResponse {
+    return new ListAccessRequestsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListAccessRequestsResponse {
+    return new ListAccessRequestsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListAccessRequestsResponse {
+    return new ListAccessRequestsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListAccessRequestsResponse | PlainMessage<ListAccessRequestsResponse> | undefined, b: ListAccessRequestsResponse | PlainMessage<ListAccessRequestsResponse> | undefined): boolean {
+    return proto3.util.equals(ListAccessRequestsResponse, a, b);
+  }
+}
+
+/**
+ * Request to approve an access request.
+ *
+ * @generated from message com.seed.documents.v3alpha.ApproveAccessRequestRequest
+ */
+export class ApproveAccessRequestRequest extends Message<ApproveAccessRequestRequest> {
+  /**
+   * Required. ID of the access request.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * Required. Name of the key to use for signing the capability.
+   * Must be the owner of the requested space.
+   *
+   * @generated from field: string signing_key_name = 2;
+   */
+  signingKeyName = "";
+
+  /**
+   * Optional. Role to grant instead of the requested one.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Role role = 3;
+   */
+  role = Role.ROLE_UNSPECIFIED;
+
+  constructor(data?: PartialMessage<ApproveAccessRequestRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ApproveAccessRequestRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "role", kind: "enum", T: proto3.getEnumType(Role) },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ApproveAccessRequestRequest {
+    return new ApproveAccessRequestRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ApproveAccessRequestRequest {
+    return new ApproveAccessRequestRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ApproveAccessRequestRequest {
+    return new ApproveAccessRequestRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ApproveAccessRequestRequest | PlainMessage<ApproveAccessRequestRequest> | undefined, b: ApproveAccessRequestRequest | PlainMessage<ApproveAccessRequestRequest> | undefined): boolean {
+    return proto3.util.equals(ApproveAccessRequestRequest, a, b);
+  }
+}
+
+/**
+ * Request to deny an access request.
+ *
+ * @generated from message com.seed.documents.v3alpha.DenyAccessRequestRequest
+ */
+export class DenyAccessRequestRequest extends Message<DenyAccessRequestRequest> {
+  /**
+   * Required. ID of the access request.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  constructor(data?: PartialMessage<DenyAccessRequestRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.DenyAccessRequestRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DenyAccessRequestRequest {
+    return new DenyAccessRequestRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DenyAccessRequestRequest {
+    return new DenyAccessRequestRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DenyAccessRequestRequest {
+    return new DenyAccessRequestRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DenyAccessRequestRequest | PlainMessage<DenyAccessRequestRequest> | undefined, b: DenyAccessRequestRequest | PlainMessage<DenyAccessRequestRequest> | undefined): boolean {
+    return proto3.util.equals(DenyAccessRequestRequest, a, b);
+  }
+}
+
+/**
+ * Request of some account to be granted access to a space.
+ *
+ * @generated from message com.seed.documents.v3alpha.AccessRequest
+ */
+export class AccessRequest extends Message<AccessRequest> {
+  /**
+   * ID of the access request.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * Account ID of the space the access is requested for.
+   *
+   * @generated from field: string account = 2;
+   */
+  account = "";
+
+  /**
+   * Path within the account the access is requested for.
+   *
+   * @generated from field: string path = 3;
+   */
+  path = "";
+
+  /**
+   * Requested role.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Role role = 4;
+   */
+  role = Role.ROLE_UNSPECIFIED;
+
+  /**
+   * Account ID that is requesting access.
+   *
+   * @generated from field: string requester = 5;
+   */
+  requester = "";
+
+  /**
+   * Message for the owner of the space.
+   *
+   * @generated from field: string message = 6;
+   */
+  message = "";
+
+  /**
+   * Time when the request was created.
+   *
+   * @generated from field: google.protobuf.Timestamp create_time = 7;
+   */
+  createTime?: Timestamp;
+
+  constructor(data?: PartialMessage<AccessRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.AccessRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "role", kind: "enum", T: proto3.getEnumType(Role) },
+    { no: 5, name: "requester", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "create_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AccessRequest {
+    return new AccessRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AccessRequest {
+    return new AccessRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AccessRequest {
+    return new AccessRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AccessRequest | PlainMessage<AccessRequest> | undefined, b: AccessRequest | PlainMessage<AccessRequest> | undefined): boolean {
+    return proto3.util.equals(AccessRequest, a, b);
+  }
+}
+
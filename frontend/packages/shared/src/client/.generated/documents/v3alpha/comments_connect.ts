@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { BatchGetCommentsRequest, BatchGetCommentsResponse, Comment, CreateCommentRequest, DeleteCommentRequest, GetCommentReplyCountRequest, GetCommentReplyCountResponse, GetCommentRequest, ListCommentsByAuthorRequest, ListCommentsRequest, ListCommentsResponse, ListCommentVersionsRequest, ListCommentVersionsResponse, UpdateCommentRequest } from "./comments_pb";
+import { BatchGetCommentsRequest, BatchGetCommentsResponse, Comment, CreateCommentRequest, CreateReactionRequest, DeleteCommentRequest, DeleteReactionRequest, GetCommentReplyCountRequest, GetCommentReplyCountResponse, GetCommentRequest, ListCommentsByAuthorRequest, ListCommentsRequest, ListCommentsResponse, ListCommentVersionsRequest, ListCommentVersionsResponse, ListReactionsRequest, ListReactionsResponse, Reaction, UpdateCommentRequest } from "./comments_pb";
 import { Empty, MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -113,6 +113,39 @@ export const Comments = {
       O: ListCommentVersionsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Creates a reaction to a document, a block, or a comment.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Comments.CreateReaction
+     */
+    createReaction: {
+      name: "CreateReaction",
+      I: CreateReactionRequest,
+      O: Reaction,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Deletes a reaction.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Comments.DeleteReaction
+     */
+    deleteReaction: {
+      name: "DeleteReaction",
+      I: DeleteReactionRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Lists reactions for a given target, along with their aggregated counts.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Comments.ListReactions
+     */
+    listReactions: {
+      name: "ListReactions",
+      I: ListReactionsRequest,
+      O: ListReactionsResponse,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
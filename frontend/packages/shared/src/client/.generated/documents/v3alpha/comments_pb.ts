@@ -5,7 +5,48 @@
 
 import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
 import { Message, proto3, protoInt64, Timestamp } from "@bufbuild/protobuf";
-import { BlockNode } from "./documents_pb";
+import { BlockNode, ReactionCount } from "./documents_pb";
+
+/**
+ * Status of a comment anchor in the latest version of the target document.
+ *
+ * @generated from enum com.seed.documents.v3alpha.CommentAnchorStatus
+ */
+export enum CommentAnchorStatus {
+  /**
+   * @generated from enum value: COMMENT_ANCHOR_STATUS_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * The anchored block and text are the same as in the target version.
+   *
+   * @generated from enum value: COMMENT_ANCHOR_STATUS_VALID = 1;
+   */
+  VALID = 1,
+
+  /**
+   * The anchored content still exists, but the block was moved, or the text was edited.
+   * Resolved range points to the anchored text in the latest version.
+   *
+   * @generated from enum value: COMMENT_ANCHOR_STATUS_MOVED = 2;
+   */
+  MOVED = 2,
+
+  /**
+   * The anchored block or all of the anchored text was deleted.
+   *
+   * @generated from enum value: COMMENT_ANCHOR_STATUS_ORPHANED = 3;
+   */
+  ORPHANED = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(CommentAnchorStatus)
+proto3.util.setEnumType(CommentAnchorStatus, "com.seed.documents.v3alpha.CommentAnchorStatus", [
+  { no: 0, name: "COMMENT_ANCHOR_STATUS_UNSPECIFIED" },
+  { no: 1, name: "COMMENT_ANCHOR_STATUS_VALID" },
+  { no: 2, name: "COMMENT_ANCHOR_STATUS_MOVED" },
+  { no: 3, name: "COMMENT_ANCHOR_STATUS_ORPHANED" },
+]);
 
 /**
  * Request to create a comment.
@@ -65,6 +106,14 @@ export class CreateCommentRequest extends Message<CreateCommentRequest> {
    */
   capability = "";
 
+  /**
+   * Optional. Block and range of text in the target version the comment refers to.
+   * Only the input fields of the anchor are used.
+   *
+   * @generated from field: com.seed.documents.v3alpha.CommentAnchor anchor = 8;
+   */
+  anchor?: CommentAnchor;
+
   constructor(data?: PartialMessage<CreateCommentRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -80,6 +129,7 @@ export class CreateCommentRequest extends Message<CreateCommentRequest> {
     { no: 5, name: "content", kind: "message", T: BlockNode, repeated: true },
     { no: 6, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 7, name: "capability", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "anchor", kind: "message", T: CommentAnchor },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateCommentRequest {
@@ -509,6 +559,20 @@ export class Comment extends Message<Comment> {
    */
   visibility = "";
 
+  /**
+   * Output only. Aggregated counts of the reactions to this comment.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.ReactionCount reactions = 16;
+   */
+  reactions: ReactionCount[] = [];
+
+  /**
+   * Optional. Block and range of text in the target version the comment refers to.
+   *
+   * @generated from field: com.seed.documents.v3alpha.CommentAnchor anchor = 17;
+   */
+  anchor?: CommentAnchor;
+
   constructor(data?: PartialMessage<Comment>) {
     super();
     proto3.util.initPartial(data, this);
@@ -532,6 +596,8 @@ export class Comment extends Message<Comment> {
     { no: 11, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 14, name: "update_time", kind: "message", T: Timestamp },
     { no: 15, name: "visibility", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 16, name: "reactions", kind: "message", T: ReactionCount, repeated: true },
+    { no: 17, name: "anchor", kind: "message", T: CommentAnchor },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Comment {
@@ -551,6 +617,105 @@ export class Comment extends Message<Comment> {
   }
 }
 
+/**
+ * Anchor of a comment to a block of the target document, or to a range of text within the block.
+ *
+ * @generated from message com.seed.documents.v3alpha.CommentAnchor
+ */
+export class CommentAnchor extends Message<CommentAnchor> {
+  /**
+   * Required. ID of the block the comment refers to.
+   *
+   * @generated from field: string block_id = 1;
+   */
+  blockId = "";
+
+  /**
+   * Optional. Start of the range of text within the block, in Unicode code points.
+   *
+   * @generated from field: int32 start = 2;
+   */
+  start = 0;
+
+  /**
+   * Optional. End of the range of text within the block, in Unicode code points, exclusive.
+   * Zero start and end refer to the whole block.
+   *
+   * @generated from field: int32 end = 3;
+   */
+  end = 0;
+
+  /**
+   * Output only. Revision of the block in the target version the anchor was made against.
+   *
+   * @generated from field: string block_revision = 4;
+   */
+  blockRevision = "";
+
+  /**
+   * Output only. What happened to the anchored content in the latest version of the document.
+   * Unspecified while the anchor wasn't resolved yet, e.g. when the target version is not available locally.
+   *
+   * @generated from field: com.seed.documents.v3alpha.CommentAnchorStatus status = 5;
+   */
+  status = CommentAnchorStatus.UNSPECIFIED;
+
+  /**
+   * Output only. Start of the anchored range of text in the latest version of the document.
+   *
+   * @generated from field: int32 resolved_start = 6;
+   */
+  resolvedStart = 0;
+
+  /**
+   * Output only. End of the anchored range of text in the latest version of the document.
+   *
+   * @generated from field: int32 resolved_end = 7;
+   */
+  resolvedEnd = 0;
+
+  /**
+   * Output only. Version of the document the anchor was resolved against.
+   *
+   * @generated from field: string resolved_version = 8;
+   */
+  resolvedVersion = "";
+
+  constructor(data?: PartialMessage<CommentAnchor>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.CommentAnchor";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "block_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "start", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "end", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 4, name: "block_revision", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "status", kind: "enum", T: proto3.getEnumType(CommentAnchorStatus) },
+    { no: 6, name: "resolved_start", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 7, name: "resolved_end", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 8, name: "resolved_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CommentAnchor {
+    return new CommentAnchor().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CommentAnchor {
+    return new CommentAnchor().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CommentAnchor {
+    return new CommentAnchor().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CommentAnchor | PlainMessage<CommentAnchor> | undefined, b: CommentAnchor | PlainMessage<CommentAnchor> | undefined): boolean {
+    return proto3.util.equals(CommentAnchor, a, b);
+  }
+}
+
 /**
  * Request to update a comment.
  *
@@ -816,3 +981,395 @@ export class ListCommentVersionsResponse extends Message<ListCommentVersionsResp
   }
 }
 
+/**
+ * Request to create a reaction.
+ *
+ * @generated from message com.seed.documents.v3alpha.CreateReactionRequest
+ */
+export class CreateReactionRequest extends Message<CreateReactionRequest> {
+  /**
+   * Required. Account ID of the target document.
+   *
+   * @generated from field: string target_account = 1;
+   */
+  targetAccount = "";
+
+  /**
+   * Required. Path of the target document within the account.
+   *
+   * @generated from field: string target_path = 2;
+   */
+  targetPath = "";
+
+  /**
+   * Required. Version of the document at the time of the reaction.
+   *
+   * @generated from field: string target_version = 3;
+   */
+  targetVersion = "";
+
+  /**
+   * Optional. ID of the block within the document to react to.
+   *
+   * @generated from field: string target_block = 4;
+   */
+  targetBlock = "";
+
+  /**
+   * Optional. ID of the comment on the document to react to.
+   * Can't be used together with target_block.
+   *
+   * @generated from field: string target_comment = 5;
+   */
+  targetComment = "";
+
+  /**
+   * Required. Emoji or a short token of the reaction.
+   *
+   * @generated from field: string value = 6;
+   */
+  value = "";
+
+  /**
+   * Required. Name of the key to use for signing the reaction.
+   *
+   * @generated from field: string signing_key_name = 7;
+   */
+  signingKeyName = "";
+
+  constructor(data?: PartialMessage<CreateReactionRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.CreateReactionRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "target_account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "target_path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "target_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "target_block", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "target_comment", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "value", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateReactionRequest {
+    return new CreateReactionRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateReactionRequest {
+    return new CreateReactionRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateReactionRequest {
+    return new CreateReactionRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateReactionRequest | PlainMessage<CreateReactionRequest> | undefined, b: CreateReactionRequest | PlainMessage<CreateReactionRequest> | undefined): boolean {
+    return proto3.util.equals(CreateReactionRequest, a, b);
+  }
+}
+
+/**
+ * Request to delete a reaction.
+ *
+ * @generated from message com.seed.documents.v3alpha.DeleteReactionRequest
+ */
+export class DeleteReactionRequest extends Message<DeleteReactionRequest> {
+  /**
+   * Required. ID of the reaction to delete.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * Required. Name of the key to use for signing the reaction deletion.
+   *
+   * @generated from field: string signing_key_name = 2;
+   */
+  signingKeyName = "";
+
+  constructor(data?: PartialMessage<DeleteReactionRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.DeleteReactionRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteReactionRequest {
+    return new DeleteReactionRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteReactionRequest {
+    return new DeleteReactionRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteReactionRequest {
+    return new DeleteReactionRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteReactionRequest | PlainMessage<DeleteReactionRequest> | undefined, b: DeleteReactionRequest | PlainMessage<DeleteReactionRequest> | undefined): boolean {
+    return proto3.util.equals(DeleteReactionRequest, a, b);
+  }
+}
+
+/**
+ * Request to list reactions.
+ *
+ * @generated from message com.seed.documents.v3alpha.ListReactionsRequest
+ */
+export class ListReactionsRequest extends Message<ListReactionsRequest> {
+  /**
+   * Required. Account ID of the target document.
+   *
+   * @generated from field: string target_account = 1;
+   */
+  targetAccount = "";
+
+  /**
+   * Required. Path of the target document within the account.
+   *
+   * @generated from field: string target_path = 2;
+   */
+  targetPath = "";
+
+  /**
+   * Optional. ID of the block to list the reactions for.
+   * If empty, reactions to the document itself are listed.
+   *
+   * @generated from field: string target_block = 3;
+   */
+  targetBlock = "";
+
+  /**
+   * Optional. ID of the comment to list the reactions for.
+   *
+   * @generated from field: string target_comment = 4;
+   */
+  targetComment = "";
+
+  /**
+   * Optional. The maximum number of reactions to return.
+   *
+   * @generated from field: int32 page_size = 5;
+   */
+  pageSize = 0;
+
+  /**
+   * Optional. The page token obtained from a previous request (if any).
+   *
+   * @generated from field: string page_token = 6;
+   */
+  pageToken = "";
+
+  constructor(data?: PartialMessage<ListReactionsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ListReactionsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "target_account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "target_path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "target_block", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "target_comment", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 6, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListReactionsRequest {
+    return new ListReactionsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListReactionsRequest {
+    return new ListReactionsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListReactionsRequest {
+    return new ListReactionsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListReactionsRequest | PlainMessage<ListReactionsRequest> | undefined, b: ListReactionsRequest | PlainMessage<ListReactionsRequest> | undefined): boolean {
+    return proto3.util.equals(ListReactionsRequest, a, b);
+  }
+}
+
+/**
+ * Response with a list of reactions.
+ *
+ * @generated from message com.seed.documents.v3alpha.ListReactionsResponse
+ */
+export class ListReactionsResponse extends Message<ListReactionsResponse> {
+  /**
+   * List of reactions, most recent first.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.Reaction reactions = 1;
+   */
+  reactions: Reaction[] = [];
+
+  /**
+   * Aggregated counts of all the reactions to the target.
+   * Only returned with the first page.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.ReactionCount counts = 2;
+   */
+  counts: ReactionCount[] = [];
+
+  /**
+   * Token to retrieve the next page of reactions (if necessary).
+   *
+   * @generated from field: string next_page_token = 3;
+   */
+  nextPageToken = "";
+
+  constructor(data?: PartialMessage<ListReactionsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ListReactionsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "reactions", kind: "message", T: Reaction, repeated: true },
+    { no: 2, name: "counts", kind: "message", T: ReactionCount, repeated: true },
+    { no: 3, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListReactionsResponse {
+    return new ListReactionsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListReactionsResponse {
+    return new ListReactionsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListReactionsResponse {
+    return new ListReactionsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListReactionsResponse | PlainMessage<ListReactionsResponse> | undefined, b: ListReactionsResponse | PlainMessage<ListReactionsResponse> | undefined): boolean {
+    return proto3.util.equals(ListReactionsResponse, a, b);
+  }
+}
+
+/**
+ * Reaction to a document, a block, or a comment.
+ *
+ * @generated from message com.seed.documents.v3alpha.Reaction
+ */
+export class Reaction extends Message<Reaction> {
+  /**
+   * ID of the reaction.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * Account ID of the target document.
+   *
+   * @generated from field: string target_account = 2;
+   */
+  targetAccount = "";
+
+  /**
+   * Path of the target document within the account.
+   *
+   * @generated from field: string target_path = 3;
+   */
+  targetPath = "";
+
+  /**
+   * Version of the document at the time of the reaction.
+   *
+   * @generated from field: string target_version = 4;
+   */
+  targetVersion = "";
+
+  /**
+   * ID of the block the reaction is about, if any.
+   *
+   * @generated from field: string target_block = 5;
+   */
+  targetBlock = "";
+
+  /**
+   * ID of the comment the reaction is about, if any.
+   *
+   * @generated from field: string target_comment = 6;
+   */
+  targetComment = "";
+
+  /**
+   * Account ID of the author of the reaction.
+   *
+   * @generated from field: string author = 7;
+   */
+  author = "";
+
+  /**
+   * Emoji or a short token of the reaction.
+   *
+   * @generated from field: string value = 8;
+   */
+  value = "";
+
+  /**
+   * Timestamp when the reaction was created.
+   *
+   * @generated from field: google.protobuf.Timestamp create_time = 9;
+   */
+  createTime?: Timestamp;
+
+  /**
+   * Version of this reaction.
+   *
+   * @generated from field: string version = 10;
+   */
+  version = "";
+
+  constructor(data?: PartialMessage<Reaction>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.Reaction";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "target_account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "target_path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "target_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "target_block", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "target_comment", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "author", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "value", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 9, name: "create_time", kind: "message", T: Timestamp },
+    { no: 10, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Reaction {
+    return new Reaction().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Reaction {
+    return new Reaction().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Reaction {
+    return new Reaction().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Reaction | PlainMessage<Reaction> | undefined, b: Reaction | PlainMessage<Reaction> | undefined): boolean {
+    return proto3.util.equals(Reaction, a, b);
+  }
+}
+
@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { Account, BatchGetAccountsRequest, BatchGetAccountsResponse, BatchGetDocumentInfoRequest, BatchGetDocumentInfoResponse, Contact, CreateAliasRequest, CreateContactRequest, CreateRefRequest, DeleteContactRequest, DeleteDocumentRequest, Document, DocumentChangeInfo, DocumentInfo, GetAccountRequest, GetContactRequest, GetDocumentChangeRequest, GetDocumentInfoRequest, GetDocumentRequest, GetRefRequest, ListAccountsRequest, ListAccountsResponse, ListContactsRequest, ListContactsResponse, ListDirectoryRequest, ListDirectoryResponse, ListDocumentAttributeNamesRequest, ListDocumentAttributeNamesResponse, ListDocumentAttributeValuesRequest, ListDocumentAttributeValuesResponse, ListDocumentChangesRequest, ListDocumentChangesResponse, ListDocumentsRequest, ListDocumentsResponse, ListRefsRequest, ListRefsResponse, ListRootDocumentsRequest, ListRootDocumentsResponse, PrepareChangeRequest, PrepareChangeResponse, QueryDocumentsRequest, QueryDocumentsResponse, Ref, UpdateContactRequest, UpdateDocumentReadStatusRequest, UpdateProfileRequest } from "./documents_pb";
+import { Account, BatchGetAccountsRequest, BatchGetAccountsResponse, BatchGetDocumentInfoRequest, BatchGetDocumentInfoResponse, Branch, CancelScheduledRefRequest, Contact, CopyDocumentRequest, CopyDocumentResponse, CreateAliasRequest, CreateBranchRequest, CreateContactRequest, CreateFromTemplateRequest, CreateRefRequest, DeleteContactRequest, DeleteDocumentRequest, DiffBranchRequest, DiffDocumentRequest, DiffDocumentResponse, Document, DocumentBlame, DocumentChangeInfo, DocumentInfo, GetAccountRequest, GetContactRequest, GetDocumentBlameRequest, GetDocumentChangeRequest, GetDocumentInfoRequest, GetDocumentRequest, GetRefRequest, ListAccountsRequest, ListAccountsResponse, ListBranchesRequest, ListBranchesResponse, ListContactsRequest, ListContactsResponse, ListDirectoryRequest, ListDirectoryResponse, ListDocumentAttributeNamesRequest, ListDocumentAttributeNamesResponse, ListDocumentAttributeValuesRequest, ListDocumentAttributeValuesResponse, ListDocumentChangesRequest, ListDocumentChangesResponse, ListDocumentsRequest, ListDocumentsResponse, ListRefsRequest, ListRefsResponse, ListRootDocumentsRequest, ListRootDocumentsResponse, ListScheduledRefsRequest, ListScheduledRefsResponse, MergeBranchRequest, MoveDocumentTreeRequest, MoveDocumentTreeResponse, PrepareChangeRequest, PrepareChangeResponse, QueryDocumentsRequest, QueryDocumentsResponse, Ref, RevertDocumentRequest, RevertDocumentResponse, ScheduledRef, ScheduleRefRequest, UpdateContactRequest, UpdateDocumentReadStatusRequest, UpdateProfileRequest } from "./documents_pb";
 import { Empty, MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -276,6 +276,121 @@ export const Documents = {
       O: DocumentChangeInfo,
       kind: MethodKind.Unary,
     },
+    /**
+     * Compares two versions of a document block by block.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.DiffDocument
+     */
+    diffDocument: {
+      name: "DiffDocument",
+      I: DiffDocumentRequest,
+      O: DiffDocumentResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Attributes each block of a document version to the changes that last touched it.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.GetDocumentBlame
+     */
+    getDocumentBlame: {
+      name: "GetDocumentBlame",
+      I: GetDocumentBlameRequest,
+      O: DocumentBlame,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Restores a previous version of a document, or some of its blocks, by creating a new change on top of the current version.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.RevertDocument
+     */
+    revertDocument: {
+      name: "RevertDocument",
+      I: RevertDocumentRequest,
+      O: RevertDocumentResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Creates or updates a named branch of a document.
+     * Branches are drafts that don't change the published version of the document until they are merged.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.CreateBranch
+     */
+    createBranch: {
+      name: "CreateBranch",
+      I: CreateBranchRequest,
+      O: Branch,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Lists the branches of a document.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.ListBranches
+     */
+    listBranches: {
+      name: "ListBranches",
+      I: ListBranchesRequest,
+      O: ListBranchesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Compares a branch with the published version of the document.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.DiffBranch
+     */
+    diffBranch: {
+      name: "DiffBranch",
+      I: DiffBranchRequest,
+      O: DiffDocumentResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Publishes the changes of a branch, creating a Ref that combines the heads of the branch and the published version.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.MergeBranch
+     */
+    mergeBranch: {
+      name: "MergeBranch",
+      I: MergeBranchRequest,
+      O: Ref,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Moves a document and all the documents under its path to a new path within the same account.
+     * Documents are republished at the new paths with the same genesis, and redirects are left at the old paths.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.MoveDocumentTree
+     */
+    moveDocumentTree: {
+      name: "MoveDocumentTree",
+      I: MoveDocumentTreeRequest,
+      O: MoveDocumentTreeResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Creates a new document with the content of an existing one, optionally together with all the documents under it.
+     * Copies are new documents with their own genesis, and they record where they were copied from in the `copiedFrom` metadata attribute.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.CopyDocument
+     */
+    copyDocument: {
+      name: "CopyDocument",
+      I: CopyDocumentRequest,
+      O: CopyDocumentResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Creates a new document from a template, replacing the {{variable}} placeholders in block texts and metadata.
+     * Templates are documents with the `template` metadata attribute set to true, and can be found with QueryDocuments.
+     * The new document records the template version it was created from in the `createdFromTemplate` metadata attribute.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.CreateFromTemplate
+     */
+    createFromTemplate: {
+      name: "CreateFromTemplate",
+      I: CreateFromTemplateRequest,
+      O: Document,
+      kind: MethodKind.Unary,
+    },
     /**
      * Updates the read status of a document.
      *
@@ -320,6 +435,41 @@ export const Documents = {
       O: ListRefsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Holds a signed Ref, together with the blobs it depends on, in local storage until its publish time.
+     * Nothing is indexed or announced to other peers before that time.
+     * Scheduled Refs survive restarts of the daemon.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.ScheduleRef
+     */
+    scheduleRef: {
+      name: "ScheduleRef",
+      I: ScheduleRefRequest,
+      O: ScheduledRef,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Lists the Refs that are scheduled for publishing.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.ListScheduledRefs
+     */
+    listScheduledRefs: {
+      name: "ListScheduledRefs",
+      I: ListScheduledRefsRequest,
+      O: ListScheduledRefsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Cancels a scheduled Ref, discarding it together with the blobs held for it.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.CancelScheduledRef
+     */
+    cancelScheduledRef: {
+      name: "CancelScheduledRef",
+      I: CancelScheduledRefRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
@@ -4,7 +4,7 @@
 // @ts-nocheck
 
 import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
-import { Empty, Message, proto3, protoInt64, Struct, Timestamp } from "@bufbuild/protobuf";
+import { Empty, Message, proto3, protoInt64, Struct, Timestamp, Value } from "@bufbuild/protobuf";
 
 /**
  * Hypermedia resources can have different visibility levels declared by their creators.
@@ -125,6 +125,86 @@ proto3.util.setEnumType(DocumentAttributeKind, "com.seed.documents.v3alpha.Docum
   { no: 4, name: "DOCUMENT_ATTRIBUTE_KIND_BOOL" },
 ]);
 
+/**
+ * Kind of the difference of a block between two versions.
+ *
+ * @generated from enum com.seed.documents.v3alpha.BlockDiffKind
+ */
+export enum BlockDiffKind {
+  /**
+   * @generated from enum value: BLOCK_DIFF_KIND_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Block only exists in the target version.
+   *
+   * @generated from enum value: BLOCK_DIFF_KIND_ADDED = 1;
+   */
+  ADDED = 1,
+
+  /**
+   * Block only exists in the base version.
+   *
+   * @generated from enum value: BLOCK_DIFF_KIND_REMOVED = 2;
+   */
+  REMOVED = 2,
+
+  /**
+   * Block exists in both versions but it was moved and/or modified.
+   *
+   * @generated from enum value: BLOCK_DIFF_KIND_CHANGED = 3;
+   */
+  CHANGED = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(BlockDiffKind)
+proto3.util.setEnumType(BlockDiffKind, "com.seed.documents.v3alpha.BlockDiffKind", [
+  { no: 0, name: "BLOCK_DIFF_KIND_UNSPECIFIED" },
+  { no: 1, name: "BLOCK_DIFF_KIND_ADDED" },
+  { no: 2, name: "BLOCK_DIFF_KIND_REMOVED" },
+  { no: 3, name: "BLOCK_DIFF_KIND_CHANGED" },
+]);
+
+/**
+ * Kind of a text difference segment.
+ *
+ * @generated from enum com.seed.documents.v3alpha.TextDiffKind
+ */
+export enum TextDiffKind {
+  /**
+   * @generated from enum value: TEXT_DIFF_KIND_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Text present in both versions.
+   *
+   * @generated from enum value: TEXT_DIFF_KIND_EQUAL = 1;
+   */
+  EQUAL = 1,
+
+  /**
+   * Text only present in the target version.
+   *
+   * @generated from enum value: TEXT_DIFF_KIND_INSERTED = 2;
+   */
+  INSERTED = 2,
+
+  /**
+   * Text only present in the base version.
+   *
+   * @generated from enum value: TEXT_DIFF_KIND_DELETED = 3;
+   */
+  DELETED = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(TextDiffKind)
+proto3.util.setEnumType(TextDiffKind, "com.seed.documents.v3alpha.TextDiffKind", [
+  { no: 0, name: "TEXT_DIFF_KIND_UNSPECIFIED" },
+  { no: 1, name: "TEXT_DIFF_KIND_EQUAL" },
+  { no: 2, name: "TEXT_DIFF_KIND_INSERTED" },
+  { no: 3, name: "TEXT_DIFF_KIND_DELETED" },
+]);
+
 /**
  * Request for getting a single document.
  *
@@ -473,6 +553,23 @@ export class PrepareChangeResponse extends Message<PrepareChangeResponse> {
    */
   unsignedChange = new Uint8Array(0);
 
+  /**
+   * Number of member signatures the Ref for this change needs,
+   * when the document is the root of a multi-signature organization.
+   * The signatures are collected with the CreateRef API by passing the `partial_ref` between the members.
+   *
+   * @generated from field: int32 required_signatures = 2;
+   */
+  requiredSignatures = 0;
+
+  /**
+   * Members of the organization who can sign the Ref.
+   * Only set along with `required_signatures`.
+   *
+   * @generated from field: repeated string members = 3;
+   */
+  members: string[] = [];
+
   constructor(data?: PartialMessage<PrepareChangeResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -482,6 +579,8 @@ export class PrepareChangeResponse extends Message<PrepareChangeResponse> {
   static readonly typeName = "com.seed.documents.v3alpha.PrepareChangeResponse";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "unsigned_change", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+    { no: 2, name: "required_signatures", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "members", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PrepareChangeResponse {
@@ -3318,295 +3417,2308 @@ export class GetDocumentChangeRequest extends Message<GetDocumentChangeRequest>
 }
 
 /**
- * Request to update document's read status.
+ * Request to compare two versions of a document.
  *
- * @generated from message com.seed.documents.v3alpha.UpdateDocumentReadStatusRequest
+ * @generated from message com.seed.documents.v3alpha.DiffDocumentRequest
  */
-export class UpdateDocumentReadStatusRequest extends Message<UpdateDocumentReadStatusRequest> {
+export class DiffDocumentRequest extends Message<DiffDocumentRequest> {
   /**
-   * Required. ID of the account to update the document in.
+   * Required. ID of the account the document belongs to.
    *
    * @generated from field: string account = 1;
    */
   account = "";
 
   /**
-   * Required. Path of the document to update.
+   * Required. Path of the document.
    *
    * @generated from field: string path = 2;
    */
   path = "";
 
   /**
-   * Required. New read status of the document.
+   * Required. Version to compare from.
    *
-   * @generated from field: bool is_read = 3;
+   * @generated from field: string base_version = 3;
    */
-  isRead = false;
+  baseVersion = "";
 
   /**
-   * Optional. Whether the status should be updated recursively for children of the path.
+   * Optional. Version to compare to. Defaults to the latest version.
    *
-   * @generated from field: bool is_recursive = 4;
+   * @generated from field: string target_version = 4;
    */
-  isRecursive = false;
+  targetVersion = "";
 
-  constructor(data?: PartialMessage<UpdateDocumentReadStatusRequest>) {
+  constructor(data?: PartialMessage<DiffDocumentRequest>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "com.seed.documents.v3alpha.UpdateDocumentReadStatusRequest";
+  static readonly typeName = "com.seed.documents.v3alpha.DiffDocumentRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 3, name: "is_read", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
-    { no: 4, name: "is_recursive", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 3, name: "base_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "target_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UpdateDocumentReadStatusRequest {
-    return new UpdateDocumentReadStatusRequest().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DiffDocumentRequest {
+    return new DiffDocumentRequest().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UpdateDocumentReadStatusRequest {
-    return new UpdateDocumentReadStatusRequest().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DiffDocumentRequest {
+    return new DiffDocumentRequest().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UpdateDocumentReadStatusRequest {
-    return new UpdateDocumentReadStatusRequest().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DiffDocumentRequest {
+    return new DiffDocumentRequest().fromJsonString(jsonString, options);
   }
 
-  static equals(a: UpdateDocumentReadStatusRequest | PlainMessage<UpdateDocumentReadStatusRequest> | undefined, b: UpdateDocumentReadStatusRequest | PlainMessage<UpdateDocumentReadStatusRequest> | undefined): boolean {
-    return proto3.util.equals(UpdateDocumentReadStatusRequest, a, b);
+  static equals(a: DiffDocumentRequest | PlainMessage<DiffDocumentRequest> | undefined, b: DiffDocumentRequest | PlainMessage<DiffDocumentRequest> | undefined): boolean {
+    return proto3.util.equals(DiffDocumentRequest, a, b);
   }
 }
 
 /**
- * Request to create a Ref.
+ * Differences between two versions of a document.
  *
- * @generated from message com.seed.documents.v3alpha.CreateRefRequest
+ * @generated from message com.seed.documents.v3alpha.DiffDocumentResponse
  */
-export class CreateRefRequest extends Message<CreateRefRequest> {
+export class DiffDocumentResponse extends Message<DiffDocumentResponse> {
   /**
-   * Required. The ID of the account/space in which to create the Ref.
+   * The version compared from.
    *
-   * @generated from field: string account = 1;
+   * @generated from field: string base_version = 1;
    */
-  account = "";
+  baseVersion = "";
 
   /**
-   * Required. Path of the new Ref.
-   * Empty string means root document.
+   * The version compared to.
    *
-   * @generated from field: string path = 2;
+   * @generated from field: string target_version = 2;
    */
-  path = "";
+  targetVersion = "";
 
   /**
-   * Required. Target for the new Ref.
+   * Changed metadata attributes.
    *
-   * @generated from field: com.seed.documents.v3alpha.RefTarget target = 3;
+   * @generated from field: repeated com.seed.documents.v3alpha.AttributeDiff metadata = 3;
    */
-  target?: RefTarget;
+  metadata: AttributeDiff[] = [];
 
   /**
-   * Required. Name of the signing key to use for signing the Ref.
+   * Blocks that differ between the versions,
+   * in the order they appear in the target version, followed by the removed blocks.
    *
-   * @generated from field: string signing_key_name = 4;
+   * @generated from field: repeated com.seed.documents.v3alpha.BlockDiff blocks = 4;
    */
-  signingKeyName = "";
+  blocks: BlockDiff[] = [];
+
+  constructor(data?: PartialMessage<DiffDocumentResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.DiffDocumentResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "base_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "target_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "metadata", kind: "message", T: AttributeDiff, repeated: true },
+    { no: 4, name: "blocks", kind: "message", T: BlockDiff, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DiffDocumentResponse {
+    return new DiffDocumentResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DiffDocumentResponse {
+    return new DiffDocumentResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DiffDocumentResponse {
+    return new DiffDocumentResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DiffDocumentResponse | PlainMessage<DiffDocumentResponse> | undefined, b: DiffDocumentResponse | PlainMessage<DiffDocumentResponse> | undefined): boolean {
+    return proto3.util.equals(DiffDocumentResponse, a, b);
+  }
+}
 
+/**
+ * Difference of a single block between two versions.
+ *
+ * @generated from message com.seed.documents.v3alpha.BlockDiff
+ */
+export class BlockDiff extends Message<BlockDiff> {
   /**
-   * Optional. ID of the Capability blob that grants the necessary rights to the signin key
-   * to write Refs for the requested account + path.
+   * ID of the block.
    *
-   * @generated from field: string capability = 5;
+   * @generated from field: string block_id = 1;
    */
-  capability = "";
+  blockId = "";
 
   /**
-   * Optional. A timestamp of the Ref blob.
-   * If not specified the server will use the current time.
-   * The provided timestamp will be rounded to the nearest millisecond,
-   * so the final timestamp in the resulting Ref blob may not be exactly as provided.
+   * Kind of the difference.
    *
-   * @generated from field: google.protobuf.Timestamp timestamp = 6;
+   * @generated from field: com.seed.documents.v3alpha.BlockDiffKind kind = 2;
    */
-  timestamp?: Timestamp;
+  kind = BlockDiffKind.UNSPECIFIED;
 
   /**
-   * Optional. The generation number for the Ref.
-   * If not specified, the server will use the generation number of the most recent generation,
-   * or will create a fresh generation number if there are no existing Refs for this path.
+   * The block in the base version. Empty for added blocks.
    *
-   * @generated from field: int64 generation = 7;
+   * @generated from field: com.seed.documents.v3alpha.Block base_block = 3;
    */
-  generation = protoInt64.zero;
+  baseBlock?: Block;
 
   /**
-   * Output only. Visibility of the document.
+   * The block in the target version. Empty for removed blocks.
    *
-   * @generated from field: com.seed.documents.v3alpha.ResourceVisibility visibility = 8;
+   * @generated from field: com.seed.documents.v3alpha.Block target_block = 4;
    */
-  visibility = ResourceVisibility.UNSPECIFIED;
+  targetBlock?: Block;
 
-  constructor(data?: PartialMessage<CreateRefRequest>) {
+  /**
+   * Whether the block has a different parent or position among its siblings.
+   *
+   * @generated from field: bool moved = 5;
+   */
+  moved = false;
+
+  /**
+   * Parent block of the block in the base version. Empty for top-level blocks.
+   *
+   * @generated from field: string base_parent = 6;
+   */
+  baseParent = "";
+
+  /**
+   * Parent block of the block in the target version. Empty for top-level blocks.
+   *
+   * @generated from field: string target_parent = 7;
+   */
+  targetParent = "";
+
+  /**
+   * Position of the block among its siblings in the base version.
+   *
+   * @generated from field: int32 base_index = 8;
+   */
+  baseIndex = 0;
+
+  /**
+   * Position of the block among its siblings in the target version.
+   *
+   * @generated from field: int32 target_index = 9;
+   */
+  targetIndex = 0;
+
+  /**
+   * Whether the content of the block is different (type, text, link, attributes or annotations).
+   *
+   * @generated from field: bool modified = 10;
+   */
+  modified = false;
+
+  /**
+   * Character-level difference of the block text.
+   * Only set when the text is different.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.TextDiff text = 11;
+   */
+  text: TextDiff[] = [];
+
+  /**
+   * Changed fields and attributes of the block.
+   * Type and link are reported as top-level keys, attributes under the "attributes" key.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.AttributeDiff attributes = 12;
+   */
+  attributes: AttributeDiff[] = [];
+
+  /**
+   * Whether the annotations of the block are different.
+   *
+   * @generated from field: bool annotations_changed = 13;
+   */
+  annotationsChanged = false;
+
+  constructor(data?: PartialMessage<BlockDiff>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "com.seed.documents.v3alpha.CreateRefRequest";
+  static readonly typeName = "com.seed.documents.v3alpha.BlockDiff";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 3, name: "target", kind: "message", T: RefTarget },
-    { no: 4, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 5, name: "capability", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 6, name: "timestamp", kind: "message", T: Timestamp },
-    { no: 7, name: "generation", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
-    { no: 8, name: "visibility", kind: "enum", T: proto3.getEnumType(ResourceVisibility) },
+    { no: 1, name: "block_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "kind", kind: "enum", T: proto3.getEnumType(BlockDiffKind) },
+    { no: 3, name: "base_block", kind: "message", T: Block },
+    { no: 4, name: "target_block", kind: "message", T: Block },
+    { no: 5, name: "moved", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 6, name: "base_parent", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "target_parent", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "base_index", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 9, name: "target_index", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 10, name: "modified", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 11, name: "text", kind: "message", T: TextDiff, repeated: true },
+    { no: 12, name: "attributes", kind: "message", T: AttributeDiff, repeated: true },
+    { no: 13, name: "annotations_changed", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateRefRequest {
-    return new CreateRefRequest().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): BlockDiff {
+    return new BlockDiff().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateRefRequest {
-    return new CreateRefRequest().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): BlockDiff {
+    return new BlockDiff().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateRefRequest {
-    return new CreateRefRequest().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): BlockDiff {
+    return new BlockDiff().fromJsonString(jsonString, options);
   }
 
-  static equals(a: CreateRefRequest | PlainMessage<CreateRefRequest> | undefined, b: CreateRefRequest | PlainMessage<CreateRefRequest> | undefined): boolean {
-    return proto3.util.equals(CreateRefRequest, a, b);
+  static equals(a: BlockDiff | PlainMessage<BlockDiff> | undefined, b: BlockDiff | PlainMessage<BlockDiff> | undefined): boolean {
+    return proto3.util.equals(BlockDiff, a, b);
   }
 }
 
 /**
- * Request to get a Ref by ID.
+ * Segment of a text difference.
+ * Concatenating the equal and deleted segments gives the base text,
+ * and concatenating the equal and inserted segments gives the target text.
  *
- * @generated from message com.seed.documents.v3alpha.GetRefRequest
+ * @generated from message com.seed.documents.v3alpha.TextDiff
  */
-export class GetRefRequest extends Message<GetRefRequest> {
+export class TextDiff extends Message<TextDiff> {
   /**
-   * Required. ID of the Ref blob to retrieve.
+   * Kind of the segment.
    *
-   * @generated from field: string id = 1;
+   * @generated from field: com.seed.documents.v3alpha.TextDiffKind kind = 1;
    */
-  id = "";
+  kind = TextDiffKind.UNSPECIFIED;
 
-  constructor(data?: PartialMessage<GetRefRequest>) {
+  /**
+   * Text of the segment.
+   *
+   * @generated from field: string text = 2;
+   */
+  text = "";
+
+  constructor(data?: PartialMessage<TextDiff>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "com.seed.documents.v3alpha.GetRefRequest";
+  static readonly typeName = "com.seed.documents.v3alpha.TextDiff";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 1, name: "kind", kind: "enum", T: proto3.getEnumType(TextDiffKind) },
+    { no: 2, name: "text", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetRefRequest {
-    return new GetRefRequest().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): TextDiff {
+    return new TextDiff().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetRefRequest {
-    return new GetRefRequest().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): TextDiff {
+    return new TextDiff().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetRefRequest {
-    return new GetRefRequest().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): TextDiff {
+    return new TextDiff().fromJsonString(jsonString, options);
   }
 
-  static equals(a: GetRefRequest | PlainMessage<GetRefRequest> | undefined, b: GetRefRequest | PlainMessage<GetRefRequest> | undefined): boolean {
-    return proto3.util.equals(GetRefRequest, a, b);
+  static equals(a: TextDiff | PlainMessage<TextDiff> | undefined, b: TextDiff | PlainMessage<TextDiff> | undefined): boolean {
+    return proto3.util.equals(TextDiff, a, b);
   }
 }
 
 /**
- * Request to list Refs for a document.
+ * Difference of a single attribute value.
  *
- * @generated from message com.seed.documents.v3alpha.ListRefsRequest
+ * @generated from message com.seed.documents.v3alpha.AttributeDiff
  */
-export class ListRefsRequest extends Message<ListRefsRequest> {
-  /**
-   * Required. Account ID where the document is located.
-   *
-   * @generated from field: string account = 1;
-   */
-  account = "";
-
+export class AttributeDiff extends Message<AttributeDiff> {
   /**
-   * Required. Path of the document.
-   * Empty string means root document.
+   * Path of the attribute.
    *
-   * @generated from field: string path = 2;
+   * @generated from field: repeated string key = 1;
    */
-  path = "";
+  key: string[] = [];
 
   /**
-   * Optional. Number of items to return per page.
-   * Pagination parameters are currently accepted but ignored.
+   * Value in the base version. Empty if the attribute was added.
    *
-   * @generated from field: int32 page_size = 3;
+   * @generated from field: google.protobuf.Value base_value = 2;
    */
-  pageSize = 0;
+  baseValue?: Value;
 
   /**
-   * Optional. Token from a previous ListRefsResponse.next_page_token.
-   * Pagination parameters are currently accepted but ignored.
+   * Value in the target version. Empty if the attribute was removed.
    *
-   * @generated from field: string page_token = 4;
+   * @generated from field: google.protobuf.Value target_value = 3;
    */
-  pageToken = "";
+  targetValue?: Value;
 
-  constructor(data?: PartialMessage<ListRefsRequest>) {
+  constructor(data?: PartialMessage<AttributeDiff>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "com.seed.documents.v3alpha.ListRefsRequest";
+  static readonly typeName = "com.seed.documents.v3alpha.AttributeDiff";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 3, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
-    { no: 4, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 1, name: "key", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 2, name: "base_value", kind: "message", T: Value },
+    { no: 3, name: "target_value", kind: "message", T: Value },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListRefsRequest {
-    return new ListRefsRequest().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AttributeDiff {
+    return new AttributeDiff().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListRefsRequest {
-    return new ListRefsRequest().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AttributeDiff {
+    return new AttributeDiff().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListRefsRequest {
-    return new ListRefsRequest().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AttributeDiff {
+    return new AttributeDiff().fromJsonString(jsonString, options);
   }
 
-  static equals(a: ListRefsRequest | PlainMessage<ListRefsRequest> | undefined, b: ListRefsRequest | PlainMessage<ListRefsRequest> | undefined): boolean {
-    return proto3.util.equals(ListRefsRequest, a, b);
+  static equals(a: AttributeDiff | PlainMessage<AttributeDiff> | undefined, b: AttributeDiff | PlainMessage<AttributeDiff> | undefined): boolean {
+    return proto3.util.equals(AttributeDiff, a, b);
   }
 }
 
 /**
- * Response to list Refs for a document.
+ * Request to get the blame of a document.
  *
- * @generated from message com.seed.documents.v3alpha.ListRefsResponse
+ * @generated from message com.seed.documents.v3alpha.GetDocumentBlameRequest
  */
-export class ListRefsResponse extends Message<ListRefsResponse> {
+export class GetDocumentBlameRequest extends Message<GetDocumentBlameRequest> {
   /**
-   * Refs for the requested document.
+   * Required. ID of the account the document belongs to.
    *
-   * @generated from field: repeated com.seed.documents.v3alpha.Ref refs = 1;
+   * @generated from field: string account = 1;
    */
-  refs: Ref[] = [];
+  account = "";
+
+  /**
+   * Required. Path of the document.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * Optional. Version of the document. Defaults to the latest version.
+   *
+   * @generated from field: string version = 3;
+   */
+  version = "";
+
+  constructor(data?: PartialMessage<GetDocumentBlameRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.GetDocumentBlameRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetDocumentBlameRequest {
+    return new GetDocumentBlameRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetDocumentBlameRequest {
+    return new GetDocumentBlameRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetDocumentBlameRequest {
+    return new GetDocumentBlameRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetDocumentBlameRequest | PlainMessage<GetDocumentBlameRequest> | undefined, b: GetDocumentBlameRequest | PlainMessage<GetDocumentBlameRequest> | undefined): boolean {
+    return proto3.util.equals(GetDocumentBlameRequest, a, b);
+  }
+}
+
+/**
+ * Attribution of the blocks of a document version.
+ *
+ * @generated from message com.seed.documents.v3alpha.DocumentBlame
+ */
+export class DocumentBlame extends Message<DocumentBlame> {
+  /**
+   * Version of the document.
+   *
+   * @generated from field: string version = 1;
+   */
+  version = "";
+
+  /**
+   * Blocks in the order they appear in the document, followed by the detached blocks.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.BlockBlame blocks = 2;
+   */
+  blocks: BlockBlame[] = [];
+
+  constructor(data?: PartialMessage<DocumentBlame>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.DocumentBlame";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "blocks", kind: "message", T: BlockBlame, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DocumentBlame {
+    return new DocumentBlame().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DocumentBlame {
+    return new DocumentBlame().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DocumentBlame {
+    return new DocumentBlame().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DocumentBlame | PlainMessage<DocumentBlame> | undefined, b: DocumentBlame | PlainMessage<DocumentBlame> | undefined): boolean {
+    return proto3.util.equals(DocumentBlame, a, b);
+  }
+}
+
+/**
+ * Attribution of a single block.
+ *
+ * @generated from message com.seed.documents.v3alpha.BlockBlame
+ */
+export class BlockBlame extends Message<BlockBlame> {
+  /**
+   * ID of the block.
+   *
+   * @generated from field: string block_id = 1;
+   */
+  blockId = "";
+
+  /**
+   * Current revision of the block, same as in the Block message.
+   *
+   * @generated from field: string revision = 2;
+   */
+  revision = "";
+
+  /**
+   * The change that set the current content of the block.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Attribution content = 3;
+   */
+  content?: Attribution;
+
+  /**
+   * The change that last moved the block. Empty for detached blocks which were never moved.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Attribution position = 4;
+   */
+  position?: Attribution;
+
+  /**
+   * The changes that last modified each of the block fields and attributes, sorted by key.
+   * Type and link are reported as top-level keys, attributes under the "attributes" key.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.AttributeBlame attributes = 5;
+   */
+  attributes: AttributeBlame[] = [];
+
+  /**
+   * The changes that wrote each range of the block text.
+   * Ranges are contiguous and cover the entire text.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.TextBlame text = 6;
+   */
+  text: TextBlame[] = [];
+
+  constructor(data?: PartialMessage<BlockBlame>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.BlockBlame";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "block_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "revision", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "content", kind: "message", T: Attribution },
+    { no: 4, name: "position", kind: "message", T: Attribution },
+    { no: 5, name: "attributes", kind: "message", T: AttributeBlame, repeated: true },
+    { no: 6, name: "text", kind: "message", T: TextBlame, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): BlockBlame {
+    return new BlockBlame().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): BlockBlame {
+    return new BlockBlame().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): BlockBlame {
+    return new BlockBlame().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: BlockBlame | PlainMessage<BlockBlame> | undefined, b: BlockBlame | PlainMessage<BlockBlame> | undefined): boolean {
+    return proto3.util.equals(BlockBlame, a, b);
+  }
+}
+
+/**
+ * Identifies the change that produced some part of a document.
+ *
+ * @generated from message com.seed.documents.v3alpha.Attribution
+ */
+export class Attribution extends Message<Attribution> {
+  /**
+   * CID of the change.
+   *
+   * @generated from field: string change_id = 1;
+   */
+  changeId = "";
+
+  /**
+   * Author of the change.
+   *
+   * @generated from field: string author = 2;
+   */
+  author = "";
+
+  /**
+   * Time when the change was created (as claimed by the author).
+   *
+   * @generated from field: google.protobuf.Timestamp create_time = 3;
+   */
+  createTime?: Timestamp;
+
+  constructor(data?: PartialMessage<Attribution>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.Attribution";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "change_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "author", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "create_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Attribution {
+    return new Attribution().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Attribution {
+    return new Attribution().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Attribution {
+    return new Attribution().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Attribution | PlainMessage<Attribution> | undefined, b: Attribution | PlainMessage<Attribution> | undefined): boolean {
+    return proto3.util.equals(Attribution, a, b);
+  }
+}
+
+/**
+ * Attribution of a single attribute of a block.
+ *
+ * @generated from message com.seed.documents.v3alpha.AttributeBlame
+ */
+export class AttributeBlame extends Message<AttributeBlame> {
+  /**
+   * Path of the attribute.
+   *
+   * @generated from field: repeated string key = 1;
+   */
+  key: string[] = [];
+
+  /**
+   * The change that last modified the attribute.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Attribution attribution = 2;
+   */
+  attribution?: Attribution;
+
+  constructor(data?: PartialMessage<AttributeBlame>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.AttributeBlame";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "key", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 2, name: "attribution", kind: "message", T: Attribution },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AttributeBlame {
+    return new AttributeBlame().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AttributeBlame {
+    return new AttributeBlame().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AttributeBlame {
+    return new AttributeBlame().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AttributeBlame | PlainMessage<AttributeBlame> | undefined, b: AttributeBlame | PlainMessage<AttributeBlame> | undefined): boolean {
+    return proto3.util.equals(AttributeBlame, a, b);
+  }
+}
+
+/**
+ * Attribution of a range of block text.
+ *
+ * @generated from message com.seed.documents.v3alpha.TextBlame
+ */
+export class TextBlame extends Message<TextBlame> {
+  /**
+   * Start offset of the range in Unicode code points, like annotation spans.
+   *
+   * @generated from field: int32 start = 1;
+   */
+  start = 0;
+
+  /**
+   * End offset of the range (exclusive).
+   *
+   * @generated from field: int32 end = 2;
+   */
+  end = 0;
+
+  /**
+   * The change that wrote the text in the range.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Attribution attribution = 3;
+   */
+  attribution?: Attribution;
+
+  constructor(data?: PartialMessage<TextBlame>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.TextBlame";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "start", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 2, name: "end", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "attribution", kind: "message", T: Attribution },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): TextBlame {
+    return new TextBlame().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): TextBlame {
+    return new TextBlame().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): TextBlame {
+    return new TextBlame().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: TextBlame | PlainMessage<TextBlame> | undefined, b: TextBlame | PlainMessage<TextBlame> | undefined): boolean {
+    return proto3.util.equals(TextBlame, a, b);
+  }
+}
+
+/**
+ * Request to revert a document to a previous version.
+ *
+ * @generated from message com.seed.documents.v3alpha.RevertDocumentRequest
+ */
+export class RevertDocumentRequest extends Message<RevertDocumentRequest> {
+  /**
+   * Required. ID of the account the document belongs to.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Required. Path of the document.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * Required. Name of the signing key to use for signing the new change.
+   *
+   * @generated from field: string signing_key_name = 3;
+   */
+  signingKeyName = "";
+
+  /**
+   * Required. Version of the document to restore.
+   *
+   * @generated from field: string target_version = 4;
+   */
+  targetVersion = "";
+
+  /**
+   * Optional. Version to create the new change on top of. Defaults to the latest version.
+   *
+   * @generated from field: string base_version = 5;
+   */
+  baseVersion = "";
+
+  /**
+   * Optional. IDs of the blocks to restore. Their content and position are restored,
+   * and the ones that didn't exist in the target version are deleted.
+   * If empty, the entire document is restored, including the metadata.
+   *
+   * @generated from field: repeated string block_ids = 6;
+   */
+  blockIds: string[] = [];
+
+  constructor(data?: PartialMessage<RevertDocumentRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.RevertDocumentRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "target_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "base_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "block_ids", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RevertDocumentRequest {
+    return new RevertDocumentRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RevertDocumentRequest {
+    return new RevertDocumentRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RevertDocumentRequest {
+    return new RevertDocumentRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RevertDocumentRequest | PlainMessage<RevertDocumentRequest> | undefined, b: RevertDocumentRequest | PlainMessage<RevertDocumentRequest> | undefined): boolean {
+    return proto3.util.equals(RevertDocumentRequest, a, b);
+  }
+}
+
+/**
+ * Response with the result of reverting a document.
+ *
+ * @generated from message com.seed.documents.v3alpha.RevertDocumentResponse
+ */
+export class RevertDocumentResponse extends Message<RevertDocumentResponse> {
+  /**
+   * CID of the new change.
+   *
+   * @generated from field: string change_id = 1;
+   */
+  changeId = "";
+
+  /**
+   * Ref pointing to the new change.
+   * For the root document of a multi-signature organization it may be partially signed,
+   * and must be co-signed by other members before it's published.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Ref ref = 2;
+   */
+  ref?: Ref;
+
+  /**
+   * The document after the revert.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Document document = 3;
+   */
+  document?: Document;
+
+  constructor(data?: PartialMessage<RevertDocumentResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.RevertDocumentResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "change_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "ref", kind: "message", T: Ref },
+    { no: 3, name: "document", kind: "message", T: Document },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RevertDocumentResponse {
+    return new RevertDocumentResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RevertDocumentResponse {
+    return new RevertDocumentResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RevertDocumentResponse {
+    return new RevertDocumentResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RevertDocumentResponse | PlainMessage<RevertDocumentResponse> | undefined, b: RevertDocumentResponse | PlainMessage<RevertDocumentResponse> | undefined): boolean {
+    return proto3.util.equals(RevertDocumentResponse, a, b);
+  }
+}
+
+/**
+ * Request to create or update a branch of a document.
+ *
+ * @generated from message com.seed.documents.v3alpha.CreateBranchRequest
+ */
+export class CreateBranchRequest extends Message<CreateBranchRequest> {
+  /**
+   * Required. ID of the account the document belongs to.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Required. Path of the document.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * Required. Name of the branch.
+   * Names can use ASCII letters, digits, dots, dashes and underscores, up to 64 characters,
+   * and must start with a letter or a digit. The name "main" is reserved for the published version.
+   *
+   * @generated from field: string branch = 3;
+   */
+  branch = "";
+
+  /**
+   * Required. Name of the signing key to use for signing the branch Ref.
+   *
+   * @generated from field: string signing_key_name = 4;
+   */
+  signingKeyName = "";
+
+  /**
+   * Optional. Version the branch must point to.
+   * Defaults to the latest published version, which is how new branches are started.
+   *
+   * @generated from field: string version = 5;
+   */
+  version = "";
+
+  /**
+   * Optional. Visibility of the branch. Defaults to public.
+   * Private branches are only synced between the devices and the writers of the account,
+   * even if the document itself is public.
+   *
+   * @generated from field: com.seed.documents.v3alpha.ResourceVisibility visibility = 6;
+   */
+  visibility = ResourceVisibility.UNSPECIFIED;
+
+  constructor(data?: PartialMessage<CreateBranchRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.CreateBranchRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "branch", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "visibility", kind: "enum", T: proto3.getEnumType(ResourceVisibility) },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateBranchRequest {
+    return new CreateBranchRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateBranchRequest {
+    return new CreateBranchRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateBranchRequest {
+    return new CreateBranchRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateBranchRequest | PlainMessage<CreateBranchRequest> | undefined, b: CreateBranchRequest | PlainMessage<CreateBranchRequest> | undefined): boolean {
+    return proto3.util.equals(CreateBranchRequest, a, b);
+  }
+}
+
+/**
+ * A named branch of a document.
+ *
+ * @generated from message com.seed.documents.v3alpha.Branch
+ */
+export class Branch extends Message<Branch> {
+  /**
+   * Name of the branch.
+   *
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * ID of the account the document belongs to.
+   *
+   * @generated from field: string account = 2;
+   */
+  account = "";
+
+  /**
+   * Path of the document.
+   *
+   * @generated from field: string path = 3;
+   */
+  path = "";
+
+  /**
+   * Genesis change of the document.
+   *
+   * @generated from field: string genesis = 4;
+   */
+  genesis = "";
+
+  /**
+   * Version the branch points to.
+   *
+   * @generated from field: string version = 5;
+   */
+  version = "";
+
+  /**
+   * Author of the latest Ref of the branch.
+   *
+   * @generated from field: string author = 6;
+   */
+  author = "";
+
+  /**
+   * Time of the latest Ref of the branch.
+   *
+   * @generated from field: google.protobuf.Timestamp update_time = 7;
+   */
+  updateTime?: Timestamp;
+
+  /**
+   * Visibility of the branch.
+   *
+   * @generated from field: com.seed.documents.v3alpha.ResourceVisibility visibility = 8;
+   */
+  visibility = ResourceVisibility.UNSPECIFIED;
+
+  /**
+   * ID of the latest Ref of the branch.
+   *
+   * @generated from field: string ref_id = 9;
+   */
+  refId = "";
+
+  constructor(data?: PartialMessage<Branch>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.Branch";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "genesis", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "author", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "update_time", kind: "message", T: Timestamp },
+    { no: 8, name: "visibility", kind: "enum", T: proto3.getEnumType(ResourceVisibility) },
+    { no: 9, name: "ref_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Branch {
+    return new Branch().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Branch {
+    return new Branch().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Branch {
+    return new Branch().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Branch | PlainMessage<Branch> | undefined, b: Branch | PlainMessage<Branch> | undefined): boolean {
+    return proto3.util.equals(Branch, a, b);
+  }
+}
+
+/**
+ * Request to list branches of a document.
+ *
+ * @generated from message com.seed.documents.v3alpha.ListBranchesRequest
+ */
+export class ListBranchesRequest extends Message<ListBranchesRequest> {
+  /**
+   * Required. ID of the account the document belongs to.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Required. Path of the document.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  constructor(data?: PartialMessage<ListBranchesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ListBranchesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListBranchesRequest {
+    return new ListBranchesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListBranchesRequest {
+    return new ListBranchesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListBranchesRequest {
+    return new ListBranchesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListBranchesRequest | PlainMessage<ListBranchesRequest> | undefined, b: ListBranchesRequest | PlainMessage<ListBranchesRequest> | undefined): boolean {
+    return proto3.util.equals(ListBranchesRequest, a, b);
+  }
+}
+
+/**
+ * Response with the branches of a document.
+ *
+ * @generated from message com.seed.documents.v3alpha.ListBranchesResponse
+ */
+export class ListBranchesResponse extends Message<ListBranchesResponse> {
+  /**
+   * Branches sorted by name.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.Branch branches = 1;
+   */
+  branches: Branch[] = [];
+
+  constructor(data?: PartialMessage<ListBranchesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ListBranchesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "branches", kind: "message", T: Branch, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListBranchesResponse {
+    return new ListBranchesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListBranchesResponse {
+    return new ListBranchesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListBranchesResponse {
+    return new ListBranchesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListBranchesResponse | PlainMessage<ListBranchesResponse> | undefined, b: ListBranchesResponse | PlainMessage<ListBranchesResponse> | undefined): boolean {
+    return proto3.util.equals(ListBranchesResponse, a, b);
+  }
+}
+
+/**
+ * Request to compare a branch with the published version of the document.
+ *
+ * @generated from message com.seed.documents.v3alpha.DiffBranchRequest
+ */
+export class DiffBranchRequest extends Message<DiffBranchRequest> {
+  /**
+   * Required. ID of the account the document belongs to.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Required. Path of the document.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * Required. Name of the branch. It's the target of the resulting diff,
+   * and the published version is the base.
+   *
+   * @generated from field: string branch = 3;
+   */
+  branch = "";
+
+  constructor(data?: PartialMessage<DiffBranchRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.DiffBranchRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "branch", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DiffBranchRequest {
+    return new DiffBranchRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DiffBranchRequest {
+    return new DiffBranchRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DiffBranchRequest {
+    return new DiffBranchRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DiffBranchRequest | PlainMessage<DiffBranchRequest> | undefined, b: DiffBranchRequest | PlainMessage<DiffBranchRequest> | undefined): boolean {
+    return proto3.util.equals(DiffBranchRequest, a, b);
+  }
+}
+
+/**
+ * Request to merge a branch into the published version of the document.
+ *
+ * @generated from message com.seed.documents.v3alpha.MergeBranchRequest
+ */
+export class MergeBranchRequest extends Message<MergeBranchRequest> {
+  /**
+   * Required. ID of the account the document belongs to.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Required. Path of the document.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * Required. Name of the branch to merge.
+   *
+   * @generated from field: string branch = 3;
+   */
+  branch = "";
+
+  /**
+   * Required. Name of the signing key to use for signing the new Ref.
+   *
+   * @generated from field: string signing_key_name = 4;
+   */
+  signingKeyName = "";
+
+  constructor(data?: PartialMessage<MergeBranchRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.MergeBranchRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "branch", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): MergeBranchRequest {
+    return new MergeBranchRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): MergeBranchRequest {
+    return new MergeBranchRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): MergeBranchRequest {
+    return new MergeBranchRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: MergeBranchRequest | PlainMessage<MergeBranchRequest> | undefined, b: MergeBranchRequest | PlainMessage<MergeBranchRequest> | undefined): boolean {
+    return proto3.util.equals(MergeBranchRequest, a, b);
+  }
+}
+
+/**
+ * Request to move a tree of documents.
+ *
+ * @generated from message com.seed.documents.v3alpha.MoveDocumentTreeRequest
+ */
+export class MoveDocumentTreeRequest extends Message<MoveDocumentTreeRequest> {
+  /**
+   * Required. ID of the account the documents belong to.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Required. Path of the document tree to move.
+   * The document at this path, if any, and all the documents under it are moved.
+   *
+   * @generated from field: string source_path = 2;
+   */
+  sourcePath = "";
+
+  /**
+   * Required. New path for the document tree.
+   * It must not be inside the source tree, and no documents must exist at the new paths.
+   *
+   * @generated from field: string target_path = 3;
+   */
+  targetPath = "";
+
+  /**
+   * Required. Name of the signing key to use for signing the new Refs.
+   *
+   * @generated from field: string signing_key_name = 4;
+   */
+  signingKeyName = "";
+
+  /**
+   * Optional. Whether to update the links to the moved documents
+   * in the documents of the same account.
+   *
+   * @generated from field: bool rewrite_links = 5;
+   */
+  rewriteLinks = false;
+
+  constructor(data?: PartialMessage<MoveDocumentTreeRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.MoveDocumentTreeRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "source_path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "target_path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "rewrite_links", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): MoveDocumentTreeRequest {
+    return new MoveDocumentTreeRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): MoveDocumentTreeRequest {
+    return new MoveDocumentTreeRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): MoveDocumentTreeRequest {
+    return new MoveDocumentTreeRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: MoveDocumentTreeRequest | PlainMessage<MoveDocumentTreeRequest> | undefined, b: MoveDocumentTreeRequest | PlainMessage<MoveDocumentTreeRequest> | undefined): boolean {
+    return proto3.util.equals(MoveDocumentTreeRequest, a, b);
+  }
+}
+
+/**
+ * Response with the result of moving a tree of documents.
+ *
+ * @generated from message com.seed.documents.v3alpha.MoveDocumentTreeResponse
+ */
+export class MoveDocumentTreeResponse extends Message<MoveDocumentTreeResponse> {
+  /**
+   * Moved documents sorted by their old path.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.MovedDocument moved_documents = 1;
+   */
+  movedDocuments: MovedDocument[] = [];
+
+  /**
+   * Paths of the documents whose links were updated to point to the new paths.
+   *
+   * @generated from field: repeated string rewritten_documents = 2;
+   */
+  rewrittenDocuments: string[] = [];
+
+  /**
+   * Paths of the documents linking to the moved documents
+   * that couldn't be updated, because the signing key has no write access to them.
+   *
+   * @generated from field: repeated string skipped_documents = 3;
+   */
+  skippedDocuments: string[] = [];
+
+  /**
+   * Partially signed Refs of the documents whose links were updated,
+   * but which need more signatures to be published, i.e. the root document of a multi-signature organization.
+   * They must be passed to other members of the organization with the CreateRef API.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.Ref partial_refs = 4;
+   */
+  partialRefs: Ref[] = [];
+
+  constructor(data?: PartialMessage<MoveDocumentTreeResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.MoveDocumentTreeResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "moved_documents", kind: "message", T: MovedDocument, repeated: true },
+    { no: 2, name: "rewritten_documents", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 3, name: "skipped_documents", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 4, name: "partial_refs", kind: "message", T: Ref, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): MoveDocumentTreeResponse {
+    return new MoveDocumentTreeResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): MoveDocumentTreeResponse {
+    return new MoveDocumentTreeResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): MoveDocumentTreeResponse {
+    return new MoveDocumentTreeResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: MoveDocumentTreeResponse | PlainMessage<MoveDocumentTreeResponse> | undefined, b: MoveDocumentTreeResponse | PlainMessage<MoveDocumentTreeResponse> | undefined): boolean {
+    return proto3.util.equals(MoveDocumentTreeResponse, a, b);
+  }
+}
+
+/**
+ * Describes a document moved by MoveDocumentTree.
+ *
+ * @generated from message com.seed.documents.v3alpha.MovedDocument
+ */
+export class MovedDocument extends Message<MovedDocument> {
+  /**
+   * Old path of the document.
+   *
+   * @generated from field: string source_path = 1;
+   */
+  sourcePath = "";
+
+  /**
+   * New path of the document.
+   *
+   * @generated from field: string target_path = 2;
+   */
+  targetPath = "";
+
+  /**
+   * The Ref published at the new path.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Ref ref = 3;
+   */
+  ref?: Ref;
+
+  /**
+   * The redirect Ref published at the old path.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Ref redirect = 4;
+   */
+  redirect?: Ref;
+
+  constructor(data?: PartialMessage<MovedDocument>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.MovedDocument";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "source_path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "target_path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "ref", kind: "message", T: Ref },
+    { no: 4, name: "redirect", kind: "message", T: Ref },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): MovedDocument {
+    return new MovedDocument().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): MovedDocument {
+    return new MovedDocument().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): MovedDocument {
+    return new MovedDocument().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: MovedDocument | PlainMessage<MovedDocument> | undefined, b: MovedDocument | PlainMessage<MovedDocument> | undefined): boolean {
+    return proto3.util.equals(MovedDocument, a, b);
+  }
+}
+
+/**
+ * Request to copy a document.
+ *
+ * @generated from message com.seed.documents.v3alpha.CopyDocumentRequest
+ */
+export class CopyDocumentRequest extends Message<CopyDocumentRequest> {
+  /**
+   * Required. ID of the account the source document belongs to.
+   *
+   * @generated from field: string source_account = 1;
+   */
+  sourceAccount = "";
+
+  /**
+   * Required. Path of the source document.
+   *
+   * @generated from field: string source_path = 2;
+   */
+  sourcePath = "";
+
+  /**
+   * Optional. Version of the source document to copy.
+   * Latest version is used by default.
+   * Documents under the source document are always copied at their latest versions.
+   *
+   * @generated from field: string source_version = 3;
+   */
+  sourceVersion = "";
+
+  /**
+   * Optional. ID of the account to create the copy in.
+   * The source account is used by default.
+   *
+   * @generated from field: string target_account = 4;
+   */
+  targetAccount = "";
+
+  /**
+   * Required. Path of the new document.
+   * No document must exist at this path, or at any of the paths of the copied children.
+   *
+   * @generated from field: string target_path = 5;
+   */
+  targetPath = "";
+
+  /**
+   * Required. Name of the signing key to use for signing the new documents.
+   *
+   * @generated from field: string signing_key_name = 6;
+   */
+  signingKeyName = "";
+
+  /**
+   * Optional. Whether to also copy all the documents under the source document.
+   *
+   * @generated from field: bool recursive = 7;
+   */
+  recursive = false;
+
+  constructor(data?: PartialMessage<CopyDocumentRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.CopyDocumentRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "source_account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "source_path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "source_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "target_account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "target_path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "recursive", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CopyDocumentRequest {
+    return new CopyDocumentRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CopyDocumentRequest {
+    return new CopyDocumentRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CopyDocumentRequest {
+    return new CopyDocumentRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CopyDocumentRequest | PlainMessage<CopyDocumentRequest> | undefined, b: CopyDocumentRequest | PlainMessage<CopyDocumentRequest> | undefined): boolean {
+    return proto3.util.equals(CopyDocumentRequest, a, b);
+  }
+}
+
+/**
+ * Response with the result of copying documents.
+ *
+ * @generated from message com.seed.documents.v3alpha.CopyDocumentResponse
+ */
+export class CopyDocumentResponse extends Message<CopyDocumentResponse> {
+  /**
+   * Copied documents sorted by their source path, starting with the requested document.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.CopiedDocument copied_documents = 1;
+   */
+  copiedDocuments: CopiedDocument[] = [];
+
+  constructor(data?: PartialMessage<CopyDocumentResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.CopyDocumentResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "copied_documents", kind: "message", T: CopiedDocument, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CopyDocumentResponse {
+    return new CopyDocumentResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CopyDocumentResponse {
+    return new CopyDocumentResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CopyDocumentResponse {
+    return new CopyDocumentResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CopyDocumentResponse | PlainMessage<CopyDocumentResponse> | undefined, b: CopyDocumentResponse | PlainMessage<CopyDocumentResponse> | undefined): boolean {
+    return proto3.util.equals(CopyDocumentResponse, a, b);
+  }
+}
+
+/**
+ * Describes a document created by CopyDocument.
+ *
+ * @generated from message com.seed.documents.v3alpha.CopiedDocument
+ */
+export class CopiedDocument extends Message<CopiedDocument> {
+  /**
+   * Path of the source document.
+   *
+   * @generated from field: string source_path = 1;
+   */
+  sourcePath = "";
+
+  /**
+   * Version of the source document that was copied.
+   *
+   * @generated from field: string source_version = 2;
+   */
+  sourceVersion = "";
+
+  /**
+   * Path of the new document.
+   *
+   * @generated from field: string target_path = 3;
+   */
+  targetPath = "";
+
+  /**
+   * The Ref of the new document.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Ref ref = 4;
+   */
+  ref?: Ref;
+
+  constructor(data?: PartialMessage<CopiedDocument>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.CopiedDocument";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "source_path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "source_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "target_path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "ref", kind: "message", T: Ref },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CopiedDocument {
+    return new CopiedDocument().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CopiedDocument {
+    return new CopiedDocument().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CopiedDocument {
+    return new CopiedDocument().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CopiedDocument | PlainMessage<CopiedDocument> | undefined, b: CopiedDocument | PlainMessage<CopiedDocument> | undefined): boolean {
+    return proto3.util.equals(CopiedDocument, a, b);
+  }
+}
+
+/**
+ * Request to create a document from a template.
+ *
+ * @generated from message com.seed.documents.v3alpha.CreateFromTemplateRequest
+ */
+export class CreateFromTemplateRequest extends Message<CreateFromTemplateRequest> {
+  /**
+   * Required. ID of the account the template belongs to.
+   *
+   * @generated from field: string template_account = 1;
+   */
+  templateAccount = "";
+
+  /**
+   * Required. Path of the template.
+   *
+   * @generated from field: string template_path = 2;
+   */
+  templatePath = "";
+
+  /**
+   * Optional. Version of the template to use.
+   * Latest version is used by default.
+   *
+   * @generated from field: string template_version = 3;
+   */
+  templateVersion = "";
+
+  /**
+   * Optional. ID of the account to create the document in.
+   * The template account is used by default.
+   *
+   * @generated from field: string account = 4;
+   */
+  account = "";
+
+  /**
+   * Required. Path of the new document.
+   *
+   * @generated from field: string path = 5;
+   */
+  path = "";
+
+  /**
+   * Required. Name of the signing key to use for signing the new document.
+   *
+   * @generated from field: string signing_key_name = 6;
+   */
+  signingKeyName = "";
+
+  /**
+   * Optional. Values for the {{variable}} placeholders of the template.
+   * Placeholders without a value are kept as is.
+   *
+   * @generated from field: map<string, string> variables = 7;
+   */
+  variables: { [key: string]: string } = {};
+
+  /**
+   * Optional. Visibility of the new document. Public by default.
+   *
+   * @generated from field: com.seed.documents.v3alpha.ResourceVisibility visibility = 8;
+   */
+  visibility = ResourceVisibility.UNSPECIFIED;
+
+  constructor(data?: PartialMessage<CreateFromTemplateRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.CreateFromTemplateRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "template_account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "template_path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "template_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "variables", kind: "map", K: 9 /* ScalarType.STRING */, V: {kind: "scalar", T: 9 /* ScalarType.STRING */} },
+    { no: 8, name: "visibility", kind: "enum", T: proto3.getEnumType(ResourceVisibility) },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateFromTemplateRequest {
+    return new CreateFromTemplateRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateFromTemplateRequest {
+    return new CreateFromTemplateRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateFromTemplateRequest {
+    return new CreateFromTemplateRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateFromTemplateRequest | PlainMessage<CreateFromTemplateRequest> | undefined, b: CreateFromTemplateRequest | PlainMessage<CreateFromTemplateRequest> | undefined): boolean {
+    return proto3.util.equals(CreateFromTemplateRequest, a, b);
+  }
+}
+
+/**
+ * Request to update document's read status.
+ *
+ * @generated from message com.seed.documents.v3alpha.UpdateDocumentReadStatusRequest
+ */
+export class UpdateDocumentReadStatusRequest extends Message<UpdateDocumentReadStatusRequest> {
+  /**
+   * Required. ID of the account to update the document in.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Required. Path of the document to update.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * Required. New read status of the document.
+   *
+   * @generated from field: bool is_read = 3;
+   */
+  isRead = false;
+
+  /**
+   * Optional. Whether the status should be updated recursively for children of the path.
+   *
+   * @generated from field: bool is_recursive = 4;
+   */
+  isRecursive = false;
+
+  constructor(data?: PartialMessage<UpdateDocumentReadStatusRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.UpdateDocumentReadStatusRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "is_read", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 4, name: "is_recursive", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UpdateDocumentReadStatusRequest {
+    return new UpdateDocumentReadStatusRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UpdateDocumentReadStatusRequest {
+    return new UpdateDocumentReadStatusRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UpdateDocumentReadStatusRequest {
+    return new UpdateDocumentReadStatusRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: UpdateDocumentReadStatusRequest | PlainMessage<UpdateDocumentReadStatusRequest> | undefined, b: UpdateDocumentReadStatusRequest | PlainMessage<UpdateDocumentReadStatusRequest> | undefined): boolean {
+    return proto3.util.equals(UpdateDocumentReadStatusRequest, a, b);
+  }
+}
+
+/**
+ * Request to create a Ref.
+ *
+ * @generated from message com.seed.documents.v3alpha.CreateRefRequest
+ */
+export class CreateRefRequest extends Message<CreateRefRequest> {
+  /**
+   * Required. The ID of the account/space in which to create the Ref.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Required. Path of the new Ref.
+   * Empty string means root document.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * Required. Target for the new Ref.
+   *
+   * @generated from field: com.seed.documents.v3alpha.RefTarget target = 3;
+   */
+  target?: RefTarget;
+
+  /**
+   * Required. Name of the signing key to use for signing the Ref.
+   *
+   * @generated from field: string signing_key_name = 4;
+   */
+  signingKeyName = "";
+
+  /**
+   * Optional. ID of the Capability blob that grants the necessary rights to the signin key
+   * to write Refs for the requested account + path.
+   *
+   * @generated from field: string capability = 5;
+   */
+  capability = "";
+
+  /**
+   * Optional. A timestamp of the Ref blob.
+   * If not specified the server will use the current time.
+   * The provided timestamp will be rounded to the nearest millisecond,
+   * so the final timestamp in the resulting Ref blob may not be exactly as provided.
+   *
+   * @generated from field: google.protobuf.Timestamp timestamp = 6;
+   */
+  timestamp?: Timestamp;
+
+  /**
+   * Optional. The generation number for the Ref.
+   * If not specified, the server will use the generation number of the most recent generation,
+   * or will create a fresh generation number if there are no existing Refs for this path.
+   *
+   * @generated from field: int64 generation = 7;
+   */
+  generation = protoInt64.zero;
+
+  /**
+   * Output only. Visibility of the document.
+   *
+   * @generated from field: com.seed.documents.v3alpha.ResourceVisibility visibility = 8;
+   */
+  visibility = ResourceVisibility.UNSPECIFIED;
+
+  /**
+   * Optional. Partially signed Ref of a multi-signature organization, obtained from another member.
+   * When specified, the signing key adds its signature to this Ref instead of creating a new one,
+   * and all the other fields except `signing_key_name` are ignored.
+   *
+   * @generated from field: bytes partial_ref = 9;
+   */
+  partialRef = new Uint8Array(0);
+
+  constructor(data?: PartialMessage<CreateRefRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.CreateRefRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "target", kind: "message", T: RefTarget },
+    { no: 4, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "capability", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "timestamp", kind: "message", T: Timestamp },
+    { no: 7, name: "generation", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 8, name: "visibility", kind: "enum", T: proto3.getEnumType(ResourceVisibility) },
+    { no: 9, name: "partial_ref", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateRefRequest {
+    return new CreateRefRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateRefRequest {
+    return new CreateRefRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateRefRequest {
+    return new CreateRefRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateRefRequest | PlainMessage<CreateRefRequest> | undefined, b: CreateRefRequest | PlainMessage<CreateRefRequest> | undefined): boolean {
+    return proto3.util.equals(CreateRefRequest, a, b);
+  }
+}
+
+/**
+ * Request to get a Ref by ID.
+ *
+ * @generated from message com.seed.documents.v3alpha.GetRefRequest
+ */
+export class GetRefRequest extends Message<GetRefRequest> {
+  /**
+   * Required. ID of the Ref blob to retrieve.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  constructor(data?: PartialMessage<GetRefRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.GetRefRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetRefRequest {
+    return new GetRefRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetRefRequest {
+    return new GetRefRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetRefRequest {
+    return new GetRefRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetRefRequest | PlainMessage<GetRefRequest> | undefined, b: GetRefRequest | PlainMessage<GetRefRequest> | undefined): boolean {
+    return proto3.util.equals(GetRefRequest, a, b);
+  }
+}
+
+/**
+ * Request to list Refs for a document.
+ *
+ * @generated from message com.seed.documents.v3alpha.ListRefsRequest
+ */
+export class ListRefsRequest extends Message<ListRefsRequest> {
+  /**
+   * Required. Account ID where the document is located.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Required. Path of the document.
+   * Empty string means root document.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * Optional. Number of items to return per page.
+   * Pagination parameters are currently accepted but ignored.
+   *
+   * @generated from field: int32 page_size = 3;
+   */
+  pageSize = 0;
+
+  /**
+   * Optional. Token from a previous ListRefsResponse.next_page_token.
+   * Pagination parameters are currently accepted but ignored.
+   *
+   * @generated from field: string page_token = 4;
+   */
+  pageToken = "";
+
+  constructor(data?: PartialMessage<ListRefsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ListRefsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 4, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListRefsRequest {
+    return new ListRefsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListRefsRequest {
+    return new ListRefsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListRefsRequest {
+    return new ListRefsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListRefsRequest | PlainMessage<ListRefsRequest> | undefined, b: ListRefsRequest | PlainMessage<ListRefsRequest> | undefined): boolean {
+    return proto3.util.equals(ListRefsRequest, a, b);
+  }
+}
+
+/**
+ * Response to list Refs for a document.
+ *
+ * @generated from message com.seed.documents.v3alpha.ListRefsResponse
+ */
+export class ListRefsResponse extends Message<ListRefsResponse> {
+  /**
+   * Refs for the requested document.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.Ref refs = 1;
+   */
+  refs: Ref[] = [];
+
+  /**
+   * Optional. Token for fetching the next page.
+   * Empty while pagination is not implemented.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken = "";
+
+  constructor(data?: PartialMessage<ListRefsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ListRefsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "refs", kind: "message", T: Ref, repeated: true },
+    { no: 2, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListRefsResponse {
+    return new ListRefsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListRefsResponse {
+    return new ListRefsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListRefsResponse {
+    return new ListRefsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListRefsResponse | PlainMessage<ListRefsResponse> | undefined, b: ListRefsResponse | PlainMessage<ListRefsResponse> | undefined): boolean {
+    return proto3.util.equals(ListRefsResponse, a, b);
+  }
+}
+
+/**
+ * Request to schedule a Ref for publishing.
+ *
+ * @generated from message com.seed.documents.v3alpha.ScheduleRefRequest
+ */
+export class ScheduleRefRequest extends Message<ScheduleRefRequest> {
+  /**
+   * Required. Raw bytes of the signed Ref blob.
+   *
+   * @generated from field: bytes ref = 1;
+   */
+  ref = new Uint8Array(0);
+
+  /**
+   * Optional. Blobs the Ref depends on, like the Changes it points to,
+   * that must not be published before the Ref.
+   * Heads of the Ref must either be among these blobs or already exist locally.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.ScheduledBlob blobs = 2;
+   */
+  blobs: ScheduledBlob[] = [];
+
+  /**
+   * Required. Time when the Ref must be published.
+   *
+   * @generated from field: google.protobuf.Timestamp publish_time = 3;
+   */
+  publishTime?: Timestamp;
+
+  constructor(data?: PartialMessage<ScheduleRefRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ScheduleRefRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "ref", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+    { no: 2, name: "blobs", kind: "message", T: ScheduledBlob, repeated: true },
+    { no: 3, name: "publish_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ScheduleRefRequest {
+    return new ScheduleRefRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ScheduleRefRequest {
+    return new ScheduleRefRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ScheduleRefRequest {
+    return new ScheduleRefRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ScheduleRefRequest | PlainMessage<ScheduleRefRequest> | undefined, b: ScheduleRefRequest | PlainMessage<ScheduleRefRequest> | undefined): boolean {
+    return proto3.util.equals(ScheduleRefRequest, a, b);
+  }
+}
+
+/**
+ * Raw blob held back together with a scheduled Ref.
+ *
+ * @generated from message com.seed.documents.v3alpha.ScheduledBlob
+ */
+export class ScheduledBlob extends Message<ScheduledBlob> {
+  /**
+   * Optional. CID of the blob (the server will verify it).
+   * If not provided, the data is assumed to be DAG-CBOR encoded, and the server will generate a CID
+   * using its default hash function.
+   *
+   * @generated from field: string cid = 1;
+   */
+  cid = "";
+
+  /**
+   * Required. Raw data of the blob.
+   *
+   * @generated from field: bytes data = 2;
+   */
+  data = new Uint8Array(0);
+
+  constructor(data?: PartialMessage<ScheduledBlob>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ScheduledBlob";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "cid", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "data", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ScheduledBlob {
+    return new ScheduledBlob().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ScheduledBlob {
+    return new ScheduledBlob().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ScheduledBlob {
+    return new ScheduledBlob().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ScheduledBlob | PlainMessage<ScheduledBlob> | undefined, b: ScheduledBlob | PlainMessage<ScheduledBlob> | undefined): boolean {
+    return proto3.util.equals(ScheduledBlob, a, b);
+  }
+}
+
+/**
+ * Request to list scheduled Refs.
+ *
+ * @generated from message com.seed.documents.v3alpha.ListScheduledRefsRequest
+ */
+export class ListScheduledRefsRequest extends Message<ListScheduledRefsRequest> {
+  /**
+   * Optional. Only list Refs for this account.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Optional. Number of results per page.
+   * Ignored while pagination is not implemented.
+   *
+   * @generated from field: int32 page_size = 2;
+   */
+  pageSize = 0;
+
+  /**
+   * Optional. Token for the page to return.
+   * Ignored while pagination is not implemented.
+   *
+   * @generated from field: string page_token = 3;
+   */
+  pageToken = "";
+
+  constructor(data?: PartialMessage<ListScheduledRefsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ListScheduledRefsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListScheduledRefsRequest {
+    return new ListScheduledRefsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListScheduledRefsRequest {
+    return new ListScheduledRefsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListScheduledRefsRequest {
+    return new ListScheduledRefsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListScheduledRefsRequest | PlainMessage<ListScheduledRefsRequest> | undefined, b: ListScheduledRefsRequest | PlainMessage<ListScheduledRefsRequest> | undefined): boolean {
+    return proto3.util.equals(ListScheduledRefsRequest, a, b);
+  }
+}
+
+/**
+ * Response with scheduled Refs.
+ *
+ * @generated from message com.seed.documents.v3alpha.ListScheduledRefsResponse
+ */
+export class ListScheduledRefsResponse extends Message<ListScheduledRefsResponse> {
+  /**
+   * Scheduled Refs ordered by their publish time.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.ScheduledRef scheduled_refs = 1;
+   */
+  scheduledRefs: ScheduledRef[] = [];
 
   /**
    * Optional. Token for fetching the next page.
@@ -3616,32 +5728,155 @@ export class ListRefsResponse extends Message<ListRefsResponse> {
    */
   nextPageToken = "";
 
-  constructor(data?: PartialMessage<ListRefsResponse>) {
+  constructor(data?: PartialMessage<ListScheduledRefsResponse>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "com.seed.documents.v3alpha.ListRefsResponse";
+  static readonly typeName = "com.seed.documents.v3alpha.ListScheduledRefsResponse";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "refs", kind: "message", T: Ref, repeated: true },
+    { no: 1, name: "scheduled_refs", kind: "message", T: ScheduledRef, repeated: true },
     { no: 2, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListRefsResponse {
-    return new ListRefsResponse().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListScheduledRefsResponse {
+    return new ListScheduledRefsResponse().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListRefsResponse {
-    return new ListRefsResponse().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListScheduledRefsResponse {
+    return new ListScheduledRefsResponse().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListRefsResponse {
-    return new ListRefsResponse().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListScheduledRefsResponse {
+    return new ListScheduledRefsResponse().fromJsonString(jsonString, options);
   }
 
-  static equals(a: ListRefsResponse | PlainMessage<ListRefsResponse> | undefined, b: ListRefsResponse | PlainMessage<ListRefsResponse> | undefined): boolean {
-    return proto3.util.equals(ListRefsResponse, a, b);
+  static equals(a: ListScheduledRefsResponse | PlainMessage<ListScheduledRefsResponse> | undefined, b: ListScheduledRefsResponse | PlainMessage<ListScheduledRefsResponse> | undefined): boolean {
+    return proto3.util.equals(ListScheduledRefsResponse, a, b);
+  }
+}
+
+/**
+ * Request to cancel a scheduled Ref.
+ *
+ * @generated from message com.seed.documents.v3alpha.CancelScheduledRefRequest
+ */
+export class CancelScheduledRefRequest extends Message<CancelScheduledRefRequest> {
+  /**
+   * Required. ID of the scheduled Ref blob.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  constructor(data?: PartialMessage<CancelScheduledRefRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.CancelScheduledRefRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CancelScheduledRefRequest {
+    return new CancelScheduledRefRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CancelScheduledRefRequest {
+    return new CancelScheduledRefRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CancelScheduledRefRequest {
+    return new CancelScheduledRefRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CancelScheduledRefRequest | PlainMessage<CancelScheduledRefRequest> | undefined, b: CancelScheduledRefRequest | PlainMessage<CancelScheduledRefRequest> | undefined): boolean {
+    return proto3.util.equals(CancelScheduledRefRequest, a, b);
+  }
+}
+
+/**
+ * A Ref waiting to be published.
+ *
+ * @generated from message com.seed.documents.v3alpha.ScheduledRef
+ */
+export class ScheduledRef extends Message<ScheduledRef> {
+  /**
+   * The scheduled Ref. It's not available with the GetRef API until it's published.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Ref ref = 1;
+   */
+  ref?: Ref;
+
+  /**
+   * Time when the Ref will be published.
+   *
+   * @generated from field: google.protobuf.Timestamp publish_time = 2;
+   */
+  publishTime?: Timestamp;
+
+  /**
+   * Time when the Ref was scheduled.
+   *
+   * @generated from field: google.protobuf.Timestamp create_time = 3;
+   */
+  createTime?: Timestamp;
+
+  /**
+   * CIDs of the blobs held back together with the Ref.
+   *
+   * @generated from field: repeated string blobs = 4;
+   */
+  blobs: string[] = [];
+
+  /**
+   * Error of the last attempt to publish the Ref.
+   * Refs that failed to be published stay scheduled, and are retried with a backoff until they're published or cancelled.
+   *
+   * @generated from field: string publish_error = 5;
+   */
+  publishError = "";
+
+  /**
+   * Time of the next attempt to publish the Ref after a failed one.
+   *
+   * @generated from field: google.protobuf.Timestamp retry_time = 6;
+   */
+  retryTime?: Timestamp;
+
+  constructor(data?: PartialMessage<ScheduledRef>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ScheduledRef";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "ref", kind: "message", T: Ref },
+    { no: 2, name: "publish_time", kind: "message", T: Timestamp },
+    { no: 3, name: "create_time", kind: "message", T: Timestamp },
+    { no: 4, name: "blobs", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 5, name: "publish_error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "retry_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ScheduledRef {
+    return new ScheduledRef().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ScheduledRef {
+    return new ScheduledRef().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ScheduledRef {
+    return new ScheduledRef().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ScheduledRef | PlainMessage<ScheduledRef> | undefined, b: ScheduledRef | PlainMessage<ScheduledRef> | undefined): boolean {
+    return proto3.util.equals(ScheduledRef, a, b);
   }
 }
 
@@ -3830,6 +6065,14 @@ export class DocumentInfo extends Message<DocumentInfo> {
    */
   firstImageInContent?: string;
 
+  /**
+   * Output only. Aggregated counts of the reactions to the document itself,
+   * excluding the reactions to its blocks and comments.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.ReactionCount reactions = 16;
+   */
+  reactions: ReactionCount[] = [];
+
   constructor(data?: PartialMessage<DocumentInfo>) {
     super();
     proto3.util.initPartial(data, this);
@@ -3852,6 +6095,7 @@ export class DocumentInfo extends Message<DocumentInfo> {
     { no: 13, name: "redirect_info", kind: "message", T: RefTarget_Redirect },
     { no: 14, name: "visibility", kind: "enum", T: proto3.getEnumType(ResourceVisibility) },
     { no: 15, name: "first_image_in_content", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 16, name: "reactions", kind: "message", T: ReactionCount, repeated: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DocumentInfo {
@@ -3871,6 +6115,55 @@ export class DocumentInfo extends Message<DocumentInfo> {
   }
 }
 
+/**
+ * Number of distinct authors who reacted with a given value.
+ *
+ * @generated from message com.seed.documents.v3alpha.ReactionCount
+ */
+export class ReactionCount extends Message<ReactionCount> {
+  /**
+   * Emoji or a short token of the reaction.
+   *
+   * @generated from field: string value = 1;
+   */
+  value = "";
+
+  /**
+   * Number of distinct authors who reacted with this value.
+   *
+   * @generated from field: int32 count = 2;
+   */
+  count = 0;
+
+  constructor(data?: PartialMessage<ReactionCount>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ReactionCount";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "value", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ReactionCount {
+    return new ReactionCount().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ReactionCount {
+    return new ReactionCount().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ReactionCount {
+    return new ReactionCount().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ReactionCount | PlainMessage<ReactionCount> | undefined, b: ReactionCount | PlainMessage<ReactionCount> | undefined): boolean {
+    return proto3.util.equals(ReactionCount, a, b);
+  }
+}
+
 /**
  * Information about the generation of a document.
  *
@@ -4474,6 +6767,14 @@ export class DocumentChange extends Message<DocumentChange> {
      */
     value: DocumentChange_SetAttribute;
     case: "setAttribute";
+  } | {
+    /**
+     * Edits the text of a block at the character level.
+     *
+     * @generated from field: com.seed.documents.v3alpha.DocumentChange.SpliceText splice_text = 6;
+     */
+    value: DocumentChange_SpliceText;
+    case: "spliceText";
   } | { case: undefined; value?: undefined } = { case: undefined };
 
   constructor(data?: PartialMessage<DocumentChange>) {
@@ -4489,6 +6790,7 @@ export class DocumentChange extends Message<DocumentChange> {
     { no: 3, name: "replace_block", kind: "message", T: Block, oneof: "op" },
     { no: 4, name: "delete_block", kind: "scalar", T: 9 /* ScalarType.STRING */, oneof: "op" },
     { no: 5, name: "set_attribute", kind: "message", T: DocumentChange_SetAttribute, oneof: "op" },
+    { no: 6, name: "splice_text", kind: "message", T: DocumentChange_SpliceText, oneof: "op" },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DocumentChange {
@@ -4705,6 +7007,74 @@ export class DocumentChange_SetAttribute extends Message<DocumentChange_SetAttri
   }
 }
 
+/**
+ * Operation to edit the text of an existing block at the character level.
+ * Unlike replacing the block, concurrent splices of the same block are merged,
+ * and the annotations of the block are adjusted to the edited text.
+ * A block can only be spliced once per change, and not together with replacing it.
+ *
+ * @generated from message com.seed.documents.v3alpha.DocumentChange.SpliceText
+ */
+export class DocumentChange_SpliceText extends Message<DocumentChange_SpliceText> {
+  /**
+   * ID of the block to edit.
+   *
+   * @generated from field: string block_id = 1;
+   */
+  blockId = "";
+
+  /**
+   * Position in the text of the block where the edit starts, in Unicode code points.
+   *
+   * @generated from field: int32 offset = 2;
+   */
+  offset = 0;
+
+  /**
+   * Number of characters to delete at the offset, in Unicode code points.
+   *
+   * @generated from field: int32 delete_count = 3;
+   */
+  deleteCount = 0;
+
+  /**
+   * Text to insert at the offset, after deleting the characters.
+   *
+   * @generated from field: string insert = 4;
+   */
+  insert = "";
+
+  constructor(data?: PartialMessage<DocumentChange_SpliceText>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.DocumentChange.SpliceText";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "block_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "offset", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "delete_count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 4, name: "insert", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DocumentChange_SpliceText {
+    return new DocumentChange_SpliceText().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DocumentChange_SpliceText {
+    return new DocumentChange_SpliceText().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DocumentChange_SpliceText {
+    return new DocumentChange_SpliceText().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DocumentChange_SpliceText | PlainMessage<DocumentChange_SpliceText> | undefined, b: DocumentChange_SpliceText | PlainMessage<DocumentChange_SpliceText> | undefined): boolean {
+    return proto3.util.equals(DocumentChange_SpliceText, a, b);
+  }
+}
+
 /**
  * Description of a Ref blob.
  *
@@ -4767,6 +7137,29 @@ export class Ref extends Message<Ref> {
    */
   generationInfo?: GenerationInfo;
 
+  /**
+   * Other members of a multi-signature organization who signed the Ref.
+   *
+   * @generated from field: repeated string cosigners = 9;
+   */
+  cosigners: string[] = [];
+
+  /**
+   * Output only. Raw bytes of the Ref, when it doesn't have enough signatures to be published yet.
+   * In this case the ID is empty, and the Ref must be passed to other members
+   * of the organization with the CreateRef API to collect the remaining signatures.
+   *
+   * @generated from field: bytes partial_ref = 10;
+   */
+  partialRef = new Uint8Array(0);
+
+  /**
+   * Name of the branch the Ref is for. Empty for the published version of the document.
+   *
+   * @generated from field: string branch = 11;
+   */
+  branch = "";
+
   constructor(data?: PartialMessage<Ref>) {
     super();
     proto3.util.initPartial(data, this);
@@ -4783,6 +7176,9 @@ export class Ref extends Message<Ref> {
     { no: 6, name: "capability", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 7, name: "timestamp", kind: "message", T: Timestamp },
     { no: 8, name: "generation_info", kind: "message", T: GenerationInfo },
+    { no: 9, name: "cosigners", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 10, name: "partial_ref", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+    { no: 11, name: "branch", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Ref {
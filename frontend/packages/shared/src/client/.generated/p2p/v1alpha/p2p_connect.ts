@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { AuthenticateRequest, AuthenticateResponse, Blob, ListBlobsRequest, ListPeersRequest, ListPeersResponse, ListSpacesRequest, ListSpacesResponse, RequestInvoiceRequest, RequestInvoiceResponse } from "./p2p_pb";
+import { AuthenticateRequest, AuthenticateResponse, Blob, ListBlobsRequest, ListPeersRequest, ListPeersResponse, ListSpacesRequest, ListSpacesResponse, RedeemInviteRequest, RedeemInviteResponse, RequestAccessRequest, RequestAccessResponse, RequestInvoiceRequest, RequestInvoiceResponse } from "./p2p_pb";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -74,6 +74,29 @@ export const P2P = {
       O: AuthenticateResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Redeems an invite issued by this peer, in exchange for a Capability for the key of the caller.
+     *
+     * @generated from rpc com.seed.p2p.v1alpha.P2P.RedeemInvite
+     */
+    redeemInvite: {
+      name: "RedeemInvite",
+      I: RedeemInviteRequest,
+      O: RedeemInviteResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Asks the owner of a space to grant access to it.
+     * The caller must be authenticated with the account that signed the access request.
+     *
+     * @generated from rpc com.seed.p2p.v1alpha.P2P.RequestAccess
+     */
+    requestAccess: {
+      name: "RequestAccess",
+      I: RequestAccessRequest,
+      O: RequestAccessResponse,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
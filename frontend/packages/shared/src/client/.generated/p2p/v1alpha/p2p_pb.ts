@@ -533,6 +533,171 @@ export class AuthenticateResponse extends Message<AuthenticateResponse> {
   }
 }
 
+/**
+ * Request to redeem an invite.
+ *
+ * @generated from message com.seed.p2p.v1alpha.RedeemInviteRequest
+ */
+export class RedeemInviteRequest extends Message<RedeemInviteRequest> {
+  /**
+   * Raw bytes of the signed invite.
+   *
+   * @generated from field: bytes invite = 1;
+   */
+  invite = new Uint8Array(0);
+
+  /**
+   * Raw bytes of the signed invite redemption.
+   * It proves that the caller holds the key that will receive the Capability.
+   *
+   * @generated from field: bytes redemption = 2;
+   */
+  redemption = new Uint8Array(0);
+
+  constructor(data?: PartialMessage<RedeemInviteRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.p2p.v1alpha.RedeemInviteRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "invite", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+    { no: 2, name: "redemption", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RedeemInviteRequest {
+    return new RedeemInviteRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RedeemInviteRequest {
+    return new RedeemInviteRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RedeemInviteRequest {
+    return new RedeemInviteRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RedeemInviteRequest | PlainMessage<RedeemInviteRequest> | undefined, b: RedeemInviteRequest | PlainMessage<RedeemInviteRequest> | undefined): boolean {
+    return proto3.util.equals(RedeemInviteRequest, a, b);
+  }
+}
+
+/**
+ * Response to redeem an invite.
+ *
+ * @generated from message com.seed.p2p.v1alpha.RedeemInviteResponse
+ */
+export class RedeemInviteResponse extends Message<RedeemInviteResponse> {
+  /**
+   * Raw bytes of the Capability blob issued for the invitee.
+   *
+   * @generated from field: bytes capability = 1;
+   */
+  capability = new Uint8Array(0);
+
+  constructor(data?: PartialMessage<RedeemInviteResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.p2p.v1alpha.RedeemInviteResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "capability", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RedeemInviteResponse {
+    return new RedeemInviteResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RedeemInviteResponse {
+    return new RedeemInviteResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RedeemInviteResponse {
+    return new RedeemInviteResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RedeemInviteResponse | PlainMessage<RedeemInviteResponse> | undefined, b: RedeemInviteResponse | PlainMessage<RedeemInviteResponse> | undefined): boolean {
+    return proto3.util.equals(RedeemInviteResponse, a, b);
+  }
+}
+
+/**
+ * Request to ask for access to a space.
+ *
+ * @generated from message com.seed.p2p.v1alpha.RequestAccessRequest
+ */
+export class RequestAccessRequest extends Message<RequestAccessRequest> {
+  /**
+   * Raw bytes of the signed access request.
+   *
+   * @generated from field: bytes access_request = 1;
+   */
+  accessRequest = new Uint8Array(0);
+
+  constructor(data?: PartialMessage<RequestAccessRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.p2p.v1alpha.RequestAccessRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "access_request", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RequestAccessRequest {
+    return new RequestAccessRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RequestAccessRequest {
+    return new RequestAccessRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RequestAccessRequest {
+    return new RequestAccessRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RequestAccessRequest | PlainMessage<RequestAccessRequest> | undefined, b: RequestAccessRequest | PlainMessage<RequestAccessRequest> | undefined): boolean {
+    return proto3.util.equals(RequestAccessRequest, a, b);
+  }
+}
+
+/**
+ * Response to ask for access to a space.
+ *
+ * @generated from message com.seed.p2p.v1alpha.RequestAccessResponse
+ */
+export class RequestAccessResponse extends Message<RequestAccessResponse> {
+  constructor(data?: PartialMessage<RequestAccessResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.p2p.v1alpha.RequestAccessResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RequestAccessResponse {
+    return new RequestAccessResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RequestAccessResponse {
+    return new RequestAccessResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RequestAccessResponse {
+    return new RequestAccessResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RequestAccessResponse | PlainMessage<RequestAccessResponse> | undefined, b: RequestAccessResponse | PlainMessage<RequestAccessResponse> | undefined): boolean {
+    return proto3.util.equals(RequestAccessResponse, a, b);
+  }
+}
+
 /**
  * @generated from message com.seed.p2p.v1alpha.Blob
  */